@@ -16,20 +16,61 @@ limitations under the License.
 package main
 
 import (
+	"flag"
+	"log"
+
 	"k8s.io/klog"
+	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
 
+	"github.com/tektoncd/triggers/pkg/reconciler/v1alpha1/clustereventlistener"
 	"github.com/tektoncd/triggers/pkg/reconciler/v1alpha1/eventlistener"
+	"github.com/tektoncd/triggers/pkg/reconciler/v1alpha1/repository"
 )
 
 const (
 	// ControllerLogKey is the name of the logger for the controller cmd
 	ControllerLogKey = "controller"
+	// EmbeddedSinkLogKey is the name of the logger for the sink embedded in
+	// the controller
+	EmbeddedSinkLogKey = "eventlistener"
+)
+
+var (
+	masterURL = flag.String("master", "",
+		"The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	kubeconfig = flag.String("kubeconfig", "",
+		"Path to a kubeconfig. Only required if out-of-cluster.")
+	enableRepositoryController = flag.Bool("enable-repository-controller", false,
+		"Register and sync the webhooks of Repositories with their Git provider.")
 )
 
 func main() {
 	klog.InitFlags(nil)
-	sharedmain.Main(ControllerLogKey,
+	flag.Parse()
+
+	ctors := []injection.ControllerConstructor{
 		eventlistener.NewController,
-	)
+		clustereventlistener.NewController,
+	}
+	if *enableRepositoryController {
+		ctors = append(ctors, repository.NewController)
+	}
+
+	cfg, err := sharedmain.GetConfig(*masterURL, *kubeconfig)
+	if err != nil {
+		log.Fatalf("Error building kubeconfig: %v", err)
+	}
+	ctx := signals.NewContext()
+	if *eventlistener.EmbeddedSinkAddress != "" {
+		logger := logging.FromContext(ctx).Named(EmbeddedSinkLogKey)
+		go func() {
+			if err := runEmbeddedSink(cfg, *eventlistener.EmbeddedSinkAddress, logger, ctx.Done()); err != nil {
+				logger.Fatalf("Embedded sink failed: %v", err)
+			}
+		}()
+	}
+	sharedmain.MainWithConfig(ctx, ControllerLogKey, cfg, ctors...)
 }
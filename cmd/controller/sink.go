@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	dynamicClientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
+	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	"github.com/tektoncd/triggers/pkg/logging"
+	"github.com/tektoncd/triggers/pkg/reconciler/v1alpha1/eventlistener"
+	"github.com/tektoncd/triggers/pkg/sink"
+	"github.com/tektoncd/triggers/pkg/template"
+)
+
+// runEmbeddedSink serves the events of all EventListeners on the port of the
+// address until stopCh is closed.
+func runEmbeddedSink(cfg *rest.Config, address string, logger *zap.SugaredLogger, stopCh <-chan struct{}) error {
+	logger = logging.Redacting(logger, logging.Secrets)
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	clients, err := sink.NewClients(cfg)
+	if err != nil {
+		return err
+	}
+	// The server is shared by all EventListeners, so its write timeout allows
+	// for the longest event timeout.
+	writeTimeout := eventlistener.SinkWriteTimeout(*eventlistener.WriteTimeout, v1alpha1.MaxEventTimeoutSeconds*time.Second)
+	args := sink.Args{
+		Port:                 port,
+		ReadTimeout:          *eventlistener.ReadTimeout,
+		WriteTimeout:         writeTimeout,
+		IdleTimeout:          *eventlistener.IdleTimeout,
+		MaxConcurrentStreams: uint32(*eventlistener.MaxConcurrentStreams),
+		MaxTriggersPerEvent:  *eventlistener.MaxTriggersPerEvent,
+		H2C:                  true,
+		MaxIdleConnsPerHost:  100,
+		IdleConnTimeout:      90 * time.Second,
+		DNSCacheTTL:          30 * time.Second,
+		StatusUpdateInterval: time.Minute,
+	}
+	// Interceptor connections and limits are shared by all EventListeners,
+	// like ClusterInterceptors are
+	httpClient := sink.NewHTTPClient(args)
+	limits := sink.NewInterceptorLimiter()
+
+	m := &sink.Multiplexer{
+		KubeClientSet:  kubeClient,
+		TriggersClient: clients.TriggersClient,
+		Config:         cfg,
+		Logger:         logger,
+		NewSink: func(el *v1alpha1.EventListener, cfg *rest.Config, stopCh <-chan struct{}) (*sink.Sink, error) {
+			kubeClient, err := kubernetes.NewForConfig(cfg)
+			if err != nil {
+				return nil, err
+			}
+			dynamicClient, err := dynamic.NewForConfig(cfg)
+			if err != nil {
+				return nil, err
+			}
+			clients, err := sink.NewClients(cfg)
+			if err != nil {
+				return nil, err
+			}
+			logger := logger.With(zap.String("eventlistener", el.Namespace+"/"+el.Name))
+			r := &sink.Sink{
+				KubeClientSet:          kubeClient,
+				DiscoveryClient:        clients.DiscoveryClient,
+				DynamicClient:          dynamicClientset.New(tekton.WithClient(dynamicClient)),
+				TriggersClient:         clients.TriggersClient,
+				PipelineClient:         clients.PipelineClient,
+				ResourceClient:         clients.ResourceClient,
+				HTTPClient:             httpClient,
+				EventListenerName:      el.Name,
+				EventListenerNamespace: el.Namespace,
+				Logger:                 logger,
+				Auth:                   sink.DefaultAuthOverride{},
+				Namespaces:             sink.NewNamespaceTracker(kubeClient, logger, stopCh),
+				Status:                 sink.NewStatusWriter(clients.TriggersClient, el.Name, el.Namespace, args.StatusUpdateInterval, logger),
+				Deliveries:             sink.NewDeliveryCoalescer(),
+				MaxTriggersPerEvent:    args.MaxTriggersPerEvent,
+				InterceptorLimits:      limits,
+				AuthFailures:           sink.NewAuthFailureTracker(),
+				Pins:                   template.NewStoredPins(kubeClient.AppsV1()),
+			}
+			go r.Status.Run(stopCh)
+			go r.SweepDeliveries(stopCh)
+			return r, nil
+		},
+	}
+	defer m.Stop()
+
+	srv, err := sink.NewServer(args, m)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Serving the events of all EventListeners on port %s", port)
+	return (&sink.HTTPSource{Server: srv, Args: args}).Run(stopCh)
+}
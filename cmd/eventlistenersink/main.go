@@ -23,10 +23,13 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	dynamicClientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
 	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	"github.com/tektoncd/triggers/pkg/interceptors/signature"
 	"github.com/tektoncd/triggers/pkg/logging"
 	"github.com/tektoncd/triggers/pkg/sink"
+	"github.com/tektoncd/triggers/pkg/template"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -60,7 +63,7 @@ func main() {
 	}
 	dynamicCS := dynamicClientset.New(tekton.WithClient(dynamicClient))
 
-	logger := logging.ConfigureLogging(EventListenerLogKey, ConfigName, stopCh, kubeClient)
+	logger := logging.Redacting(logging.ConfigureLogging(EventListenerLogKey, ConfigName, stopCh, kubeClient), logging.Secrets)
 	defer func() {
 		err := logger.Sync()
 		if err != nil {
@@ -75,6 +78,22 @@ func main() {
 		logger.Fatal(err)
 	}
 
+	sink.TuneRuntime(sinkArgs, logger)
+	go sink.SnapshotOnSIGQUIT(sinkArgs, logger, stopCh)
+
+	if sinkArgs.FIPS {
+		if err := signature.SetFIPS(true); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("Verifying signatures in FIPS mode")
+	}
+
+	faults := sink.NewFaultInjector(sinkArgs)
+	if faults != nil {
+		logger.Warnf("Injecting failures for testing: interceptor latency %s at rate %g, resource creation failures at rate %g, dropped secrets at rate %g",
+			faults.InterceptorLatency, faults.InterceptorLatencyRate, faults.CreateFailureRate, faults.SecretDropRate)
+	}
+
 	sinkClients, err := sink.ConfigureClients()
 	if err != nil {
 		logger.Fatal(err)
@@ -82,26 +101,80 @@ func main() {
 
 	// Create EventListener Sink
 	r := sink.Sink{
-		KubeClientSet:          kubeClient,
+		KubeClientSet:          faults.KubeClient(kubeClient),
 		DiscoveryClient:        sinkClients.DiscoveryClient,
 		DynamicClient:          dynamicCS,
 		TriggersClient:         sinkClients.TriggersClient,
 		PipelineClient:         sinkClients.PipelineClient,
 		ResourceClient:         sinkClients.ResourceClient,
-		HTTPClient:             http.DefaultClient,
+		HTTPClient:             sink.NewHTTPClient(sinkArgs),
 		EventListenerName:      sinkArgs.ElName,
 		EventListenerNamespace: sinkArgs.ElNamespace,
 		Logger:                 logger,
 		Auth:                   sink.DefaultAuthOverride{},
+		Namespaces:             sink.NewNamespaceTracker(kubeClient, logger, stopCh),
+		Status:                 sink.NewStatusWriter(sinkClients.TriggersClient, sinkArgs.ElName, sinkArgs.ElNamespace, sinkArgs.StatusUpdateInterval, logger),
+		Faults:                 faults,
+		Deliveries:             sink.NewDeliveryCoalescer(),
+		MaxTriggersPerEvent:    sinkArgs.MaxTriggersPerEvent,
+		InterceptorLimits:      sink.NewInterceptorLimiter(),
+		InterceptorRetry:       sinkArgs.InterceptorRetry,
+		AuthFailures:           sink.NewAuthFailureTracker(),
+		Pins:                   template.NewStoredPins(kubeClient.AppsV1()),
+		RemoteClusters:         sink.NewRemoteClusterCache(),
 	}
+	// Misconfigured interceptors are reported before events are served
+	if sinkArgs.WarmUp != v1alpha1.WarmUpOff {
+		errs := r.WarmUp()
+		for _, err := range errs {
+			logger.Warnf("Warm-up: %s", err)
+		}
+		if len(errs) > 0 && sinkArgs.WarmUp == v1alpha1.WarmUpFail {
+			logger.Fatalf("Warm-up found %d problems", len(errs))
+		}
+	}
+	go r.Status.Run(stopCh)
+	go r.SweepDeliveries(stopCh)
 
 	// Listen and serve
 	logger.Infof("Listen and serve on port %s", sinkArgs.Port)
-	http.HandleFunc("/", r.HandleEvent)
+	// The Sink doesn't use the default mux, where imported packages can
+	// register operational endpoints
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", r.HandleEvent)
+	mux.HandleFunc("/manual/", r.HandleManual)
 	// For handling Liveness Probe
-	http.HandleFunc("/live", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/live", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(200)
 		fmt.Fprint(w, "ok")
 	})
-	logger.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", sinkArgs.Port), nil))
+	admin := sink.NewAdminHandler(sinkArgs, logger)
+	if sinkArgs.AdminPort == "" {
+		if sinkArgs.Metrics {
+			mux.Handle("/metrics", admin)
+		}
+	} else {
+		adminSrv, err := sink.NewAdminServer(sinkArgs, admin)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		logger.Infof("Serving admin endpoints on port %s", sinkArgs.AdminPort)
+		go func() {
+			logger.Fatal(sink.ListenAndServeAdmin(adminSrv, sinkArgs))
+		}()
+	}
+	srv, err := sink.NewServer(sinkArgs, mux)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	// HTTP is always served, other sources are enabled by the EventListener
+	sources, err := sink.NewSources(r, sinkArgs)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	sources = append([]sink.Source{&sink.HTTPSource{Server: srv, Args: sinkArgs}}, sources...)
+	if err := sink.RunSources(sources, stopCh, logger); err != nil {
+		logger.Fatal(err)
+	}
 }
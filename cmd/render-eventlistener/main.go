@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// render-eventlistener prints the Service and Deployment that the Triggers
+// controller generates for EventListeners, so that changes to an
+// EventListener or to the controller defaults can be reviewed before they are
+// applied.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/tektoncd/triggers/pkg/apis/config"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/reconciler/v1alpha1/eventlistener"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	filename = flag.String("f", "-",
+		"The file with the EventListeners to render, or - for stdin.")
+	defaultsFile = flag.String("config-defaults", "",
+		"An optional file with the config-defaults-triggers ConfigMap of the cluster.")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-f eventlistener.yaml] [-config-defaults config-defaults.yaml] [controller flags]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	in, err := readFile(*filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+	if *defaultsFile != "" {
+		if ctx, err = withDefaults(ctx, *defaultsFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := render(ctx, in, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(name)
+}
+
+// withDefaults returns a context with the defaults from a
+// config-defaults-triggers ConfigMap file.
+func withDefaults(ctx context.Context, name string) (context.Context, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var cm corev1.ConfigMap
+	if err := yaml.Unmarshal(b, &cm); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	defaults, err := config.NewDefaultsFromConfigMap(&cm)
+	if err != nil {
+		return nil, fmt.Errorf("invalid defaults in %s: %w", name, err)
+	}
+	return config.ToContext(ctx, &config.Config{Defaults: defaults}), nil
+}
+
+// render writes the Service and Deployment of every EventListener in the
+// YAML documents of in.
+func render(ctx context.Context, in []byte, out io.Writer) error {
+	d := eventlistener.SinkDefaults(ctx)
+	for _, doc := range bytes.Split(in, []byte("\n---")) {
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		var el v1alpha1.EventListener
+		if err := yaml.UnmarshalStrict(doc, &el); err != nil {
+			return fmt.Errorf("failed to parse EventListener: %w", err)
+		}
+		if el.Namespace == "" {
+			el.Namespace = "default"
+		}
+		el.SetDefaults(ctx)
+		if err := el.Validate(ctx); err != nil {
+			return fmt.Errorf("invalid EventListener %s: %w", el.Name, err)
+		}
+		el.Status.Configuration.GeneratedResourceName = fmt.Sprintf("%s-%s", eventlistener.GeneratedResourcePrefix, el.Name)
+
+		svc := eventlistener.MakeService(&el, d)
+		svc.APIVersion, svc.Kind = "v1", "Service"
+		deployment := eventlistener.MakeDeployment(&el, d)
+		deployment.APIVersion, deployment.Kind = "apps/v1", "Deployment"
+		objs := []interface{}{svc, deployment}
+		if pdb := eventlistener.MakePodDisruptionBudget(&el); pdb != nil {
+			pdb.APIVersion, pdb.Kind = "policy/v1beta1", "PodDisruptionBudget"
+			objs = append(objs, pdb)
+		}
+		// The KEDA objects carry their apiVersion and kind already
+		if auth := eventlistener.MakeTriggerAuthentication(&el); auth != nil {
+			objs = append(objs, auth.Object)
+		}
+		if so := eventlistener.MakeScaledObject(&el); so != nil {
+			objs = append(objs, so.Object)
+		}
+		for _, obj := range objs {
+			b, err := yaml.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(out, "---\n%s", b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
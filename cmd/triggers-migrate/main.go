@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// triggers-migrate rewrites all Triggers objects of a cluster in the current
+// storage version of their CRDs and strips the fields that were removed from
+// the API, so that the CRDs can be upgraded safely. It is run as a Job after
+// an upgrade of Triggers, or with -dry-run to report what it would change.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/tektoncd/triggers/pkg/migrate"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/injection/sharedmain"
+)
+
+var (
+	masterURL = flag.String("master", "",
+		"The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	kubeconfig = flag.String("kubeconfig", "",
+		"Path to a kubeconfig. Only required if out-of-cluster.")
+	dryRun = flag.Bool("dry-run", false,
+		"Report the objects that would be migrated and the fields that would be removed without updating them.")
+	pageSize = flag.Int64("page-size", 500,
+		"How many objects of a resource are listed at once.")
+)
+
+func main() {
+	flag.Parse()
+
+	cfg, err := sharedmain.GetConfig(*masterURL, *kubeconfig)
+	if err != nil {
+		log.Fatalf("Error building kubeconfig: %v", err)
+	}
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create dynamic client: %v", err)
+	}
+
+	m := migrate.Migrator{Client: client, DryRun: *dryRun, PageSize: *pageSize}
+	var migrated, stripped, failed int
+	if err := m.Migrate(context.Background(), func(r migrate.Result) {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Printf("%s: failed: %v\n", r, r.Err)
+			return
+		case len(r.Removed) > 0:
+			stripped++
+			verb := "removed"
+			if *dryRun {
+				verb = "would remove"
+			}
+			fmt.Printf("%s: %s %s\n", r, verb, strings.Join(r.Removed, ", "))
+		}
+		migrated++
+	}); err != nil {
+		log.Fatal(err)
+	}
+
+	verb := "migrated"
+	if *dryRun {
+		verb = "would be migrated"
+	}
+	fmt.Printf("%d objects %s, %d with removed fields, %d failed\n", migrated, verb, stripped, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
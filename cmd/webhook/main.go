@@ -20,7 +20,10 @@ import (
 	"context"
 	"os"
 
+	"github.com/tektoncd/triggers/pkg/apis/config"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersclient "github.com/tektoncd/triggers/pkg/client/injection/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -36,8 +39,12 @@ import (
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
+	v1alpha1.SchemeGroupVersion.WithKind("ClusterEventListener"):  &v1alpha1.ClusterEventListener{},
+	v1alpha1.SchemeGroupVersion.WithKind("ClusterInterceptor"):    &v1alpha1.ClusterInterceptor{},
 	v1alpha1.SchemeGroupVersion.WithKind("ClusterTriggerBinding"): &v1alpha1.ClusterTriggerBinding{},
 	v1alpha1.SchemeGroupVersion.WithKind("EventListener"):         &v1alpha1.EventListener{},
+	v1alpha1.SchemeGroupVersion.WithKind("InterceptorChain"):      &v1alpha1.InterceptorChain{},
+	v1alpha1.SchemeGroupVersion.WithKind("Repository"):            &v1alpha1.Repository{},
 	v1alpha1.SchemeGroupVersion.WithKind("TriggerBinding"):        &v1alpha1.TriggerBinding{},
 	v1alpha1.SchemeGroupVersion.WithKind("TriggerTemplate"):       &v1alpha1.TriggerTemplate{},
 }
@@ -63,6 +70,7 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 }
 
 func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	triggersClient := triggersclient.Get(ctx)
 	return validation.NewAdmissionController(ctx,
 
 		// Name of the resource webhook.
@@ -75,8 +83,28 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 		types,
 
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
+		// Trigger interceptor params are validated against the schema of the
+		// ClusterInterceptor they reference.
+		// Params set by more than one binding of a Trigger are detected by
+		// looking up the bindings.
 		func(ctx context.Context) context.Context {
-			return ctx
+			ctx = v1alpha1.WithClusterInterceptorGetter(ctx, func(name string) (*v1alpha1.ClusterInterceptor, error) {
+				return triggersClient.TriggersV1alpha1().ClusterInterceptors().Get(name, metav1.GetOptions{})
+			})
+			return v1alpha1.WithTriggerBindingGetter(ctx, func(kind v1alpha1.TriggerBindingKind, namespace, name string) (*v1alpha1.TriggerBindingSpec, error) {
+				if kind == v1alpha1.ClusterTriggerBindingKind {
+					ctb, err := triggersClient.TriggersV1alpha1().ClusterTriggerBindings().Get(name, metav1.GetOptions{})
+					if err != nil {
+						return nil, err
+					}
+					return &ctb.Spec, nil
+				}
+				tb, err := triggersClient.TriggersV1alpha1().TriggerBindings(namespace).Get(name, metav1.GetOptions{})
+				if err != nil {
+					return nil, err
+				}
+				return &tb.Spec, nil
+			})
 		},
 
 		// Whether to disallow unknown fields.
@@ -94,7 +122,8 @@ func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 		"/config-validation",
 
 		configmap.Constructors{
-			logging.ConfigMapName(): logging.NewConfigFromConfigMap,
+			logging.ConfigMapName():   logging.NewConfigFromConfigMap,
+			config.DefaultsConfigName: config.NewDefaultsFromConfigMap,
 		},
 	)
 }
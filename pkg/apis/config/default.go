@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultsConfigName is the name of the ConfigMap that holds the
+	// controller-wide defaults for EventListeners.
+	DefaultsConfigName = "config-defaults-triggers"
+
+	elImageKey               = "el-image"
+	elPortKey                = "el-port"
+	periodSecondsKey         = "period-seconds"
+	failureThresholdKey      = "failure-threshold"
+	elReadTimeoutKey         = "el-read-timeout"
+	elWriteTimeoutKey        = "el-write-timeout"
+	elIdleTimeoutKey         = "el-idle-timeout"
+	elMaxConcurrentStreamKey = "el-max-concurrent-streams"
+	elMaxTriggersKey         = "el-max-triggers-per-event"
+	elResourcesKey           = "el-resources"
+	elMetricsEnabledKey      = "el-metrics-enabled"
+	defaultServiceAccountKey = "default-service-account"
+	elTLSMinVersionKey       = "el-tls-min-version"
+	elTLSCipherSuitesKey     = "el-tls-cipher-suites"
+	elTLSCurvesKey           = "el-tls-curve-preferences"
+	elPrometheusMonitorKey   = "el-prometheus-monitor"
+)
+
+// Defaults holds the controller-wide defaults for EventListeners. Zero values
+// mean that the corresponding controller flag is used.
+// +k8s:deepcopy-gen=true
+type Defaults struct {
+	// ELImage is the container image of the EventListener sink.
+	ELImage string
+	// ELPort is the port the EventListener sink listens on.
+	ELPort int
+	// PeriodSeconds and FailureThreshold configure the liveness probe of
+	// the EventListener sink.
+	PeriodSeconds    int
+	FailureThreshold int
+	// ReadTimeout, WriteTimeout and IdleTimeout configure the EventListener
+	// sink HTTP server.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// MaxConcurrentStreams is the maximum number of HTTP/2 streams per
+	// connection to the EventListener sink.
+	MaxConcurrentStreams int
+	// MaxTriggersPerEvent limits how many Triggers may create resources for
+	// a single event.
+	MaxTriggersPerEvent int
+	// ELResources are the compute resources of the EventListener sink
+	// container.
+	ELResources *corev1.ResourceRequirements
+	// ELMetricsEnabled exposes Prometheus metrics on /metrics of the
+	// EventListener sink.
+	ELMetricsEnabled bool
+	// DefaultServiceAccount is used by EventListeners that do not specify
+	// a serviceAccountName.
+	DefaultServiceAccount string
+	// TLSPolicy restricts the TLS connections of the EventListener sinks
+	// whose tlsPolicy does not set the same fields.
+	TLSPolicy *v1alpha1.TLSPolicy
+	// PrometheusMonitor generates a monitor for the Prometheus Operator for
+	// the EventListeners that do not set their own prometheusMonitor.
+	PrometheusMonitor *v1alpha1.PrometheusMonitor
+}
+
+// NewDefaultsFromMap returns Defaults given a map corresponding to a ConfigMap.
+func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
+	d := Defaults{
+		ELImage:               cfgMap[elImageKey],
+		DefaultServiceAccount: cfgMap[defaultServiceAccountKey],
+		ELMetricsEnabled:      true,
+	}
+
+	for key, field := range map[string]*int{
+		elPortKey:                &d.ELPort,
+		periodSecondsKey:         &d.PeriodSeconds,
+		failureThresholdKey:      &d.FailureThreshold,
+		elMaxConcurrentStreamKey: &d.MaxConcurrentStreams,
+		elMaxTriggersKey:         &d.MaxTriggersPerEvent,
+	} {
+		if v, ok := cfgMap[key]; ok {
+			i, err := strconv.Atoi(v)
+			if err != nil || i <= 0 {
+				return nil, fmt.Errorf("%s must be a positive integer, got %q", key, v)
+			}
+			*field = i
+		}
+	}
+
+	for key, field := range map[string]*time.Duration{
+		elReadTimeoutKey:  &d.ReadTimeout,
+		elWriteTimeoutKey: &d.WriteTimeout,
+		elIdleTimeoutKey:  &d.IdleTimeout,
+	} {
+		if v, ok := cfgMap[key]; ok {
+			dur, err := time.ParseDuration(v)
+			if err != nil || dur <= 0 {
+				return nil, fmt.Errorf("%s must be a positive duration, got %q", key, v)
+			}
+			*field = dur
+		}
+	}
+
+	if v, ok := cfgMap[elResourcesKey]; ok {
+		var resources corev1.ResourceRequirements
+		if err := yaml.UnmarshalStrict([]byte(v), &resources); err != nil {
+			return nil, fmt.Errorf("failed parsing %s: %w", elResourcesKey, err)
+		}
+		d.ELResources = &resources
+	}
+
+	if v, ok := cfgMap[elMetricsEnabledKey]; ok {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a boolean, got %q", elMetricsEnabledKey, v)
+		}
+		d.ELMetricsEnabled = enabled
+	}
+
+	if v, ok := cfgMap[elPrometheusMonitorKey]; ok {
+		var monitor v1alpha1.PrometheusMonitor
+		if err := yaml.UnmarshalStrict([]byte(v), &monitor); err != nil {
+			return nil, fmt.Errorf("failed parsing %s: %w", elPrometheusMonitorKey, err)
+		}
+		if err := monitor.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", elPrometheusMonitorKey, err)
+		}
+		d.PrometheusMonitor = &monitor
+	}
+
+	policy := v1alpha1.TLSPolicy{
+		MinVersion:       cfgMap[elTLSMinVersionKey],
+		CipherSuites:     splitList(cfgMap[elTLSCipherSuitesKey]),
+		CurvePreferences: splitList(cfgMap[elTLSCurvesKey]),
+	}
+	if policy.MinVersion != "" || len(policy.CipherSuites) > 0 || len(policy.CurvePreferences) > 0 {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid TLS policy: %w", err)
+		}
+		d.TLSPolicy = &policy
+	}
+
+	return &d, nil
+}
+
+// splitList splits a comma-separated list, ignoring spaces and empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// NewDefaultsFromConfigMap returns Defaults for the given ConfigMap.
+func NewDefaultsFromConfigMap(config *corev1.ConfigMap) (*Defaults, error) {
+	return NewDefaultsFromMap(config.Data)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNewDefaultsFromMap(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+		want *Defaults
+	}{{
+		name: "empty",
+		data: map[string]string{},
+		want: &Defaults{ELMetricsEnabled: true},
+	}, {
+		name: "all keys",
+		data: map[string]string{
+			"el-image":                  "example.com/sink:v1",
+			"el-port":                   "9000",
+			"period-seconds":            "5",
+			"failure-threshold":         "3",
+			"el-read-timeout":           "10s",
+			"el-write-timeout":          "20s",
+			"el-idle-timeout":           "1m",
+			"el-max-concurrent-streams": "100",
+			"el-max-triggers-per-event": "20",
+			"el-resources":              "requests:\n  cpu: 100m\nlimits:\n  memory: 256Mi\n",
+			"el-metrics-enabled":        "false",
+			"default-service-account":   "tekton-triggers",
+			"el-tls-min-version":        "VersionTLS12",
+			"el-tls-cipher-suites":      "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			"el-tls-curve-preferences":  "X25519,P256",
+			"el-prometheus-monitor":     "labels:\n  release: prometheus\ninterval: 30s\n",
+			"_example":                  "ignored",
+		},
+		want: &Defaults{
+			ELImage:              "example.com/sink:v1",
+			ELPort:               9000,
+			PeriodSeconds:        5,
+			FailureThreshold:     3,
+			ReadTimeout:          10 * time.Second,
+			WriteTimeout:         20 * time.Second,
+			IdleTimeout:          time.Minute,
+			MaxConcurrentStreams: 100,
+			MaxTriggersPerEvent:  20,
+			ELResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+			ELMetricsEnabled:      false,
+			DefaultServiceAccount: "tekton-triggers",
+			TLSPolicy: &v1alpha1.TLSPolicy{
+				MinVersion:       "VersionTLS12",
+				CipherSuites:     []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+				CurvePreferences: []string{"X25519", "P256"},
+			},
+			PrometheusMonitor: &v1alpha1.PrometheusMonitor{
+				Labels:   map[string]string{"release": "prometheus"},
+				Interval: "30s",
+			},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewDefaultsFromMap(tc.data)
+			if err != nil {
+				t.Fatalf("NewDefaultsFromMap() = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewDefaultsFromMap() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewDefaultsFromMap_error(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+	}{{
+		name: "port not a number",
+		data: map[string]string{"el-port": "http"},
+	}, {
+		name: "negative failure threshold",
+		data: map[string]string{"failure-threshold": "-1"},
+	}, {
+		name: "invalid timeout",
+		data: map[string]string{"el-read-timeout": "30"},
+	}, {
+		name: "zero timeout",
+		data: map[string]string{"el-idle-timeout": "0s"},
+	}, {
+		name: "zero max triggers",
+		data: map[string]string{"el-max-triggers-per-event": "0"},
+	}, {
+		name: "unknown resources field",
+		data: map[string]string{"el-resources": "request:\n  cpu: 100m\n"},
+	}, {
+		name: "invalid quantity",
+		data: map[string]string{"el-resources": "limits:\n  cpu: lots\n"},
+	}, {
+		name: "metrics not a boolean",
+		data: map[string]string{"el-metrics-enabled": "yes please"},
+	}, {
+		name: "unknown TLS version",
+		data: map[string]string{"el-tls-min-version": "1.2"},
+	}, {
+		name: "insecure cipher suite",
+		data: map[string]string{"el-tls-cipher-suites": "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_RSA_WITH_RC4_128_SHA"},
+	}, {
+		name: "invalid monitor interval",
+		data: map[string]string{"el-prometheus-monitor": "interval: often\n"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, err := NewDefaultsFromMap(tc.data); err == nil {
+				t.Errorf("NewDefaultsFromMap() = %v, wanted error", got)
+			}
+		})
+	}
+}
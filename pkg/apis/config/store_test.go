@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(logtesting.TestLogger(t))
+
+	// The built-in defaults are used until the ConfigMap is observed.
+	want := &Config{Defaults: &Defaults{ELMetricsEnabled: true}}
+	if diff := cmp.Diff(want, store.Load()); diff != "" {
+		t.Errorf("Load() -want,+got: %s", diff)
+	}
+
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultsConfigName},
+		Data: map[string]string{
+			"el-image":                "example.com/sink:v1",
+			"default-service-account": "tekton-triggers",
+		},
+	})
+	want = &Config{Defaults: &Defaults{
+		ELImage:               "example.com/sink:v1",
+		ELMetricsEnabled:      true,
+		DefaultServiceAccount: "tekton-triggers",
+	}}
+	ctx := store.ToContext(context.Background())
+	if diff := cmp.Diff(want, FromContext(ctx)); diff != "" {
+		t.Errorf("FromContext() -want,+got: %s", diff)
+	}
+
+	// Invalid updates keep the last valid configuration.
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultsConfigName},
+		Data:       map[string]string{"el-port": "http"},
+	})
+	if diff := cmp.Diff(want, store.Load()); diff != "" {
+		t.Errorf("Load() after invalid update -want,+got: %s", diff)
+	}
+}
+
+func TestFromContextOrDefaults(t *testing.T) {
+	want := &Config{Defaults: &Defaults{ELMetricsEnabled: true}}
+	if diff := cmp.Diff(want, FromContextOrDefaults(context.Background())); diff != "" {
+		t.Errorf("FromContextOrDefaults() -want,+got: %s", diff)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+// Check that ClusterEventListener may be validated and defaulted.
+var _ apis.Validatable = (*ClusterEventListener)(nil)
+var _ apis.Defaultable = (*ClusterEventListener)(nil)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// ClusterEventListener is an EventListener with a cluster scope.
+// ClusterEventListeners are used for centralized, platform managed event
+// ingestion; the controller runs them in its own system namespace, and their
+// Triggers may only reference ClusterTriggerBindings.
+type ClusterEventListener struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the ClusterEventListener from the client
+	// +optional
+	Spec EventListenerSpec `json:"spec"`
+
+	// +optional
+	Status EventListenerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterEventListenerList contains a list of ClusterEventListener
+type ClusterEventListenerList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterEventListener `json:"items"`
+}
+
+// GetOwnerReference gets the ClusterEventListener as owner reference for any
+// related objects.
+func (cel *ClusterEventListener) GetOwnerReference() *metav1.OwnerReference {
+	return metav1.NewControllerRef(cel, schema.GroupVersionKind{
+		Group:   SchemeGroupVersion.Group,
+		Version: SchemeGroupVersion.Version,
+		Kind:    "ClusterEventListener",
+	})
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+// Validate ClusterEventListener.
+func (cel *ClusterEventListener) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(cel.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if err := cel.Spec.validate(ctx, nil); err != nil {
+		return err
+	}
+	for i, t := range cel.Spec.Triggers {
+		for j, b := range t.Bindings {
+			if b.Kind != ClusterTriggerBindingKind {
+				return apis.ErrInvalidValue(fmt.Errorf("only ClusterTriggerBindings can be referenced"), fmt.Sprintf("spec.triggers[%d].bindings[%d].kind", i, j))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func clusterEventListener(kind v1alpha1.TriggerBindingKind) *v1alpha1.ClusterEventListener {
+	return &v1alpha1.ClusterEventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "name",
+		},
+		Spec: v1alpha1.EventListenerSpec{
+			Triggers: []v1alpha1.EventListenerTrigger{{
+				Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: kind}},
+				Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+			}},
+		},
+	}
+}
+
+func Test_ClusterEventListenerValidate(t *testing.T) {
+	cel := clusterEventListener(v1alpha1.ClusterTriggerBindingKind)
+	if err := cel.Validate(context.Background()); err != nil {
+		t.Errorf("ClusterEventListener.Validate() returned error: %s", err)
+	}
+}
+
+func Test_ClusterEventListenerValidate_error(t *testing.T) {
+	tests := []struct {
+		name string
+		cel  *v1alpha1.ClusterEventListener
+	}{{
+		name: "no triggers",
+		cel: &v1alpha1.ClusterEventListener{
+			ObjectMeta: metav1.ObjectMeta{Name: "name"},
+		},
+	}, {
+		name: "namespaced TriggerBinding",
+		cel:  clusterEventListener(v1alpha1.NamespacedTriggerBindingKind),
+	}, {
+		name: "missing binding kind",
+		cel:  clusterEventListener(""),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cel.Validate(context.Background()); err == nil {
+				t.Errorf("ClusterEventListener.Validate() expected error, got none")
+			}
+		})
+	}
+}
+
+func Test_ClusterEventListenerSetDefaults(t *testing.T) {
+	cel := clusterEventListener("")
+	cel.SetDefaults(v1alpha1.WithUpgradeViaDefaulting(context.Background()))
+	if kind := cel.Spec.Triggers[0].Bindings[0].Kind; kind != v1alpha1.ClusterTriggerBindingKind {
+		t.Errorf("expected binding kind to default to %s, got %s", v1alpha1.ClusterTriggerBindingKind, kind)
+	}
+}
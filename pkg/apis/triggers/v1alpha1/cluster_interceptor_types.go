@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// Check that ClusterInterceptor may be validated and defaulted.
+var _ apis.Validatable = (*ClusterInterceptor)(nil)
+var _ apis.Defaultable = (*ClusterInterceptor)(nil)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// ClusterInterceptor describes a pluggable interceptor service that Triggers
+// can reference by name, along with a JSON Schema for the params it accepts.
+type ClusterInterceptor struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the ClusterInterceptor from the client
+	// +optional
+	Spec ClusterInterceptorSpec `json:"spec,omitempty"`
+}
+
+// ClusterInterceptorSpec describes how to reach an interceptor and what params
+// it accepts.
+type ClusterInterceptorSpec struct {
+	ClientConfig ClientConfig `json:"clientConfig"`
+	// ParamsSchema is a JSON Schema that the params of every Trigger
+	// interceptor referencing this ClusterInterceptor are validated against.
+	// +optional
+	ParamsSchema *runtime.RawExtension `json:"paramsSchema,omitempty"`
+	// Concurrency limits the requests that each EventListener sends to the
+	// interceptor at the same time, so that a burst of events cannot
+	// overload it. If not set, requests are not limited.
+	// +optional
+	Concurrency *InterceptorConcurrency `json:"concurrency,omitempty"`
+}
+
+// InterceptorConcurrency limits the requests in flight to an interceptor.
+// Requests over the limit wait in a queue until a request finishes or the
+// event times out. Requests that do not fit in the queue are shed, i.e. the
+// Trigger fails with InterceptorOverloaded without calling the interceptor.
+type InterceptorConcurrency struct {
+	// MaxInFlight is the maximum number of requests in flight.
+	MaxInFlight int32 `json:"maxInFlight"`
+	// MaxQueued is the maximum number of requests waiting for a request in
+	// flight to finish. Defaults to 0, which sheds all requests over the
+	// limit.
+	// +optional
+	MaxQueued int32 `json:"maxQueued,omitempty"`
+}
+
+// ClientConfig describes how to connect to an interceptor.
+type ClientConfig struct {
+	// Service is a reference to the Service running the interceptor.
+	Service *ServiceReference `json:"service,omitempty"`
+}
+
+// ServiceReference is a reference to a Service.
+type ServiceReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterInterceptorList contains a list of ClusterInterceptor
+type ClusterInterceptorList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterInterceptor `json:"items"`
+}
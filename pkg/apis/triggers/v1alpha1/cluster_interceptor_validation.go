@@ -0,0 +1,258 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// schemaTypes are the JSON Schema types supported in a paramsSchema.
+var schemaTypes = map[string]bool{
+	"array":   true,
+	"boolean": true,
+	"integer": true,
+	"null":    true,
+	"number":  true,
+	"object":  true,
+	"string":  true,
+}
+
+// Validate ClusterInterceptor.
+func (ci *ClusterInterceptor) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(ci.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	svc := ci.Spec.ClientConfig.Service
+	if svc == nil {
+		return apis.ErrMissingField("spec.clientConfig.service")
+	}
+	if svc.Name == "" {
+		return apis.ErrMissingField("spec.clientConfig.service.name")
+	}
+	if svc.Namespace == "" {
+		return apis.ErrMissingField("spec.clientConfig.service.namespace")
+	}
+	if ci.Spec.ParamsSchema != nil {
+		schema, err := ci.paramsSchema()
+		if err != nil {
+			return apis.ErrInvalidValue(err, "spec.paramsSchema")
+		}
+		if err := checkSchema(schema).ViaField("spec.paramsSchema"); err != nil {
+			return err
+		}
+	}
+	if c := ci.Spec.Concurrency; c != nil {
+		if c.MaxInFlight < 1 {
+			return apis.ErrInvalidValue(fmt.Errorf("maxInFlight must be at least 1"), "spec.concurrency.maxInFlight")
+		}
+		if c.MaxQueued < 0 {
+			return apis.ErrInvalidValue(fmt.Errorf("maxQueued must not be negative"), "spec.concurrency.maxQueued")
+		}
+	}
+	return nil
+}
+
+// ValidateParams validates the params of a Trigger interceptor against the
+// paramsSchema of the ClusterInterceptor.
+func (ci *ClusterInterceptor) ValidateParams(params map[string]runtime.RawExtension) *apis.FieldError {
+	if ci.Spec.ParamsSchema == nil {
+		return nil
+	}
+	schema, err := ci.paramsSchema()
+	if err != nil {
+		return apis.ErrInvalidValue(fmt.Errorf("ClusterInterceptor %s has an invalid paramsSchema: %w", ci.Name, err), apis.CurrentField)
+	}
+	value := map[string]interface{}{}
+	for name, raw := range params {
+		var v interface{}
+		if err := json.Unmarshal(raw.Raw, &v); err != nil {
+			return apis.ErrInvalidValue(err, name)
+		}
+		value[name] = v
+	}
+	return validateSchema(schema, value, "")
+}
+
+func (ci *ClusterInterceptor) paramsSchema() (map[string]interface{}, error) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(ci.Spec.ParamsSchema.Raw, &schema); err != nil {
+		return nil, fmt.Errorf("paramsSchema must be a JSON object: %w", err)
+	}
+	return schema, nil
+}
+
+// checkSchema checks that a paramsSchema only uses the supported subset of
+// JSON Schema: type, enum, properties, required, additionalProperties and
+// items.
+func checkSchema(schema map[string]interface{}) *apis.FieldError {
+	if t, ok := schema["type"]; ok {
+		if s, ok := t.(string); !ok || !schemaTypes[s] {
+			return apis.ErrInvalidValue(fmt.Errorf("unsupported type %v", t), "type")
+		}
+	}
+	if e, ok := schema["enum"]; ok {
+		if _, ok := e.([]interface{}); !ok {
+			return apis.ErrInvalidValue(fmt.Errorf("enum must be an array"), "enum")
+		}
+	}
+	if r, ok := schema["required"]; ok {
+		required, ok := r.([]interface{})
+		if !ok {
+			return apis.ErrInvalidValue(fmt.Errorf("required must be an array of strings"), "required")
+		}
+		for _, name := range required {
+			if _, ok := name.(string); !ok {
+				return apis.ErrInvalidValue(fmt.Errorf("required must be an array of strings"), "required")
+			}
+		}
+	}
+	if p, ok := schema["properties"]; ok {
+		properties, ok := p.(map[string]interface{})
+		if !ok {
+			return apis.ErrInvalidValue(fmt.Errorf("properties must be an object"), "properties")
+		}
+		for name, prop := range properties {
+			s, ok := prop.(map[string]interface{})
+			if !ok {
+				return apis.ErrInvalidValue(fmt.Errorf("property schema must be an object"), "properties."+name)
+			}
+			if err := checkSchema(s).ViaField("properties." + name); err != nil {
+				return err
+			}
+		}
+	}
+	if a, ok := schema["additionalProperties"]; ok {
+		switch s := a.(type) {
+		case bool:
+		case map[string]interface{}:
+			if err := checkSchema(s).ViaField("additionalProperties"); err != nil {
+				return err
+			}
+		default:
+			return apis.ErrInvalidValue(fmt.Errorf("additionalProperties must be a boolean or an object"), "additionalProperties")
+		}
+	}
+	if i, ok := schema["items"]; ok {
+		s, ok := i.(map[string]interface{})
+		if !ok {
+			return apis.ErrInvalidValue(fmt.Errorf("items must be an object"), "items")
+		}
+		if err := checkSchema(s).ViaField("items"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSchema validates a decoded JSON value against a schema that has
+// been checked with checkSchema.
+func validateSchema(schema map[string]interface{}, value interface{}, path string) *apis.FieldError {
+	if t, ok := schema["type"].(string); ok && !hasSchemaType(value, t) {
+		return apis.ErrInvalidValue(fmt.Sprintf("expected %s", t), path)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return apis.ErrInvalidValue(fmt.Sprintf("%v is not one of %v", value, enum), path)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := v[name.(string)]; !ok {
+					return apis.ErrMissingField(joinPath(path, name.(string)))
+				}
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			field := v[name]
+			if prop, ok := properties[name].(map[string]interface{}); ok {
+				if err := validateSchema(prop, field, joinPath(path, name)); err != nil {
+					return err
+				}
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					return apis.ErrDisallowedFields(joinPath(path, name))
+				}
+			case map[string]interface{}:
+				if err := validateSchema(additional, field, joinPath(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hasSchemaType(value interface{}, t string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case float64:
+		return t == "number" || (t == "integer" && v == math.Trunc(v))
+	case nil:
+		return t == "null"
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
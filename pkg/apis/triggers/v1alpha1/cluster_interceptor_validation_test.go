@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const githubParamsSchema = `{
+	"type": "object",
+	"properties": {
+		"secretName": {"type": "string"},
+		"secretKey": {"type": "string"},
+		"eventTypes": {"type": "array", "items": {"type": "string", "enum": ["push", "pull_request"]}},
+		"retries": {"type": "integer"}
+	},
+	"required": ["secretName"],
+	"additionalProperties": false
+}`
+
+func clusterInterceptor(schema string) *v1alpha1.ClusterInterceptor {
+	ci := &v1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "github",
+		},
+		Spec: v1alpha1.ClusterInterceptorSpec{
+			ClientConfig: v1alpha1.ClientConfig{
+				Service: &v1alpha1.ServiceReference{Name: "github-interceptor", Namespace: "tekton-pipelines"},
+			},
+		},
+	}
+	if schema != "" {
+		ci.Spec.ParamsSchema = &runtime.RawExtension{Raw: []byte(schema)}
+	}
+	return ci
+}
+
+func withConcurrency(ci *v1alpha1.ClusterInterceptor, maxInFlight, maxQueued int32) *v1alpha1.ClusterInterceptor {
+	ci.Spec.Concurrency = &v1alpha1.InterceptorConcurrency{MaxInFlight: maxInFlight, MaxQueued: maxQueued}
+	return ci
+}
+
+func Test_ClusterInterceptorValidate(t *testing.T) {
+	for _, schema := range []string{"", githubParamsSchema, `{"additionalProperties": {"type": "string"}}`} {
+		if err := clusterInterceptor(schema).Validate(context.Background()); err != nil {
+			t.Errorf("ClusterInterceptor.Validate() returned error for schema %q: %s", schema, err)
+		}
+	}
+	if err := withConcurrency(clusterInterceptor(""), 10, 0).Validate(context.Background()); err != nil {
+		t.Errorf("ClusterInterceptor.Validate() returned error for concurrency: %s", err)
+	}
+}
+
+func Test_ClusterInterceptorValidate_error(t *testing.T) {
+	tests := []struct {
+		name string
+		ci   *v1alpha1.ClusterInterceptor
+		want string
+	}{{
+		name: "missing service",
+		ci: &v1alpha1.ClusterInterceptor{
+			ObjectMeta: metav1.ObjectMeta{Name: "github"},
+		},
+		want: "missing field(s): spec.clientConfig.service",
+	}, {
+		name: "missing service namespace",
+		ci: &v1alpha1.ClusterInterceptor{
+			ObjectMeta: metav1.ObjectMeta{Name: "github"},
+			Spec: v1alpha1.ClusterInterceptorSpec{
+				ClientConfig: v1alpha1.ClientConfig{
+					Service: &v1alpha1.ServiceReference{Name: "github-interceptor"},
+				},
+			},
+		},
+		want: "missing field(s): spec.clientConfig.service.namespace",
+	}, {
+		name: "schema not an object",
+		ci:   clusterInterceptor(`["string"]`),
+		want: "invalid value: paramsSchema must be a JSON object: json: cannot unmarshal array into Go value of type map[string]interface {}: spec.paramsSchema",
+	}, {
+		name: "unsupported type",
+		ci:   clusterInterceptor(`{"properties": {"secretKey": {"type": "secret"}}}`),
+		want: "invalid value: unsupported type secret: spec.paramsSchema.properties.secretKey.type",
+	}, {
+		name: "required not strings",
+		ci:   clusterInterceptor(`{"required": [1]}`),
+		want: "invalid value: required must be an array of strings: spec.paramsSchema.required",
+	}, {
+		name: "no requests in flight",
+		ci:   withConcurrency(clusterInterceptor(""), 0, 0),
+		want: "invalid value: maxInFlight must be at least 1: spec.concurrency.maxInFlight",
+	}, {
+		name: "negative queue",
+		ci:   withConcurrency(clusterInterceptor(""), 1, -1),
+		want: "invalid value: maxQueued must not be negative: spec.concurrency.maxQueued",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ci.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("ClusterInterceptor.Validate() expected error, got none")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("ClusterInterceptor.Validate() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_ClusterInterceptorValidateParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		params map[string]string
+		want   string
+	}{{
+		name:   "no schema",
+		params: map[string]string{"anything": `"goes"`},
+	}, {
+		name:   "valid params",
+		schema: githubParamsSchema,
+		params: map[string]string{
+			"secretName": `"github-secret"`,
+			"secretKey":  `"token"`,
+			"eventTypes": `["push"]`,
+			"retries":    `3`,
+		},
+	}, {
+		name:   "misspelled param",
+		schema: githubParamsSchema,
+		params: map[string]string{"secretName": `"github-secret"`, "secertKey": `"token"`},
+		want:   "must not set the field(s): secertKey",
+	}, {
+		name:   "missing required param",
+		schema: githubParamsSchema,
+		params: map[string]string{"secretKey": `"token"`},
+		want:   "missing field(s): secretName",
+	}, {
+		name:   "wrong type",
+		schema: githubParamsSchema,
+		params: map[string]string{"secretName": `{"name": "github-secret"}`},
+		want:   "invalid value: expected string: secretName",
+	}, {
+		name:   "not an integer",
+		schema: githubParamsSchema,
+		params: map[string]string{"secretName": `"github-secret"`, "retries": `1.5`},
+		want:   "invalid value: expected integer: retries",
+	}, {
+		name:   "item not in enum",
+		schema: githubParamsSchema,
+		params: map[string]string{"secretName": `"github-secret"`, "eventTypes": `["push", "issues"]`},
+		want:   "invalid value: issues is not one of [push pull_request]: eventTypes[1]",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]runtime.RawExtension{}
+			for k, v := range tt.params {
+				params[k] = runtime.RawExtension{Raw: []byte(v)}
+			}
+			err := clusterInterceptor(tt.schema).ValidateParams(params)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ClusterInterceptor.ValidateParams() returned error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ClusterInterceptor.ValidateParams() expected error, got none")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("ClusterInterceptor.ValidateParams() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_EventListenerValidate_clusterInterceptorParams(t *testing.T) {
+	getter := func(name string) (*v1alpha1.ClusterInterceptor, error) {
+		if name != "github" {
+			return nil, errors.NewNotFound(v1alpha1.Resource("clusterinterceptors"), name)
+		}
+		return clusterInterceptor(githubParamsSchema), nil
+	}
+	ctx := v1alpha1.WithClusterInterceptorGetter(context.Background(), getter)
+
+	tests := []struct {
+		name   string
+		ref    string
+		params map[string]runtime.RawExtension
+		want   string
+	}{{
+		name:   "valid params",
+		ref:    "github",
+		params: map[string]runtime.RawExtension{"secretName": {Raw: []byte(`"github-secret"`)}},
+	}, {
+		name: "misspelled param",
+		ref:  "github",
+		params: map[string]runtime.RawExtension{
+			"secretName": {Raw: []byte(`"github-secret"`)},
+			"secertKey":  {Raw: []byte(`"token"`)},
+		},
+		want: "must not set the field(s): spec.triggers[0].interceptors[0].interceptor.params.secertKey",
+	}, {
+		name: "unknown ClusterInterceptor",
+		ref:  "gitlab",
+		want: `invalid value: clusterinterceptors.triggers.tekton.dev "gitlab" not found: spec.triggers[0].interceptors[0].interceptor.ref.name`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			el := &v1alpha1.EventListener{
+				ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+				Spec: v1alpha1.EventListenerSpec{
+					Triggers: []v1alpha1.EventListenerTrigger{{
+						Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+						Interceptors: []*v1alpha1.EventInterceptor{{
+							Ref:    &v1alpha1.InterceptorRef{Name: tt.ref},
+							Params: tt.params,
+						}},
+					}},
+				},
+			}
+			err := el.Validate(ctx)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("EventListener.Validate() returned error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("EventListener.Validate() expected error, got none")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("EventListener.Validate() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"knative.dev/pkg/apis"
@@ -27,5 +28,12 @@ func (ctb *ClusterTriggerBinding) Validate(ctx context.Context) *apis.FieldError
 	if err := validate.ObjectMetadata(ctb.GetObjectMeta()); err != nil {
 		return err.ViaField("metadata")
 	}
+	// ClusterTriggerBindings are not namespaced, so they can only include
+	// other ClusterTriggerBindings.
+	for i, inc := range ctb.Spec.Includes {
+		if inc.Kind == NamespacedTriggerBindingKind {
+			return apis.ErrInvalidValue(fmt.Errorf("ClusterTriggerBindings can only include ClusterTriggerBindings"), fmt.Sprintf("spec.includes[%d].kind", i))
+		}
+	}
 	return ctb.Spec.Validate(ctx)
 }
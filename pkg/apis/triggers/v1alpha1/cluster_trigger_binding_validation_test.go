@@ -47,6 +47,13 @@ func Test_ClusterTriggerBindingValidate(t *testing.T) {
 				bldr.TriggerBindingParam("PARAM1", "$(body.input2)"),
 				bldr.TriggerBindingParam("Param1", "$(body.input3)"),
 			)),
+	}, {
+		name: "includes cluster bindings",
+		tb: bldr.ClusterTriggerBinding("name",
+			bldr.ClusterTriggerBindingSpec(
+				bldr.TriggerBindingInclude("base", ""),
+				bldr.TriggerBindingInclude("other", v1alpha1.ClusterTriggerBindingKind),
+			)),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,6 +76,12 @@ func Test_ClusterTriggerBindingValidate_error(t *testing.T) {
 				bldr.TriggerBindingParam("param1", "$(body.param1)"),
 				bldr.TriggerBindingParam("param3", "$(body.param1)"),
 			)),
+	}, {
+		name: "includes namespaced binding",
+		tb: bldr.ClusterTriggerBinding("name",
+			bldr.ClusterTriggerBindingSpec(
+				bldr.TriggerBindingInclude("base", v1alpha1.NamespacedTriggerBindingKind),
+			)),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -20,3 +20,50 @@ func WithUpgradeViaDefaulting(ctx context.Context) context.Context {
 func IsUpgradeViaDefaulting(ctx context.Context) bool {
 	return ctx.Value(upgradeViaDefaultingKey{}) != nil
 }
+
+// ClusterInterceptorGetter returns the ClusterInterceptor with the given name.
+type ClusterInterceptorGetter func(name string) (*ClusterInterceptor, error)
+
+// clusterInterceptorGetterKey is used as the key in a context.Context for the
+// ClusterInterceptorGetter used during validation.
+type clusterInterceptorGetterKey struct{}
+
+// WithClusterInterceptorGetter sets the function used to look up the
+// ClusterInterceptors referenced by Triggers, so that interceptor params can
+// be validated against their schema.
+func WithClusterInterceptorGetter(ctx context.Context, getter ClusterInterceptorGetter) context.Context {
+	return context.WithValue(ctx, clusterInterceptorGetterKey{}, getter)
+}
+
+// getClusterInterceptorGetter returns the ClusterInterceptorGetter set on the
+// context, or nil if there is none.
+func getClusterInterceptorGetter(ctx context.Context) ClusterInterceptorGetter {
+	if getter, ok := ctx.Value(clusterInterceptorGetterKey{}).(ClusterInterceptorGetter); ok {
+		return getter
+	}
+	return nil
+}
+
+// TriggerBindingGetter returns the spec of the TriggerBinding with the given
+// namespace and name, or of the ClusterTriggerBinding with the given name.
+type TriggerBindingGetter func(kind TriggerBindingKind, namespace, name string) (*TriggerBindingSpec, error)
+
+// triggerBindingGetterKey is used as the key in a context.Context for the
+// TriggerBindingGetter used during validation.
+type triggerBindingGetterKey struct{}
+
+// WithTriggerBindingGetter sets the function used to look up the bindings
+// referenced by Triggers, so that params set by more than one binding can be
+// detected.
+func WithTriggerBindingGetter(ctx context.Context, getter TriggerBindingGetter) context.Context {
+	return context.WithValue(ctx, triggerBindingGetterKey{}, getter)
+}
+
+// getTriggerBindingGetter returns the TriggerBindingGetter set on the context,
+// or nil if there is none.
+func getTriggerBindingGetter(ctx context.Context) TriggerBindingGetter {
+	if getter, ok := ctx.Value(triggerBindingGetterKey{}).(TriggerBindingGetter); ok {
+		return getter
+	}
+	return nil
+}
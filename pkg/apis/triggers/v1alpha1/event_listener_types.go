@@ -18,11 +18,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
@@ -56,6 +58,449 @@ type EventListenerSpec struct {
 	ServiceAccountName string                 `json:"serviceAccountName"`
 	Triggers           []EventListenerTrigger `json:"triggers"`
 	ServiceType        corev1.ServiceType     `json:"serviceType,omitempty"`
+	// Maintenance, when set, makes the EventListener reject all events with
+	// 503 Service Unavailable so that providers redeliver them later.
+	// +optional
+	Maintenance *MaintenanceMode `json:"maintenance,omitempty"`
+	// NamespaceSelector selects additional namespaces to serve the Triggers
+	// in. For every selected namespace that has the TriggerTemplate of a
+	// Trigger, the Trigger is resolved and its resources are created in that
+	// namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// SQS, when set, makes the EventListener consume events from an Amazon
+	// SQS queue in addition to the events it receives over HTTP.
+	// +optional
+	SQS *SQSSource `json:"sqs,omitempty"`
+	// Autoscaling generates a KEDA ScaledObject that scales the EventListener
+	// with the number of messages waiting in its queue sources, or with the
+	// events in flight in its pods. It requires a queue source like sqs or
+	// the prometheus server that the events in flight are read from.
+	// +optional
+	Autoscaling *QueueAutoscaling `json:"autoscaling,omitempty"`
+	// Admin serves the operational endpoints of the sink, like /metrics, on
+	// a separate port from events, optionally protected by a bearer token or
+	// client certificates.
+	// +optional
+	Admin *AdminEndpoints `json:"admin,omitempty"`
+	// PrometheusMonitor generates a Prometheus Operator ServiceMonitor, or a
+	// PodMonitor when the admin endpoints are served on their own port, that
+	// scrapes the metrics of the EventListener. It overrides the monitor
+	// configured for all EventListeners in config-defaults-triggers.
+	// +optional
+	PrometheusMonitor *PrometheusMonitor `json:"prometheusMonitor,omitempty"`
+	// Replicas is the number of pods of the EventListener. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Availability runs multiple replicas of the EventListener that are
+	// spread across nodes, and protects them with a PodDisruptionBudget.
+	// +optional
+	Availability *Availability `json:"availability,omitempty"`
+	// FIPS restricts the signature verification of interceptors to
+	// FIPS-approved algorithms. The sink image must be built with the
+	// BoringCrypto module, otherwise the sink does not start.
+	// +optional
+	FIPS bool `json:"fips,omitempty"`
+	// WarmUp sets what the sink does with the problems found by checking
+	// the interceptors of the Triggers at startup: compiling CEL
+	// expressions, reading the Secrets of interceptors and pinging
+	// interceptor Services. Defaults to warn.
+	// +optional
+	WarmUp WarmUpMode `json:"warmUp,omitempty"`
+	// EventTimeoutSeconds limits how long the sink processes an event, from
+	// the interceptors to the creation of the resources. Triggers that do
+	// not finish in time fail with DeadlineExceeded. Defaults to 30, at
+	// most 600.
+	// +optional
+	EventTimeoutSeconds int32 `json:"eventTimeoutSeconds,omitempty"`
+	// PhaseTimeouts limit the phases of processing an event for each
+	// Trigger, within the event timeout. Triggers can override them.
+	// +optional
+	PhaseTimeouts *PhaseTimeouts `json:"phaseTimeouts,omitempty"`
+	// Deduplication makes the EventListeners that receive the same
+	// deliveries of a provider, e.g. while migrating from one to another,
+	// process each delivery only once.
+	// +optional
+	Deduplication *Deduplication `json:"deduplication,omitempty"`
+	// Runtime tunes the Go runtime of the sink, so that it can be adapted to
+	// the resources of its container without rebuilding the image.
+	// +optional
+	Runtime *SinkRuntime `json:"runtime,omitempty"`
+	// TLSSecret is the name of a Secret in the namespace of the
+	// EventListener with tls.crt and tls.key keys, with which the sink
+	// serves HTTPS. The sink reloads the certificate when the Secret changes.
+	// +optional
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// TLSPolicy restricts the TLS versions, cipher suites and curves that
+	// the sink accepts when it serves TLS and uses to connect to
+	// interceptors. Fields that are not set fall back to the
+	// config-defaults-triggers ConfigMap, and then to the Go defaults.
+	// +optional
+	TLSPolicy *TLSPolicy `json:"tlsPolicy,omitempty"`
+	// AuthFailureBlock rejects the events of a source for a while after too
+	// many of its events failed the signature or token validation of an
+	// interceptor.
+	// +optional
+	AuthFailureBlock *AuthFailureBlock `json:"authFailureBlock,omitempty"`
+	// Manual enables firing a Trigger on demand on /manual/<trigger>, with
+	// the values of its params instead of an event.
+	// +optional
+	Manual *ManualTriggering `json:"manual,omitempty"`
+	// TrustedSenders are internal senders whose events are already
+	// authenticated, so that the interceptors of the Triggers skip
+	// validating their signature or token. The filters of the interceptors
+	// still apply to their events.
+	// +optional
+	TrustedSenders *TrustedSenders `json:"trustedSenders,omitempty"`
+	// OpenShift integrates the EventListener with the service CA and the
+	// Routes of OpenShift.
+	// +optional
+	OpenShift *OpenShift `json:"openshift,omitempty"`
+	// Callback posts the outcome of processing each event to a URL that the
+	// sender supplies in the event, for senders that wait for a callback.
+	// +optional
+	Callback *EventCallback `json:"callback,omitempty"`
+}
+
+// EventCallback configures where the outcome of an event is posted.
+type EventCallback struct {
+	// URLField is the JSONPath of the field of the event body with the
+	// callback URL, e.g. callback.url. Events without the field get no
+	// callback.
+	URLField string `json:"urlField"`
+	// AllowedHosts are the hosts that callbacks may be posted to. A host
+	// starting with "*." allows its subdomains, e.g. *.example.com.
+	AllowedHosts []string `json:"allowedHosts"`
+}
+
+// OpenShift configures the OpenShift features that an EventListener uses.
+type OpenShift struct {
+	// ServingCert makes the sink serve HTTPS with a certificate that the
+	// service CA issues for the Service of the EventListener.
+	// +optional
+	ServingCert bool `json:"servingCert,omitempty"`
+	// Route exposes the EventListener through a Route that terminates TLS
+	// at the router and re-encrypts the connections to the sink. Requires
+	// ServingCert.
+	// +optional
+	Route *OpenShiftRoute `json:"route,omitempty"`
+	// TrustServiceCA makes the sink trust the certificates that the service
+	// CA issues when it calls interceptors over HTTPS, in addition to the
+	// system CAs.
+	// +optional
+	TrustServiceCA bool `json:"trustServiceCA,omitempty"`
+}
+
+// OpenShiftRoute is the Route of an EventListener.
+type OpenShiftRoute struct {
+	// Host is the hostname of the Route. Defaults to a hostname that the
+	// router generates.
+	// +optional
+	Host string `json:"host,omitempty"`
+}
+
+// TrustedSenders identifies the senders whose events are pre-authenticated. A
+// sender is trusted if it matches any of the fields.
+type TrustedSenders struct {
+	// CommonNames trust the senders that present a client certificate with
+	// one of the common names. The certificate must be verified by the sink,
+	// which requires it to serve TLS with a client CA.
+	// +optional
+	CommonNames []string `json:"commonNames,omitempty"`
+	// SourceRanges trust the senders whose address is in one of the CIDRs.
+	// The address is the remote address of the connection to the sink, so
+	// behind a proxy or load balancer that does not preserve it, every
+	// sender has the address of the proxy. X-Forwarded-For is not used,
+	// since any sender can set it.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+	// TokenSecret trusts the senders that send the token in the key of the
+	// Secret in the Tekton-Sender-Token header. The Secret must be in the
+	// namespace of the EventListener.
+	// +optional
+	TokenSecret *SecretRef `json:"tokenSecret,omitempty"`
+}
+
+// ManualTriggering configures how Triggers are fired on demand.
+type ManualTriggering struct {
+	// TokenSecret is the key of the Secret in the namespace of the
+	// EventListener with the bearer token that requests must send.
+	TokenSecret SecretRef `json:"tokenSecret"`
+}
+
+// AuthFailureBlock configures how sources whose events fail authentication
+// are blocked. A source is the IP address that an event is received from.
+type AuthFailureBlock struct {
+	// MaxFailures is the number of events of a source that fail
+	// authentication within the window after which the source is blocked.
+	MaxFailures int32 `json:"maxFailures"`
+	// WindowSeconds is how long the failures of a source are counted for.
+	// Defaults to 60.
+	// +optional
+	WindowSeconds int32 `json:"windowSeconds,omitempty"`
+	// BlockSeconds is how long the events of a blocked source are rejected
+	// with 429 Too Many Requests. Defaults to 600.
+	// +optional
+	BlockSeconds int32 `json:"blockSeconds,omitempty"`
+}
+
+// TLSPolicy restricts the TLS connections of the sink of an EventListener.
+type TLSPolicy struct {
+	// MinVersion is the minimum TLS version, one of VersionTLS10,
+	// VersionTLS11, VersionTLS12 or VersionTLS13.
+	// +optional
+	MinVersion string `json:"minVersion,omitempty"`
+	// CipherSuites are the IANA names of the cipher suites allowed for TLS
+	// 1.2 and earlier, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. The
+	// cipher suites of TLS 1.3 are not configurable.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// CurvePreferences are the elliptic curves used for key exchange, in
+	// order of preference: X25519, P256, P384 or P521.
+	// +optional
+	CurvePreferences []string `json:"curvePreferences,omitempty"`
+}
+
+// WarmUpMode is what the sink of an EventListener does with the problems
+// found when it checks the interceptors of the Triggers at startup.
+type WarmUpMode string
+
+const (
+	// WarmUpOff skips the checks.
+	WarmUpOff WarmUpMode = "off"
+	// WarmUpWarn logs the problems and serves events anyway.
+	WarmUpWarn WarmUpMode = "warn"
+	// WarmUpFail does not start the sink if there is a problem.
+	WarmUpFail WarmUpMode = "fail"
+)
+
+// PhaseTimeouts limit how long each phase of processing an event for a Trigger
+// takes. A Trigger whose phase does not finish in time fails with
+// DeadlineExceeded. Phases without a timeout are only limited by the event
+// timeout.
+type PhaseTimeouts struct {
+	// InterceptorSeconds limits the interceptor phase, from the first to the
+	// last interceptor of the Trigger.
+	// +optional
+	InterceptorSeconds int32 `json:"interceptorSeconds,omitempty"`
+	// RenderSeconds limits resolving the bindings and rendering the
+	// TriggerTemplate.
+	// +optional
+	RenderSeconds int32 `json:"renderSeconds,omitempty"`
+	// CreateSeconds limits creating the resources, including retries.
+	// +optional
+	CreateSeconds int32 `json:"createSeconds,omitempty"`
+}
+
+// SinkRuntime configures the Go runtime of the sink.
+type SinkRuntime struct {
+	// GoMaxProcs limits the number of OS threads that execute Go code at
+	// once, like GOMAXPROCS. Defaults to the number of CPUs of the node,
+	// which is usually more than the CPU limit of the sink container.
+	// +optional
+	GoMaxProcs int32 `json:"goMaxProcs,omitempty"`
+	// GCPercent is the garbage collection target percentage, like GOGC.
+	// Lower values trade CPU for memory, and -1 disables the garbage
+	// collector. Defaults to 100.
+	// +optional
+	GCPercent *int32 `json:"gcPercent,omitempty"`
+}
+
+// DeduplicationBackend is where the deliveries that EventListeners claimed are
+// recorded.
+type DeduplicationBackend string
+
+const (
+	// DeduplicationLease records each delivery in a Lease.
+	DeduplicationLease DeduplicationBackend = "Lease"
+	// DeduplicationConfigMap records each delivery in a ConfigMap, for
+	// EventListeners that may not manage Leases.
+	DeduplicationConfigMap DeduplicationBackend = "ConfigMap"
+)
+
+// Deduplication identifies deliveries by the delivery ID that the provider
+// sends in a header. The first EventListener of a group that receives a
+// delivery claims it, and the others acknowledge it without evaluating their
+// Triggers. Events without the header are always processed.
+type Deduplication struct {
+	// Header is the name of the header with the delivery ID, e.g.
+	// X-GitHub-Delivery.
+	Header string `json:"header"`
+	// Group identifies the EventListeners that share deliveries. It must be
+	// the same for all of them.
+	Group string `json:"group"`
+	// Backend is where the deliveries are recorded. Defaults to Lease.
+	// +optional
+	Backend DeduplicationBackend `json:"backend,omitempty"`
+	// Namespace is the namespace of the Leases or ConfigMaps that record
+	// the deliveries. It must be the same for all EventListeners of the
+	// group. Defaults to the namespace of the EventListener.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// TTLSeconds is how long a delivery is remembered after it was claimed.
+	// Defaults to 3600.
+	// +optional
+	TTLSeconds int32 `json:"ttlSeconds,omitempty"`
+}
+
+// Availability keeps an EventListener available during node maintenance.
+type Availability struct {
+	// Replicas is the number of pods of the EventListener, at least 2.
+	Replicas int32 `json:"replicas"`
+	// MaxUnavailable is the number of pods that voluntary disruptions, like
+	// node drains, may evict at once. It defaults to 1 and must be less than
+	// Replicas.
+	// +optional
+	MaxUnavailable int32 `json:"maxUnavailable,omitempty"`
+	// TopologyKeys are the node labels across whose values the pods are
+	// spread, e.g. topology.kubernetes.io/zone. They default to
+	// kubernetes.io/hostname.
+	// +optional
+	TopologyKeys []string `json:"topologyKeys,omitempty"`
+}
+
+// AdminEndpoints configures how the operational endpoints of an EventListener
+// sink are served, so that exposing the event port publicly does not expose
+// them too.
+type AdminEndpoints struct {
+	// Port is the container port of the admin endpoints. It must differ from
+	// the port of the EventListener and is not added to its Service.
+	Port int32 `json:"port"`
+	// TokenSecret is the name of a Secret in the namespace of the
+	// EventListener with a token key. Requests to the admin endpoints must
+	// send the token as a bearer token in the Authorization header.
+	// +optional
+	TokenSecret string `json:"tokenSecret,omitempty"`
+	// TLSSecret is the name of a Secret in the namespace of the
+	// EventListener with tls.crt and tls.key keys, with which the admin
+	// endpoints are served over TLS.
+	// +optional
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// RequireClientCert requires clients of the admin endpoints to present a
+	// certificate signed by the ca.crt of the TLSSecret.
+	// +optional
+	RequireClientCert bool `json:"requireClientCert,omitempty"`
+	// Profiling serves the Go profiler on /debug/pprof/.
+	// +optional
+	Profiling bool `json:"profiling,omitempty"`
+}
+
+// PrometheusMonitor configures the monitor that the Prometheus Operator
+// scrapes the metrics of an EventListener with. The token and TLS Secrets of
+// the admin endpoints are used to scrape them.
+type PrometheusMonitor struct {
+	// Labels are added to the monitor, so that the serviceMonitorSelector or
+	// podMonitorSelector of a Prometheus selects it, e.g. release: prometheus.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Interval is how often the metrics are scraped, e.g. 30s. Defaults to
+	// the scrape interval of the Prometheus.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// ServerName is the name that the certificate of the admin endpoints is
+	// verified for, with the ca.crt of their TLSSecret, or the OpenShift
+	// serving certificate of the sink. Defaults to the DNS name of the
+	// Service of the EventListener.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// ClientCertSecret is the name of a Secret in the namespace of the
+	// EventListener with tls.crt and tls.key keys, which Prometheus presents
+	// to admin endpoints that require client certificates.
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+}
+
+// SQSSource configures an Amazon SQS queue that an EventListener polls for
+// events. The body of a message is the event body and its string message
+// attributes are the event headers. A message is deleted from the queue once
+// it has been processed successfully; otherwise it becomes visible again
+// after the visibility timeout and is redelivered.
+type SQSSource struct {
+	// QueueURL is the URL of the queue, e.g.
+	// https://sqs.us-east-1.amazonaws.com/123456789012/events
+	QueueURL string `json:"queueURL"`
+	// Region is the AWS region of the queue. Defaults to the region in the
+	// queue URL.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// CredentialsSecret is the name of a Secret in the namespace of the
+	// EventListener with the aws_access_key_id and aws_secret_access_key
+	// keys. If not set, the EventListener uses the AWS credentials of its
+	// environment, e.g. IAM roles for service accounts.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	// VisibilityTimeoutSeconds is how long a received message is hidden from
+	// other consumers. It is extended while the message is being processed.
+	// At most 43200. Defaults to 30.
+	// +optional
+	VisibilityTimeoutSeconds int32 `json:"visibilityTimeoutSeconds,omitempty"`
+	// BatchSize is the maximum number of messages received at once, at most
+	// 10. Defaults to 10.
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+	// AttributeHeaders sets message attributes and metadata as additional
+	// event headers, so that header-based interceptors and bindings work for
+	// queued events, e.g. a type attribute as X-GitHub-Event.
+	// +optional
+	AttributeHeaders []AttributeHeader `json:"attributeHeaders,omitempty"`
+}
+
+// AttributeHeader maps a message attribute or metadata field of a queued
+// message to an event header.
+type AttributeHeader struct {
+	// Attribute is the name of a message attribute, or of a metadata field
+	// of the message prefixed with sqs:, e.g. sqs:MessageId, sqs:SentTimestamp
+	// or sqs:ApproximateReceiveCount.
+	Attribute string `json:"attribute"`
+	// Header is the name of the header that the value is set as.
+	Header string `json:"header"`
+}
+
+// QueueAutoscaling configures how KEDA scales an EventListener that consumes
+// a queue.
+type QueueAutoscaling struct {
+	// MinReplicas is the minimum number of pods. Defaults to 1, so that the
+	// EventListener keeps receiving events over HTTP.
+	// +optional
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the maximum number of pods.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// TargetQueueLength is the number of waiting messages per pod that KEDA
+	// scales towards. Defaults to 5.
+	// +optional
+	TargetQueueLength int32 `json:"targetQueueLength,omitempty"`
+	// Prometheus additionally scales the EventListener with the events its
+	// pods are processing, as scraped by Prometheus, so that bursts of
+	// webhooks scale it before its CPU usage does.
+	// +optional
+	Prometheus *PrometheusAutoscaling `json:"prometheus,omitempty"`
+}
+
+// PrometheusAutoscaling configures how KEDA scales an EventListener with the
+// eventlistener_events_in_flight metric of its pods.
+type PrometheusAutoscaling struct {
+	// ServerAddress is the URL of the Prometheus server that scrapes the
+	// metrics of the EventListener.
+	ServerAddress string `json:"serverAddress"`
+	// TargetEventsInFlight is the number of events in flight per pod that
+	// KEDA scales towards. Defaults to 10.
+	// +optional
+	TargetEventsInFlight int32 `json:"targetEventsInFlight,omitempty"`
+	// Query replaces the query of the events in flight of the EventListener,
+	// e.g. when Prometheus labels its pods differently. Defaults to the sum
+	// of eventlistener_events_in_flight over the pods of the EventListener
+	// by their namespace and pod labels.
+	// +optional
+	Query string `json:"query,omitempty"`
+}
+
+// MaintenanceMode configures how events are rejected while an EventListener
+// is in maintenance.
+type MaintenanceMode struct {
+	// RetryAfterSeconds is sent in the Retry-After header of the response.
+	// Defaults to 60.
+	// +optional
+	RetryAfterSeconds int32 `json:"retryAfterSeconds,omitempty"`
 }
 
 // EventListenerTrigger represents a connection between TriggerBinding, Params,
@@ -76,14 +521,194 @@ type EventListenerTrigger struct {
 	// TODO do we want to restrict this to the event listener namespace and just ask for the service account name here?
 	// +optional
 	ServiceAccount *corev1.ObjectReference `json:"serviceAccount,omitempty"`
+	// InterceptorServiceAccount optionally sets the credentials that the
+	// interceptors of the trigger read Secrets with, instead of the
+	// credentials of the EventListener, so that a trigger can only read the
+	// Secrets that its ServiceAccount is allowed to.
+	// +optional
+	InterceptorServiceAccount *corev1.ObjectReference `json:"interceptorServiceAccount,omitempty"`
+	// ValidateBeforeCreate performs a server-side dry-run of every rendered
+	// resource before creating any of them. If a dry-run is rejected, no
+	// resources are created for the event.
+	// +optional
+	ValidateBeforeCreate bool `json:"validateBeforeCreate,omitempty"`
+	// Capture persists selected headers and body fields of the event for the
+	// resources that the Trigger creates.
+	// +optional
+	Capture *EventCapture `json:"capture,omitempty"`
+	// MaxEventAge rejects events that were sent by the provider longer ago
+	// than a maximum age, e.g. redeliveries that are replayed hours later.
+	// +optional
+	MaxEventAge *EventAge `json:"maxEventAge,omitempty"`
+	// InterceptorResources allows webhook interceptors and ClusterInterceptors
+	// to respond with the resources to create, instead of the bindings and
+	// template of the Trigger rendering them.
+	// +optional
+	InterceptorResources *InterceptorResources `json:"interceptorResources,omitempty"`
+	// MatchHeaders selects the Trigger only for events whose headers match
+	// all of the entries. They are checked before any interceptor is called.
+	// +optional
+	MatchHeaders []HeaderMatch `json:"matchHeaders,omitempty"`
+	// OnMissingField sets how binding params whose values refer to fields
+	// that are not in the event are handled. Defaults to fail.
+	// +optional
+	OnMissingField MissingFieldPolicy `json:"onMissingField,omitempty"`
+	// OnBindingConflict sets how params that are set by more than one of the
+	// bindings are handled. Defaults to fail.
+	// +optional
+	OnBindingConflict BindingConflictPolicy `json:"onBindingConflict,omitempty"`
+	// PhaseTimeouts override the phase timeouts of the EventListener for
+	// the Trigger, phase by phase.
+	// +optional
+	PhaseTimeouts *PhaseTimeouts `json:"phaseTimeouts,omitempty"`
+	// Cluster creates the resources of the Trigger on a remote cluster
+	// instead of the cluster of the EventListener.
+	// +optional
+	Cluster *RemoteCluster `json:"cluster,omitempty"`
+}
+
+// RemoteCluster is a cluster that a Trigger creates its resources on.
+type RemoteCluster struct {
+	// KubeconfigSecret is a Secret in the namespace of the Trigger with a
+	// kubeconfig for the remote cluster. The key defaults to kubeconfig.
+	// Kubeconfigs that run commands or read files to authenticate are
+	// rejected.
+	KubeconfigSecret SecretRef `json:"kubeconfigSecret"`
+	// Namespace is the namespace on the remote cluster that the resources
+	// are created in. Defaults to the namespace of the Trigger.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DefaultKubeconfigKey is the key of the kubeconfig in the Secret of a
+// RemoteCluster, unless it sets one.
+const DefaultKubeconfigKey = "kubeconfig"
+
+// MissingFieldPolicy is how a Trigger handles binding params whose values
+// refer to fields that are not in an event.
+type MissingFieldPolicy string
+
+const (
+	// MissingFieldFail rejects the event.
+	MissingFieldFail MissingFieldPolicy = "fail"
+	// MissingFieldEmpty replaces the missing fields with empty strings.
+	MissingFieldEmpty MissingFieldPolicy = "empty"
+	// MissingFieldDefault leaves out the param, so that the default of the
+	// TriggerTemplate param is used. The event is rejected if the param has
+	// no default.
+	MissingFieldDefault MissingFieldPolicy = "default"
+)
+
+// BindingConflictPolicy is how a Trigger handles params that are set by more
+// than one of its bindings.
+type BindingConflictPolicy string
+
+const (
+	// BindingConflictFail rejects the Trigger when it is created or updated,
+	// and rejects events if the bindings change to conflict later.
+	BindingConflictFail BindingConflictPolicy = "fail"
+	// BindingConflictLastWins uses the value of the binding that is listed
+	// last.
+	BindingConflictLastWins BindingConflictPolicy = "lastWins"
+)
+
+// HeaderMatch matches the values of a header of an event.
+type HeaderMatch struct {
+	// Name is the name of the header, e.g. X-GitHub-Event.
+	Name string `json:"name"`
+	// Values matches the header if it is one of the values.
+	// +optional
+	Values []string `json:"values,omitempty"`
+	// Prefixes matches the header if it starts with one of the prefixes.
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// InterceptorResources restricts the resources that interceptors may respond
+// with.
+type InterceptorResources struct {
+	// Kinds lists the kinds of resources that interceptors may respond with,
+	// e.g. PipelineRun.
+	Kinds []string `json:"kinds"`
+}
+
+// EventAge reads the time an event was sent from a header or a body field of
+// the event. The timestamp may be in RFC 3339 format, an HTTP date, or Unix
+// seconds. Events without a valid timestamp are rejected.
+type EventAge struct {
+	// MaxAge is the maximum age of an event, e.g. 1h.
+	MaxAge metav1.Duration `json:"maxAge"`
+	// Header is the name of the header with the timestamp.
+	// +optional
+	Header string `json:"header,omitempty"`
+	// Body is the path of the body field with the timestamp, as in
+	// $(body.<path>) expressions, e.g. head_commit.timestamp.
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+// CaptureTarget is where the values captured from an event are stored.
+type CaptureTarget string
+
+const (
+	// CaptureConfigMap stores the captured values in a ConfigMap named
+	// triggers-event-$(uid) that is created with the resources of a Trigger.
+	CaptureConfigMap CaptureTarget = "ConfigMap"
+	// CaptureAnnotations stores the captured values as annotations of the
+	// resources that a Trigger creates.
+	CaptureAnnotations CaptureTarget = "Annotations"
+)
+
+// EventCapture selects the parts of an event to persist. Headers are stored
+// under header.<name> and body fields under body.<path>.
+type EventCapture struct {
+	// Headers are the names of the request headers to capture.
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+	// Body are the fields of the body to capture, as paths like in
+	// $(body.<path>) expressions, e.g. head_commit.message. Objects and
+	// arrays are stored as JSON.
+	// +optional
+	Body []string `json:"body,omitempty"`
+	// Target is where the captured values are stored. Defaults to
+	// ConfigMap.
+	// +optional
+	Target CaptureTarget `json:"target,omitempty"`
 }
 
 // EventInterceptor provides a hook to intercept and pre-process events
 type EventInterceptor struct {
-	Webhook *WebhookInterceptor `json:"webhook,omitempty"`
-	GitHub  *GitHubInterceptor  `json:"github,omitempty"`
-	GitLab  *GitLabInterceptor  `json:"gitlab,omitempty"`
-	CEL     *CELInterceptor     `json:"cel,omitempty"`
+	Webhook   *WebhookInterceptor   `json:"webhook,omitempty"`
+	GitHub    *GitHubInterceptor    `json:"github,omitempty"`
+	GitLab    *GitLabInterceptor    `json:"gitlab,omitempty"`
+	CEL       *CELInterceptor       `json:"cel,omitempty"`
+	Sentry    *SentryInterceptor    `json:"sentry,omitempty"`
+	Bitbucket *BitbucketInterceptor `json:"bitbucket,omitempty"`
+	Alert     *AlertInterceptor     `json:"alert,omitempty"`
+	OPA       *OPAInterceptor       `json:"opa,omitempty"`
+	Scanner   *ScannerInterceptor   `json:"scanner,omitempty"`
+	// SupplyChain processes CDEvents and in-toto attestations
+	// +optional
+	SupplyChain *SupplyChainInterceptor `json:"supplyChain,omitempty"`
+	// Captcha validates the CAPTCHA tokens of events sent by public forms
+	// +optional
+	Captcha *CaptchaInterceptor `json:"captcha,omitempty"`
+	// Ref refers to a ClusterInterceptor to call with Params
+	// +optional
+	Ref *InterceptorRef `json:"ref,omitempty"`
+	// Params are passed to the ClusterInterceptor in Ref, and are validated
+	// against its paramsSchema
+	// +optional
+	Params map[string]runtime.RawExtension `json:"params,omitempty"`
+	// Chain runs the interceptors of an InterceptorChain in place of this
+	// interceptor
+	// +optional
+	Chain *InterceptorChainRef `json:"chain,omitempty"`
+}
+
+// InterceptorRef refers to a ClusterInterceptor
+type InterceptorRef struct {
+	Name string `json:"name"`
 }
 
 // WebhookInterceptor provides a webhook to intercept and pre-process events
@@ -96,18 +721,263 @@ type WebhookInterceptor struct {
 	// interceptor request headers. This allows the interceptor to make
 	// decisions specific to an EventListenerTrigger.
 	Header []pipelinev1.Param `json:"header,omitempty"`
+	// Retries is how many times a request that fails to connect, times out
+	// or is responded to with a 5xx status is sent again. Defaults to the
+	// -interceptor-retries flag of the sink.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+	// RetryBackoff is the delay before the first retry, e.g. 500ms, which
+	// doubles with each further retry. Defaults to the
+	// -interceptor-retry-backoff flag of the sink.
+	// +optional
+	RetryBackoff string `json:"retryBackoff,omitempty"`
 }
 
 // GitHubInterceptor provides a webhook to intercept and pre-process events
 type GitHubInterceptor struct {
 	SecretRef  *SecretRef `json:"secretRef,omitempty"`
 	EventTypes []string   `json:"eventTypes,omitempty"`
+	// PreviousSecretRef is also accepted while SecretRef is rotated, until
+	// all events are sent with the new secret
+	// +optional
+	PreviousSecretRef *SecretRef `json:"previousSecretRef,omitempty"`
+	// ProcessPing evaluates the Triggers of the EventListener for GitHub ping
+	// events. By default, the sink of an EventListener with a GitHub
+	// interceptor acknowledges ping events without evaluating any Trigger.
+	// +optional
+	ProcessPing bool `json:"processPing,omitempty"`
+	// Tags only allows push, create, delete and release events for a
+	// matching tag.
+	// +optional
+	Tags *TagFilter `json:"tags,omitempty"`
+	// ReleaseActions only allows release events with one of the actions,
+	// e.g. published or prereleased.
+	// +optional
+	ReleaseActions []string `json:"releaseActions,omitempty"`
+	// InstallationTargets only allows events for one of the installation
+	// targets, e.g. to separate the events of the GitHub Apps that share an
+	// EventListener.
+	// +optional
+	InstallationTargets []GitHubInstallationTarget `json:"installationTargets,omitempty"`
+	// Ignore filters the events of bot accounts and of commits that ask to
+	// skip CI.
+	// +optional
+	Ignore *IgnoreFilter `json:"ignore,omitempty"`
+}
+
+// GitHubInstallationTarget matches the installation target of a GitHub event,
+// i.e. what the webhook is installed on, as set in the
+// X-GitHub-Hook-Installation-Target-Type and -ID headers.
+type GitHubInstallationTarget struct {
+	// Type is the type of the target, e.g. integration for the webhook of a
+	// GitHub App, repository or organization.
+	Type string `json:"type"`
+	// ID is the ID of the target, e.g. the ID of the GitHub App. An empty ID
+	// matches any target of the type.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// TagFilter filters events on the name of the tag they are for.
+type TagFilter struct {
+	// Prefixes filters on the start of the tag name, e.g. v or release-.
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+	// SemverRange filters on the semantic version of the tag, after any of
+	// the matching Prefixes or a leading v, e.g. ">=1.2.0 <2.0.0".
+	// +optional
+	SemverRange string `json:"semverRange,omitempty"`
+}
+
+// IgnoreFilter filters the events that bots and commits marked with [skip ci]
+// or [ci skip] generate, so that they do not trigger any resource.
+type IgnoreFilter struct {
+	// Accounts ignores the events sent by one of the accounts, e.g.
+	// dependabot[bot] or renovate-bot. Accounts are compared to the login of
+	// the sender of GitHub events, the username of the user of GitLab events
+	// and the name or slug of the actor of Bitbucket events, ignoring case.
+	// +optional
+	Accounts []string `json:"accounts,omitempty"`
+	// SkipCI ignores push events whose head commit message contains
+	// [skip ci] or [ci skip], and pull or merge request events whose title
+	// or last commit message does.
+	// +optional
+	SkipCI bool `json:"skipCI,omitempty"`
 }
 
 // GitLabInterceptor provides a webhook to intercept and pre-process events
 type GitLabInterceptor struct {
 	SecretRef  *SecretRef `json:"secretRef,omitempty"`
 	EventTypes []string   `json:"eventTypes,omitempty"`
+	// PreviousSecretRef is also accepted while SecretRef is rotated, until
+	// all events are sent with the new secret
+	// +optional
+	PreviousSecretRef *SecretRef `json:"previousSecretRef,omitempty"`
+	// Statuses only allows Pipeline Hook, Job Hook and Deployment Hook
+	// events with one of the statuses, e.g. success or failed. GitLab sends
+	// these events on each status transition.
+	// +optional
+	Statuses []string `json:"statuses,omitempty"`
+	// Environments only allows Job Hook and Deployment Hook events for one
+	// of the environments, e.g. production.
+	// +optional
+	Environments []string `json:"environments,omitempty"`
+	// Ignore filters the events of bot accounts and of commits that ask to
+	// skip CI.
+	// +optional
+	Ignore *IgnoreFilter `json:"ignore,omitempty"`
+}
+
+// BitbucketInterceptor provides a webhook to intercept and pre-process events
+// sent by Bitbucket Server
+type BitbucketInterceptor struct {
+	SecretRef *SecretRef `json:"secretRef,omitempty"`
+	// PreviousSecretRef is also accepted while SecretRef is rotated, until
+	// all events are sent with the new secret
+	// +optional
+	PreviousSecretRef *SecretRef `json:"previousSecretRef,omitempty"`
+	// EventTypes filters on the X-Event-Key header, e.g. repo:refs_changed
+	// or pr:opened
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// SourceRanges filters on the address the event was sent from, as a list
+	// of CIDRs
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+	// Projects filters on the key of the project the repository belongs to
+	Projects []string `json:"projects,omitempty"`
+	// Repositories filters on the repository slug
+	Repositories []string `json:"repositories,omitempty"`
+	// ReviewerStates filters pr:reviewer events on the new status of the
+	// reviewer, e.g. APPROVED, UNAPPROVED or NEEDS_WORK
+	ReviewerStates []string `json:"reviewerStates,omitempty"`
+	// Tags only allows repo:refs_changed events that change a matching tag
+	// +optional
+	Tags *TagFilter `json:"tags,omitempty"`
+	// Ignore filters the events of bot accounts and of commits that ask to
+	// skip CI.
+	// +optional
+	Ignore *IgnoreFilter `json:"ignore,omitempty"`
+}
+
+// SentryInterceptor provides a webhook to intercept and pre-process events
+// sent by Sentry integrations
+type SentryInterceptor struct {
+	SecretRef *SecretRef `json:"secretRef,omitempty"`
+	// ResourceTypes filters on the Sentry-Hook-Resource header, e.g. issue,
+	// error, event_alert or metric_alert
+	ResourceTypes []string `json:"resourceTypes,omitempty"`
+	// Projects filters on the slug or ID of the project the event belongs to
+	Projects []string `json:"projects,omitempty"`
+	// Levels filters on the level of the issue or event, e.g. error or fatal
+	Levels []string `json:"levels,omitempty"`
+}
+
+// AlertInterceptor provides a webhook to intercept and pre-process alert
+// notifications sent by Datadog, Prometheus Alertmanager or Grafana
+type AlertInterceptor struct {
+	// SecretRef is compared to the bearer token in the Authorization header
+	SecretRef *SecretRef `json:"secretRef,omitempty"`
+	// Statuses filters on the status of the alert, e.g. firing or resolved for
+	// Alertmanager and Grafana, or Triggered or Recovered for Datadog
+	Statuses []string `json:"statuses,omitempty"`
+	// Tags lists key:value tags that the alert must all have. Alertmanager
+	// and Grafana alerts are tagged with their common labels.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// ScannerInterceptor provides a webhook to intercept and pre-process the
+// vulnerability notifications of security scanners: Snyk, Dependabot alerts
+// sent by GitHub and Trivy operator VulnerabilityReports. The vulnerabilities
+// are added to the body in extensions.scanner.
+type ScannerInterceptor struct {
+	// SecretRef validates the X-Hub-Signature-256 or X-Hub-Signature header
+	// of Snyk and GitHub events, and is compared to the bearer token in the
+	// Authorization header of other events
+	SecretRef *SecretRef `json:"secretRef,omitempty"`
+	// MinSeverity drops vulnerabilities below low, medium, high or critical.
+	// Events without any remaining vulnerability are filtered.
+	MinSeverity string `json:"minSeverity,omitempty"`
+}
+
+// CaptchaProvider is a service that issues and verifies CAPTCHA tokens.
+type CaptchaProvider string
+
+const (
+	// CaptchaRecaptcha is Google reCAPTCHA, v2 or v3.
+	CaptchaRecaptcha CaptchaProvider = "recaptcha"
+	// CaptchaTurnstile is Cloudflare Turnstile.
+	CaptchaTurnstile CaptchaProvider = "turnstile"
+	// CaptchaHCaptcha is hCaptcha.
+	CaptchaHCaptcha CaptchaProvider = "hcaptcha"
+)
+
+// CaptchaInterceptor provides a webhook to intercept and pre-process events
+// sent by public forms. The CAPTCHA token of the form is verified with the
+// provider, so that bots cannot create resources through the EventListener.
+type CaptchaInterceptor struct {
+	// Provider is recaptcha, turnstile or hcaptcha.
+	Provider CaptchaProvider `json:"provider"`
+	// SecretRef refers to the secret key of the site, which the provider
+	// verifies tokens with.
+	SecretRef *SecretRef `json:"secretRef"`
+	// TokenField is the path of the token in the body. Defaults to the field
+	// that the widget of the provider submits, e.g. g-recaptcha-response.
+	// +optional
+	TokenField string `json:"tokenField,omitempty"`
+	// MinScore rejects reCAPTCHA v3 tokens scored below it, between 0.0 and
+	// 1.0.
+	// +optional
+	MinScore string `json:"minScore,omitempty"`
+	// Action rejects tokens issued for another action of the site.
+	// +optional
+	Action string `json:"action,omitempty"`
+	// Hostnames rejects tokens issued on other sites.
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+	// VerifyURL replaces the verification endpoint of the provider, e.g. to
+	// go through a proxy.
+	// +optional
+	VerifyURL string `json:"verifyURL,omitempty"`
+}
+
+// SupplyChainInterceptor provides a webhook to intercept and pre-process
+// supply chain events: CDEvents, and in-toto attestations in DSSE envelopes,
+// Sigstore bundles or JSON lines bundles of either. The subject and predicate
+// fields are added to the body in extensions.supplychain.
+type SupplyChainInterceptor struct {
+	// SecretRef validates the X-Hub-Signature-256 header of events, or is
+	// compared to the bearer token in the Authorization header
+	// +optional
+	SecretRef *SecretRef `json:"secretRef,omitempty"`
+	// PublicKeyRef refers to PEM-encoded public keys. When set, every
+	// attestation must be signed by one of the keys, and unsigned
+	// attestations are rejected.
+	// +optional
+	PublicKeyRef *SecretRef `json:"publicKeyRef,omitempty"`
+	// EventTypes only allows CDEvents of these types without their version,
+	// e.g. dev.cdevents.artifact.published
+	// +optional
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// PredicateTypes only keeps the attestations with these predicate types,
+	// e.g. https://slsa.dev/provenance/v1. Events without any remaining
+	// attestation are filtered.
+	// +optional
+	PredicateTypes []string `json:"predicateTypes,omitempty"`
+}
+
+// OPAInterceptor provides a webhook to intercept events and evaluate them
+// against a Rego policy on an Open Policy Agent server
+type OPAInterceptor struct {
+	// Server is the URL of the OPA server, e.g. http://opa.opa.svc:8181
+	Server string `json:"server"`
+	// Decision is the path of the rule that decides whether the event is
+	// processed, e.g. triggers/allow. The rule must evaluate to true.
+	Decision string `json:"decision"`
+	// PolicyRef refers to a key of a ConfigMap in the namespace of the
+	// EventListener that holds the Rego policy, which is loaded into the
+	// server before the decision is evaluated. If not set, the policy must
+	// already be loaded on the server, e.g. from a bundle.
+	// +optional
+	PolicyRef *corev1.ConfigMapKeySelector `json:"policyRef,omitempty"`
 }
 
 // CELInterceptor provides a webhook to intercept and pre-process events
@@ -136,12 +1006,26 @@ type EventListenerBinding struct {
 	Name       string             `json:"name"`
 	Kind       TriggerBindingKind `json:"kind"`
 	APIVersion string             `json:"apiversion,omitempty"`
+	// Generation pins the reference to a generation of the binding.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// Version pins the reference to the binding with this value of the
+	// triggers.tekton.dev/version label.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // EventListenerTemplate refers to a particular TriggerTemplate resource.
 type EventListenerTemplate struct {
 	Name       string `json:"name"`
 	APIVersion string `json:"apiversion,omitempty"`
+	// Generation pins the reference to a generation of the TriggerTemplate.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// Version pins the reference to the TriggerTemplate with this value of
+	// the triggers.tekton.dev/version label.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // EventListenerList contains a list of TriggerBinding
@@ -165,6 +1049,41 @@ type EventListenerStatus struct {
 
 	// Configuration stores configuration for the EventListener service
 	Configuration EventListenerConfig `json:"configuration"`
+
+	// AvailableTriggers is the number of Triggers whose referenced resources
+	// are usable.
+	// +optional
+	AvailableTriggers int32 `json:"availableTriggers,omitempty"`
+
+	// LastEventTime is when the sink last received an event. It is updated
+	// at most once per status update interval of the sink.
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// Triggers counts the events processed by each Trigger. The counters are
+	// updated in batches by the sink.
+	// +optional
+	Triggers []TriggerStatus `json:"triggers,omitempty"`
+}
+
+// TriggerStatus counts the events processed by a Trigger of an EventListener.
+type TriggerStatus struct {
+	// Name is the name of the Trigger.
+	Name string `json:"name"`
+	// Events is the number of events processed by the Trigger.
+	// +optional
+	Events int64 `json:"events,omitempty"`
+	// Created is the number of events for which the Trigger created
+	// resources.
+	// +optional
+	Created int64 `json:"created,omitempty"`
+	// Failed is the number of events for which the Trigger failed with one
+	// of the error reasons.
+	// +optional
+	Failed int64 `json:"failed,omitempty"`
+	// LastCreatedTime is when the Trigger last created resources.
+	// +optional
+	LastCreatedTime *metav1.Time `json:"lastCreatedTime,omitempty"`
 }
 
 // EventListenerConfig stores configuration for resources generated by the
@@ -183,6 +1102,50 @@ const (
 	// DeploymentExists is the ConditionType set on the EventListener, which
 	// specifies Deployment existence.
 	DeploymentExists apis.ConditionType = "Deployment"
+	// TriggersResolved is the ConditionType set on the EventListener, which
+	// specifies whether the resources referenced by its Triggers are usable.
+	TriggersResolved apis.ConditionType = "TriggersResolved"
+)
+
+// The reasons set on the TriggersResolved condition and returned by the sink
+// when processing a Trigger fails. They are part of the API so that automation
+// can react to specific classes of failures.
+const (
+	// ReasonSecretMissing indicates that a Secret referenced by an
+	// interceptor does not exist.
+	ReasonSecretMissing = "SecretMissing"
+	// ReasonInterceptorUnreachable indicates that a webhook interceptor or
+	// OPA server could not be reached, or that an OPA policy is missing.
+	ReasonInterceptorUnreachable = "InterceptorUnreachable"
+	// ReasonTemplateInvalid indicates that a TriggerTemplate does not exist
+	// or could not be rendered.
+	ReasonTemplateInvalid = "TemplateInvalid"
+	// ReasonRBACDenied indicates that a request to the Kubernetes API was
+	// not authorized.
+	ReasonRBACDenied = "RBACDenied"
+	// ReasonResourceRejected indicates that a rendered resource was rejected
+	// by the server-side dry-run of a Trigger with validateBeforeCreate.
+	ReasonResourceRejected = "ResourceRejected"
+	// ReasonInterceptorRejected indicates that an interceptor rejected the
+	// event, e.g. because a CEL filter did not match. It is only returned by
+	// the sink.
+	ReasonInterceptorRejected = "InterceptorRejected"
+	// ReasonDeadlineExceeded indicates that processing the event did not
+	// finish within the event timeout of the EventListener, or a phase
+	// within its phase timeout. It is only returned by the sink.
+	ReasonDeadlineExceeded = "DeadlineExceeded"
+	// ReasonFieldMissing indicates that a binding param refers to a field
+	// that is not in the event, and that the Trigger rejects such events. It
+	// is only returned by the sink.
+	ReasonFieldMissing = "FieldMissing"
+	// ReasonTooManyTriggers indicates that more Triggers matched the event
+	// than the sink allows to create resources for a single event. It is
+	// only returned by the sink.
+	ReasonTooManyTriggers = "TooManyTriggers"
+	// ReasonInterceptorOverloaded indicates that a ClusterInterceptor had
+	// as many requests in flight and queued as its concurrency allows, so
+	// that the request was shed. It is only returned by the sink.
+	ReasonInterceptorOverloaded = "InterceptorOverloaded"
 )
 
 // Check that EventListener may be validated and defaulted.
@@ -255,6 +1218,25 @@ func (els *EventListenerStatus) SetExistsCondition(cond apis.ConditionType, err
 	}
 }
 
+// SetTriggersResolvedCondition sets the TriggersResolved condition. An empty
+// reason marks all Triggers as resolved.
+func (els *EventListenerStatus) SetTriggersResolvedCondition(reason, message string) {
+	if reason == "" {
+		els.SetCondition(&apis.Condition{
+			Type:    TriggersResolved,
+			Status:  corev1.ConditionTrue,
+			Message: "Triggers resolved",
+		})
+		return
+	}
+	els.SetCondition(&apis.Condition{
+		Type:    TriggersResolved,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
 // InitializeConditions will set all conditions in eventListenerCondSet to false
 // for the EventListener. This does not use the InitializeCondition() provided
 // by the conditionsImpl to avoid setting the happy condition. This is a local
@@ -271,6 +1253,23 @@ func (els *EventListenerStatus) InitializeConditions() {
 	}
 }
 
+const (
+	// DefaultEventTimeoutSeconds is the event timeout of EventListeners that
+	// do not set eventTimeoutSeconds.
+	DefaultEventTimeoutSeconds = 30
+	// MaxEventTimeoutSeconds is the largest eventTimeoutSeconds.
+	MaxEventTimeoutSeconds = 600
+)
+
+// EventTimeout returns how long the sink processes an event for the
+// EventListener.
+func (s *EventListenerSpec) EventTimeout() time.Duration {
+	if s.EventTimeoutSeconds > 0 {
+		return time.Duration(s.EventTimeoutSeconds) * time.Second
+	}
+	return DefaultEventTimeoutSeconds * time.Second
+}
+
 // GetOwnerReference gets the EventListener as owner reference for any related
 // objects.
 func (el *EventListener) GetOwnerReference() *metav1.OwnerReference {
@@ -281,6 +1280,24 @@ func (el *EventListener) GetOwnerReference() *metav1.OwnerReference {
 	})
 }
 
+// ExternalHostnameAnnotation is the annotation of an EventListener with the
+// hostname it is reachable at from outside the cluster, e.g. the host of an
+// Ingress in front of it. The address of the EventListener is then its HTTPS
+// URL, so that webhooks can be registered with it.
+const ExternalHostnameAnnotation = GroupName + "/external-hostname"
+
+// SetExternalAddress sets the address to the HTTPS URL of the hostname the
+// EventListener is reachable at from outside the cluster.
+func (els *EventListenerStatus) SetExternalAddress(hostname string) {
+	if els.Address == nil {
+		els.Address = &duckv1alpha1.Addressable{}
+	}
+	els.Address.URL = &apis.URL{
+		Scheme: "https",
+		Host:   hostname,
+	}
+}
+
 // SetAddress sets the address (as part of Addressable contract) and marks the correct condition.
 func (els *EventListenerStatus) SetAddress(hostname string) {
 	if els.Address == nil {
@@ -152,6 +152,42 @@ func TestSetExistsCondition(t *testing.T) {
 	}
 }
 
+func TestSetTriggersResolvedCondition(t *testing.T) {
+	tests := []struct {
+		name              string
+		reason            string
+		message           string
+		expectedCondition *apis.Condition
+	}{{
+		name:    "Condition with reason",
+		reason:  ReasonSecretMissing,
+		message: "secret \"foo\" not found",
+		expectedCondition: &apis.Condition{
+			Type:    TriggersResolved,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonSecretMissing,
+			Message: "secret \"foo\" not found",
+		},
+	}, {
+		name: "Condition without reason",
+		expectedCondition: &apis.Condition{
+			Type:    TriggersResolved,
+			Status:  corev1.ConditionTrue,
+			Message: "Triggers resolved",
+		},
+	}}
+	for i := range tests {
+		t.Run(tests[i].name, func(t *testing.T) {
+			els := EventListenerStatus{}
+			els.SetTriggersResolvedCondition(tests[i].reason, tests[i].message)
+			actualCond := els.GetCondition(TriggersResolved)
+			if !equality.Semantic.DeepEqual(tests[i].expectedCondition, actualCond) {
+				t.Errorf("Get Condition %v does not equal expected %v\n", actualCond, tests[i].expectedCondition)
+			}
+		})
+	}
+}
+
 func TestSetDeploymentConditions(t *testing.T) {
 	tests := []struct {
 		name                 string
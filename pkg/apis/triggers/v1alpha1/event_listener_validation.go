@@ -19,18 +19,44 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
 // Validate EventListener.
 func (e *EventListener) Validate(ctx context.Context) *apis.FieldError {
+	if host, ok := e.Annotations[ExternalHostnameAnnotation]; ok {
+		if err := validateExternalHostname(host); err != nil {
+			return apis.ErrInvalidValue(err, fmt.Sprintf("metadata.annotations[%s]", ExternalHostnameAnnotation))
+		}
+	}
 	return e.Spec.validate(ctx, e)
 }
 
+// validateExternalHostname checks that the external hostname of an
+// EventListener is a DNS name, optionally with a port.
+func validateExternalHostname(host string) error {
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("invalid port %s", port)
+		}
+		host = h
+	}
+	if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+		return fmt.Errorf("invalid hostname %s: %s", host, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
 func (s *EventListenerSpec) validate(ctx context.Context, el *EventListener) *apis.FieldError {
 	if len(s.Triggers) == 0 {
 		return apis.ErrMissingField("spec.triggers")
@@ -39,6 +65,411 @@ func (s *EventListenerSpec) validate(ctx context.Context, el *EventListener) *ap
 		if err := trigger.validate(ctx).ViaField(fmt.Sprintf("spec.triggers[%d]", i)); err != nil {
 			return err
 		}
+		// Conflicts can only be checked when the bindings can be looked up,
+		// i.e. in the validating webhook.
+		if getter := getTriggerBindingGetter(ctx); getter != nil && trigger.OnBindingConflict != BindingConflictLastWins {
+			if err := validateBindingConflicts(getter, el.Namespace, trigger.Bindings); err != nil {
+				return apis.ErrInvalidValue(err, fmt.Sprintf("spec.triggers[%d].bindings", i))
+			}
+		}
+	}
+	if s.Maintenance != nil && s.Maintenance.RetryAfterSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("retryAfterSeconds must not be negative"), "spec.maintenance.retryAfterSeconds")
+	}
+	switch s.WarmUp {
+	case "", WarmUpOff, WarmUpWarn, WarmUpFail:
+	default:
+		return apis.ErrInvalidValue(s.WarmUp, "spec.warmUp")
+	}
+	if s.EventTimeoutSeconds < 0 || s.EventTimeoutSeconds > MaxEventTimeoutSeconds {
+		return apis.ErrOutOfBoundsValue(s.EventTimeoutSeconds, 0, MaxEventTimeoutSeconds, "spec.eventTimeoutSeconds")
+	}
+	if s.PhaseTimeouts != nil {
+		if err := s.PhaseTimeouts.validate().ViaField("spec.phaseTimeouts"); err != nil {
+			return err
+		}
+	}
+	if s.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector); err != nil {
+			return apis.ErrInvalidValue(err, "spec.namespaceSelector")
+		}
+	}
+	if s.SQS != nil {
+		if err := s.SQS.validate().ViaField("spec.sqs"); err != nil {
+			return err
+		}
+	}
+	if s.Admin != nil {
+		if err := s.Admin.validate().ViaField("spec.admin"); err != nil {
+			return err
+		}
+	}
+	if s.PrometheusMonitor != nil {
+		if err := s.PrometheusMonitor.Validate().ViaField("spec.prometheusMonitor"); err != nil {
+			return err
+		}
+		if s.Admin != nil && s.Admin.RequireClientCert && s.PrometheusMonitor.ClientCertSecret == "" {
+			return apis.ErrMissingField("spec.prometheusMonitor.clientCertSecret")
+		}
+	}
+	if s.Replicas != nil {
+		// The replicas of available or autoscaled EventListeners are set by
+		// those fields
+		if s.Availability != nil {
+			return apis.ErrMultipleOneOf("spec.replicas", "spec.availability")
+		}
+		if s.Autoscaling != nil {
+			return apis.ErrMultipleOneOf("spec.replicas", "spec.autoscaling")
+		}
+		if *s.Replicas < 1 {
+			return apis.ErrInvalidValue(fmt.Errorf("replicas must be at least 1"), "spec.replicas")
+		}
+	}
+	if s.Availability != nil {
+		if err := s.Availability.validate().ViaField("spec.availability"); err != nil {
+			return err
+		}
+	}
+	if s.Autoscaling != nil {
+		if err := s.validateAutoscaling().ViaField("spec.autoscaling"); err != nil {
+			return err
+		}
+	}
+	if s.Deduplication != nil {
+		if err := s.Deduplication.validate().ViaField("spec.deduplication"); err != nil {
+			return err
+		}
+	}
+	if s.Runtime != nil {
+		if err := s.Runtime.validate().ViaField("spec.runtime"); err != nil {
+			return err
+		}
+	}
+	if s.TLSPolicy != nil {
+		if err := s.TLSPolicy.Validate().ViaField("spec.tlsPolicy"); err != nil {
+			return err
+		}
+	}
+	if s.AuthFailureBlock != nil {
+		if err := s.AuthFailureBlock.validate().ViaField("spec.authFailureBlock"); err != nil {
+			return err
+		}
+	}
+	if s.Manual != nil {
+		if s.Manual.TokenSecret.SecretName == "" {
+			return apis.ErrMissingField("spec.manual.tokenSecret.secretName")
+		}
+		if s.Manual.TokenSecret.SecretKey == "" {
+			return apis.ErrMissingField("spec.manual.tokenSecret.secretKey")
+		}
+	}
+	if s.TrustedSenders != nil {
+		if err := s.TrustedSenders.validate().ViaField("spec.trustedSenders"); err != nil {
+			return err
+		}
+	}
+	if s.OpenShift != nil {
+		if err := s.OpenShift.validate().ViaField("spec.openshift"); err != nil {
+			return err
+		}
+	}
+	if s.TLSSecret != "" && s.OpenShift != nil && s.OpenShift.ServingCert {
+		return apis.ErrMultipleOneOf("spec.tlsSecret", "spec.openshift.servingCert")
+	}
+	if s.Callback != nil {
+		if err := s.Callback.validate().ViaField("spec.callback"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *EventCallback) validate() *apis.FieldError {
+	if c.URLField == "" {
+		return apis.ErrMissingField("urlField")
+	}
+	if len(c.AllowedHosts) == 0 {
+		return apis.ErrMissingField("allowedHosts")
+	}
+	for i, host := range c.AllowedHosts {
+		if errs := validation.IsDNS1123Subdomain(strings.TrimPrefix(host, "*.")); len(errs) > 0 {
+			return apis.ErrInvalidValue(fmt.Errorf("invalid host %s: %s", host, strings.Join(errs, ", ")), fmt.Sprintf("allowedHosts[%d]", i))
+		}
+	}
+	return nil
+}
+
+func (o *OpenShift) validate() *apis.FieldError {
+	if !o.ServingCert && o.Route == nil && !o.TrustServiceCA {
+		return apis.ErrMissingOneOf("servingCert", "route", "trustServiceCA")
+	}
+	if o.Route != nil {
+		if !o.ServingCert {
+			return apis.ErrInvalidValue(fmt.Errorf("route requires servingCert"), "route")
+		}
+		if host := o.Route.Host; host != "" {
+			if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+				return apis.ErrInvalidValue(fmt.Errorf("invalid hostname %s: %s", host, strings.Join(errs, ", ")), "route.host")
+			}
+		}
+	}
+	return nil
+}
+
+func (t *TrustedSenders) validate() *apis.FieldError {
+	if len(t.CommonNames) == 0 && len(t.SourceRanges) == 0 && t.TokenSecret == nil {
+		return apis.ErrMissingOneOf("commonNames", "sourceRanges", "tokenSecret")
+	}
+	for i, cn := range t.CommonNames {
+		if cn == "" {
+			return apis.ErrInvalidValue(fmt.Errorf("common name must not be empty"), fmt.Sprintf("commonNames[%d]", i))
+		}
+	}
+	for i, cidr := range t.SourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return apis.ErrInvalidValue(err, fmt.Sprintf("sourceRanges[%d]", i))
+		}
+	}
+	if t.TokenSecret != nil {
+		if t.TokenSecret.SecretName == "" {
+			return apis.ErrMissingField("tokenSecret.secretName")
+		}
+		if t.TokenSecret.SecretKey == "" {
+			return apis.ErrMissingField("tokenSecret.secretKey")
+		}
+		if t.TokenSecret.Namespace != "" {
+			return apis.ErrDisallowedFields("tokenSecret.namespace")
+		}
+	}
+	return nil
+}
+
+func (b *AuthFailureBlock) validate() *apis.FieldError {
+	if b.MaxFailures < 1 {
+		return apis.ErrInvalidValue(fmt.Errorf("maxFailures must be at least 1"), "maxFailures")
+	}
+	if b.WindowSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("windowSeconds must not be negative"), "windowSeconds")
+	}
+	if b.BlockSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("blockSeconds must not be negative"), "blockSeconds")
+	}
+	return nil
+}
+
+func (p *PhaseTimeouts) validate() *apis.FieldError {
+	if p.InterceptorSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("interceptorSeconds must not be negative"), "interceptorSeconds")
+	}
+	if p.RenderSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("renderSeconds must not be negative"), "renderSeconds")
+	}
+	if p.CreateSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("createSeconds must not be negative"), "createSeconds")
+	}
+	return nil
+}
+
+func (r *SinkRuntime) validate() *apis.FieldError {
+	if r.GoMaxProcs < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("goMaxProcs must not be negative"), "goMaxProcs")
+	}
+	if r.GCPercent != nil && *r.GCPercent < -1 {
+		return apis.ErrInvalidValue(fmt.Errorf("gcPercent must be -1 or more"), "gcPercent")
+	}
+	return nil
+}
+
+func (d *Deduplication) validate() *apis.FieldError {
+	if d.Header == "" {
+		return apis.ErrMissingField("header")
+	}
+	if strings.ContainsAny(d.Header, " \t:") {
+		return apis.ErrInvalidValue(d.Header, "header")
+	}
+	if d.Group == "" {
+		return apis.ErrMissingField("group")
+	}
+	// The group is set as a label value of the Leases and ConfigMaps
+	if errs := validation.IsValidLabelValue(d.Group); len(errs) > 0 {
+		return apis.ErrInvalidValue(strings.Join(errs, ", "), "group")
+	}
+	if d.Namespace != "" {
+		if errs := validation.IsDNS1123Label(d.Namespace); len(errs) > 0 {
+			return apis.ErrInvalidValue(strings.Join(errs, ", "), "namespace")
+		}
+	}
+	if d.TTLSeconds < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("ttlSeconds must not be negative"), "ttlSeconds")
+	}
+	switch d.Backend {
+	case "", DeduplicationLease, DeduplicationConfigMap:
+	default:
+		return apis.ErrInvalidValue(d.Backend, "backend")
+	}
+	return nil
+}
+
+func (s *EventListenerSpec) validateAutoscaling() *apis.FieldError {
+	a := s.Autoscaling
+	if s.SQS == nil && a.Prometheus == nil {
+		return apis.ErrGeneric("autoscaling requires a queue source like sqs, or prometheus")
+	}
+	if a.MinReplicas < 0 {
+		return apis.ErrInvalidValue(a.MinReplicas, "minReplicas")
+	}
+	minReplicas := a.MinReplicas
+	if minReplicas == 0 {
+		minReplicas = 1
+	}
+	if a.MaxReplicas < minReplicas {
+		return apis.ErrInvalidValue(fmt.Errorf("maxReplicas must be at least %d", minReplicas), "maxReplicas")
+	}
+	if a.TargetQueueLength < 0 {
+		return apis.ErrInvalidValue(a.TargetQueueLength, "targetQueueLength")
+	}
+	if p := a.Prometheus; p != nil {
+		if p.ServerAddress == "" {
+			return apis.ErrMissingField("prometheus.serverAddress")
+		}
+		if u, err := url.Parse(p.ServerAddress); err != nil || u.Host == "" {
+			return apis.ErrInvalidValue(p.ServerAddress, "prometheus.serverAddress")
+		}
+		if p.TargetEventsInFlight < 0 {
+			return apis.ErrInvalidValue(p.TargetEventsInFlight, "prometheus.targetEventsInFlight")
+		}
+	}
+	// The availability replicas would be undercut by KEDA otherwise
+	if s.Availability != nil && minReplicas < s.Availability.Replicas {
+		return apis.ErrInvalidValue(fmt.Errorf("minReplicas must be at least the %d replicas of spec.availability", s.Availability.Replicas), "minReplicas")
+	}
+	return nil
+}
+
+func (a *Availability) validate() *apis.FieldError {
+	// A single replica is unavailable during every disruption
+	if a.Replicas < 2 {
+		return apis.ErrInvalidValue(fmt.Errorf("replicas must be at least 2"), "replicas")
+	}
+	if a.MaxUnavailable < 0 || a.MaxUnavailable >= a.Replicas {
+		return apis.ErrOutOfBoundsValue(a.MaxUnavailable, 0, a.Replicas-1, "maxUnavailable")
+	}
+	for i, key := range a.TopologyKeys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return apis.ErrInvalidValue(strings.Join(errs, ", "), fmt.Sprintf("topologyKeys[%d]", i))
+		}
+	}
+	return nil
+}
+
+func (s *SQSSource) validate() *apis.FieldError {
+	if s.QueueURL == "" {
+		return apis.ErrMissingField("queueURL")
+	}
+	if u, err := url.Parse(s.QueueURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apis.ErrInvalidValue(s.QueueURL, "queueURL")
+	}
+	// 0 is not set, and uses the defaults
+	if s.BatchSize < 0 || s.BatchSize > 10 {
+		return apis.ErrOutOfBoundsValue(s.BatchSize, 0, 10, "batchSize")
+	}
+	// SQS allows a visibility timeout of up to 12 hours
+	if s.VisibilityTimeoutSeconds < 0 || s.VisibilityTimeoutSeconds > 43200 {
+		return apis.ErrOutOfBoundsValue(s.VisibilityTimeoutSeconds, 0, 43200, "visibilityTimeoutSeconds")
+	}
+	for i, ah := range s.AttributeHeaders {
+		if ah.Attribute == "" {
+			return apis.ErrMissingField(fmt.Sprintf("attributeHeaders[%d].attribute", i))
+		}
+		if ah.Header == "" {
+			return apis.ErrMissingField(fmt.Sprintf("attributeHeaders[%d].header", i))
+		}
+		if strings.ContainsAny(ah.Header, " \t:") {
+			return apis.ErrInvalidValue(ah.Header, fmt.Sprintf("attributeHeaders[%d].header", i))
+		}
+	}
+	return nil
+}
+
+func (a *AdminEndpoints) validate() *apis.FieldError {
+	if a.Port < 1 || a.Port > 65535 {
+		return apis.ErrOutOfBoundsValue(a.Port, 1, 65535, "port")
+	}
+	if a.RequireClientCert && a.TLSSecret == "" {
+		return apis.ErrMissingField("tlsSecret")
+	}
+	return nil
+}
+
+// Validate checks the interval and labels of the monitor.
+func (m *PrometheusMonitor) Validate() *apis.FieldError {
+	if m.Interval != "" {
+		if d, err := time.ParseDuration(m.Interval); err != nil || d <= 0 {
+			return apis.ErrInvalidValue(m.Interval, "interval")
+		}
+	}
+	for k, v := range m.Labels {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return apis.ErrInvalidKeyName(k, "labels", errs...)
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return apis.ErrInvalidValue(v, fmt.Sprintf("labels[%s]", k))
+		}
+	}
+	return nil
+}
+
+// validateBindingConflicts checks that no param is set by more than one of the
+// bindings, including the params they inherit from their includes. Bindings
+// that cannot be looked up are skipped, since they may be created later.
+func validateBindingConflicts(getter TriggerBindingGetter, namespace string, bindings []*EventListenerBinding) error {
+	setBy := map[string]string{}
+	for _, b := range bindings {
+		params := map[string]bool{}
+		collectBindingParams(getter, namespace, TriggerBindingRef{Name: b.Name, Kind: b.Kind}, map[TriggerBindingRef]bool{}, params)
+		for name := range params {
+			if other, ok := setBy[name]; ok {
+				return fmt.Errorf("param %s is set by both %s and %s; set onBindingConflict to lastWins to use the value of the last binding", name, other, b.Name)
+			}
+			setBy[name] = b.Name
+		}
+	}
+	return nil
+}
+
+// collectBindingParams adds the names of the params that a binding sets or
+// includes to params.
+func collectBindingParams(getter TriggerBindingGetter, namespace string, ref TriggerBindingRef, seen map[TriggerBindingRef]bool, params map[string]bool) {
+	if ref.Kind == "" {
+		ref.Kind = NamespacedTriggerBindingKind
+	}
+	if seen[ref] {
+		return
+	}
+	seen[ref] = true
+	spec, err := getter(ref.Kind, namespace, ref.Name)
+	if err != nil {
+		return
+	}
+	for _, include := range spec.Includes {
+		if include.Kind == "" {
+			include.Kind = ref.Kind
+		}
+		collectBindingParams(getter, namespace, include, seen, params)
+	}
+	for _, p := range spec.Params {
+		params[p.Name] = true
+	}
+}
+
+// validatePin validates the generation and version that a reference to a
+// binding or TriggerTemplate is pinned to.
+func validatePin(generation int64, version string) *apis.FieldError {
+	if generation < 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("generation must not be negative"), "generation")
+	}
+	if errs := validation.IsValidLabelValue(version); len(errs) > 0 {
+		return apis.ErrInvalidValue(fmt.Errorf("version must be a valid label value: %s", strings.Join(errs, ", ")), "version")
 	}
 	return nil
 }
@@ -53,6 +484,9 @@ func (t *EventListenerTrigger) validate(ctx context.Context) *apis.FieldError {
 		if b.Kind != NamespacedTriggerBindingKind && b.Kind != ClusterTriggerBindingKind {
 			return apis.ErrInvalidValue(fmt.Errorf("invalid kind"), fmt.Sprintf("bindings[%d].kind", i))
 		}
+		if err := validatePin(b.Generation, b.Version).ViaField(fmt.Sprintf("bindings[%d]", i)); err != nil {
+			return err
+		}
 	}
 	// Validate required TriggerTemplate
 	// Optional explicit match
@@ -64,6 +498,9 @@ func (t *EventListenerTrigger) validate(ctx context.Context) *apis.FieldError {
 	if t.Template.Name == "" {
 		return apis.ErrMissingField("template.name")
 	}
+	if err := validatePin(t.Template.Generation, t.Template.Version).ViaField("template"); err != nil {
+		return err
+	}
 	for i, interceptor := range t.Interceptors {
 		if err := interceptor.validate(ctx).ViaField(fmt.Sprintf("interceptors[%d]", i)); err != nil {
 			return err
@@ -76,11 +513,195 @@ func (t *EventListenerTrigger) validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrInvalidValue(fmt.Sprintf("trigger name '%s' must be a valid label value", t.Name), "name")
 	}
 
+	if t.Capture != nil {
+		if err := t.Capture.validate().ViaField("capture"); err != nil {
+			return err
+		}
+	}
+
+	if t.MaxEventAge != nil {
+		if err := t.MaxEventAge.validate().ViaField("maxEventAge"); err != nil {
+			return err
+		}
+	}
+
+	for i, m := range t.MatchHeaders {
+		if err := m.validate().ViaFieldIndex("matchHeaders", i); err != nil {
+			return err
+		}
+	}
+
+	if sa := t.InterceptorServiceAccount; sa != nil {
+		if sa.Name == "" {
+			return apis.ErrMissingField("interceptorServiceAccount.name")
+		}
+		if sa.Namespace == "" {
+			return apis.ErrMissingField("interceptorServiceAccount.namespace")
+		}
+	}
+
+	switch t.OnMissingField {
+	case "", MissingFieldFail, MissingFieldEmpty, MissingFieldDefault:
+	default:
+		return apis.ErrInvalidValue(t.OnMissingField, "onMissingField")
+	}
+
+	switch t.OnBindingConflict {
+	case "", BindingConflictFail, BindingConflictLastWins:
+	default:
+		return apis.ErrInvalidValue(t.OnBindingConflict, "onBindingConflict")
+	}
+
+	if t.PhaseTimeouts != nil {
+		if err := t.PhaseTimeouts.validate().ViaField("phaseTimeouts"); err != nil {
+			return err
+		}
+	}
+
+	if t.Cluster != nil {
+		if err := t.Cluster.validate().ViaField("cluster"); err != nil {
+			return err
+		}
+		// A captured ConfigMap is created on the cluster of the
+		// EventListener, where the resources cannot mount it
+		if t.Capture != nil && t.Capture.Target != CaptureAnnotations {
+			return apis.ErrGeneric("a trigger with a cluster can only capture to annotations", "capture.target", "cluster")
+		}
+	}
+
+	if t.InterceptorResources != nil {
+		if len(t.InterceptorResources.Kinds) == 0 {
+			return apis.ErrMissingField("interceptorResources.kinds")
+		}
+		for i, kind := range t.InterceptorResources.Kinds {
+			if kind == "" {
+				return apis.ErrInvalidValue(kind, fmt.Sprintf("interceptorResources.kinds[%d]", i))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *RemoteCluster) validate() *apis.FieldError {
+	if c.KubeconfigSecret.SecretName == "" {
+		return apis.ErrMissingField("kubeconfigSecret.secretName")
+	}
+	// The Secret is read from the namespace of the Trigger, so that a
+	// Trigger cannot use the credentials of other namespaces
+	if c.KubeconfigSecret.Namespace != "" {
+		return apis.ErrDisallowedFields("kubeconfigSecret.namespace")
+	}
+	if c.Namespace != "" {
+		if errs := validation.IsDNS1123Label(c.Namespace); len(errs) > 0 {
+			return apis.ErrInvalidValue(fmt.Errorf("namespace must be a valid namespace name: %s", strings.Join(errs, ", ")), "namespace")
+		}
+	}
+	return nil
+}
+
+func (c *EventCapture) validate() *apis.FieldError {
+	if len(c.Headers) == 0 && len(c.Body) == 0 {
+		return apis.ErrMissingOneOf("headers", "body")
+	}
+	var validKey func(string) []string
+	switch c.Target {
+	case "", CaptureConfigMap:
+		validKey = validation.IsConfigMapKey
+	case CaptureAnnotations:
+		validKey = func(key string) []string {
+			return validation.IsQualifiedName(GroupName + "/" + key)
+		}
+	default:
+		return apis.ErrInvalidValue(c.Target, "target")
+	}
+	for i, h := range c.Headers {
+		if errs := validKey("header." + h); len(errs) > 0 {
+			return apis.ErrInvalidArrayValue(h, "headers", i)
+		}
+	}
+	for i, b := range c.Body {
+		if errs := validKey("body." + b); len(errs) > 0 {
+			return apis.ErrInvalidArrayValue(b, "body", i)
+		}
+	}
+	return nil
+}
+
+func (a *EventAge) validate() *apis.FieldError {
+	if a.MaxAge.Duration <= 0 {
+		return apis.ErrInvalidValue(a.MaxAge.Duration.String(), "maxAge")
+	}
+	if a.Header == "" && a.Body == "" {
+		return apis.ErrMissingOneOf("header", "body")
+	}
+	if a.Header != "" && a.Body != "" {
+		return apis.ErrMultipleOneOf("header", "body")
+	}
+	return nil
+}
+
+// validatePreviousSecretRef checks that the secret kept during a rotation
+// is complete and only set alongside the current secret.
+func validatePreviousSecretRef(current, previous *SecretRef) *apis.FieldError {
+	if previous == nil {
+		return nil
+	}
+	if current == nil {
+		return apis.ErrMissingField("secretRef")
+	}
+	if previous.SecretName == "" || previous.SecretKey == "" {
+		return apis.ErrMissingField("previousSecretRef")
+	}
+	return nil
+}
+
+func (m *HeaderMatch) validate() *apis.FieldError {
+	if m.Name == "" {
+		return apis.ErrMissingField("name")
+	}
+	if len(m.Values) == 0 && len(m.Prefixes) == 0 {
+		return apis.ErrMissingOneOf("values", "prefixes")
+	}
+	for i, p := range m.Prefixes {
+		if p == "" {
+			return apis.ErrInvalidValue("empty prefix", fmt.Sprintf("prefixes[%d]", i))
+		}
+	}
+	return nil
+}
+
+func (c *CaptchaInterceptor) validate() *apis.FieldError {
+	switch c.Provider {
+	case CaptchaRecaptcha, CaptchaTurnstile, CaptchaHCaptcha:
+	case "":
+		return apis.ErrMissingField("provider")
+	default:
+		return apis.ErrInvalidValue(fmt.Errorf("provider must be recaptcha, turnstile or hcaptcha"), "provider")
+	}
+	if c.SecretRef == nil || c.SecretRef.SecretName == "" || c.SecretRef.SecretKey == "" {
+		return apis.ErrMissingField("secretRef")
+	}
+	if c.MinScore != "" {
+		score, err := strconv.ParseFloat(c.MinScore, 64)
+		if err != nil || score < 0 || score > 1 {
+			return apis.ErrInvalidValue(fmt.Errorf("minScore must be between 0.0 and 1.0"), "minScore")
+		}
+		// Only reCAPTCHA v3 scores tokens
+		if c.Provider != CaptchaRecaptcha {
+			return apis.ErrInvalidValue(fmt.Errorf("minScore requires the recaptcha provider"), "minScore")
+		}
+	}
+	if c.VerifyURL != "" {
+		if u, err := url.Parse(c.VerifyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return apis.ErrInvalidValue(fmt.Errorf("verifyURL must be an http or https URL"), "verifyURL")
+		}
+	}
 	return nil
 }
 
 func (i *EventInterceptor) validate(ctx context.Context) *apis.FieldError {
-	if i.Webhook == nil && i.GitHub == nil && i.GitLab == nil && i.CEL == nil {
+	if i.Webhook == nil && i.GitHub == nil && i.GitLab == nil && i.CEL == nil && i.Sentry == nil && i.Bitbucket == nil && i.Alert == nil && i.OPA == nil && i.Scanner == nil && i.SupplyChain == nil && i.Captcha == nil && i.Ref == nil && i.Chain == nil {
 		return apis.ErrMissingField("interceptor")
 	}
 
@@ -95,9 +716,59 @@ func (i *EventInterceptor) validate(ctx context.Context) *apis.FieldError {
 	if i.GitLab != nil {
 		numSet++
 	}
+	if i.Sentry != nil {
+		numSet++
+	}
+	if i.Bitbucket != nil {
+		numSet++
+	}
+	if i.Alert != nil {
+		numSet++
+	}
+	if i.OPA != nil {
+		numSet++
+	}
+	if i.Scanner != nil {
+		numSet++
+	}
+	if i.SupplyChain != nil {
+		numSet++
+	}
+	if i.Captcha != nil {
+		numSet++
+	}
+	if i.Ref != nil {
+		numSet++
+	}
+	if i.Chain != nil {
+		numSet++
+	}
 
 	if numSet > 1 {
-		return apis.ErrMultipleOneOf("interceptor.webhook", "interceptor.github", "interceptor.gitlab")
+		return apis.ErrMultipleOneOf("interceptor.webhook", "interceptor.github", "interceptor.gitlab", "interceptor.sentry", "interceptor.bitbucket", "interceptor.alert", "interceptor.opa", "interceptor.scanner", "interceptor.supplyChain", "interceptor.captcha", "interceptor.ref", "interceptor.chain")
+	}
+	if i.Chain != nil && i.Chain.Name == "" {
+		return apis.ErrMissingField("interceptor.chain.name")
+	}
+
+	if i.Ref == nil && len(i.Params) > 0 {
+		return apis.ErrDisallowedFields("interceptor.params")
+	}
+	if i.Ref != nil {
+		if i.Ref.Name == "" {
+			return apis.ErrMissingField("interceptor.ref.name")
+		}
+		// Params can only be checked when the ClusterInterceptor can be looked
+		// up, i.e. in the validating webhook.
+		if getter := getClusterInterceptorGetter(ctx); getter != nil {
+			ci, err := getter(i.Ref.Name)
+			if err != nil {
+				return apis.ErrInvalidValue(err, "interceptor.ref.name")
+			}
+			if err := ci.ValidateParams(i.Params).ViaField("interceptor.params"); err != nil {
+				return err
+			}
+		}
 	}
 
 	if i.Webhook != nil {
@@ -128,17 +799,150 @@ func (i *EventInterceptor) validate(ctx context.Context) *apis.FieldError {
 				return apis.ErrInvalidValue(fmt.Errorf("invalid header value"), fmt.Sprintf("interceptor.webhook.header[%d].value", i))
 			}
 		}
+		if w.Retries != nil && (*w.Retries < 0 || *w.Retries > 10) {
+			return apis.ErrOutOfBoundsValue(*w.Retries, 0, 10, "interceptor.webhook.retries")
+		}
+		if w.RetryBackoff != "" {
+			if d, err := time.ParseDuration(w.RetryBackoff); err != nil || d <= 0 {
+				return apis.ErrInvalidValue(w.RetryBackoff, "interceptor.webhook.retryBackoff")
+			}
+		}
+	}
+
+	if i.GitHub != nil {
+		if err := validatePreviousSecretRef(i.GitHub.SecretRef, i.GitHub.PreviousSecretRef).ViaField("interceptor.github"); err != nil {
+			return err
+		}
+		if err := i.GitHub.Tags.validate().ViaField("interceptor.github.tags"); err != nil {
+			return err
+		}
+		if err := i.GitHub.Ignore.validate().ViaField("interceptor.github.ignore"); err != nil {
+			return err
+		}
+		for j, action := range i.GitHub.ReleaseActions {
+			switch action {
+			case "published", "unpublished", "created", "edited", "deleted", "prereleased", "released":
+			default:
+				return apis.ErrInvalidValue(fmt.Errorf("invalid release action %s", action), fmt.Sprintf("interceptor.github.releaseActions[%d]", j))
+			}
+		}
+		for j, target := range i.GitHub.InstallationTargets {
+			if target.Type == "" {
+				return apis.ErrMissingField(fmt.Sprintf("interceptor.github.installationTargets[%d].type", j))
+			}
+		}
+	}
+
+	if i.GitLab != nil {
+		if err := validatePreviousSecretRef(i.GitLab.SecretRef, i.GitLab.PreviousSecretRef).ViaField("interceptor.gitlab"); err != nil {
+			return err
+		}
+		for j, status := range i.GitLab.Statuses {
+			switch status {
+			case "created", "waiting_for_resource", "preparing", "pending", "running", "success", "failed", "canceled", "skipped", "manual", "scheduled", "blocked":
+			default:
+				return apis.ErrInvalidValue(fmt.Errorf("invalid status %s", status), fmt.Sprintf("interceptor.gitlab.statuses[%d]", j))
+			}
+		}
+		for j, env := range i.GitLab.Environments {
+			if env == "" {
+				return apis.ErrInvalidValue(fmt.Errorf("environment must not be empty"), fmt.Sprintf("interceptor.gitlab.environments[%d]", j))
+			}
+		}
+		if err := i.GitLab.Ignore.validate().ViaField("interceptor.gitlab.ignore"); err != nil {
+			return err
+		}
+	}
+
+	if i.Sentry != nil && i.Sentry.SecretRef != nil {
+		if i.Sentry.SecretRef.SecretName == "" || i.Sentry.SecretRef.SecretKey == "" {
+			return apis.ErrMissingField("interceptor.sentry.secretRef")
+		}
+	}
+
+	if i.Bitbucket != nil {
+		if i.Bitbucket.SecretRef != nil && (i.Bitbucket.SecretRef.SecretName == "" || i.Bitbucket.SecretRef.SecretKey == "") {
+			return apis.ErrMissingField("interceptor.bitbucket.secretRef")
+		}
+		if err := validatePreviousSecretRef(i.Bitbucket.SecretRef, i.Bitbucket.PreviousSecretRef).ViaField("interceptor.bitbucket"); err != nil {
+			return err
+		}
+		for j, cidr := range i.Bitbucket.SourceRanges {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return apis.ErrInvalidValue(err, fmt.Sprintf("interceptor.bitbucket.sourceRanges[%d]", j))
+			}
+		}
+		for j, state := range i.Bitbucket.ReviewerStates {
+			switch state {
+			case "APPROVED", "UNAPPROVED", "NEEDS_WORK":
+			default:
+				return apis.ErrInvalidValue(fmt.Errorf("invalid reviewer state %s", state), fmt.Sprintf("interceptor.bitbucket.reviewerStates[%d]", j))
+			}
+		}
+		if err := i.Bitbucket.Tags.validate().ViaField("interceptor.bitbucket.tags"); err != nil {
+			return err
+		}
+		if err := i.Bitbucket.Ignore.validate().ViaField("interceptor.bitbucket.ignore"); err != nil {
+			return err
+		}
+	}
+
+	if i.Alert != nil && i.Alert.SecretRef != nil {
+		if i.Alert.SecretRef.SecretName == "" || i.Alert.SecretRef.SecretKey == "" {
+			return apis.ErrMissingField("interceptor.alert.secretRef")
+		}
+	}
+
+	if i.Scanner != nil {
+		if i.Scanner.SecretRef != nil && (i.Scanner.SecretRef.SecretName == "" || i.Scanner.SecretRef.SecretKey == "") {
+			return apis.ErrMissingField("interceptor.scanner.secretRef")
+		}
+		switch i.Scanner.MinSeverity {
+		case "", "low", "medium", "high", "critical":
+		default:
+			return apis.ErrInvalidValue(fmt.Errorf("invalid severity %s", i.Scanner.MinSeverity), "interceptor.scanner.minSeverity")
+		}
+	}
+
+	if i.SupplyChain != nil {
+		if i.SupplyChain.SecretRef != nil && (i.SupplyChain.SecretRef.SecretName == "" || i.SupplyChain.SecretRef.SecretKey == "") {
+			return apis.ErrMissingField("interceptor.supplyChain.secretRef")
+		}
+		if i.SupplyChain.PublicKeyRef != nil && (i.SupplyChain.PublicKeyRef.SecretName == "" || i.SupplyChain.PublicKeyRef.SecretKey == "") {
+			return apis.ErrMissingField("interceptor.supplyChain.publicKeyRef")
+		}
+		for j, t := range i.SupplyChain.EventTypes {
+			if !strings.HasPrefix(t, "dev.cdevents.") {
+				return apis.ErrInvalidValue(fmt.Errorf("CDEvent types start with dev.cdevents."), fmt.Sprintf("interceptor.supplyChain.eventTypes[%d]", j))
+			}
+		}
+		for j, t := range i.SupplyChain.PredicateTypes {
+			if t == "" {
+				return apis.ErrInvalidValue("empty predicate type", fmt.Sprintf("interceptor.supplyChain.predicateTypes[%d]", j))
+			}
+		}
 	}
 
-	// No github validation required yet.
-	// if i.GitHub != nil {
-	//
-	// }
+	if i.Captcha != nil {
+		if err := i.Captcha.validate(); err != nil {
+			return err.ViaField("interceptor.captcha")
+		}
+	}
 
-	// No gitlab validation required yet.
-	// if i.GitLab != nil {
-	//
-	// }
+	if i.OPA != nil {
+		if i.OPA.Server == "" {
+			return apis.ErrMissingField("interceptor.opa.server")
+		}
+		if u, err := url.Parse(i.OPA.Server); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return apis.ErrInvalidValue(fmt.Errorf("server must be an http or https URL"), "interceptor.opa.server")
+		}
+		if strings.Trim(i.OPA.Decision, "/") == "" {
+			return apis.ErrMissingField("interceptor.opa.decision")
+		}
+		if i.OPA.PolicyRef != nil && (i.OPA.PolicyRef.Name == "" || i.OPA.PolicyRef.Key == "") {
+			return apis.ErrMissingField("interceptor.opa.policyRef")
+		}
+	}
 
 	if i.CEL != nil {
 		if i.CEL.Filter == "" && len(i.CEL.Overlays) == 0 {
@@ -18,8 +18,12 @@ package v1alpha1_test
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	bldr "github.com/tektoncd/triggers/test/builder"
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +31,7 @@ import (
 )
 
 func Test_EventListenerValidate(t *testing.T) {
+	var gcOff int32 = -1
 	tests := []struct {
 		name string
 		el   *v1alpha1.EventListener
@@ -37,6 +42,129 @@ func Test_EventListenerValidate(t *testing.T) {
 				bldr.EventListenerTrigger("dne", "v1alpha1",
 					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
 				))),
+	}, {
+		name: "Valid EventListener with external hostname",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerMeta(bldr.Annotation(v1alpha1.ExternalHostnameAnnotation, "hooks.example.com:8443")),
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener in maintenance",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerMaintenance(120),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with event timeout",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerEventTimeout(10),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with phase timeouts",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerPhaseTimeouts(v1alpha1.PhaseTimeouts{InterceptorSeconds: 5, RenderSeconds: 2, CreateSeconds: 10}),
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerPhaseTimeouts(v1alpha1.PhaseTimeouts{InterceptorSeconds: 20}),
+				))),
+	}, {
+		name: "Valid EventListener with namespaceSelector",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerNamespaceSelector(map[string]string{"team": "a"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with SQS queue",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{
+					QueueURL:                 "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+					CredentialsSecret:        "aws-creds",
+					VisibilityTimeoutSeconds: 60,
+					BatchSize:                5,
+					AttributeHeaders: []v1alpha1.AttributeHeader{
+						{Attribute: "type", Header: "X-GitHub-Event"},
+						{Attribute: "sqs:MessageId", Header: "X-GitHub-Delivery"},
+					},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with deduplication",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerDeduplication(v1alpha1.Deduplication{
+					Header:     "X-GitHub-Delivery",
+					Group:      "github-migration",
+					Namespace:  "triggers",
+					TTLSeconds: 600,
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with ConfigMap deduplication",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerDeduplication(v1alpha1.Deduplication{
+					Header:  "X-GitHub-Delivery",
+					Group:   "github-migration",
+					Backend: v1alpha1.DeduplicationConfigMap,
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with runtime tuning",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerRuntime(v1alpha1.SinkRuntime{GoMaxProcs: 2, GCPercent: &gcOff}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with autoscaling",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"}),
+				bldr.EventListenerAvailability(v1alpha1.Availability{Replicas: 2}),
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{MinReplicas: 2, MaxReplicas: 10}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with autoscaling on events in flight",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{
+					MaxReplicas: 10,
+					Prometheus:  &v1alpha1.PrometheusAutoscaling{ServerAddress: "http://prometheus.monitoring.svc:9090"},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with replicas",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerReplicas(3),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with availability",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAvailability(v1alpha1.Availability{
+					Replicas:       3,
+					MaxUnavailable: 2,
+					TopologyKeys:   []string{"kubernetes.io/hostname", "topology.kubernetes.io/zone"},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with admin endpoints",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAdmin(v1alpha1.AdminEndpoints{
+					Port:              9000,
+					TokenSecret:       "admin-token",
+					TLSSecret:         "admin-tls",
+					RequireClientCert: true,
+				}),
+				bldr.EventListenerPrometheusMonitor(v1alpha1.PrometheusMonitor{
+					Labels:           map[string]string{"release": "prometheus"},
+					Interval:         "30s",
+					ClientCertSecret: "prometheus-client",
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
 	}, {
 		name: "Valid EventListener No TriggerBinding",
 		el: bldr.EventListener("name", "namespace",
@@ -82,6 +210,16 @@ func Test_EventListenerValidate(t *testing.T) {
 					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
 					bldr.EventListenerTriggerInterceptor("svc", "v1", "Service", "namespace"),
 				))),
+	}, {
+		name: "Valid EventListener Interceptor With Retries",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerTriggerInterceptor("svc", "v1", "Service", "namespace",
+						bldr.EventInterceptorRetries(3, "500ms"),
+					),
+				))),
 	}, {
 		name: "Valid EventListener Interceptor With Header",
 		el: bldr.EventListener("name", "namespace",
@@ -130,6 +268,200 @@ func Test_EventListenerValidate(t *testing.T) {
 				bldr.EventListenerTrigger("tt", "v1alpha1",
 					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
 				))),
+	}, {
+		name: "Valid EventListener with tag, release and ignore filters",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								Tags:           &v1alpha1.TagFilter{Prefixes: []string{"v"}, SemverRange: ">=1.0.0 <2.0.0"},
+								Ignore:         &v1alpha1.IgnoreFilter{Accounts: []string{"renovate-bot"}, SkipCI: true},
+								ReleaseActions: []string{"published", "prereleased"},
+							},
+						})
+					}))),
+	}, {
+		name: "Valid EventListener with installation targets",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								InstallationTargets: []v1alpha1.GitHubInstallationTarget{{Type: "integration", ID: "12345"}, {Type: "repository"}},
+							},
+						})
+					}))),
+	}, {
+		name: "Valid EventListener with previous secrets",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitLab: &v1alpha1.GitLabInterceptor{
+								SecretRef:         &v1alpha1.SecretRef{SecretName: "new", SecretKey: "token"},
+								PreviousSecretRef: &v1alpha1.SecretRef{SecretName: "old", SecretKey: "token"},
+							},
+						})
+					}))),
+	}, {
+		name: "Valid EventListener with GitLab status and environment filters",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitLab: &v1alpha1.GitLabInterceptor{
+								EventTypes:   []string{"Pipeline Hook", "Deployment Hook"},
+								Statuses:     []string{"success", "failed"},
+								Environments: []string{"production"},
+							},
+						})
+					}))),
+	}, {
+		name: "Valid EventListener with header matches",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMatchHeader(v1alpha1.HeaderMatch{Name: "X-GitHub-Event", Values: []string{"push"}}),
+					bldr.EventListenerTriggerMatchHeader(v1alpha1.HeaderMatch{Name: "X-Event-Key", Prefixes: []string{"pr:"}}),
+				))),
+	}, {
+		name: "Valid EventListener with missing field policy",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerOnMissingField(v1alpha1.MissingFieldDefault),
+				))),
+	}, {
+		name: "Valid EventListener with warm-up",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{Template: v1alpha1.EventListenerTemplate{Name: "tt"}}},
+				WarmUp:   v1alpha1.WarmUpFail,
+			},
+		},
+	}, {
+		name: "Valid EventListener with TLS policy",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{Template: v1alpha1.EventListenerTemplate{Name: "tt"}}},
+				TLSPolicy: &v1alpha1.TLSPolicy{
+					MinVersion:       "VersionTLS12",
+					CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+					CurvePreferences: []string{"X25519"},
+				},
+			},
+		},
+	}, {
+		name: "Valid EventListener with auth failure block",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAuthFailureBlock(v1alpha1.AuthFailureBlock{MaxFailures: 10, WindowSeconds: 60, BlockSeconds: 300}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with manual triggering",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerManual("manual-token", "token"),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with trusted senders",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrustedSenders(v1alpha1.TrustedSenders{
+					CommonNames:  []string{"build-events"},
+					SourceRanges: []string{"10.0.0.0/8"},
+					TokenSecret:  &v1alpha1.SecretRef{SecretName: "sender-token", SecretKey: "token"},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener on OpenShift",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerOpenShift(v1alpha1.OpenShift{
+					ServingCert:    true,
+					Route:          &v1alpha1.OpenShiftRoute{Host: "hooks.apps.example.com"},
+					TrustServiceCA: true,
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with a TLS Secret",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTLSSecret("listener-tls"),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with a callback",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerCallback(v1alpha1.EventCallback{
+					URLField:     "callback.url",
+					AllowedHosts: []string{"ci.example.com", "*.hooks.example.com"},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Valid EventListener with a remote cluster",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCluster(v1alpha1.RemoteCluster{
+						KubeconfigSecret: v1alpha1.SecretRef{SecretName: "workload", SecretKey: "config"},
+						Namespace:        "workloads",
+					}),
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Capture = &v1alpha1.EventCapture{Headers: []string{"X-GitHub-Delivery"}, Target: v1alpha1.CaptureAnnotations}
+					}))),
+	}, {
+		name: "Valid EventListener with onBindingConflict",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerOnBindingConflict(v1alpha1.BindingConflictLastWins),
+				))),
+	}, {
+		name: "Valid EventListener with interceptor ServiceAccount",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerInterceptorServiceAccount("team-a", "namespace"),
+					bldr.EventListenerCELInterceptor("true"),
+				))),
+	}, {
+		name: "Valid EventListener with event capture",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCapture(v1alpha1.EventCapture{
+						Headers: []string{"X-GitHub-Event"},
+						Body:    []string{"head_commit.message", "repository"},
+					}),
+				))),
+	}, {
+		name: "Valid EventListener with event capture in annotations",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCapture(v1alpha1.EventCapture{
+						Headers: []string{"X-GitHub-Event"},
+						Target:  v1alpha1.CaptureAnnotations,
+					}),
+				))),
+	}, {
+		name: "Valid EventListener with max event age",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMaxEventAge(v1alpha1.EventAge{
+						MaxAge: metav1.Duration{Duration: time.Hour},
+						Body:   "head_commit.timestamp",
+					}),
+				))),
 	}, {
 		name: "Valid EventListener with CEL overlays",
 		el: bldr.EventListener("name", "namespace",
@@ -138,6 +470,93 @@ func Test_EventListenerValidate(t *testing.T) {
 					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
 					bldr.EventListenerCELInterceptor("", bldr.EventListenerCELOverlay("body.value", "'testing'")),
 				))),
+	}, {
+		name: "Valid EventListener with ClusterInterceptor",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerClusterInterceptor("github", bldr.EventInterceptorRefParam("secretName", `"github-secret"`)),
+				))),
+	}, {
+		name: "Valid EventListener with scanner interceptor",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Scanner: &v1alpha1.ScannerInterceptor{
+								SecretRef:   &v1alpha1.SecretRef{SecretName: "scanner", SecretKey: "token"},
+								MinSeverity: "high",
+							},
+						})
+					},
+				))),
+	}, {
+		name: "Valid EventListener with supply chain interceptor",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							SupplyChain: &v1alpha1.SupplyChainInterceptor{
+								SecretRef:      &v1alpha1.SecretRef{SecretName: "supplychain", SecretKey: "token"},
+								PublicKeyRef:   &v1alpha1.SecretRef{SecretName: "supplychain", SecretKey: "cosign.pub"},
+								EventTypes:     []string{"dev.cdevents.artifact.published"},
+								PredicateTypes: []string{"https://slsa.dev/provenance/v1"},
+							},
+						})
+					},
+				))),
+	}, {
+		name: "Valid EventListener with captcha interceptor",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Captcha: &v1alpha1.CaptchaInterceptor{
+								Provider:  v1alpha1.CaptchaRecaptcha,
+								SecretRef: &v1alpha1.SecretRef{SecretName: "recaptcha", SecretKey: "secret"},
+								MinScore:  "0.5",
+								Action:    "feedback",
+								Hostnames: []string{"docs.example.com"},
+							},
+						})
+					},
+				))),
+	}, {
+		name: "Valid EventListener with InterceptorChain",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerInterceptorChain("github-checks"),
+					bldr.EventListenerCELInterceptor("body.ref == 'refs/heads/main'"),
+				))),
+	}, {
+		name: "Valid EventListener with OPA interceptor",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						OPA: &v1alpha1.OPAInterceptor{
+							Server:   "http://opa.opa.svc:8181",
+							Decision: "triggers/allow",
+							PolicyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+								Key:                  "policy.rego",
+							},
+						},
+					}},
+				}},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -151,10 +570,23 @@ func Test_EventListenerValidate(t *testing.T) {
 }
 
 func TestEventListenerValidate_error(t *testing.T) {
+	var gcInvalid int32 = -2
 	tests := []struct {
 		name string
 		el   *v1alpha1.EventListener
 	}{{
+		name: "invalid external hostname",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerMeta(bldr.Annotation(v1alpha1.ExternalHostnameAnnotation, "https://hooks.example.com")),
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "external hostname with invalid port",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerMeta(bldr.Annotation(v1alpha1.ExternalHostnameAnnotation, "hooks.example.com:https")),
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
 		name: "no triggers",
 		el: &v1alpha1.EventListener{
 			ObjectMeta: metav1.ObjectMeta{
@@ -288,6 +720,36 @@ func TestEventListenerValidate_error(t *testing.T) {
 					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
 					bldr.EventListenerTriggerInterceptor("foo", "v1", "Deployment", ""),
 				))),
+	}, {
+		name: "Interceptor Negative Retries",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerTriggerInterceptor("foo", "v1", "Service", "",
+						bldr.EventInterceptorRetries(-1, ""),
+					),
+				))),
+	}, {
+		name: "Interceptor Too Many Retries",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerTriggerInterceptor("foo", "v1", "Service", "",
+						bldr.EventInterceptorRetries(11, ""),
+					),
+				))),
+	}, {
+		name: "Interceptor Invalid Retry Backoff",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerTriggerInterceptor("foo", "v1", "Service", "",
+						bldr.EventInterceptorRetries(2, "soon"),
+					),
+				))),
 	}, {
 		name: "Interceptor Non-Canonical Header",
 		el: bldr.EventListener("name", "namespace",
@@ -337,7 +799,7 @@ func TestEventListenerValidate_error(t *testing.T) {
 			},
 		},
 	}, {
-		name: "CEL interceptor with no filter or overlays",
+		name: "Sentry interceptor with incomplete secretRef",
 		el: &v1alpha1.EventListener{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "name",
@@ -348,34 +810,1043 @@ func TestEventListenerValidate_error(t *testing.T) {
 					Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: v1alpha1.NamespacedTriggerBindingKind}},
 					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
 					Interceptors: []*v1alpha1.EventInterceptor{{
-						CEL: &v1alpha1.CELInterceptor{},
+						Sentry: &v1alpha1.SentryInterceptor{
+							SecretRef: &v1alpha1.SecretRef{SecretName: "sentry"},
+						},
 					}},
 				}},
 			},
 		},
 	}, {
-		name: "Triggers name has invalid label characters",
+		name: "Captcha interceptor without provider",
 		el: bldr.EventListener("name", "namespace",
 			bldr.EventListenerSpec(
 				bldr.EventListenerTrigger("tt", "v1alpha1",
-					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
-					bldr.EventListenerTriggerName("github.com/tektoncd/triggers"),
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Captcha: &v1alpha1.CaptchaInterceptor{
+								SecretRef: &v1alpha1.SecretRef{SecretName: "captcha", SecretKey: "secret"},
+							},
+						})
+					},
 				))),
 	}, {
-		name: "Triggers name is longer than the allowable label value (63 characters)",
+		name: "Captcha interceptor without secretRef",
 		el: bldr.EventListener("name", "namespace",
 			bldr.EventListenerSpec(
 				bldr.EventListenerTrigger("tt", "v1alpha1",
-					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
-					bldr.EventListenerTriggerName("1234567890123456789012345678901234567890123456789012345678901234"),
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Captcha: &v1alpha1.CaptchaInterceptor{Provider: v1alpha1.CaptchaTurnstile},
+						})
+					},
 				))),
-	}}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			err := test.el.Validate(context.Background())
-			if err == nil {
-				t.Errorf("EventListener.Validate() expected error, but get none, EventListener: %v", test.el)
+	}, {
+		name: "Captcha interceptor with minScore out of range",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Captcha: &v1alpha1.CaptchaInterceptor{
+								Provider:  v1alpha1.CaptchaRecaptcha,
+								SecretRef: &v1alpha1.SecretRef{SecretName: "captcha", SecretKey: "secret"},
+								MinScore:  "1.5",
+							},
+						})
+					},
+				))),
+	}, {
+		name: "Captcha interceptor with minScore for turnstile",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Captcha: &v1alpha1.CaptchaInterceptor{
+								Provider:  v1alpha1.CaptchaTurnstile,
+								SecretRef: &v1alpha1.SecretRef{SecretName: "captcha", SecretKey: "secret"},
+								MinScore:  "0.5",
+							},
+						})
+					},
+				))),
+	}, {
+		name: "Bitbucket interceptor with invalid source range",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: v1alpha1.NamespacedTriggerBindingKind}},
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						Bitbucket: &v1alpha1.BitbucketInterceptor{
+							SourceRanges: []string{"10.0.0.0/33"},
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "Bitbucket interceptor with invalid reviewer state",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: v1alpha1.NamespacedTriggerBindingKind}},
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						Bitbucket: &v1alpha1.BitbucketInterceptor{
+							ReviewerStates: []string{"MERGED"},
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "GitHub interceptor with invalid semver range",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								Tags: &v1alpha1.TagFilter{SemverRange: ">=1.x"},
+							},
+						})
+					}))),
+	}, {
+		name: "GitHub interceptor with invalid release action",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								ReleaseActions: []string{"shipped"},
+							},
+						})
+					}))),
+	}, {
+		name: "GitHub interceptor with installation target without type",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								InstallationTargets: []v1alpha1.GitHubInstallationTarget{{ID: "12345"}},
+							},
+						})
+					}))),
+	}, {
+		name: "GitLab interceptor with invalid status",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitLab: &v1alpha1.GitLabInterceptor{
+								Statuses: []string{"succeeded"},
+							},
+						})
+					}))),
+	}, {
+		name: "GitLab interceptor with empty environment",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitLab: &v1alpha1.GitLabInterceptor{
+								Environments: []string{""},
+							},
+						})
+					}))),
+	}, {
+		name: "GitHub interceptor with previous secret but no secret",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								PreviousSecretRef: &v1alpha1.SecretRef{SecretName: "old", SecretKey: "token"},
+							},
+						})
+					}))),
+	}, {
+		name: "Bitbucket interceptor with incomplete previous secret",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Bitbucket: &v1alpha1.BitbucketInterceptor{
+								SecretRef:         &v1alpha1.SecretRef{SecretName: "new", SecretKey: "token"},
+								PreviousSecretRef: &v1alpha1.SecretRef{SecretName: "old"},
+							},
+						})
+					}))),
+	}, {
+		name: "Bitbucket interceptor with empty tag filter",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Bitbucket: &v1alpha1.BitbucketInterceptor{
+								Tags: &v1alpha1.TagFilter{},
+							},
+						})
+					}))),
+	}, {
+		name: "GitLab interceptor with empty ignore filter",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitLab: &v1alpha1.GitLabInterceptor{
+								Ignore: &v1alpha1.IgnoreFilter{},
+							},
+						})
+					}))),
+	}, {
+		name: "GitHub interceptor ignoring an empty account",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							GitHub: &v1alpha1.GitHubInterceptor{
+								Ignore: &v1alpha1.IgnoreFilter{Accounts: []string{""}},
+							},
+						})
+					}))),
+	}, {
+		name: "Header match without name",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMatchHeader(v1alpha1.HeaderMatch{Values: []string{"push"}})))),
+	}, {
+		name: "Header match without values or prefixes",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMatchHeader(v1alpha1.HeaderMatch{Name: "X-GitHub-Event"})))),
+	}, {
+		name: "Interceptor ServiceAccount without namespace",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerInterceptorServiceAccount("team-a", "")))),
+	}, {
+		name: "Invalid missing field policy",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerOnMissingField("ignore")))),
+	}, {
+		name: "invalid warm-up",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{Template: v1alpha1.EventListenerTemplate{Name: "tt"}}},
+				WarmUp:   "strict",
+			},
+		},
+	}, {
+		name: "invalid TLS policy",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers:  []v1alpha1.EventListenerTrigger{{Template: v1alpha1.EventListenerTemplate{Name: "tt"}}},
+				TLSPolicy: &v1alpha1.TLSPolicy{MinVersion: "VersionTLS14"},
+			},
+		},
+	}, {
+		name: "auth failure block without maxFailures",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAuthFailureBlock(v1alpha1.AuthFailureBlock{}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "negative auth failure block duration",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAuthFailureBlock(v1alpha1.AuthFailureBlock{MaxFailures: 1, BlockSeconds: -1}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "manual triggering without a token secret name",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerManual("", "token"),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "manual triggering without a token secret key",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerManual("manual-token", ""),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "trusted senders without any sender",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrustedSenders(v1alpha1.TrustedSenders{}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "trusted senders with an invalid source range",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrustedSenders(v1alpha1.TrustedSenders{SourceRanges: []string{"10.0.0.1"}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "trusted senders with a token secret in another namespace",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrustedSenders(v1alpha1.TrustedSenders{
+					TokenSecret: &v1alpha1.SecretRef{SecretName: "sender-token", SecretKey: "token", Namespace: "other"},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "OpenShift without any integration",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerOpenShift(v1alpha1.OpenShift{}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "OpenShift route without a serving certificate",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerOpenShift(v1alpha1.OpenShift{Route: &v1alpha1.OpenShiftRoute{}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "OpenShift route with an invalid host",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerOpenShift(v1alpha1.OpenShift{ServingCert: true, Route: &v1alpha1.OpenShiftRoute{Host: "Hooks_Example"}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "TLS Secret with an OpenShift serving certificate",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTLSSecret("listener-tls"),
+				bldr.EventListenerOpenShift(v1alpha1.OpenShift{ServingCert: true}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "callback without a URL field",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerCallback(v1alpha1.EventCallback{AllowedHosts: []string{"ci.example.com"}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "callback without allowed hosts",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerCallback(v1alpha1.EventCallback{URLField: "callback.url"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "callback with an invalid allowed host",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerCallback(v1alpha1.EventCallback{URLField: "callback.url", AllowedHosts: []string{"https://ci.example.com"}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "cluster without a kubeconfig Secret",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCluster(v1alpha1.RemoteCluster{Namespace: "workloads"})))),
+	}, {
+		name: "cluster with a kubeconfig Secret in another namespace",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCluster(v1alpha1.RemoteCluster{
+						KubeconfigSecret: v1alpha1.SecretRef{SecretName: "workload", Namespace: "other"},
+					})))),
+	}, {
+		name: "cluster with an invalid namespace",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCluster(v1alpha1.RemoteCluster{
+						KubeconfigSecret: v1alpha1.SecretRef{SecretName: "workload"},
+						Namespace:        "Workloads",
+					})))),
+	}, {
+		name: "cluster with a captured ConfigMap",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCluster(v1alpha1.RemoteCluster{
+						KubeconfigSecret: v1alpha1.SecretRef{SecretName: "workload"},
+					}),
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Capture = &v1alpha1.EventCapture{Headers: []string{"X-GitHub-Delivery"}}
+					}))),
+	}, {
+		name: "invalid onBindingConflict",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerOnBindingConflict("firstWins")))),
+	}, {
+		name: "ClusterInterceptor ref missing name",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerClusterInterceptor("")))),
+	}, {
+		name: "Params without ClusterInterceptor ref",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerCELInterceptor("body.value == 'test'", bldr.EventInterceptorRefParam("secretName", `"github-secret"`))))),
+	}, {
+		name: "Alert interceptor with incomplete secretRef",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						Alert: &v1alpha1.AlertInterceptor{
+							SecretRef: &v1alpha1.SecretRef{SecretName: "alert-secret"},
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "OPA interceptor without server",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						OPA: &v1alpha1.OPAInterceptor{
+							Decision: "triggers/allow",
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "OPA interceptor with invalid server",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						OPA: &v1alpha1.OPAInterceptor{
+							Server:   "opa.opa.svc:8181",
+							Decision: "triggers/allow",
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "OPA interceptor without decision",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						OPA: &v1alpha1.OPAInterceptor{
+							Server:   "http://opa.opa.svc:8181",
+							Decision: "/",
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "OPA interceptor with incomplete policyRef",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						OPA: &v1alpha1.OPAInterceptor{
+							Server:    "http://opa.opa.svc:8181",
+							Decision:  "triggers/allow",
+							PolicyRef: &corev1.ConfigMapKeySelector{Key: "policy.rego"},
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "Negative maintenance retry after",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerMaintenance(-1),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Negative event timeout",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerEventTimeout(-1),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Event timeout above the maximum",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerEventTimeout(601),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Negative phase timeout",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerPhaseTimeouts(v1alpha1.PhaseTimeouts{CreateSeconds: -1}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Negative Trigger phase timeout",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerPhaseTimeouts(v1alpha1.PhaseTimeouts{InterceptorSeconds: -1}),
+				))),
+	}, {
+		name: "Invalid namespaceSelector",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerNamespaceSelector(map[string]string{"team": "a b"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Event capture without headers or body",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCapture(v1alpha1.EventCapture{}),
+				))),
+	}, {
+		name: "Max event age without a maximum age",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMaxEventAge(v1alpha1.EventAge{
+						Header: "X-Timestamp",
+					}),
+				))),
+	}, {
+		name: "Max event age without a timestamp",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMaxEventAge(v1alpha1.EventAge{
+						MaxAge: metav1.Duration{Duration: time.Hour},
+					}),
+				))),
+	}, {
+		name: "Scanner interceptor with invalid minimum severity",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							Scanner: &v1alpha1.ScannerInterceptor{MinSeverity: "HIGH"},
+						})
+					},
+				))),
+	}, {
+		name: "Supply chain interceptor with public key reference without key",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							SupplyChain: &v1alpha1.SupplyChainInterceptor{
+								PublicKeyRef: &v1alpha1.SecretRef{SecretName: "supplychain"},
+							},
+						})
+					},
+				))),
+	}, {
+		name: "Supply chain interceptor with invalid event type",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							SupplyChain: &v1alpha1.SupplyChainInterceptor{EventTypes: []string{"artifact.published"}},
+						})
+					},
+				))),
+	}, {
+		name: "Supply chain interceptor with empty predicate type",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+							SupplyChain: &v1alpha1.SupplyChainInterceptor{PredicateTypes: []string{""}},
+						})
+					},
+				))),
+	}, {
+		name: "InterceptorChain without a name",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerInterceptorChain(""),
+				))),
+	}, {
+		name: "InterceptorChain and ClusterInterceptor in one interceptor",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerClusterInterceptor("github"),
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.Interceptors[0].Chain = &v1alpha1.InterceptorChainRef{Name: "github-checks"}
+					},
+				))),
+	}, {
+		name: "Interceptor resources without kinds",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					func(t *v1alpha1.EventListenerTrigger) {
+						t.InterceptorResources = &v1alpha1.InterceptorResources{}
+					},
+				))),
+	}, {
+		name: "Max event age with both a header and a body field",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerMaxEventAge(v1alpha1.EventAge{
+						MaxAge: metav1.Duration{Duration: time.Hour},
+						Header: "X-Timestamp",
+						Body:   "head_commit.timestamp",
+					}),
+				))),
+	}, {
+		name: "Event capture with invalid target",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCapture(v1alpha1.EventCapture{
+						Headers: []string{"X-GitHub-Event"},
+						Target:  "Secret",
+					}),
+				))),
+	}, {
+		name: "Event capture of a body field that is not a valid ConfigMap key",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCapture(v1alpha1.EventCapture{
+						Body: []string{"commits[0].id"},
+					}),
+				))),
+	}, {
+		name: "Event capture of a header that is not a valid annotation",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerCapture(v1alpha1.EventCapture{
+						Headers: []string{"X-" + strings.Repeat("a", 60)},
+						Target:  v1alpha1.CaptureAnnotations,
+					}),
+				))),
+	}, {
+		name: "Deduplication without header",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerDeduplication(v1alpha1.Deduplication{Group: "github"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Deduplication group is not a label value",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerDeduplication(v1alpha1.Deduplication{Header: "X-GitHub-Delivery", Group: "github/migration"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Unknown deduplication backend",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerDeduplication(v1alpha1.Deduplication{Header: "X-GitHub-Delivery", Group: "github", Backend: "Redis"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Negative GOMAXPROCS",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerRuntime(v1alpha1.SinkRuntime{GoMaxProcs: -1}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "GC percent below -1",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerRuntime(v1alpha1.SinkRuntime{GCPercent: &gcInvalid}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS queue without URL",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS queue URL is not an HTTP URL",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{QueueURL: "sqs/events"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS batch size too large",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{
+					QueueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+					BatchSize: 11,
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS visibility timeout too large",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{
+					QueueURL:                 "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+					VisibilityTimeoutSeconds: 43201,
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS attribute header without attribute",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{
+					QueueURL:         "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+					AttributeHeaders: []v1alpha1.AttributeHeader{{Header: "X-GitHub-Event"}},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS attribute header with invalid header",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{
+					QueueURL:         "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+					AttributeHeaders: []v1alpha1.AttributeHeader{{Attribute: "type", Header: "X-GitHub Event"}},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "SQS negative visibility timeout",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{
+					QueueURL:                 "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+					VisibilityTimeoutSeconds: -1,
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Autoscaling without a queue source",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{MaxReplicas: 10}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Autoscaling on events in flight without a server address",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{
+					MaxReplicas: 10,
+					Prometheus:  &v1alpha1.PrometheusAutoscaling{TargetEventsInFlight: 5},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Autoscaling on events in flight with a negative target",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{
+					MaxReplicas: 10,
+					Prometheus:  &v1alpha1.PrometheusAutoscaling{ServerAddress: "http://prometheus:9090", TargetEventsInFlight: -1},
+				}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Autoscaling with fewer max than min replicas",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"}),
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{MinReplicas: 3, MaxReplicas: 2}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Autoscaling below the availability replicas",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"}),
+				bldr.EventListenerAvailability(v1alpha1.Availability{Replicas: 3}),
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{MaxReplicas: 10}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "No replicas",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerReplicas(0),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Replicas with availability",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerReplicas(3),
+				bldr.EventListenerAvailability(v1alpha1.Availability{Replicas: 3}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Replicas with autoscaling",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerSQS(v1alpha1.SQSSource{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"}),
+				bldr.EventListenerReplicas(3),
+				bldr.EventListenerAutoscaling(v1alpha1.QueueAutoscaling{MaxReplicas: 10}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Availability with a single replica",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAvailability(v1alpha1.Availability{Replicas: 1}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Availability allowing every replica to be unavailable",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAvailability(v1alpha1.Availability{Replicas: 2, MaxUnavailable: 2}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Availability with an invalid topology key",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAvailability(v1alpha1.Availability{Replicas: 2, TopologyKeys: []string{""}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Admin endpoints without port",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAdmin(v1alpha1.AdminEndpoints{TokenSecret: "admin-token"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Admin endpoints requiring client certificates without TLS",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAdmin(v1alpha1.AdminEndpoints{Port: 9000, RequireClientCert: true}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Prometheus monitor with invalid interval",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerPrometheusMonitor(v1alpha1.PrometheusMonitor{Interval: "1 minute"}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Prometheus monitor with invalid label",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerPrometheusMonitor(v1alpha1.PrometheusMonitor{Labels: map[string]string{"release": "not valid"}}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "Prometheus monitor of admin endpoints requiring client certificates without one",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerAdmin(v1alpha1.AdminEndpoints{Port: 9000, TLSSecret: "admin-tls", RequireClientCert: true}),
+				bldr.EventListenerPrometheusMonitor(v1alpha1.PrometheusMonitor{}),
+				bldr.EventListenerTrigger("tt", "v1alpha1"))),
+	}, {
+		name: "CEL interceptor with no filter or overlays",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: v1alpha1.NamespacedTriggerBindingKind}},
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						CEL: &v1alpha1.CELInterceptor{},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "Binding pinned to a negative generation",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: v1alpha1.NamespacedTriggerBindingKind, Generation: -1}},
+					Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+				}},
+			},
+		},
+	}, {
+		name: "Template pinned to an invalid version",
+		el: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Bindings: []*v1alpha1.EventListenerBinding{{Name: "tb", Kind: v1alpha1.NamespacedTriggerBindingKind}},
+					Template: v1alpha1.EventListenerTemplate{Name: "tt", Version: "v1/2"},
+				}},
+			},
+		},
+	}, {
+		name: "Triggers name has invalid label characters",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerTriggerName("github.com/tektoncd/triggers"),
+				))),
+	}, {
+		name: "Triggers name is longer than the allowable label value (63 characters)",
+		el: bldr.EventListener("name", "namespace",
+			bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerTriggerName("1234567890123456789012345678901234567890123456789012345678901234"),
+				))),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.el.Validate(context.Background())
+			if err == nil {
+				t.Errorf("EventListener.Validate() expected error, but get none, EventListener: %v", test.el)
+			}
+		})
+	}
+}
+
+func Test_EventListenerValidate_bindingConflicts(t *testing.T) {
+	bindings := map[string]*v1alpha1.TriggerBindingSpec{
+		"TriggerBinding/namespace/event": {Params: []v1alpha1.BindingParam{{Param: pipelinev1.Param{Name: "revision"}}, {Param: pipelinev1.Param{Name: "url"}}}},
+		"TriggerBinding/namespace/env":   {Params: []v1alpha1.BindingParam{{Param: pipelinev1.Param{Name: "environment"}}}},
+		"TriggerBinding/namespace/team": {
+			Includes: []v1alpha1.TriggerBindingRef{{Name: "base", Kind: v1alpha1.ClusterTriggerBindingKind}},
+			Params:   []v1alpha1.BindingParam{{Param: pipelinev1.Param{Name: "team"}}},
+		},
+		"ClusterTriggerBinding//base": {Params: []v1alpha1.BindingParam{{Param: pipelinev1.Param{Name: "environment"}}}},
+	}
+	getter := func(kind v1alpha1.TriggerBindingKind, namespace, name string) (*v1alpha1.TriggerBindingSpec, error) {
+		if kind == v1alpha1.ClusterTriggerBindingKind {
+			namespace = ""
+		}
+		spec, ok := bindings[fmt.Sprintf("%s/%s/%s", kind, namespace, name)]
+		if !ok {
+			return nil, fmt.Errorf("%s %s not found", kind, name)
+		}
+		return spec, nil
+	}
+	ctx := v1alpha1.WithTriggerBindingGetter(context.Background(), getter)
+
+	tests := []struct {
+		name     string
+		bindings []string
+		policy   v1alpha1.BindingConflictPolicy
+		want     string
+	}{{
+		name:     "distinct params",
+		bindings: []string{"event", "env"},
+	}, {
+		name:     "conflicting params",
+		bindings: []string{"event", "env", "team"},
+		want:     "invalid value: param environment is set by both env and team; set onBindingConflict to lastWins to use the value of the last binding: spec.triggers[0].bindings",
+	}, {
+		name:     "conflicting params with lastWins",
+		bindings: []string{"event", "env", "team"},
+		policy:   v1alpha1.BindingConflictLastWins,
+	}, {
+		name:     "unknown binding",
+		bindings: []string{"env", "missing"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []bldr.EventListenerTriggerOp
+			for _, b := range tt.bindings {
+				ops = append(ops, bldr.EventListenerTriggerBinding(b, "", "v1alpha1"))
+			}
+			ops = append(ops, bldr.EventListenerTriggerOnBindingConflict(tt.policy))
+			el := bldr.EventListener("name", "namespace", bldr.EventListenerSpec(bldr.EventListenerTrigger("tt", "v1alpha1", ops...)))
+			err := el.Validate(ctx)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("EventListener.Validate() returned error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("EventListener.Validate() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQSSourceValidate_bounds(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/events"
+	tests := []struct {
+		name string
+		sqs  v1alpha1.SQSSource
+		want string
+	}{{
+		name: "defaults",
+		sqs:  v1alpha1.SQSSource{QueueURL: queueURL},
+	}, {
+		name: "maximum values",
+		sqs:  v1alpha1.SQSSource{QueueURL: queueURL, BatchSize: 10, VisibilityTimeoutSeconds: 43200},
+	}, {
+		name: "negative visibility timeout",
+		sqs:  v1alpha1.SQSSource{QueueURL: queueURL, VisibilityTimeoutSeconds: -1},
+		want: "expected 0 <= -1 <= 43200: spec.sqs.visibilityTimeoutSeconds",
+	}, {
+		name: "batch size too large",
+		sqs:  v1alpha1.SQSSource{QueueURL: queueURL, BatchSize: 11},
+		want: "expected 0 <= 11 <= 10: spec.sqs.batchSize",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			el := bldr.EventListener("name", "namespace", bldr.EventListenerSpec(
+				bldr.EventListenerSQS(tt.sqs),
+				bldr.EventListenerTrigger("tt", "v1alpha1")))
+			err := el.Validate(context.Background())
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("EventListener.Validate() returned error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("EventListener.Validate() error = %v, want %q", err, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// skipCIMarkers are the markers of commit messages that ask to skip CI, as
+// GitHub Actions, GitLab CI and most other CI systems recognize them.
+var skipCIMarkers = []string{"[skip ci]", "[ci skip]"}
+
+// IgnoresAccount reports whether the events sent by the account are ignored.
+func (f *IgnoreFilter) IgnoresAccount(account string) bool {
+	if account == "" {
+		return false
+	}
+	for _, a := range f.Accounts {
+		if strings.EqualFold(a, account) {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoresMessage reports whether the commit message or title asks to skip CI
+// and the filter ignores those.
+func (f *IgnoreFilter) IgnoresMessage(message string) bool {
+	if !f.SkipCI {
+		return false
+	}
+	message = strings.ToLower(message)
+	for _, m := range skipCIMarkers {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *IgnoreFilter) validate() *apis.FieldError {
+	if f == nil {
+		return nil
+	}
+	if len(f.Accounts) == 0 && !f.SkipCI {
+		return apis.ErrMissingOneOf("accounts", "skipCI")
+	}
+	for j, a := range f.Accounts {
+		if a == "" {
+			return apis.ErrInvalidValue("empty account", fmt.Sprintf("accounts[%d]", j))
+		}
+	}
+	return nil
+}
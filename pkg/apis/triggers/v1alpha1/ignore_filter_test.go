@@ -0,0 +1,71 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestIgnoreFilter_IgnoresAccount(t *testing.T) {
+	f := IgnoreFilter{Accounts: []string{"dependabot[bot]", "renovate-bot"}}
+	for _, tc := range []struct {
+		account string
+		want    bool
+	}{
+		{account: "dependabot[bot]", want: true},
+		{account: "Renovate-Bot", want: true},
+		{account: "dependabot", want: false},
+		{account: "", want: false},
+	} {
+		if got := f.IgnoresAccount(tc.account); got != tc.want {
+			t.Errorf("IgnoresAccount(%q) = %t, want %t", tc.account, got, tc.want)
+		}
+	}
+}
+
+func TestIgnoreFilter_IgnoresMessage(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		filter  IgnoreFilter
+		message string
+		want    bool
+	}{{
+		name:    "skip ci",
+		filter:  IgnoreFilter{SkipCI: true},
+		message: "Update docs [skip ci]",
+		want:    true,
+	}, {
+		name:    "ci skip in the body",
+		filter:  IgnoreFilter{SkipCI: true},
+		message: "Bump version\n\n[CI SKIP]",
+		want:    true,
+	}, {
+		name:    "no marker",
+		filter:  IgnoreFilter{SkipCI: true},
+		message: "Skip flaky test in ci",
+		want:    false,
+	}, {
+		name:    "markers not ignored",
+		filter:  IgnoreFilter{Accounts: []string{"renovate-bot"}},
+		message: "Update docs [skip ci]",
+		want:    false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.IgnoresMessage(tc.message); got != tc.want {
+				t.Errorf("IgnoresMessage(%q) = %t, want %t", tc.message, got, tc.want)
+			}
+		})
+	}
+}
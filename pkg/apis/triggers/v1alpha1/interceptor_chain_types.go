@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Check that InterceptorChain may be validated and defaulted.
+var _ apis.Validatable = (*InterceptorChain)(nil)
+var _ apis.Defaultable = (*InterceptorChain)(nil)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// InterceptorChain is a reusable, ordered list of interceptors that Triggers
+// in the same namespace can reference by name, e.g. to verify the signature
+// of an event, filter bot users and filter changed paths the same way in
+// every Trigger.
+type InterceptorChain struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the InterceptorChain from the client
+	// +optional
+	Spec InterceptorChainSpec `json:"spec,omitempty"`
+}
+
+// InterceptorChainSpec holds the interceptors of an InterceptorChain.
+type InterceptorChainSpec struct {
+	// Interceptors are run in order, like the interceptors of a Trigger.
+	// They cannot reference other InterceptorChains.
+	Interceptors []*EventInterceptor `json:"interceptors"`
+}
+
+// InterceptorChainRef refers to an InterceptorChain in the namespace of the
+// EventListener.
+type InterceptorChainRef struct {
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterceptorChainList contains a list of InterceptorChain
+type InterceptorChainList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InterceptorChain `json:"items"`
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+// Validate InterceptorChain.
+func (ic *InterceptorChain) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(ic.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if len(ic.Spec.Interceptors) == 0 {
+		return apis.ErrMissingField("spec.interceptors")
+	}
+	for i, interceptor := range ic.Spec.Interceptors {
+		field := fmt.Sprintf("spec.interceptors[%d]", i)
+		if interceptor == nil {
+			return apis.ErrMissingField(field)
+		}
+		// Chains are not nested, so that they cannot form cycles
+		if interceptor.Chain != nil {
+			return apis.ErrDisallowedFields("interceptor.chain").ViaField(field)
+		}
+		if err := interceptor.validate(ctx).ViaField(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_InterceptorChainValidate(t *testing.T) {
+	ic := &v1alpha1.InterceptorChain{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-checks", Namespace: "namespace"},
+		Spec: v1alpha1.InterceptorChainSpec{
+			Interceptors: []*v1alpha1.EventInterceptor{{
+				GitHub: &v1alpha1.GitHubInterceptor{EventTypes: []string{"push"}},
+			}, {
+				CEL: &v1alpha1.CELInterceptor{Filter: "body.sender.type != 'Bot'"},
+			}},
+		},
+	}
+	if err := ic.Validate(context.Background()); err != nil {
+		t.Errorf("InterceptorChain.Validate() returned error: %s", err)
+	}
+}
+
+func Test_InterceptorChainValidate_error(t *testing.T) {
+	tests := []struct {
+		name         string
+		interceptors []*v1alpha1.EventInterceptor
+		want         string
+	}{{
+		name: "no interceptors",
+		want: "missing field(s): spec.interceptors",
+	}, {
+		name:         "empty interceptor",
+		interceptors: []*v1alpha1.EventInterceptor{{}},
+		want:         "missing field(s): spec.interceptors[0].interceptor",
+	}, {
+		name: "nested chain",
+		interceptors: []*v1alpha1.EventInterceptor{{
+			CEL: &v1alpha1.CELInterceptor{Filter: "true"},
+		}, {
+			Chain: &v1alpha1.InterceptorChainRef{Name: "other"},
+		}},
+		want: "must not set the field(s): spec.interceptors[1].interceptor.chain",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ic := &v1alpha1.InterceptorChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "github-checks", Namespace: "namespace"},
+				Spec:       v1alpha1.InterceptorChainSpec{Interceptors: tt.interceptors},
+			}
+			err := ic.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("InterceptorChain.Validate() expected error, got none")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("InterceptorChain.Validate() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -32,8 +32,29 @@ const (
 	// EventIDLabelKey is used as the label identifier for an EventListener event.
 	EventIDLabelKey = "/triggers-eventid"
 
+	// DeliveryIDLabelKey is used as the label identifier for the delivery
+	// of an event by its provider, e.g. the X-GitHub-Delivery header.
+	DeliveryIDLabelKey = "/delivery-id"
+
+	// CorrelationIDLabelKey is used as the label identifier for the ID that
+	// the sender of an event correlates it with.
+	CorrelationIDLabelKey = "/correlation-id"
+
 	// TriggerLabelKey is used as the label identifier for a Trigger
 	TriggerLabelKey = "/trigger"
+
+	// ClusterEventListenerLabelKey is used as the label identifier for the
+	// ClusterEventListener that an EventListener was generated for.
+	ClusterEventListenerLabelKey = "/clustereventlistener"
+
+	// VersionLabelKey is the label of bindings and TriggerTemplates that
+	// the version of a Trigger reference is matched against.
+	VersionLabelKey = "/version"
+
+	// PinnedByLabelKey is the label of the ControllerRevisions that store the
+	// pinned copies of bindings and TriggerTemplates, with the name of the
+	// EventListener that pins them.
+	PinnedByLabelKey = "/pinned-by"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -59,10 +80,18 @@ var (
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ClusterEventListener{},
+		&ClusterEventListenerList{},
+		&ClusterInterceptor{},
+		&ClusterInterceptorList{},
+		&InterceptorChain{},
+		&InterceptorChainList{},
 		&ClusterTriggerBinding{},
 		&ClusterTriggerBindingList{},
 		&EventListener{},
 		&EventListenerList{},
+		&Repository{},
+		&RepositoryList{},
 		&TriggerBinding{},
 		&TriggerBindingList{},
 		&TriggerTemplate{},
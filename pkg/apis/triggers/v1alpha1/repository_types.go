@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// Check that Repository may be validated and defaulted.
+var _ apis.Validatable = (*Repository)(nil)
+var _ apis.Defaultable = (*Repository)(nil)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// Repository registers a webhook on a repository of a Git provider that
+// sends events to an EventListener, and keeps the webhook in sync.
+type Repository struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the Repository from the client
+	// +optional
+	Spec RepositorySpec `json:"spec"`
+
+	// +optional
+	Status RepositoryStatus `json:"status,omitempty"`
+}
+
+// RepositoryProvider is the Git provider that hosts a Repository.
+type RepositoryProvider string
+
+const (
+	// GitHubProvider registers webhooks with the GitHub REST API.
+	GitHubProvider RepositoryProvider = "github"
+	// GitLabProvider registers webhooks with the GitLab REST API.
+	GitLabProvider RepositoryProvider = "gitlab"
+)
+
+// RepositorySpec defines the webhook to register on a repository.
+type RepositorySpec struct {
+	// Provider is the Git provider of the repository, github or gitlab.
+	Provider RepositoryProvider `json:"provider"`
+	// URL is the URL of the repository, e.g.
+	// https://github.com/tektoncd/triggers.
+	URL string `json:"url"`
+	// APIURL is the URL of the REST API of the provider. Defaults to
+	// https://api.github.com for github.com, https://HOST/api/v3 for GitHub
+	// Enterprise and https://HOST/api/v4 for GitLab.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+	// EventListener is the name of the EventListener in the namespace of the
+	// Repository that the webhook sends events to.
+	EventListener string `json:"eventListener"`
+	// WebhookURL is the address that the provider sends events to, e.g. the
+	// URL of an Ingress in front of the EventListener. Defaults to the
+	// address of the EventListener.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+	// Events are the events that the webhook is sent for, e.g. push or
+	// pull_request for GitHub, and push or merge_requests for GitLab.
+	Events []string `json:"events"`
+	// TokenRef references the key of a Secret in the namespace of the
+	// Repository with an API token that can manage the webhooks of the
+	// repository.
+	TokenRef corev1.SecretKeySelector `json:"tokenRef"`
+	// SecretRef references the key of a Secret in the namespace of the
+	// Repository with the secret of the webhook, which the github or gitlab
+	// interceptor validates events with.
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// RepositoryStatus holds the status of the webhook of a Repository.
+type RepositoryStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// WebhookID is the ID of the webhook at the provider.
+	// +optional
+	WebhookID string `json:"webhookID,omitempty"`
+	// WebhookURL is the address that the webhook sends events to.
+	// +optional
+	WebhookURL string `json:"webhookURL,omitempty"`
+	// ConfigHash is a hash of the configuration that the webhook was last
+	// registered with, including its secret, which providers do not return.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RepositoryList contains a list of Repository
+type RepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Repository `json:"items"`
+}
+
+// WebhookRegistered is the ConditionType set on the Repository, which
+// specifies whether its webhook is registered at the provider.
+const WebhookRegistered apis.ConditionType = "WebhookRegistered"
+
+var repositoryCondSet = apis.NewLivingConditionSet(WebhookRegistered)
+
+// GetCondition returns the Condition matching the given type.
+func (rs *RepositoryStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return repositoryCondSet.Manage(rs).GetCondition(t)
+}
+
+// MarkWebhookRegistered marks the webhook as registered and in sync.
+func (rs *RepositoryStatus) MarkWebhookRegistered() {
+	repositoryCondSet.Manage(rs).MarkTrue(WebhookRegistered)
+}
+
+// MarkWebhookFailed marks the webhook as not registered for the reason.
+func (rs *RepositoryStatus) MarkWebhookFailed(reason, messageFormat string, messageA ...interface{}) {
+	repositoryCondSet.Manage(rs).MarkFalse(WebhookRegistered, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// GitLabWebhookEvents are the events that a GitLab webhook can be sent for.
+// Each event is enabled with the <event>_events field of the webhook.
+var GitLabWebhookEvents = []string{
+	"push",
+	"tag_push",
+	"merge_requests",
+	"issues",
+	"confidential_issues",
+	"note",
+	"confidential_note",
+	"job",
+	"pipeline",
+	"wiki_page",
+	"deployment",
+	"releases",
+}
+
+// Validate Repository.
+func (r *Repository) Validate(ctx context.Context) *apis.FieldError {
+	if err := validate.ObjectMetadata(r.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return r.Spec.validate().ViaField("spec")
+}
+
+func (s *RepositorySpec) validate() *apis.FieldError {
+	switch s.Provider {
+	case GitHubProvider, GitLabProvider:
+	case "":
+		return apis.ErrMissingField("provider")
+	default:
+		return apis.ErrInvalidValue(s.Provider, "provider")
+	}
+	if s.URL == "" {
+		return apis.ErrMissingField("url")
+	}
+	if u, err := url.Parse(s.URL); err != nil || !isHTTPURL(u) || len(strings.Split(strings.Trim(u.Path, "/"), "/")) < 2 {
+		return apis.ErrInvalidValue(s.URL, "url")
+	}
+	if s.APIURL != "" {
+		if u, err := url.Parse(s.APIURL); err != nil || !isHTTPURL(u) {
+			return apis.ErrInvalidValue(s.APIURL, "apiURL")
+		}
+	}
+	if s.EventListener == "" {
+		return apis.ErrMissingField("eventListener")
+	}
+	if s.WebhookURL != "" {
+		if u, err := url.Parse(s.WebhookURL); err != nil || !isHTTPURL(u) {
+			return apis.ErrInvalidValue(s.WebhookURL, "webhookURL")
+		}
+	}
+	if len(s.Events) == 0 {
+		return apis.ErrMissingField("events")
+	}
+	for i, e := range s.Events {
+		if e == "" || (s.Provider == GitLabProvider && !contains(GitLabWebhookEvents, e)) {
+			return apis.ErrInvalidValue(fmt.Errorf("invalid event %q", e), fmt.Sprintf("events[%d]", i))
+		}
+	}
+	if err := validateSecretKeySelector(&s.TokenRef).ViaField("tokenRef"); err != nil {
+		return err
+	}
+	if s.SecretRef != nil {
+		if err := validateSecretKeySelector(s.SecretRef).ViaField("secretRef"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSecretKeySelector(s *corev1.SecretKeySelector) *apis.FieldError {
+	if s.Name == "" {
+		return apis.ErrMissingField("name")
+	}
+	if s.Key == "" {
+		return apis.ErrMissingField("key")
+	}
+	return nil
+}
+
+func isHTTPURL(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
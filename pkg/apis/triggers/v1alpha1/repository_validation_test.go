@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func repositorySpec() v1alpha1.RepositorySpec {
+	return v1alpha1.RepositorySpec{
+		Provider:      v1alpha1.GitHubProvider,
+		URL:           "https://github.com/tektoncd/triggers",
+		EventListener: "github-listener",
+		Events:        []string{"push", "pull_request"},
+		TokenRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "github-token"},
+			Key:                  "token",
+		},
+	}
+}
+
+func Test_RepositoryValidate(t *testing.T) {
+	gitlab := repositorySpec()
+	gitlab.Provider = v1alpha1.GitLabProvider
+	gitlab.URL = "https://gitlab.example.com/group/subgroup/project"
+	gitlab.Events = []string{"push", "merge_requests"}
+	gitlab.WebhookURL = "https://hooks.example.com/gitlab"
+	gitlab.SecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "gitlab-secret"},
+		Key:                  "secret",
+	}
+	for _, spec := range []v1alpha1.RepositorySpec{repositorySpec(), gitlab} {
+		r := &v1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: "triggers", Namespace: "namespace"},
+			Spec:       spec,
+		}
+		if err := r.Validate(context.Background()); err != nil {
+			t.Errorf("Repository.Validate() returned error: %s", err)
+		}
+	}
+}
+
+func Test_RepositoryValidate_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*v1alpha1.RepositorySpec)
+		want   string
+	}{{
+		name:   "unknown provider",
+		mutate: func(s *v1alpha1.RepositorySpec) { s.Provider = "svn" },
+		want:   "invalid value: svn: spec.provider",
+	}, {
+		name:   "url without repository",
+		mutate: func(s *v1alpha1.RepositorySpec) { s.URL = "https://github.com/tektoncd" },
+		want:   "invalid value: https://github.com/tektoncd: spec.url",
+	}, {
+		name:   "no eventlistener",
+		mutate: func(s *v1alpha1.RepositorySpec) { s.EventListener = "" },
+		want:   "missing field(s): spec.eventListener",
+	}, {
+		name:   "no events",
+		mutate: func(s *v1alpha1.RepositorySpec) { s.Events = nil },
+		want:   "missing field(s): spec.events",
+	}, {
+		name: "unknown gitlab event",
+		mutate: func(s *v1alpha1.RepositorySpec) {
+			s.Provider = v1alpha1.GitLabProvider
+			s.Events = []string{"pull_request"}
+		},
+		want: `invalid value: invalid event "pull_request": spec.events[0]`,
+	}, {
+		name:   "token without key",
+		mutate: func(s *v1alpha1.RepositorySpec) { s.TokenRef.Key = "" },
+		want:   "missing field(s): spec.tokenRef.key",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := repositorySpec()
+			tt.mutate(&spec)
+			r := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "triggers", Namespace: "namespace"},
+				Spec:       spec,
+			}
+			err := r.Validate(context.Background())
+			if err == nil {
+				t.Fatalf("Repository.Validate() expected error, got none")
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Repository.Validate() error = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rogpeppe/go-internal/semver"
+	"knative.dev/pkg/apis"
+)
+
+// semverConstraint is a single comparison of a semver range, e.g. >=1.2.0.
+type semverConstraint struct {
+	op      string
+	version string
+}
+
+// Match reports whether the tag is allowed by the filter. The SemverRange is
+// expected to be valid.
+func (f *TagFilter) Match(tag string) bool {
+	version := tag
+	if len(f.Prefixes) > 0 {
+		matched := false
+		for _, p := range f.Prefixes {
+			if strings.HasPrefix(tag, p) {
+				version, matched = strings.TrimPrefix(tag, p), true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if f.SemverRange == "" {
+		return true
+	}
+	constraints, err := parseSemverRange(f.SemverRange)
+	if err != nil {
+		return false
+	}
+	version = canonicalVersion(version)
+	if !semver.IsValid(version) {
+		return false
+	}
+	for _, c := range constraints {
+		cmp := semver.Compare(version, c.version)
+		var ok bool
+		switch c.op {
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *TagFilter) validate() *apis.FieldError {
+	if f == nil {
+		return nil
+	}
+	if len(f.Prefixes) == 0 && f.SemverRange == "" {
+		return apis.ErrMissingOneOf("prefixes", "semverRange")
+	}
+	for j, p := range f.Prefixes {
+		if p == "" {
+			return apis.ErrInvalidValue("empty prefix", fmt.Sprintf("prefixes[%d]", j))
+		}
+	}
+	if f.SemverRange != "" {
+		if _, err := parseSemverRange(f.SemverRange); err != nil {
+			return apis.ErrInvalidValue(err, "semverRange")
+		}
+	}
+	return nil
+}
+
+// parseSemverRange parses a list of constraints separated by spaces or
+// commas, which all have to hold.
+func parseSemverRange(r string) ([]semverConstraint, error) {
+	fields := strings.FieldsFunc(r, func(c rune) bool {
+		return c == ' ' || c == ','
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty semver range")
+	}
+	var constraints []semverConstraint
+	for _, f := range fields {
+		c := semverConstraint{}
+		for _, op := range []string{">=", "<=", "!=", ">", "<", "="} {
+			if strings.HasPrefix(f, op) {
+				c.op, f = op, strings.TrimPrefix(f, op)
+				break
+			}
+		}
+		c.version = canonicalVersion(f)
+		if !semver.IsValid(c.version) {
+			return nil, fmt.Errorf("invalid version %q in semver range", f)
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// canonicalVersion adds the leading v the semver package expects.
+func canonicalVersion(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestTagFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter TagFilter
+		tag    string
+		want   bool
+	}{{
+		name:   "prefix",
+		filter: TagFilter{Prefixes: []string{"release-", "v"}},
+		tag:    "v1.2.0",
+		want:   true,
+	}, {
+		name:   "no matching prefix",
+		filter: TagFilter{Prefixes: []string{"release-"}},
+		tag:    "v1.2.0",
+		want:   false,
+	}, {
+		name:   "in range",
+		filter: TagFilter{SemverRange: ">=1.2.0 <2.0.0"},
+		tag:    "v1.10.3",
+		want:   true,
+	}, {
+		name:   "in range without leading v",
+		filter: TagFilter{SemverRange: ">=v1.2.0,<v2.0.0"},
+		tag:    "1.2.0",
+		want:   true,
+	}, {
+		name:   "above range",
+		filter: TagFilter{SemverRange: ">=1.2.0 <2.0.0"},
+		tag:    "v2.0.0",
+		want:   false,
+	}, {
+		name:   "prerelease below release",
+		filter: TagFilter{SemverRange: ">=1.2.0"},
+		tag:    "v1.2.0-rc.1",
+		want:   false,
+	}, {
+		name:   "exact version",
+		filter: TagFilter{SemverRange: "1.2.0"},
+		tag:    "v1.2.0",
+		want:   true,
+	}, {
+		name:   "excluded version",
+		filter: TagFilter{SemverRange: ">1.0.0 !=1.2.0"},
+		tag:    "v1.2.0",
+		want:   false,
+	}, {
+		name:   "range after prefix",
+		filter: TagFilter{Prefixes: []string{"release-"}, SemverRange: ">=1.0.0"},
+		tag:    "release-1.4.0",
+		want:   true,
+	}, {
+		name:   "not a semantic version",
+		filter: TagFilter{SemverRange: ">=1.0.0"},
+		tag:    "nightly",
+		want:   false,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.filter.Match(tc.tag); got != tc.want {
+				t.Errorf("Match(%q) = %v, want %v", tc.tag, got, tc.want)
+			}
+		})
+	}
+}
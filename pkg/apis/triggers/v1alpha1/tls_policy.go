@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// tlsVersions are the names of the TLS versions, as used by the flags of
+// Kubernetes components.
+var tlsVersions = map[string]uint16{
+	"VersionTLS10": tls.VersionTLS10,
+	"VersionTLS11": tls.VersionTLS11,
+	"VersionTLS12": tls.VersionTLS12,
+	"VersionTLS13": tls.VersionTLS13,
+}
+
+var tlsCurves = map[string]tls.CurveID{
+	"X25519": tls.X25519,
+	"P256":   tls.CurveP256,
+	"P384":   tls.CurveP384,
+	"P521":   tls.CurveP521,
+}
+
+// Config returns the TLS configuration of the policy. Fields that are not set
+// keep the Go defaults.
+func (p *TLSPolicy) Config() (*tls.Config, error) {
+	c := &tls.Config{}
+	if p == nil {
+		return c, nil
+	}
+	if p.MinVersion != "" {
+		v, ok := tlsVersions[p.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown TLS version %q", p.MinVersion)
+		}
+		c.MinVersion = v
+	}
+	for _, name := range p.CipherSuites {
+		id, err := tlsCipherSuite(name)
+		if err != nil {
+			return nil, err
+		}
+		c.CipherSuites = append(c.CipherSuites, id)
+	}
+	// HTTP/2 servers reject configurations that list a suite that HTTP/2
+	// prohibits before one that it approves. Go picks suites in its own
+	// order anyway.
+	sort.SliceStable(c.CipherSuites, func(i, j int) bool {
+		return http2Approved(c.CipherSuites[i]) && !http2Approved(c.CipherSuites[j])
+	})
+	for _, name := range p.CurvePreferences {
+		id, ok := tlsCurves[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown curve %q", name)
+		}
+		c.CurvePreferences = append(c.CurvePreferences, id)
+	}
+	return c, nil
+}
+
+// tlsCipherSuite returns the ID of a secure cipher suite of TLS 1.2 or
+// earlier. Go ignores the cipher suites of TLS 1.3 in the configuration.
+func tlsCipherSuite(name string) (uint16, error) {
+	for _, s := range tls.CipherSuites() {
+		if s.Name != name {
+			continue
+		}
+		for _, v := range s.SupportedVersions {
+			if v <= tls.VersionTLS12 {
+				return s.ID, nil
+			}
+		}
+		return 0, fmt.Errorf("cipher suite %s of TLS 1.3 is not configurable", name)
+	}
+	for _, s := range tls.InsecureCipherSuites() {
+		if s.Name == name {
+			return 0, fmt.Errorf("cipher suite %s is insecure", name)
+		}
+	}
+	return 0, fmt.Errorf("unknown cipher suite %q", name)
+}
+
+// http2Approved reports whether HTTP/2 allows a secure cipher suite, i.e.
+// whether it uses ECDHE and an AEAD.
+func http2Approved(id uint16) bool {
+	name := tls.CipherSuiteName(id)
+	return strings.HasPrefix(name, "TLS_ECDHE_") && (strings.Contains(name, "_GCM_") || strings.Contains(name, "_CHACHA20_"))
+}
+
+// Validate returns an error for TLS versions, cipher suites and curves that
+// are unknown, for cipher suites that have no effect with the minimum version,
+// and for cipher suites that HTTP/2 cannot use.
+func (p *TLSPolicy) Validate() *apis.FieldError {
+	if p.MinVersion != "" {
+		if _, ok := tlsVersions[p.MinVersion]; !ok {
+			return apis.ErrInvalidValue(p.MinVersion, "minVersion")
+		}
+	}
+	for i, name := range p.CipherSuites {
+		if _, err := tlsCipherSuite(name); err != nil {
+			return apis.ErrInvalidValue(err, fmt.Sprintf("cipherSuites[%d]", i))
+		}
+	}
+	if len(p.CipherSuites) > 0 {
+		if p.MinVersion == "VersionTLS13" {
+			return apis.ErrInvalidValue(fmt.Errorf("cipher suites are not configurable with VersionTLS13"), "cipherSuites")
+		}
+		// HTTP/2 requires one of them
+		h2 := false
+		for _, name := range p.CipherSuites {
+			h2 = h2 || name == "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256" || name == "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"
+		}
+		if !h2 {
+			return apis.ErrInvalidValue(fmt.Errorf("cipher suites must include TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 or TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, which HTTP/2 requires"), "cipherSuites")
+		}
+	}
+	for i, name := range p.CurvePreferences {
+		if _, ok := tlsCurves[name]; !ok {
+			return apis.ErrInvalidValue(name, fmt.Sprintf("curvePreferences[%d]", i))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTLSPolicy_Config(t *testing.T) {
+	policy := &TLSPolicy{
+		MinVersion:       "VersionTLS12",
+		CipherSuites:     []string{"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		CurvePreferences: []string{"P384", "X25519"},
+	}
+	got, err := policy.Config()
+	if err != nil {
+		t.Fatalf("Config() = %v", err)
+	}
+	if got.MinVersion != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %#x, want %#x", got.MinVersion, tls.VersionTLS12)
+	}
+	// The suites that HTTP/2 approves come first
+	if diff := cmp.Diff([]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA}, got.CipherSuites); diff != "" {
+		t.Errorf("CipherSuites -want,+got: %s", diff)
+	}
+	if diff := cmp.Diff([]tls.CurveID{tls.CurveP384, tls.X25519}, got.CurvePreferences); diff != "" {
+		t.Errorf("CurvePreferences -want,+got: %s", diff)
+	}
+
+	var empty *TLSPolicy
+	if got, err := empty.Config(); err != nil || got.MinVersion != 0 || got.CipherSuites != nil {
+		t.Errorf("Config() of a nil policy = %v, %v, want the Go defaults", got, err)
+	}
+}
+
+func TestTLSPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  TLSPolicy
+		wantErr bool
+	}{{
+		name:   "empty",
+		policy: TLSPolicy{},
+	}, {
+		name:   "TLS 1.3 with curves",
+		policy: TLSPolicy{MinVersion: "VersionTLS13", CurvePreferences: []string{"X25519"}},
+	}, {
+		name:    "unknown version",
+		policy:  TLSPolicy{MinVersion: "TLSv1.2"},
+		wantErr: true,
+	}, {
+		name:    "unknown cipher suite",
+		policy:  TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "ECDHE-RSA-AES128-GCM-SHA256"}},
+		wantErr: true,
+	}, {
+		name:    "insecure cipher suite",
+		policy:  TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_RC4_128_SHA"}},
+		wantErr: true,
+	}, {
+		name:    "TLS 1.3 cipher suite",
+		policy:  TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_AES_128_GCM_SHA256"}},
+		wantErr: true,
+	}, {
+		name:    "cipher suites with TLS 1.3",
+		policy:  TLSPolicy{MinVersion: "VersionTLS13", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		wantErr: true,
+	}, {
+		name:    "no cipher suite for HTTP/2",
+		policy:  TLSPolicy{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+		wantErr: true,
+	}, {
+		name:    "unknown curve",
+		policy:  TLSPolicy{CurvePreferences: []string{"secp256r1"}},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.policy.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
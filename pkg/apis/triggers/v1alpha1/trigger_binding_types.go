@@ -41,7 +41,30 @@ func (tb *TriggerBinding) Copy() TriggerBindingInterface {
 // TriggerBindingSpec defines the desired state of the TriggerBinding.
 type TriggerBindingSpec struct {
 	// Params defines the parameter mapping from the given input event.
-	Params []pipelinev1.Param `json:"params,omitempty"`
+	Params []BindingParam `json:"params,omitempty"`
+	// Includes lists bindings whose params are inherited by this binding.
+	// Params of later includes override those of earlier ones, and Params
+	// override all included params.
+	// +optional
+	Includes []TriggerBindingRef `json:"includes,omitempty"`
+}
+
+// BindingParam is a param of a binding, whose value is evaluated against the
+// event.
+type BindingParam struct {
+	pipelinev1.Param `json:",inline"`
+	// Default is the value of the param if its value refers to a field that
+	// is not in the event.
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
+
+// TriggerBindingRef refers to a TriggerBinding or ClusterTriggerBinding.
+type TriggerBindingRef struct {
+	Name string `json:"name"`
+	// Kind defaults to the kind of the binding the reference is part of.
+	// +optional
+	Kind TriggerBindingKind `json:"kind,omitempty"`
 }
 
 // TriggerBindingStatus defines the observed state of TriggerBinding.
@@ -18,8 +18,8 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"knative.dev/pkg/apis"
 )
 
@@ -33,10 +33,18 @@ func (s *TriggerBindingSpec) Validate(ctx context.Context) *apis.FieldError {
 	if err := validateParams(s.Params); err != nil {
 		return err
 	}
+	for i, inc := range s.Includes {
+		if inc.Name == "" {
+			return apis.ErrMissingField(fmt.Sprintf("spec.includes[%d].name", i))
+		}
+		if inc.Kind != "" && inc.Kind != NamespacedTriggerBindingKind && inc.Kind != ClusterTriggerBindingKind {
+			return apis.ErrInvalidValue(fmt.Errorf("invalid kind"), fmt.Sprintf("spec.includes[%d].kind", i))
+		}
+	}
 	return nil
 }
 
-func validateParams(params []v1beta1.Param) *apis.FieldError {
+func validateParams(params []BindingParam) *apis.FieldError {
 	// Ensure there aren't multiple params with the same name.
 	seen := map[string]struct{}{}
 	for _, param := range params {
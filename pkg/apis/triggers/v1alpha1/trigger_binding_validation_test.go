@@ -47,6 +47,21 @@ func Test_TriggerBindingValidate(t *testing.T) {
 				bldr.TriggerBindingParam("PARAM1", "$(body.input2)"),
 				bldr.TriggerBindingParam("Param1", "$(body.input3)"),
 			)),
+	}, {
+		name: "includes",
+		tb: bldr.TriggerBinding("name", "namespace",
+			bldr.TriggerBindingSpec(
+				bldr.TriggerBindingInclude("base", ""),
+				bldr.TriggerBindingInclude("cluster-base", v1alpha1.ClusterTriggerBindingKind),
+				bldr.TriggerBindingParam("param1", "$(body.input1)"),
+			)),
+	}, {
+		name: "defaults",
+		tb: bldr.TriggerBinding("name", "namespace",
+			bldr.TriggerBindingSpec(
+				bldr.TriggerBindingParamWithDefault("param1", "$(body.input1)", "main"),
+				bldr.TriggerBindingParamWithDefault("param2", "$(body.input2)", ""),
+			)),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,6 +84,18 @@ func Test_TriggerBindingValidate_error(t *testing.T) {
 				bldr.TriggerBindingParam("param1", "$(body.param1)"),
 				bldr.TriggerBindingParam("param3", "$(body.param1)"),
 			)),
+	}, {
+		name: "include without name",
+		tb: bldr.TriggerBinding("name", "namespace",
+			bldr.TriggerBindingSpec(
+				bldr.TriggerBindingInclude("", v1alpha1.NamespacedTriggerBindingKind),
+			)),
+	}, {
+		name: "include with invalid kind",
+		tb: bldr.TriggerBinding("name", "namespace",
+			bldr.TriggerBindingSpec(
+				bldr.TriggerBindingInclude("base", "Binding"),
+			)),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
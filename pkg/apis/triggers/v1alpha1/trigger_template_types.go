@@ -43,10 +43,85 @@ func init() {
 	)
 }
 
+// TemplateEngine is the rendering engine used to resolve resource templates.
+type TemplateEngine string
+
+const (
+	// DefaultTemplateEngine substitutes $(params.NAME) and $(uid) variables.
+	DefaultTemplateEngine TemplateEngine = ""
+	// GoTemplateEngine renders each string in the resource templates as a Go
+	// text/template with a sandboxed set of sprig compatible functions. Strings
+	// that end with a toJson action render to the JSON value.
+	GoTemplateEngine TemplateEngine = "gotemplate"
+)
+
 // TriggerTemplateSpec holds the desired state of TriggerTemplate
 type TriggerTemplateSpec struct {
-	Params            []pipelinev1beta1.ParamSpec `json:"params,omitempty"`
-	ResourceTemplates []TriggerResourceTemplate   `json:"resourcetemplates,omitempty"`
+	Params            []ParamSpec               `json:"params,omitempty"`
+	ResourceTemplates []TriggerResourceTemplate `json:"resourcetemplates,omitempty"`
+	// Engine selects how the resource templates are rendered.
+	// +optional
+	Engine TemplateEngine `json:"engine,omitempty"`
+	// Patches are applied in order to every resource that is rendered from
+	// the resource templates, e.g. to add common labels or a nodeSelector.
+	// +optional
+	Patches []TriggerResourcePatch `json:"patches,omitempty"`
+}
+
+// ParamSpec declares a parameter of a TriggerTemplate.
+type ParamSpec struct {
+	pipelinev1beta1.ParamSpec `json:",inline"`
+	// Sensitive redacts the resolved value of the param from the logs and
+	// errors of the EventListener. The value is still substituted into the
+	// created resources. Params substituted into Secrets are sensitive
+	// regardless.
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Escape selects how the value of the param is escaped where it is
+	// substituted into the resource templates without an escape of its own.
+	// Defaults to jsonEscape.
+	// +optional
+	Escape ParamEscape `json:"escape,omitempty"`
+}
+
+// ParamEscape is the escaping of a param value that is substituted into the
+// resource templates. It can be set for a single substitution with
+// $(params.NAME | ESCAPE).
+type ParamEscape string
+
+const (
+	// JSONEscape escapes the value as the contents of a JSON string, so that
+	// quotes, backslashes and newlines in the value keep the resource valid.
+	JSONEscape ParamEscape = "jsonEscape"
+	// ShellEscape quotes the value as a single word for POSIX shells, e.g.
+	// for use in the script of a Task.
+	ShellEscape ParamEscape = "shellEscape"
+	// YAMLEscape quotes the value as a YAML double-quoted scalar, e.g. for
+	// use in YAML that is embedded in a string.
+	YAMLEscape ParamEscape = "yamlEscape"
+	// RawEscape substitutes the value as it is, e.g. to insert JSON objects
+	// or arrays from the event into the resource.
+	RawEscape ParamEscape = "raw"
+	// Base64Escape encodes the value as base64, e.g. for the data of a
+	// Secret.
+	Base64Escape ParamEscape = "base64"
+)
+
+// ParamEscapes are the supported escapes of param values.
+var ParamEscapes = []ParamEscape{JSONEscape, ShellEscape, YAMLEscape, RawEscape, Base64Escape}
+
+// IsValid returns whether the escape is supported. The empty escape selects
+// the default.
+func (e ParamEscape) IsValid() bool {
+	if e == "" {
+		return true
+	}
+	for _, v := range ParamEscapes {
+		if e == v {
+			return true
+		}
+	}
+	return false
 }
 
 // TriggerResourceTemplate describes a resource to create
@@ -54,6 +129,14 @@ type TriggerResourceTemplate struct {
 	runtime.RawExtension `json:",inline"`
 }
 
+// TriggerResourcePatch is a patch of the resources created by a
+// TriggerTemplate. It is rendered like the resource templates and applied as a
+// strategic merge patch to resources of known types, and as a JSON merge patch
+// to others.
+type TriggerResourcePatch struct {
+	runtime.RawExtension `json:",inline"`
+}
+
 // TriggerTemplateStatus describes the desired state of TriggerTemplate
 type TriggerTemplateStatus struct{}
 
@@ -18,19 +18,20 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 
-	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 )
 
-// paramsRegexp captures TriggerTemplate parameter names $(params.NAME)
-var paramsRegexp = regexp.MustCompile(`\$\(params.(?P<var>[_a-zA-Z][_a-zA-Z0-9.-]*)\)`)
+// ParamsRegexp captures TriggerTemplate parameter names and optional escapes
+// $(params.NAME) and $(params.NAME | ESCAPE)
+var ParamsRegexp = regexp.MustCompile(`\$\(params\.(?P<var>[_a-zA-Z][_a-zA-Z0-9.-]*)(?:\s*\|\s*(?P<escape>[a-zA-Z][a-zA-Z0-9]*))?\s*\)`)
 
 // Validate validates a TriggerTemplate.
 func (t *TriggerTemplate) Validate(ctx context.Context) *apis.FieldError {
@@ -48,12 +49,53 @@ func (s *TriggerTemplateSpec) validate(ctx context.Context) *apis.FieldError {
 	if len(s.ResourceTemplates) == 0 {
 		return apis.ErrMissingField("resourcetemplates")
 	}
+	switch s.Engine {
+	case DefaultTemplateEngine, GoTemplateEngine:
+	default:
+		return apis.ErrInvalidValue(fmt.Sprintf("unsupported engine %q", s.Engine), "engine")
+	}
 	if err := validateResourceTemplates(s.ResourceTemplates).ViaField("resourcetemplates"); err != nil {
 		return err
 	}
-	if err := verifyParamDeclarations(s.Params, s.ResourceTemplates).ViaField("resourcetemplates"); err != nil {
+	for i, p := range s.Params {
+		if !p.Escape.IsValid() {
+			return apis.ErrInvalidValue(fmt.Sprintf("unsupported escape %q", p.Escape), "escape").ViaFieldIndex("params", i)
+		}
+	}
+	if err := validatePatches(s.Patches).ViaField("patches"); err != nil {
+		return err
+	}
+	templates := make([][]byte, len(s.ResourceTemplates))
+	for i, trt := range s.ResourceTemplates {
+		templates[i] = trt.RawExtension.Raw
+	}
+	if err := verifyParamDeclarations(s.Params, templates).ViaField("resourcetemplates"); err != nil {
 		return err
 	}
+	patches := make([][]byte, len(s.Patches))
+	for i, p := range s.Patches {
+		patches[i] = p.RawExtension.Raw
+	}
+	if err := verifyParamDeclarations(s.Params, patches).ViaField("patches"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validatePatches checks that every patch is an object that does not change
+// the type of the resources it is applied to.
+func validatePatches(patches []TriggerResourcePatch) *apis.FieldError {
+	for i, p := range patches {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(p.RawExtension.Raw, &fields); err != nil || fields == nil {
+			return apis.ErrInvalidValue("patch must be an object", fmt.Sprintf("[%d]", i))
+		}
+		for _, f := range []string{"apiVersion", "kind"} {
+			if _, ok := fields[f]; ok {
+				return apis.ErrDisallowedFields(fmt.Sprintf("[%d].%s", i, f))
+			}
+		}
+	}
 	return nil
 }
 
@@ -85,17 +127,23 @@ func validateResourceTemplates(templates []TriggerResourceTemplate) *apis.FieldE
 	return nil
 }
 
-// Verify every param in the ResourceTemplates is declared with a ParamSpec
-func verifyParamDeclarations(params []pipelinev1.ParamSpec, templates []TriggerResourceTemplate) *apis.FieldError {
+// Verify every param in the templates is declared with a ParamSpec
+func verifyParamDeclarations(params []ParamSpec, templates [][]byte) *apis.FieldError {
 	declaredParamNames := map[string]struct{}{}
 	for _, param := range params {
 		declaredParamNames[param.Name] = struct{}{}
 	}
 	for i, template := range templates {
 		// Get all params in the template $(params.NAME)
-		templateParams := paramsRegexp.FindAllSubmatch(template.RawExtension.Raw, -1)
+		templateParams := ParamsRegexp.FindAllSubmatch(template, -1)
 		for _, templateParam := range templateParams {
 			templateParamName := string(templateParam[1])
+			if escape := ParamEscape(templateParam[2]); !escape.IsValid() {
+				return apis.ErrInvalidValue(
+					fmt.Sprintf("unsupported escape %q in '%s'", escape, templateParam[0]),
+					fmt.Sprintf("[%d]", i),
+				)
+			}
 			if _, ok := declaredParamNames[templateParamName]; !ok {
 				fieldErr := apis.ErrInvalidValue(
 					fmt.Sprintf("undeclared param '$(params.%s)'", templateParamName),
@@ -38,6 +38,12 @@ var v1beta1ResourceTemplate = runtime.RawExtension{
 var paramResourceTemplate = runtime.RawExtension{
 	Raw: []byte(`{"kind":"PipelineRun","apiVersion":"tekton.dev/v1alpha1","metadata":{"creationTimestamp":null},"spec": "$(params.foo)","status":{}}`),
 }
+var escapedParamResourceTemplate = runtime.RawExtension{
+	Raw: []byte(`{"kind":"PipelineRun","apiVersion":"tekton.dev/v1alpha1","metadata":{"creationTimestamp":null},"spec": "$(params.foo | shellEscape)","status":{}}`),
+}
+var unknownEscapeResourceTemplate = runtime.RawExtension{
+	Raw: []byte(`{"kind":"PipelineRun","apiVersion":"tekton.dev/v1alpha1","metadata":{"creationTimestamp":null},"spec": "$(params.foo | htmlEscape)","status":{}}`),
+}
 
 func TestTriggerTemplate_Validate(t *testing.T) {
 	tcs := []struct {
@@ -79,6 +85,21 @@ func TestTriggerTemplate_Validate(t *testing.T) {
 				b.TriggerTemplateParam("foo", "desc", "val"),
 				b.TriggerResourceTemplate(v1beta1ResourceTemplate))),
 			want: nil,
+		}, {
+			name: "valid gotemplate engine",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerTemplateEngine(v1alpha1.GoTemplateEngine),
+				b.TriggerResourceTemplate(simpleResourceTemplate))),
+			want: nil,
+		}, {
+			name: "unsupported engine",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerTemplateEngine("jinja"),
+				b.TriggerResourceTemplate(simpleResourceTemplate))),
+			want: &apis.FieldError{
+				Message: `invalid value: unsupported engine "jinja"`,
+				Paths:   []string{"spec.engine"},
+			},
 		}, {
 			name: "missing resource template",
 			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
@@ -138,6 +159,75 @@ func TestTriggerTemplate_Validate(t *testing.T) {
 				Paths:   []string{"spec.resourcetemplates[0]"},
 				Details: "'$(params.foo)' must be declared in spec.params",
 			},
+		}, {
+			name: "params used in resource template with escape",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerTemplateEscapedParam("foo", "desc", "val", v1alpha1.YAMLEscape),
+				b.TriggerResourceTemplate(escapedParamResourceTemplate))),
+			want: nil,
+		}, {
+			name: "escaped params used in resource template are not declared",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerResourceTemplate(escapedParamResourceTemplate))),
+			want: &apis.FieldError{
+				Message: "invalid value: undeclared param '$(params.foo)'",
+				Paths:   []string{"spec.resourcetemplates[0]"},
+				Details: "'$(params.foo)' must be declared in spec.params",
+			},
+		}, {
+			name: "unsupported escape in resource template",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerTemplateParam("foo", "desc", "val"),
+				b.TriggerResourceTemplate(unknownEscapeResourceTemplate))),
+			want: &apis.FieldError{
+				Message: "invalid value: unsupported escape \"htmlEscape\" in '$(params.foo | htmlEscape)'",
+				Paths:   []string{"spec.resourcetemplates[0]"},
+			},
+		}, {
+			name: "patch with declared params",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerTemplateParam("foo", "desc", "val"),
+				b.TriggerResourceTemplate(simpleResourceTemplate),
+				b.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"foo":"$(params.foo)"}}}`)}))),
+			want: nil,
+		}, {
+			name: "patch with undeclared params",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerResourceTemplate(simpleResourceTemplate),
+				b.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"foo":"$(params.foo)"}}}`)}))),
+			want: &apis.FieldError{
+				Message: "invalid value: undeclared param '$(params.foo)'",
+				Paths:   []string{"spec.patches[0]"},
+				Details: "'$(params.foo)' must be declared in spec.params",
+			},
+		}, {
+			name: "patch is not an object",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerResourceTemplate(simpleResourceTemplate),
+				b.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`["a"]`)}))),
+			want: &apis.FieldError{
+				Message: "invalid value: patch must be an object",
+				Paths:   []string{"spec.patches[0]"},
+			},
+		}, {
+			name: "patch changes the kind",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerResourceTemplate(simpleResourceTemplate),
+				b.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"a":"b"}}}`)}),
+				b.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"kind":"TaskRun"}`)}))),
+			want: &apis.FieldError{
+				Message: "must not set the field(s)",
+				Paths:   []string{"spec.patches[1].kind"},
+			},
+		}, {
+			name: "unsupported escape of param",
+			template: b.TriggerTemplate("tt", "foo", b.TriggerTemplateSpec(
+				b.TriggerTemplateEscapedParam("foo", "desc", "val", "htmlEscape"),
+				b.TriggerResourceTemplate(paramResourceTemplate))),
+			want: &apis.FieldError{
+				Message: "invalid value: unsupported escape \"htmlEscape\"",
+				Paths:   []string{"spec.params[0].escape"},
+			},
 		}}
 
 	for _, tc := range tcs {
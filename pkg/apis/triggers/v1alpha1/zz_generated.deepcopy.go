@@ -22,10 +22,194 @@ package v1alpha1
 
 import (
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminEndpoints) DeepCopyInto(out *AdminEndpoints) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminEndpoints.
+func (in *AdminEndpoints) DeepCopy() *AdminEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertInterceptor) DeepCopyInto(out *AlertInterceptor) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Statuses != nil {
+		in, out := &in.Statuses, &out.Statuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertInterceptor.
+func (in *AlertInterceptor) DeepCopy() *AlertInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttributeHeader) DeepCopyInto(out *AttributeHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttributeHeader.
+func (in *AttributeHeader) DeepCopy() *AttributeHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(AttributeHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthFailureBlock) DeepCopyInto(out *AuthFailureBlock) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthFailureBlock.
+func (in *AuthFailureBlock) DeepCopy() *AuthFailureBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthFailureBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Availability) DeepCopyInto(out *Availability) {
+	*out = *in
+	if in.TopologyKeys != nil {
+		in, out := &in.TopologyKeys, &out.TopologyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Availability.
+func (in *Availability) DeepCopy() *Availability {
+	if in == nil {
+		return nil
+	}
+	out := new(Availability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindingParam) DeepCopyInto(out *BindingParam) {
+	*out = *in
+	in.Param.DeepCopyInto(&out.Param)
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingParam.
+func (in *BindingParam) DeepCopy() *BindingParam {
+	if in == nil {
+		return nil
+	}
+	out := new(BindingParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BitbucketInterceptor) DeepCopyInto(out *BitbucketInterceptor) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.PreviousSecretRef != nil {
+		in, out := &in.PreviousSecretRef, &out.PreviousSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReviewerStates != nil {
+		in, out := &in.ReviewerStates, &out.ReviewerStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(TagFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ignore != nil {
+		in, out := &in.Ignore, &out.Ignore
+		*out = new(IgnoreFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketInterceptor.
+func (in *BitbucketInterceptor) DeepCopy() *BitbucketInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(BitbucketInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CELInterceptor) DeepCopyInto(out *CELInterceptor) {
 	*out = *in
@@ -64,27 +248,1296 @@ func (in *CELOverlay) DeepCopy() *CELOverlay {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTriggerBinding) DeepCopyInto(out *ClusterTriggerBinding) {
+func (in *CaptchaInterceptor) DeepCopyInto(out *CaptchaInterceptor) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CaptchaInterceptor.
+func (in *CaptchaInterceptor) DeepCopy() *CaptchaInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(CaptchaInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientConfig) DeepCopyInto(out *ClientConfig) {
+	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientConfig.
+func (in *ClientConfig) DeepCopy() *ClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEventListener) DeepCopyInto(out *ClusterEventListener) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerBinding.
-func (in *ClusterTriggerBinding) DeepCopy() *ClusterTriggerBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEventListener.
+func (in *ClusterEventListener) DeepCopy() *ClusterEventListener {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTriggerBinding)
+	out := new(ClusterEventListener)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTriggerBinding) DeepCopyObject() runtime.Object {
+func (in *ClusterEventListener) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEventListenerList) DeepCopyInto(out *ClusterEventListenerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterEventListener, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEventListenerList.
+func (in *ClusterEventListenerList) DeepCopy() *ClusterEventListenerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEventListenerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterEventListenerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInterceptor) DeepCopyInto(out *ClusterInterceptor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInterceptor.
+func (in *ClusterInterceptor) DeepCopy() *ClusterInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterInterceptor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInterceptorList) DeepCopyInto(out *ClusterInterceptorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterInterceptor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInterceptorList.
+func (in *ClusterInterceptorList) DeepCopy() *ClusterInterceptorList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInterceptorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterInterceptorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInterceptorSpec) DeepCopyInto(out *ClusterInterceptorSpec) {
+	*out = *in
+	in.ClientConfig.DeepCopyInto(&out.ClientConfig)
+	if in.ParamsSchema != nil {
+		in, out := &in.ParamsSchema, &out.ParamsSchema
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(InterceptorConcurrency)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInterceptorSpec.
+func (in *ClusterInterceptorSpec) DeepCopy() *ClusterInterceptorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInterceptorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTriggerBinding) DeepCopyInto(out *ClusterTriggerBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerBinding.
+func (in *ClusterTriggerBinding) DeepCopy() *ClusterTriggerBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTriggerBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTriggerBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTriggerBindingList) DeepCopyInto(out *ClusterTriggerBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTriggerBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerBindingList.
+func (in *ClusterTriggerBindingList) DeepCopy() *ClusterTriggerBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTriggerBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTriggerBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Deduplication) DeepCopyInto(out *Deduplication) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Deduplication.
+func (in *Deduplication) DeepCopy() *Deduplication {
+	if in == nil {
+		return nil
+	}
+	out := new(Deduplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventAge) DeepCopyInto(out *EventAge) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventAge.
+func (in *EventAge) DeepCopy() *EventAge {
+	if in == nil {
+		return nil
+	}
+	out := new(EventAge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventCallback) DeepCopyInto(out *EventCallback) {
+	*out = *in
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventCallback.
+func (in *EventCallback) DeepCopy() *EventCallback {
+	if in == nil {
+		return nil
+	}
+	out := new(EventCallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventCapture) DeepCopyInto(out *EventCapture) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventCapture.
+func (in *EventCapture) DeepCopy() *EventCapture {
+	if in == nil {
+		return nil
+	}
+	out := new(EventCapture)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventInterceptor) DeepCopyInto(out *EventInterceptor) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(GitLabInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = new(CELInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sentry != nil {
+		in, out := &in.Sentry, &out.Sentry
+		*out = new(SentryInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(BitbucketInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alert != nil {
+		in, out := &in.Alert, &out.Alert
+		*out = new(AlertInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OPA != nil {
+		in, out := &in.OPA, &out.OPA
+		*out = new(OPAInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scanner != nil {
+		in, out := &in.Scanner, &out.Scanner
+		*out = new(ScannerInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SupplyChain != nil {
+		in, out := &in.SupplyChain, &out.SupplyChain
+		*out = new(SupplyChainInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Captcha != nil {
+		in, out := &in.Captcha, &out.Captcha
+		*out = new(CaptchaInterceptor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(InterceptorRef)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]runtime.RawExtension, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Chain != nil {
+		in, out := &in.Chain, &out.Chain
+		*out = new(InterceptorChainRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventInterceptor.
+func (in *EventInterceptor) DeepCopy() *EventInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(EventInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListener) DeepCopyInto(out *EventListener) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListener.
+func (in *EventListener) DeepCopy() *EventListener {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventListener) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerBinding) DeepCopyInto(out *EventListenerBinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerBinding.
+func (in *EventListenerBinding) DeepCopy() *EventListenerBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerConfig) DeepCopyInto(out *EventListenerConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerConfig.
+func (in *EventListenerConfig) DeepCopy() *EventListenerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerList) DeepCopyInto(out *EventListenerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EventListener, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerList.
+func (in *EventListenerList) DeepCopy() *EventListenerList {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventListenerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerSpec) DeepCopyInto(out *EventListenerSpec) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]EventListenerTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceMode)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQS != nil {
+		in, out := &in.SQS, &out.SQS
+		*out = new(SQSSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(QueueAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(AdminEndpoints)
+		**out = **in
+	}
+	if in.PrometheusMonitor != nil {
+		in, out := &in.PrometheusMonitor, &out.PrometheusMonitor
+		*out = new(PrometheusMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(Availability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PhaseTimeouts != nil {
+		in, out := &in.PhaseTimeouts, &out.PhaseTimeouts
+		*out = new(PhaseTimeouts)
+		**out = **in
+	}
+	if in.Deduplication != nil {
+		in, out := &in.Deduplication, &out.Deduplication
+		*out = new(Deduplication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(SinkRuntime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSPolicy != nil {
+		in, out := &in.TLSPolicy, &out.TLSPolicy
+		*out = new(TLSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthFailureBlock != nil {
+		in, out := &in.AuthFailureBlock, &out.AuthFailureBlock
+		*out = new(AuthFailureBlock)
+		**out = **in
+	}
+	if in.Manual != nil {
+		in, out := &in.Manual, &out.Manual
+		*out = new(ManualTriggering)
+		**out = **in
+	}
+	if in.TrustedSenders != nil {
+		in, out := &in.TrustedSenders, &out.TrustedSenders
+		*out = new(TrustedSenders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenShift != nil {
+		in, out := &in.OpenShift, &out.OpenShift
+		*out = new(OpenShift)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Callback != nil {
+		in, out := &in.Callback, &out.Callback
+		*out = new(EventCallback)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerSpec.
+func (in *EventListenerSpec) DeepCopy() *EventListenerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerStatus) DeepCopyInto(out *EventListenerStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	in.AddressStatus.DeepCopyInto(&out.AddressStatus)
+	out.Configuration = in.Configuration
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerStatus.
+func (in *EventListenerStatus) DeepCopy() *EventListenerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerTemplate) DeepCopyInto(out *EventListenerTemplate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerTemplate.
+func (in *EventListenerTemplate) DeepCopy() *EventListenerTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventListenerTrigger) DeepCopyInto(out *EventListenerTrigger) {
+	*out = *in
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]*EventListenerBinding, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(EventListenerBinding)
+				**out = **in
+			}
+		}
+	}
+	out.Template = in.Template
+	if in.Interceptors != nil {
+		in, out := &in.Interceptors, &out.Interceptors
+		*out = make([]*EventInterceptor, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(EventInterceptor)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.InterceptorServiceAccount != nil {
+		in, out := &in.InterceptorServiceAccount, &out.InterceptorServiceAccount
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.Capture != nil {
+		in, out := &in.Capture, &out.Capture
+		*out = new(EventCapture)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxEventAge != nil {
+		in, out := &in.MaxEventAge, &out.MaxEventAge
+		*out = new(EventAge)
+		**out = **in
+	}
+	if in.InterceptorResources != nil {
+		in, out := &in.InterceptorResources, &out.InterceptorResources
+		*out = new(InterceptorResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchHeaders != nil {
+		in, out := &in.MatchHeaders, &out.MatchHeaders
+		*out = make([]HeaderMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PhaseTimeouts != nil {
+		in, out := &in.PhaseTimeouts, &out.PhaseTimeouts
+		*out = new(PhaseTimeouts)
+		**out = **in
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(RemoteCluster)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerTrigger.
+func (in *EventListenerTrigger) DeepCopy() *EventListenerTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(EventListenerTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubInstallationTarget) DeepCopyInto(out *GitHubInstallationTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubInstallationTarget.
+func (in *GitHubInstallationTarget) DeepCopy() *GitHubInstallationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubInstallationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubInterceptor) DeepCopyInto(out *GitHubInterceptor) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousSecretRef != nil {
+		in, out := &in.PreviousSecretRef, &out.PreviousSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(TagFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseActions != nil {
+		in, out := &in.ReleaseActions, &out.ReleaseActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallationTargets != nil {
+		in, out := &in.InstallationTargets, &out.InstallationTargets
+		*out = make([]GitHubInstallationTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ignore != nil {
+		in, out := &in.Ignore, &out.Ignore
+		*out = new(IgnoreFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubInterceptor.
+func (in *GitHubInterceptor) DeepCopy() *GitHubInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabInterceptor) DeepCopyInto(out *GitLabInterceptor) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousSecretRef != nil {
+		in, out := &in.PreviousSecretRef, &out.PreviousSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.Statuses != nil {
+		in, out := &in.Statuses, &out.Statuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ignore != nil {
+		in, out := &in.Ignore, &out.Ignore
+		*out = new(IgnoreFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabInterceptor.
+func (in *GitLabInterceptor) DeepCopy() *GitLabInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderMatch) DeepCopyInto(out *HeaderMatch) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderMatch.
+func (in *HeaderMatch) DeepCopy() *HeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnoreFilter) DeepCopyInto(out *IgnoreFilter) {
+	*out = *in
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnoreFilter.
+func (in *IgnoreFilter) DeepCopy() *IgnoreFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnoreFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorChain) DeepCopyInto(out *InterceptorChain) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorChain.
+func (in *InterceptorChain) DeepCopy() *InterceptorChain {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterceptorChain) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorChainList) DeepCopyInto(out *InterceptorChainList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InterceptorChain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorChainList.
+func (in *InterceptorChainList) DeepCopy() *InterceptorChainList {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorChainList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterceptorChainList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorChainRef) DeepCopyInto(out *InterceptorChainRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorChainRef.
+func (in *InterceptorChainRef) DeepCopy() *InterceptorChainRef {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorChainRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorChainSpec) DeepCopyInto(out *InterceptorChainSpec) {
+	*out = *in
+	if in.Interceptors != nil {
+		in, out := &in.Interceptors, &out.Interceptors
+		*out = make([]*EventInterceptor, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(EventInterceptor)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorChainSpec.
+func (in *InterceptorChainSpec) DeepCopy() *InterceptorChainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorChainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorConcurrency) DeepCopyInto(out *InterceptorConcurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorConcurrency.
+func (in *InterceptorConcurrency) DeepCopy() *InterceptorConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorRef) DeepCopyInto(out *InterceptorRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorRef.
+func (in *InterceptorRef) DeepCopy() *InterceptorRef {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterceptorResources) DeepCopyInto(out *InterceptorResources) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterceptorResources.
+func (in *InterceptorResources) DeepCopy() *InterceptorResources {
+	if in == nil {
+		return nil
+	}
+	out := new(InterceptorResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceMode) DeepCopyInto(out *MaintenanceMode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceMode.
+func (in *MaintenanceMode) DeepCopy() *MaintenanceMode {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualTriggering) DeepCopyInto(out *ManualTriggering) {
+	*out = *in
+	out.TokenSecret = in.TokenSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualTriggering.
+func (in *ManualTriggering) DeepCopy() *ManualTriggering {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualTriggering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OPAInterceptor) DeepCopyInto(out *OPAInterceptor) {
+	*out = *in
+	if in.PolicyRef != nil {
+		in, out := &in.PolicyRef, &out.PolicyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OPAInterceptor.
+func (in *OPAInterceptor) DeepCopy() *OPAInterceptor {
+	if in == nil {
+		return nil
+	}
+	out := new(OPAInterceptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenShift) DeepCopyInto(out *OpenShift) {
+	*out = *in
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(OpenShiftRoute)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenShift.
+func (in *OpenShift) DeepCopy() *OpenShift {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenShift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenShiftRoute) DeepCopyInto(out *OpenShiftRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenShiftRoute.
+func (in *OpenShiftRoute) DeepCopy() *OpenShiftRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenShiftRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
+	*out = *in
+	in.ParamSpec.DeepCopyInto(&out.ParamSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamSpec.
+func (in *ParamSpec) DeepCopy() *ParamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTimeouts) DeepCopyInto(out *PhaseTimeouts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTimeouts.
+func (in *PhaseTimeouts) DeepCopy() *PhaseTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAutoscaling) DeepCopyInto(out *PrometheusAutoscaling) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAutoscaling.
+func (in *PrometheusAutoscaling) DeepCopy() *PrometheusAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMonitor) DeepCopyInto(out *PrometheusMonitor) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMonitor.
+func (in *PrometheusMonitor) DeepCopy() *PrometheusMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueAutoscaling) DeepCopyInto(out *QueueAutoscaling) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusAutoscaling)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueAutoscaling.
+func (in *QueueAutoscaling) DeepCopy() *QueueAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
+	*out = *in
+	out.KubeconfigSecret = in.KubeconfigSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
+func (in *RemoteCluster) DeepCopy() *RemoteCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Repository) DeepCopyInto(out *Repository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Repository.
+func (in *Repository) DeepCopy() *Repository {
+	if in == nil {
+		return nil
+	}
+	out := new(Repository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Repository) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -92,13 +1545,13 @@ func (in *ClusterTriggerBinding) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTriggerBindingList) DeepCopyInto(out *ClusterTriggerBindingList) {
+func (in *RepositoryList) DeepCopyInto(out *RepositoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterTriggerBinding, len(*in))
+		*out = make([]Repository, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -106,18 +1559,18 @@ func (in *ClusterTriggerBindingList) DeepCopyInto(out *ClusterTriggerBindingList
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTriggerBindingList.
-func (in *ClusterTriggerBindingList) DeepCopy() *ClusterTriggerBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryList.
+func (in *RepositoryList) DeepCopy() *RepositoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTriggerBindingList)
+	out := new(RepositoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTriggerBindingList) DeepCopyObject() runtime.Object {
+func (in *RepositoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -125,300 +1578,259 @@ func (in *ClusterTriggerBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventInterceptor) DeepCopyInto(out *EventInterceptor) {
+func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 	*out = *in
-	if in.Webhook != nil {
-		in, out := &in.Webhook, &out.Webhook
-		*out = new(WebhookInterceptor)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GitHub != nil {
-		in, out := &in.GitHub, &out.GitHub
-		*out = new(GitHubInterceptor)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GitLab != nil {
-		in, out := &in.GitLab, &out.GitLab
-		*out = new(GitLabInterceptor)
-		(*in).DeepCopyInto(*out)
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.CEL != nil {
-		in, out := &in.CEL, &out.CEL
-		*out = new(CELInterceptor)
+	in.TokenRef.DeepCopyInto(&out.TokenRef)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventInterceptor.
-func (in *EventInterceptor) DeepCopy() *EventInterceptor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
+func (in *RepositorySpec) DeepCopy() *RepositorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EventInterceptor)
+	out := new(RepositorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListener) DeepCopyInto(out *EventListener) {
+func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListener.
-func (in *EventListener) DeepCopy() *EventListener {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryStatus.
+func (in *RepositoryStatus) DeepCopy() *RepositoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListener)
+	out := new(RepositoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EventListener) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerBinding) DeepCopyInto(out *EventListenerBinding) {
+func (in *SQSSource) DeepCopyInto(out *SQSSource) {
 	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerBinding.
-func (in *EventListenerBinding) DeepCopy() *EventListenerBinding {
-	if in == nil {
-		return nil
+	if in.AttributeHeaders != nil {
+		in, out := &in.AttributeHeaders, &out.AttributeHeaders
+		*out = make([]AttributeHeader, len(*in))
+		copy(*out, *in)
 	}
-	out := new(EventListenerBinding)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerConfig) DeepCopyInto(out *EventListenerConfig) {
-	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerConfig.
-func (in *EventListenerConfig) DeepCopy() *EventListenerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQSSource.
+func (in *SQSSource) DeepCopy() *SQSSource {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListenerConfig)
+	out := new(SQSSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerList) DeepCopyInto(out *EventListenerList) {
+func (in *ScannerInterceptor) DeepCopyInto(out *ScannerInterceptor) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]EventListener, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerList.
-func (in *EventListenerList) DeepCopy() *EventListenerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScannerInterceptor.
+func (in *ScannerInterceptor) DeepCopy() *ScannerInterceptor {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListenerList)
+	out := new(ScannerInterceptor)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EventListenerList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerSpec) DeepCopyInto(out *EventListenerSpec) {
+func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
-	if in.Triggers != nil {
-		in, out := &in.Triggers, &out.Triggers
-		*out = make([]EventListenerTrigger, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerSpec.
-func (in *EventListenerSpec) DeepCopy() *EventListenerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
+func (in *SecretRef) DeepCopy() *SecretRef {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListenerSpec)
+	out := new(SecretRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerStatus) DeepCopyInto(out *EventListenerStatus) {
+func (in *SentryInterceptor) DeepCopyInto(out *SentryInterceptor) {
 	*out = *in
-	in.Status.DeepCopyInto(&out.Status)
-	in.AddressStatus.DeepCopyInto(&out.AddressStatus)
-	out.Configuration = in.Configuration
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.ResourceTypes != nil {
+		in, out := &in.ResourceTypes, &out.ResourceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Levels != nil {
+		in, out := &in.Levels, &out.Levels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerStatus.
-func (in *EventListenerStatus) DeepCopy() *EventListenerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SentryInterceptor.
+func (in *SentryInterceptor) DeepCopy() *SentryInterceptor {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListenerStatus)
+	out := new(SentryInterceptor)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerTemplate) DeepCopyInto(out *EventListenerTemplate) {
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerTemplate.
-func (in *EventListenerTemplate) DeepCopy() *EventListenerTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListenerTemplate)
+	out := new(ServiceReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventListenerTrigger) DeepCopyInto(out *EventListenerTrigger) {
+func (in *SinkRuntime) DeepCopyInto(out *SinkRuntime) {
 	*out = *in
-	if in.Bindings != nil {
-		in, out := &in.Bindings, &out.Bindings
-		*out = make([]*EventListenerBinding, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(EventListenerBinding)
-				**out = **in
-			}
-		}
-	}
-	out.Template = in.Template
-	if in.Interceptors != nil {
-		in, out := &in.Interceptors, &out.Interceptors
-		*out = make([]*EventInterceptor, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(EventInterceptor)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.ServiceAccount != nil {
-		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(v1.ObjectReference)
+	if in.GCPercent != nil {
+		in, out := &in.GCPercent, &out.GCPercent
+		*out = new(int32)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventListenerTrigger.
-func (in *EventListenerTrigger) DeepCopy() *EventListenerTrigger {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SinkRuntime.
+func (in *SinkRuntime) DeepCopy() *SinkRuntime {
 	if in == nil {
 		return nil
 	}
-	out := new(EventListenerTrigger)
+	out := new(SinkRuntime)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubInterceptor) DeepCopyInto(out *GitHubInterceptor) {
+func (in *SupplyChainInterceptor) DeepCopyInto(out *SupplyChainInterceptor) {
 	*out = *in
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
 		*out = new(SecretRef)
 		**out = **in
 	}
+	if in.PublicKeyRef != nil {
+		in, out := &in.PublicKeyRef, &out.PublicKeyRef
+		*out = new(SecretRef)
+		**out = **in
+	}
 	if in.EventTypes != nil {
 		in, out := &in.EventTypes, &out.EventTypes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PredicateTypes != nil {
+		in, out := &in.PredicateTypes, &out.PredicateTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubInterceptor.
-func (in *GitHubInterceptor) DeepCopy() *GitHubInterceptor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupplyChainInterceptor.
+func (in *SupplyChainInterceptor) DeepCopy() *SupplyChainInterceptor {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubInterceptor)
+	out := new(SupplyChainInterceptor)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitLabInterceptor) DeepCopyInto(out *GitLabInterceptor) {
+func (in *TLSPolicy) DeepCopyInto(out *TLSPolicy) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(SecretRef)
-		**out = **in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.EventTypes != nil {
-		in, out := &in.EventTypes, &out.EventTypes
+	if in.CurvePreferences != nil {
+		in, out := &in.CurvePreferences, &out.CurvePreferences
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabInterceptor.
-func (in *GitLabInterceptor) DeepCopy() *GitLabInterceptor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSPolicy.
+func (in *TLSPolicy) DeepCopy() *TLSPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(GitLabInterceptor)
+	out := new(TLSPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+func (in *TagFilter) DeepCopyInto(out *TagFilter) {
 	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
-func (in *SecretRef) DeepCopy() *SecretRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagFilter.
+func (in *TagFilter) DeepCopy() *TagFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretRef)
+	out := new(TagFilter)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -484,16 +1896,37 @@ func (in *TriggerBindingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBindingRef) DeepCopyInto(out *TriggerBindingRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBindingRef.
+func (in *TriggerBindingRef) DeepCopy() *TriggerBindingRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBindingRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerBindingSpec) DeepCopyInto(out *TriggerBindingSpec) {
 	*out = *in
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
-		*out = make([]v1beta1.Param, len(*in))
+		*out = make([]BindingParam, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]TriggerBindingRef, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -523,6 +1956,23 @@ func (in *TriggerBindingStatus) DeepCopy() *TriggerBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerResourcePatch) DeepCopyInto(out *TriggerResourcePatch) {
+	*out = *in
+	in.RawExtension.DeepCopyInto(&out.RawExtension)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerResourcePatch.
+func (in *TriggerResourcePatch) DeepCopy() *TriggerResourcePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerResourcePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerResourceTemplate) DeepCopyInto(out *TriggerResourceTemplate) {
 	*out = *in
@@ -540,6 +1990,26 @@ func (in *TriggerResourceTemplate) DeepCopy() *TriggerResourceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	if in.LastCreatedTime != nil {
+		in, out := &in.LastCreatedTime, &out.LastCreatedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerTemplate) DeepCopyInto(out *TriggerTemplate) {
 	*out = *in
@@ -606,7 +2076,7 @@ func (in *TriggerTemplateSpec) DeepCopyInto(out *TriggerTemplateSpec) {
 	*out = *in
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
-		*out = make([]v1beta1.ParamSpec, len(*in))
+		*out = make([]ParamSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -618,6 +2088,13 @@ func (in *TriggerTemplateSpec) DeepCopyInto(out *TriggerTemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]TriggerResourcePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -647,12 +2124,43 @@ func (in *TriggerTemplateStatus) DeepCopy() *TriggerTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedSenders) DeepCopyInto(out *TrustedSenders) {
+	*out = *in
+	if in.CommonNames != nil {
+		in, out := &in.CommonNames, &out.CommonNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenSecret != nil {
+		in, out := &in.TokenSecret, &out.TokenSecret
+		*out = new(SecretRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedSenders.
+func (in *TrustedSenders) DeepCopy() *TrustedSenders {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedSenders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookInterceptor) DeepCopyInto(out *WebhookInterceptor) {
 	*out = *in
 	if in.ObjectRef != nil {
 		in, out := &in.ObjectRef, &out.ObjectRef
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.Header != nil {
@@ -662,6 +2170,11 @@ func (in *WebhookInterceptor) DeepCopyInto(out *WebhookInterceptor) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
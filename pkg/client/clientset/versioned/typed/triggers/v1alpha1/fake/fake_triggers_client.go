@@ -28,6 +28,14 @@ type FakeTriggersV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTriggersV1alpha1) ClusterEventListeners() v1alpha1.ClusterEventListenerInterface {
+	return &FakeClusterEventListeners{c}
+}
+
+func (c *FakeTriggersV1alpha1) ClusterInterceptors() v1alpha1.ClusterInterceptorInterface {
+	return &FakeClusterInterceptors{c}
+}
+
 func (c *FakeTriggersV1alpha1) ClusterTriggerBindings() v1alpha1.ClusterTriggerBindingInterface {
 	return &FakeClusterTriggerBindings{c}
 }
@@ -36,6 +44,14 @@ func (c *FakeTriggersV1alpha1) EventListeners(namespace string) v1alpha1.EventLi
 	return &FakeEventListeners{c, namespace}
 }
 
+func (c *FakeTriggersV1alpha1) InterceptorChains(namespace string) v1alpha1.InterceptorChainInterface {
+	return &FakeInterceptorChains{c, namespace}
+}
+
+func (c *FakeTriggersV1alpha1) Repositories(namespace string) v1alpha1.RepositoryInterface {
+	return &FakeRepositories{c, namespace}
+}
+
 func (c *FakeTriggersV1alpha1) TriggerBindings(namespace string) v1alpha1.TriggerBindingInterface {
 	return &FakeTriggerBindings{c, namespace}
 }
@@ -18,10 +18,18 @@ limitations under the License.
 
 package v1alpha1
 
+type ClusterEventListenerExpansion interface{}
+
+type ClusterInterceptorExpansion interface{}
+
 type ClusterTriggerBindingExpansion interface{}
 
 type EventListenerExpansion interface{}
 
+type InterceptorChainExpansion interface{}
+
+type RepositoryExpansion interface{}
+
 type TriggerBindingExpansion interface{}
 
 type TriggerTemplateExpansion interface{}
@@ -26,8 +26,12 @@ import (
 
 type TriggersV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterEventListenersGetter
+	ClusterInterceptorsGetter
 	ClusterTriggerBindingsGetter
 	EventListenersGetter
+	InterceptorChainsGetter
+	RepositoriesGetter
 	TriggerBindingsGetter
 	TriggerTemplatesGetter
 }
@@ -37,6 +41,14 @@ type TriggersV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TriggersV1alpha1Client) ClusterEventListeners() ClusterEventListenerInterface {
+	return newClusterEventListeners(c)
+}
+
+func (c *TriggersV1alpha1Client) ClusterInterceptors() ClusterInterceptorInterface {
+	return newClusterInterceptors(c)
+}
+
 func (c *TriggersV1alpha1Client) ClusterTriggerBindings() ClusterTriggerBindingInterface {
 	return newClusterTriggerBindings(c)
 }
@@ -45,6 +57,14 @@ func (c *TriggersV1alpha1Client) EventListeners(namespace string) EventListenerI
 	return newEventListeners(c, namespace)
 }
 
+func (c *TriggersV1alpha1Client) InterceptorChains(namespace string) InterceptorChainInterface {
+	return newInterceptorChains(c, namespace)
+}
+
+func (c *TriggersV1alpha1Client) Repositories(namespace string) RepositoryInterface {
+	return newRepositories(c, namespace)
+}
+
 func (c *TriggersV1alpha1Client) TriggerBindings(namespace string) TriggerBindingInterface {
 	return newTriggerBindings(c, namespace)
 }
@@ -24,10 +24,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterEventListeners returns a ClusterEventListenerInformer.
+	ClusterEventListeners() ClusterEventListenerInformer
+	// ClusterInterceptors returns a ClusterInterceptorInformer.
+	ClusterInterceptors() ClusterInterceptorInformer
 	// ClusterTriggerBindings returns a ClusterTriggerBindingInformer.
 	ClusterTriggerBindings() ClusterTriggerBindingInformer
 	// EventListeners returns a EventListenerInformer.
 	EventListeners() EventListenerInformer
+	// InterceptorChains returns a InterceptorChainInformer.
+	InterceptorChains() InterceptorChainInformer
+	// Repositories returns a RepositoryInformer.
+	Repositories() RepositoryInformer
 	// TriggerBindings returns a TriggerBindingInformer.
 	TriggerBindings() TriggerBindingInformer
 	// TriggerTemplates returns a TriggerTemplateInformer.
@@ -45,6 +53,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterEventListeners returns a ClusterEventListenerInformer.
+func (v *version) ClusterEventListeners() ClusterEventListenerInformer {
+	return &clusterEventListenerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterInterceptors returns a ClusterInterceptorInformer.
+func (v *version) ClusterInterceptors() ClusterInterceptorInformer {
+	return &clusterInterceptorInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterTriggerBindings returns a ClusterTriggerBindingInformer.
 func (v *version) ClusterTriggerBindings() ClusterTriggerBindingInformer {
 	return &clusterTriggerBindingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -55,6 +73,16 @@ func (v *version) EventListeners() EventListenerInformer {
 	return &eventListenerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// InterceptorChains returns a InterceptorChainInformer.
+func (v *version) InterceptorChains() InterceptorChainInformer {
+	return &interceptorChainInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Repositories returns a RepositoryInformer.
+func (v *version) Repositories() RepositoryInformer {
+	return &repositoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TriggerBindings returns a TriggerBindingInformer.
 func (v *version) TriggerBindings() TriggerBindingInformer {
 	return &triggerBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// ClusterEventListenerListerExpansion allows custom methods to be added to
+// ClusterEventListenerLister.
+type ClusterEventListenerListerExpansion interface{}
+
+// ClusterInterceptorListerExpansion allows custom methods to be added to
+// ClusterInterceptorLister.
+type ClusterInterceptorListerExpansion interface{}
+
 // ClusterTriggerBindingListerExpansion allows custom methods to be added to
 // ClusterTriggerBindingLister.
 type ClusterTriggerBindingListerExpansion interface{}
@@ -30,6 +38,22 @@ type EventListenerListerExpansion interface{}
 // EventListenerNamespaceLister.
 type EventListenerNamespaceListerExpansion interface{}
 
+// InterceptorChainListerExpansion allows custom methods to be added to
+// InterceptorChainLister.
+type InterceptorChainListerExpansion interface{}
+
+// InterceptorChainNamespaceListerExpansion allows custom methods to be added to
+// InterceptorChainNamespaceLister.
+type InterceptorChainNamespaceListerExpansion interface{}
+
+// RepositoryListerExpansion allows custom methods to be added to
+// RepositoryLister.
+type RepositoryListerExpansion interface{}
+
+// RepositoryNamespaceListerExpansion allows custom methods to be added to
+// RepositoryNamespaceLister.
+type RepositoryNamespaceListerExpansion interface{}
+
 // TriggerBindingListerExpansion allows custom methods to be added to
 // TriggerBindingLister.
 type TriggerBindingListerExpansion interface{}
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+type Interceptor struct {
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	Alert                  *triggersv1.AlertInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(a *triggersv1.AlertInterceptor, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		Logger:                 l,
+		Alert:                  a,
+		KubeClientSet:          k,
+		EventListenerNamespace: ns,
+	}
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	// Validate the token first, if set.
+	if w.Alert.SecretRef != nil && !interceptors.TrustedSender(request) {
+		header := request.Header.Get(authorizationHeader)
+		if !strings.HasPrefix(header, bearerPrefix) {
+			return nil, interceptors.AuthError(fmt.Errorf("no bearer token set in %s header", authorizationHeader))
+		}
+		secretToken, err := interceptors.GetSecretToken(w.KubeClientSet, w.Alert.SecretRef, w.EventListenerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(header, bearerPrefix)), secretToken) == 0 {
+			return nil, interceptors.AuthError(errors.New("invalid bearer token"))
+		}
+	}
+
+	status, tags := parseAlert(payload)
+
+	if w.Alert.Statuses != nil {
+		if !containsFold(w.Alert.Statuses, status) {
+			return nil, fmt.Errorf("alert status %s is not allowed", status)
+		}
+	}
+
+	for _, tag := range w.Alert.Tags {
+		if !tags[tag] {
+			return nil, fmt.Errorf("alert does not have tag %s", tag)
+		}
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// parseAlert returns the status and the key:value tags of an alert
+// notification. Payloads with an alerts array use the Prometheus Alertmanager
+// envelope, which Grafana also sends, and are tagged with their common
+// labels. Any other payload is treated as a Datadog webhook, whose payload
+// template is expected to include the $ALERT_TRANSITION and $TAGS variables
+// as alert_transition and tags.
+func parseAlert(payload []byte) (string, map[string]bool) {
+	tags := map[string]bool{}
+	if gjson.GetBytes(payload, "alerts").IsArray() {
+		gjson.GetBytes(payload, "commonLabels").ForEach(func(key, value gjson.Result) bool {
+			tags[key.String()+":"+value.String()] = true
+			return true
+		})
+		return gjson.GetBytes(payload, "status").String(), tags
+	}
+
+	res := gjson.GetBytes(payload, "tags")
+	var values []string
+	if res.IsArray() {
+		for _, r := range res.Array() {
+			values = append(values, r.String())
+		}
+	} else {
+		values = strings.Split(res.String(), ",")
+	}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			tags[v] = true
+		}
+	}
+	return gjson.GetBytes(payload, "alert_transition").String(), tags
+}
+
+func containsFold(allowed []string, s string) bool {
+	for _, a := range allowed {
+		if strings.EqualFold(a, s) {
+			return true
+		}
+	}
+	return false
+}
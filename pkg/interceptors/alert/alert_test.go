@@ -0,0 +1,199 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/logging"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	datadogPayload      = `{"title":"High error rate","alert_transition":"Triggered","tags":"env:prod, service:checkout"}`
+	alertmanagerPayload = `{"version":"4","status":"firing","alerts":[{"status":"firing","labels":{"alertname":"HighErrorRate","env":"prod","pod":"checkout-1"}}],"commonLabels":{"alertname":"HighErrorRate","env":"prod"}}`
+)
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysecret",
+		},
+		Data: map[string][]byte{
+			"token": []byte("secrettoken"),
+		},
+	}
+	secretRef := &triggersv1.SecretRef{
+		SecretName: "mysecret",
+		SecretKey:  "token",
+	}
+	type args struct {
+		payload       string
+		secret        *corev1.Secret
+		authorization string
+	}
+	tests := []struct {
+		name    string
+		Alert   *triggersv1.AlertInterceptor
+		args    args
+		wantErr bool
+	}{{
+		name:  "no secret",
+		Alert: &triggersv1.AlertInterceptor{},
+		args: args{
+			payload: datadogPayload,
+		},
+	}, {
+		name:  "missing token",
+		Alert: &triggersv1.AlertInterceptor{SecretRef: secretRef},
+		args: args{
+			payload: datadogPayload,
+			secret:  secret,
+		},
+		wantErr: true,
+	}, {
+		name:  "invalid token",
+		Alert: &triggersv1.AlertInterceptor{SecretRef: secretRef},
+		args: args{
+			payload:       datadogPayload,
+			secret:        secret,
+			authorization: "Bearer othertoken",
+		},
+		wantErr: true,
+	}, {
+		name:  "valid token",
+		Alert: &triggersv1.AlertInterceptor{SecretRef: secretRef},
+		args: args{
+			payload:       datadogPayload,
+			secret:        secret,
+			authorization: "Bearer secrettoken",
+		},
+	}, {
+		name:  "allowed Datadog status",
+		Alert: &triggersv1.AlertInterceptor{Statuses: []string{"triggered", "re-triggered"}},
+		args: args{
+			payload: datadogPayload,
+		},
+	}, {
+		name:  "disallowed Datadog status",
+		Alert: &triggersv1.AlertInterceptor{Statuses: []string{"Recovered"}},
+		args: args{
+			payload: datadogPayload,
+		},
+		wantErr: true,
+	}, {
+		name:  "Datadog tags",
+		Alert: &triggersv1.AlertInterceptor{Tags: []string{"service:checkout", "env:prod"}},
+		args: args{
+			payload: datadogPayload,
+		},
+	}, {
+		name:  "Datadog tags array",
+		Alert: &triggersv1.AlertInterceptor{Tags: []string{"env:prod"}},
+		args: args{
+			payload: `{"alert_transition":"Triggered","tags":["env:prod","service:checkout"]}`,
+		},
+	}, {
+		name:  "missing Datadog tag",
+		Alert: &triggersv1.AlertInterceptor{Tags: []string{"env:staging"}},
+		args: args{
+			payload: datadogPayload,
+		},
+		wantErr: true,
+	}, {
+		name:  "allowed Alertmanager status",
+		Alert: &triggersv1.AlertInterceptor{Statuses: []string{"firing"}},
+		args: args{
+			payload: alertmanagerPayload,
+		},
+	}, {
+		name:  "disallowed Alertmanager status",
+		Alert: &triggersv1.AlertInterceptor{Statuses: []string{"resolved"}},
+		args: args{
+			payload: alertmanagerPayload,
+		},
+		wantErr: true,
+	}, {
+		name:  "Alertmanager common labels",
+		Alert: &triggersv1.AlertInterceptor{Tags: []string{"alertname:HighErrorRate", "env:prod"}},
+		args: args{
+			payload: alertmanagerPayload,
+		},
+	}, {
+		name:  "label not common to all alerts",
+		Alert: &triggersv1.AlertInterceptor{Tags: []string{"pod:checkout-1"}},
+		args: args{
+			payload: alertmanagerPayload,
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			request := &http.Request{
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(tt.args.payload))),
+				Header: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			}
+			if tt.args.authorization != "" {
+				request.Header.Add("Authorization", tt.args.authorization)
+			}
+			if tt.args.secret != nil {
+				if _, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(tt.args.secret); err != nil {
+					t.Error(err)
+				}
+			}
+			w := &Interceptor{
+				KubeClientSet:          kubeClient,
+				Alert:                  tt.Alert,
+				Logger:                 logger,
+				EventListenerNamespace: metav1.NamespaceDefault,
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("Interceptor.ExecuteTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatalf("Interceptor.ExecuteTrigger() expected error, got none")
+			}
+
+			got, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response: %v", err)
+			}
+			defer resp.Body.Close()
+			if !reflect.DeepEqual(got, []byte(tt.args.payload)) {
+				t.Errorf("Interceptor.ExecuteTrigger() = %s, want %s", got, tt.args.payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/signature"
+	"github.com/tektoncd/triggers/pkg/payloads"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	signatureHeader = "X-Hub-Signature"
+	eventKeyHeader  = "X-Event-Key"
+
+	reviewerEventPrefix = "pr:reviewer:"
+)
+
+// projectPaths and repositoryPaths are the locations of the project key and
+// repository slug in repository and pull request event payloads.
+var (
+	projectPaths    = []string{"repository.project.key", "pullRequest.toRef.repository.project.key"}
+	repositoryPaths = []string{"repository.slug", "pullRequest.toRef.repository.slug"}
+)
+
+type Interceptor struct {
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	Bitbucket              *triggersv1.BitbucketInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(b *triggersv1.BitbucketInterceptor, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		Logger:                 l,
+		Bitbucket:              b,
+		KubeClientSet:          k,
+		EventListenerNamespace: ns,
+	}
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	// Check where the event came from before anything else, if set.
+	if w.Bitbucket.SourceRanges != nil {
+		if err := validateSource(request.RemoteAddr, w.Bitbucket.SourceRanges); err != nil {
+			return nil, err
+		}
+	}
+
+	if w.Bitbucket.SecretRef != nil && !interceptors.TrustedSender(request) {
+		header := request.Header.Get(signatureHeader)
+		if header == "" {
+			return nil, interceptors.AuthError(fmt.Errorf("no %s header set", signatureHeader))
+		}
+		if err := interceptors.ValidateWithSecrets(w.KubeClientSet, "bitbucket", w.Bitbucket.SecretRef, w.Bitbucket.PreviousSecretRef, w.EventListenerNamespace, func(secretToken []byte) error {
+			return signature.Validate(header, payload, secretToken)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	actualEvent := request.Header.Get(eventKeyHeader)
+	if w.Bitbucket.EventTypes != nil {
+		if !contains(w.Bitbucket.EventTypes, actualEvent) {
+			return nil, fmt.Errorf("event type %s is not allowed", actualEvent)
+		}
+	}
+
+	if w.Bitbucket.Projects != nil {
+		if !matchAny(payload, projectPaths, w.Bitbucket.Projects) {
+			return nil, errors.New("event project is not allowed")
+		}
+	}
+
+	if w.Bitbucket.Repositories != nil {
+		if !matchAny(payload, repositoryPaths, w.Bitbucket.Repositories) {
+			return nil, errors.New("event repository is not allowed")
+		}
+	}
+
+	// Reviewer states only apply to reviewer events, other events pass through.
+	if w.Bitbucket.ReviewerStates != nil && strings.HasPrefix(actualEvent, reviewerEventPrefix) {
+		if !matchAny(payload, []string{"participant.status"}, w.Bitbucket.ReviewerStates) {
+			return nil, errors.New("reviewer state is not allowed")
+		}
+	}
+
+	if f := w.Bitbucket.Ignore; f != nil {
+		for _, res := range gjson.GetManyBytes(payload, "actor.name", "actor.slug") {
+			if f.IgnoresAccount(res.String()) {
+				return nil, fmt.Errorf("events of %s are ignored", res.String())
+			}
+		}
+		// Push events of Bitbucket Server do not include commit messages
+		if f.IgnoresMessage(gjson.GetBytes(payload, "pullRequest.title").String()) {
+			return nil, fmt.Errorf("%s event asks to skip CI", actualEvent)
+		}
+	}
+
+	if w.Bitbucket.Tags != nil {
+		tags := changedTags(payload)
+		if len(tags) == 0 {
+			return nil, fmt.Errorf("%s event does not change a tag", actualEvent)
+		}
+		if !matchTag(w.Bitbucket.Tags, tags) {
+			return nil, fmt.Errorf("tags %s are not allowed", strings.Join(tags, ", "))
+		}
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// validateSource checks that the remote address of the request is within one
+// of the allowed CIDRs.
+func validateSource(remoteAddr string, sourceRanges []string) error {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("unable to parse source address %q", remoteAddr)
+	}
+	for _, r := range sourceRanges {
+		_, cidr, err := net.ParseCIDR(r)
+		if err != nil {
+			return fmt.Errorf("invalid source range %s: %w", r, err)
+		}
+		if cidr.Contains(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("source address %s is not allowed", ip)
+}
+
+// matchAny returns true if the value at any of the paths in the payload is
+// one of the allowed values.
+func matchAny(payload []byte, paths, allowed []string) bool {
+	for _, res := range gjson.GetManyBytes(payload, paths...) {
+		if res.Exists() && contains(allowed, res.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedTags returns the names of the tags changed by a repo:refs_changed
+// event.
+func changedTags(payload []byte) []string {
+	var event payloads.BitbucketRefsChangedEvent
+	if err := payloads.Decode(payload, &event); err != nil {
+		return nil
+	}
+	var tags []string
+	for _, c := range event.Changes {
+		if c.Ref.Type == "TAG" {
+			tags = append(tags, c.Ref.DisplayID)
+		}
+	}
+	return tags
+}
+
+// matchTag returns true if any of the tags is allowed by the filter.
+func matchTag(f *triggersv1.TagFilter, tags []string) bool {
+	for _, t := range tags {
+		if f.Match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(allowed []string, s string) bool {
+	for _, a := range allowed {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
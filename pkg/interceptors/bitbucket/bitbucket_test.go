@@ -0,0 +1,317 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bitbucket
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/logging"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	pushPayload     = `{"eventKey":"repo:refs_changed","repository":{"slug":"triggers","project":{"key":"TEK"}}}`
+	tagPayload      = `{"eventKey":"repo:refs_changed","changes":[{"ref":{"id":"refs/heads/main","displayId":"main","type":"BRANCH"}},{"ref":{"id":"refs/tags/v1.2.0","displayId":"v1.2.0","type":"TAG"}}]}`
+	reviewerPayload = `{"eventKey":"pr:reviewer:approved","participant":{"status":"APPROVED"},"pullRequest":{"toRef":{"repository":{"slug":"triggers","project":{"key":"TEK"}}}}}`
+)
+
+func sign(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysecret",
+		},
+		Data: map[string][]byte{
+			"token":          []byte("secrettoken"),
+			"previous-token": []byte("previoustoken"),
+		},
+	}
+	secretRef := &triggersv1.SecretRef{
+		SecretName: "mysecret",
+		SecretKey:  "token",
+	}
+	previousSecretRef := &triggersv1.SecretRef{
+		SecretName: "mysecret",
+		SecretKey:  "previous-token",
+	}
+	type args struct {
+		payload    string
+		secret     *corev1.Secret
+		signature  string
+		eventType  string
+		remoteAddr string
+	}
+	tests := []struct {
+		name      string
+		Bitbucket *triggersv1.BitbucketInterceptor
+		args      args
+		wantErr   bool
+	}{{
+		name:      "no secret",
+		Bitbucket: &triggersv1.BitbucketInterceptor{},
+		args: args{
+			payload: pushPayload,
+		},
+	}, {
+		name:      "missing signature",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SecretRef: secretRef},
+		args: args{
+			payload: pushPayload,
+			secret:  secret,
+		},
+		wantErr: true,
+	}, {
+		name:      "invalid signature",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SecretRef: secretRef},
+		args: args{
+			payload:   pushPayload,
+			secret:    secret,
+			signature: sign(pushPayload, "othersecret"),
+		},
+		wantErr: true,
+	}, {
+		name:      "valid signature",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SecretRef: secretRef},
+		args: args{
+			payload:   pushPayload,
+			secret:    secret,
+			signature: sign(pushPayload, "secrettoken"),
+		},
+	}, {
+		name:      "valid signature for previous secret",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SecretRef: secretRef, PreviousSecretRef: previousSecretRef},
+		args: args{
+			payload:   pushPayload,
+			secret:    secret,
+			signature: sign(pushPayload, "previoustoken"),
+		},
+	}, {
+		name:      "invalid signature for current and previous secret",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SecretRef: secretRef, PreviousSecretRef: previousSecretRef},
+		args: args{
+			payload:   pushPayload,
+			secret:    secret,
+			signature: sign(pushPayload, "othersecret"),
+		},
+		wantErr: true,
+	}, {
+		name:      "allowed event type",
+		Bitbucket: &triggersv1.BitbucketInterceptor{EventTypes: []string{"repo:refs_changed", "pr:opened"}},
+		args: args{
+			payload:   pushPayload,
+			eventType: "repo:refs_changed",
+		},
+	}, {
+		name:      "disallowed event type",
+		Bitbucket: &triggersv1.BitbucketInterceptor{EventTypes: []string{"pr:opened"}},
+		args: args{
+			payload:   pushPayload,
+			eventType: "repo:refs_changed",
+		},
+		wantErr: true,
+	}, {
+		name:      "allowed source range",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SourceRanges: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+		args: args{
+			payload:    pushPayload,
+			remoteAddr: "10.1.2.3:34567",
+		},
+	}, {
+		name:      "disallowed source range",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SourceRanges: []string{"192.168.0.0/16"}},
+		args: args{
+			payload:    pushPayload,
+			remoteAddr: "10.1.2.3:34567",
+		},
+		wantErr: true,
+	}, {
+		name:      "missing source address",
+		Bitbucket: &triggersv1.BitbucketInterceptor{SourceRanges: []string{"0.0.0.0/0"}},
+		args: args{
+			payload: pushPayload,
+		},
+		wantErr: true,
+	}, {
+		name:      "allowed project",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Projects: []string{"TEK"}},
+		args: args{
+			payload: pushPayload,
+		},
+	}, {
+		name:      "allowed pull request project",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Projects: []string{"TEK"}},
+		args: args{
+			payload:   reviewerPayload,
+			eventType: "pr:reviewer:approved",
+		},
+	}, {
+		name:      "disallowed project",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Projects: []string{"OTHER"}},
+		args: args{
+			payload: pushPayload,
+		},
+		wantErr: true,
+	}, {
+		name:      "allowed repository",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Repositories: []string{"triggers", "pipeline"}},
+		args: args{
+			payload: pushPayload,
+		},
+	}, {
+		name:      "disallowed repository",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Repositories: []string{"pipeline"}},
+		args: args{
+			payload: reviewerPayload,
+		},
+		wantErr: true,
+	}, {
+		name:      "allowed reviewer state",
+		Bitbucket: &triggersv1.BitbucketInterceptor{ReviewerStates: []string{"APPROVED"}},
+		args: args{
+			payload:   reviewerPayload,
+			eventType: "pr:reviewer:approved",
+		},
+	}, {
+		name:      "disallowed reviewer state",
+		Bitbucket: &triggersv1.BitbucketInterceptor{ReviewerStates: []string{"NEEDS_WORK"}},
+		args: args{
+			payload:   reviewerPayload,
+			eventType: "pr:reviewer:approved",
+		},
+		wantErr: true,
+	}, {
+		name:      "reviewer state ignored for other events",
+		Bitbucket: &triggersv1.BitbucketInterceptor{ReviewerStates: []string{"NEEDS_WORK"}},
+		args: args{
+			payload:   pushPayload,
+			eventType: "repo:refs_changed",
+		},
+	}, {
+		name:      "allowed tag",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Tags: &triggersv1.TagFilter{SemverRange: ">=1.0.0 <2.0.0"}},
+		args: args{
+			payload:   tagPayload,
+			eventType: "repo:refs_changed",
+		},
+	}, {
+		name:      "disallowed tag",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Tags: &triggersv1.TagFilter{Prefixes: []string{"release-"}}},
+		args: args{
+			payload:   tagPayload,
+			eventType: "repo:refs_changed",
+		},
+		wantErr: true,
+	}, {
+		name:      "tag filter with a branch push",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Tags: &triggersv1.TagFilter{Prefixes: []string{"v"}}},
+		args: args{
+			payload:   pushPayload,
+			eventType: "repo:refs_changed",
+		},
+		wantErr: true,
+	}, {
+		name:      "push of an ignored bot",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Ignore: &triggersv1.IgnoreFilter{Accounts: []string{"renovate-bot"}}},
+		args: args{
+			payload:   `{"eventKey":"repo:refs_changed","actor":{"name":"Renovate-Bot","slug":"renovate-bot"}}`,
+			eventType: "repo:refs_changed",
+		},
+		wantErr: true,
+	}, {
+		name:      "pull request with skip ci",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Ignore: &triggersv1.IgnoreFilter{SkipCI: true}},
+		args: args{
+			payload:   `{"eventKey":"pr:opened","actor":{"name":"jane"},"pullRequest":{"title":"[skip ci] Update docs"}}`,
+			eventType: "pr:opened",
+		},
+		wantErr: true,
+	}, {
+		name:      "push with skip ci filter",
+		Bitbucket: &triggersv1.BitbucketInterceptor{Ignore: &triggersv1.IgnoreFilter{SkipCI: true}},
+		args: args{
+			payload:   pushPayload,
+			eventType: "repo:refs_changed",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			request := &http.Request{
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(tt.args.payload))),
+				Header: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+				RemoteAddr: tt.args.remoteAddr,
+			}
+			if tt.args.signature != "" {
+				request.Header.Add("X-Hub-Signature", tt.args.signature)
+			}
+			if tt.args.eventType != "" {
+				request.Header.Add("X-Event-Key", tt.args.eventType)
+			}
+			if tt.args.secret != nil {
+				if _, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(tt.args.secret); err != nil {
+					t.Error(err)
+				}
+			}
+			w := &Interceptor{
+				KubeClientSet:          kubeClient,
+				Bitbucket:              tt.Bitbucket,
+				Logger:                 logger,
+				EventListenerNamespace: metav1.NamespaceDefault,
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("Interceptor.ExecuteTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatalf("Interceptor.ExecuteTrigger() expected error, got none")
+			}
+
+			got, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response: %v", err)
+			}
+			defer resp.Body.Close()
+			if !reflect.DeepEqual(got, []byte(tt.args.payload)) {
+				t.Errorf("Interceptor.ExecuteTrigger() = %s, want %s", got, tt.args.payload)
+			}
+		})
+	}
+}
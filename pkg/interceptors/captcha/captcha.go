@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package captcha
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+// providers are the verification endpoints of the providers, and the fields
+// that their widgets submit the token in.
+var providers = map[triggersv1.CaptchaProvider]struct {
+	verifyURL  string
+	tokenField string
+}{
+	triggersv1.CaptchaRecaptcha: {"https://www.google.com/recaptcha/api/siteverify", "g-recaptcha-response"},
+	triggersv1.CaptchaTurnstile: {"https://challenges.cloudflare.com/turnstile/v0/siteverify", "cf-turnstile-response"},
+	triggersv1.CaptchaHCaptcha:  {"https://api.hcaptcha.com/siteverify", "h-captcha-response"},
+}
+
+type Interceptor struct {
+	HTTPClient             *http.Client
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	Captcha                *triggersv1.CaptchaInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(c *triggersv1.CaptchaInterceptor, hc *http.Client, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		HTTPClient:             hc,
+		KubeClientSet:          k,
+		Logger:                 l,
+		Captcha:                c,
+		EventListenerNamespace: ns,
+	}
+}
+
+// verification is the response of the verification endpoints, which the
+// providers share.
+type verification struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score,omitempty"`
+	Action     string   `json:"action,omitempty"`
+	Hostname   string   `json:"hostname,omitempty"`
+	ErrorCodes []string `json:"error-codes,omitempty"`
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	provider, ok := providers[w.Captcha.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown CAPTCHA provider %s", w.Captcha.Provider)
+	}
+	field := w.Captcha.TokenField
+	if field == "" {
+		field = provider.tokenField
+	}
+	token := gjson.GetBytes(payload, field).String()
+	if token == "" {
+		return nil, interceptors.AuthError(fmt.Errorf("no CAPTCHA token in %s", field))
+	}
+	secret, err := interceptors.GetSecretToken(w.KubeClientSet, w.Captcha.SecretRef, w.EventListenerNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	verifyURL := w.Captcha.VerifyURL
+	if verifyURL == "" {
+		verifyURL = provider.verifyURL
+	}
+	v, err := w.verify(request, verifyURL, strings.TrimSpace(string(secret)), token)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.check(v); err != nil {
+		return nil, interceptors.AuthError(err)
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// verify sends the token to the verification endpoint of the provider.
+func (w *Interceptor) verify(request *http.Request, verifyURL, secret, token string) (*verification, error) {
+	form := url.Values{"secret": {secret}, "response": {token}}
+	req, err := http.NewRequestWithContext(request.Context(), http.MethodPost, verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify the CAPTCHA token: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CAPTCHA verification: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to verify the CAPTCHA token: %s: %s", resp.Status, body)
+	}
+	var v verification
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("invalid CAPTCHA verification: %w", err)
+	}
+	return &v, nil
+}
+
+// check returns an error unless the verification passed and meets the score,
+// action and hostnames of the interceptor.
+func (w *Interceptor) check(v *verification) error {
+	if !v.Success {
+		if len(v.ErrorCodes) > 0 {
+			return fmt.Errorf("CAPTCHA token rejected: %s", strings.Join(v.ErrorCodes, ", "))
+		}
+		return errors.New("CAPTCHA token rejected")
+	}
+	if w.Captcha.MinScore != "" {
+		min, err := strconv.ParseFloat(w.Captcha.MinScore, 64)
+		if err != nil {
+			return fmt.Errorf("invalid minScore %s: %w", w.Captcha.MinScore, err)
+		}
+		// Tokens of reCAPTCHA v2 have no score, and cannot meet one
+		if v.Score == nil || *v.Score < min {
+			return fmt.Errorf("CAPTCHA score below %s", w.Captcha.MinScore)
+		}
+	}
+	if w.Captcha.Action != "" && v.Action != w.Captcha.Action {
+		return fmt.Errorf("CAPTCHA token issued for action %q, want %q", v.Action, w.Captcha.Action)
+	}
+	if len(w.Captcha.Hostnames) > 0 && !contains(w.Captcha.Hostnames, v.Hostname) {
+		return fmt.Errorf("CAPTCHA token issued on host %q", v.Hostname)
+	}
+	return nil
+}
+
+func contains(allowed []string, s string) bool {
+	for _, a := range allowed {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
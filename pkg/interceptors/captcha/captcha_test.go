@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package captcha
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/logging"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+// fakeProvider verifies the tokens of the responses with the secret key
+// "site-secret".
+func fakeProvider(responses map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.FormValue("secret") != "site-secret" {
+			w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-secret"]}`))
+			return
+		}
+		resp, ok := responses[r.FormValue("response")]
+		if !ok {
+			w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+			return
+		}
+		w.Write([]byte(resp))
+	})
+}
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "captcha", Namespace: "ns"},
+		Data:       map[string][]byte{"secret": []byte("site-secret\n")},
+	}
+	responses := map[string]string{
+		"human":   `{"success": true, "score": 0.9, "action": "feedback", "hostname": "docs.example.com"}`,
+		"bot":     `{"success": true, "score": 0.1, "action": "feedback", "hostname": "docs.example.com"}`,
+		"v2":      `{"success": true, "hostname": "docs.example.com"}`,
+		"elsewhr": `{"success": true, "action": "login", "hostname": "evil.example.com"}`,
+	}
+	tests := []struct {
+		name        string
+		captcha     triggersv1.CaptchaInterceptor
+		payload     string
+		status      int
+		wantErr     bool
+		wantAuthErr bool
+	}{{
+		name:    "valid token",
+		captcha: triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha},
+		payload: `{"g-recaptcha-response": "human", "message": "typo"}`,
+	}, {
+		name:    "default turnstile field",
+		captcha: triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaTurnstile},
+		payload: `{"cf-turnstile-response": "v2"}`,
+	}, {
+		name:    "custom field",
+		captcha: triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaHCaptcha, TokenField: "form.captcha"},
+		payload: `{"form": {"captcha": "v2"}}`,
+	}, {
+		name:        "missing token",
+		captcha:     triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha},
+		payload:     `{"message": "typo"}`,
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:        "rejected token",
+		captcha:     triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha},
+		payload:     `{"g-recaptcha-response": "forged"}`,
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:    "score above the minimum",
+		captcha: triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha, MinScore: "0.5"},
+		payload: `{"g-recaptcha-response": "human"}`,
+	}, {
+		name:        "score below the minimum",
+		captcha:     triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha, MinScore: "0.5"},
+		payload:     `{"g-recaptcha-response": "bot"}`,
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:        "minimum score without a score",
+		captcha:     triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha, MinScore: "0.5"},
+		payload:     `{"g-recaptcha-response": "v2"}`,
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:        "other action",
+		captcha:     triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaTurnstile, Action: "feedback"},
+		payload:     `{"cf-turnstile-response": "elsewhr"}`,
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:    "allowed hostname",
+		captcha: triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha, Hostnames: []string{"docs.example.com"}},
+		payload: `{"g-recaptcha-response": "human"}`,
+	}, {
+		name:        "other hostname",
+		captcha:     triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha, Hostnames: []string{"docs.example.com"}},
+		payload:     `{"g-recaptcha-response": "elsewhr"}`,
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:    "provider unavailable",
+		captcha: triggersv1.CaptchaInterceptor{Provider: triggersv1.CaptchaRecaptcha},
+		payload: `{"g-recaptcha-response": "human"}`,
+		status:  http.StatusServiceUnavailable,
+		wantErr: true,
+	}, {
+		name: "missing secret",
+		captcha: triggersv1.CaptchaInterceptor{
+			Provider:  triggersv1.CaptchaRecaptcha,
+			SecretRef: &triggersv1.SecretRef{SecretName: "other", SecretKey: "secret"},
+		},
+		payload: `{"g-recaptcha-response": "human"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			if _, err := kubeClient.CoreV1().Secrets("ns").Create(secret); err != nil {
+				t.Fatal(err)
+			}
+			handler := fakeProvider(responses)
+			if tt.status != 0 {
+				handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+				})
+			}
+			ts := httptest.NewServer(handler)
+			defer ts.Close()
+
+			c := tt.captcha
+			c.VerifyURL = ts.URL
+			if c.SecretRef == nil {
+				c.SecretRef = &triggersv1.SecretRef{SecretName: "captcha", SecretKey: "secret"}
+			}
+			w := NewInterceptor(&c, ts.Client(), kubeClient, "ns", logger)
+			request := &http.Request{
+				Body:   ioutil.NopCloser(bytes.NewReader([]byte(tt.payload))),
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("Interceptor.ExecuteTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if got := errors.Is(err, interceptors.ErrAuthFailed); got != tt.wantAuthErr {
+					t.Errorf("Interceptor.ExecuteTrigger() error %v is an authentication failure: %t, want %t", err, got, tt.wantAuthErr)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatal("Interceptor.ExecuteTrigger() did not return an error")
+			}
+			got, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response body %v", err)
+			}
+			if string(got) != tt.payload {
+				t.Errorf("Interceptor.ExecuteTrigger() = %s, want %s", got, tt.payload)
+			}
+		})
+	}
+}
@@ -24,9 +24,11 @@ import (
 	"net/http"
 	"reflect"
 
+	"github.com/golang/protobuf/jsonpb"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/operators"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/google/cel-go/interpreter/functions"
@@ -46,14 +48,28 @@ type Interceptor struct {
 	KubeClientSet          kubernetes.Interface
 	Logger                 *zap.SugaredLogger
 	CEL                    *triggersv1.CELInterceptor
+	Trigger                Trigger
 	EventListenerNamespace string
 }
 
+// Trigger identifies the Trigger that evaluates the expressions of an
+// Interceptor, so that shared expressions can refer to it as trigger.name,
+// trigger.namespace and trigger.listenerName.
+type Trigger struct {
+	// Name is the name of the EventListenerTrigger
+	Name string
+	// Namespace is the namespace that the Trigger is served in
+	Namespace string
+	// ListenerName is the name of the EventListener
+	ListenerName string
+}
+
 // NewInterceptor creates a prepopulated Interceptor.
-func NewInterceptor(cel *triggersv1.CELInterceptor, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+func NewInterceptor(cel *triggersv1.CELInterceptor, trigger Trigger, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
 	return &Interceptor{
 		Logger:                 l,
 		CEL:                    cel,
+		Trigger:                trigger,
 		KubeClientSet:          k,
 		EventListenerNamespace: ns,
 	}
@@ -75,7 +91,7 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 		}
 	}
 
-	evalContext, err := makeEvalContext(payload, request)
+	evalContext, err := makeEvalContext(payload, request, w.Trigger)
 	if err != nil {
 		return nil, fmt.Errorf("error making the evaluation context: %w", err)
 	}
@@ -111,10 +127,20 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 			if err == nil {
 				b, err = json.Marshal(raw.(*structpb.Value).GetNumberValue())
 			}
-
-		default:
+		case types.Bytes:
 			raw, err = val.ConvertToNative(reflect.TypeOf([]byte{}))
-			b = raw.([]byte)
+			if err == nil {
+				b = raw.([]byte)
+			}
+		default:
+			// Bools, lists and maps, e.g. the results of the map and filter
+			// macros, are written as JSON
+			raw, err = val.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+			if err == nil {
+				var s string
+				s, err = (&jsonpb.Marshaler{}).MarshalToString(raw.(*structpb.Value))
+				b = []byte(s)
+			}
 		}
 
 		if err != nil {
@@ -134,6 +160,23 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 
 }
 
+// Compile parses and type-checks an expression, so that invalid filters and
+// overlays can be reported before an event is evaluated.
+func Compile(expr string) error {
+	env, err := makeCelEnv()
+	if err != nil {
+		return fmt.Errorf("error creating cel environment: %w", err)
+	}
+	parsed, issues := env.Parse(expr)
+	if issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	if _, issues := env.Check(parsed); issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	return nil
+}
+
 func evaluate(expr string, env cel.Env, data map[string]interface{}, ns string, k kubernetes.Interface) (ref.Val, error) {
 	parsed, issues := env.Parse(expr)
 	if issues != nil && issues.Err() != nil {
@@ -145,7 +188,7 @@ func evaluate(expr string, env cel.Env, data map[string]interface{}, ns string,
 		return nil, issues.Err()
 	}
 
-	prg, err := env.Program(checked, embeddedFunctions(ns, k))
+	prg, err := env.Program(checked, embeddedFunctions(ns, k, data))
 	if err != nil {
 		return nil, err
 	}
@@ -154,17 +197,22 @@ func evaluate(expr string, env cel.Env, data map[string]interface{}, ns string,
 	return out, err
 }
 
-func embeddedFunctions(ns string, k kubernetes.Interface) cel.ProgramOption {
+func embeddedFunctions(ns string, k kubernetes.Interface, data map[string]interface{}) cel.ProgramOption {
 	return cel.Functions(
 		&functions.Overload{
 			Operator: "match",
 			Function: matchHeader},
 		&functions.Overload{
 			Operator: "canonical",
+			Unary:    canonicalString,
 			Binary:   canonicalHeader},
 		&functions.Overload{
 			Operator: "truncate",
 			Binary:   truncateString},
+		&functions.Overload{
+			Operator: "sanitizeK8sName",
+			Unary:    sanitizeK8sName,
+			Binary:   sanitizeK8sNameLength},
 		&functions.Overload{
 			Operator: "split",
 			Binary:   splitString},
@@ -174,6 +222,33 @@ func embeddedFunctions(ns string, k kubernetes.Interface) cel.ProgramOption {
 		&functions.Overload{
 			Operator: "compareSecret",
 			Function: makeCompareSecret(ns, k)},
+		&functions.Overload{
+			Operator: "jsonpath",
+			Unary:    makeJSONPath(data)},
+		&functions.Overload{
+			Operator: "parseURL",
+			Unary:    parseURL},
+		&functions.Overload{
+			Operator: "isURL",
+			Unary:    isURL},
+		&functions.Overload{
+			Operator: "semver",
+			Unary:    parseSemverString},
+		&functions.Overload{
+			Operator: "isSemver",
+			Unary:    isSemver},
+		&functions.Overload{
+			Operator: "major",
+			Unary:    makeSemverPart("major", 0)},
+		&functions.Overload{
+			Operator: "minor",
+			Unary:    makeSemverPart("minor", 1)},
+		&functions.Overload{
+			Operator: "patch",
+			Unary:    makeSemverPart("patch", 2)},
+		&functions.Overload{
+			Operator: "prerelease",
+			Unary:    semverPrerelease},
 	)
 
 }
@@ -184,6 +259,7 @@ func makeCelEnv() (cel.Env, error) {
 		cel.Declarations(
 			decls.NewIdent("body", mapStrDyn, nil),
 			decls.NewIdent("header", mapStrDyn, nil),
+			decls.NewIdent("trigger", decls.NewMapType(decls.String, decls.String), nil),
 			decls.NewFunction("match",
 				decls.NewInstanceOverload("match_map_string_string",
 					[]*exprpb.Type{mapStrDyn, decls.String, decls.String}, decls.Bool)),
@@ -192,7 +268,14 @@ func makeCelEnv() (cel.Env, error) {
 					[]*exprpb.Type{decls.Dyn, decls.String}, listStr)),
 			decls.NewFunction("canonical",
 				decls.NewInstanceOverload("canonical_map_string",
-					[]*exprpb.Type{mapStrDyn, decls.String}, decls.String)),
+					[]*exprpb.Type{mapStrDyn, decls.String}, decls.String),
+				decls.NewInstanceOverload("canonical_string",
+					[]*exprpb.Type{decls.String}, decls.String)),
+			decls.NewFunction("sanitizeK8sName",
+				decls.NewOverload("sanitizeK8sName_string",
+					[]*exprpb.Type{decls.String}, decls.String),
+				decls.NewOverload("sanitizeK8sName_string_int",
+					[]*exprpb.Type{decls.String, decls.Int}, decls.String)),
 			decls.NewFunction("compareSecret",
 				decls.NewInstanceOverload("compareSecret_string_string_string",
 					[]*exprpb.Type{decls.String, decls.String, decls.String, decls.String}, decls.String)),
@@ -204,14 +287,70 @@ func makeCelEnv() (cel.Env, error) {
 					[]*exprpb.Type{decls.String}, decls.String)),
 			decls.NewFunction("truncate",
 				decls.NewOverload("truncate_string_uint",
-					[]*exprpb.Type{decls.String, decls.Int}, decls.String))))
+					[]*exprpb.Type{decls.String, decls.Int}, decls.String)),
+			decls.NewFunction("jsonpath",
+				decls.NewOverload("jsonpath_string",
+					[]*exprpb.Type{decls.String}, decls.String)),
+			decls.NewFunction("parseURL",
+				decls.NewOverload("parseURL_string",
+					[]*exprpb.Type{decls.String}, mapStrDyn)),
+			decls.NewFunction("isURL",
+				decls.NewOverload("isURL_string",
+					[]*exprpb.Type{decls.String}, decls.Bool)),
+			decls.NewFunction("semver",
+				decls.NewOverload("semver_string",
+					[]*exprpb.Type{decls.String}, semverDeclType)),
+			decls.NewFunction("isSemver",
+				decls.NewOverload("isSemver_string",
+					[]*exprpb.Type{decls.String}, decls.Bool)),
+			decls.NewFunction("major",
+				decls.NewInstanceOverload("major_semver",
+					[]*exprpb.Type{semverDeclType}, decls.Int)),
+			decls.NewFunction("minor",
+				decls.NewInstanceOverload("minor_semver",
+					[]*exprpb.Type{semverDeclType}, decls.Int)),
+			decls.NewFunction("patch",
+				decls.NewInstanceOverload("patch_semver",
+					[]*exprpb.Type{semverDeclType}, decls.Int)),
+			decls.NewFunction("prerelease",
+				decls.NewInstanceOverload("prerelease_semver",
+					[]*exprpb.Type{semverDeclType}, decls.String)),
+			decls.NewFunction(operators.Less,
+				decls.NewOverload("less_semver",
+					[]*exprpb.Type{semverDeclType, semverDeclType}, decls.Bool)),
+			decls.NewFunction(operators.LessEquals,
+				decls.NewOverload("less_equals_semver",
+					[]*exprpb.Type{semverDeclType, semverDeclType}, decls.Bool)),
+			decls.NewFunction(operators.Greater,
+				decls.NewOverload("greater_semver",
+					[]*exprpb.Type{semverDeclType, semverDeclType}, decls.Bool)),
+			decls.NewFunction(operators.GreaterEquals,
+				decls.NewOverload("greater_equals_semver",
+					[]*exprpb.Type{semverDeclType, semverDeclType}, decls.Bool))))
 }
 
-func makeEvalContext(body []byte, r *http.Request) (map[string]interface{}, error) {
+func makeEvalContext(body []byte, r *http.Request, t Trigger) (map[string]interface{}, error) {
 	var jsonMap map[string]interface{}
 	err := json.Unmarshal(body, &jsonMap)
 	if err != nil {
 		return nil, err
 	}
-	return map[string]interface{}{"body": jsonMap, "header": r.Header}, nil
+	trigger := map[string]string{
+		"name":         t.Name,
+		"namespace":    t.Namespace,
+		"listenerName": t.ListenerName,
+	}
+	return map[string]interface{}{"body": jsonMap, "header": canonicalHeaders(r.Header), "trigger": trigger}, nil
+}
+
+// canonicalHeaders returns the headers with canonical keys, so that macros
+// like header.exists(k, k == 'X-Github-Event') match however the keys were
+// set. Values of keys that only differ by case are merged.
+func canonicalHeaders(h http.Header) http.Header {
+	canonical := make(http.Header, len(h))
+	for k, v := range h {
+		key := http.CanonicalHeaderKey(k)
+		canonical[key] = append(canonical[key], v...)
+	}
+	return canonical
 }
@@ -12,6 +12,8 @@ import (
 
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/logging"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -81,6 +83,17 @@ func TestInterceptor_ExecuteTrigger(t *testing.T) {
 			payload: ioutil.NopCloser(bytes.NewBufferString(`{"value":"test"}`)),
 			want:    []byte(`{"new":"test","value":"test"}`),
 		},
+		{
+			name: "filter and overlay on the trigger",
+			CEL: &triggersv1.CELInterceptor{
+				Filter: "trigger.namespace == 'testing-ns'",
+				Overlays: []triggersv1.CELOverlay{
+					{Key: "extensions.trigger", Expression: "trigger.listenerName + '/' + trigger.name"},
+				},
+			},
+			payload: ioutil.NopCloser(bytes.NewBufferString(`{"value":"test"}`)),
+			want:    []byte(`{"extensions":{"trigger":"test-listener/test-trigger"},"value":"test"}`),
+		},
 		{
 			name: "single overlay with no filter",
 			CEL: &triggersv1.CELInterceptor{
@@ -142,6 +155,34 @@ func TestInterceptor_ExecuteTrigger(t *testing.T) {
 			payload: ioutil.NopCloser(bytes.NewBufferString(`{"count":1,"measure":1.7}`)),
 			want:    []byte(`{"val4":5.1,"val3":4.5,"val2":4,"val1":2,"count":1,"measure":1.7}`),
 		},
+		{
+			name: "exists macro over non-canonical header keys",
+			CEL: &triggersv1.CELInterceptor{
+				Filter: "header.exists(k, k == 'X-Lower-Case') && header['X-Lower-Case'].all(v, v == 'lower')",
+			},
+			payload: ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+			want:    []byte(`{}`),
+		},
+		{
+			name: "overlays with lists and bools from macros",
+			CEL: &triggersv1.CELInterceptor{
+				Overlays: []triggersv1.CELOverlay{
+					{Key: "ids", Expression: "body.commits.filter(c, c.added.size() > 0).map(c, c.id)"},
+					{Key: "test", Expression: "header.exists(k, k.startsWith('X-Test'))"},
+				},
+			},
+			payload: ioutil.NopCloser(bytes.NewBufferString(`{"commits":[{"id":"1","added":["a.go"]},{"id":"2","added":[]}]}`)),
+			want:    []byte(`{"test":true,"ids":["1"],"commits":[{"id":"1","added":["a.go"]},{"id":"2","added":[]}]}`),
+		},
+		{
+			name: "overlay with a semver",
+			CEL: &triggersv1.CELInterceptor{
+				Filter:   "semver(body.tag) >= semver('2.0.0')",
+				Overlays: []triggersv1.CELOverlay{{Key: "version", Expression: "semver(body.tag)"}},
+			},
+			payload: ioutil.NopCloser(bytes.NewBufferString(`{"tag":"v2.1.0+build.7"}`)),
+			want:    []byte(`{"version":"2.1.0","tag":"v2.1.0+build.7"}`),
+		},
 		{
 			name: "validating a secret",
 			CEL: &triggersv1.CELInterceptor{
@@ -167,13 +208,15 @@ func TestInterceptor_ExecuteTrigger(t *testing.T) {
 			if _, err := kubeClient.CoreV1().Secrets(testNS).Create(makeSecret()); err != nil {
 				rt.Error(err)
 			}
-			w := NewInterceptor(tt.CEL, kubeClient, "testing-ns", logger)
+			trigger := Trigger{Name: "test-trigger", Namespace: "testing-ns", ListenerName: "test-listener"}
+			w := NewInterceptor(tt.CEL, trigger, kubeClient, "testing-ns", logger)
 			request := &http.Request{
 				Body: tt.payload,
 				Header: http.Header{
 					"Content-Type":   []string{"application/json"},
 					"X-Test":         []string{"test-value"},
 					"X-Secret-Token": []string{"secrettoken"},
+					"x-lower-case":   []string{"lower"},
 				},
 			}
 			resp, err := w.ExecuteTrigger(request)
@@ -289,11 +332,17 @@ func TestExpressionEvaluation(t *testing.T) {
 			"commits": 2,
 		},
 		"b64value": "ZXhhbXBsZQ==",
+		"labels":   []interface{}{"bug", "ci"},
+		"html_url": "https://github.com/tektoncd/triggers/pull/42?w=1&tab=files#diff",
+		"tag":      "v2.1.0",
 	}
 	refParts := strings.Split(testRef, "/")
 	header := http.Header{}
 	header.Add("X-Test-Header", "value")
-	evalEnv := map[string]interface{}{"body": jsonMap, "header": header}
+	header.Add("X-Multi-Header", "first")
+	header.Add("X-Multi-Header", "second")
+	trigger := map[string]string{"name": "github-push", "namespace": "team-a", "listenerName": "shared"}
+	evalEnv := map[string]interface{}{"body": jsonMap, "header": header, "trigger": trigger}
 	env, err := makeCelEnv()
 	if err != nil {
 		t.Fatal(err)
@@ -314,6 +363,51 @@ func TestExpressionEvaluation(t *testing.T) {
 			expr: "body.value == 'testing'",
 			want: types.Bool(true),
 		},
+		{
+			name: "trigger metadata",
+			expr: "trigger.name.startsWith('github-') && trigger.namespace == 'team-a'",
+			want: types.Bool(true),
+		},
+		{
+			name: "trigger listener name",
+			expr: "trigger.listenerName",
+			want: types.String("shared"),
+		},
+		{
+			name: "exists macro over header keys",
+			expr: "header.exists(k, k == 'X-Test-Header')",
+			want: types.Bool(true),
+		},
+		{
+			name: "all macro over header values",
+			expr: "header.all(k, header[k].size() > 0)",
+			want: types.Bool(true),
+		},
+		{
+			name: "exists macro over a list of header values",
+			expr: "header['X-Multi-Header'].exists(v, v == 'second')",
+			want: types.Bool(true),
+		},
+		{
+			name: "filter macro over header keys",
+			expr: "header.filter(k, k.startsWith('X-')).size()",
+			want: types.Int(2),
+		},
+		{
+			name: "map macro over header values",
+			expr: "header['X-Multi-Header'].map(v, v.size()) == [5, 6]",
+			want: types.Bool(true),
+		},
+		{
+			name: "exists macro over a body array",
+			expr: "body.labels.exists(l, l == 'ci')",
+			want: types.Bool(true),
+		},
+		{
+			name: "filter and map macros over a body array",
+			expr: "body.labels.filter(l, l != 'bug').map(l, l + '-label') == ['ci-label']",
+			want: types.Bool(true),
+		},
 		{
 			name: "truncate a long string",
 			expr: "truncate(body.sha, 7)",
@@ -339,6 +433,36 @@ func TestExpressionEvaluation(t *testing.T) {
 			expr: "truncate(split(body.value, '/')[0], 2)",
 			want: types.String("te"),
 		},
+		{
+			name: "truncate on rune boundaries",
+			expr: "truncate('héllo', 2)",
+			want: types.String("hé"),
+		},
+		{
+			name: "canonical string",
+			expr: "'Feature/JIRA-123_Add Login!'.canonical()",
+			want: types.String("feature-jira-123-add-login"),
+		},
+		{
+			name: "sanitize a short name",
+			expr: "sanitizeK8sName('Feature/Login')",
+			want: types.String("feature-login"),
+		},
+		{
+			name: "sanitize a long name",
+			expr: "sanitizeK8sName('refs/heads/feature/" + strings.Repeat("a", 60) + "')",
+			want: types.String("refs-heads-feature-aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa-fdda4c86"),
+		},
+		{
+			name: "sanitize a name to a maximum length",
+			expr: "sanitizeK8sName('refs/heads/feature/" + strings.Repeat("a", 60) + "', 20)",
+			want: types.String("refs-heads-fdda4c86"),
+		},
+		{
+			name: "sanitize a name without valid characters",
+			expr: "sanitizeK8sName('///')",
+			want: types.String("732c4e97"),
+		},
 		{
 			name: "exact header lookup",
 			expr: "header.canonical('X-Test-Header')",
@@ -377,6 +501,91 @@ func TestExpressionEvaluation(t *testing.T) {
 			want:   types.Bool(true),
 			secret: makeSecret(),
 		},
+		{
+			name: "jsonpath against the body",
+			expr: "jsonpath('.labels[1]')",
+			want: types.String("ci"),
+		},
+		{
+			name: "jsonpath with a non-string value",
+			expr: "jsonpath('pull_request.commits')",
+			want: types.String("2"),
+		},
+		{
+			name: "jsonpath with a binding body expression",
+			expr: "jsonpath('$(body.value)') == body.value",
+			want: types.Bool(true),
+		},
+		{
+			name: "jsonpath with a binding header expression",
+			expr: "jsonpath('$(header.X-Test-Header)')",
+			want: types.String("value"),
+		},
+		{
+			name: "host of a url",
+			expr: "parseURL(body.html_url).host",
+			want: types.String("github.com"),
+		},
+		{
+			name: "repository from the path of a url",
+			expr: "split(parseURL(body.html_url).path, '/')[2]",
+			want: types.String("triggers"),
+		},
+		{
+			name: "query parameter of a url",
+			expr: "parseURL(body.html_url).query['tab'][0]",
+			want: types.String("files"),
+		},
+		{
+			name: "port and fragment of a url",
+			expr: "parseURL('http://localhost:8080/hook#x').port + parseURL(body.html_url).fragment",
+			want: types.String("8080diff"),
+		},
+		{
+			name: "valid url",
+			expr: "isURL(body.html_url)",
+			want: types.Bool(true),
+		},
+		{
+			name: "relative url",
+			expr: "isURL('/tektoncd/triggers')",
+			want: types.Bool(false),
+		},
+		{
+			name: "semver at least and not a prerelease",
+			expr: "semver(body.tag) >= semver('2.0.0') && semver(body.tag).prerelease() == ''",
+			want: types.Bool(true),
+		},
+		{
+			name: "semver prerelease precedence",
+			expr: "semver('2.0.0-rc.1') < semver('2.0.0') && semver('2.0.0-rc.2') > semver('2.0.0-rc.1')",
+			want: types.Bool(true),
+		},
+		{
+			name: "semver numeric precedence",
+			expr: "semver('1.10.0') > semver('1.9.0') && semver('1.9.0') <= semver('v1.9')",
+			want: types.Bool(true),
+		},
+		{
+			name: "semver equality ignores build metadata",
+			expr: "semver('1.2.3+build.5') == semver('v1.2.3') && semver('1.2.3') != semver('1.2.4')",
+			want: types.Bool(true),
+		},
+		{
+			name: "semver parts",
+			expr: "semver(body.tag).major() * 100 + semver(body.tag).minor() * 10 + semver(body.tag).patch()",
+			want: types.Int(210),
+		},
+		{
+			name: "semver prerelease",
+			expr: "semver('3.0.0-beta.1+exp').prerelease()",
+			want: types.String("beta.1"),
+		},
+		{
+			name: "semver guarded by isSemver",
+			expr: "isSemver('latest') || isSemver(body.tag) && semver(body.tag).major() == 2",
+			want: types.Bool(true),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(rt *testing.T) {
@@ -405,6 +614,22 @@ func TestExpressionEvaluation(t *testing.T) {
 	}
 }
 
+func TestCompile(t *testing.T) {
+	for _, expr := range []string{
+		`body.action == "opened" && header.match("X-GitHub-Event", "pull_request")`,
+		`trigger.name == "github-push" || trigger.namespace == "team-a"`,
+	} {
+		if err := Compile(expr); err != nil {
+			t.Errorf("Compile(%q) = %v", expr, err)
+		}
+	}
+	for _, expr := range []string{`body.action ==`, `unknown(body)`, `body.action.truncate("x")`} {
+		if err := Compile(expr); err == nil {
+			t.Errorf("Compile(%q) expected error", expr)
+		}
+	}
+}
+
 func TestExpressionEvaluation_Error(t *testing.T) {
 	testSHA := "ec26c3e57ca3a959ca5aad62de7213c562f8c821"
 	jsonMap := map[string]interface{}{
@@ -461,6 +686,16 @@ func TestExpressionEvaluation_Error(t *testing.T) {
 			expr: "body.canonical(52)",
 			want: "found no matching overload",
 		},
+		{
+			name: "negative truncate length",
+			expr: "truncate(body.sha, -1)",
+			want: "truncate length must not be negative",
+		},
+		{
+			name: "sanitizeK8sName length too short",
+			expr: "sanitizeK8sName(body.value, 5)",
+			want: "sanitizeK8sName length must be between 10 and 253, got 5",
+		},
 		{
 			name: "invalid base64 decoding",
 			expr: "decodeb64(\"AA=A\")",
@@ -471,6 +706,31 @@ func TestExpressionEvaluation_Error(t *testing.T) {
 			expr: "'testing'.compareSecret('testing', 'testSecret', 'mytoken')",
 			want: "failed to find secret.*testing.*",
 		},
+		{
+			name: "invalid url",
+			expr: "parseURL('http://[::1').host",
+			want: "failed to parse 'http://\\[::1' in parseURL",
+		},
+		{
+			name: "invalid semver",
+			expr: "semver('latest') > semver('1.0.0')",
+			want: "failed to parse 'latest' in semver",
+		},
+		{
+			name: "semver with empty build metadata",
+			expr: "semver('1.0.0+build..1').major()",
+			want: "failed to parse '1.0.0\\+build..1' in semver",
+		},
+		{
+			name: "semver compared with a string",
+			expr: "semver('1.0.0') > '0.9.0'",
+			want: "found no matching overload for '_>_'",
+		},
+		{
+			name: "jsonpath with a missing key",
+			expr: "jsonpath('.missing')",
+			want: "failed to evaluate '.missing' in jsonpath",
+		},
 		{
 			name:     "secret not in default ns",
 			expr:     "'testing'.compareSecret('testSecret', 'mytoken')",
@@ -507,6 +767,22 @@ func matchError(t *testing.T, s string, e error) bool {
 	return match
 }
 
+func TestCanonicalHeaders(t *testing.T) {
+	got := canonicalHeaders(http.Header{
+		"x-github-event":  []string{"push"},
+		"X-Hub-Signature": []string{"sha1=abc"},
+		"x-hub-signature": []string{"sha1=def"},
+	})
+	want := http.Header{
+		"X-Github-Event":  []string{"push"},
+		"X-Hub-Signature": []string{"sha1=abc", "sha1=def"},
+	}
+	sortValues := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	if diff := cmp.Diff(want, got, sortValues); diff != "" {
+		t.Errorf("canonicalHeaders() mismatch (-want +got): %s", diff)
+	}
+}
+
 func makeSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
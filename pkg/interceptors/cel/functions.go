@@ -1,9 +1,12 @@
 package cel
 
 import (
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 
@@ -11,11 +14,26 @@ import (
 	"github.com/google/cel-go/common/types/ref"
 	"github.com/google/cel-go/interpreter/functions"
 	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/template"
 	"k8s.io/client-go/kubernetes"
 
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 )
 
+const (
+	// maxNameLength is the maximum length of an RFC 1123 label, which is the
+	// default length that sanitizeK8sName truncates names to.
+	maxNameLength = 63
+	// maxSubdomainLength is the maximum length of an RFC 1123 subdomain, the
+	// longest name that most Kubernetes resources allow.
+	maxSubdomainLength = 253
+	// nameHashLength is the length of the hash suffix of truncated names.
+	nameHashLength = 8
+	// minNameLength leaves room for at least one character before the hash
+	// suffix of a truncated name.
+	minNameLength = nameHashLength + 2
+)
+
 func matchHeader(vals ...ref.Val) ref.Val {
 	h, err := vals[0].ConvertToNative(reflect.TypeOf(http.Header{}))
 	if err != nil {
@@ -46,7 +64,15 @@ func truncateString(lhs, rhs ref.Val) ref.Val {
 		return types.ValOrErr(n, "unexpected type '%v' passed to truncate", rhs.Type())
 	}
 
-	return types.String(str[:max(n, types.Int(len(str)))])
+	if n < 0 {
+		return types.NewErr("truncate length must not be negative, got %d", n)
+	}
+	// Truncate on rune boundaries so that multi-byte characters are not split.
+	runes := []rune(string(str))
+	if types.Int(len(runes)) <= n {
+		return str
+	}
+	return types.String(runes[:n])
 }
 
 func splitString(lhs, rhs ref.Val) ref.Val {
@@ -133,13 +159,132 @@ func makeCompareSecret(defaultNS string, k kubernetes.Interface) functions.Funct
 	}
 }
 
-func max(x, y types.Int) types.Int {
-	switch x.Compare(y) {
-	case types.IntNegOne:
-		return x
-	case types.IntOne:
-		return y
-	default:
-		return x
+// makeJSONPath returns a function that evaluates JSONPath expressions against
+// the event. Expressions wrapped in $() are evaluated exactly like they are in
+// TriggerBindings, e.g. $(body.a.b[0]) or $(header.X-Event), other expressions
+// are evaluated against the body, e.g. .a.b[0].
+func makeJSONPath(data map[string]interface{}) functions.UnaryOp {
+	event := map[string]interface{}{"body": data["body"]}
+	if h, ok := data["header"].(http.Header); ok {
+		joinedHeaders := make(map[string]string, len(h))
+		for k, v := range h {
+			joinedHeaders[k] = strings.Join(v, ",")
+		}
+		event["header"] = joinedHeaders
+	}
+	return func(val ref.Val) ref.Val {
+		expr, ok := val.(types.String)
+		if !ok {
+			return types.ValOrErr(expr, "unexpected type '%v' passed to jsonpath", val.Type())
+		}
+		var input interface{} = event
+		path := string(expr)
+		if !strings.HasPrefix(path, "$(") {
+			input = event["body"]
+			path = "$(" + path + ")"
+		}
+		res, err := template.ParseJSONPath(input, path)
+		if err != nil {
+			return types.NewErr("failed to evaluate '%s' in jsonpath: %w", expr, err)
+		}
+		return types.String(res)
+	}
+}
+
+// canonicalString converts a string into a valid RFC 1123 label, without
+// limiting its length: it is lowercased, every run of characters other than
+// a-z, 0-9 and '-' is replaced with a single '-', and leading and trailing
+// '-' are removed.
+func canonicalString(val ref.Val) ref.Val {
+	str, ok := val.(types.String)
+	if !ok {
+		return types.ValOrErr(str, "unexpected type '%v' passed to canonical", val.Type())
+	}
+	return types.String(canonicalName(string(str)))
+}
+
+func canonicalName(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// sanitizeK8sName converts a string into a valid Kubernetes resource name of
+// at most maxNameLength characters.
+func sanitizeK8sName(val ref.Val) ref.Val {
+	return sanitizeK8sNameLength(val, types.Int(maxNameLength))
+}
+
+// sanitizeK8sNameLength converts a string into a valid Kubernetes resource
+// name of at most the given length. Names that are too long are truncated and
+// given a hash suffix of the original string, so that different long strings
+// with the same prefix still produce different names.
+func sanitizeK8sNameLength(lhs, rhs ref.Val) ref.Val {
+	str, ok := lhs.(types.String)
+	if !ok {
+		return types.ValOrErr(str, "unexpected type '%v' passed to sanitizeK8sName", lhs.Type())
+	}
+	n, ok := rhs.(types.Int)
+	if !ok {
+		return types.ValOrErr(n, "unexpected type '%v' passed to sanitizeK8sName", rhs.Type())
+	}
+	if n < minNameLength || n > maxSubdomainLength {
+		return types.NewErr("sanitizeK8sName length must be between %d and %d, got %d", minNameLength, maxSubdomainLength, n)
+	}
+
+	sum := sha256.Sum256([]byte(str))
+	hash := hex.EncodeToString(sum[:])[:nameHashLength]
+	name := canonicalName(string(str))
+	if name == "" {
+		return types.String(hash)
+	}
+	if types.Int(len(name)) <= n {
+		return types.String(name)
+	}
+	prefix := strings.TrimRight(name[:int(n)-nameHashLength-1], "-")
+	return types.String(prefix + "-" + hash)
+}
+
+// parseURL parses a URL into a map of its parts, so that expressions can
+// extract e.g. the host, or the repository from the path of an HTML URL.
+func parseURL(val ref.Val) ref.Val {
+	str, ok := val.(types.String)
+	if !ok {
+		return types.ValOrErr(str, "unexpected type '%v' passed to parseURL", val.Type())
+	}
+	u, err := url.Parse(string(str))
+	if err != nil {
+		return types.NewErr("failed to parse '%v' in parseURL: %w", str, err)
+	}
+	return types.NewDynamicMap(types.NewRegistry(), map[string]interface{}{
+		"scheme":   u.Scheme,
+		"host":     u.Host,
+		"hostname": u.Hostname(),
+		"port":     u.Port(),
+		"path":     u.Path,
+		"rawQuery": u.RawQuery,
+		"query":    map[string][]string(u.Query()),
+		"fragment": u.Fragment,
+	})
+}
+
+// isURL reports whether a string is an absolute URL with a scheme and host.
+func isURL(val ref.Val) ref.Val {
+	str, ok := val.(types.String)
+	if !ok {
+		return types.ValOrErr(str, "unexpected type '%v' passed to isURL", val.Type())
 	}
+	u, err := url.Parse(string(str))
+	return types.Bool(err == nil && u.Scheme != "" && u.Host != "")
 }
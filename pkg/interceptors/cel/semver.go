@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/rogpeppe/go-internal/semver"
+)
+
+// semverType is the type of the values returned by semver(). Semvers can be
+// compared with the standard comparison operators, which dispatch to Compare.
+var (
+	semverType     = types.NewTypeValue("semver", traits.ComparerType)
+	semverDeclType = decls.NewAbstractType("semver")
+)
+
+// semverVal is a parsed semantic version. version is in the canonical form of
+// the semver package, with a leading v and without build metadata.
+type semverVal struct {
+	version string
+}
+
+// parseSemver parses a semantic version with or without a leading v. Missing
+// minor and patch versions default to 0, e.g. 1.2 is 1.2.0. Build metadata is
+// checked and dropped here, since the semver package rejects build metadata
+// with several identifiers, e.g. +build.5.
+func parseSemver(s string) (semverVal, error) {
+	v := s
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		if !validBuild(v[i+1:]) {
+			return semverVal{}, fmt.Errorf("invalid semantic version %q", s)
+		}
+		v = v[:i]
+	}
+	if !semver.IsValid(v) {
+		return semverVal{}, fmt.Errorf("invalid semantic version %q", s)
+	}
+	return semverVal{version: semver.Canonical(v)}, nil
+}
+
+// validBuild reports whether build metadata is a list of non-empty
+// identifiers of alphanumerics and hyphens separated by dots.
+func validBuild(build string) bool {
+	for _, id := range strings.Split(build, ".") {
+		if id == "" {
+			return false
+		}
+		for _, c := range id {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ConvertToNative implements ref.Val. Semvers are converted to strings, also
+// when they are the result of an overlay.
+func (s semverVal) ConvertToNative(typeDesc reflect.Type) (interface{}, error) {
+	switch {
+	case typeDesc.Kind() == reflect.String:
+		return s.String(), nil
+	case typeDesc == reflect.TypeOf(&structpb.Value{}):
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s.String()}}, nil
+	}
+	return nil, fmt.Errorf("type conversion error from semver to '%v'", typeDesc)
+}
+
+// ConvertToType implements ref.Val.
+func (s semverVal) ConvertToType(typeVal ref.Type) ref.Val {
+	switch typeVal {
+	case types.StringType:
+		return types.String(s.String())
+	case types.TypeType:
+		return semverType
+	}
+	return types.NewErr("type conversion error from semver to '%v'", typeVal)
+}
+
+// Equal implements ref.Val. Versions that only differ by build metadata are
+// equal.
+func (s semverVal) Equal(other ref.Val) ref.Val {
+	o, ok := other.(semverVal)
+	if !ok {
+		return types.ValOrErr(other, "no such overload")
+	}
+	return types.Bool(semver.Compare(s.version, o.version) == 0)
+}
+
+// Compare implements traits.Comparer with semantic version precedence.
+func (s semverVal) Compare(other ref.Val) ref.Val {
+	o, ok := other.(semverVal)
+	if !ok {
+		return types.ValOrErr(other, "no such overload")
+	}
+	return types.Int(semver.Compare(s.version, o.version))
+}
+
+// Type implements ref.Val.
+func (s semverVal) Type() ref.Type {
+	return semverType
+}
+
+// Value implements ref.Val.
+func (s semverVal) Value() interface{} {
+	return s.String()
+}
+
+// String returns the version without the leading v.
+func (s semverVal) String() string {
+	return strings.TrimPrefix(s.version, "v")
+}
+
+// part returns the major (0), minor (1) or patch (2) version.
+func (s semverVal) part(i int) ref.Val {
+	core := strings.TrimSuffix(s.String(), semver.Prerelease(s.version))
+	n, err := strconv.ParseInt(strings.SplitN(core, ".", 3)[i], 10, 64)
+	if err != nil {
+		return types.NewErr("failed to read version %q: %w", s, err)
+	}
+	return types.Int(n)
+}
+
+func parseSemverString(val ref.Val) ref.Val {
+	str, ok := val.(types.String)
+	if !ok {
+		return types.ValOrErr(str, "unexpected type '%v' passed to semver", val.Type())
+	}
+	v, err := parseSemver(string(str))
+	if err != nil {
+		return types.NewErr("failed to parse '%v' in semver: %w", str, err)
+	}
+	return v
+}
+
+// isSemver reports whether a string is a semantic version that semver()
+// accepts.
+func isSemver(val ref.Val) ref.Val {
+	str, ok := val.(types.String)
+	if !ok {
+		return types.ValOrErr(str, "unexpected type '%v' passed to isSemver", val.Type())
+	}
+	_, err := parseSemver(string(str))
+	return types.Bool(err == nil)
+}
+
+func makeSemverPart(name string, i int) func(ref.Val) ref.Val {
+	return func(val ref.Val) ref.Val {
+		v, ok := val.(semverVal)
+		if !ok {
+			return types.ValOrErr(val, "unexpected type '%v' passed to %s", val.Type(), name)
+		}
+		return v.part(i)
+	}
+}
+
+// semverPrerelease returns the prerelease of a version without the leading -,
+// or an empty string for releases.
+func semverPrerelease(val ref.Val) ref.Val {
+	v, ok := val.(semverVal)
+	if !ok {
+		return types.ValOrErr(val, "unexpected type '%v' passed to prerelease", val.Type())
+	}
+	return types.String(strings.TrimPrefix(semver.Prerelease(v.version), "-"))
+}
@@ -22,10 +22,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
-	gh "github.com/google/go-github/github"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/signature"
+	"github.com/tektoncd/triggers/pkg/payloads"
+	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
 )
@@ -59,23 +62,30 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 	}
 
 	// Validate secrets first before anything else, if set
-	if w.GitHub.SecretRef != nil {
-		header := request.Header.Get("X-Hub-Signature")
-		if header == "" {
-			return nil, errors.New("no X-Hub-Signature header set")
-		}
-		secretToken, err := interceptors.GetSecretToken(w.KubeClientSet, w.GitHub.SecretRef, w.EventListenerNamespace)
-		if err != nil {
+	if w.GitHub.SecretRef != nil && !interceptors.TrustedSender(request) {
+		if err := ValidateSignature(w.KubeClientSet, w.GitHub, w.EventListenerNamespace, request.Header, payload); err != nil {
 			return nil, err
 		}
-		if err := gh.ValidateSignature(header, payload, secretToken); err != nil {
-			return nil, err
+	}
+
+	if w.GitHub.InstallationTargets != nil {
+		targetType := request.Header.Get("X-GitHub-Hook-Installation-Target-Type")
+		targetID := request.Header.Get("X-GitHub-Hook-Installation-Target-ID")
+		isAllowed := false
+		for _, target := range w.GitHub.InstallationTargets {
+			if targetType == target.Type && (target.ID == "" || targetID == target.ID) {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return nil, fmt.Errorf("installation target %s %s is not allowed", targetType, targetID)
 		}
 	}
 
 	// Next see if the event type is in the allow-list
+	actualEvent := request.Header.Get("X-GitHub-Event")
 	if w.GitHub.EventTypes != nil {
-		actualEvent := request.Header.Get("X-GitHub-Event")
 		isAllowed := false
 		for _, allowedEvent := range w.GitHub.EventTypes {
 			if actualEvent == allowedEvent {
@@ -88,8 +98,96 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 		}
 	}
 
+	if f := w.GitHub.Ignore; f != nil {
+		if sender := gjson.GetBytes(payload, "sender.login").String(); f.IgnoresAccount(sender) {
+			return nil, fmt.Errorf("events of %s are ignored", sender)
+		}
+		if f.IgnoresMessage(commitMessage(actualEvent, payload)) {
+			return nil, fmt.Errorf("%s event asks to skip CI", actualEvent)
+		}
+	}
+
+	if w.GitHub.Tags != nil {
+		tag, ok := tagName(actualEvent, payload)
+		if !ok {
+			return nil, fmt.Errorf("%s event is not for a tag", actualEvent)
+		}
+		if !w.GitHub.Tags.Match(tag) {
+			return nil, fmt.Errorf("tag %s is not allowed", tag)
+		}
+	}
+
+	if w.GitHub.ReleaseActions != nil {
+		if actualEvent != "release" {
+			return nil, fmt.Errorf("%s event is not a release", actualEvent)
+		}
+		action := gjson.GetBytes(payload, "action").String()
+		isAllowed := false
+		for _, allowedAction := range w.GitHub.ReleaseActions {
+			if action == allowedAction {
+				isAllowed = true
+				break
+			}
+		}
+		if !isAllowed {
+			return nil, fmt.Errorf("release action %s is not allowed", action)
+		}
+	}
+
 	return &http.Response{
 		Header: request.Header,
 		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
 	}, nil
 }
+
+// tagName returns the name of the tag a push, create, delete or release event
+// is for.
+// ValidateSignature validates the signature of the payload of an event with the
+// secret of the GitHub interceptor, which must have a SecretRef.
+func ValidateSignature(k kubernetes.Interface, gh *triggersv1.GitHubInterceptor, ns string, h http.Header, payload []byte) error {
+	// The SHA-256 signature is preferred, SHA-1 is rejected in FIPS mode
+	header := h.Get("X-Hub-Signature-256")
+	if header == "" {
+		header = h.Get("X-Hub-Signature")
+	}
+	if header == "" {
+		return interceptors.AuthError(errors.New("no X-Hub-Signature header set"))
+	}
+	return interceptors.ValidateWithSecrets(k, "github", gh.SecretRef, gh.PreviousSecretRef, ns, func(secretToken []byte) error {
+		return signature.Validate(header, payload, secretToken)
+	})
+}
+
+func tagName(event string, payload []byte) (string, bool) {
+	switch e := payloads.GitHubEvent(event).(type) {
+	case *payloads.GitHubPushEvent:
+		if payloads.Decode(payload, e) == nil && strings.HasPrefix(e.Ref, "refs/tags/") {
+			return strings.TrimPrefix(e.Ref, "refs/tags/"), true
+		}
+	case *payloads.GitHubRefEvent:
+		if payloads.Decode(payload, e) == nil && e.RefType == "tag" {
+			return e.Ref, true
+		}
+	case *payloads.GitHubReleaseEvent:
+		if payloads.Decode(payload, e) == nil && e.Release.TagName != "" {
+			return e.Release.TagName, true
+		}
+	}
+	return "", false
+}
+
+// commitMessage returns the message of the head commit of a push event, or the
+// title of a pull_request event.
+func commitMessage(event string, payload []byte) string {
+	switch e := payloads.GitHubEvent(event).(type) {
+	case *payloads.GitHubPushEvent:
+		if payloads.Decode(payload, e) == nil && e.HeadCommit != nil {
+			return e.HeadCommit.Message
+		}
+	case *payloads.GitHubPullRequestEvent:
+		if payloads.Decode(payload, e) == nil {
+			return e.PullRequest.Title
+		}
+	}
+	return ""
+}
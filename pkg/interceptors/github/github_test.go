@@ -2,6 +2,7 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/logging"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
@@ -18,10 +20,13 @@ import (
 
 func TestInterceptor_ExecuteTrigger_Signature(t *testing.T) {
 	type args struct {
-		payload   io.ReadCloser
-		secret    *corev1.Secret
-		signature string
-		eventType string
+		payload      io.ReadCloser
+		secret       *corev1.Secret
+		signature    string
+		signature256 string
+		eventType    string
+		header       map[string]string
+		trusted      bool
 	}
 	tests := []struct {
 		name    string
@@ -40,6 +45,36 @@ func TestInterceptor_ExecuteTrigger_Signature(t *testing.T) {
 			want:    []byte("somepayload"),
 			wantErr: false,
 		},
+		{
+			name: "trusted sender without signature",
+			GitHub: &triggersv1.GitHubInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+			},
+			args: args{
+				payload: ioutil.NopCloser(bytes.NewBufferString("somepayload")),
+				trusted: true,
+			},
+			want: []byte("somepayload"),
+		},
+		{
+			name: "trusted sender with disallowed event type",
+			GitHub: &triggersv1.GitHubInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+				EventTypes: []string{"push"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString("somepayload")),
+				eventType: "pull_request",
+				trusted:   true,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid header for secret",
 			GitHub: &triggersv1.GitHubInterceptor{
@@ -87,6 +122,85 @@ func TestInterceptor_ExecuteTrigger_Signature(t *testing.T) {
 			wantErr: false,
 			want:    []byte("somepayload"),
 		},
+		{
+			name: "valid header for previous secret",
+			GitHub: &triggersv1.GitHubInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+				PreviousSecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "previous-token",
+				},
+			},
+			args: args{
+				signature: "sha1=38e005ef7dd3faee13204505532011257023654e",
+				secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mysecret",
+					},
+					Data: map[string][]byte{
+						"token":          []byte("newsecret"),
+						"previous-token": []byte("secret"),
+					},
+				},
+				payload: ioutil.NopCloser(bytes.NewBufferString("somepayload")),
+			},
+			wantErr: false,
+			want:    []byte("somepayload"),
+		},
+		{
+			name: "invalid header for current and previous secret",
+			GitHub: &triggersv1.GitHubInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+				PreviousSecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "previous-token",
+				},
+			},
+			args: args{
+				signature: "sha1=38e005ef7dd3faee13204505532011257023654e",
+				secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mysecret",
+					},
+					Data: map[string][]byte{
+						"token":          []byte("newsecret"),
+						"previous-token": []byte("oldsecret"),
+					},
+				},
+				payload: ioutil.NopCloser(bytes.NewBufferString("somepayload")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "SHA-256 signature is preferred",
+			GitHub: &triggersv1.GitHubInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+			},
+			args: args{
+				signature:    "sha1=invalid",
+				signature256: "sha256=2f6387035fee47c72cb461517ee7de9bb2f8bf72fd9dc637ed11863a38f5744f",
+				secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mysecret",
+					},
+					Data: map[string][]byte{
+						"token": []byte("secret"),
+					},
+				},
+				payload: ioutil.NopCloser(bytes.NewBufferString("somepayload")),
+			},
+			wantErr: false,
+			want:    []byte("somepayload"),
+		},
 		{
 			name: "no secret, matching event",
 			GitHub: &triggersv1.GitHubInterceptor{
@@ -186,6 +300,168 @@ func TestInterceptor_ExecuteTrigger_Signature(t *testing.T) {
 				payload:   ioutil.NopCloser(bytes.NewBufferString("somepayload")),
 			},
 			wantErr: true,
+		}, {
+			name: "tag push in semver range",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Tags: &triggersv1.TagFilter{SemverRange: ">=1.0.0 <2.0.0"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"refs/tags/v1.2.3"}`)),
+				eventType: "push",
+			},
+			want: []byte(`{"ref":"refs/tags/v1.2.3"}`),
+		}, {
+			name: "branch push with tag filter",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Tags: &triggersv1.TagFilter{SemverRange: ">=1.0.0 <2.0.0"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"refs/heads/main"}`)),
+				eventType: "push",
+			},
+			wantErr: true,
+		}, {
+			name: "release outside semver range",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Tags: &triggersv1.TagFilter{SemverRange: ">=1.0.0 <2.0.0"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"action":"published","release":{"tag_name":"v2.0.0"}}`)),
+				eventType: "release",
+			},
+			wantErr: true,
+		}, {
+			name: "tag created with matching prefix",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Tags: &triggersv1.TagFilter{Prefixes: []string{"release-"}, SemverRange: ">=1.0.0"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"release-1.4.0","ref_type":"tag"}`)),
+				eventType: "create",
+			},
+			want: []byte(`{"ref":"release-1.4.0","ref_type":"tag"}`),
+		}, {
+			name: "tag created without matching prefix",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Tags: &triggersv1.TagFilter{Prefixes: []string{"release-"}},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"v1.4.0","ref_type":"tag"}`)),
+				eventType: "create",
+			},
+			wantErr: true,
+		}, {
+			name: "push of an ignored bot",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{Accounts: []string{"dependabot[bot]"}},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"refs/heads/main","sender":{"login":"dependabot[bot]","type":"Bot"}}`)),
+				eventType: "push",
+			},
+			wantErr: true,
+		}, {
+			name: "push with skip ci",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{SkipCI: true},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"refs/heads/main","head_commit":{"message":"Fix typo [skip ci]"}}`)),
+				eventType: "push",
+			},
+			wantErr: true,
+		}, {
+			name: "pull request without skip ci",
+			GitHub: &triggersv1.GitHubInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{Accounts: []string{"dependabot[bot]"}, SkipCI: true},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"action":"opened","pull_request":{"title":"Fix typo"},"sender":{"login":"octocat"}}`)),
+				eventType: "pull_request",
+			},
+			want: []byte(`{"action":"opened","pull_request":{"title":"Fix typo"},"sender":{"login":"octocat"}}`),
+		}, {
+			name: "allowed release action",
+			GitHub: &triggersv1.GitHubInterceptor{
+				ReleaseActions: []string{"published", "prereleased"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"action":"prereleased","release":{"tag_name":"v1.0.0-rc.1"}}`)),
+				eventType: "release",
+			},
+			want: []byte(`{"action":"prereleased","release":{"tag_name":"v1.0.0-rc.1"}}`),
+		}, {
+			name: "release action not allowed",
+			GitHub: &triggersv1.GitHubInterceptor{
+				ReleaseActions: []string{"published", "prereleased"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"action":"edited","release":{"tag_name":"v1.0.0"}}`)),
+				eventType: "release",
+			},
+			wantErr: true,
+		}, {
+			name: "release actions with a push event",
+			GitHub: &triggersv1.GitHubInterceptor{
+				ReleaseActions: []string{"published"},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{"ref":"refs/tags/v1.0.0"}`)),
+				eventType: "push",
+			},
+			wantErr: true,
+		}, {
+			name: "allowed GitHub App",
+			GitHub: &triggersv1.GitHubInterceptor{
+				InstallationTargets: []triggersv1.GitHubInstallationTarget{{Type: "integration", ID: "12345"}, {Type: "repository"}},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+				eventType: "push",
+				header: map[string]string{
+					"X-GitHub-Hook-Installation-Target-Type": "integration",
+					"X-GitHub-Hook-Installation-Target-ID":   "12345",
+				},
+			},
+			want: []byte(`{}`),
+		}, {
+			name: "allowed installation target type",
+			GitHub: &triggersv1.GitHubInterceptor{
+				InstallationTargets: []triggersv1.GitHubInstallationTarget{{Type: "integration", ID: "12345"}, {Type: "repository"}},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+				eventType: "push",
+				header: map[string]string{
+					"X-GitHub-Hook-Installation-Target-Type": "repository",
+					"X-GitHub-Hook-Installation-Target-ID":   "67890",
+				},
+			},
+			want: []byte(`{}`),
+		}, {
+			name: "other GitHub App",
+			GitHub: &triggersv1.GitHubInterceptor{
+				InstallationTargets: []triggersv1.GitHubInstallationTarget{{Type: "integration", ID: "12345"}},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+				eventType: "push",
+				header: map[string]string{
+					"X-GitHub-Hook-Installation-Target-Type": "integration",
+					"X-GitHub-Hook-Installation-Target-ID":   "67890",
+				},
+			},
+			wantErr: true,
+		}, {
+			name: "no installation target",
+			GitHub: &triggersv1.GitHubInterceptor{
+				InstallationTargets: []triggersv1.GitHubInstallationTarget{{Type: "integration"}},
+			},
+			args: args{
+				payload:   ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+				eventType: "push",
+			},
+			wantErr: true,
 		}, {
 			name:   "nil body does not panic",
 			GitHub: &triggersv1.GitHubInterceptor{},
@@ -211,9 +487,18 @@ func TestInterceptor_ExecuteTrigger_Signature(t *testing.T) {
 			if tt.args.eventType != "" {
 				request.Header.Add("X-GITHUB-EVENT", tt.args.eventType)
 			}
+			if tt.args.trusted {
+				request = request.WithContext(interceptors.WithTrustedSender(context.Background()))
+			}
+			for k, v := range tt.args.header {
+				request.Header.Add(k, v)
+			}
 			if tt.args.signature != "" {
 				request.Header.Add("X-Hub-Signature", tt.args.signature)
 			}
+			if tt.args.signature256 != "" {
+				request.Header.Add("X-Hub-Signature-256", tt.args.signature256)
+			}
 			if tt.args.secret != nil {
 				ns := tt.GitHub.SecretRef.Namespace
 				if ns == "" {
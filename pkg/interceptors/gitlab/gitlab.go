@@ -17,12 +17,16 @@ limitations under the License.
 package gitlab
 
 import (
+	"bytes"
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/payloads"
+	"github.com/tidwall/gjson"
 
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 
@@ -48,24 +52,24 @@ func NewInterceptor(gl *triggersv1.GitLabInterceptor, k kubernetes.Interface, ns
 
 func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
 	// Validate the secret first, if set.
-	if w.GitLab.SecretRef != nil {
+	if w.GitLab.SecretRef != nil && !interceptors.TrustedSender(request) {
 		header := request.Header.Get("X-GitLab-Token")
 		if header == "" {
-			return nil, errors.New("no X-GitLab-Token header set")
+			return nil, interceptors.AuthError(errors.New("no X-GitLab-Token header set"))
 		}
 
-		secretToken, err := interceptors.GetSecretToken(w.KubeClientSet, w.GitLab.SecretRef, w.EventListenerNamespace)
-		if err != nil {
+		if err := interceptors.ValidateWithSecrets(w.KubeClientSet, "gitlab", w.GitLab.SecretRef, w.GitLab.PreviousSecretRef, w.EventListenerNamespace, func(secretToken []byte) error {
+			// Make sure to use a constant time comparison here.
+			if subtle.ConstantTimeCompare([]byte(header), secretToken) == 0 {
+				return errors.New("Invalid X-GitLab-Token")
+			}
+			return nil
+		}); err != nil {
 			return nil, err
 		}
-
-		// Make sure to use a constant time comparison here.
-		if subtle.ConstantTimeCompare([]byte(header), secretToken) == 0 {
-			return nil, errors.New("Invalid X-GitLab-Token")
-		}
 	}
+	actualEvent := request.Header.Get("X-GitLab-Event")
 	if w.GitLab.EventTypes != nil {
-		actualEvent := request.Header.Get("X-GitLab-Event")
 		isAllowed := false
 		for _, allowedEvent := range w.GitLab.EventTypes {
 			if actualEvent == allowedEvent {
@@ -78,8 +82,134 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 		}
 	}
 
+	// The payload is only read for the filters on its content
+	if w.GitLab.Statuses == nil && w.GitLab.Environments == nil && w.GitLab.Ignore == nil {
+		return &http.Response{
+			Header: request.Header,
+			Body:   request.Body,
+		}, nil
+	}
+	payload := []byte{}
+	if request.Body != nil {
+		defer request.Body.Close()
+		var err error
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	if f := w.GitLab.Ignore; f != nil {
+		if user := username(payload); f.IgnoresAccount(user) {
+			return nil, fmt.Errorf("events of %s are ignored", user)
+		}
+		if f.IgnoresMessage(commitMessage(actualEvent, payload)) {
+			return nil, fmt.Errorf("%s event asks to skip CI", actualEvent)
+		}
+	}
+
+	if w.GitLab.Statuses != nil {
+		status, ok := eventStatus(actualEvent, payload)
+		if !ok {
+			return nil, fmt.Errorf("%s event has no status", actualEvent)
+		}
+		if !contains(w.GitLab.Statuses, status) {
+			return nil, fmt.Errorf("status %s is not allowed", status)
+		}
+	}
+
+	if w.GitLab.Environments != nil {
+		env, ok := environmentName(actualEvent, payload)
+		if !ok {
+			return nil, fmt.Errorf("%s event is not for an environment", actualEvent)
+		}
+		if !contains(w.GitLab.Environments, env) {
+			return nil, fmt.Errorf("environment %s is not allowed", env)
+		}
+	}
+
 	return &http.Response{
 		Header: request.Header,
-		Body:   request.Body,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
 	}, nil
 }
+
+// eventStatus returns the status of the pipeline, job or deployment that a
+// Pipeline Hook, Job Hook or Deployment Hook event is for.
+func eventStatus(event string, payload []byte) (string, bool) {
+	var status string
+	switch e := payloads.GitLabEvent(event).(type) {
+	case *payloads.GitLabPipelineEvent:
+		if payloads.Decode(payload, e) == nil {
+			status = e.ObjectAttributes.Status
+		}
+	case *payloads.GitLabJobEvent:
+		if payloads.Decode(payload, e) == nil {
+			status = e.BuildStatus
+		}
+	case *payloads.GitLabDeploymentEvent:
+		if payloads.Decode(payload, e) == nil {
+			status = e.Status
+		}
+	}
+	return status, status != ""
+}
+
+// environmentName returns the name of the environment that a Job Hook or
+// Deployment Hook event is for. Jobs that do not deploy to an environment
+// have none.
+func environmentName(event string, payload []byte) (string, bool) {
+	var env string
+	switch e := payloads.GitLabEvent(event).(type) {
+	case *payloads.GitLabJobEvent:
+		if payloads.Decode(payload, e) == nil && e.Environment != nil {
+			env = e.Environment.Name
+		}
+	case *payloads.GitLabDeploymentEvent:
+		if payloads.Decode(payload, e) == nil {
+			env = e.Environment
+		}
+	}
+	return env, env != ""
+}
+
+// username returns the username of the user of an event. Push events set it
+// at the top level, other events in their user.
+func username(payload []byte) string {
+	for _, res := range gjson.GetManyBytes(payload, "user_username", "user.username") {
+		if res.String() != "" {
+			return res.String()
+		}
+	}
+	return ""
+}
+
+// commitMessage returns the message of the head commit of a Push Hook event,
+// or the title and last commit message of a Merge Request Hook event.
+func commitMessage(event string, payload []byte) string {
+	switch e := payloads.GitLabEvent(event).(type) {
+	case *payloads.GitLabPushEvent:
+		if payloads.Decode(payload, e) != nil {
+			return ""
+		}
+		for _, c := range e.Commits {
+			if c.ID == e.CheckoutSHA {
+				return c.Message
+			}
+		}
+	case *payloads.GitLabMergeRequestEvent:
+		if payloads.Decode(payload, e) == nil {
+			return e.ObjectAttributes.Title + "\n" + e.ObjectAttributes.LastCommit.Message
+		}
+	}
+	return ""
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
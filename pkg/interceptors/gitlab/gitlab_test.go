@@ -101,6 +101,61 @@ func TestInterceptor_ExecuteTrigger(t *testing.T) {
 			wantErr: false,
 			want:    []byte("somepayload"),
 		},
+		{
+			name: "valid header for previous secret",
+			GitLab: &triggersv1.GitLabInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+				PreviousSecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "previous-token",
+				},
+			},
+			args: args{
+				token: "secret",
+				secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mysecret",
+					},
+					Data: map[string][]byte{
+						"token":          []byte("newsecret"),
+						"previous-token": []byte("secret"),
+					},
+				},
+				payload: []byte("somepayload"),
+			},
+			wantErr: false,
+			want:    []byte("somepayload"),
+		},
+		{
+			name: "invalid header for current and previous secret",
+			GitLab: &triggersv1.GitLabInterceptor{
+				SecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "token",
+				},
+				PreviousSecretRef: &triggersv1.SecretRef{
+					SecretName: "mysecret",
+					SecretKey:  "previous-token",
+				},
+			},
+			args: args{
+				token: "secret",
+				secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mysecret",
+					},
+					Data: map[string][]byte{
+						"token":          []byte("newsecret"),
+						"previous-token": []byte("oldsecret"),
+					},
+				},
+				payload: []byte("somepayload"),
+			},
+			wantErr: true,
+		},
 		{
 			name: "valid event",
 			GitLab: &triggersv1.GitLabInterceptor{
@@ -196,6 +251,151 @@ func TestInterceptor_ExecuteTrigger(t *testing.T) {
 			},
 			want: []byte("somepayload"),
 		},
+		{
+			name: "allowed pipeline status",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Statuses: []string{"success", "failed"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"pipeline","object_attributes":{"status":"success"}}`),
+				eventType: "Pipeline Hook",
+			},
+			want: []byte(`{"object_kind":"pipeline","object_attributes":{"status":"success"}}`),
+		},
+		{
+			name: "pipeline status not allowed",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Statuses: []string{"failed"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"pipeline","object_attributes":{"status":"success"}}`),
+				eventType: "Pipeline Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "allowed job status",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Statuses: []string{"failed"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"build","build_status":"failed","environment":{"name":"staging"}}`),
+				eventType: "Job Hook",
+			},
+			want: []byte(`{"object_kind":"build","build_status":"failed","environment":{"name":"staging"}}`),
+		},
+		{
+			name: "status filter on event without status",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Statuses: []string{"success"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"push"}`),
+				eventType: "Push Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "allowed deployment status and environment",
+			GitLab: &triggersv1.GitLabInterceptor{
+				EventTypes:   []string{"Deployment Hook"},
+				Statuses:     []string{"success"},
+				Environments: []string{"production"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"deployment","status":"success","environment":"production"}`),
+				eventType: "Deployment Hook",
+			},
+			want: []byte(`{"object_kind":"deployment","status":"success","environment":"production"}`),
+		},
+		{
+			name: "push of an ignored bot",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{Accounts: []string{"renovate-bot"}},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"push","user_username":"renovate-bot"}`),
+				eventType: "Push Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "push with ci skip in the head commit",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{SkipCI: true},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"push","checkout_sha":"b","commits":[{"id":"a","message":"Fix build"},{"id":"b","message":"Update docs\n\n[ci skip]"}]}`),
+				eventType: "Push Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "push with skip ci in an older commit",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{SkipCI: true},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"push","checkout_sha":"b","commits":[{"id":"a","message":"Update docs [skip ci]"},{"id":"b","message":"Fix build"}]}`),
+				eventType: "Push Hook",
+			},
+			want: []byte(`{"object_kind":"push","checkout_sha":"b","commits":[{"id":"a","message":"Update docs [skip ci]"},{"id":"b","message":"Fix build"}]}`),
+		},
+		{
+			name: "merge request with skip ci in the last commit",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Ignore: &triggersv1.IgnoreFilter{Accounts: []string{"renovate-bot"}, SkipCI: true},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"merge_request","user":{"username":"jane"},"object_attributes":{"title":"Fix build","last_commit":{"message":"WIP [skip ci]"}}}`),
+				eventType: "Merge Request Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "deployment environment not allowed",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Environments: []string{"staging"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"deployment","status":"success","environment":"production"}`),
+				eventType: "Deployment Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "allowed job environment",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Environments: []string{"staging"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"build","build_status":"failed","environment":{"name":"staging"}}`),
+				eventType: "Job Hook",
+			},
+			want: []byte(`{"object_kind":"build","build_status":"failed","environment":{"name":"staging"}}`),
+		},
+		{
+			name: "environment filter on job without environment",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Environments: []string{"staging"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"build","build_status":"running","environment":null}`),
+				eventType: "Job Hook",
+			},
+			wantErr: true,
+		},
+		{
+			name: "environment filter on pipeline",
+			GitLab: &triggersv1.GitLabInterceptor{
+				Environments: []string{"production"},
+			},
+			args: args{
+				payload:   []byte(`{"object_kind":"pipeline","object_attributes":{"status":"success"}}`),
+				eventType: "Pipeline Hook",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -17,9 +17,12 @@ limitations under the License.
 package interceptors
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -29,6 +32,51 @@ type Interceptor interface {
 	ExecuteTrigger(req *http.Request) (*http.Response, error)
 }
 
+// ErrAuthFailed matches the errors of interceptors that rejected an event
+// because its signature or token is missing or invalid, e.g. to count the
+// failures of a source.
+var ErrAuthFailed = errors.New("authentication failed")
+
+// authError keeps the message of the interceptor and matches ErrAuthFailed.
+type authError struct {
+	err error
+}
+
+func (e *authError) Error() string {
+	return e.err.Error()
+}
+
+func (e *authError) Unwrap() error {
+	return e.err
+}
+
+func (e *authError) Is(target error) bool {
+	return target == ErrAuthFailed
+}
+
+// AuthError marks err as a failure to authenticate an event.
+func AuthError(err error) error {
+	return &authError{err: err}
+}
+
+type trustedSenderKey struct{}
+
+// WithTrustedSender marks the requests with the context as sent by a trusted
+// sender, whose events are authenticated before they reach the interceptors.
+func WithTrustedSender(ctx context.Context) context.Context {
+	return context.WithValue(ctx, trustedSenderKey{}, true)
+}
+
+// TrustedSender returns true if the request was sent by a trusted sender, in
+// which case interceptors skip validating its signature or token but still
+// apply their filters.
+func TrustedSender(req *http.Request) bool {
+	trusted, _ := req.Context().Value(trustedSenderKey{}).(bool)
+	return trusted
+}
+
+// GetSecretToken returns the value of the key of the Secret, which is redacted
+// from the logs of the process from then on.
 func GetSecretToken(cs kubernetes.Interface, sr *triggersv1.SecretRef, eventListenerNamespace string) ([]byte, error) {
 	ns := sr.Namespace
 	if ns == "" {
@@ -39,5 +87,6 @@ func GetSecretToken(cs kubernetes.Interface, sr *triggersv1.SecretRef, eventList
 		return nil, err
 	}
 
+	logging.Secrets.AddSecret(secret.Data[sr.SecretKey])
 	return secret.Data[sr.SecretKey], nil
 }
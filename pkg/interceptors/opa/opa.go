@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Interceptor struct {
+	HTTPClient             *http.Client
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	OPA                    *triggersv1.OPAInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(o *triggersv1.OPAInterceptor, c *http.Client, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		HTTPClient:             c,
+		KubeClientSet:          k,
+		Logger:                 l,
+		OPA:                    o,
+		EventListenerNamespace: ns,
+	}
+}
+
+// input is the document that the policy is evaluated against, available as
+// input.body and input.header in Rego.
+type input struct {
+	Body   json.RawMessage     `json:"body"`
+	Header map[string][]string `json:"header"`
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	if !json.Valid(payload) {
+		return nil, fmt.Errorf("event body is not valid JSON")
+	}
+
+	if w.OPA.PolicyRef != nil {
+		if err := w.putPolicy(request.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	allowed, err := w.evaluate(request.Context(), input{Body: payload, Header: request.Header})
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("event rejected by policy decision %s", w.decision())
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// putPolicy loads the Rego policy in the referenced ConfigMap into the OPA
+// server. The policy ID is derived from the ConfigMap, so that updating the
+// ConfigMap replaces the policy.
+func (w *Interceptor) putPolicy(ctx context.Context) error {
+	ref := w.OPA.PolicyRef
+	cm, err := w.KubeClientSet.CoreV1().ConfigMaps(w.EventListenerNamespace).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	policy, ok := cm.Data[ref.Key]
+	if !ok {
+		return fmt.Errorf("key %s not found in ConfigMap %s/%s", ref.Key, w.EventListenerNamespace, ref.Name)
+	}
+
+	id := strings.Join([]string{"triggers", w.EventListenerNamespace, ref.Name, ref.Key}, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, w.url("/v1/policies/"+id), strings.NewReader(policy))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to load policy %s: %s: %s", id, resp.Status, body)
+	}
+	return nil
+}
+
+// evaluate queries the decision with the Data API of the OPA server. An
+// undefined decision is an error, as it usually means that the policy is not
+// loaded.
+func (w *Interceptor) evaluate(ctx context.Context, in input) (bool, error) {
+	body, err := json.Marshal(map[string]input{"input": in})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url("/v1/data/"+w.decision()), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return false, fmt.Errorf("failed to evaluate policy decision %s: %s: %s", w.decision(), resp.Status, body)
+	}
+
+	var result struct {
+		Result *json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode policy decision %s: %w", w.decision(), err)
+	}
+	if result.Result == nil {
+		return false, fmt.Errorf("policy decision %s is undefined", w.decision())
+	}
+	var allowed bool
+	if err := json.Unmarshal(*result.Result, &allowed); err != nil {
+		return false, fmt.Errorf("policy decision %s is not a boolean: %s", w.decision(), *result.Result)
+	}
+	return allowed, nil
+}
+
+func (w *Interceptor) decision() string {
+	return strings.Trim(w.OPA.Decision, "/")
+}
+
+func (w *Interceptor) url(path string) string {
+	return strings.TrimSuffix(w.OPA.Server, "/") + path
+}
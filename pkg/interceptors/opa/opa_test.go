@@ -0,0 +1,208 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opa
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+// fakeOPA serves the OPA Data and Policy APIs. The triggers/allow decision
+// is true when the event has "allow": true and an X-Team header.
+type fakeOPA struct {
+	policies map[string]string
+}
+
+func (f *fakeOPA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	switch {
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/v1/policies/"):
+		f.policies[strings.TrimPrefix(r.URL.Path, "/v1/policies/")] = string(body)
+		w.Write([]byte(`{}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/data/triggers/allow":
+		var req struct {
+			Input struct {
+				Body struct {
+					Allow bool `json:"allow"`
+				} `json:"body"`
+				Header map[string][]string `json:"header"`
+			} `json:"input"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		allowed := req.Input.Body.Allow && len(req.Input.Header["X-Team"]) > 0
+		json.NewEncoder(w).Encode(map[string]bool{"result": allowed})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/data/triggers/count":
+		w.Write([]byte(`{"result": 3}`))
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/data/triggers/undefined":
+		w.Write([]byte(`{}`))
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	policy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "policy",
+			Namespace: "ns",
+		},
+		Data: map[string]string{
+			"policy.rego": "package triggers\n",
+		},
+	}
+	tests := []struct {
+		name         string
+		decision     string
+		policyRef    *corev1.ConfigMapKeySelector
+		payload      string
+		header       http.Header
+		wantPolicies map[string]string
+		wantErr      bool
+	}{{
+		name:     "allowed",
+		decision: "triggers/allow",
+		payload:  `{"allow": true}`,
+		header:   http.Header{"X-Team": []string{"a"}},
+	}, {
+		name:     "decision with slashes",
+		decision: "/triggers/allow/",
+		payload:  `{"allow": true}`,
+		header:   http.Header{"X-Team": []string{"a"}},
+	}, {
+		name:     "denied by body",
+		decision: "triggers/allow",
+		payload:  `{"allow": false}`,
+		header:   http.Header{"X-Team": []string{"a"}},
+		wantErr:  true,
+	}, {
+		name:     "denied by header",
+		decision: "triggers/allow",
+		payload:  `{"allow": true}`,
+		wantErr:  true,
+	}, {
+		name:     "undefined decision",
+		decision: "triggers/undefined",
+		payload:  `{}`,
+		wantErr:  true,
+	}, {
+		name:     "decision not a boolean",
+		decision: "triggers/count",
+		payload:  `{}`,
+		wantErr:  true,
+	}, {
+		name:     "unknown decision path",
+		decision: "other/allow",
+		payload:  `{}`,
+		wantErr:  true,
+	}, {
+		name:     "body not JSON",
+		decision: "triggers/allow",
+		payload:  `allow`,
+		wantErr:  true,
+	}, {
+		name:      "policy from ConfigMap",
+		decision:  "triggers/allow",
+		policyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "policy"}, Key: "policy.rego"},
+		payload:   `{"allow": true}`,
+		header:    http.Header{"X-Team": []string{"a"}},
+		wantPolicies: map[string]string{
+			"triggers/ns/policy/policy.rego": "package triggers\n",
+		},
+	}, {
+		name:      "missing ConfigMap",
+		decision:  "triggers/allow",
+		policyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "other"}, Key: "policy.rego"},
+		payload:   `{"allow": true}`,
+		header:    http.Header{"X-Team": []string{"a"}},
+		wantErr:   true,
+	}, {
+		name:      "missing ConfigMap key",
+		decision:  "triggers/allow",
+		policyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "policy"}, Key: "other.rego"},
+		payload:   `{"allow": true}`,
+		header:    http.Header{"X-Team": []string{"a"}},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			if _, err := kubeClient.CoreV1().ConfigMaps("ns").Create(policy); err != nil {
+				t.Fatal(err)
+			}
+			opa := &fakeOPA{policies: map[string]string{}}
+			ts := httptest.NewServer(opa)
+			defer ts.Close()
+
+			w := &Interceptor{
+				HTTPClient:    ts.Client(),
+				KubeClientSet: kubeClient,
+				OPA: &triggersv1.OPAInterceptor{
+					Server:    ts.URL + "/",
+					Decision:  tt.decision,
+					PolicyRef: tt.policyRef,
+				},
+				Logger:                 logger,
+				EventListenerNamespace: "ns",
+			}
+			request := &http.Request{
+				Body:   ioutil.NopCloser(bytes.NewReader([]byte(tt.payload))),
+				Header: tt.header,
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("Interceptor.ExecuteTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatal("Interceptor.ExecuteTrigger() did not return an error")
+			}
+			got, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response body %v", err)
+			}
+			if string(got) != tt.payload {
+				t.Errorf("Interceptor.ExecuteTrigger() = %s, want %s", got, tt.payload)
+			}
+			if tt.wantPolicies == nil {
+				tt.wantPolicies = map[string]string{}
+			}
+			if diff := cmp.Diff(tt.wantPolicies, opa.policies); diff != "" {
+				t.Errorf("loaded policies -want,+got: %s", diff)
+			}
+		})
+	}
+}
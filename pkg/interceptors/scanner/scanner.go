@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scanner
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/signature"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	// extensionsKey is where the vulnerabilities are added to the body.
+	extensionsKey = "extensions.scanner"
+)
+
+// severities ranks the normalized severities.
+var severities = map[string]int{
+	"unknown":  0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// Vulnerability is a vulnerable package reported by a scanner.
+type Vulnerability struct {
+	ID       string `json:"id"`
+	Title    string `json:"title,omitempty"`
+	Package  string `json:"package"`
+	Severity string `json:"severity"`
+	// Version is the vulnerable version, or range of versions for
+	// Dependabot alerts.
+	Version      string `json:"version,omitempty"`
+	FixedVersion string `json:"fixedVersion,omitempty"`
+}
+
+// Report holds the vulnerabilities of an event, normalized across scanners.
+type Report struct {
+	// Source is snyk, dependabot or trivy.
+	Source string `json:"source"`
+	// Severity is the highest severity of the vulnerabilities.
+	Severity string `json:"severity"`
+	// Packages are the names of the vulnerable packages.
+	Packages        []string        `json:"packages"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+type Interceptor struct {
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	Scanner                *triggersv1.ScannerInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(s *triggersv1.ScannerInterceptor, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		Logger:                 l,
+		Scanner:                s,
+		KubeClientSet:          k,
+		EventListenerNamespace: ns,
+	}
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	// Validate the signature or token first, if set.
+	if w.Scanner.SecretRef != nil && !interceptors.TrustedSender(request) {
+		secretToken, err := interceptors.GetSecretToken(w.KubeClientSet, w.Scanner.SecretRef, w.EventListenerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(request.Header, payload, secretToken); err != nil {
+			return nil, interceptors.AuthError(err)
+		}
+	}
+
+	report, err := parseReport(request.Header, payload)
+	if err != nil {
+		return nil, err
+	}
+	if w.Scanner.MinSeverity != "" {
+		report = report.filter(w.Scanner.MinSeverity)
+	}
+	if len(report.Vulnerabilities) == 0 {
+		return nil, fmt.Errorf("no %s vulnerabilities with severity %s or higher", report.Source, minSeverity(w.Scanner.MinSeverity))
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal vulnerabilities: %w", err)
+	}
+	payload, err = sjson.SetRawBytes(payload, extensionsKey, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add vulnerabilities to the body: %w", err)
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// validate checks the HMAC signature of Snyk and GitHub events, or the bearer
+// token of other events.
+func validate(header http.Header, payload, secretToken []byte) error {
+	for _, h := range []string{"X-Hub-Signature-256", "X-Hub-Signature"} {
+		if sig := header.Get(h); sig != "" {
+			return signature.Validate(sig, payload, secretToken)
+		}
+	}
+	auth := header.Get(authorizationHeader)
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return fmt.Errorf("no X-Hub-Signature header or bearer token in %s header set", authorizationHeader)
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, bearerPrefix)), secretToken) == 0 {
+		return errors.New("invalid bearer token")
+	}
+	return nil
+}
+
+// parseReport returns the vulnerabilities of a Snyk project snapshot, which
+// has an X-Snyk-Event header, of a GitHub dependabot_alert event or of a Trivy
+// operator VulnerabilityReport.
+func parseReport(header http.Header, payload []byte) (Report, error) {
+	body := gjson.ParseBytes(payload)
+	var r Report
+	switch {
+	case header.Get("X-Snyk-Event") != "":
+		r.Source = "snyk"
+		// Only the issues that are new since the last snapshot
+		body.Get("newIssues").ForEach(func(_, issue gjson.Result) bool {
+			r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+				ID:           issue.Get("id").String(),
+				Title:        issue.Get("issueData.title").String(),
+				Package:      issue.Get("pkgName").String(),
+				Severity:     normalizeSeverity(issue.Get("issueData.severity").String()),
+				Version:      issue.Get("pkgVersions.0").String(),
+				FixedVersion: issue.Get("fixInfo.nearestFixedInVersion").String(),
+			})
+			return true
+		})
+	case header.Get("X-GitHub-Event") == "dependabot_alert":
+		r.Source = "dependabot"
+		// Alerts that were fixed or dismissed don't need patching
+		switch body.Get("action").String() {
+		case "created", "reopened", "reintroduced":
+			alert := body.Get("alert")
+			r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+				ID:           alert.Get("security_advisory.ghsa_id").String(),
+				Title:        alert.Get("security_advisory.summary").String(),
+				Package:      alert.Get("security_vulnerability.package.name").String(),
+				Severity:     normalizeSeverity(alert.Get("security_vulnerability.severity").String()),
+				Version:      alert.Get("security_vulnerability.vulnerable_version_range").String(),
+				FixedVersion: alert.Get("security_vulnerability.first_patched_version.identifier").String(),
+			})
+		}
+	case body.Get("kind").String() == "VulnerabilityReport":
+		r.Source = "trivy"
+		body.Get("report.vulnerabilities").ForEach(func(_, v gjson.Result) bool {
+			r.Vulnerabilities = append(r.Vulnerabilities, Vulnerability{
+				ID:           v.Get("vulnerabilityID").String(),
+				Title:        v.Get("title").String(),
+				Package:      v.Get("resource").String(),
+				Severity:     normalizeSeverity(v.Get("severity").String()),
+				Version:      v.Get("installedVersion").String(),
+				FixedVersion: v.Get("fixedVersion").String(),
+			})
+			return true
+		})
+	default:
+		return Report{}, errors.New("event is not a Snyk, Dependabot alert or Trivy VulnerabilityReport event")
+	}
+	r.summarize()
+	return r, nil
+}
+
+// filter returns the report with only the vulnerabilities of at least the
+// given severity.
+func (r Report) filter(min string) Report {
+	filtered := Report{Source: r.Source}
+	for _, v := range r.Vulnerabilities {
+		if severities[v.Severity] >= severities[min] {
+			filtered.Vulnerabilities = append(filtered.Vulnerabilities, v)
+		}
+	}
+	filtered.summarize()
+	return filtered
+}
+
+// summarize sets the highest severity and the vulnerable packages.
+func (r *Report) summarize() {
+	r.Severity = "unknown"
+	seen := map[string]bool{}
+	r.Packages = []string{}
+	for _, v := range r.Vulnerabilities {
+		if severities[v.Severity] > severities[r.Severity] {
+			r.Severity = v.Severity
+		}
+		if v.Package != "" && !seen[v.Package] {
+			seen[v.Package] = true
+			r.Packages = append(r.Packages, v.Package)
+		}
+	}
+	sort.Strings(r.Packages)
+}
+
+// normalizeSeverity lowercases the severity of a scanner, and maps GitHub's
+// moderate to medium.
+func normalizeSeverity(s string) string {
+	s = strings.ToLower(s)
+	if s == "moderate" {
+		return "medium"
+	}
+	if _, ok := severities[s]; !ok {
+		return "unknown"
+	}
+	return s
+}
+
+func minSeverity(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scanner
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	snykPayload = `{"project":{"name":"checkout"},"newIssues":[` +
+		`{"id":"SNYK-JS-LODASH-567746","pkgName":"lodash","pkgVersions":["4.17.15"],"issueData":{"title":"Prototype Pollution","severity":"high"},"fixInfo":{"nearestFixedInVersion":"4.17.19"}},` +
+		`{"id":"SNYK-JS-MINIMIST-559764","pkgName":"minimist","pkgVersions":["1.2.0"],"issueData":{"title":"Prototype Pollution","severity":"medium"},"fixInfo":{"nearestFixedInVersion":"1.2.3"}}` +
+		`],"removedIssues":[]}`
+	dependabotPayload = `{"action":"created","alert":{"security_advisory":{"ghsa_id":"GHSA-jf85-cpcp-j695","summary":"Prototype Pollution in lodash","severity":"critical"},` +
+		`"security_vulnerability":{"package":{"ecosystem":"npm","name":"lodash"},"severity":"critical","vulnerable_version_range":"< 4.17.12","first_patched_version":{"identifier":"4.17.12"}}}}`
+	trivyPayload = `{"apiVersion":"aquasecurity.github.io/v1alpha1","kind":"VulnerabilityReport","report":{"vulnerabilities":[` +
+		`{"vulnerabilityID":"CVE-2022-0778","resource":"libssl1.1","installedVersion":"1.1.1k","fixedVersion":"1.1.1n","severity":"HIGH","title":"openssl: Infinite loop in BN_mod_sqrt()"},` +
+		`{"vulnerabilityID":"CVE-2021-3999","resource":"libc6","installedVersion":"2.31","severity":"LOW"}` +
+		`]}}`
+)
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte("secrettoken"))
+	_, _ = mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysecret",
+		},
+		Data: map[string][]byte{
+			"token": []byte("secrettoken"),
+		},
+	}
+	secretRef := &triggersv1.SecretRef{
+		SecretName: "mysecret",
+		SecretKey:  "token",
+	}
+	lodash := Vulnerability{
+		ID:           "SNYK-JS-LODASH-567746",
+		Title:        "Prototype Pollution",
+		Package:      "lodash",
+		Severity:     "high",
+		Version:      "4.17.15",
+		FixedVersion: "4.17.19",
+	}
+	minimist := Vulnerability{
+		ID:           "SNYK-JS-MINIMIST-559764",
+		Title:        "Prototype Pollution",
+		Package:      "minimist",
+		Severity:     "medium",
+		Version:      "1.2.0",
+		FixedVersion: "1.2.3",
+	}
+	libssl := Vulnerability{
+		ID:           "CVE-2022-0778",
+		Title:        "openssl: Infinite loop in BN_mod_sqrt()",
+		Package:      "libssl1.1",
+		Severity:     "high",
+		Version:      "1.1.1k",
+		FixedVersion: "1.1.1n",
+	}
+	libc := Vulnerability{
+		ID:       "CVE-2021-3999",
+		Package:  "libc6",
+		Severity: "low",
+		Version:  "2.31",
+	}
+
+	tests := []struct {
+		name    string
+		Scanner *triggersv1.ScannerInterceptor
+		payload string
+		header  http.Header
+		want    *Report
+	}{{
+		name:    "Snyk",
+		Scanner: &triggersv1.ScannerInterceptor{},
+		payload: snykPayload,
+		header:  http.Header{"X-Snyk-Event": []string{"project_snapshot/v0"}},
+		want: &Report{
+			Source:          "snyk",
+			Severity:        "high",
+			Packages:        []string{"lodash", "minimist"},
+			Vulnerabilities: []Vulnerability{lodash, minimist},
+		},
+	}, {
+		name:    "Snyk with minimum severity",
+		Scanner: &triggersv1.ScannerInterceptor{MinSeverity: "high"},
+		payload: snykPayload,
+		header:  http.Header{"X-Snyk-Event": []string{"project_snapshot/v0"}},
+		want: &Report{
+			Source:          "snyk",
+			Severity:        "high",
+			Packages:        []string{"lodash"},
+			Vulnerabilities: []Vulnerability{lodash},
+		},
+	}, {
+		name:    "Snyk below minimum severity",
+		Scanner: &triggersv1.ScannerInterceptor{MinSeverity: "critical"},
+		payload: snykPayload,
+		header:  http.Header{"X-Snyk-Event": []string{"project_snapshot/v0"}},
+	}, {
+		name:    "Snyk with valid signature",
+		Scanner: &triggersv1.ScannerInterceptor{SecretRef: secretRef, MinSeverity: "high"},
+		payload: snykPayload,
+		header:  http.Header{"X-Snyk-Event": []string{"project_snapshot/v0"}, "X-Hub-Signature": []string{sign(snykPayload)}},
+		want: &Report{
+			Source:          "snyk",
+			Severity:        "high",
+			Packages:        []string{"lodash"},
+			Vulnerabilities: []Vulnerability{lodash},
+		},
+	}, {
+		name:    "Snyk with invalid signature",
+		Scanner: &triggersv1.ScannerInterceptor{SecretRef: secretRef},
+		payload: snykPayload,
+		header:  http.Header{"X-Snyk-Event": []string{"project_snapshot/v0"}, "X-Hub-Signature": []string{sign("{}")}},
+	}, {
+		name:    "Dependabot alert",
+		Scanner: &triggersv1.ScannerInterceptor{SecretRef: secretRef},
+		payload: dependabotPayload,
+		header:  http.Header{"X-Github-Event": []string{"dependabot_alert"}, "X-Hub-Signature-256": []string{sign(dependabotPayload)}},
+		want: &Report{
+			Source:   "dependabot",
+			Severity: "critical",
+			Packages: []string{"lodash"},
+			Vulnerabilities: []Vulnerability{{
+				ID:           "GHSA-jf85-cpcp-j695",
+				Title:        "Prototype Pollution in lodash",
+				Package:      "lodash",
+				Severity:     "critical",
+				Version:      "< 4.17.12",
+				FixedVersion: "4.17.12",
+			}},
+		},
+	}, {
+		name:    "fixed Dependabot alert",
+		Scanner: &triggersv1.ScannerInterceptor{},
+		payload: `{"action":"fixed","alert":{}}`,
+		header:  http.Header{"X-Github-Event": []string{"dependabot_alert"}},
+	}, {
+		name:    "Trivy VulnerabilityReport",
+		Scanner: &triggersv1.ScannerInterceptor{},
+		payload: trivyPayload,
+		want: &Report{
+			Source:          "trivy",
+			Severity:        "high",
+			Packages:        []string{"libc6", "libssl1.1"},
+			Vulnerabilities: []Vulnerability{libssl, libc},
+		},
+	}, {
+		name:    "Trivy VulnerabilityReport with bearer token",
+		Scanner: &triggersv1.ScannerInterceptor{SecretRef: secretRef, MinSeverity: "medium"},
+		payload: trivyPayload,
+		header:  http.Header{"Authorization": []string{"Bearer secrettoken"}},
+		want: &Report{
+			Source:          "trivy",
+			Severity:        "high",
+			Packages:        []string{"libssl1.1"},
+			Vulnerabilities: []Vulnerability{libssl},
+		},
+	}, {
+		name:    "Trivy VulnerabilityReport without bearer token",
+		Scanner: &triggersv1.ScannerInterceptor{SecretRef: secretRef},
+		payload: trivyPayload,
+	}, {
+		name:    "unknown event",
+		Scanner: &triggersv1.ScannerInterceptor{},
+		payload: `{"kind":"ConfigAuditReport"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			if _, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(secret); err != nil {
+				t.Error(err)
+			}
+			request := &http.Request{
+				Body:   ioutil.NopCloser(bytes.NewReader([]byte(tt.payload))),
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+			}
+			for k, v := range tt.header {
+				request.Header[k] = v
+			}
+			w := &Interceptor{
+				KubeClientSet:          kubeClient,
+				Scanner:                tt.Scanner,
+				Logger:                 logger,
+				EventListenerNamespace: metav1.NamespaceDefault,
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if tt.want != nil {
+					t.Errorf("Interceptor.ExecuteTrigger() unexpected error: %v", err)
+				}
+				return
+			}
+			if tt.want == nil {
+				t.Fatalf("Interceptor.ExecuteTrigger() expected error, got none")
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response: %v", err)
+			}
+			defer resp.Body.Close()
+			if !gjson.GetBytes(body, "extensions.scanner").Exists() {
+				t.Fatalf("Interceptor.ExecuteTrigger() = %s, want extensions.scanner", body)
+			}
+			// The original body is kept
+			for key, value := range gjson.Parse(tt.payload).Map() {
+				if gjson.GetBytes(body, key).Raw != value.Raw {
+					t.Errorf("Interceptor.ExecuteTrigger() changed %s", key)
+				}
+			}
+			var got Report
+			if err := json.Unmarshal([]byte(gjson.GetBytes(body, "extensions.scanner").Raw), &got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(*tt.want, got); diff != "" {
+				t.Errorf("-want +got: %s", diff)
+			}
+		})
+	}
+}
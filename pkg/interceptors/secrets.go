@@ -0,0 +1,69 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The versions of a secret that an event can be validated with.
+const (
+	CurrentSecret  = "current"
+	PreviousSecret = "previous"
+)
+
+var secretMatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "eventlistener_interceptor_secret_matches_total",
+	Help: "Number of events validated by an interceptor, by the version of the secret that matched.",
+}, []string{"interceptor", "secret"})
+
+func init() {
+	prometheus.MustRegister(secretMatches)
+}
+
+// ValidateWithSecrets validates an event with the secret referenced by
+// current and, during a rotation, with the secret referenced by previous.
+// The version of the secret that matched is counted, so that operators know
+// when the previous secret is no longer used and the rotation is complete.
+// If neither secret matches, the error of the current secret is returned as
+// an AuthError.
+func ValidateWithSecrets(cs kubernetes.Interface, interceptor string, current, previous *triggersv1.SecretRef, ns string, validate func(secretToken []byte) error) error {
+	secretToken, err := GetSecretToken(cs, current, ns)
+	if err != nil {
+		return err
+	}
+	validationErr := validate(secretToken)
+	if validationErr == nil {
+		secretMatches.WithLabelValues(interceptor, CurrentSecret).Inc()
+		return nil
+	}
+	if previous == nil {
+		return AuthError(validationErr)
+	}
+
+	previousToken, err := GetSecretToken(cs, previous, ns)
+	if err != nil {
+		return err
+	}
+	if err := validate(previousToken); err != nil {
+		return AuthError(validationErr)
+	}
+	secretMatches.WithLabelValues(interceptor, PreviousSecret).Inc()
+	return nil
+}
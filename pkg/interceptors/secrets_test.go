@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interceptors
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+)
+
+func secretMatchesValue(t *testing.T, interceptor, secret string) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := secretMatches.WithLabelValues(interceptor, secret).Write(&m); err != nil {
+		t.Fatalf("Error reading counter: %s", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestValidateWithSecrets(t *testing.T) {
+	cs := fakekubeclientset.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mysecret",
+			Namespace: "ns",
+		},
+		Data: map[string][]byte{
+			"token":          []byte("new"),
+			"previous-token": []byte("old"),
+		},
+	})
+	current := &triggersv1.SecretRef{SecretName: "mysecret", SecretKey: "token"}
+	previous := &triggersv1.SecretRef{SecretName: "mysecret", SecretKey: "previous-token"}
+	missing := &triggersv1.SecretRef{SecretName: "missing", SecretKey: "token"}
+
+	tests := []struct {
+		name         string
+		previous     *triggersv1.SecretRef
+		token        string
+		wantErr      bool
+		wantAuthErr  bool
+		wantCurrent  float64
+		wantPrevious float64
+	}{{
+		name:        "current secret",
+		previous:    previous,
+		token:       "new",
+		wantCurrent: 1,
+	}, {
+		name:         "previous secret",
+		previous:     previous,
+		token:        "old",
+		wantPrevious: 1,
+	}, {
+		name:        "previous secret not set",
+		token:       "old",
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:        "no secret matches",
+		previous:    previous,
+		token:       "other",
+		wantErr:     true,
+		wantAuthErr: true,
+	}, {
+		name:     "previous secret not found",
+		previous: missing,
+		token:    "old",
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := "test-" + tt.name
+			err := ValidateWithSecrets(cs, interceptor, current, tt.previous, "ns", func(secretToken []byte) error {
+				if !bytes.Equal(secretToken, []byte(tt.token)) {
+					return errors.New("invalid token")
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateWithSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrAuthFailed); got != tt.wantAuthErr {
+				t.Errorf("ValidateWithSecrets() error %v is ErrAuthFailed: %t, want %t", err, got, tt.wantAuthErr)
+			}
+			if got := secretMatchesValue(t, interceptor, CurrentSecret); got != tt.wantCurrent {
+				t.Errorf("current secret matches = %v, want %v", got, tt.wantCurrent)
+			}
+			if got := secretMatchesValue(t, interceptor, PreviousSecret); got != tt.wantPrevious {
+				t.Errorf("previous secret matches = %v, want %v", got, tt.wantPrevious)
+			}
+		})
+	}
+}
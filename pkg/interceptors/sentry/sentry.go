@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sentry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/signature"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	signatureHeader = "Sentry-Hook-Signature"
+	resourceHeader  = "Sentry-Hook-Resource"
+)
+
+// projectPaths and levelPaths are the locations of the project and level
+// fields in the payloads of the different Sentry resource types.
+var (
+	projectPaths = []string{"data.issue.project.slug", "data.issue.project.id", "data.error.project", "data.event.project", "data.metric_alert.projects"}
+	levelPaths   = []string{"data.issue.level", "data.error.level", "data.event.level"}
+)
+
+type Interceptor struct {
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	Sentry                 *triggersv1.SentryInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(s *triggersv1.SentryInterceptor, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		Logger:                 l,
+		Sentry:                 s,
+		KubeClientSet:          k,
+		EventListenerNamespace: ns,
+	}
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	// Validate the signature first, if set.
+	if w.Sentry.SecretRef != nil && !interceptors.TrustedSender(request) {
+		header := request.Header.Get(signatureHeader)
+		if header == "" {
+			return nil, interceptors.AuthError(fmt.Errorf("no %s header set", signatureHeader))
+		}
+		secretToken, err := interceptors.GetSecretToken(w.KubeClientSet, w.Sentry.SecretRef, w.EventListenerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateSignature(header, payload, secretToken); err != nil {
+			return nil, interceptors.AuthError(err)
+		}
+	}
+
+	if w.Sentry.ResourceTypes != nil {
+		actualResource := request.Header.Get(resourceHeader)
+		if !contains(w.Sentry.ResourceTypes, actualResource) {
+			return nil, fmt.Errorf("resource type %s is not allowed", actualResource)
+		}
+	}
+
+	if w.Sentry.Projects != nil {
+		if !matchAny(payload, projectPaths, w.Sentry.Projects) {
+			return nil, errors.New("event project is not allowed")
+		}
+	}
+
+	if w.Sentry.Levels != nil {
+		if !matchAny(payload, levelPaths, w.Sentry.Levels) {
+			return nil, errors.New("event level is not allowed")
+		}
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// validateSignature checks that the signature is the hex encoded HMAC-SHA256
+// of the payload keyed with the integration's client secret.
+func validateSignature(digest string, payload, secret []byte) error {
+	if err := signature.ValidateHex(signature.SHA256, digest, payload, secret); err != nil {
+		return fmt.Errorf("invalid %s: %w", signatureHeader, err)
+	}
+	return nil
+}
+
+// matchAny returns true if the value at any of the paths in the payload is
+// one of the allowed values. Array values match if any element does.
+func matchAny(payload []byte, paths, allowed []string) bool {
+	for _, res := range gjson.GetManyBytes(payload, paths...) {
+		if res.IsArray() {
+			for _, r := range res.Array() {
+				if contains(allowed, r.String()) {
+					return true
+				}
+			}
+			continue
+		}
+		if res.Exists() && contains(allowed, res.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(allowed []string, s string) bool {
+	for _, a := range allowed {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
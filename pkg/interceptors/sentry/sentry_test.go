@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sentry
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/logging"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const issuePayload = `{"action":"created","data":{"issue":{"level":"error","project":{"id":"2","slug":"backend"}}}}`
+
+func sign(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysecret",
+		},
+		Data: map[string][]byte{
+			"token": []byte("secrettoken"),
+		},
+	}
+	secretRef := &triggersv1.SecretRef{
+		SecretName: "mysecret",
+		SecretKey:  "token",
+	}
+	type args struct {
+		payload   string
+		secret    *corev1.Secret
+		signature string
+		resource  string
+	}
+	tests := []struct {
+		name    string
+		Sentry  *triggersv1.SentryInterceptor
+		args    args
+		wantErr bool
+	}{{
+		name:   "no secret",
+		Sentry: &triggersv1.SentryInterceptor{},
+		args: args{
+			payload: issuePayload,
+		},
+	}, {
+		name:   "missing signature",
+		Sentry: &triggersv1.SentryInterceptor{SecretRef: secretRef},
+		args: args{
+			payload: issuePayload,
+			secret:  secret,
+		},
+		wantErr: true,
+	}, {
+		name:   "invalid signature",
+		Sentry: &triggersv1.SentryInterceptor{SecretRef: secretRef},
+		args: args{
+			payload:   issuePayload,
+			secret:    secret,
+			signature: sign(issuePayload, "othersecret"),
+		},
+		wantErr: true,
+	}, {
+		name:   "valid signature",
+		Sentry: &triggersv1.SentryInterceptor{SecretRef: secretRef},
+		args: args{
+			payload:   issuePayload,
+			secret:    secret,
+			signature: sign(issuePayload, "secrettoken"),
+		},
+	}, {
+		name:   "allowed resource type",
+		Sentry: &triggersv1.SentryInterceptor{ResourceTypes: []string{"issue", "error"}},
+		args: args{
+			payload:  issuePayload,
+			resource: "issue",
+		},
+	}, {
+		name:   "disallowed resource type",
+		Sentry: &triggersv1.SentryInterceptor{ResourceTypes: []string{"metric_alert"}},
+		args: args{
+			payload:  issuePayload,
+			resource: "issue",
+		},
+		wantErr: true,
+	}, {
+		name:   "allowed project slug",
+		Sentry: &triggersv1.SentryInterceptor{Projects: []string{"backend"}},
+		args: args{
+			payload: issuePayload,
+		},
+	}, {
+		name:   "allowed project id",
+		Sentry: &triggersv1.SentryInterceptor{Projects: []string{"2"}},
+		args: args{
+			payload: issuePayload,
+		},
+	}, {
+		name:   "allowed metric alert project",
+		Sentry: &triggersv1.SentryInterceptor{Projects: []string{"backend"}},
+		args: args{
+			payload: `{"data":{"metric_alert":{"projects":["frontend","backend"]}}}`,
+		},
+	}, {
+		name:   "disallowed project",
+		Sentry: &triggersv1.SentryInterceptor{Projects: []string{"frontend"}},
+		args: args{
+			payload: issuePayload,
+		},
+		wantErr: true,
+	}, {
+		name:   "allowed level",
+		Sentry: &triggersv1.SentryInterceptor{Levels: []string{"error", "fatal"}},
+		args: args{
+			payload: issuePayload,
+		},
+	}, {
+		name:   "disallowed level",
+		Sentry: &triggersv1.SentryInterceptor{Levels: []string{"fatal"}},
+		args: args{
+			payload: issuePayload,
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			request := &http.Request{
+				Body: ioutil.NopCloser(bytes.NewReader([]byte(tt.args.payload))),
+				Header: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			}
+			if tt.args.signature != "" {
+				request.Header.Add("Sentry-Hook-Signature", tt.args.signature)
+			}
+			if tt.args.resource != "" {
+				request.Header.Add("Sentry-Hook-Resource", tt.args.resource)
+			}
+			if tt.args.secret != nil {
+				if _, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(tt.args.secret); err != nil {
+					t.Error(err)
+				}
+			}
+			w := &Interceptor{
+				KubeClientSet:          kubeClient,
+				Sentry:                 tt.Sentry,
+				Logger:                 logger,
+				EventListenerNamespace: metav1.NamespaceDefault,
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("Interceptor.ExecuteTrigger() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatalf("Interceptor.ExecuteTrigger() expected error, got none")
+			}
+
+			got, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response: %v", err)
+			}
+			defer resp.Body.Close()
+			if !reflect.DeepEqual(got, []byte(tt.args.payload)) {
+				t.Errorf("Interceptor.ExecuteTrigger() = %s, want %s", got, tt.args.payload)
+			}
+		})
+	}
+}
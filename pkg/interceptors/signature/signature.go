@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature verifies the HMAC signatures of webhook events. The
+// interceptors verify all signatures through it, so that the sink can
+// restrict them to FIPS-approved algorithms.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// The algorithms of the signatures, as used in the prefix of signatures like
+// sha256=<hex digest>.
+const (
+	SHA1   = "sha1"
+	SHA256 = "sha256"
+	SHA512 = "sha512"
+)
+
+// fips is set once when the sink starts, before any event is handled.
+var fips bool
+
+// SetFIPS enables or disables FIPS mode. In FIPS mode SHA-1 signatures are
+// rejected. Enabling it fails unless the binary was built with the
+// FIPS-validated BoringCrypto module.
+func SetFIPS(enabled bool) error {
+	if enabled && !FIPSBuild() {
+		return errors.New("FIPS mode requires a binary built with GOEXPERIMENT=boringcrypto")
+	}
+	fips = enabled
+	return nil
+}
+
+// FIPS returns whether FIPS mode is enabled.
+func FIPS() bool {
+	return fips
+}
+
+// FIPSBuild returns whether the binary uses the FIPS-validated BoringCrypto
+// module for its cryptography.
+func FIPSBuild() bool {
+	return boringEnabled()
+}
+
+// Validate checks a signature of the form <algorithm>=<hex digest>, e.g.
+// sha256=..., as sent by GitHub and Bitbucket Server, against the HMAC of
+// the payload keyed with the secret.
+func Validate(signature string, payload, secret []byte) error {
+	parts := strings.SplitN(signature, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("error parsing signature %q", signature)
+	}
+	return ValidateHex(parts[0], parts[1], payload, secret)
+}
+
+// ValidateHex checks a hex encoded HMAC of the payload keyed with the secret,
+// computed with the given algorithm.
+func ValidateHex(algorithm, digest string, payload, secret []byte) error {
+	hashFunc, err := hashFor(algorithm)
+	if err != nil {
+		return err
+	}
+	sig, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("error decoding signature: %w", err)
+	}
+	mac := hmac.New(hashFunc, secret)
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errors.New("payload signature check failed")
+	}
+	return nil
+}
+
+func hashFor(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case SHA1:
+		if fips {
+			return nil, errors.New("sha1 signatures are not allowed in FIPS mode")
+		}
+		return sha1.New, nil
+	case SHA256:
+		return sha256.New, nil
+	case SHA512:
+		return sha512.New, nil
+	}
+	return nil, fmt.Errorf("unknown hash type prefix: %q", algorithm)
+}
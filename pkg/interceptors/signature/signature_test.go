@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	payload := []byte("somepayload")
+	secret := []byte("secret")
+	tests := []struct {
+		name      string
+		signature string
+		fips      bool
+		wantErr   bool
+	}{{
+		name:      "sha1",
+		signature: "sha1=38e005ef7dd3faee13204505532011257023654e",
+	}, {
+		name:      "sha256",
+		signature: "sha256=2f6387035fee47c72cb461517ee7de9bb2f8bf72fd9dc637ed11863a38f5744f",
+	}, {
+		name:      "sha256 in FIPS mode",
+		signature: "sha256=2f6387035fee47c72cb461517ee7de9bb2f8bf72fd9dc637ed11863a38f5744f",
+		fips:      true,
+	}, {
+		name:      "sha1 in FIPS mode",
+		signature: "sha1=38e005ef7dd3faee13204505532011257023654e",
+		fips:      true,
+		wantErr:   true,
+	}, {
+		name:      "mismatch",
+		signature: "sha256=0000000000000000000000000000000000000000000000000000000000000000",
+		wantErr:   true,
+	}, {
+		name:      "unknown algorithm",
+		signature: "md5=38e005ef7dd3faee13204505532011257023654e",
+		wantErr:   true,
+	}, {
+		name:      "no algorithm",
+		signature: "38e005ef7dd3faee13204505532011257023654e",
+		wantErr:   true,
+	}, {
+		name:      "invalid hex",
+		signature: "sha256=xyz",
+		wantErr:   true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fips = tc.fips
+			defer func() { fips = false }()
+			if err := Validate(tc.signature, payload, secret); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetFIPS(t *testing.T) {
+	defer func() { fips = false }()
+	err := SetFIPS(true)
+	if FIPSBuild() {
+		if err != nil || !FIPS() {
+			t.Errorf("SetFIPS() error = %v, FIPS() = %t in a BoringCrypto build", err, FIPS())
+		}
+		return
+	}
+	if err == nil || FIPS() {
+		t.Errorf("SetFIPS() error = %v, FIPS() = %t without BoringCrypto", err, FIPS())
+	}
+}
@@ -0,0 +1,477 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/signature"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+
+	// extensionsKey is where the normalized event is added to the body.
+	extensionsKey = "extensions.supplychain"
+
+	// cdeventsPrefix starts the types of all CDEvents.
+	cdeventsPrefix = "dev.cdevents."
+	// intotoPayloadType is the DSSE payload type of in-toto statements.
+	intotoPayloadType = "application/vnd.in-toto+json"
+	// statementTypePrefix starts the _type of all versions of in-toto
+	// statements.
+	statementTypePrefix = "https://in-toto.io/Statement/"
+)
+
+var errUnknownEvent = errors.New("event is not a CDEvent or an in-toto attestation")
+
+// Event is a supply chain event, normalized across formats.
+type Event struct {
+	// Format is cdevents or intoto.
+	Format string `json:"format"`
+	// Type, ID and Source identify a CDEvent. The type includes the version,
+	// e.g. dev.cdevents.artifact.published.0.1.0.
+	Type   string `json:"type,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Source string `json:"source,omitempty"`
+	// Subject is the subject of a CDEvent.
+	Subject *CDEventSubject `json:"subject,omitempty"`
+	// Attestations are the in-toto statements of the event.
+	Attestations []Attestation `json:"attestations,omitempty"`
+	// PredicateTypes are the distinct predicate types of the attestations.
+	PredicateTypes []string `json:"predicateTypes,omitempty"`
+}
+
+// CDEventSubject is the subject of a CDEvent.
+type CDEventSubject struct {
+	ID      string          `json:"id"`
+	Source  string          `json:"source,omitempty"`
+	Type    string          `json:"type,omitempty"`
+	Content json.RawMessage `json:"content,omitempty"`
+}
+
+// Attestation is an in-toto statement about software artifacts.
+type Attestation struct {
+	PredicateType string          `json:"predicateType"`
+	Subjects      []Subject       `json:"subjects"`
+	Predicate     json.RawMessage `json:"predicate,omitempty"`
+	// Verified reports whether the attestation is signed by one of the
+	// public keys of the interceptor.
+	Verified bool `json:"verified"`
+}
+
+// Subject is an artifact that an attestation is about.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type Interceptor struct {
+	KubeClientSet          kubernetes.Interface
+	Logger                 *zap.SugaredLogger
+	SupplyChain            *triggersv1.SupplyChainInterceptor
+	EventListenerNamespace string
+}
+
+func NewInterceptor(s *triggersv1.SupplyChainInterceptor, k kubernetes.Interface, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+	return &Interceptor{
+		Logger:                 l,
+		SupplyChain:            s,
+		KubeClientSet:          k,
+		EventListenerNamespace: ns,
+	}
+}
+
+func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	payload := []byte{}
+	var err error
+
+	if request.Body != nil {
+		defer request.Body.Close()
+		payload, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	// Validate the signature or token first, if set.
+	if w.SupplyChain.SecretRef != nil && !interceptors.TrustedSender(request) {
+		secretToken, err := interceptors.GetSecretToken(w.KubeClientSet, w.SupplyChain.SecretRef, w.EventListenerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(request.Header, payload, secretToken); err != nil {
+			return nil, interceptors.AuthError(err)
+		}
+	}
+	var keys []crypto.PublicKey
+	if w.SupplyChain.PublicKeyRef != nil {
+		pemKeys, err := interceptors.GetSecretToken(w.KubeClientSet, w.SupplyChain.PublicKeyRef, w.EventListenerNamespace)
+		if err != nil {
+			return nil, err
+		}
+		if keys, err = parsePublicKeys(pemKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	event, payload, err := parseEvent(payload, keys)
+	if err != nil {
+		return nil, err
+	}
+	switch event.Format {
+	case "cdevents":
+		if len(w.SupplyChain.EventTypes) > 0 && !matchesType(event.Type, w.SupplyChain.EventTypes) {
+			return nil, fmt.Errorf("CDEvent type %s is not allowed", event.Type)
+		}
+	case "intoto":
+		if len(w.SupplyChain.PredicateTypes) > 0 {
+			event = event.filter(w.SupplyChain.PredicateTypes)
+			if len(event.Attestations) == 0 {
+				return nil, fmt.Errorf("no attestations with predicate type %s", strings.Join(w.SupplyChain.PredicateTypes, ", "))
+			}
+		}
+		if len(keys) > 0 {
+			for _, a := range event.Attestations {
+				if !a.Verified {
+					return nil, fmt.Errorf("%s attestation of %s is not signed by a trusted key", a.PredicateType, subjectNames(a.Subjects))
+				}
+			}
+		}
+	}
+
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal supply chain event: %w", err)
+	}
+	payload, err = sjson.SetRawBytes(payload, extensionsKey, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add supply chain event to the body: %w", err)
+	}
+
+	return &http.Response{
+		Header: request.Header,
+		Body:   ioutil.NopCloser(bytes.NewBuffer(payload)),
+	}, nil
+}
+
+// validate checks the HMAC signature of the event, or its bearer token.
+func validate(header http.Header, payload, secretToken []byte) error {
+	if sig := header.Get("X-Hub-Signature-256"); sig != "" {
+		return signature.Validate(sig, payload, secretToken)
+	}
+	auth := header.Get(authorizationHeader)
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return fmt.Errorf("no X-Hub-Signature-256 header or bearer token in %s header set", authorizationHeader)
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, bearerPrefix)), secretToken) == 0 {
+		return errors.New("invalid bearer token")
+	}
+	return nil
+}
+
+// parseEvent returns the CDEvent or the attestations of the payload, and the
+// body to pass on. A JSON lines bundle of attestations is not a JSON body, so
+// its lines are passed on in the bundle field of a JSON object instead.
+func parseEvent(payload []byte, keys []crypto.PublicKey) (Event, []byte, error) {
+	if gjson.ValidBytes(payload) {
+		body := gjson.ParseBytes(payload)
+		if !body.IsObject() {
+			return Event{}, nil, errUnknownEvent
+		}
+		if t := body.Get("context.type").String(); strings.HasPrefix(t, cdeventsPrefix) {
+			return parseCDEvent(body), payload, nil
+		}
+		a, err := parseAttestation(body, keys)
+		if err != nil {
+			return Event{}, nil, err
+		}
+		return newAttestationEvent([]Attestation{a}), payload, nil
+	}
+
+	var attestations []Attestation
+	var lines []json.RawMessage
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !gjson.ValidBytes(line) {
+			return Event{}, nil, errUnknownEvent
+		}
+		a, err := parseAttestation(gjson.ParseBytes(line), keys)
+		if err != nil {
+			return Event{}, nil, fmt.Errorf("line %d of bundle: %w", len(lines)+1, err)
+		}
+		attestations = append(attestations, a)
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return Event{}, nil, errUnknownEvent
+	}
+	body, err := json.Marshal(map[string][]json.RawMessage{"bundle": lines})
+	if err != nil {
+		return Event{}, nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+	return newAttestationEvent(attestations), body, nil
+}
+
+func parseCDEvent(body gjson.Result) Event {
+	s := body.Get("subject")
+	e := Event{
+		Format: "cdevents",
+		Type:   body.Get("context.type").String(),
+		ID:     body.Get("context.id").String(),
+		Source: body.Get("context.source").String(),
+		Subject: &CDEventSubject{
+			ID:     s.Get("id").String(),
+			Source: s.Get("source").String(),
+			Type:   s.Get("type").String(),
+		},
+	}
+	if c := s.Get("content"); c.Exists() {
+		e.Subject.Content = json.RawMessage(c.Raw)
+	}
+	return e
+}
+
+// parseAttestation returns the in-toto statement of a Sigstore bundle, a DSSE
+// envelope or of an unsigned statement.
+func parseAttestation(r gjson.Result, keys []crypto.PublicKey) (Attestation, error) {
+	switch {
+	case r.Get("dsseEnvelope").Exists():
+		return parseEnvelope(r.Get("dsseEnvelope"), keys)
+	case r.Get("payloadType").Exists():
+		return parseEnvelope(r, keys)
+	case strings.HasPrefix(r.Get("_type").String(), statementTypePrefix):
+		return parseStatement(r), nil
+	default:
+		return Attestation{}, errUnknownEvent
+	}
+}
+
+// parseEnvelope returns the in-toto statement of a DSSE envelope, which is
+// verified if one of its signatures was made by one of the keys.
+func parseEnvelope(r gjson.Result, keys []crypto.PublicKey) (Attestation, error) {
+	payloadType := r.Get("payloadType").String()
+	if payloadType != intotoPayloadType {
+		return Attestation{}, fmt.Errorf("unsupported DSSE payload type %s", payloadType)
+	}
+	payload, err := decodeBase64(r.Get("payload").String())
+	if err != nil {
+		return Attestation{}, fmt.Errorf("failed to decode DSSE payload: %w", err)
+	}
+	statement := gjson.ParseBytes(payload)
+	if !gjson.ValidBytes(payload) || !strings.HasPrefix(statement.Get("_type").String(), statementTypePrefix) {
+		return Attestation{}, errors.New("DSSE payload is not an in-toto statement")
+	}
+	a := parseStatement(statement)
+	message := pae(payloadType, payload)
+	r.Get("signatures").ForEach(func(_, s gjson.Result) bool {
+		sig, err := decodeBase64(s.Get("sig").String())
+		if err == nil && verify(keys, message, sig) {
+			a.Verified = true
+			return false
+		}
+		return true
+	})
+	return a, nil
+}
+
+func parseStatement(r gjson.Result) Attestation {
+	a := Attestation{
+		PredicateType: r.Get("predicateType").String(),
+		Subjects:      []Subject{},
+	}
+	r.Get("subject").ForEach(func(_, s gjson.Result) bool {
+		subject := Subject{Name: s.Get("name").String(), Digest: map[string]string{}}
+		s.Get("digest").ForEach(func(alg, digest gjson.Result) bool {
+			subject.Digest[alg.String()] = digest.String()
+			return true
+		})
+		a.Subjects = append(a.Subjects, subject)
+		return true
+	})
+	if p := r.Get("predicate"); p.Exists() {
+		a.Predicate = json.RawMessage(p.Raw)
+	}
+	return a
+}
+
+// pae returns the pre-authentication encoding of a DSSE payload, which is what
+// its signatures sign.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// verify reports whether sig is a signature of message by one of the keys.
+// ECDSA keys sign a digest that matches the size of their curve, and RSA keys
+// a SHA-256 digest with PKCS #1 v1.5 or PSS padding.
+func verify(keys []crypto.PublicKey, message, sig []byte) bool {
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			var esig struct{ R, S *big.Int }
+			if _, err := asn1.Unmarshal(sig, &esig); err != nil {
+				continue
+			}
+			if ecdsa.Verify(k, ecdsaDigest(k.Curve, message), esig.R, esig.S) {
+				return true
+			}
+		case *rsa.PublicKey:
+			h := sha256.Sum256(message)
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig) == nil || rsa.VerifyPSS(k, crypto.SHA256, h[:], sig, nil) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, message, sig) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ecdsaDigest(curve elliptic.Curve, message []byte) []byte {
+	switch curve {
+	case elliptic.P384():
+		h := sha512.Sum384(message)
+		return h[:]
+	case elliptic.P521():
+		h := sha512.Sum512(message)
+		return h[:]
+	default:
+		h := sha256.Sum256(message)
+		return h[:]
+	}
+}
+
+// parsePublicKeys returns the public keys of the PUBLIC KEY blocks in data.
+func parsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		data = rest
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM-encoded public keys found")
+	}
+	return keys, nil
+}
+
+// decodeBase64 decodes standard or URL-safe base64, with or without padding,
+// since DSSE implementations differ.
+func decodeBase64(s string) ([]byte, error) {
+	var err error
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		var b []byte
+		if b, err = enc.DecodeString(s); err == nil {
+			return b, nil
+		}
+	}
+	return nil, err
+}
+
+// matchesType reports whether the type of a CDEvent is one of the types,
+// which don't include a version.
+func matchesType(eventType string, types []string) bool {
+	for _, t := range types {
+		if eventType == t || strings.HasPrefix(eventType, t+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func newAttestationEvent(attestations []Attestation) Event {
+	e := Event{Format: "intoto", Attestations: attestations}
+	e.summarize()
+	return e
+}
+
+// filter returns the event with only the attestations of the predicate types.
+func (e Event) filter(predicateTypes []string) Event {
+	var attestations []Attestation
+	for _, a := range e.Attestations {
+		for _, t := range predicateTypes {
+			if a.PredicateType == t {
+				attestations = append(attestations, a)
+				break
+			}
+		}
+	}
+	return newAttestationEvent(attestations)
+}
+
+// summarize sets the distinct predicate types of the attestations.
+func (e *Event) summarize() {
+	seen := map[string]bool{}
+	e.PredicateTypes = nil
+	for _, a := range e.Attestations {
+		if !seen[a.PredicateType] {
+			seen[a.PredicateType] = true
+			e.PredicateTypes = append(e.PredicateTypes, a.PredicateType)
+		}
+	}
+	sort.Strings(e.PredicateTypes)
+}
+
+func subjectNames(subjects []Subject) string {
+	names := make([]string, 0, len(subjects))
+	for _, s := range subjects {
+		names = append(names, s.Name)
+	}
+	return strings.Join(names, ", ")
+}
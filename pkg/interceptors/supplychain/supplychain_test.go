@@ -0,0 +1,328 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	cdeventPayload = `{"context":{"version":"0.1.0","id":"271069a8","source":"/registry","type":"dev.cdevents.artifact.published.0.1.0","timestamp":"2023-03-20T14:27:05Z"},` +
+		`"subject":{"id":"pkg:oci/app@sha256%3A0b31","source":"/registry","type":"artifact","content":{"name":"app"}}}`
+	provenance = `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"app","digest":{"sha256":"0b31"}}],` +
+		`"predicateType":"https://slsa.dev/provenance/v1","predicate":{"buildDefinition":{"buildType":"https://tekton.dev/chains/v2/slsa"}}}`
+	vsa = `{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":"app","digest":{"sha256":"0b31"}}],` +
+		`"predicateType":"https://slsa.dev/verification_summary/v1","predicate":{"verificationResult":"PASSED"}}`
+)
+
+// envelope returns a DSSE envelope of the statement, signed by each signer.
+func envelope(t *testing.T, statement string, signers ...interface{}) string {
+	t.Helper()
+	message := pae(intotoPayloadType, []byte(statement))
+	var sigs []string
+	for _, s := range signers {
+		var sig []byte
+		switch k := s.(type) {
+		case *ecdsa.PrivateKey:
+			h := sha256.Sum256(message)
+			r, s, err := ecdsa.Sign(rand.Reader, k, h[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sig, err = asn1.Marshal(struct{ R, S interface{} }{r, s}); err != nil {
+				t.Fatal(err)
+			}
+		case ed25519.PrivateKey:
+			sig = ed25519.Sign(k, message)
+		}
+		sigs = append(sigs, fmt.Sprintf(`{"keyid":"","sig":%q}`, base64.StdEncoding.EncodeToString(sig)))
+	}
+	return fmt.Sprintf(`{"payloadType":%q,"payload":%q,"signatures":[%s]}`,
+		intotoPayloadType, base64.StdEncoding.EncodeToString([]byte(statement)), strings.Join(sigs, ","))
+}
+
+func publicKeyPEM(t *testing.T, key interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestInterceptor_ExecuteTrigger(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mysecret",
+		},
+		Data: map[string][]byte{
+			"token": []byte("secrettoken"),
+			"keys":  append(publicKeyPEM(t, &ecKey.PublicKey), publicKeyPEM(t, edPublic)...),
+		},
+	}
+	secretRef := &triggersv1.SecretRef{SecretName: "mysecret", SecretKey: "token"}
+	publicKeyRef := &triggersv1.SecretRef{SecretName: "mysecret", SecretKey: "keys"}
+
+	subjects := []Subject{{Name: "app", Digest: map[string]string{"sha256": "0b31"}}}
+	provenanceAttestation := Attestation{
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Subjects:      subjects,
+		Predicate:     json.RawMessage(`{"buildDefinition":{"buildType":"https://tekton.dev/chains/v2/slsa"}}`),
+	}
+	vsaAttestation := Attestation{
+		PredicateType: "https://slsa.dev/verification_summary/v1",
+		Subjects:      subjects,
+		Predicate:     json.RawMessage(`{"verificationResult":"PASSED"}`),
+	}
+	verified := func(a Attestation) Attestation {
+		a.Verified = true
+		return a
+	}
+	bundle := envelope(t, provenance, ecKey) + "\n" + envelope(t, vsa, edKey) + "\n"
+
+	tests := []struct {
+		name        string
+		SupplyChain *triggersv1.SupplyChainInterceptor
+		payload     string
+		header      http.Header
+		want        *Event
+		// wantBundle is the number of lines of a JSON lines bundle
+		wantBundle int
+	}{{
+		name:        "CDEvent",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{},
+		payload:     cdeventPayload,
+		want: &Event{
+			Format: "cdevents",
+			Type:   "dev.cdevents.artifact.published.0.1.0",
+			ID:     "271069a8",
+			Source: "/registry",
+			Subject: &CDEventSubject{
+				ID:      "pkg:oci/app@sha256%3A0b31",
+				Source:  "/registry",
+				Type:    "artifact",
+				Content: json.RawMessage(`{"name":"app"}`),
+			},
+		},
+	}, {
+		name:        "CDEvent of an allowed type with bearer token",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{SecretRef: secretRef, EventTypes: []string{"dev.cdevents.artifact.published"}},
+		payload:     cdeventPayload,
+		header:      http.Header{"Authorization": []string{"Bearer secrettoken"}},
+		want: &Event{
+			Format:  "cdevents",
+			Type:    "dev.cdevents.artifact.published.0.1.0",
+			ID:      "271069a8",
+			Source:  "/registry",
+			Subject: &CDEventSubject{ID: "pkg:oci/app@sha256%3A0b31", Source: "/registry", Type: "artifact", Content: json.RawMessage(`{"name":"app"}`)},
+		},
+	}, {
+		name:        "CDEvent with invalid bearer token",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{SecretRef: secretRef},
+		payload:     cdeventPayload,
+		header:      http.Header{"Authorization": []string{"Bearer wrong"}},
+	}, {
+		name:        "CDEvent of another type",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{EventTypes: []string{"dev.cdevents.pipelinerun.finished"}},
+		payload:     cdeventPayload,
+	}, {
+		name:        "unsigned statement",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{},
+		payload:     provenance,
+		want: &Event{
+			Format:         "intoto",
+			Attestations:   []Attestation{provenanceAttestation},
+			PredicateTypes: []string{"https://slsa.dev/provenance/v1"},
+		},
+	}, {
+		name:        "unsigned statement with public keys",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PublicKeyRef: publicKeyRef},
+		payload:     provenance,
+	}, {
+		name:        "DSSE envelope without public keys",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{},
+		payload:     envelope(t, provenance, ecKey),
+		want: &Event{
+			Format:         "intoto",
+			Attestations:   []Attestation{provenanceAttestation},
+			PredicateTypes: []string{"https://slsa.dev/provenance/v1"},
+		},
+	}, {
+		name:        "signed DSSE envelope",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PublicKeyRef: publicKeyRef},
+		payload:     envelope(t, provenance, untrustedKey, ecKey),
+		want: &Event{
+			Format:         "intoto",
+			Attestations:   []Attestation{verified(provenanceAttestation)},
+			PredicateTypes: []string{"https://slsa.dev/provenance/v1"},
+		},
+	}, {
+		name:        "DSSE envelope signed by an untrusted key",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PublicKeyRef: publicKeyRef},
+		payload:     envelope(t, provenance, untrustedKey),
+	}, {
+		name:        "Sigstore bundle",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PublicKeyRef: publicKeyRef},
+		payload:     `{"mediaType":"application/vnd.dev.sigstore.bundle+json;version=0.1","dsseEnvelope":` + envelope(t, vsa, edKey) + `}`,
+		want: &Event{
+			Format:         "intoto",
+			Attestations:   []Attestation{verified(vsaAttestation)},
+			PredicateTypes: []string{"https://slsa.dev/verification_summary/v1"},
+		},
+	}, {
+		name:        "JSON lines bundle",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PublicKeyRef: publicKeyRef},
+		payload:     bundle,
+		want: &Event{
+			Format:         "intoto",
+			Attestations:   []Attestation{verified(provenanceAttestation), verified(vsaAttestation)},
+			PredicateTypes: []string{"https://slsa.dev/provenance/v1", "https://slsa.dev/verification_summary/v1"},
+		},
+		wantBundle: 2,
+	}, {
+		name:        "JSON lines bundle with predicate types",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PredicateTypes: []string{"https://slsa.dev/verification_summary/v1"}},
+		payload:     bundle,
+		want: &Event{
+			Format:         "intoto",
+			Attestations:   []Attestation{vsaAttestation},
+			PredicateTypes: []string{"https://slsa.dev/verification_summary/v1"},
+		},
+		wantBundle: 2,
+	}, {
+		name:        "no attestation of the predicate types",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{PredicateTypes: []string{"https://spdx.dev/Document"}},
+		payload:     bundle,
+	}, {
+		name:        "DSSE envelope of another payload type",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{},
+		payload:     `{"payloadType":"text/plain","payload":"aGVsbG8=","signatures":[]}`,
+	}, {
+		name:        "unknown event",
+		SupplyChain: &triggersv1.SupplyChainInterceptor{},
+		payload:     `{"action":"opened"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logging.NewLogger("", "")
+			kubeClient := fakekubeclient.Get(ctx)
+			if _, err := kubeClient.CoreV1().Secrets(metav1.NamespaceDefault).Create(secret); err != nil {
+				t.Error(err)
+			}
+			request := &http.Request{
+				Body:   ioutil.NopCloser(bytes.NewReader([]byte(tt.payload))),
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+			}
+			for k, v := range tt.header {
+				request.Header[k] = v
+			}
+			w := &Interceptor{
+				KubeClientSet:          kubeClient,
+				SupplyChain:            tt.SupplyChain,
+				Logger:                 logger,
+				EventListenerNamespace: metav1.NamespaceDefault,
+			}
+			resp, err := w.ExecuteTrigger(request)
+			if err != nil {
+				if tt.want != nil {
+					t.Errorf("Interceptor.ExecuteTrigger() unexpected error: %v", err)
+				}
+				return
+			}
+			if tt.want == nil {
+				t.Fatalf("Interceptor.ExecuteTrigger() expected error, got none")
+			}
+
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("error reading response: %v", err)
+			}
+			defer resp.Body.Close()
+			if !gjson.GetBytes(body, "extensions.supplychain").Exists() {
+				t.Fatalf("Interceptor.ExecuteTrigger() = %s, want extensions.supplychain", body)
+			}
+			if tt.wantBundle > 0 {
+				if got := gjson.GetBytes(body, "bundle.#").Int(); got != int64(tt.wantBundle) {
+					t.Errorf("Interceptor.ExecuteTrigger() passed on %d bundle lines, want %d", got, tt.wantBundle)
+				}
+			} else {
+				// The original body is kept
+				for key, value := range gjson.Parse(tt.payload).Map() {
+					if gjson.GetBytes(body, key).Raw != value.Raw {
+						t.Errorf("Interceptor.ExecuteTrigger() changed %s", key)
+					}
+				}
+			}
+			var got Event
+			if err := json.Unmarshal([]byte(gjson.GetBytes(body, "extensions.supplychain").Raw), &got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(*tt.want, got); diff != "" {
+				t.Errorf("-want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestParsePublicKeys_error(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+		"-----BEGIN PUBLIC KEY-----\nMIIB\n-----END PUBLIC KEY-----\n",
+	} {
+		if keys, err := parsePublicKeys([]byte(data)); err == nil {
+			t.Errorf("parsePublicKeys(%q) = %v, want error", data, keys)
+		}
+	}
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package webhook
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -24,9 +27,13 @@ import (
 	"net/url"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
+	pkgerrors "github.com/pkg/errors"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/triggers/pkg/interceptors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 
@@ -36,14 +43,90 @@ import (
 // Timeout for outgoing requests to interceptor services
 const interceptorTimeout = 5 * time.Second
 
+// ParamsHeader is the request header in which the params of a Trigger
+// interceptor that references a ClusterInterceptor are sent, as a JSON object.
+const ParamsHeader = "Tekton-Interceptor-Params"
+
+// ResponseTypeHeader is the response header in which interceptor services set
+// ResourcesResponse to respond with the resources to create for the event,
+// as {"resources": [...]}, instead of the event to pass to the next
+// interceptor.
+const ResponseTypeHeader = "Tekton-Interceptor-Response"
+
+// ResourcesResponse is the ResponseTypeHeader of responses with resources.
+const ResourcesResponse = "resources"
+
+// IsResourcesResponse returns true if the header marks a response with the
+// resources to create.
+func IsResourcesResponse(header http.Header) bool {
+	return header.Get(ResponseTypeHeader) == ResourcesResponse
+}
+
+// PatchResponse is the ResponseTypeHeader of responses with an RFC 6902 JSON
+// Patch to apply to the event that was sent to the interceptor, instead of
+// the whole event.
+const PatchResponse = "json-patch"
+
+// IsPatchResponse returns true if the header marks a response with a JSON
+// Patch of the event.
+func IsPatchResponse(header http.Header) bool {
+	return header.Get(ResponseTypeHeader) == PatchResponse
+}
+
+// ApplyPatch applies the JSON Patch of a PatchResponse to the event. A patch
+// with a test operation that fails leaves the event unchanged, so that
+// interceptors can mutate events conditionally.
+func ApplyPatch(event, patch []byte) ([]byte, error) {
+	p, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON Patch: %w", err)
+	}
+	if len(bytes.TrimSpace(event)) == 0 {
+		return nil, errors.New("failed to apply JSON Patch: event body is empty")
+	}
+	patched, err := p.Apply(event)
+	switch {
+	case pkgerrors.Cause(err) == jsonpatch.ErrTestFailed:
+		return event, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to apply JSON Patch: %w", err)
+	}
+	return patched, nil
+}
+
+// RetryPolicy is how requests to an interceptor service that fail to
+// connect, time out or are responded to with a 5xx status are retried.
+type RetryPolicy struct {
+	// Retries is how many times a request is sent again. If zero, requests
+	// are not retried.
+	Retries int
+	// Backoff is the delay before the first retry, which doubles with each
+	// further retry.
+	Backoff time.Duration
+}
+
+// policyFor returns the policy of the webhook, which overrides the defaults.
+func (p RetryPolicy) policyFor(wh *triggersv1.WebhookInterceptor) RetryPolicy {
+	if wh.Retries != nil {
+		p.Retries = int(*wh.Retries)
+	}
+	if d, err := time.ParseDuration(wh.RetryBackoff); err == nil && d > 0 {
+		p.Backoff = d
+	}
+	return p
+}
+
 type Interceptor struct {
 	HTTPClient             *http.Client
 	EventListenerNamespace string
 	Logger                 *zap.SugaredLogger
 	Webhook                *triggersv1.WebhookInterceptor
+	Retry                  RetryPolicy
 }
 
-func NewInterceptor(wh *triggersv1.WebhookInterceptor, c *http.Client, ns string, l *zap.SugaredLogger) interceptors.Interceptor {
+// NewInterceptor returns an interceptor that calls the webhook, retrying
+// requests as configured by the webhook or else by the default policy.
+func NewInterceptor(wh *triggersv1.WebhookInterceptor, c *http.Client, ns string, defaults RetryPolicy, l *zap.SugaredLogger) interceptors.Interceptor {
 	timeoutClient := &http.Client{
 		Transport: c.Transport,
 		Timeout:   interceptorTimeout,
@@ -53,6 +136,7 @@ func NewInterceptor(wh *triggersv1.WebhookInterceptor, c *http.Client, ns string
 		EventListenerNamespace: ns,
 		Logger:                 l,
 		Webhook:                wh,
+		Retry:                  defaults.policyFor(wh),
 	}
 }
 
@@ -65,11 +149,12 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 	request.Host = u.Host
 	addInterceptorHeaders(request.Header, w.Webhook.Header)
 
-	resp, err := w.HTTPClient.Do(request)
+	resp, err := w.do(request)
 	if err != nil {
 		return resp, err
 	}
 	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
 		respBody, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return resp, errors.New("failed to parse response body")
@@ -79,6 +164,112 @@ func (w *Interceptor) ExecuteTrigger(request *http.Request) (*http.Response, err
 	return resp, err
 }
 
+// do sends the request, and sends it again after a backoff while it fails for
+// a transient reason and retries are left, unless the context of the request
+// is done.
+func (w *Interceptor) do(request *http.Request) (*http.Response, error) {
+	if w.Retry.Retries <= 0 {
+		return w.HTTPClient.Do(request)
+	}
+	var body []byte
+	if request.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(request.Body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		request.Body.Close()
+	}
+	backoff := wait.Backoff{
+		Steps:    w.Retry.Retries,
+		Duration: w.Retry.Backoff,
+		Factor:   2,
+		Jitter:   0.1,
+	}
+	for attempt := 0; ; attempt++ {
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		resp, err := w.HTTPClient.Do(request)
+		if !isTransient(resp, err) || attempt == w.Retry.Retries || request.Context().Err() != nil {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		delay := backoff.Step()
+		if w.Logger != nil {
+			w.Logger.Warnf("Retrying the request to interceptor service %s in %s: %v", request.URL.Host, delay, retryReason(resp, err))
+		}
+		select {
+		case <-request.Context().Done():
+			return nil, fmt.Errorf("%v: %w", retryReason(resp, err), request.Context().Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isTransient reports whether a request failed for a reason that may not
+// persist, i.e. it failed to connect, timed out or was responded to with a
+// 5xx status.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryReason describes why a request is retried.
+func retryReason(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("request rejected; status: %s", resp.Status)
+}
+
+// Ping checks that the Service of the webhook accepts requests. Any response
+// counts, since interceptor services only have to handle events.
+func Ping(ctx context.Context, c *http.Client, wh *triggersv1.WebhookInterceptor, ns string) error {
+	u, err := getURI(wh.ObjectRef, ns)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Do(request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// FromClusterInterceptor returns a WebhookInterceptor that calls the Service
+// of the ClusterInterceptor with the given params.
+func FromClusterInterceptor(ci *triggersv1.ClusterInterceptor, params map[string]runtime.RawExtension) (*triggersv1.WebhookInterceptor, error) {
+	svc := ci.Spec.ClientConfig.Service
+	if svc == nil {
+		return nil, fmt.Errorf("ClusterInterceptor %s has no service", ci.Name)
+	}
+	wh := &triggersv1.WebhookInterceptor{
+		ObjectRef: &corev1.ObjectReference{
+			Kind:       "Service",
+			APIVersion: "v1",
+			Name:       svc.Name,
+			Namespace:  svc.Namespace,
+		},
+	}
+	if len(params) > 0 {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params for ClusterInterceptor %s: %w", ci.Name, err)
+		}
+		wh.Header = []pipelinev1.Param{{
+			Name:  ParamsHeader,
+			Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeString, StringVal: string(b)},
+		}}
+	}
+	return wh, nil
+}
+
 // getURI retrieves the ObjectReference to URI.
 func getURI(objRef *corev1.ObjectReference, ns string) (*url.URL, error) {
 	// TODO: This should work for any Addressable.
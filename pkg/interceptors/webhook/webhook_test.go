@@ -25,11 +25,14 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestWebHookInterceptor(t *testing.T) {
@@ -77,7 +80,7 @@ func TestWebHookInterceptor(t *testing.T) {
 			}},
 		},
 	}
-	i := NewInterceptor(webhook, client, "default", nil)
+	i := NewInterceptor(webhook, client, "default", RetryPolicy{}, nil)
 
 	incoming, _ := http.NewRequest("POST", "http://doesnotmatter.example.com", payload)
 	incoming.Header.Add("Content-type", "application/json")
@@ -129,7 +132,7 @@ func TestWebHookInterceptor_NotOK(t *testing.T) {
 			Name:       "foo",
 		},
 	}
-	i := NewInterceptor(webhook, client, "default", nil)
+	i := NewInterceptor(webhook, client, "default", RetryPolicy{}, nil)
 
 	incoming, _ := http.NewRequest("POST", "http://doesnotmatter.example.com", payload)
 	resp, err := i.ExecuteTrigger(incoming)
@@ -140,6 +143,126 @@ func TestWebHookInterceptor_NotOK(t *testing.T) {
 
 }
 
+func TestWebHookInterceptor_Retries(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	tests := []struct {
+		name         string
+		failures     int
+		failStatus   int
+		defaults     RetryPolicy
+		retries      *int32
+		wantErr      bool
+		wantAttempts int
+	}{{
+		name:         "no retries",
+		failures:     1,
+		failStatus:   http.StatusServiceUnavailable,
+		wantErr:      true,
+		wantAttempts: 1,
+	}, {
+		name:         "default retries",
+		failures:     2,
+		failStatus:   http.StatusBadGateway,
+		defaults:     RetryPolicy{Retries: 2, Backoff: time.Millisecond},
+		wantAttempts: 3,
+	}, {
+		name:         "retries exhausted",
+		failures:     3,
+		failStatus:   http.StatusInternalServerError,
+		defaults:     RetryPolicy{Retries: 2, Backoff: time.Millisecond},
+		wantErr:      true,
+		wantAttempts: 3,
+	}, {
+		name:         "webhook retries",
+		failures:     3,
+		failStatus:   http.StatusServiceUnavailable,
+		defaults:     RetryPolicy{Backoff: time.Millisecond},
+		retries:      int32Ptr(3),
+		wantAttempts: 4,
+	}, {
+		name:         "webhook disables retries",
+		failures:     1,
+		failStatus:   http.StatusServiceUnavailable,
+		defaults:     RetryPolicy{Retries: 2, Backoff: time.Millisecond},
+		retries:      int32Ptr(0),
+		wantErr:      true,
+		wantAttempts: 1,
+	}, {
+		name:         "client errors are not retried",
+		failures:     1,
+		failStatus:   http.StatusBadRequest,
+		defaults:     RetryPolicy{Retries: 2, Backoff: time.Millisecond},
+		wantErr:      true,
+		wantAttempts: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				body, _ := ioutil.ReadAll(r.Body)
+				if string(body) != `{"foo":"bar"}` {
+					t.Errorf("attempt %d sent body %s", attempts, body)
+				}
+				if attempts <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				_, _ = w.Write(body)
+			}))
+			defer ts.Close()
+			interceptorURL, _ := url.Parse(ts.URL)
+			client := &http.Client{
+				Transport: &http.Transport{
+					Proxy: http.ProxyURL(interceptorURL),
+				},
+			}
+			webhook := &v1alpha1.WebhookInterceptor{
+				ObjectRef: &corev1.ObjectReference{
+					APIVersion: "v1",
+					Kind:       "Service",
+					Name:       "foo",
+				},
+				Retries: tt.retries,
+			}
+			i := NewInterceptor(webhook, client, "default", tt.defaults, nil)
+
+			incoming, _ := http.NewRequest("POST", "http://doesnotmatter.example.com", bytes.NewBufferString(`{"foo":"bar"}`))
+			_, err := i.ExecuteTrigger(incoming)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecuteTrigger() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("ExecuteTrigger() sent %d requests, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_policyFor(t *testing.T) {
+	retries := int32(5)
+	defaults := RetryPolicy{Retries: 2, Backoff: 200 * time.Millisecond}
+	for _, tc := range []struct {
+		name string
+		wh   *v1alpha1.WebhookInterceptor
+		want RetryPolicy
+	}{{
+		name: "defaults",
+		wh:   &v1alpha1.WebhookInterceptor{},
+		want: defaults,
+	}, {
+		name: "webhook policy",
+		wh:   &v1alpha1.WebhookInterceptor{Retries: &retries, RetryBackoff: "1s"},
+		want: RetryPolicy{Retries: 5, Backoff: time.Second},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, defaults.policyFor(tc.wh)); diff != "" {
+				t.Errorf("policyFor() -want +got: %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetURI(t *testing.T) {
 	var eventListenerNs = "default"
 	tcs := []struct {
@@ -191,6 +314,68 @@ func TestGetURI(t *testing.T) {
 	}
 }
 
+func TestFromClusterInterceptor(t *testing.T) {
+	ci := &v1alpha1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "github"},
+		Spec: v1alpha1.ClusterInterceptorSpec{
+			ClientConfig: v1alpha1.ClientConfig{
+				Service: &v1alpha1.ServiceReference{Name: "github-interceptor", Namespace: "tekton-pipelines"},
+			},
+		},
+	}
+	tcs := []struct {
+		name   string
+		params map[string]runtime.RawExtension
+		want   *v1alpha1.WebhookInterceptor
+	}{{
+		name: "no params",
+		want: &v1alpha1.WebhookInterceptor{
+			ObjectRef: &corev1.ObjectReference{
+				Kind:       "Service",
+				APIVersion: "v1",
+				Name:       "github-interceptor",
+				Namespace:  "tekton-pipelines",
+			},
+		},
+	}, {
+		name: "params",
+		params: map[string]runtime.RawExtension{
+			"secretName": {Raw: []byte(`"github-secret"`)},
+			"eventTypes": {Raw: []byte(`["push"]`)},
+		},
+		want: &v1alpha1.WebhookInterceptor{
+			ObjectRef: &corev1.ObjectReference{
+				Kind:       "Service",
+				APIVersion: "v1",
+				Name:       "github-interceptor",
+				Namespace:  "tekton-pipelines",
+			},
+			Header: []pipelinev1.Param{{
+				Name: ParamsHeader,
+				Value: pipelinev1.ArrayOrString{
+					Type:      pipelinev1.ParamTypeString,
+					StringVal: `{"eventTypes":["push"],"secretName":"github-secret"}`,
+				},
+			}},
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromClusterInterceptor(ci, tc.params)
+			if err != nil {
+				t.Fatalf("FromClusterInterceptor() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FromClusterInterceptor() -want +got: %s", diff)
+			}
+		})
+	}
+
+	if _, err := FromClusterInterceptor(&v1alpha1.ClusterInterceptor{}, nil); err == nil {
+		t.Error("FromClusterInterceptor() expected error for ClusterInterceptor without service")
+	}
+}
+
 func Test_addInterceptorHeaders(t *testing.T) {
 	type args struct {
 		header       http.Header
@@ -274,3 +459,50 @@ func Test_addInterceptorHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyPatch(t *testing.T) {
+	event := []byte(`{"ref":"refs/heads/main","files":["a"]}`)
+	tests := []struct {
+		name    string
+		patch   string
+		want    string
+		wantErr bool
+	}{{
+		name:  "add and remove",
+		patch: `[{"op":"add","path":"/branch","value":"main"},{"op":"remove","path":"/files"}]`,
+		want:  `{"branch":"main","ref":"refs/heads/main"}`,
+	}, {
+		name:  "passing test",
+		patch: `[{"op":"test","path":"/ref","value":"refs/heads/main"},{"op":"add","path":"/release","value":true}]`,
+		want:  `{"files":["a"],"ref":"refs/heads/main","release":true}`,
+	}, {
+		name:  "failing test leaves event unchanged",
+		patch: `[{"op":"test","path":"/ref","value":"refs/heads/dev"},{"op":"add","path":"/release","value":true}]`,
+		want:  string(event),
+	}, {
+		name:    "missing path",
+		patch:   `[{"op":"replace","path":"/sender/login","value":"bot"}]`,
+		wantErr: true,
+	}, {
+		name:    "not a patch",
+		patch:   `{"op":"add"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyPatch(event, []byte(tt.patch))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ApplyPatch() expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyPatch() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("ApplyPatch() -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces secrets in log output.
+const Redacted = "[REDACTED]"
+
+// minSecretLength is the length below which secret values are not redacted,
+// since short values would mask unrelated parts of every log line.
+const minSecretLength = 6
+
+// maxSecrets bounds the number of secret values a Redactor keeps, e.g. when
+// the Secrets of interceptors are rotated often.
+const maxSecrets = 1000
+
+// tokenPatterns match common tokens that payloads may contain by accident.
+var tokenPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// GitHub personal access, OAuth, app and refresh tokens
+	{re: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`), repl: Redacted},
+	{re: regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}\b`), repl: Redacted},
+	// GitLab personal access tokens
+	{re: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`), repl: Redacted},
+	// AWS access key IDs, and secret access keys assigned to their usual names
+	{re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), repl: Redacted},
+	{re: regexp.MustCompile(`(?i)(aws_?secret_?access_?key["']?\s*[:=]\s*["']?)[A-Za-z0-9/+=]{40}`), repl: "${1}" + Redacted},
+}
+
+// sensitiveHeaders carry credentials, and their values are never logged.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"X-Gitlab-Token",
+	// The token of trusted senders
+	"Tekton-Sender-Token",
+}
+
+// RedactHeaders returns a copy of the headers with the values of the headers
+// that carry credentials replaced, for logging.
+func RedactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, h := range sensitiveHeaders {
+		if values, ok := redacted[http.CanonicalHeaderKey(h)]; ok {
+			for i := range values {
+				values[i] = Redacted
+			}
+		}
+	}
+	return redacted
+}
+
+// Redactor masks the values of secrets and common token patterns in strings.
+// A nil Redactor only masks the token patterns.
+type Redactor struct {
+	mu sync.RWMutex
+	// values are kept longest first, so that values that contain other
+	// values are replaced before those.
+	values []string
+	known  map[string]bool
+}
+
+// Secrets redacts the secrets read by the interceptors of the process.
+var Secrets = NewRedactor()
+
+// NewRedactor returns a Redactor without secret values.
+func NewRedactor() *Redactor {
+	return &Redactor{known: map[string]bool{}}
+}
+
+// AddSecret adds the value of a secret to redact, and its JSON-escaped form
+// since log output is encoded as JSON.
+func (r *Redactor) AddSecret(value []byte) {
+	v := strings.TrimSpace(string(value))
+	if r == nil || len(v) < minSecretLength {
+		return
+	}
+	escaped, _ := json.Marshal(v)
+	forms := []string{v, strings.Trim(string(escaped), `"`)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range forms {
+		if r.known[f] {
+			continue
+		}
+		if len(r.values) >= maxSecrets {
+			// Forget the oldest values, which were most likely rotated
+			r.known = map[string]bool{}
+			r.values = nil
+		}
+		r.known[f] = true
+		r.values = append(r.values, f)
+	}
+	sort.SliceStable(r.values, func(i, j int) bool {
+		return len(r.values[i]) > len(r.values[j])
+	})
+}
+
+// Redact replaces the secret values and the token patterns in s.
+func (r *Redactor) Redact(s string) string {
+	if r != nil {
+		r.mu.RLock()
+		for _, v := range r.values {
+			s = strings.Replace(s, v, Redacted, -1)
+		}
+		r.mu.RUnlock()
+	}
+	for _, p := range tokenPatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// Redacting returns a logger whose messages, stack traces and field values are
+// redacted by r.
+func Redacting(logger *zap.SugaredLogger, r *Redactor) *zap.SugaredLogger {
+	return logger.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &redactingCore{Core: core, redactor: r}
+	})).Sugar()
+}
+
+type redactingCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redactFields(fields)), redactor: c.redactor}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redactor.Redact(entry.Message)
+	entry.Stack = c.redactor.Redact(entry.Stack)
+	return c.Core.Write(entry, c.redactFields(fields))
+}
+
+// redactFields returns a copy of the fields with their values redacted. Errors
+// and Stringers are logged as their redacted strings, and other objects as
+// their redacted JSON.
+func (c *redactingCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = c.redactor.Redact(f.String)
+		case zapcore.ByteStringType:
+			f = zap.ByteString(f.Key, []byte(c.redactor.Redact(string(f.Interface.([]byte)))))
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				f = zap.String(f.Key, c.redactor.Redact(err.Error()))
+			}
+		case zapcore.StringerType:
+			if s, ok := f.Interface.(fmt.Stringer); ok && s != nil {
+				f = zap.String(f.Key, c.redactor.Redact(s.String()))
+			}
+		case zapcore.ReflectType:
+			if b, err := json.Marshal(f.Interface); err == nil {
+				if redacted := c.redactor.Redact(string(b)); json.Valid([]byte(redacted)) {
+					f = zap.Reflect(f.Key, json.RawMessage(redacted))
+				} else {
+					f = zap.String(f.Key, redacted)
+				}
+			}
+		}
+		out[i] = f
+	}
+	return out
+}
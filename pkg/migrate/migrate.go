@@ -0,0 +1,263 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migrate rewrites the Triggers objects stored in a cluster, so that
+// they are stored in the current storage version of their CRD and no longer
+// have fields that were removed from the API.
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/client/clientset/versioned/scheme"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/pager"
+	"k8s.io/client-go/util/retry"
+)
+
+// Resources are the Triggers resources that are migrated, in order.
+var Resources = []string{
+	"clusterinterceptors",
+	"interceptorchains",
+	"triggerbindings",
+	"clustertriggerbindings",
+	"triggertemplates",
+	"eventlisteners",
+	"clustereventlisteners",
+	"repositories",
+}
+
+// Migrator rewrites every object of the Resources in all namespaces. An
+// object that is updated without changes is written again in the storage
+// version of its CRD, which is what makes a later upgrade that stops serving
+// an older version safe.
+type Migrator struct {
+	Client dynamic.Interface
+	// DryRun reports the fields that would be removed without updating any
+	// object.
+	DryRun bool
+	// PageSize is how many objects are listed at once. If zero, the default
+	// of the pager is used.
+	PageSize int64
+}
+
+// Result is the outcome of the migration of one object.
+type Result struct {
+	Resource  string
+	Namespace string
+	Name      string
+	// Removed are the paths of the fields that were removed from the API,
+	// e.g. spec.triggers[0].legacy.
+	Removed []string
+	// Err is set if the object could not be migrated.
+	Err error
+}
+
+func (r Result) String() string {
+	name := r.Name
+	if r.Namespace != "" {
+		name = r.Namespace + "/" + r.Name
+	}
+	return fmt.Sprintf("%s %s", r.Resource, name)
+}
+
+// Migrate migrates the objects of each of the Resources and calls report with
+// the result of every object. It only returns an error if the objects of a
+// resource cannot be listed.
+func (m Migrator) Migrate(ctx context.Context, report func(Result)) error {
+	for _, resource := range Resources {
+		gvr := v1alpha1.SchemeGroupVersion.WithResource(resource)
+		p := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+			return m.Client.Resource(gvr).List(opts)
+		}))
+		if m.PageSize > 0 {
+			p.PageSize = m.PageSize
+		}
+		if err := p.EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("unexpected %T in list of %s", obj, resource)
+			}
+			report(m.migrate(gvr, u))
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to list %s: %w", resource, err)
+		}
+	}
+	return nil
+}
+
+// migrate strips the removed fields of the object and updates it, fetching it
+// again if it was changed in the meantime.
+func (m Migrator) migrate(gvr schema.GroupVersionResource, u *unstructured.Unstructured) Result {
+	result := Result{Resource: gvr.Resource, Namespace: u.GetNamespace(), Name: u.GetName()}
+	client := m.Client.Resource(gvr).Namespace(u.GetNamespace())
+	attempt := 0
+	result.Err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if attempt++; attempt > 1 {
+			var err error
+			if u, err = client.Get(u.GetName(), metav1.GetOptions{}); err != nil {
+				return err
+			}
+		}
+		removed, err := StripRemovedFields(u)
+		if err != nil {
+			return err
+		}
+		result.Removed = removed
+		if m.DryRun {
+			return nil
+		}
+		_, err = client.Update(u, metav1.UpdateOptions{})
+		return err
+	})
+	// Objects that were deleted while they were migrated need no migration
+	if kerrors.IsNotFound(result.Err) {
+		result.Err = nil
+	}
+	return result
+}
+
+// StripRemovedFields removes the fields of the object that its type in the
+// current API has no field for, and returns their paths in order. The
+// metadata and status of the object are kept as they are.
+func StripRemovedFields(u *unstructured.Unstructured) ([]string, error) {
+	typed, err := scheme.Scheme.New(u.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	kept := map[string]interface{}{}
+	for _, k := range []string{"metadata", "status"} {
+		if v, ok := u.Object[k]; ok {
+			kept[k] = v
+			delete(u.Object, k)
+		}
+	}
+	removed := strip(u.Object, reflect.TypeOf(typed), "")
+	for k, v := range kept {
+		u.Object[k] = v
+	}
+	sort.Strings(removed)
+	return removed, nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// strip removes the fields of v that t has no field for, and returns their
+// paths. Values of types that decode themselves, like params and resource
+// templates, are kept as they are.
+func strip(v interface{}, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+	var removed []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for k, fv := range v {
+				ft, ok := lookupField(fields, k)
+				if !ok {
+					delete(v, k)
+					removed = append(removed, joinPath(path, k))
+					continue
+				}
+				removed = append(removed, strip(fv, ft, joinPath(path, k))...)
+			}
+		case reflect.Map:
+			for k, fv := range v {
+				removed = append(removed, strip(fv, t.Elem(), fmt.Sprintf("%s[%s]", path, k))...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, e := range v {
+				removed = append(removed, strip(e, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return removed
+}
+
+// jsonFields returns the types of the fields of a struct by their JSON name,
+// including the fields of embedded and inlined structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" && (f.Anonymous || strings.Contains(tag, ",inline")) {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					fields[k] = v
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookupField returns the type of the field with the JSON name, which like
+// encoding/json matches names case-insensitively if there is no exact match.
+func lookupField(fields map[string]reflect.Type, name string) (reflect.Type, bool) {
+	if t, ok := fields[name]; ok {
+		return t, true
+	}
+	for k, t := range fields {
+		if strings.EqualFold(k, name) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func object(kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "triggers.tekton.dev/v1alpha1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"annotations": map[string]interface{}{"team": "ci"},
+		},
+		"spec": spec,
+	}}
+	if namespace != "" {
+		u.SetNamespace(namespace)
+	}
+	return u
+}
+
+func eventListener() *unstructured.Unstructured {
+	el := object("EventListener", "ns", "listener", map[string]interface{}{
+		"serviceAccountName": "triggers",
+		"podTemplate":        map[string]interface{}{"nodeSelector": map[string]interface{}{"ci": "true"}},
+		"triggers": []interface{}{
+			map[string]interface{}{
+				"name":     "push",
+				"template": map[string]interface{}{"name": "tt", "apiversion": "v1alpha1"},
+				"interceptors": []interface{}{
+					map[string]interface{}{
+						"github": map[string]interface{}{
+							"eventTypes":  []interface{}{"push"},
+							"legacyToken": "abc",
+						},
+					},
+				},
+			},
+		},
+		"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"ci": "true"}},
+	})
+	el.Object["status"] = map[string]interface{}{"configuration": map[string]interface{}{"generatedName": "el-listener"}}
+	return el
+}
+
+func TestStripRemovedFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         *unstructured.Unstructured
+		wantRemoved []string
+		want        *unstructured.Unstructured
+	}{{
+		name:        "removed fields",
+		obj:         eventListener(),
+		wantRemoved: []string{"spec.podTemplate", "spec.triggers[0].interceptors[0].github.legacyToken"},
+		want: func() *unstructured.Unstructured {
+			el := eventListener()
+			spec := el.Object["spec"].(map[string]interface{})
+			delete(spec, "podTemplate")
+			github := spec["triggers"].([]interface{})[0].(map[string]interface{})["interceptors"].([]interface{})[0].(map[string]interface{})["github"].(map[string]interface{})
+			delete(github, "legacyToken")
+			return el
+		}(),
+	}, {
+		name: "resource templates and params are kept",
+		obj: object("TriggerTemplate", "ns", "tt", map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"name": "rev", "default": "main"}},
+			"resourcetemplates": []interface{}{
+				map[string]interface{}{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "anything": "goes"},
+			},
+		}),
+		want: object("TriggerTemplate", "ns", "tt", map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"name": "rev", "default": "main"}},
+			"resourcetemplates": []interface{}{
+				map[string]interface{}{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "anything": "goes"},
+			},
+		}),
+	}, {
+		name: "cluster-scoped",
+		obj: object("ClusterTriggerBinding", "", "ctb", map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"name": "url", "value": "$(body.url)", "pattern": "x"}},
+		}),
+		wantRemoved: []string{"spec.params[0].pattern"},
+		want: object("ClusterTriggerBinding", "", "ctb", map[string]interface{}{
+			"params": []interface{}{map[string]interface{}{"name": "url", "value": "$(body.url)"}},
+		}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removed, err := StripRemovedFields(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantRemoved, removed); diff != "" {
+				t.Errorf("StripRemovedFields() removed -want +got: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, tt.obj); diff != "" {
+				t.Errorf("StripRemovedFields() object -want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		client := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+			eventListener(),
+			object("TriggerBinding", "other", "tb", map[string]interface{}{
+				"params": []interface{}{map[string]interface{}{"name": "rev", "value": "$(body.sha)"}},
+			}),
+		)
+		m := Migrator{Client: client, DryRun: dryRun}
+		var got []Result
+		if err := m.Migrate(context.Background(), func(r Result) { got = append(got, r) }); err != nil {
+			t.Fatalf("Migrate() = %v", err)
+		}
+		want := []Result{{
+			Resource:  "triggerbindings",
+			Namespace: "other",
+			Name:      "tb",
+		}, {
+			Resource:  "eventlisteners",
+			Namespace: "ns",
+			Name:      "listener",
+			Removed:   []string{"spec.podTemplate", "spec.triggers[0].interceptors[0].github.legacyToken"},
+		}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Migrate() with dryRun %t -want +got: %s", dryRun, diff)
+		}
+
+		el, err := client.Resource(v1alpha1.SchemeGroupVersion.WithResource("eventlisteners")).Namespace("ns").Get("listener", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, found, _ := unstructured.NestedFieldNoCopy(el.Object, "spec", "podTemplate")
+		if found != dryRun {
+			t.Errorf("spec.podTemplate is stored: %t, want %t with dryRun %t", found, dryRun, dryRun)
+		}
+		updates := 0
+		for _, a := range client.Actions() {
+			if a.GetVerb() == "update" {
+				updates++
+			}
+		}
+		if wantUpdates := map[bool]int{true: 0, false: 2}[dryRun]; updates != wantUpdates {
+			t.Errorf("Migrate() with dryRun %t made %d updates, want %d", dryRun, updates, wantUpdates)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payloads
+
+// BitbucketEvent returns a pointer to a new event of the type for the
+// X-Event-Key header of a Bitbucket Server webhook, or nil if the event has no
+// type in this package.
+func BitbucketEvent(eventKey string) interface{} {
+	switch eventKey {
+	case "repo:refs_changed":
+		return &BitbucketRefsChangedEvent{}
+	case "pr:opened", "pr:from_ref_updated", "pr:modified", "pr:merged", "pr:declined", "pr:deleted",
+		"pr:reviewer:approved", "pr:reviewer:unapproved", "pr:reviewer:needs_work":
+		return &BitbucketPullRequestEvent{}
+	}
+	return nil
+}
+
+// BitbucketRefsChangedEvent is the payload of repo:refs_changed events.
+type BitbucketRefsChangedEvent struct {
+	EventKey   string              `json:"eventKey"`
+	Date       string              `json:"date"`
+	Actor      BitbucketUser       `json:"actor"`
+	Repository BitbucketRepository `json:"repository"`
+	Changes    []BitbucketChange   `json:"changes"`
+}
+
+// BitbucketPullRequestEvent is the payload of pr: events.
+type BitbucketPullRequestEvent struct {
+	EventKey    string               `json:"eventKey"`
+	Date        string               `json:"date"`
+	Actor       BitbucketUser        `json:"actor"`
+	PullRequest BitbucketPullRequest `json:"pullRequest"`
+	// Participant is the reviewer of pr:reviewer: events.
+	Participant *BitbucketParticipant `json:"participant"`
+}
+
+// BitbucketChange is a ref changed by a repo:refs_changed event.
+type BitbucketChange struct {
+	Ref BitbucketChangedRef `json:"ref"`
+	// RefID is the full ref, e.g. refs/heads/main.
+	RefID    string `json:"refId"`
+	FromHash string `json:"fromHash"`
+	ToHash   string `json:"toHash"`
+	// Type is ADD, DELETE or UPDATE.
+	Type string `json:"type"`
+}
+
+// BitbucketChangedRef is the branch or tag of a change.
+type BitbucketChangedRef struct {
+	ID        string `json:"id"`
+	DisplayID string `json:"displayId"`
+	// Type is BRANCH or TAG.
+	Type string `json:"type"`
+}
+
+// BitbucketPullRequest is the pull request of a pr: event.
+type BitbucketPullRequest struct {
+	ID        int64                  `json:"id"`
+	Version   int64                  `json:"version"`
+	Title     string                 `json:"title"`
+	State     string                 `json:"state"`
+	Open      bool                   `json:"open"`
+	FromRef   BitbucketRef           `json:"fromRef"`
+	ToRef     BitbucketRef           `json:"toRef"`
+	Author    BitbucketParticipant   `json:"author"`
+	Reviewers []BitbucketParticipant `json:"reviewers"`
+}
+
+// BitbucketRef is the source or target of a pull request.
+type BitbucketRef struct {
+	ID           string              `json:"id"`
+	DisplayID    string              `json:"displayId"`
+	LatestCommit string              `json:"latestCommit"`
+	Repository   BitbucketRepository `json:"repository"`
+}
+
+// BitbucketParticipant is the author or a reviewer of a pull request.
+type BitbucketParticipant struct {
+	User BitbucketUser `json:"user"`
+	Role string        `json:"role"`
+	// Status is UNAPPROVED, NEEDS_WORK or APPROVED.
+	Status string `json:"status"`
+}
+
+// BitbucketRepository is a repository of an event.
+type BitbucketRepository struct {
+	ID      int64            `json:"id"`
+	Slug    string           `json:"slug"`
+	Name    string           `json:"name"`
+	Project BitbucketProject `json:"project"`
+}
+
+// BitbucketProject is the project of a repository.
+type BitbucketProject struct {
+	ID   int64  `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// BitbucketUser is a user of an event.
+type BitbucketUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	Slug         string `json:"slug"`
+}
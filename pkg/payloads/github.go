@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payloads
+
+// GitHubEvent returns a pointer to a new event of the type for the
+// X-GitHub-Event header of a webhook, or nil if the event has no type in this
+// package.
+func GitHubEvent(eventType string) interface{} {
+	switch eventType {
+	case "push":
+		return &GitHubPushEvent{}
+	case "pull_request":
+		return &GitHubPullRequestEvent{}
+	case "create", "delete":
+		return &GitHubRefEvent{}
+	case "release":
+		return &GitHubReleaseEvent{}
+	}
+	return nil
+}
+
+// GitHubPushEvent is the payload of push events.
+type GitHubPushEvent struct {
+	// Ref is the full ref that was pushed, e.g. refs/heads/main or
+	// refs/tags/v1.0.0.
+	Ref        string           `json:"ref"`
+	Before     string           `json:"before"`
+	After      string           `json:"after"`
+	Created    bool             `json:"created"`
+	Deleted    bool             `json:"deleted"`
+	Forced     bool             `json:"forced"`
+	Compare    string           `json:"compare"`
+	Commits    []GitHubCommit   `json:"commits"`
+	HeadCommit *GitHubCommit    `json:"head_commit"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+// GitHubPullRequestEvent is the payload of pull_request events.
+type GitHubPullRequestEvent struct {
+	// Action is what happened to the pull request, e.g. opened or
+	// synchronize.
+	Action      string            `json:"action"`
+	Number      int64             `json:"number"`
+	PullRequest GitHubPullRequest `json:"pull_request"`
+	Repository  GitHubRepository  `json:"repository"`
+	Sender      GitHubUser        `json:"sender"`
+}
+
+// GitHubRefEvent is the payload of create and delete events.
+type GitHubRefEvent struct {
+	// Ref is the short name of the branch or tag, e.g. v1.0.0.
+	Ref string `json:"ref"`
+	// RefType is branch or tag.
+	RefType    string           `json:"ref_type"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+// GitHubReleaseEvent is the payload of release events.
+type GitHubReleaseEvent struct {
+	Action     string           `json:"action"`
+	Release    GitHubRelease    `json:"release"`
+	Repository GitHubRepository `json:"repository"`
+	Sender     GitHubUser       `json:"sender"`
+}
+
+// GitHubCommit is a commit of a push event.
+type GitHubCommit struct {
+	ID        string   `json:"id"`
+	Message   string   `json:"message"`
+	Timestamp string   `json:"timestamp"`
+	URL       string   `json:"url"`
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Modified  []string `json:"modified"`
+}
+
+// GitHubPullRequest is the pull request of a pull_request event.
+type GitHubPullRequest struct {
+	Number  int64          `json:"number"`
+	State   string         `json:"state"`
+	Title   string         `json:"title"`
+	HTMLURL string         `json:"html_url"`
+	Draft   bool           `json:"draft"`
+	Merged  bool           `json:"merged"`
+	Head    GitHubPRBranch `json:"head"`
+	Base    GitHubPRBranch `json:"base"`
+	User    GitHubUser     `json:"user"`
+	Labels  []GitHubLabel  `json:"labels"`
+}
+
+// GitHubPRBranch is the head or base branch of a pull request.
+type GitHubPRBranch struct {
+	Ref  string            `json:"ref"`
+	SHA  string            `json:"sha"`
+	Repo *GitHubRepository `json:"repo"`
+}
+
+// GitHubLabel is a label of a pull request.
+type GitHubLabel struct {
+	Name string `json:"name"`
+}
+
+// GitHubRelease is the release of a release event.
+type GitHubRelease struct {
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	Name            string `json:"name"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+}
+
+// GitHubRepository is the repository of an event. Timestamps are left out
+// since their type depends on the event.
+type GitHubRepository struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	FullName      string     `json:"full_name"`
+	Private       bool       `json:"private"`
+	HTMLURL       string     `json:"html_url"`
+	CloneURL      string     `json:"clone_url"`
+	SSHURL        string     `json:"ssh_url"`
+	DefaultBranch string     `json:"default_branch"`
+	Owner         GitHubUser `json:"owner"`
+}
+
+// GitHubUser is a user or organization of an event.
+type GitHubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
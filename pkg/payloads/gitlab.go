@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payloads
+
+// GitLabEvent returns a pointer to a new event of the type for the
+// X-GitLab-Event header of a webhook, or nil if the event has no type in this
+// package.
+func GitLabEvent(eventType string) interface{} {
+	switch eventType {
+	case "Push Hook", "Tag Push Hook":
+		return &GitLabPushEvent{}
+	case "Merge Request Hook":
+		return &GitLabMergeRequestEvent{}
+	case "Pipeline Hook":
+		return &GitLabPipelineEvent{}
+	case "Job Hook":
+		return &GitLabJobEvent{}
+	case "Deployment Hook":
+		return &GitLabDeploymentEvent{}
+	}
+	return nil
+}
+
+// GitLabPushEvent is the payload of Push Hook and Tag Push Hook events.
+type GitLabPushEvent struct {
+	// ObjectKind is push or tag_push.
+	ObjectKind        string         `json:"object_kind"`
+	Ref               string         `json:"ref"`
+	Before            string         `json:"before"`
+	After             string         `json:"after"`
+	CheckoutSHA       string         `json:"checkout_sha"`
+	UserUsername      string         `json:"user_username"`
+	ProjectID         int64          `json:"project_id"`
+	Project           GitLabProject  `json:"project"`
+	Commits           []GitLabCommit `json:"commits"`
+	TotalCommitsCount int64          `json:"total_commits_count"`
+}
+
+// GitLabMergeRequestEvent is the payload of Merge Request Hook events.
+type GitLabMergeRequestEvent struct {
+	ObjectKind       string             `json:"object_kind"`
+	User             GitLabUser         `json:"user"`
+	Project          GitLabProject      `json:"project"`
+	ObjectAttributes GitLabMergeRequest `json:"object_attributes"`
+	Labels           []GitLabLabel      `json:"labels"`
+}
+
+// GitLabPipelineEvent is the payload of Pipeline Hook events.
+type GitLabPipelineEvent struct {
+	ObjectKind       string         `json:"object_kind"`
+	ObjectAttributes GitLabPipeline `json:"object_attributes"`
+	User             GitLabUser     `json:"user"`
+	Project          GitLabProject  `json:"project"`
+	Commit           *GitLabCommit  `json:"commit"`
+}
+
+// GitLabJobEvent is the payload of Job Hook events.
+type GitLabJobEvent struct {
+	ObjectKind string `json:"object_kind"`
+	Ref        string `json:"ref"`
+	Tag        bool   `json:"tag"`
+	SHA        string `json:"sha"`
+	BuildID    int64  `json:"build_id"`
+	BuildName  string `json:"build_name"`
+	BuildStage string `json:"build_stage"`
+	// BuildStatus is the status of the job, e.g. running or success.
+	BuildStatus string     `json:"build_status"`
+	PipelineID  int64      `json:"pipeline_id"`
+	ProjectID   int64      `json:"project_id"`
+	ProjectName string     `json:"project_name"`
+	User        GitLabUser `json:"user"`
+	// Environment is set for jobs that deploy to an environment.
+	Environment *GitLabJobEnvironment `json:"environment"`
+}
+
+// GitLabJobEnvironment is the environment that a job deploys to.
+type GitLabJobEnvironment struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// GitLabDeploymentEvent is the payload of Deployment Hook events.
+type GitLabDeploymentEvent struct {
+	ObjectKind   string `json:"object_kind"`
+	DeploymentID int64  `json:"deployment_id"`
+	// Status is the status of the deployment, e.g. running or success.
+	Status      string        `json:"status"`
+	Environment string        `json:"environment"`
+	ShortSHA    string        `json:"short_sha"`
+	Ref         string        `json:"ref"`
+	CommitURL   string        `json:"commit_url"`
+	User        GitLabUser    `json:"user"`
+	Project     GitLabProject `json:"project"`
+}
+
+// GitLabCommit is a commit of an event.
+type GitLabCommit struct {
+	ID        string   `json:"id"`
+	Message   string   `json:"message"`
+	Timestamp string   `json:"timestamp"`
+	URL       string   `json:"url"`
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Modified  []string `json:"modified"`
+}
+
+// GitLabMergeRequest is the merge request of a Merge Request Hook event.
+type GitLabMergeRequest struct {
+	ID           int64  `json:"id"`
+	IID          int64  `json:"iid"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+	Action       string `json:"action"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	URL          string `json:"url"`
+	LastCommit   struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	} `json:"last_commit"`
+}
+
+// GitLabPipeline is the pipeline of a Pipeline Hook event.
+type GitLabPipeline struct {
+	ID     int64  `json:"id"`
+	Ref    string `json:"ref"`
+	Tag    bool   `json:"tag"`
+	SHA    string `json:"sha"`
+	Source string `json:"source"`
+	// Status is the status of the pipeline, e.g. running or success.
+	Status string `json:"status"`
+}
+
+// GitLabProject is the project of an event.
+type GitLabProject struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+	GitHTTPURL        string `json:"git_http_url"`
+	GitSSHURL         string `json:"git_ssh_url"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+// GitLabUser is the user of an event.
+type GitLabUser struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// GitLabLabel is a label of a merge request.
+type GitLabLabel struct {
+	Title string `json:"title"`
+}
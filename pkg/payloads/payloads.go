@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package payloads has Go types for the webhook payloads of GitHub, GitLab
+// and Bitbucket Server that the built-in interceptors read, for interceptors
+// that want to read events without walking untyped JSON.
+//
+// The types only have the commonly used fields of each payload. Decode
+// ignores the fields that are not part of a type, so that payloads keep
+// decoding when providers add fields. Use the event types with Decode, or
+// look up the type for the event header of a request with GitHubEvent,
+// GitLabEvent or BitbucketEvent:
+//
+//	event := payloads.GitHubEvent(request.Header.Get("X-GitHub-Event"))
+//	if event == nil {
+//		return fmt.Errorf("unsupported event")
+//	}
+//	if err := payloads.Decode(body, event); err != nil {
+//		return err
+//	}
+//	if push, ok := event.(*payloads.GitHubPushEvent); ok {
+//		...
+//	}
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Decode decodes a JSON payload into v, which is usually a pointer to one of
+// the event types of this package. Fields of the payload that v has no field
+// for are ignored.
+func Decode(payload []byte, v interface{}) error {
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("failed to decode %T: %w", v, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package payloads
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   interface{}
+		payload string
+		want    interface{}
+	}{{
+		name:  "GitHub push",
+		event: GitHubEvent("push"),
+		// created_at is a number in push events and a string in others
+		payload: `{"ref":"refs/tags/v1.0.0","after":"abc","head_commit":{"id":"abc"},"repository":{"full_name":"tektoncd/triggers","created_at":1577836800},"pusher":{"name":"x"}}`,
+		want: &GitHubPushEvent{
+			Ref:        "refs/tags/v1.0.0",
+			After:      "abc",
+			HeadCommit: &GitHubCommit{ID: "abc"},
+			Repository: GitHubRepository{FullName: "tektoncd/triggers"},
+		},
+	}, {
+		name:    "GitHub pull request",
+		event:   GitHubEvent("pull_request"),
+		payload: `{"action":"opened","number":7,"pull_request":{"head":{"ref":"fix","sha":"abc"},"base":{"ref":"main"},"labels":[{"name":"bug"}]}}`,
+		want: &GitHubPullRequestEvent{
+			Action: "opened",
+			Number: 7,
+			PullRequest: GitHubPullRequest{
+				Head:   GitHubPRBranch{Ref: "fix", SHA: "abc"},
+				Base:   GitHubPRBranch{Ref: "main"},
+				Labels: []GitHubLabel{{Name: "bug"}},
+			},
+		},
+	}, {
+		name:    "GitLab job",
+		event:   GitLabEvent("Job Hook"),
+		payload: `{"object_kind":"build","build_status":"success","environment":{"name":"production","action":"start"},"repository":{}}`,
+		want: &GitLabJobEvent{
+			ObjectKind:  "build",
+			BuildStatus: "success",
+			Environment: &GitLabJobEnvironment{Name: "production", Action: "start"},
+		},
+	}, {
+		name:    "GitLab pipeline",
+		event:   GitLabEvent("Pipeline Hook"),
+		payload: `{"object_kind":"pipeline","object_attributes":{"id":1,"ref":"main","status":"failed","stages":["test"]}}`,
+		want: &GitLabPipelineEvent{
+			ObjectKind:       "pipeline",
+			ObjectAttributes: GitLabPipeline{ID: 1, Ref: "main", Status: "failed"},
+		},
+	}, {
+		name:    "Bitbucket refs changed",
+		event:   BitbucketEvent("repo:refs_changed"),
+		payload: `{"eventKey":"repo:refs_changed","repository":{"slug":"app","project":{"key":"PRJ"}},"changes":[{"ref":{"id":"refs/tags/v1","displayId":"v1","type":"TAG"},"refId":"refs/tags/v1","type":"ADD"}]}`,
+		want: &BitbucketRefsChangedEvent{
+			EventKey:   "repo:refs_changed",
+			Repository: BitbucketRepository{Slug: "app", Project: BitbucketProject{Key: "PRJ"}},
+			Changes: []BitbucketChange{{
+				Ref:   BitbucketChangedRef{ID: "refs/tags/v1", DisplayID: "v1", Type: "TAG"},
+				RefID: "refs/tags/v1",
+				Type:  "ADD",
+			}},
+		},
+	}, {
+		name:    "Bitbucket reviewer",
+		event:   BitbucketEvent("pr:reviewer:approved"),
+		payload: `{"eventKey":"pr:reviewer:approved","pullRequest":{"id":3,"toRef":{"displayId":"main"}},"participant":{"status":"APPROVED"}}`,
+		want: &BitbucketPullRequestEvent{
+			EventKey:    "pr:reviewer:approved",
+			PullRequest: BitbucketPullRequest{ID: 3, ToRef: BitbucketRef{DisplayID: "main"}},
+			Participant: &BitbucketParticipant{Status: "APPROVED"},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Decode([]byte(tc.payload), tc.event); err != nil {
+				t.Fatalf("Decode() = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.event); diff != "" {
+				t.Errorf("Decode() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecode_error(t *testing.T) {
+	for _, payload := range []string{
+		`not json`,
+		`{"ref": 1}`,
+	} {
+		if err := Decode([]byte(payload), &GitHubPushEvent{}); err == nil {
+			t.Errorf("Decode(%q) expected error", payload)
+		}
+	}
+}
+
+func TestEventTypes(t *testing.T) {
+	for _, event := range []interface{}{GitHubEvent("ping"), GitLabEvent("Note Hook"), BitbucketEvent("repo:forked")} {
+		if event != nil {
+			t.Errorf("got event type %T, want nil for events without a type", event)
+		}
+	}
+}
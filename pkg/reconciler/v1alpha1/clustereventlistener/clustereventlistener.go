@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustereventlistener
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	listers "github.com/tektoncd/triggers/pkg/client/listers/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// clusterEventListenerAgentName defines logging agent name for ClusterEventListener Controller
+	clusterEventListenerAgentName = "clustereventlistener-controller"
+	// clusterEventListenerControllerName defines name for ClusterEventListener Controller
+	clusterEventListenerControllerName = "ClusterEventListener"
+	// clusterEventListenerLabel is set on the generated EventListener to
+	// point back at the ClusterEventListener it was generated for
+	clusterEventListenerLabel = v1alpha1.GroupName + v1alpha1.ClusterEventListenerLabelKey
+
+	// EventListenerExists is the ConditionType set on the ClusterEventListener,
+	// which specifies existence of the generated EventListener.
+	EventListenerExists apis.ConditionType = "EventListener"
+)
+
+// Reconciler implements controller.Reconciler for ClusterEventListener
+// resources. Every ClusterEventListener is materialized as an EventListener
+// in the system namespace, which the EventListener reconciler then turns
+// into a Deployment and Service.
+type Reconciler struct {
+	*reconciler.Base
+	// listers index properties about resources
+	clusterEventListenerLister listers.ClusterEventListenerLister
+	eventListenerLister        listers.EventListenerLister
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile compares the actual state with the desired, and attempts to
+// converge the two.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	c.Logger.Infof("cluster-event-listener-reconcile %s", key)
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	original, err := c.clusterEventListenerLister.Get(name)
+	if errors.IsNotFound(err) {
+		// The generated EventListener is garbage collected through its
+		// owner reference.
+		c.Logger.Infof("ClusterEventListener %q in work queue no longer exists", key)
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving ClusterEventListener %q: %s", name, err)
+		return err
+	}
+
+	// Don't modify the informer's copy
+	cel := original.DeepCopy()
+	reconcileErr := c.reconcile(ctx, cel)
+	if !equality.Semantic.DeepEqual(original.Status, cel.Status) {
+		if _, err := c.TriggersClientSet.TriggersV1alpha1().ClusterEventListeners().UpdateStatus(cel); err != nil {
+			c.Logger.Warn("Failed to update ClusterEventListener status", err.Error())
+			return err
+		}
+	}
+	return reconcileErr
+}
+
+func (c *Reconciler) reconcile(ctx context.Context, cel *v1alpha1.ClusterEventListener) error {
+	cel.SetDefaults(v1alpha1.WithUpgradeViaDefaulting(ctx))
+
+	el := generateEventListener(cel)
+	existing, err := c.eventListenerLister.EventListeners(el.Namespace).Get(el.Name)
+	switch {
+	case err == nil:
+		if !metav1.IsControlledBy(existing, cel) {
+			err = fmt.Errorf("EventListener %s/%s already exists and is not owned by ClusterEventListener %s", el.Namespace, el.Name, cel.Name)
+			setExistsCondition(cel, err)
+			return err
+		}
+		updated := existing.DeepCopy()
+		if !reflect.DeepEqual(updated.Labels, el.Labels) || !equality.Semantic.DeepEqual(updated.Spec, el.Spec) {
+			updated.Labels = el.Labels
+			updated.Spec = el.Spec
+			if updated, err = c.TriggersClientSet.TriggersV1alpha1().EventListeners(el.Namespace).Update(updated); err != nil {
+				c.Logger.Errorf("Error updating EventListener for ClusterEventListener %s: %s", cel.Name, err)
+				return err
+			}
+			c.Logger.Infof("Updated EventListener %s in Namespace %s", el.Name, el.Namespace)
+		}
+		propagateStatus(cel, updated)
+	case errors.IsNotFound(err):
+		created, err := c.TriggersClientSet.TriggersV1alpha1().EventListeners(el.Namespace).Create(el)
+		setExistsCondition(cel, err)
+		if err != nil {
+			c.Logger.Errorf("Error creating EventListener for ClusterEventListener %s: %s", cel.Name, err)
+			return err
+		}
+		propagateStatus(cel, created)
+		c.Logger.Infof("Created EventListener %s in Namespace %s", el.Name, el.Namespace)
+	default:
+		c.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// generateEventListener returns the EventListener in the system namespace
+// that backs the ClusterEventListener.
+func generateEventListener(cel *v1alpha1.ClusterEventListener) *v1alpha1.EventListener {
+	labels := make(map[string]string, len(cel.Labels)+1)
+	for k, v := range cel.Labels {
+		labels[k] = v
+	}
+	labels[clusterEventListenerLabel] = cel.Name
+	return &v1alpha1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cel.Name,
+			Namespace:       system.GetNamespace(),
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*cel.GetOwnerReference()},
+		},
+		Spec: *cel.Spec.DeepCopy(),
+	}
+}
+
+// propagateStatus mirrors the status of the generated EventListener onto the
+// ClusterEventListener.
+func propagateStatus(cel *v1alpha1.ClusterEventListener, el *v1alpha1.EventListener) {
+	conditions := cel.Status.Conditions
+	cel.Status = *el.Status.DeepCopy()
+	for i := range conditions {
+		if conditions[i].Type == EventListenerExists {
+			cel.Status.Conditions = append(cel.Status.Conditions, conditions[i])
+		}
+	}
+	setExistsCondition(cel, nil)
+}
+
+func setExistsCondition(cel *v1alpha1.ClusterEventListener, err error) {
+	cel.Status.SetExistsCondition(EventListenerExists, err)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustereventlistener
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+var (
+	clusterEventListenerName = "my-clustereventlistener"
+	systemNamespace          = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: system.GetNamespace(),
+		},
+	}
+)
+
+func getClusterEventListenerTestAssets(t *testing.T, r test.Resources) (test.Assets, context.CancelFunc) {
+	t.Helper()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	clients := test.SeedResources(t, ctx, r)
+	cmw := configmap.NewInformedWatcher(clients.Kube, system.GetNamespace())
+	return test.Assets{
+		Controller: NewController(ctx, cmw),
+		Clients:    clients,
+	}, cancel
+}
+
+func clusterEventListener() *v1alpha1.ClusterEventListener {
+	return &v1alpha1.ClusterEventListener{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   clusterEventListenerName,
+			Labels: map[string]string{"team": "platform"},
+		},
+		Spec: v1alpha1.EventListenerSpec{
+			ServiceAccountName: "sa",
+			Triggers: []v1alpha1.EventListenerTrigger{{
+				Bindings: []*v1alpha1.EventListenerBinding{{Name: "ctb"}},
+				Template: v1alpha1.EventListenerTemplate{Name: "tt"},
+			}},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	cel := clusterEventListener()
+	wantSpec := *cel.Spec.DeepCopy()
+	wantSpec.Triggers[0].Bindings[0].Kind = v1alpha1.ClusterTriggerBindingKind
+	wantEventListener := bldr.EventListener(clusterEventListenerName, system.GetNamespace(),
+		bldr.EventListenerMeta(
+			bldr.Label("team", "platform"),
+			bldr.Label(clusterEventListenerLabel, clusterEventListenerName),
+		),
+	)
+	wantEventListener.OwnerReferences = []metav1.OwnerReference{*cel.GetOwnerReference()}
+	wantEventListener.Spec = wantSpec
+
+	// An existing EventListener whose spec has drifted is updated
+	staleEventListener := wantEventListener.DeepCopy()
+	staleEventListener.Spec.ServiceAccountName = "other"
+
+	tests := []struct {
+		name           string
+		startResources test.Resources
+	}{{
+		name: "create-eventlistener",
+		startResources: test.Resources{
+			Namespaces:            []*corev1.Namespace{systemNamespace},
+			ClusterEventListeners: []*v1alpha1.ClusterEventListener{cel},
+		},
+	}, {
+		name: "update-eventlistener",
+		startResources: test.Resources{
+			Namespaces:            []*corev1.Namespace{systemNamespace},
+			ClusterEventListeners: []*v1alpha1.ClusterEventListener{cel},
+			EventListeners:        []*v1alpha1.EventListener{staleEventListener},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testAssets, cancel := getClusterEventListenerTestAssets(t, tt.startResources)
+			defer cancel()
+
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), clusterEventListenerName); err != nil {
+				t.Fatal(err)
+			}
+			got, err := testAssets.Clients.Triggers.TriggersV1alpha1().EventListeners(system.GetNamespace()).Get(clusterEventListenerName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(wantEventListener, got); diff != "" {
+				t.Errorf("EventListener did not match expected state. -want, +got: %s", diff)
+			}
+			cel, err := testAssets.Clients.Triggers.TriggersV1alpha1().ClusterEventListeners().Get(clusterEventListenerName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c := cel.Status.GetCondition(EventListenerExists); c == nil || c.Status != corev1.ConditionTrue {
+				t.Errorf("expected %s condition to be true, got %v", EventListenerExists, c)
+			}
+		})
+	}
+}
+
+func TestReconcile_NotOwned(t *testing.T) {
+	existing := bldr.EventListener(clusterEventListenerName, system.GetNamespace())
+	testAssets, cancel := getClusterEventListenerTestAssets(t, test.Resources{
+		Namespaces:            []*corev1.Namespace{systemNamespace},
+		ClusterEventListeners: []*v1alpha1.ClusterEventListener{clusterEventListener()},
+		EventListeners:        []*v1alpha1.EventListener{existing},
+	})
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), clusterEventListenerName); err == nil {
+		t.Fatal("expected error reconciling ClusterEventListener over an unowned EventListener")
+	}
+	cel, err := testAssets.Clients.Triggers.TriggersV1alpha1().ClusterEventListeners().Get(clusterEventListenerName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := cel.Status.GetCondition(EventListenerExists); c == nil || c.Status != corev1.ConditionFalse {
+		t.Errorf("expected %s condition to be false, got %v", EventListenerExists, c)
+	}
+}
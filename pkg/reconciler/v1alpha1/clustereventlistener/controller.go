@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustereventlistener
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersclient "github.com/tektoncd/triggers/pkg/client/injection/client"
+	clustereventlistenerinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/clustereventlistener"
+	eventlistenerinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/eventlistener"
+	"github.com/tektoncd/triggers/pkg/reconciler"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	resyncPeriod = 10 * time.Hour
+)
+
+// NewController creates a new instance of a ClusterEventListener controller.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := logging.FromContext(ctx)
+	kubeclientset := kubeclient.Get(ctx)
+	triggersclientset := triggersclient.Get(ctx)
+	clusterEventListenerInformer := clustereventlistenerinformer.Get(ctx)
+	eventListenerInformer := eventlistenerinformer.Get(ctx)
+
+	opt := reconciler.Options{
+		KubeClientSet:     kubeclientset,
+		TriggersClientSet: triggersclientset,
+		ConfigMapWatcher:  cmw,
+		Logger:            logger,
+		ResyncPeriod:      resyncPeriod,
+	}
+
+	c := &Reconciler{
+		Base:                       reconciler.NewBase(opt, clusterEventListenerAgentName),
+		clusterEventListenerLister: clusterEventListenerInformer.Lister(),
+		eventListenerLister:        eventListenerInformer.Lister(),
+	}
+	impl := controller.NewImpl(c, c.Logger, clusterEventListenerControllerName)
+
+	c.Logger.Info("Setting up event handlers")
+	clusterEventListenerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    impl.Enqueue,
+		UpdateFunc: controller.PassNew(impl.Enqueue),
+		DeleteFunc: impl.Enqueue,
+	})
+
+	eventListenerInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("ClusterEventListener")),
+		Handler:    controller.HandleAll(impl.EnqueueLabelOfClusterScopedResource(clusterEventListenerLabel)),
+	})
+
+	return impl
+}
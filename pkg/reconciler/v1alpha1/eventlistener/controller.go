@@ -22,16 +22,25 @@ import (
 
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	resourceclient "github.com/tektoncd/pipeline/pkg/client/resource/injection/client"
+	"github.com/tektoncd/triggers/pkg/apis/config"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	triggersclient "github.com/tektoncd/triggers/pkg/client/injection/client"
+	clusterinterceptorinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/clusterinterceptor"
+	clustertriggerbindinginformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/clustertriggerbinding"
 	eventlistenerinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/eventlistener"
+	interceptorchaininformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/interceptorchain"
+	triggerbindinginformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/triggerbinding"
+	triggertemplateinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/triggertemplate"
 	"github.com/tektoncd/triggers/pkg/reconciler"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deployinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 )
 
@@ -49,6 +58,13 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	eventListenerInformer := eventlistenerinformer.Get(ctx)
 	deploymentInformer := deployinformer.Get(ctx)
 	serviceInformer := serviceinformer.Get(ctx)
+	configMapInformer := configmapinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+	triggerBindingInformer := triggerbindinginformer.Get(ctx)
+	clusterTriggerBindingInformer := clustertriggerbindinginformer.Get(ctx)
+	triggerTemplateInformer := triggertemplateinformer.Get(ctx)
+	interceptorChainInformer := interceptorchaininformer.Get(ctx)
+	clusterInterceptorInformer := clusterinterceptorinformer.Get(ctx)
 
 	opt := reconciler.Options{
 		KubeClientSet:     kubeclientset,
@@ -61,11 +77,27 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	}
 
 	c := &Reconciler{
-		Base:                reconciler.NewBase(opt, eventListenerAgentName),
-		eventListenerLister: eventListenerInformer.Lister(),
+		Base:                        reconciler.NewBase(opt, eventListenerAgentName),
+		eventListenerLister:         eventListenerInformer.Lister(),
+		triggerBindingLister:        triggerBindingInformer.Lister(),
+		clusterTriggerBindingLister: clusterTriggerBindingInformer.Lister(),
+		triggerTemplateLister:       triggerTemplateInformer.Lister(),
+		interceptorChainLister:      interceptorChainInformer.Lister(),
+		clusterInterceptorLister:    clusterInterceptorInformer.Lister(),
+		serviceLister:               serviceInformer.Lister(),
+		configMapLister:             configMapInformer.Lister(),
+		secretLister:                secretInformer.Lister(),
+		dynamicClientSet:            dynamicclient.Get(ctx),
 	}
 	impl := controller.NewImpl(c, c.Logger, eventListenerControllerName)
 
+	// Reconcile all EventListeners when the defaults change so that the sinks
+	// pick them up without restarting the controller.
+	c.configStore = config.NewStore(c.Logger.Named("config-store"), func(string, interface{}) {
+		impl.GlobalResync(eventListenerInformer.Informer())
+	})
+	c.configStore.WatchConfigs(cmw)
+
 	c.Logger.Info("Setting up event handlers")
 	eventListenerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    impl.Enqueue,
@@ -83,5 +115,20 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// Changes to the objects that Triggers reference update the
+	// TriggersResolved condition of the EventListeners that reference them
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(c.enqueueReferencing(impl, c.referencesInterceptor(usesSecret))))
+	serviceInformer.Informer().AddEventHandler(controller.HandleAll(c.enqueueReferencing(impl, c.referencesInterceptor(usesService))))
+	configMapInformer.Informer().AddEventHandler(controller.HandleAll(c.enqueueReferencing(impl, c.referencesInterceptor(usesConfigMap))))
+	interceptorChainInformer.Informer().AddEventHandler(controller.HandleAll(
+		c.enqueueReferencing(impl, c.referencesInterceptor(usesInterceptorChain))))
+	clusterInterceptorInformer.Informer().AddEventHandler(controller.HandleAll(
+		c.enqueueReferencing(impl, c.referencesInterceptor(usesClusterInterceptor))))
+	triggerBindingInformer.Informer().AddEventHandler(controller.HandleAll(
+		c.enqueueReferencing(impl, referencesBinding(v1alpha1.NamespacedTriggerBindingKind))))
+	clusterTriggerBindingInformer.Informer().AddEventHandler(controller.HandleAll(
+		c.enqueueReferencing(impl, referencesBinding(v1alpha1.ClusterTriggerBindingKind))))
+	triggerTemplateInformer.Informer().AddEventHandler(controller.HandleAll(c.enqueueReferencing(impl, referencesTriggerTemplate)))
+
 	return impl
 }
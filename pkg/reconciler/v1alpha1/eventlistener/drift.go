@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SpecHashAnnotation records the hash of the spec that the reconciler
+	// last wrote to a generated Deployment or Service
+	SpecHashAnnotation = "triggers.tekton.dev/spec-hash"
+	// UnmanagedFieldsAnnotation is a comma separated list of the fields of a
+	// generated Deployment or Service that are managed outside of the
+	// reconciler and are left as they are
+	UnmanagedFieldsAnnotation = "triggers.tekton.dev/unmanaged-fields"
+	// reasonDriftCorrected is the reason of the Events recorded on an
+	// EventListener when out-of-band changes to its resources are reverted
+	reasonDriftCorrected = "DriftCorrected"
+)
+
+// specHash returns the hex encoded SHA-256 of the JSON encoding of the spec.
+func specHash(spec interface{}) string {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// setSpecHash records the hash of the spec in the annotations of the object.
+func setSpecHash(meta *metav1.ObjectMeta, spec interface{}) {
+	metav1.SetMetaDataAnnotation(meta, SpecHashAnnotation, specHash(spec))
+}
+
+// reconcileSpecHash copies the spec hash of the desired object to the
+// existing one and returns whether it changed. The fields of an existing
+// object that differ from the desired object while the hashes are equal were
+// changed out-of-band.
+func reconcileSpecHash(oldMeta *metav1.ObjectMeta, newMeta metav1.ObjectMeta) (updated bool) {
+	hash := newMeta.Annotations[SpecHashAnnotation]
+	if oldMeta.Annotations[SpecHashAnnotation] == hash {
+		return false
+	}
+	metav1.SetMetaDataAnnotation(oldMeta, SpecHashAnnotation, hash)
+	return true
+}
+
+// unmanagedFields is the set of fields listed in the UnmanagedFieldsAnnotation
+// of a generated object.
+type unmanagedFields map[string]bool
+
+func getUnmanagedFields(meta metav1.ObjectMeta) unmanagedFields {
+	fields := unmanagedFields{}
+	for _, f := range strings.Split(meta.Annotations[UnmanagedFieldsAnnotation], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields[f] = true
+		}
+	}
+	return fields
+}
+
+// manages returns whether the reconciler manages the field.
+func (u unmanagedFields) manages(field string) bool {
+	return !u[field]
+}
+
+// recordDrift reports the fields of a generated resource that were changed
+// out-of-band and reverted by the reconciler.
+func (c *Reconciler) recordDrift(el *v1alpha1.EventListener, kind, name string, fields []string) {
+	c.Logger.Warnf("Corrected drift of %s %s in Namespace %s: %s", kind, name, el.Namespace, strings.Join(fields, ", "))
+	c.Recorder.Eventf(el, corev1.EventTypeWarning, reasonDriftCorrected,
+		"Reverted out-of-band changes to %s of %s %s", strings.Join(fields, ", "), kind, name)
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// generatedDeployment returns the Deployment the reconciler generates for the
+// EventListener, including its spec hash.
+func generatedDeployment(el *v1alpha1.EventListener) *appsv1.Deployment {
+	d := MakeDeployment(el, SinkDefaults(context.Background()))
+	setSpecHash(&d.ObjectMeta, d.Spec)
+	return d
+}
+
+// generatedService returns the Service the reconciler generates for the
+// EventListener, including its spec hash.
+func generatedService(el *v1alpha1.EventListener) *corev1.Service {
+	s := MakeService(el, SinkDefaults(context.Background()))
+	setSpecHash(&s.ObjectMeta, s.Spec)
+	return s
+}
+
+// drainEvents returns the Events recorded so far.
+func drainEvents(r *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-r.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func Test_reconcileDeployment_drift(t *testing.T) {
+	el := eventListener0.DeepCopy()
+
+	imageDrift := generatedDeployment(el)
+	imageDrift.Spec.Template.Spec.Containers[0].Image = "example.com/patched:latest"
+
+	limits := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	unmanagedResources := generatedDeployment(el)
+	unmanagedResources.Spec.Template.Spec.Containers[0].Resources = limits
+	metav1.SetMetaDataAnnotation(&unmanagedResources.ObjectMeta, UnmanagedFieldsAnnotation, "resources, replicas")
+
+	// withoutHash was generated before the reconciler recorded spec hashes
+	withoutHash := imageDrift.DeepCopy()
+	delete(withoutHash.Annotations, SpecHashAnnotation)
+
+	elUpdatedSa := el.DeepCopy()
+	elUpdatedSa.Spec.ServiceAccountName = updatedSa
+
+	elAvailability := el.DeepCopy()
+	elAvailability.Spec.Availability = &v1alpha1.Availability{Replicas: 3}
+	var scaledReplicas int32 = 7
+	unmanagedReplicas := generatedDeployment(elAvailability)
+	unmanagedReplicas.Spec.Replicas = &scaledReplicas
+	metav1.SetMetaDataAnnotation(&unmanagedReplicas.ObjectMeta, UnmanagedFieldsAnnotation, "replicas")
+
+	tests := []struct {
+		name       string
+		el         *v1alpha1.EventListener
+		existing   *appsv1.Deployment
+		want       *appsv1.Deployment
+		wantEvents []string
+	}{{
+		name:     "out-of-band image is reverted",
+		el:       el,
+		existing: imageDrift,
+		want:     generatedDeployment(el),
+		wantEvents: []string{
+			fmt.Sprintf("Warning DriftCorrected Reverted out-of-band changes to image of Deployment %s", generatedResourceName),
+		},
+	}, {
+		name:     "unmanaged fields are kept",
+		el:       el,
+		existing: unmanagedResources,
+		want:     unmanagedResources,
+	}, {
+		name:     "unmanaged replicas are kept",
+		el:       elAvailability,
+		existing: unmanagedReplicas,
+		want:     unmanagedReplicas,
+	}, {
+		name:     "eventlistener update is not drift",
+		el:       elUpdatedSa,
+		existing: generatedDeployment(el),
+		want:     generatedDeployment(elUpdatedSa),
+	}, {
+		name:     "deployment without hash is not drift",
+		el:       el,
+		existing: withoutHash,
+		want:     generatedDeployment(el),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{tc.el},
+				Deployments:    []*appsv1.Deployment{tc.existing},
+			})
+			defer cancel()
+			r := testAssets.Controller.Reconciler.(*Reconciler)
+			recorder := record.NewFakeRecorder(10)
+			r.Recorder = recorder
+
+			if err := r.reconcileDeployment(tc.el.DeepCopy(), SinkDefaults(context.Background())); err != nil {
+				t.Fatalf("reconcileDeployment() returned error: %s", err)
+			}
+			got, err := testAssets.Clients.Kube.AppsV1().Deployments(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(tc.want.ObjectMeta.Annotations, got.Annotations) || !equality.Semantic.DeepEqual(tc.want.Spec, got.Spec) {
+				t.Errorf("reconcileDeployment() mismatch (-want +got): %s", cmp.Diff(tc.want, got))
+			}
+			if diff := cmp.Diff(tc.wantEvents, drainEvents(recorder)); diff != "" {
+				t.Errorf("reconcileDeployment() events mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_reconcileService_drift(t *testing.T) {
+	el := eventListener0.DeepCopy()
+
+	typeDrift := generatedService(el)
+	typeDrift.Spec.Type = corev1.ServiceTypeNodePort
+
+	unmanagedType := typeDrift.DeepCopy()
+	metav1.SetMetaDataAnnotation(&unmanagedType.ObjectMeta, UnmanagedFieldsAnnotation, "type")
+
+	elNodePort := el.DeepCopy()
+	elNodePort.Spec.ServiceType = corev1.ServiceTypeNodePort
+
+	tests := []struct {
+		name       string
+		el         *v1alpha1.EventListener
+		existing   *corev1.Service
+		want       *corev1.Service
+		wantEvents []string
+	}{{
+		name:     "out-of-band type is reverted",
+		el:       el,
+		existing: typeDrift,
+		want:     generatedService(el),
+		wantEvents: []string{
+			fmt.Sprintf("Warning DriftCorrected Reverted out-of-band changes to type of Service %s", generatedResourceName),
+		},
+	}, {
+		name:     "unmanaged fields are kept",
+		el:       el,
+		existing: unmanagedType,
+		want:     unmanagedType,
+	}, {
+		name:     "eventlistener update is not drift",
+		el:       elNodePort,
+		existing: generatedService(el),
+		want:     generatedService(elNodePort),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{tc.el},
+				Services:       []*corev1.Service{tc.existing},
+			})
+			defer cancel()
+			r := testAssets.Controller.Reconciler.(*Reconciler)
+			recorder := record.NewFakeRecorder(10)
+			r.Recorder = recorder
+
+			if err := r.reconcileService(tc.el.DeepCopy(), SinkDefaults(context.Background())); err != nil {
+				t.Fatalf("reconcileService() returned error: %s", err)
+			}
+			got, err := testAssets.Clients.Kube.CoreV1().Services(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("reconcileService() mismatch (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEvents, drainEvents(recorder)); diff != "" {
+				t.Errorf("reconcileService() events mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_getUnmanagedFields(t *testing.T) {
+	meta := metav1.ObjectMeta{Annotations: map[string]string{UnmanagedFieldsAnnotation: " replicas,resources ,,"}}
+	want := unmanagedFields{"replicas": true, "resources": true}
+	got := getUnmanagedFields(meta)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getUnmanagedFields() mismatch (-want +got): %s", diff)
+	}
+	if got.manages("replicas") || !got.manages("image") {
+		t.Errorf("manages() = %v, %v; want false, true", got.manages("replicas"), got.manages("image"))
+	}
+}
@@ -22,21 +22,28 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/triggers/pkg/apis/config"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	listers "github.com/tektoncd/triggers/pkg/client/listers/triggers/v1alpha1"
 	"github.com/tektoncd/triggers/pkg/reconciler"
 	"golang.org/x/xerrors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/dynamic"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
 
 	"knative.dev/pkg/controller"
 )
@@ -50,6 +57,17 @@ const (
 	eventListenerConfigMapName = "config-logging-triggers"
 	// eventListenerServicePortName defines service port name for EventListener Service
 	eventListenerServicePortName = "http-listener"
+	// adminPortName is the name of the container port of the admin endpoints
+	adminPortName = "admin"
+	// adminTokenPath and adminTLSPath are where the Secrets of the admin
+	// endpoints are mounted
+	adminTokenPath = "/etc/admin-token"
+	adminTLSPath   = "/etc/admin-tls"
+	// tlsPath is where the TLS Secret of the sink is mounted
+	tlsPath = "/etc/tls"
+	// defaultTopologyKey is the node label pods are spread across when an
+	// EventListener sets no topology keys
+	defaultTopologyKey = "kubernetes.io/hostname"
 	// GeneratedResourcePrefix is the name prefix for resources generated in the
 	// EventListener reconciler
 	GeneratedResourcePrefix = "el"
@@ -70,6 +88,25 @@ var (
 	// FailureThreshold defines the Failure Threshold for the EventListener Liveness Probe
 	FailureThreshold = flag.Int("failure-threshold", 1,
 		"The Failure Threshold for the EventListener Liveness Probe.")
+	// ReadTimeout, WriteTimeout and IdleTimeout configure the EventListener
+	// sink HTTP server
+	ReadTimeout = flag.Duration("el-read-timeout", 30*time.Second,
+		"The read timeout of the EventListener sink.")
+	WriteTimeout = flag.Duration("el-write-timeout", 60*time.Second,
+		"The write timeout of the EventListener sink.")
+	IdleTimeout = flag.Duration("el-idle-timeout", 120*time.Second,
+		"The keep-alive idle timeout of the EventListener sink.")
+	// MaxConcurrentStreams defines the HTTP/2 streams allowed per connection
+	MaxConcurrentStreams = flag.Int("el-max-concurrent-streams", 250,
+		"The maximum number of concurrent HTTP/2 streams per connection to the EventListener sink.")
+	// MaxTriggersPerEvent limits how many Triggers may create resources for
+	// a single event
+	MaxTriggersPerEvent = flag.Int("el-max-triggers-per-event", 0,
+		"The maximum number of Triggers that may create resources for a single event. 0 means no limit.")
+	// EmbeddedSinkAddress is the host and port of the controller Service if
+	// the controller serves the events of all EventListeners itself
+	EmbeddedSinkAddress = flag.String("embedded-sink-address", "",
+		"The host:port of the controller Service, e.g. tekton-triggers-controller.tekton-pipelines.svc.cluster.local:8080. When set, the controller serves the events of all EventListeners on that port instead of a Deployment for each.")
 	// StaticResourceLabels is a map with all the labels that should be on
 	// all resources generated by the EventListener
 	StaticResourceLabels = map[string]string{
@@ -83,6 +120,22 @@ type Reconciler struct {
 	*reconciler.Base
 	// listers index properties about resources
 	eventListenerLister listers.EventListenerLister
+	// The listers of the objects that Triggers reference, whose informers
+	// enqueue the EventListeners that reference a changed object
+	triggerBindingLister        listers.TriggerBindingLister
+	clusterTriggerBindingLister listers.ClusterTriggerBindingLister
+	triggerTemplateLister       listers.TriggerTemplateLister
+	interceptorChainLister      listers.InterceptorChainLister
+	clusterInterceptorLister    listers.ClusterInterceptorLister
+	serviceLister               corelisters.ServiceLister
+	configMapLister             corelisters.ConfigMapLister
+	secretLister                corelisters.SecretLister
+	// dynamicClientSet manages the KEDA and Prometheus Operator resources,
+	// whose types are not vendored
+	dynamicClientSet dynamic.Interface
+	// configStore holds the controller-wide defaults from the
+	// config-defaults-triggers ConfigMap
+	configStore *config.Store
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -92,6 +145,9 @@ var _ controller.Reconciler = (*Reconciler)(nil)
 // converge the two.
 func (c *Reconciler) Reconcile(ctx context.Context, key string) (returnError error) {
 	c.Logger.Infof("event-listener-reconcile %s", key)
+	if c.configStore != nil {
+		ctx = c.configStore.ToContext(ctx)
+	}
 	// Convert the namespace/name string into a distinct namespace and name
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -154,9 +210,198 @@ func (c *Reconciler) reconcile(ctx context.Context, el *v1alpha1.EventListener)
 	// updates within an admission webhook instead. The reconciler is resolving
 	// behavior after it has been approved, which is from the wrong point of the
 	// lifecycle and presents inherent problems.
-	serviceReconcileError := c.reconcileService(el)
-	deploymentReconcileError := c.reconcileDeployment(el)
-	return wrapError(serviceReconcileError, deploymentReconcileError)
+	if *EmbeddedSinkAddress != "" {
+		embeddedReconcileError := c.reconcileEmbeddedSink(el, *EmbeddedSinkAddress)
+		c.reconcileTriggers(ctx, el)
+		return embeddedReconcileError
+	}
+	d := SinkDefaults(ctx)
+	serviceReconcileError := c.reconcileService(el, d)
+	openShiftReconcileError := c.reconcileOpenShift(el)
+	deploymentReconcileError := c.reconcileDeployment(el, d)
+	pdbReconcileError := c.reconcilePodDisruptionBudget(el)
+	scaledObjectReconcileError := c.reconcileScaledObject(el)
+	monitorReconcileError := c.reconcilePrometheusMonitor(el, d)
+	c.reconcileTriggers(ctx, el)
+	return wrapError(wrapError(wrapError(serviceReconcileError, openShiftReconcileError), deploymentReconcileError), wrapError(pdbReconcileError, wrapError(scaledObjectReconcileError, monitorReconcileError)))
+}
+
+// reconcileTriggers checks that the resources referenced by the Triggers of the
+// EventListener are usable, reports the first problem found on the
+// TriggersResolved condition and counts the available Triggers.
+func (c *Reconciler) reconcileTriggers(ctx context.Context, el *v1alpha1.EventListener) {
+	var available int32
+	var reason, message string
+	for _, t := range el.Spec.Triggers {
+		r, err := c.checkTrigger(ctx, el.Namespace, t)
+		if err == nil {
+			available++
+			continue
+		}
+		if message == "" {
+			reason, message = r, fmt.Sprintf("trigger %q: %s", t.Name, err)
+		}
+	}
+	el.Status.AvailableTriggers = available
+	el.Status.SetTriggersResolvedCondition(reason, message)
+}
+
+// checkTrigger returns the reason and error for the first resource referenced
+// by the Trigger that is not usable. The resources are read from the listers,
+// since their informers reconcile the EventListener again when they change.
+func (c *Reconciler) checkTrigger(ctx context.Context, ns string, t v1alpha1.EventListenerTrigger) (string, error) {
+	if r, err := c.checkInterceptors(ns, t.Interceptors); err != nil {
+		return r, err
+	}
+	for _, b := range t.Bindings {
+		var err error
+		if b.Kind == v1alpha1.ClusterTriggerBindingKind {
+			_, err = c.clusterTriggerBindingLister.Get(b.Name)
+		} else {
+			_, err = c.triggerBindingLister.TriggerBindings(ns).Get(b.Name)
+		}
+		if err != nil {
+			return kubeErrorReason(err, v1alpha1.ReasonTemplateInvalid), err
+		}
+	}
+	tt, err := c.triggerTemplateLister.TriggerTemplates(ns).Get(t.Template.Name)
+	if err != nil {
+		return kubeErrorReason(err, v1alpha1.ReasonTemplateInvalid), err
+	}
+	if err := tt.Validate(ctx); err != nil {
+		return v1alpha1.ReasonTemplateInvalid, err
+	}
+	return "", nil
+}
+
+// checkInterceptors returns the reason and error for the first resource
+// referenced by the interceptors that is not usable. The interceptors of
+// referenced InterceptorChains are checked too.
+func (c *Reconciler) checkInterceptors(ns string, interceptors []*v1alpha1.EventInterceptor) (string, error) {
+	for _, i := range interceptors {
+		if i.Webhook != nil && i.Webhook.ObjectRef != nil {
+			svcNamespace := i.Webhook.ObjectRef.Namespace
+			if svcNamespace == "" {
+				svcNamespace = ns
+			}
+			if _, err := c.serviceLister.Services(svcNamespace).Get(i.Webhook.ObjectRef.Name); err != nil {
+				return kubeErrorReason(err, v1alpha1.ReasonInterceptorUnreachable), err
+			}
+		}
+		if i.Chain != nil {
+			chain, err := c.interceptorChainLister.InterceptorChains(ns).Get(i.Chain.Name)
+			if err != nil {
+				return kubeErrorReason(err, v1alpha1.ReasonInterceptorUnreachable), err
+			}
+			if r, err := c.checkInterceptors(ns, chain.Spec.Interceptors); err != nil {
+				return r, err
+			}
+		}
+		if i.Ref != nil {
+			if _, err := c.clusterInterceptorLister.Get(i.Ref.Name); err != nil {
+				return kubeErrorReason(err, v1alpha1.ReasonInterceptorUnreachable), err
+			}
+		}
+		if i.OPA != nil && i.OPA.PolicyRef != nil {
+			ref := i.OPA.PolicyRef
+			cm, err := c.configMapLister.ConfigMaps(ns).Get(ref.Name)
+			if err != nil {
+				return kubeErrorReason(err, v1alpha1.ReasonInterceptorUnreachable), err
+			}
+			if _, ok := cm.Data[ref.Key]; !ok {
+				return v1alpha1.ReasonInterceptorUnreachable, fmt.Errorf("key %q not found in ConfigMap %s/%s", ref.Key, ns, ref.Name)
+			}
+		}
+		for _, sr := range interceptorSecretRefs(i) {
+			secretNamespace := sr.Namespace
+			if secretNamespace == "" {
+				secretNamespace = ns
+			}
+			secret, err := c.secretLister.Secrets(secretNamespace).Get(sr.SecretName)
+			if err != nil {
+				return kubeErrorReason(err, v1alpha1.ReasonSecretMissing), err
+			}
+			if _, ok := secret.Data[sr.SecretKey]; !ok {
+				return v1alpha1.ReasonSecretMissing, fmt.Errorf("key %q not found in secret %s/%s", sr.SecretKey, secretNamespace, sr.SecretName)
+			}
+		}
+	}
+	return "", nil
+}
+
+// SinkDefaults returns the defaults for the EventListener sinks from the
+// config-defaults-triggers ConfigMap. Settings that are not in the ConfigMap
+// fall back to the controller flags.
+func SinkDefaults(ctx context.Context) *config.Defaults {
+	d := config.FromContextOrDefaults(ctx).Defaults.DeepCopy()
+	if d.ELImage == "" {
+		d.ELImage = *elImage
+	}
+	if d.ELPort == 0 {
+		d.ELPort = *ElPort
+	}
+	if d.PeriodSeconds == 0 {
+		d.PeriodSeconds = *PeriodSeconds
+	}
+	if d.FailureThreshold == 0 {
+		d.FailureThreshold = *FailureThreshold
+	}
+	if d.ReadTimeout == 0 {
+		d.ReadTimeout = *ReadTimeout
+	}
+	if d.WriteTimeout == 0 {
+		d.WriteTimeout = *WriteTimeout
+	}
+	if d.IdleTimeout == 0 {
+		d.IdleTimeout = *IdleTimeout
+	}
+	if d.MaxConcurrentStreams == 0 {
+		d.MaxConcurrentStreams = *MaxConcurrentStreams
+	}
+	if d.MaxTriggersPerEvent == 0 {
+		d.MaxTriggersPerEvent = *MaxTriggersPerEvent
+	}
+	return d
+}
+
+// interceptorSecretRefs returns the SecretRefs used by the interceptor to
+// validate events.
+func interceptorSecretRefs(i *v1alpha1.EventInterceptor) []*v1alpha1.SecretRef {
+	var refs []*v1alpha1.SecretRef
+	switch {
+	case i.GitHub != nil:
+		refs = append(refs, i.GitHub.SecretRef, i.GitHub.PreviousSecretRef)
+	case i.GitLab != nil:
+		refs = append(refs, i.GitLab.SecretRef, i.GitLab.PreviousSecretRef)
+	case i.Sentry != nil:
+		refs = append(refs, i.Sentry.SecretRef)
+	case i.Bitbucket != nil:
+		refs = append(refs, i.Bitbucket.SecretRef, i.Bitbucket.PreviousSecretRef)
+	case i.Alert != nil:
+		refs = append(refs, i.Alert.SecretRef)
+	case i.Scanner != nil:
+		refs = append(refs, i.Scanner.SecretRef)
+	case i.SupplyChain != nil:
+		refs = append(refs, i.SupplyChain.SecretRef, i.SupplyChain.PublicKeyRef)
+	case i.Captcha != nil:
+		refs = append(refs, i.Captcha.SecretRef)
+	}
+	var set []*v1alpha1.SecretRef
+	for _, sr := range refs {
+		if sr != nil {
+			set = append(set, sr)
+		}
+	}
+	return set
+}
+
+// kubeErrorReason returns ReasonRBACDenied for authorization errors and
+// otherwise the given reason.
+func kubeErrorReason(err error, reason string) string {
+	if errors.IsForbidden(err) || errors.IsUnauthorized(err) {
+		return v1alpha1.ReasonRBACDenied
+	}
+	return reason
 }
 
 func reconcileObjectMeta(oldMeta *metav1.ObjectMeta, newMeta metav1.ObjectMeta) (updated bool) {
@@ -171,9 +416,15 @@ func reconcileObjectMeta(oldMeta *metav1.ObjectMeta, newMeta metav1.ObjectMeta)
 	return
 }
 
-func (c *Reconciler) reconcileService(el *v1alpha1.EventListener) error {
-	service := &corev1.Service{
-		ObjectMeta: generateObjectMeta(el),
+// MakeService returns the Service that is generated for the EventListener
+// with the given defaults.
+func MakeService(el *v1alpha1.EventListener, d *config.Defaults) *corev1.Service {
+	meta := generateObjectMeta(el)
+	if servingCert(el) {
+		meta.Annotations = map[string]string{servingCertAnnotation: servingCertSecretName(el)}
+	}
+	return &corev1.Service{
+		ObjectMeta: meta,
 		Spec: corev1.ServiceSpec{
 			Selector: GenerateResourceLabels(el.Name),
 			Type:     el.Spec.ServiceType,
@@ -181,41 +432,64 @@ func (c *Reconciler) reconcileService(el *v1alpha1.EventListener) error {
 				{
 					Name:     eventListenerServicePortName,
 					Protocol: corev1.ProtocolTCP,
-					Port:     int32(*ElPort),
+					Port:     int32(d.ELPort),
 					TargetPort: intstr.IntOrString{
-						IntVal: int32(*ElPort),
+						IntVal: int32(d.ELPort),
 					},
 				},
 			},
 		},
 	}
+}
+
+func (c *Reconciler) reconcileService(el *v1alpha1.EventListener, d *config.Defaults) error {
+	service := MakeService(el, d)
+	setSpecHash(&service.ObjectMeta, service.Spec)
 	existingService, err := c.KubeClientSet.CoreV1().Services(el.Namespace).Get(el.Status.Configuration.GeneratedResourceName, metav1.GetOptions{})
 	switch {
 	case err == nil:
 		// Determine if reconciliation has to occur
 		updated := reconcileObjectMeta(&existingService.ObjectMeta, service.ObjectMeta)
-		if !reflect.DeepEqual(existingService.Spec.Selector, service.Spec.Selector) {
-			existingService.Spec.Selector = service.Spec.Selector
+		if secret := service.Annotations[servingCertAnnotation]; existingService.Annotations[servingCertAnnotation] != secret {
+			if secret == "" {
+				delete(existingService.Annotations, servingCertAnnotation)
+			} else {
+				metav1.SetMetaDataAnnotation(&existingService.ObjectMeta, servingCertAnnotation, secret)
+			}
 			updated = true
 		}
-		if existingService.Spec.Type != service.Spec.Type {
+		unmanaged := getUnmanagedFields(existingService.ObjectMeta)
+		var changed []string
+		if unmanaged.manages("selector") && !reflect.DeepEqual(existingService.Spec.Selector, service.Spec.Selector) {
+			existingService.Spec.Selector = service.Spec.Selector
+			changed = append(changed, "selector")
+		}
+		if unmanaged.manages("type") && existingService.Spec.Type != service.Spec.Type {
 			existingService.Spec.Type = service.Spec.Type
 			// When transitioning from NodePort or LoadBalancer to ClusterIP
 			// we need to remove NodePort from Ports
 			existingService.Spec.Ports = service.Spec.Ports
-			updated = true
+			changed = append(changed, "type")
 		}
-		if !cmp.Equal(existingService.Spec.Ports, service.Spec.Ports, cmpopts.IgnoreFields(corev1.ServicePort{}, "NodePort")) {
+		if unmanaged.manages("ports") && !cmp.Equal(existingService.Spec.Ports, service.Spec.Ports, cmpopts.IgnoreFields(corev1.ServicePort{}, "NodePort")) {
 			existingService.Spec.Ports = service.Spec.Ports
+			changed = append(changed, "ports")
+		}
+		// The changes to an object whose hash matches the desired spec were
+		// made out-of-band
+		if reconcileSpecHash(&existingService.ObjectMeta, service.ObjectMeta) {
 			updated = true
+		} else if len(changed) > 0 {
+			c.recordDrift(el, "Service", existingService.Name, changed)
 		}
-		if updated {
+		if updated || len(changed) > 0 {
 			if _, err := c.KubeClientSet.CoreV1().Services(el.Namespace).Update(existingService); err != nil {
 				c.Logger.Errorf("Error updating EventListener Service: %s", err)
 				return err
 			}
 			c.Logger.Infof("Updated EventListener Service %s in Namespace %s", existingService.Namespace, el.Namespace)
 		}
+		setAddress(el, existingService.Name, d.ELPort)
 	case errors.IsNotFound(err):
 		// Create the EventListener Service
 		_, err = c.KubeClientSet.CoreV1().Services(el.Namespace).Create(service)
@@ -224,7 +498,7 @@ func (c *Reconciler) reconcileService(el *v1alpha1.EventListener) error {
 			c.Logger.Errorf("Error creating EventListener Service: %s", err)
 			return err
 		}
-		el.Status.SetAddress(listenerHostname(service.Name, el.Namespace, *ElPort))
+		setAddress(el, service.Name, d.ELPort)
 		c.Logger.Infof("Created EventListener Service %s in Namespace %s", service.Name, el.Namespace)
 	default:
 		c.Logger.Error(err)
@@ -233,6 +507,38 @@ func (c *Reconciler) reconcileService(el *v1alpha1.EventListener) error {
 	return nil
 }
 
+// reconcileEmbeddedSink points the address of the EventListener at the sink
+// embedded in the controller, and deletes the Deployment and Service of the
+// separate sink it had before.
+func (c *Reconciler) reconcileEmbeddedSink(el *v1alpha1.EventListener, address string) error {
+	name := el.Status.Configuration.GeneratedResourceName
+	err := c.KubeClientSet.AppsV1().Deployments(el.Namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		c.Logger.Errorf("Error deleting EventListener Deployment: %s", err)
+		return err
+	}
+	err = c.KubeClientSet.CoreV1().Services(el.Namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		c.Logger.Errorf("Error deleting EventListener Service: %s", err)
+		return err
+	}
+	for _, cond := range []apis.ConditionType{v1alpha1.ServiceExists, v1alpha1.DeploymentExists} {
+		el.Status.SetCondition(&apis.Condition{
+			Type:    cond,
+			Status:  corev1.ConditionTrue,
+			Reason:  "EmbeddedSink",
+			Message: "Events are served by the controller",
+		})
+	}
+	if host := el.Annotations[v1alpha1.ExternalHostnameAnnotation]; host != "" {
+		el.Status.SetExternalAddress(host)
+		return nil
+	}
+	el.Status.SetAddress(address)
+	el.Status.Address.URL.Path = fmt.Sprintf("/%s/%s", el.Namespace, el.Name)
+	return nil
+}
+
 func (c *Reconciler) reconcileLoggingConfig(el *v1alpha1.EventListener) error {
 	_, err := c.KubeClientSet.CoreV1().ConfigMaps(el.Namespace).Get(eventListenerConfigMapName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
@@ -249,21 +555,154 @@ func (c *Reconciler) reconcileLoggingConfig(el *v1alpha1.EventListener) error {
 	return nil
 }
 
-func (c *Reconciler) reconcileDeployment(el *v1alpha1.EventListener) error {
-	// check logging config, create if it doesn't exist
-	err := c.reconcileLoggingConfig(el)
-	if err != nil {
-		c.Logger.Error(err)
-		return err
+// addSQSConfig configures the sink container to consume the SQS queue of an
+// EventListener. Credentials from a Secret are passed in the standard AWS
+// environment variables; without a Secret the AWS SDK falls back to the
+// web identity token of IAM roles for service accounts.
+func addSQSConfig(container *corev1.Container, sqs *v1alpha1.SQSSource) {
+	container.Args = append(container.Args, "-sqs-queue-url", sqs.QueueURL)
+	if sqs.Region != "" {
+		container.Args = append(container.Args, "-sqs-region", sqs.Region)
+	}
+	if sqs.BatchSize != 0 {
+		container.Args = append(container.Args, "-sqs-batch-size", strconv.Itoa(int(sqs.BatchSize)))
+	}
+	if sqs.VisibilityTimeoutSeconds != 0 {
+		container.Args = append(container.Args, "-sqs-visibility-timeout", (time.Duration(sqs.VisibilityTimeoutSeconds) * time.Second).String())
+	}
+	if sqs.CredentialsSecret == "" {
+		return
+	}
+	for _, v := range []struct{ env, key string }{
+		{"AWS_ACCESS_KEY_ID", "aws_access_key_id"},
+		{"AWS_SECRET_ACCESS_KEY", "aws_secret_access_key"},
+	} {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: v.env,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: sqs.CredentialsSecret},
+					Key:                  v.key,
+				},
+			},
+		})
+	}
+}
+
+// addAdminConfig serves the admin endpoints of the sink container on a
+// separate port, with the token and TLS Secrets mounted as volumes so that
+// they can be rotated.
+func addAdminConfig(container *corev1.Container, volumes *[]corev1.Volume, admin *v1alpha1.AdminEndpoints) {
+	container.Ports = append(container.Ports, corev1.ContainerPort{
+		Name:          adminPortName,
+		ContainerPort: admin.Port,
+		Protocol:      corev1.ProtocolTCP,
+	})
+	container.Args = append(container.Args, "-admin-port", strconv.Itoa(int(admin.Port)))
+	if admin.Profiling {
+		container.Args = append(container.Args, "-pprof")
+	}
+	mount := func(name, secret, path string) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path,
+			ReadOnly:  true,
+		})
+		*volumes = append(*volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: secret},
+			},
+		})
+	}
+	if admin.TokenSecret != "" {
+		mount("admin-token", admin.TokenSecret, adminTokenPath)
+		container.Args = append(container.Args, "-admin-token-file", adminTokenPath+"/token")
+	}
+	if admin.TLSSecret != "" {
+		mount("admin-tls", admin.TLSSecret, adminTLSPath)
+		container.Args = append(container.Args,
+			"-admin-tls-cert-file", adminTLSPath+"/"+corev1.TLSCertKey,
+			"-admin-tls-key-file", adminTLSPath+"/"+corev1.TLSPrivateKeyKey)
+		if admin.RequireClientCert {
+			container.Args = append(container.Args, "-admin-client-ca-file", adminTLSPath+"/"+corev1.ServiceAccountRootCAKey)
+		}
 	}
+}
 
+// addTLSSecret serves the sink over HTTPS with the certificate of the TLS
+// Secret, mounted as a volume so that the sink picks up rotations.
+func addTLSSecret(container *corev1.Container, volumes *[]corev1.Volume, secret string) {
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "tls",
+		MountPath: tlsPath,
+		ReadOnly:  true,
+	})
+	*volumes = append(*volumes, corev1.Volume{
+		Name: "tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secret},
+		},
+	})
+	container.Args = append(container.Args,
+		"-tls-cert-file", tlsPath+"/"+corev1.TLSCertKey,
+		"-tls-key-file", tlsPath+"/"+corev1.TLSPrivateKeyKey)
+	container.LivenessProbe.HTTPGet.Scheme = corev1.URISchemeHTTPS
+}
+
+// addTLSPolicy passes the TLS policy of the EventListener to the sink. Fields
+// that the EventListener does not set fall back to the defaults.
+func addTLSPolicy(container *corev1.Container, policy, defaults *v1alpha1.TLSPolicy) {
+	merged := v1alpha1.TLSPolicy{}
+	for _, p := range []*v1alpha1.TLSPolicy{defaults, policy} {
+		if p == nil {
+			continue
+		}
+		if p.MinVersion != "" {
+			merged.MinVersion = p.MinVersion
+		}
+		if len(p.CipherSuites) > 0 {
+			merged.CipherSuites = p.CipherSuites
+		}
+		if len(p.CurvePreferences) > 0 {
+			merged.CurvePreferences = p.CurvePreferences
+		}
+	}
+	if merged.MinVersion != "" {
+		container.Args = append(container.Args, "-tls-min-version", merged.MinVersion)
+	}
+	if len(merged.CipherSuites) > 0 {
+		container.Args = append(container.Args, "-tls-cipher-suites", strings.Join(merged.CipherSuites, ","))
+	}
+	if len(merged.CurvePreferences) > 0 {
+		container.Args = append(container.Args, "-tls-curve-preferences", strings.Join(merged.CurvePreferences, ","))
+	}
+}
+
+// writeTimeoutMargin is the time that the sink has to write its response after
+// the event timeout passed.
+const writeTimeoutMargin = 10 * time.Second
+
+// SinkWriteTimeout returns the write timeout of a sink whose events may be
+// processed for eventTimeout, so that the response is not cut off before the
+// sink reports the Triggers that did not finish.
+func SinkWriteTimeout(writeTimeout, eventTimeout time.Duration) time.Duration {
+	if min := eventTimeout + writeTimeoutMargin; writeTimeout < min {
+		return min
+	}
+	return writeTimeout
+}
+
+// MakeDeployment returns the Deployment that is generated for the
+// EventListener with the given defaults.
+func MakeDeployment(el *v1alpha1.EventListener, d *config.Defaults) *appsv1.Deployment {
 	labels := mergeLabels(el.Labels, GenerateResourceLabels(el.Name))
 	var replicas int32 = 1
 	container := corev1.Container{
 		Name:  "event-listener",
-		Image: *elImage,
+		Image: d.ELImage,
 		Ports: []corev1.ContainerPort{{
-			ContainerPort: int32(*ElPort),
+			ContainerPort: int32(d.ELPort),
 			Protocol:      corev1.ProtocolTCP,
 		}},
 		LivenessProbe: &corev1.Probe{
@@ -271,16 +710,20 @@ func (c *Reconciler) reconcileDeployment(el *v1alpha1.EventListener) error {
 				HTTPGet: &corev1.HTTPGetAction{
 					Path:   "/live",
 					Scheme: corev1.URISchemeHTTP,
-					Port:   intstr.FromInt((*ElPort)),
+					Port:   intstr.FromInt(d.ELPort),
 				},
 			},
-			PeriodSeconds:    int32(*PeriodSeconds),
-			FailureThreshold: int32(*FailureThreshold),
+			PeriodSeconds:    int32(d.PeriodSeconds),
+			FailureThreshold: int32(d.FailureThreshold),
 		},
 		Args: []string{
 			"-el-name", el.Name,
 			"-el-namespace", el.Namespace,
-			"-port", strconv.Itoa(*ElPort),
+			"-port", strconv.Itoa(d.ELPort),
+			"-read-timeout", d.ReadTimeout.String(),
+			"-write-timeout", SinkWriteTimeout(d.WriteTimeout, el.Spec.EventTimeout()).String(),
+			"-idle-timeout", d.IdleTimeout.String(),
+			"-max-concurrent-streams", strconv.Itoa(d.MaxConcurrentStreams),
 		},
 		VolumeMounts: []corev1.VolumeMount{{
 			Name:      "config-logging",
@@ -295,7 +738,65 @@ func (c *Reconciler) reconcileDeployment(el *v1alpha1.EventListener) error {
 			},
 		}},
 	}
-	deployment := &appsv1.Deployment{
+	if !d.ELMetricsEnabled {
+		container.Args = append(container.Args, "-metrics=false")
+	}
+	if d.MaxTriggersPerEvent > 0 {
+		container.Args = append(container.Args, "-max-triggers-per-event", strconv.Itoa(d.MaxTriggersPerEvent))
+	}
+	if el.Spec.FIPS {
+		container.Args = append(container.Args, "-fips")
+	}
+	if el.Spec.WarmUp != "" {
+		container.Args = append(container.Args, "-warm-up", string(el.Spec.WarmUp))
+	}
+	addTLSPolicy(&container, el.Spec.TLSPolicy, d.TLSPolicy)
+	if rt := el.Spec.Runtime; rt != nil {
+		if rt.GoMaxProcs > 0 {
+			container.Args = append(container.Args, "-gomaxprocs", strconv.Itoa(int(rt.GoMaxProcs)))
+		}
+		if rt.GCPercent != nil {
+			container.Args = append(container.Args, "-gc-percent", strconv.Itoa(int(*rt.GCPercent)))
+		}
+	}
+	if d.ELResources != nil {
+		container.Resources = *d.ELResources
+	}
+	volumes := []corev1.Volume{{
+		Name: "config-logging",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: eventListenerConfigMapName,
+				},
+			},
+		},
+	}}
+	if sqs := el.Spec.SQS; sqs != nil {
+		addSQSConfig(&container, sqs)
+	}
+	if admin := el.Spec.Admin; admin != nil {
+		addAdminConfig(&container, &volumes, admin)
+	}
+	if el.Spec.TLSSecret != "" {
+		addTLSSecret(&container, &volumes, el.Spec.TLSSecret)
+	}
+	if el.Spec.OpenShift != nil {
+		addOpenShiftConfig(el, &container, &volumes)
+	}
+	serviceAccountName := el.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = d.DefaultServiceAccount
+	}
+	var spread []corev1.TopologySpreadConstraint
+	if el.Spec.Replicas != nil {
+		replicas = *el.Spec.Replicas
+	}
+	if a := el.Spec.Availability; a != nil {
+		replicas = a.Replicas
+		spread = makeTopologySpreadConstraints(el.Name, a)
+	}
+	return &appsv1.Deployment{
 		ObjectMeta: generateObjectMeta(el),
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
@@ -307,80 +808,122 @@ func (c *Reconciler) reconcileDeployment(el *v1alpha1.EventListener) error {
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName: el.Spec.ServiceAccountName,
-					Containers:         []corev1.Container{container},
-
-					Volumes: []corev1.Volume{{
-						Name: "config-logging",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: eventListenerConfigMapName,
-								},
-							},
-						},
-					}},
+					ServiceAccountName:        serviceAccountName,
+					Containers:                []corev1.Container{container},
+					Volumes:                   volumes,
+					TopologySpreadConstraints: spread,
 				},
 			},
 		},
 	}
+}
+
+func (c *Reconciler) reconcileDeployment(el *v1alpha1.EventListener, d *config.Defaults) error {
+	// check logging config, create if it doesn't exist
+	err := c.reconcileLoggingConfig(el)
+	if err != nil {
+		c.Logger.Error(err)
+		return err
+	}
+
+	deployment := MakeDeployment(el, d)
+	setSpecHash(&deployment.ObjectMeta, deployment.Spec)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	var replicas int32 = 1
 	existingDeployment, err := c.KubeClientSet.AppsV1().Deployments(el.Namespace).Get(el.Status.Configuration.GeneratedResourceName, metav1.GetOptions{})
 	switch {
 	case err == nil:
 		el.Status.SetDeploymentConditions(existingDeployment.Status.Conditions)
 		// Determine if reconciliation has to occur
 		updated := reconcileObjectMeta(&existingDeployment.ObjectMeta, deployment.ObjectMeta)
-		if existingDeployment.Spec.Replicas == nil || *existingDeployment.Spec.Replicas == 0 {
-			existingDeployment.Spec.Replicas = &replicas
-			updated = true
+		unmanaged := getUnmanagedFields(existingDeployment.ObjectMeta)
+		var changed []string
+		if unmanaged.manages("replicas") {
+			if (el.Spec.Replicas != nil || el.Spec.Availability != nil) && el.Spec.Autoscaling == nil {
+				// The replicas of an EventListener that sets them are owned
+				// by its spec, unless KEDA scales it
+				if existingDeployment.Spec.Replicas == nil || *existingDeployment.Spec.Replicas != *deployment.Spec.Replicas {
+					existingDeployment.Spec.Replicas = deployment.Spec.Replicas
+					changed = append(changed, "replicas")
+				}
+			} else if existingDeployment.Spec.Replicas == nil || *existingDeployment.Spec.Replicas == 0 {
+				existingDeployment.Spec.Replicas = &replicas
+				changed = append(changed, "replicas")
+			}
 		}
 		if existingDeployment.Spec.Selector != deployment.Spec.Selector {
 			existingDeployment.Spec.Selector = deployment.Spec.Selector
 			updated = true
 		}
-		if !reflect.DeepEqual(existingDeployment.Spec.Template.Labels, deployment.Spec.Template.Labels) {
+		if unmanaged.manages("template.labels") && !reflect.DeepEqual(existingDeployment.Spec.Template.Labels, deployment.Spec.Template.Labels) {
 			existingDeployment.Spec.Template.Labels = deployment.Spec.Template.Labels
-			updated = true
+			changed = append(changed, "template.labels")
+		}
+		if unmanaged.manages("topologySpreadConstraints") && !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.TopologySpreadConstraints, deployment.Spec.Template.Spec.TopologySpreadConstraints) {
+			existingDeployment.Spec.Template.Spec.TopologySpreadConstraints = deployment.Spec.Template.Spec.TopologySpreadConstraints
+			changed = append(changed, "topologySpreadConstraints")
 		}
-		if existingDeployment.Spec.Template.Spec.ServiceAccountName != deployment.Spec.Template.Spec.ServiceAccountName {
+		if unmanaged.manages("serviceAccountName") && existingDeployment.Spec.Template.Spec.ServiceAccountName != deployment.Spec.Template.Spec.ServiceAccountName {
 			existingDeployment.Spec.Template.Spec.ServiceAccountName = deployment.Spec.Template.Spec.ServiceAccountName
-			updated = true
+			changed = append(changed, "serviceAccountName")
 		}
 		if len(existingDeployment.Spec.Template.Spec.Containers) == 0 ||
 			len(existingDeployment.Spec.Template.Spec.Containers) > 1 {
 			existingDeployment.Spec.Template.Spec.Containers = []corev1.Container{container}
-			updated = true
+			changed = append(changed, "containers")
 		} else {
-			if existingDeployment.Spec.Template.Spec.Containers[0].Name != container.Name {
+			if unmanaged.manages("name") && existingDeployment.Spec.Template.Spec.Containers[0].Name != container.Name {
 				existingDeployment.Spec.Template.Spec.Containers[0].Name = container.Name
-				updated = true
+				changed = append(changed, "name")
 			}
-			if existingDeployment.Spec.Template.Spec.Containers[0].Image != container.Image {
+			if unmanaged.manages("image") && existingDeployment.Spec.Template.Spec.Containers[0].Image != container.Image {
 				existingDeployment.Spec.Template.Spec.Containers[0].Image = container.Image
-				updated = true
+				changed = append(changed, "image")
 			}
-			if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Ports, container.Ports) {
+			if unmanaged.manages("ports") && !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Ports, container.Ports) {
 				existingDeployment.Spec.Template.Spec.Containers[0].Ports = container.Ports
-				updated = true
+				changed = append(changed, "ports")
 			}
-			if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Args, container.Args) {
+			if unmanaged.manages("args") && !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Args, container.Args) {
 				existingDeployment.Spec.Template.Spec.Containers[0].Args = container.Args
-				updated = true
+				changed = append(changed, "args")
+			}
+			// Only the scheme of the probe is compared, since its other
+			// fields are defaulted
+			if probe := existingDeployment.Spec.Template.Spec.Containers[0].LivenessProbe; unmanaged.manages("livenessProbe") &&
+				(probe == nil || probe.HTTPGet == nil || probe.HTTPGet.Scheme != container.LivenessProbe.HTTPGet.Scheme) {
+				existingDeployment.Spec.Template.Spec.Containers[0].LivenessProbe = container.LivenessProbe
+				changed = append(changed, "livenessProbe")
+			}
+			if unmanaged.manages("env") && !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Env, container.Env) {
+				existingDeployment.Spec.Template.Spec.Containers[0].Env = container.Env
+				changed = append(changed, "env")
+			}
+			if unmanaged.manages("resources") && !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].Resources, container.Resources) {
+				existingDeployment.Spec.Template.Spec.Containers[0].Resources = container.Resources
+				changed = append(changed, "resources")
 			}
-			if existingDeployment.Spec.Template.Spec.Containers[0].Command != nil {
+			if unmanaged.manages("command") && existingDeployment.Spec.Template.Spec.Containers[0].Command != nil {
 				existingDeployment.Spec.Template.Spec.Containers[0].Command = nil
-				updated = true
+				changed = append(changed, "command")
 			}
-			if len(existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts) == 0 {
+			if unmanaged.manages("volumeMounts") && !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts, container.VolumeMounts) {
 				existingDeployment.Spec.Template.Spec.Containers[0].VolumeMounts = container.VolumeMounts
-				updated = true
+				changed = append(changed, "volumeMounts")
 			}
-			if !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Volumes, deployment.Spec.Template.Spec.Volumes) {
+			if unmanaged.manages("volumes") && !reflect.DeepEqual(existingDeployment.Spec.Template.Spec.Volumes, deployment.Spec.Template.Spec.Volumes) {
 				existingDeployment.Spec.Template.Spec.Volumes = deployment.Spec.Template.Spec.Volumes
-				updated = true
+				changed = append(changed, "volumes")
 			}
 		}
-		if updated {
+		// The changes to an object whose hash matches the desired spec were
+		// made out-of-band
+		if reconcileSpecHash(&existingDeployment.ObjectMeta, deployment.ObjectMeta) {
+			updated = true
+		} else if len(changed) > 0 {
+			c.recordDrift(el, "Deployment", existingDeployment.Name, changed)
+		}
+		if updated || len(changed) > 0 {
 			if _, err := c.KubeClientSet.AppsV1().Deployments(el.Namespace).Update(existingDeployment); err != nil {
 				c.Logger.Errorf("Error updating EventListener Deployment: %s", err)
 				return err
@@ -404,6 +947,91 @@ func (c *Reconciler) reconcileDeployment(el *v1alpha1.EventListener) error {
 	return nil
 }
 
+// makeTopologySpreadConstraints spreads the pods of the EventListener evenly
+// across each of the topology keys, preferring to schedule a pod over keeping
+// the spread when no node satisfies it.
+func makeTopologySpreadConstraints(name string, a *v1alpha1.Availability) []corev1.TopologySpreadConstraint {
+	keys := a.TopologyKeys
+	if len(keys) == 0 {
+		keys = []string{defaultTopologyKey}
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(keys))
+	for _, key := range keys {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       key,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: GenerateResourceLabels(name),
+			},
+		})
+	}
+	return constraints
+}
+
+// MakePodDisruptionBudget returns the PodDisruptionBudget that protects the
+// pods of the EventListener. It returns nil when the EventListener does not
+// set availability.
+func MakePodDisruptionBudget(el *v1alpha1.EventListener) *policyv1beta1.PodDisruptionBudget {
+	a := el.Spec.Availability
+	if a == nil {
+		return nil
+	}
+	maxUnavailable := intstr.FromInt(int(a.MaxUnavailable))
+	if a.MaxUnavailable == 0 {
+		maxUnavailable = intstr.FromInt(1)
+	}
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: generateObjectMeta(el),
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: GenerateResourceLabels(el.Name),
+			},
+		},
+	}
+}
+
+func (c *Reconciler) reconcilePodDisruptionBudget(el *v1alpha1.EventListener) error {
+	pdbs := c.KubeClientSet.PolicyV1beta1().PodDisruptionBudgets(el.Namespace)
+	pdb := MakePodDisruptionBudget(el)
+	if pdb == nil {
+		// Remove the PodDisruptionBudget once availability is turned off
+		err := pdbs.Delete(el.Status.Configuration.GeneratedResourceName, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			c.Logger.Errorf("Error deleting EventListener PodDisruptionBudget: %s", err)
+			return err
+		}
+		return nil
+	}
+	existingPDB, err := pdbs.Get(el.Status.Configuration.GeneratedResourceName, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		updated := reconcileObjectMeta(&existingPDB.ObjectMeta, pdb.ObjectMeta)
+		if !equality.Semantic.DeepEqual(existingPDB.Spec, pdb.Spec) {
+			existingPDB.Spec = pdb.Spec
+			updated = true
+		}
+		if updated {
+			if _, err := pdbs.Update(existingPDB); err != nil {
+				c.Logger.Errorf("Error updating EventListener PodDisruptionBudget: %s", err)
+				return err
+			}
+			c.Logger.Infof("Updated EventListener PodDisruptionBudget %s in Namespace %s", existingPDB.Name, el.Namespace)
+		}
+	case errors.IsNotFound(err):
+		if _, err := pdbs.Create(pdb); err != nil {
+			c.Logger.Errorf("Error creating EventListener PodDisruptionBudget: %s", err)
+			return err
+		}
+		c.Logger.Infof("Created EventListener PodDisruptionBudget %s in Namespace %s", pdb.Name, el.Namespace)
+	default:
+		c.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
 // GenerateResourceLabels generates the labels to be used on all generated resources.
 func GenerateResourceLabels(eventListenerName string) map[string]string {
 	resourceLabels := make(map[string]string, len(StaticResourceLabels)+1)
@@ -450,6 +1078,20 @@ func wrapError(err1, err2 error) error {
 	return xerrors.Errorf("%s : %s", err1.Error(), err2.Error())
 }
 
+// setAddress sets the address of the EventListener to the URL of its external
+// hostname if it is annotated with one, and otherwise to the URL of its
+// Service.
+func setAddress(el *v1alpha1.EventListener, serviceName string, port int) {
+	if host := el.Annotations[v1alpha1.ExternalHostnameAnnotation]; host != "" {
+		el.Status.SetExternalAddress(host)
+		return
+	}
+	el.Status.SetAddress(listenerHostname(serviceName, el.Namespace, port))
+	if servingCert(el) || el.Spec.TLSSecret != "" {
+		el.Status.Address.URL.Scheme = "https"
+	}
+}
+
 // listenerHostname returns the intended hostname for the EventListener service.
 func listenerHostname(name, namespace string, port int) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", name, namespace, port)
@@ -25,12 +25,16 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/triggers/pkg/apis/config"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	"github.com/tektoncd/triggers/test"
 	bldr "github.com/tektoncd/triggers/test/builder"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -146,6 +150,7 @@ func Test_reconcileService(t *testing.T) {
 			},
 		},
 	}
+	setSpecHash(&service1.ObjectMeta, service1.Spec)
 	service2 := service1.DeepCopy()
 	service2.Labels = mergeLabels(generatedLabels, updateLabel)
 	service2.Spec.Selector = generatedLabels
@@ -153,6 +158,17 @@ func Test_reconcileService(t *testing.T) {
 	service3 := service1.DeepCopy()
 	service3.Spec.Ports[0].NodePort = 30000
 
+	// eventListener3 is reachable at an external hostname
+	eventListener3 := eventListener0.DeepCopy()
+	eventListener3.Annotations = map[string]string{v1alpha1.ExternalHostnameAnnotation: "hooks.example.com"}
+	eventListener4 := eventListener3.DeepCopy()
+	eventListener4.Status.SetExistsCondition(v1alpha1.ServiceExists, nil)
+	eventListener4.Status.SetExternalAddress("hooks.example.com")
+	eventListener5 := eventListener1.DeepCopy()
+	eventListener5.Annotations = eventListener3.Annotations
+	eventListener6 := eventListener5.DeepCopy()
+	eventListener6.Status.SetExternalAddress("hooks.example.com")
+
 	tests := []struct {
 		name           string
 		startResources test.Resources
@@ -207,6 +223,29 @@ func Test_reconcileService(t *testing.T) {
 				Services:       []*corev1.Service{service3},
 			},
 		},
+		{
+			name: "create-service-external-hostname",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListener3},
+			},
+			endResources: test.Resources{
+				EventListeners: []*v1alpha1.EventListener{eventListener4},
+				Services:       []*corev1.Service{service1},
+			},
+		},
+		{
+			name: "external-hostname-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListener5},
+				Services:       []*corev1.Service{service1},
+			},
+			endResources: test.Resources{
+				EventListeners: []*v1alpha1.EventListener{eventListener6},
+				Services:       []*corev1.Service{service1},
+			},
+		},
 	}
 	for i := range tests {
 		t.Run(tests[i].name, func(t *testing.T) {
@@ -215,7 +254,7 @@ func Test_reconcileService(t *testing.T) {
 			defer cancel()
 
 			// Run Reconcile
-			err := testAssets.Controller.Reconciler.(*Reconciler).reconcileService(tests[i].startResources.EventListeners[0])
+			err := testAssets.Controller.Reconciler.(*Reconciler).reconcileService(tests[i].startResources.EventListeners[0], SinkDefaults(context.Background()))
 			if err != nil {
 				t.Errorf("eventlistener.Reconcile() returned error: %s", err)
 				return
@@ -299,6 +338,10 @@ func Test_reconcileDeployment(t *testing.T) {
 								"-el-name", eventListenerName,
 								"-el-namespace", namespace,
 								"-port", strconv.Itoa(*ElPort),
+								"-read-timeout", ReadTimeout.String(),
+								"-write-timeout", WriteTimeout.String(),
+								"-idle-timeout", IdleTimeout.String(),
+								"-max-concurrent-streams", strconv.Itoa(*MaxConcurrentStreams),
 							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
@@ -341,6 +384,8 @@ func Test_reconcileDeployment(t *testing.T) {
 		},
 	}
 
+	setSpecHash(&deployment1.ObjectMeta, deployment1.Spec)
+
 	// deployment 2 == initial deployment + labels from eventListener
 	deployment2 := deployment1.DeepCopy()
 	deployment2.Labels = mergeLabels(generatedLabels, updateLabel)
@@ -355,12 +400,156 @@ func Test_reconcileDeployment(t *testing.T) {
 	deployment4 := deployment1.DeepCopy()
 	deployment4.Spec.Template.Spec.ServiceAccountName = updatedSa
 
+	// eventListener5 relies on the default ServiceAccount
+	eventListener5 := eventListener1.DeepCopy()
+	eventListener5.Spec.ServiceAccountName = ""
+
+	// deployment5 == initial deployment + defaults from config-defaults-triggers
+	deployment5 := deployment1.DeepCopy()
+	deployment5.Spec.Template.Spec.ServiceAccountName = "tekton-triggers"
+	deployment5.Spec.Template.Spec.Containers[0].Image = "example.com/sink:v2"
+	deployment5.Spec.Template.Spec.Containers[0].Args = append(deployment5.Spec.Template.Spec.Containers[0].Args, "-metrics=false", "-max-triggers-per-event", "10")
+	deployment5.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+
+	// eventListenerSQS consumes an SQS queue with credentials from a Secret
+	eventListenerSQS := eventListener1.DeepCopy()
+	eventListenerSQS.Spec.SQS = &v1alpha1.SQSSource{
+		QueueURL:                 "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+		CredentialsSecret:        "aws-creds",
+		VisibilityTimeoutSeconds: 60,
+	}
+
+	// deploymentSQS == initial deployment + SQS args and credentials
+	deploymentSQS := deployment1.DeepCopy()
+	deploymentSQS.Spec.Template.Spec.Containers[0].Args = append(deploymentSQS.Spec.Template.Spec.Containers[0].Args,
+		"-sqs-queue-url", "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+		"-sqs-visibility-timeout", "1m0s",
+	)
+	awsSecretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "aws-creds"},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	deploymentSQS.Spec.Template.Spec.Containers[0].Env = append(deploymentSQS.Spec.Template.Spec.Containers[0].Env,
+		awsSecretEnv("AWS_ACCESS_KEY_ID", "aws_access_key_id"),
+		awsSecretEnv("AWS_SECRET_ACCESS_KEY", "aws_secret_access_key"),
+	)
+
+	// eventListenerAdmin serves the admin endpoints on a separate port with
+	// a token and client certificates
+	eventListenerAdmin := eventListener1.DeepCopy()
+	eventListenerAdmin.Spec.Admin = &v1alpha1.AdminEndpoints{
+		Port:              9000,
+		TokenSecret:       "admin-token",
+		TLSSecret:         "admin-tls",
+		RequireClientCert: true,
+	}
+
+	// deploymentAdmin == initial deployment + admin port, args and Secrets
+	deploymentAdmin := deployment1.DeepCopy()
+	adminContainer := &deploymentAdmin.Spec.Template.Spec.Containers[0]
+	adminContainer.Ports = append(adminContainer.Ports, corev1.ContainerPort{
+		Name:          "admin",
+		ContainerPort: 9000,
+		Protocol:      corev1.ProtocolTCP,
+	})
+	adminContainer.Args = append(adminContainer.Args,
+		"-admin-port", "9000",
+		"-admin-token-file", "/etc/admin-token/token",
+		"-admin-tls-cert-file", "/etc/admin-tls/tls.crt",
+		"-admin-tls-key-file", "/etc/admin-tls/tls.key",
+		"-admin-client-ca-file", "/etc/admin-tls/ca.crt",
+	)
+	for _, v := range []struct{ name, secret string }{{"admin-token", "admin-token"}, {"admin-tls", "admin-tls"}} {
+		adminContainer.VolumeMounts = append(adminContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      v.name,
+			MountPath: "/etc/" + v.name,
+			ReadOnly:  true,
+		})
+		deploymentAdmin.Spec.Template.Spec.Volumes = append(deploymentAdmin.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: v.name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: v.secret},
+			},
+		})
+	}
+
+	// eventListenerReplicas runs two replicas
+	eventListenerReplicas := eventListener1.DeepCopy()
+	var specReplicas int32 = 2
+	eventListenerReplicas.Spec.Replicas = &specReplicas
+
+	// deploymentReplicas == initial deployment + replicas
+	deploymentReplicas := deployment1.DeepCopy()
+	deploymentReplicas.Spec.Replicas = &specReplicas
+
+	// eventListenerAvailability runs three replicas spread across zones
+	eventListenerAvailability := eventListener1.DeepCopy()
+	eventListenerAvailability.Spec.Availability = &v1alpha1.Availability{
+		Replicas:     3,
+		TopologyKeys: []string{"topology.kubernetes.io/zone"},
+	}
+
+	// deploymentAvailability == initial deployment + replicas and spread
+	deploymentAvailability := deployment1.DeepCopy()
+	var availableReplicas int32 = 3
+	deploymentAvailability.Spec.Replicas = &availableReplicas
+	deploymentAvailability.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: generatedLabels},
+	}}
+
+	eventListenerFIPS := eventListener1.DeepCopy()
+	eventListenerFIPS.Spec.FIPS = true
+
+	// deploymentFIPS == initial deployment + FIPS mode
+	deploymentFIPS := deployment1.DeepCopy()
+	deploymentFIPS.Spec.Template.Spec.Containers[0].Args = append(deploymentFIPS.Spec.Template.Spec.Containers[0].Args, "-fips")
+
+	eventListenerWarmUp := eventListener1.DeepCopy()
+	eventListenerWarmUp.Spec.WarmUp = v1alpha1.WarmUpFail
+
+	// deploymentWarmUp == initial deployment + failing warm-up
+	deploymentWarmUp := deployment1.DeepCopy()
+	deploymentWarmUp.Spec.Template.Spec.Containers[0].Args = append(deploymentWarmUp.Spec.Template.Spec.Containers[0].Args, "-warm-up", "fail")
+
+	eventListenerTLSPolicy := eventListener1.DeepCopy()
+	eventListenerTLSPolicy.Spec.TLSPolicy = &v1alpha1.TLSPolicy{MinVersion: "VersionTLS13", CurvePreferences: []string{"X25519", "P256"}}
+
+	// deploymentTLSPolicy == initial deployment + TLS policy
+	deploymentTLSPolicy := deployment1.DeepCopy()
+	deploymentTLSPolicy.Spec.Template.Spec.Containers[0].Args = append(deploymentTLSPolicy.Spec.Template.Spec.Containers[0].Args, "-tls-min-version", "VersionTLS13", "-tls-curve-preferences", "X25519,P256")
+
+	var gcPercent int32 = 50
+	eventListenerRuntime := eventListener1.DeepCopy()
+	eventListenerRuntime.Spec.Runtime = &v1alpha1.SinkRuntime{GoMaxProcs: 2, GCPercent: &gcPercent}
+
+	// deploymentRuntime == initial deployment + runtime tuning
+	deploymentRuntime := deployment1.DeepCopy()
+	deploymentRuntime.Spec.Template.Spec.Containers[0].Args = append(deploymentRuntime.Spec.Template.Spec.Containers[0].Args, "-gomaxprocs", "2", "-gc-percent", "50")
+
+	// The deployments are reconciled to the spec recorded in their hash
+	for _, d := range []*appsv1.Deployment{deployment2, deployment4, deployment5, deploymentSQS, deploymentAdmin, deploymentReplicas, deploymentAvailability, deploymentFIPS, deploymentWarmUp, deploymentTLSPolicy, deploymentRuntime} {
+		setSpecHash(&d.ObjectMeta, d.Spec)
+	}
+
 	deploymentMissingVolumes := deployment1.DeepCopy()
 	deploymentMissingVolumes.Spec.Template.Spec.Volumes = nil
 	deploymentMissingVolumes.Spec.Template.Spec.Containers[0].VolumeMounts = nil
 
 	tests := []struct {
 		name           string
+		config         map[string]string
 		startResources test.Resources
 		endResources   test.Resources
 	}{
@@ -452,6 +641,134 @@ func Test_reconcileDeployment(t *testing.T) {
 				EventListeners: []*v1alpha1.EventListener{eventListener2},
 				Deployments:    []*appsv1.Deployment{deployment2},
 			},
+		}, {
+			name: "config-defaults-update",
+			config: map[string]string{
+				"el-image":                  "example.com/sink:v2",
+				"el-resources":              "limits:\n  memory: 256Mi\n",
+				"el-metrics-enabled":        "false",
+				"el-max-triggers-per-event": "10",
+				"default-service-account":   "tekton-triggers",
+			},
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListener5},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListener5},
+				Deployments:    []*appsv1.Deployment{deployment5},
+			},
+		}, {
+			name: "eventlistener-sqs-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerSQS},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerSQS},
+				Deployments:    []*appsv1.Deployment{deploymentSQS},
+			},
+		}, {
+			name: "eventlistener-admin-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerAdmin},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerAdmin},
+				Deployments:    []*appsv1.Deployment{deploymentAdmin},
+			},
+		}, {
+			name: "eventlistener-replicas-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerReplicas},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerReplicas},
+				Deployments:    []*appsv1.Deployment{deploymentReplicas},
+			},
+		}, {
+			// Manual scaling is reset to the replicas of the spec
+			name: "eventlistener-replicas-reset",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerReplicas},
+				Deployments:    []*appsv1.Deployment{deployment3},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerReplicas},
+				Deployments:    []*appsv1.Deployment{deploymentReplicas},
+			},
+		}, {
+			name: "eventlistener-availability-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerAvailability},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerAvailability},
+				Deployments:    []*appsv1.Deployment{deploymentAvailability},
+			},
+		}, {
+			name: "eventlistener-fips-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerFIPS},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerFIPS},
+				Deployments:    []*appsv1.Deployment{deploymentFIPS},
+			},
+		}, {
+			name: "eventlistener-warm-up-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerWarmUp},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerWarmUp},
+				Deployments:    []*appsv1.Deployment{deploymentWarmUp},
+			},
+		}, {
+			name: "eventlistener-tls-policy-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerTLSPolicy},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerTLSPolicy},
+				Deployments:    []*appsv1.Deployment{deploymentTLSPolicy},
+			},
+		}, {
+			name: "eventlistener-runtime-update",
+			startResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerRuntime},
+				Deployments:    []*appsv1.Deployment{deployment1},
+			},
+			endResources: test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{eventListenerRuntime},
+				Deployments:    []*appsv1.Deployment{deploymentRuntime},
+			},
 		},
 	}
 	for i := range tests {
@@ -459,9 +776,17 @@ func Test_reconcileDeployment(t *testing.T) {
 			// Setup
 			testAssets, cancel := getEventListenerTestAssets(t, tests[i].startResources)
 			defer cancel()
+			ctx := context.Background()
+			if tests[i].config != nil {
+				defaults, err := config.NewDefaultsFromMap(tests[i].config)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ctx = config.ToContext(ctx, &config.Config{Defaults: defaults})
+			}
 
 			// Run Reconcile
-			err := testAssets.Controller.Reconciler.(*Reconciler).reconcileDeployment(tests[i].startResources.EventListeners[0])
+			err := testAssets.Controller.Reconciler.(*Reconciler).reconcileDeployment(tests[i].startResources.EventListeners[0], SinkDefaults(ctx))
 			if err != nil {
 				t.Errorf("eventlistener.Reconcile() returned error: %s", err)
 				return
@@ -489,6 +814,78 @@ func Test_reconcileDeployment(t *testing.T) {
 	}
 }
 
+func Test_reconcilePodDisruptionBudget(t *testing.T) {
+	eventListenerAvailability := eventListener0.DeepCopy()
+	eventListenerAvailability.Spec.Availability = &v1alpha1.Availability{Replicas: 2}
+
+	eventListenerMaxUnavailable := eventListener0.DeepCopy()
+	eventListenerMaxUnavailable.Spec.Availability = &v1alpha1.Availability{Replicas: 4, MaxUnavailable: 2}
+
+	makePDB := func(maxUnavailable int) *policyv1beta1.PodDisruptionBudget {
+		m := intstr.FromInt(maxUnavailable)
+		return &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: generateObjectMeta(eventListener0),
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &m,
+				Selector:       &metav1.LabelSelector{MatchLabels: generatedLabels},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		el   *v1alpha1.EventListener
+		pdb  *policyv1beta1.PodDisruptionBudget
+		want *policyv1beta1.PodDisruptionBudget
+	}{{
+		name: "no availability",
+		el:   eventListener0,
+	}, {
+		name: "create",
+		el:   eventListenerAvailability,
+		want: makePDB(1),
+	}, {
+		name: "update max unavailable",
+		el:   eventListenerMaxUnavailable,
+		pdb:  makePDB(1),
+		want: makePDB(2),
+	}, {
+		name: "delete when availability is removed",
+		el:   eventListener0,
+		pdb:  makePDB(1),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{tc.el},
+			})
+			defer cancel()
+			pdbs := testAssets.Clients.Kube.PolicyV1beta1().PodDisruptionBudgets(namespace)
+			if tc.pdb != nil {
+				if _, err := pdbs.Create(tc.pdb); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := testAssets.Controller.Reconciler.(*Reconciler).reconcilePodDisruptionBudget(tc.el); err != nil {
+				t.Fatalf("reconcilePodDisruptionBudget() returned error: %s", err)
+			}
+
+			got, err := pdbs.Get(generatedResourceName, metav1.GetOptions{})
+			switch {
+			case errors.IsNotFound(err):
+				got = nil
+			case err != nil:
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PodDisruptionBudget mismatch (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestReconcile(t *testing.T) {
 	eventListener1 := bldr.EventListener(eventListenerName, namespace,
 		bldr.EventListenerSpec(
@@ -519,6 +916,11 @@ func TestReconcile(t *testing.T) {
 				fmt.Sprintf("ReplicaSet \"%s\" has successfully progressed.", eventListenerName),
 				"NewReplicaSetAvailable",
 			),
+			bldr.EventListenerCondition(
+				v1alpha1.TriggersResolved,
+				corev1.ConditionTrue,
+				"Triggers resolved", "",
+			),
 		),
 	)
 
@@ -567,6 +969,10 @@ func TestReconcile(t *testing.T) {
 							"-el-name", eventListenerName,
 							"-el-namespace", namespace,
 							"-port", strconv.Itoa(*ElPort),
+							"-read-timeout", ReadTimeout.String(),
+							"-write-timeout", WriteTimeout.String(),
+							"-idle-timeout", IdleTimeout.String(),
+							"-max-concurrent-streams", strconv.Itoa(*MaxConcurrentStreams),
 						},
 						VolumeMounts: []corev1.VolumeMount{{
 							Name:      "config-logging",
@@ -635,6 +1041,13 @@ func TestReconcile(t *testing.T) {
 	service3 := service2.DeepCopy()
 	service3.Spec.Type = corev1.ServiceTypeNodePort
 
+	for _, d := range []*appsv1.Deployment{deployment1, deployment2, deployment3} {
+		setSpecHash(&d.ObjectMeta, d.Spec)
+	}
+	for _, s := range []*corev1.Service{service1, service2, service3} {
+		setSpecHash(&s.ObjectMeta, s.Spec)
+	}
+
 	loggingConfigMap := defaultLoggingConfigMap()
 	loggingConfigMap.ObjectMeta.Namespace = namespace
 
@@ -765,6 +1178,210 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestReconcile_embeddedSink(t *testing.T) {
+	*EmbeddedSinkAddress = "tekton-triggers-controller.tekton-pipelines.svc.cluster.local:8080"
+	defer func() { *EmbeddedSinkAddress = "" }()
+
+	el := bldr.EventListener(eventListenerName, namespace,
+		bldr.EventListenerSpec(
+			bldr.EventListenerServiceAccount("sa"),
+		),
+		bldr.EventListenerStatus(
+			bldr.EventListenerConfig(generatedResourceName),
+		),
+	)
+	// The Deployment and Service of the separate sink are deleted
+	testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+		Namespaces:     []*corev1.Namespace{namespaceResource},
+		EventListeners: []*v1alpha1.EventListener{el},
+		Deployments:    []*appsv1.Deployment{{ObjectMeta: generateObjectMeta(el)}},
+		Services:       []*corev1.Service{{ObjectMeta: generateObjectMeta(el)}},
+	})
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), reconcileKey); err != nil {
+		t.Fatalf("eventlistener.Reconcile() returned error: %s", err)
+	}
+	actual, err := test.GetResourcesFromClients(testAssets.Clients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actual.Deployments) != 0 || len(actual.Services) != 0 {
+		t.Errorf("eventlistener.Reconcile() kept %d Deployments and %d Services, want none", len(actual.Deployments), len(actual.Services))
+	}
+	got := actual.EventListeners[0]
+	want := "http://tekton-triggers-controller.tekton-pipelines.svc.cluster.local:8080/tekton-pipelines/my-eventlistener"
+	if got.Status.Address == nil || got.Status.Address.URL.String() != want {
+		t.Errorf("eventlistener.Reconcile() set the address %v, want %s", got.Status.Address, want)
+	}
+	for _, cond := range []apis.ConditionType{v1alpha1.ServiceExists, v1alpha1.DeploymentExists} {
+		if c := got.Status.GetCondition(cond); c == nil || c.Status != corev1.ConditionTrue || c.Reason != "EmbeddedSink" {
+			t.Errorf("eventlistener.Reconcile() set the %s condition %v, want it true with reason EmbeddedSink", cond, c)
+		}
+	}
+}
+
+func Test_reconcileTriggers(t *testing.T) {
+	tt := bldr.TriggerTemplate("tt", namespace, bldr.TriggerTemplateSpec(
+		bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"kind":"PipelineRun","apiVersion":"tekton.dev/v1alpha1"}`)}),
+	))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("secret")},
+	}
+	webhookService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "interceptor", Namespace: namespace},
+	}
+	githubInterceptor := &v1alpha1.EventInterceptor{
+		GitHub: &v1alpha1.GitHubInterceptor{SecretRef: &v1alpha1.SecretRef{SecretName: "secret", SecretKey: "token"}},
+	}
+	githubTrigger := bldr.EventListenerTrigger("tt", "v1alpha1", func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.Interceptors = append(trigger.Interceptors, githubInterceptor)
+	})
+	webhookTrigger := bldr.EventListenerTrigger("tt", "v1alpha1",
+		bldr.EventListenerTriggerInterceptor("interceptor", "v1", "Service", ""))
+
+	tests := []struct {
+		name       string
+		el         *v1alpha1.EventListener
+		resources  test.Resources
+		wantStatus corev1.ConditionStatus
+		wantReason string
+		// wantAvailable is the number of available Triggers
+		wantAvailable int32
+	}{{
+		name:          "all triggers resolved",
+		el:            bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(githubTrigger, webhookTrigger)),
+		resources:     test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}, Secrets: []*corev1.Secret{secret}, Services: []*corev1.Service{webhookService}},
+		wantStatus:    corev1.ConditionTrue,
+		wantAvailable: 2,
+	}, {
+		name:          "some triggers resolved",
+		el:            bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(githubTrigger, bldr.EventListenerTrigger("tt", "v1alpha1"))),
+		resources:     test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus:    corev1.ConditionFalse,
+		wantReason:    v1alpha1.ReasonSecretMissing,
+		wantAvailable: 1,
+	}, {
+		name:       "missing secret",
+		el:         bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(githubTrigger)),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonSecretMissing,
+	}, {
+		name:       "missing interceptor service",
+		el:         bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(webhookTrigger)),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonInterceptorUnreachable,
+	}, {
+		name: "ClusterInterceptor",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerClusterInterceptor("github")))),
+		resources: test.Resources{
+			TriggerTemplates:    []*v1alpha1.TriggerTemplate{tt},
+			ClusterInterceptors: []*v1alpha1.ClusterInterceptor{{ObjectMeta: metav1.ObjectMeta{Name: "github"}}},
+		},
+		wantStatus:    corev1.ConditionTrue,
+		wantAvailable: 1,
+	}, {
+		name: "missing ClusterInterceptor",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerClusterInterceptor("github")))),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonInterceptorUnreachable,
+	}, {
+		name: "missing InterceptorChain",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerInterceptorChain("checks")))),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonInterceptorUnreachable,
+	}, {
+		name: "missing secret in InterceptorChain",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerInterceptorChain("checks")))),
+		resources: test.Resources{
+			TriggerTemplates: []*v1alpha1.TriggerTemplate{tt},
+			InterceptorChains: []*v1alpha1.InterceptorChain{{
+				ObjectMeta: metav1.ObjectMeta{Name: "checks", Namespace: namespace},
+				Spec:       v1alpha1.InterceptorChainSpec{Interceptors: []*v1alpha1.EventInterceptor{githubInterceptor}},
+			}},
+		},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonSecretMissing,
+	}, {
+		name: "missing OPA policy",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", func(trigger *v1alpha1.EventListenerTrigger) {
+				trigger.Interceptors = append(trigger.Interceptors, &v1alpha1.EventInterceptor{
+					OPA: &v1alpha1.OPAInterceptor{
+						Server:   "http://opa.opa.svc:8181",
+						Decision: "triggers/allow",
+						PolicyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+							Key:                  "policy.rego",
+						},
+					},
+				})
+			}))),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonInterceptorUnreachable,
+	}, {
+		name: "missing previous secret",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", func(trigger *v1alpha1.EventListenerTrigger) {
+				trigger.Interceptors = append(trigger.Interceptors, &v1alpha1.EventInterceptor{
+					GitHub: &v1alpha1.GitHubInterceptor{
+						SecretRef:         &v1alpha1.SecretRef{SecretName: "secret", SecretKey: "token"},
+						PreviousSecretRef: &v1alpha1.SecretRef{SecretName: "old-secret", SecretKey: "token"},
+					},
+				})
+			}))),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}, Secrets: []*corev1.Secret{secret}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonSecretMissing,
+	}, {
+		name: "missing binding",
+		el: bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerTriggerBinding("dne", "", "v1alpha1")))),
+		resources:  test.Resources{TriggerTemplates: []*v1alpha1.TriggerTemplate{tt}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonTemplateInvalid,
+	}, {
+		name:       "missing template",
+		el:         bldr.EventListener(eventListenerName, namespace, bldr.EventListenerSpec(bldr.EventListenerTrigger("dne", "v1alpha1"))),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.ReasonTemplateInvalid,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, tc.resources)
+			defer cancel()
+
+			testAssets.Controller.Reconciler.(*Reconciler).reconcileTriggers(context.Background(), tc.el)
+			cond := tc.el.Status.GetCondition(v1alpha1.TriggersResolved)
+			if cond == nil {
+				t.Fatalf("TriggersResolved condition not set")
+			}
+			if cond.Status != tc.wantStatus || cond.Reason != tc.wantReason {
+				t.Errorf("TriggersResolved condition = %s/%s, want %s/%s", cond.Status, cond.Reason, tc.wantStatus, tc.wantReason)
+			}
+			if tc.el.Status.AvailableTriggers != tc.wantAvailable {
+				t.Errorf("AvailableTriggers = %d, want %d", tc.el.Status.AvailableTriggers, tc.wantAvailable)
+			}
+			// The referenced objects are read from the listers
+			for _, a := range append(testAssets.Clients.Kube.Actions(), testAssets.Clients.Triggers.Actions()...) {
+				if a.GetVerb() == "get" {
+					t.Errorf("Unexpected API read of %s", a.GetResource().Resource)
+				}
+			}
+		})
+	}
+}
+
 func Test_wrapError(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -810,6 +1427,104 @@ func Test_wrapError(t *testing.T) {
 	}
 }
 
+func Test_addTLSPolicy(t *testing.T) {
+	defaults := &v1alpha1.TLSPolicy{
+		MinVersion:   "VersionTLS12",
+		CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+	}
+	tests := []struct {
+		name     string
+		policy   *v1alpha1.TLSPolicy
+		defaults *v1alpha1.TLSPolicy
+		want     []string
+	}{{
+		name: "no policy",
+	}, {
+		name:     "defaults",
+		defaults: defaults,
+		want:     []string{"-tls-min-version", "VersionTLS12", "-tls-cipher-suites", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+	}, {
+		name:     "EventListener fields override defaults",
+		policy:   &v1alpha1.TLSPolicy{MinVersion: "VersionTLS13", CurvePreferences: []string{"X25519"}},
+		defaults: defaults,
+		want:     []string{"-tls-min-version", "VersionTLS13", "-tls-cipher-suites", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "-tls-curve-preferences", "X25519"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			container := corev1.Container{}
+			addTLSPolicy(&container, tc.policy, tc.defaults)
+			if diff := cmp.Diff(tc.want, container.Args); diff != "" {
+				t.Errorf("addTLSPolicy() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMakeDeployment_TLSSecret(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.TLSSecret = "listener-tls"
+
+	spec := MakeDeployment(el, SinkDefaults(context.Background())).Spec.Template.Spec
+	container := spec.Containers[0]
+	wantArgs := []string{"-tls-cert-file", "/etc/tls/tls.crt", "-tls-key-file", "/etc/tls/tls.key"}
+	if diff := cmp.Diff(wantArgs, container.Args[len(container.Args)-len(wantArgs):]); diff != "" {
+		t.Errorf("Args mismatch (-want +got): %s", diff)
+	}
+	if container.LivenessProbe.HTTPGet.Scheme != corev1.URISchemeHTTPS {
+		t.Errorf("LivenessProbe scheme = %s, want HTTPS", container.LivenessProbe.HTTPGet.Scheme)
+	}
+	wantVolumes := []corev1.Volume{{
+		Name: "tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "listener-tls"},
+		},
+	}}
+	if diff := cmp.Diff(wantVolumes, spec.Volumes[1:]); diff != "" {
+		t.Errorf("Volumes mismatch (-want +got): %s", diff)
+	}
+
+	setAddress(el, generatedResourceName, 8080)
+	if el.Status.Address.URL.Scheme != "https" {
+		t.Errorf("address = %s, want an https address", el.Status.Address.URL)
+	}
+}
+
+func TestMakeDeployment_EventTimeout(t *testing.T) {
+	tests := []struct {
+		name                string
+		eventTimeoutSeconds int32
+		want                string
+	}{{
+		name: "default event timeout",
+		want: WriteTimeout.String(),
+	}, {
+		name:                "event timeout below the write timeout",
+		eventTimeoutSeconds: 45,
+		want:                WriteTimeout.String(),
+	}, {
+		name:                "event timeout above the write timeout",
+		eventTimeoutSeconds: 300,
+		want:                "5m10s",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			el := eventListener0.DeepCopy()
+			el.Spec.EventTimeoutSeconds = tc.eventTimeoutSeconds
+
+			args := MakeDeployment(el, SinkDefaults(context.Background())).Spec.Template.Spec.Containers[0].Args
+			var got string
+			for i, arg := range args {
+				if arg == "-write-timeout" {
+					got = args[i+1]
+				}
+			}
+			if got != tc.want {
+				t.Errorf("-write-timeout = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
 func Test_mergeLabels(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,232 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/sqs"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// kedaAPIVersion is the API version of the KEDA resources that are
+	// generated for EventListeners with autoscaling
+	kedaAPIVersion = "keda.sh/v1alpha1"
+	// defaultTargetQueueLength is the number of waiting messages per pod
+	// that KEDA scales towards by default
+	defaultTargetQueueLength = 5
+	// defaultTargetEventsInFlight is the number of events in flight per pod
+	// that KEDA scales towards by default
+	defaultTargetEventsInFlight = 10
+	// eventsInFlightMetric is the metric of the sink that counts the events
+	// it is processing
+	eventsInFlightMetric = "eventlistener_events_in_flight"
+)
+
+var (
+	scaledObjectGVR          = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "scaledobjects"}
+	triggerAuthenticationGVR = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "triggerauthentications"}
+)
+
+// MakeScaledObject returns the KEDA ScaledObject that scales the Deployment
+// of the EventListener with the messages waiting in its queue sources and
+// the events in flight in its pods. It returns nil when the EventListener
+// does not set autoscaling.
+func MakeScaledObject(el *v1alpha1.EventListener) *unstructured.Unstructured {
+	a := el.Spec.Autoscaling
+	if a == nil {
+		return nil
+	}
+	minReplicas := a.MinReplicas
+	if minReplicas == 0 {
+		minReplicas = 1
+	}
+	targetQueueLength := a.TargetQueueLength
+	if targetQueueLength == 0 {
+		targetQueueLength = defaultTargetQueueLength
+	}
+	var triggers []interface{}
+	if s := el.Spec.SQS; s != nil {
+		metadata := map[string]interface{}{
+			"queueURL":    s.QueueURL,
+			"queueLength": strconv.Itoa(int(targetQueueLength)),
+		}
+		region := s.Region
+		if region == "" {
+			region, _ = sqs.RegionFromURL(s.QueueURL)
+		}
+		if region != "" {
+			metadata["awsRegion"] = region
+		}
+		trigger := map[string]interface{}{
+			"type":     "aws-sqs-queue",
+			"metadata": metadata,
+		}
+		if s.CredentialsSecret != "" {
+			trigger["authenticationRef"] = map[string]interface{}{
+				"name": el.Status.Configuration.GeneratedResourceName,
+			}
+		} else {
+			// Without credentials, KEDA reads the queue with its own role
+			metadata["identityOwner"] = "operator"
+		}
+		triggers = append(triggers, trigger)
+	}
+	if p := a.Prometheus; p != nil {
+		target := p.TargetEventsInFlight
+		if target == 0 {
+			target = defaultTargetEventsInFlight
+		}
+		triggers = append(triggers, map[string]interface{}{
+			"type": "prometheus",
+			"metadata": map[string]interface{}{
+				"serverAddress": p.ServerAddress,
+				"metricName":    eventsInFlightMetric,
+				"query":         eventsInFlightQuery(el),
+				"threshold":     strconv.Itoa(int(target)),
+			},
+		})
+	}
+	return makeGeneratedObject(el, kedaAPIVersion, "ScaledObject", map[string]interface{}{
+		"scaleTargetRef": map[string]interface{}{
+			"name": el.Status.Configuration.GeneratedResourceName,
+		},
+		"minReplicaCount": int64(minReplicas),
+		"maxReplicaCount": int64(a.MaxReplicas),
+		"triggers":        triggers,
+	})
+}
+
+// eventsInFlightQuery returns the query of the events in flight of the
+// EventListener. The pods of its Deployment are named after the Deployment,
+// followed by the hashes of their ReplicaSet and pod.
+func eventsInFlightQuery(el *v1alpha1.EventListener) string {
+	if q := el.Spec.Autoscaling.Prometheus.Query; q != "" {
+		return q
+	}
+	return fmt.Sprintf(`sum(%s{namespace=%q,pod=~"%s-[a-z0-9]+-[a-z0-9]+"})`,
+		eventsInFlightMetric, el.Namespace, el.Status.Configuration.GeneratedResourceName)
+}
+
+// MakeTriggerAuthentication returns the KEDA TriggerAuthentication that lets
+// the ScaledObject of the EventListener read its SQS queue with the
+// credentials of the queue source. It returns nil when the EventListener
+// does not set autoscaling or has no credentials Secret.
+func MakeTriggerAuthentication(el *v1alpha1.EventListener) *unstructured.Unstructured {
+	if el.Spec.Autoscaling == nil || el.Spec.SQS == nil || el.Spec.SQS.CredentialsSecret == "" {
+		return nil
+	}
+	var refs []interface{}
+	for _, p := range []struct{ parameter, key string }{
+		{"awsAccessKeyID", "aws_access_key_id"},
+		{"awsSecretAccessKey", "aws_secret_access_key"},
+	} {
+		refs = append(refs, map[string]interface{}{
+			"parameter": p.parameter,
+			"name":      el.Spec.SQS.CredentialsSecret,
+			"key":       p.key,
+		})
+	}
+	return makeGeneratedObject(el, kedaAPIVersion, "TriggerAuthentication", map[string]interface{}{
+		"secretTargetRef": refs,
+	})
+}
+
+// makeGeneratedObject returns an object of a type that is not vendored, with
+// the generated name, labels and owner of the resources of the EventListener.
+func makeGeneratedObject(el *v1alpha1.EventListener, apiVersion, kind string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"spec":       spec,
+	}}
+	meta := generateObjectMeta(el)
+	u.SetNamespace(meta.Namespace)
+	u.SetName(meta.Name)
+	u.SetOwnerReferences(meta.OwnerReferences)
+	u.SetLabels(meta.Labels)
+	return u
+}
+
+func (c *Reconciler) reconcileScaledObject(el *v1alpha1.EventListener) error {
+	// The TriggerAuthentication must exist before the ScaledObject uses it
+	if err := c.reconcileGeneratedObject(el, triggerAuthenticationGVR, MakeTriggerAuthentication(el)); err != nil {
+		return err
+	}
+	return c.reconcileGeneratedObject(el, scaledObjectGVR, MakeScaledObject(el))
+}
+
+// reconcileGeneratedObject creates or updates the generated object of the
+// resource, or deletes it when obj is nil. Only the spec fields that are
+// generated are compared, so that fields defaulted by KEDA or the Prometheus
+// Operator are kept.
+func (c *Reconciler) reconcileGeneratedObject(el *v1alpha1.EventListener, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+	client := c.dynamicClientSet.Resource(gvr).Namespace(el.Namespace)
+	name := el.Status.Configuration.GeneratedResourceName
+	if obj == nil {
+		err := client.Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			c.Logger.Errorf("Error deleting EventListener %s: %s", gvr.Resource, err)
+			return err
+		}
+		return nil
+	}
+	existing, err := client.Get(name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		updated := false
+		if !equality.Semantic.DeepEqual(existing.GetLabels(), obj.GetLabels()) {
+			existing.SetLabels(obj.GetLabels())
+			updated = true
+		}
+		spec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		for k, v := range obj.Object["spec"].(map[string]interface{}) {
+			if !equality.Semantic.DeepEqual(spec[k], v) {
+				spec[k] = v
+				updated = true
+			}
+		}
+		if updated {
+			existing.Object["spec"] = spec
+			if _, err := client.Update(existing, metav1.UpdateOptions{}); err != nil {
+				c.Logger.Errorf("Error updating EventListener %s: %s", gvr.Resource, err)
+				return err
+			}
+			c.Logger.Infof("Updated EventListener %s %s in Namespace %s", obj.GetKind(), name, el.Namespace)
+		}
+	case errors.IsNotFound(err):
+		if _, err := client.Create(obj, metav1.CreateOptions{}); err != nil {
+			c.Logger.Errorf("Error creating EventListener %s: %s", gvr.Resource, err)
+			return err
+		}
+		c.Logger.Infof("Created EventListener %s %s in Namespace %s", obj.GetKind(), name, el.Namespace)
+	default:
+		c.Logger.Error(err)
+		return err
+	}
+	return nil
+}
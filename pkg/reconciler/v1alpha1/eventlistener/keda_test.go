@@ -0,0 +1,180 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMakeScaledObject(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.SQS = &v1alpha1.SQSSource{QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"}
+	el.Spec.Autoscaling = &v1alpha1.QueueAutoscaling{MaxReplicas: 10}
+
+	got := MakeScaledObject(el)
+	wantSpec := map[string]interface{}{
+		"scaleTargetRef":  map[string]interface{}{"name": generatedResourceName},
+		"minReplicaCount": int64(1),
+		"maxReplicaCount": int64(10),
+		"triggers": []interface{}{map[string]interface{}{
+			"type": "aws-sqs-queue",
+			"metadata": map[string]interface{}{
+				"queueURL":      "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+				"queueLength":   "5",
+				"awsRegion":     "us-east-1",
+				"identityOwner": "operator",
+			},
+		}},
+	}
+	if diff := cmp.Diff(wantSpec, got.Object["spec"]); diff != "" {
+		t.Errorf("MakeScaledObject() spec mismatch (-want +got): %s", diff)
+	}
+	if got.GetName() != generatedResourceName || got.GetKind() != "ScaledObject" {
+		t.Errorf("MakeScaledObject() = %s %s, want ScaledObject %s", got.GetKind(), got.GetName(), generatedResourceName)
+	}
+	if MakeTriggerAuthentication(el) != nil {
+		t.Error("MakeTriggerAuthentication() without a credentials Secret should be nil")
+	}
+}
+
+func TestMakeScaledObject_eventsInFlight(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.Autoscaling = &v1alpha1.QueueAutoscaling{
+		MaxReplicas: 10,
+		Prometheus:  &v1alpha1.PrometheusAutoscaling{ServerAddress: "http://prometheus.monitoring.svc:9090"},
+	}
+
+	got := MakeScaledObject(el)
+	wantTriggers := []interface{}{map[string]interface{}{
+		"type": "prometheus",
+		"metadata": map[string]interface{}{
+			"serverAddress": "http://prometheus.monitoring.svc:9090",
+			"metricName":    "eventlistener_events_in_flight",
+			"query":         `sum(eventlistener_events_in_flight{namespace="` + namespace + `",pod=~"` + generatedResourceName + `-[a-z0-9]+-[a-z0-9]+"})`,
+			"threshold":     "10",
+		},
+	}}
+	if diff := cmp.Diff(wantTriggers, got.Object["spec"].(map[string]interface{})["triggers"]); diff != "" {
+		t.Errorf("MakeScaledObject() triggers mismatch (-want +got): %s", diff)
+	}
+
+	el.Spec.Autoscaling.Prometheus.Query = "sum(eventlistener_events_in_flight{eventlistener=\"foo\"})"
+	el.Spec.Autoscaling.Prometheus.TargetEventsInFlight = 3
+	metadata := MakeScaledObject(el).Object["spec"].(map[string]interface{})["triggers"].([]interface{})[0].(map[string]interface{})["metadata"].(map[string]interface{})
+	if metadata["query"] != el.Spec.Autoscaling.Prometheus.Query || metadata["threshold"] != "3" {
+		t.Errorf("MakeScaledObject() metadata = %v, want the query and threshold of the spec", metadata)
+	}
+}
+
+func Test_reconcileScaledObject(t *testing.T) {
+	elAutoscaling := eventListener0.DeepCopy()
+	elAutoscaling.Spec.SQS = &v1alpha1.SQSSource{
+		QueueURL:          "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+		CredentialsSecret: "aws-creds",
+	}
+	elAutoscaling.Spec.Autoscaling = &v1alpha1.QueueAutoscaling{MinReplicas: 2, MaxReplicas: 10, TargetQueueLength: 20}
+
+	elUpdated := elAutoscaling.DeepCopy()
+	elUpdated.Spec.Autoscaling.MaxReplicas = 20
+
+	// existing has a field that KEDA defaulted
+	existing := MakeScaledObject(elAutoscaling)
+	if err := unstructured.SetNestedField(existing.Object, int64(30), "spec", "pollingInterval"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		el               *v1alpha1.EventListener
+		existing         []*unstructured.Unstructured
+		wantScaledObject map[string]interface{}
+		wantAuth         bool
+	}{{
+		name: "no autoscaling",
+		el:   eventListener0,
+	}, {
+		name:             "create",
+		el:               elAutoscaling,
+		wantScaledObject: MakeScaledObject(elAutoscaling).Object["spec"].(map[string]interface{}),
+		wantAuth:         true,
+	}, {
+		name:     "update keeps defaulted fields",
+		el:       elUpdated,
+		existing: []*unstructured.Unstructured{existing, MakeTriggerAuthentication(elAutoscaling)},
+		wantScaledObject: func() map[string]interface{} {
+			spec := MakeScaledObject(elUpdated).Object["spec"].(map[string]interface{})
+			spec["pollingInterval"] = int64(30)
+			return spec
+		}(),
+		wantAuth: true,
+	}, {
+		name:     "delete when autoscaling is removed",
+		el:       eventListener0,
+		existing: []*unstructured.Unstructured{existing, MakeTriggerAuthentication(elAutoscaling)},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{tc.el},
+			})
+			defer cancel()
+			r := testAssets.Controller.Reconciler.(*Reconciler)
+			for _, obj := range tc.existing {
+				gvr := scaledObjectGVR
+				if obj.GetKind() == "TriggerAuthentication" {
+					gvr = triggerAuthenticationGVR
+				}
+				if _, err := r.dynamicClientSet.Resource(gvr).Namespace(namespace).Create(obj, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := r.reconcileScaledObject(tc.el); err != nil {
+				t.Fatalf("reconcileScaledObject() returned error: %s", err)
+			}
+
+			so, err := r.dynamicClientSet.Resource(scaledObjectGVR).Namespace(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			switch {
+			case tc.wantScaledObject == nil:
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected no ScaledObject, got %v, %v", so, err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			default:
+				if diff := cmp.Diff(tc.wantScaledObject, so.Object["spec"]); diff != "" {
+					t.Errorf("ScaledObject spec mismatch (-want +got): %s", diff)
+				}
+			}
+			_, err = r.dynamicClientSet.Resource(triggerAuthenticationGVR).Namespace(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			if tc.wantAuth && err != nil {
+				t.Errorf("expected a TriggerAuthentication, got %v", err)
+			} else if !tc.wantAuth && !errors.IsNotFound(err) {
+				t.Errorf("expected no TriggerAuthentication, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/triggers/pkg/apis/config"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// monitoringAPIVersion is the API version of the Prometheus Operator resources
+// that are generated for EventListeners with a prometheusMonitor
+const monitoringAPIVersion = "monitoring.coreos.com/v1"
+
+var (
+	serviceMonitorGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "servicemonitors"}
+	podMonitorGVR     = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "podmonitors"}
+)
+
+// prometheusMonitor returns the monitor of the EventListener, or the default
+// monitor. It returns nil when the sink does not expose metrics.
+func prometheusMonitor(el *v1alpha1.EventListener, d *config.Defaults) *v1alpha1.PrometheusMonitor {
+	if !d.ELMetricsEnabled {
+		return nil
+	}
+	if el.Spec.PrometheusMonitor != nil {
+		return el.Spec.PrometheusMonitor
+	}
+	return d.PrometheusMonitor
+}
+
+// MakeServiceMonitor returns the ServiceMonitor that scrapes the metrics of
+// the EventListener from its Service, verifying the certificate of the sink
+// with the ca.crt of its TLS Secret or the service CA if it serves HTTPS. It
+// returns nil when the EventListener has no monitor, or serves its admin
+// endpoints on a port that the Service does not expose.
+func MakeServiceMonitor(el *v1alpha1.EventListener, d *config.Defaults) *unstructured.Unstructured {
+	m := prometheusMonitor(el, d)
+	if m == nil || el.Spec.Admin != nil {
+		return nil
+	}
+	endpoint := map[string]interface{}{
+		"port": eventListenerServicePortName,
+		"path": "/metrics",
+	}
+	if m.Interval != "" {
+		endpoint["interval"] = m.Interval
+	}
+	switch {
+	case el.Spec.TLSSecret != "":
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = map[string]interface{}{
+			"ca":         map[string]interface{}{"secret": secretKeySelector(el.Spec.TLSSecret, corev1.ServiceAccountRootCAKey)},
+			"serverName": serverName(el, m),
+		}
+	case servingCert(el):
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = map[string]interface{}{
+			"ca":         map[string]interface{}{"configMap": map[string]interface{}{"name": serviceCAConfigMapName(el), "key": serviceCAKey}},
+			"serverName": serverName(el, m),
+		}
+	}
+	return makeMonitorObject(el, m, "ServiceMonitor", map[string]interface{}{
+		"selector":  matchLabels(el),
+		"endpoints": []interface{}{endpoint},
+	})
+}
+
+// MakePodMonitor returns the PodMonitor that scrapes the metrics of the
+// EventListener from the admin port of its pods, with the token and TLS
+// Secrets of the admin endpoints. It returns nil when the EventListener has
+// no monitor or no admin endpoints.
+func MakePodMonitor(el *v1alpha1.EventListener, d *config.Defaults) *unstructured.Unstructured {
+	m := prometheusMonitor(el, d)
+	admin := el.Spec.Admin
+	if m == nil || admin == nil {
+		return nil
+	}
+	endpoint := map[string]interface{}{
+		"port": adminPortName,
+		"path": "/metrics",
+	}
+	if m.Interval != "" {
+		endpoint["interval"] = m.Interval
+	}
+	if admin.TokenSecret != "" {
+		endpoint["bearerTokenSecret"] = secretKeySelector(admin.TokenSecret, "token")
+	}
+	if admin.TLSSecret != "" {
+		tlsConfig := map[string]interface{}{
+			"ca":         map[string]interface{}{"secret": secretKeySelector(admin.TLSSecret, corev1.ServiceAccountRootCAKey)},
+			"serverName": serverName(el, m),
+		}
+		if m.ClientCertSecret != "" {
+			tlsConfig["cert"] = map[string]interface{}{"secret": secretKeySelector(m.ClientCertSecret, corev1.TLSCertKey)}
+			tlsConfig["keySecret"] = secretKeySelector(m.ClientCertSecret, corev1.TLSPrivateKeyKey)
+		}
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = tlsConfig
+	}
+	return makeMonitorObject(el, m, "PodMonitor", map[string]interface{}{
+		"selector":            matchLabels(el),
+		"podMetricsEndpoints": []interface{}{endpoint},
+	})
+}
+
+// serverName returns the name that the certificate of the sink is verified
+// against, which defaults to the DNS name of the Service of the EventListener.
+func serverName(el *v1alpha1.EventListener, m *v1alpha1.PrometheusMonitor) string {
+	if m.ServerName != "" {
+		return m.ServerName
+	}
+	return fmt.Sprintf("%s.%s.svc", el.Status.Configuration.GeneratedResourceName, el.Namespace)
+}
+
+// makeMonitorObject returns a monitor with the labels of the PrometheusMonitor
+// in addition to the generated labels, so that a Prometheus selects it.
+func makeMonitorObject(el *v1alpha1.EventListener, m *v1alpha1.PrometheusMonitor, kind string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := makeGeneratedObject(el, monitoringAPIVersion, kind, spec)
+	u.SetLabels(mergeLabels(m.Labels, u.GetLabels()))
+	return u
+}
+
+func matchLabels(el *v1alpha1.EventListener) map[string]interface{} {
+	labels := map[string]interface{}{}
+	for k, v := range GenerateResourceLabels(el.Name) {
+		labels[k] = v
+	}
+	return map[string]interface{}{"matchLabels": labels}
+}
+
+func secretKeySelector(name, key string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "key": key}
+}
+
+// reconcilePrometheusMonitor generates the ServiceMonitor or the PodMonitor of
+// the EventListener, and deletes the other one.
+func (c *Reconciler) reconcilePrometheusMonitor(el *v1alpha1.EventListener, d *config.Defaults) error {
+	if err := c.reconcileGeneratedObject(el, serviceMonitorGVR, MakeServiceMonitor(el, d)); err != nil {
+		return err
+	}
+	return c.reconcileGeneratedObject(el, podMonitorGVR, MakePodMonitor(el, d))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/config"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMakeServiceMonitor(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.PrometheusMonitor = &v1alpha1.PrometheusMonitor{
+		Labels:   map[string]string{"release": "prometheus"},
+		Interval: "30s",
+	}
+	d := &config.Defaults{ELMetricsEnabled: true}
+
+	got := MakeServiceMonitor(el, d)
+	wantSpec := map[string]interface{}{
+		"selector": map[string]interface{}{"matchLabels": map[string]interface{}{
+			"app.kubernetes.io/managed-by": "EventListener",
+			"app.kubernetes.io/part-of":    "Triggers",
+			"eventlistener":                eventListenerName,
+		}},
+		"endpoints": []interface{}{map[string]interface{}{
+			"port":     "http-listener",
+			"path":     "/metrics",
+			"interval": "30s",
+		}},
+	}
+	if diff := cmp.Diff(wantSpec, got.Object["spec"]); diff != "" {
+		t.Errorf("MakeServiceMonitor() spec mismatch (-want +got): %s", diff)
+	}
+	if got.GetName() != generatedResourceName || got.GetKind() != "ServiceMonitor" || got.GetLabels()["release"] != "prometheus" {
+		t.Errorf("MakeServiceMonitor() = %s %s with labels %v, want ServiceMonitor %s with the release label", got.GetKind(), got.GetName(), got.GetLabels(), generatedResourceName)
+	}
+	if MakePodMonitor(el, d) != nil {
+		t.Error("MakePodMonitor() without admin endpoints should be nil")
+	}
+
+	d.ELMetricsEnabled = false
+	if MakeServiceMonitor(el, d) != nil {
+		t.Error("MakeServiceMonitor() with metrics disabled should be nil")
+	}
+}
+
+func TestMakeServiceMonitor_TLSSecret(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.PrometheusMonitor = &v1alpha1.PrometheusMonitor{}
+	el.Spec.TLSSecret = "listener-tls"
+
+	got := MakeServiceMonitor(el, &config.Defaults{ELMetricsEnabled: true})
+	wantEndpoints := []interface{}{map[string]interface{}{
+		"port":   "http-listener",
+		"path":   "/metrics",
+		"scheme": "https",
+		"tlsConfig": map[string]interface{}{
+			"ca":         map[string]interface{}{"secret": map[string]interface{}{"name": "listener-tls", "key": "ca.crt"}},
+			"serverName": generatedResourceName + "." + namespace + ".svc",
+		},
+	}}
+	if diff := cmp.Diff(wantEndpoints, got.Object["spec"].(map[string]interface{})["endpoints"]); diff != "" {
+		t.Errorf("MakeServiceMonitor() endpoints mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestMakePodMonitor(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.Admin = &v1alpha1.AdminEndpoints{
+		Port:              9090,
+		TokenSecret:       "admin-token",
+		TLSSecret:         "admin-tls",
+		RequireClientCert: true,
+	}
+	d := &config.Defaults{
+		ELMetricsEnabled:  true,
+		PrometheusMonitor: &v1alpha1.PrometheusMonitor{ClientCertSecret: "prometheus-client"},
+	}
+
+	got := MakePodMonitor(el, d)
+	wantEndpoints := []interface{}{map[string]interface{}{
+		"port":              "admin",
+		"path":              "/metrics",
+		"scheme":            "https",
+		"bearerTokenSecret": map[string]interface{}{"name": "admin-token", "key": "token"},
+		"tlsConfig": map[string]interface{}{
+			"ca":         map[string]interface{}{"secret": map[string]interface{}{"name": "admin-tls", "key": "ca.crt"}},
+			"serverName": generatedResourceName + "." + namespace + ".svc",
+			"cert":       map[string]interface{}{"secret": map[string]interface{}{"name": "prometheus-client", "key": "tls.crt"}},
+			"keySecret":  map[string]interface{}{"name": "prometheus-client", "key": "tls.key"},
+		},
+	}}
+	if diff := cmp.Diff(wantEndpoints, got.Object["spec"].(map[string]interface{})["podMetricsEndpoints"]); diff != "" {
+		t.Errorf("MakePodMonitor() endpoints mismatch (-want +got): %s", diff)
+	}
+	if got.GetKind() != "PodMonitor" {
+		t.Errorf("MakePodMonitor() kind = %s, want PodMonitor", got.GetKind())
+	}
+	if MakeServiceMonitor(el, d) != nil {
+		t.Error("MakeServiceMonitor() with admin endpoints should be nil")
+	}
+}
+
+func Test_reconcilePrometheusMonitor(t *testing.T) {
+	elMonitor := eventListener0.DeepCopy()
+	elMonitor.Spec.PrometheusMonitor = &v1alpha1.PrometheusMonitor{}
+	elAdmin := elMonitor.DeepCopy()
+	elAdmin.Spec.Admin = &v1alpha1.AdminEndpoints{Port: 9090}
+	d := &config.Defaults{ELMetricsEnabled: true}
+
+	tests := []struct {
+		name               string
+		el                 *v1alpha1.EventListener
+		existing           *v1alpha1.EventListener
+		wantServiceMonitor bool
+		wantPodMonitor     bool
+	}{{
+		name: "no monitor",
+		el:   eventListener0,
+	}, {
+		name:               "create ServiceMonitor",
+		el:                 elMonitor,
+		wantServiceMonitor: true,
+	}, {
+		name:           "replace ServiceMonitor with PodMonitor",
+		el:             elAdmin,
+		existing:       elMonitor,
+		wantPodMonitor: true,
+	}, {
+		name:     "delete when the monitor is removed",
+		el:       eventListener0,
+		existing: elAdmin,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{tc.el},
+			})
+			defer cancel()
+			r := testAssets.Controller.Reconciler.(*Reconciler)
+			if tc.existing != nil {
+				if err := r.reconcilePrometheusMonitor(tc.existing, d); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := r.reconcilePrometheusMonitor(tc.el, d); err != nil {
+				t.Fatalf("reconcilePrometheusMonitor() returned error: %s", err)
+			}
+
+			_, err := r.dynamicClientSet.Resource(serviceMonitorGVR).Namespace(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			if tc.wantServiceMonitor && err != nil {
+				t.Errorf("expected a ServiceMonitor, got %v", err)
+			} else if !tc.wantServiceMonitor && !errors.IsNotFound(err) {
+				t.Errorf("expected no ServiceMonitor, got %v", err)
+			}
+			_, err = r.dynamicClientSet.Resource(podMonitorGVR).Namespace(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			if tc.wantPodMonitor && err != nil {
+				t.Errorf("expected a PodMonitor, got %v", err)
+			} else if !tc.wantPodMonitor && !errors.IsNotFound(err) {
+				t.Errorf("expected no PodMonitor, got %v", err)
+			}
+		})
+	}
+}
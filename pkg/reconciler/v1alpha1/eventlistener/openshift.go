@@ -0,0 +1,208 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// servingCertAnnotation makes the service CA operator of OpenShift
+	// create a Secret with a certificate for the annotated Service
+	servingCertAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// injectCABundleAnnotation makes the service CA operator of OpenShift add
+	// the service CA to the annotated ConfigMap
+	injectCABundleAnnotation = "service.beta.openshift.io/inject-cabundle"
+	// serviceCAKey is the key of the service CA in the injected ConfigMap
+	serviceCAKey = "service-ca.crt"
+	// servingCertPath and serviceCAPath are where the serving certificate
+	// and the service CA are mounted
+	servingCertPath = "/etc/serving-cert"
+	serviceCAPath   = "/etc/service-ca"
+	// routeAPIVersion is the API version of the OpenShift Routes that are
+	// generated for EventListeners
+	routeAPIVersion = "route.openshift.io/v1"
+)
+
+var routeGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+
+// servingCert returns whether the sink of the EventListener serves HTTPS with
+// a certificate of the OpenShift service CA.
+func servingCert(el *v1alpha1.EventListener) bool {
+	return el.Spec.OpenShift != nil && el.Spec.OpenShift.ServingCert
+}
+
+func servingCertSecretName(el *v1alpha1.EventListener) string {
+	return el.Status.Configuration.GeneratedResourceName + "-serving-cert"
+}
+
+func serviceCAConfigMapName(el *v1alpha1.EventListener) string {
+	return el.Status.Configuration.GeneratedResourceName + "-service-ca"
+}
+
+// addOpenShiftConfig mounts the serving certificate and the service CA of the
+// EventListener into the sink and passes them to it.
+func addOpenShiftConfig(el *v1alpha1.EventListener, container *corev1.Container, volumes *[]corev1.Volume) {
+	o := el.Spec.OpenShift
+	if o.ServingCert {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "serving-cert",
+			MountPath: servingCertPath,
+			ReadOnly:  true,
+		})
+		*volumes = append(*volumes, corev1.Volume{
+			Name: "serving-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: servingCertSecretName(el)},
+			},
+		})
+		container.Args = append(container.Args,
+			"-tls-cert-file", servingCertPath+"/"+corev1.TLSCertKey,
+			"-tls-key-file", servingCertPath+"/"+corev1.TLSPrivateKeyKey)
+		container.LivenessProbe.HTTPGet.Scheme = corev1.URISchemeHTTPS
+	}
+	if o.TrustServiceCA {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "service-ca",
+			MountPath: serviceCAPath,
+			ReadOnly:  true,
+		})
+		*volumes = append(*volumes, corev1.Volume{
+			Name: "service-ca",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: serviceCAConfigMapName(el)},
+				},
+			},
+		})
+		container.Args = append(container.Args, "-interceptor-ca-file", serviceCAPath+"/"+serviceCAKey)
+	}
+}
+
+// MakeServiceCAConfigMap returns the ConfigMap that the service CA of
+// OpenShift is injected into, for the sink to verify interceptors and for the
+// ServiceMonitor to verify the sink. It returns nil when the EventListener
+// uses neither.
+func MakeServiceCAConfigMap(el *v1alpha1.EventListener) *corev1.ConfigMap {
+	o := el.Spec.OpenShift
+	if o == nil || (!o.ServingCert && !o.TrustServiceCA) {
+		return nil
+	}
+	meta := generateObjectMeta(el)
+	meta.Name = serviceCAConfigMapName(el)
+	meta.Annotations = map[string]string{injectCABundleAnnotation: "true"}
+	return &corev1.ConfigMap{ObjectMeta: meta}
+}
+
+// MakeRoute returns the Route that exposes the EventListener, re-encrypting
+// connections with the serving certificate of the sink, which the router
+// trusts. It returns nil when the EventListener has no Route.
+func MakeRoute(el *v1alpha1.EventListener) *unstructured.Unstructured {
+	o := el.Spec.OpenShift
+	if o == nil || o.Route == nil {
+		return nil
+	}
+	spec := map[string]interface{}{
+		"to": map[string]interface{}{
+			"kind": "Service",
+			"name": el.Status.Configuration.GeneratedResourceName,
+		},
+		"port": map[string]interface{}{
+			"targetPort": eventListenerServicePortName,
+		},
+		"tls": map[string]interface{}{
+			"termination":                   "reencrypt",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		},
+	}
+	if o.Route.Host != "" {
+		spec["host"] = o.Route.Host
+	}
+	return makeGeneratedObject(el, routeAPIVersion, "Route", spec)
+}
+
+// reconcileOpenShift generates the service CA ConfigMap and the Route of the
+// EventListener, and points its address at the host of the Route.
+func (c *Reconciler) reconcileOpenShift(el *v1alpha1.EventListener) error {
+	if err := c.reconcileServiceCAConfigMap(el); err != nil {
+		return err
+	}
+	route := MakeRoute(el)
+	if err := c.reconcileGeneratedObject(el, routeGVR, route); err != nil {
+		return err
+	}
+	if route == nil || el.Annotations[v1alpha1.ExternalHostnameAnnotation] != "" {
+		return nil
+	}
+	// The router assigns a host to Routes that do not set one
+	existing, err := c.dynamicClientSet.Resource(routeGVR).Namespace(el.Namespace).Get(route.GetName(), metav1.GetOptions{})
+	if err != nil {
+		c.Logger.Error(err)
+		return err
+	}
+	if host, _, _ := unstructured.NestedString(existing.Object, "spec", "host"); host != "" {
+		el.Status.SetExternalAddress(host)
+	}
+	return nil
+}
+
+// reconcileServiceCAConfigMap creates the service CA ConfigMap, or deletes it
+// when it is not needed. The CA bundle is injected by OpenShift, so only the
+// metadata of an existing ConfigMap is updated.
+func (c *Reconciler) reconcileServiceCAConfigMap(el *v1alpha1.EventListener) error {
+	configMaps := c.KubeClientSet.CoreV1().ConfigMaps(el.Namespace)
+	cm := MakeServiceCAConfigMap(el)
+	if cm == nil {
+		err := configMaps.Delete(serviceCAConfigMapName(el), &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			c.Logger.Errorf("Error deleting EventListener service CA ConfigMap: %s", err)
+			return err
+		}
+		return nil
+	}
+	existing, err := configMaps.Get(cm.Name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		updated := reconcileObjectMeta(&existing.ObjectMeta, cm.ObjectMeta)
+		if existing.Annotations[injectCABundleAnnotation] != "true" {
+			metav1.SetMetaDataAnnotation(&existing.ObjectMeta, injectCABundleAnnotation, "true")
+			updated = true
+		}
+		if updated {
+			if _, err := configMaps.Update(existing); err != nil {
+				c.Logger.Errorf("Error updating EventListener service CA ConfigMap: %s", err)
+				return err
+			}
+			c.Logger.Infof("Updated EventListener service CA ConfigMap %s in Namespace %s", existing.Name, el.Namespace)
+		}
+	case errors.IsNotFound(err):
+		if _, err := configMaps.Create(cm); err != nil {
+			c.Logger.Errorf("Error creating EventListener service CA ConfigMap: %s", err)
+			return err
+		}
+		c.Logger.Infof("Created EventListener service CA ConfigMap %s in Namespace %s", cm.Name, el.Namespace)
+	default:
+		c.Logger.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/config"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMakeRoute(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	if MakeRoute(el) != nil {
+		t.Error("MakeRoute() without a route should be nil")
+	}
+
+	el.Spec.OpenShift = &v1alpha1.OpenShift{ServingCert: true, Route: &v1alpha1.OpenShiftRoute{Host: "hooks.apps.example.com"}}
+	got := MakeRoute(el)
+	wantSpec := map[string]interface{}{
+		"host": "hooks.apps.example.com",
+		"to": map[string]interface{}{
+			"kind": "Service",
+			"name": generatedResourceName,
+		},
+		"port": map[string]interface{}{"targetPort": "http-listener"},
+		"tls": map[string]interface{}{
+			"termination":                   "reencrypt",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		},
+	}
+	if diff := cmp.Diff(wantSpec, got.Object["spec"]); diff != "" {
+		t.Errorf("MakeRoute() spec mismatch (-want +got): %s", diff)
+	}
+	if got.GetName() != generatedResourceName || got.GetKind() != "Route" {
+		t.Errorf("MakeRoute() = %s %s, want Route %s", got.GetKind(), got.GetName(), generatedResourceName)
+	}
+}
+
+func TestMakeDeployment_OpenShift(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.OpenShift = &v1alpha1.OpenShift{ServingCert: true, TrustServiceCA: true}
+
+	spec := MakeDeployment(el, SinkDefaults(context.Background())).Spec.Template.Spec
+	container := spec.Containers[0]
+	wantArgs := []string{
+		"-tls-cert-file", "/etc/serving-cert/tls.crt",
+		"-tls-key-file", "/etc/serving-cert/tls.key",
+		"-interceptor-ca-file", "/etc/service-ca/service-ca.crt",
+	}
+	if diff := cmp.Diff(wantArgs, container.Args[len(container.Args)-len(wantArgs):]); diff != "" {
+		t.Errorf("Args mismatch (-want +got): %s", diff)
+	}
+	if container.LivenessProbe.HTTPGet.Scheme != corev1.URISchemeHTTPS {
+		t.Errorf("LivenessProbe scheme = %s, want HTTPS", container.LivenessProbe.HTTPGet.Scheme)
+	}
+	wantVolumes := []corev1.Volume{{
+		Name: "serving-cert",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: generatedResourceName + "-serving-cert"},
+		},
+	}, {
+		Name: "service-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: generatedResourceName + "-service-ca"},
+			},
+		},
+	}}
+	if diff := cmp.Diff(wantVolumes, spec.Volumes[1:]); diff != "" {
+		t.Errorf("Volumes mismatch (-want +got): %s", diff)
+	}
+}
+
+func TestMakeServiceMonitor_ServingCert(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.PrometheusMonitor = &v1alpha1.PrometheusMonitor{}
+	el.Spec.OpenShift = &v1alpha1.OpenShift{ServingCert: true}
+
+	got := MakeServiceMonitor(el, &config.Defaults{ELMetricsEnabled: true})
+	wantEndpoints := []interface{}{map[string]interface{}{
+		"port":   "http-listener",
+		"path":   "/metrics",
+		"scheme": "https",
+		"tlsConfig": map[string]interface{}{
+			"ca":         map[string]interface{}{"configMap": map[string]interface{}{"name": generatedResourceName + "-service-ca", "key": "service-ca.crt"}},
+			"serverName": generatedResourceName + "." + namespace + ".svc",
+		},
+	}}
+	if diff := cmp.Diff(wantEndpoints, got.Object["spec"].(map[string]interface{})["endpoints"]); diff != "" {
+		t.Errorf("MakeServiceMonitor() endpoints mismatch (-want +got): %s", diff)
+	}
+}
+
+func Test_reconcileOpenShift(t *testing.T) {
+	elRoute := eventListener0.DeepCopy()
+	elRoute.Spec.OpenShift = &v1alpha1.OpenShift{ServingCert: true, Route: &v1alpha1.OpenShiftRoute{}}
+	elTrust := eventListener0.DeepCopy()
+	elTrust.Spec.OpenShift = &v1alpha1.OpenShift{TrustServiceCA: true}
+
+	// existing has the host that the router assigned
+	existing := MakeRoute(elRoute)
+	if err := unstructured.SetNestedField(existing.Object, "el.apps.example.com", "spec", "host"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		el            *v1alpha1.EventListener
+		existing      []*unstructured.Unstructured
+		wantConfigMap bool
+		wantRoute     bool
+		wantAddress   string
+	}{{
+		name: "not on OpenShift",
+		el:   eventListener0,
+	}, {
+		name:          "trust the service CA",
+		el:            elTrust,
+		wantConfigMap: true,
+	}, {
+		name:          "route with the host assigned by the router",
+		el:            elRoute,
+		existing:      []*unstructured.Unstructured{existing},
+		wantConfigMap: true,
+		wantRoute:     true,
+		wantAddress:   "https://el.apps.example.com",
+	}, {
+		name:     "delete when the route is removed",
+		el:       eventListener0,
+		existing: []*unstructured.Unstructured{existing},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			el := tc.el.DeepCopy()
+			testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+				Namespaces:     []*corev1.Namespace{namespaceResource},
+				EventListeners: []*v1alpha1.EventListener{el},
+			})
+			defer cancel()
+			r := testAssets.Controller.Reconciler.(*Reconciler)
+			for _, obj := range tc.existing {
+				if _, err := r.dynamicClientSet.Resource(routeGVR).Namespace(namespace).Create(obj, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := r.reconcileOpenShift(el); err != nil {
+				t.Fatalf("reconcileOpenShift() returned error: %s", err)
+			}
+
+			cm, err := testAssets.Clients.Kube.CoreV1().ConfigMaps(namespace).Get(generatedResourceName+"-service-ca", metav1.GetOptions{})
+			switch {
+			case !tc.wantConfigMap:
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected no service CA ConfigMap, got %v, %v", cm, err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case cm.Annotations["service.beta.openshift.io/inject-cabundle"] != "true":
+				t.Errorf("service CA ConfigMap annotations = %v, want the inject-cabundle annotation", cm.Annotations)
+			}
+			_, err = r.dynamicClientSet.Resource(routeGVR).Namespace(namespace).Get(generatedResourceName, metav1.GetOptions{})
+			if tc.wantRoute && err != nil {
+				t.Errorf("expected a Route, got %v", err)
+			} else if !tc.wantRoute && !errors.IsNotFound(err) {
+				t.Errorf("expected no Route, got %v", err)
+			}
+			// Without a Route the address is left alone
+			want := tc.wantAddress
+			if want == "" {
+				want = tc.el.Status.Address.URL.String()
+			}
+			if got := el.Status.Address.URL.String(); got != want {
+				t.Errorf("address = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func Test_reconcileService_ServingCert(t *testing.T) {
+	el := eventListener0.DeepCopy()
+	el.Spec.OpenShift = &v1alpha1.OpenShift{ServingCert: true}
+	testAssets, cancel := getEventListenerTestAssets(t, test.Resources{
+		Namespaces:     []*corev1.Namespace{namespaceResource},
+		EventListeners: []*v1alpha1.EventListener{el},
+	})
+	defer cancel()
+	r := testAssets.Controller.Reconciler.(*Reconciler)
+	d := SinkDefaults(context.Background())
+	services := testAssets.Clients.Kube.CoreV1().Services(namespace)
+
+	if err := r.reconcileService(el, d); err != nil {
+		t.Fatal(err)
+	}
+	svc, err := services.Get(generatedResourceName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := svc.Annotations["service.beta.openshift.io/serving-cert-secret-name"]; got != generatedResourceName+"-serving-cert" {
+		t.Errorf("serving cert annotation = %q, want %q", got, generatedResourceName+"-serving-cert")
+	}
+	if el.Status.Address.URL.Scheme != "https" {
+		t.Errorf("address = %s, want an https address", el.Status.Address.URL)
+	}
+
+	// Turning the serving certificate off removes the annotation
+	el.Spec.OpenShift = nil
+	if err := r.reconcileService(el, d); err != nil {
+		t.Fatal(err)
+	}
+	if svc, err = services.Get(generatedResourceName, metav1.GetOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := svc.Annotations["service.beta.openshift.io/serving-cert-secret-name"]; ok {
+		t.Errorf("serving cert annotation was not removed: %v", svc.Annotations)
+	}
+	if el.Status.Address.URL.Scheme != "http" {
+		t.Errorf("address = %s, want an http address", el.Status.Address.URL)
+	}
+}
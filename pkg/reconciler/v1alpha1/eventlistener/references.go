@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+)
+
+// enqueueReferencing returns an event handler that enqueues the EventListeners
+// that reference the changed object, so that their TriggersResolved condition
+// follows the objects that their Triggers use.
+func (c *Reconciler) enqueueReferencing(impl *controller.Impl, references func(*v1alpha1.EventListener, metav1.Object) bool) func(interface{}) {
+	return func(o interface{}) {
+		obj, err := kmeta.DeletionHandlingAccessor(o)
+		if err != nil {
+			c.Logger.Errorf("Error reading the changed object: %s", err)
+			return
+		}
+		els, err := c.eventListenerLister.List(labels.Everything())
+		if err != nil {
+			c.Logger.Errorf("Error listing EventListeners: %s", err)
+			return
+		}
+		for _, el := range els {
+			if references(el, obj) {
+				impl.Enqueue(el)
+			}
+		}
+	}
+}
+
+// referencesTriggerTemplate reports whether a Trigger of the EventListener uses
+// the TriggerTemplate.
+func referencesTriggerTemplate(el *v1alpha1.EventListener, obj metav1.Object) bool {
+	if obj.GetNamespace() != el.Namespace {
+		return false
+	}
+	for _, t := range el.Spec.Triggers {
+		if t.Template.Name == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// referencesBinding returns a function that reports whether a Trigger of the
+// EventListener uses the binding of the kind.
+func referencesBinding(kind v1alpha1.TriggerBindingKind) func(*v1alpha1.EventListener, metav1.Object) bool {
+	return func(el *v1alpha1.EventListener, obj metav1.Object) bool {
+		if kind == v1alpha1.NamespacedTriggerBindingKind && obj.GetNamespace() != el.Namespace {
+			return false
+		}
+		for _, t := range el.Spec.Triggers {
+			for _, b := range t.Bindings {
+				bindingKind := b.Kind
+				if bindingKind == "" {
+					bindingKind = v1alpha1.NamespacedTriggerBindingKind
+				}
+				if bindingKind == kind && b.Name == obj.GetName() {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// referencesInterceptor returns a function that reports whether an
+// interceptor of a Trigger of the EventListener, or of an InterceptorChain that
+// it references, uses the object.
+func (c *Reconciler) referencesInterceptor(uses func(ns string, i *v1alpha1.EventInterceptor, obj metav1.Object) bool) func(*v1alpha1.EventListener, metav1.Object) bool {
+	return func(el *v1alpha1.EventListener, obj metav1.Object) bool {
+		for _, t := range el.Spec.Triggers {
+			if c.interceptorsReference(el.Namespace, t.Interceptors, uses, obj) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c *Reconciler) interceptorsReference(ns string, interceptors []*v1alpha1.EventInterceptor, uses func(string, *v1alpha1.EventInterceptor, metav1.Object) bool, obj metav1.Object) bool {
+	for _, i := range interceptors {
+		if uses(ns, i, obj) {
+			return true
+		}
+		if i.Chain != nil {
+			chain, err := c.interceptorChainLister.InterceptorChains(ns).Get(i.Chain.Name)
+			if err == nil && c.interceptorsReference(ns, chain.Spec.Interceptors, uses, obj) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usesSecret reports whether the interceptor validates events with the Secret.
+func usesSecret(ns string, i *v1alpha1.EventInterceptor, obj metav1.Object) bool {
+	for _, sr := range interceptorSecretRefs(i) {
+		secretNamespace := sr.Namespace
+		if secretNamespace == "" {
+			secretNamespace = ns
+		}
+		if secretNamespace == obj.GetNamespace() && sr.SecretName == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+// usesService reports whether the interceptor is a webhook that calls the
+// Service.
+func usesService(ns string, i *v1alpha1.EventInterceptor, obj metav1.Object) bool {
+	if i.Webhook == nil || i.Webhook.ObjectRef == nil {
+		return false
+	}
+	svcNamespace := i.Webhook.ObjectRef.Namespace
+	if svcNamespace == "" {
+		svcNamespace = ns
+	}
+	return svcNamespace == obj.GetNamespace() && i.Webhook.ObjectRef.Name == obj.GetName()
+}
+
+// usesConfigMap reports whether the interceptor reads its OPA policy from the
+// ConfigMap.
+func usesConfigMap(ns string, i *v1alpha1.EventInterceptor, obj metav1.Object) bool {
+	return i.OPA != nil && i.OPA.PolicyRef != nil && ns == obj.GetNamespace() && i.OPA.PolicyRef.Name == obj.GetName()
+}
+
+// usesInterceptorChain reports whether the interceptor runs the
+// InterceptorChain.
+func usesInterceptorChain(ns string, i *v1alpha1.EventInterceptor, obj metav1.Object) bool {
+	return i.Chain != nil && ns == obj.GetNamespace() && i.Chain.Name == obj.GetName()
+}
+
+// usesClusterInterceptor reports whether the interceptor calls the
+// ClusterInterceptor.
+func usesClusterInterceptor(_ string, i *v1alpha1.EventInterceptor, obj metav1.Object) bool {
+	return i.Ref != nil && i.Ref.Name == obj.GetName()
+}
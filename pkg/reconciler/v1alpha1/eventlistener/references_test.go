@@ -0,0 +1,173 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_enqueueReferencing(t *testing.T) {
+	githubInterceptor := &v1alpha1.EventInterceptor{
+		GitHub: &v1alpha1.GitHubInterceptor{
+			SecretRef:         &v1alpha1.SecretRef{SecretName: "secret", SecretKey: "token"},
+			PreviousSecretRef: &v1alpha1.SecretRef{SecretName: "old-secret", SecretKey: "token"},
+		},
+	}
+	opaInterceptor := &v1alpha1.EventInterceptor{
+		OPA: &v1alpha1.OPAInterceptor{
+			PolicyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "policy.rego",
+			},
+		},
+	}
+	webhookInterceptor := &v1alpha1.EventInterceptor{
+		Webhook: &v1alpha1.WebhookInterceptor{
+			ObjectRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Name: "interceptor"},
+		},
+	}
+	resources := test.Resources{
+		EventListeners: []*v1alpha1.EventListener{
+			bldr.EventListener("github", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("tb", "", "v1alpha1"),
+					bldr.EventListenerClusterInterceptor("cel"),
+					func(trigger *v1alpha1.EventListenerTrigger) {
+						trigger.Interceptors = append(trigger.Interceptors, githubInterceptor, opaInterceptor)
+					}))),
+			bldr.EventListener("chain", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("other-tt", "v1alpha1",
+					bldr.EventListenerTriggerBinding("ctb", string(v1alpha1.ClusterTriggerBindingKind), "v1alpha1"),
+					bldr.EventListenerInterceptorChain("checks")))),
+		},
+		InterceptorChains: []*v1alpha1.InterceptorChain{{
+			ObjectMeta: metav1.ObjectMeta{Name: "checks", Namespace: namespace},
+			Spec:       v1alpha1.InterceptorChainSpec{Interceptors: []*v1alpha1.EventInterceptor{githubInterceptor, webhookInterceptor}},
+		}},
+	}
+	tests := []struct {
+		name string
+		obj  interface{}
+		kind string
+		want []string
+	}{{
+		name: "secret",
+		obj:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: namespace}},
+		kind: "Secret",
+		want: []string{namespace + "/chain", namespace + "/github"},
+	}, {
+		name: "previous secret",
+		obj:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old-secret", Namespace: namespace}},
+		kind: "Secret",
+		want: []string{namespace + "/chain", namespace + "/github"},
+	}, {
+		name: "deleted secret",
+		obj: cache.DeletedFinalStateUnknown{
+			Key: namespace + "/secret",
+			Obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: namespace}},
+		},
+		kind: "Secret",
+		want: []string{namespace + "/chain", namespace + "/github"},
+	}, {
+		name: "secret in another namespace",
+		obj:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "other"}},
+		kind: "Secret",
+	}, {
+		name: "webhook Service",
+		obj:  &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "interceptor", Namespace: namespace}},
+		kind: "Service",
+		want: []string{namespace + "/chain"},
+	}, {
+		name: "OPA policy ConfigMap",
+		obj:  &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: namespace}},
+		kind: "ConfigMap",
+		want: []string{namespace + "/github"},
+	}, {
+		name: "InterceptorChain",
+		obj:  &v1alpha1.InterceptorChain{ObjectMeta: metav1.ObjectMeta{Name: "checks", Namespace: namespace}},
+		kind: "InterceptorChain",
+		want: []string{namespace + "/chain"},
+	}, {
+		name: "ClusterInterceptor",
+		obj:  &v1alpha1.ClusterInterceptor{ObjectMeta: metav1.ObjectMeta{Name: "cel"}},
+		kind: "ClusterInterceptor",
+		want: []string{namespace + "/github"},
+	}, {
+		name: "TriggerBinding",
+		obj:  &v1alpha1.TriggerBinding{ObjectMeta: metav1.ObjectMeta{Name: "tb", Namespace: namespace}},
+		kind: "TriggerBinding",
+		want: []string{namespace + "/github"},
+	}, {
+		name: "ClusterTriggerBinding",
+		obj:  &v1alpha1.ClusterTriggerBinding{ObjectMeta: metav1.ObjectMeta{Name: "ctb"}},
+		kind: "ClusterTriggerBinding",
+		want: []string{namespace + "/chain"},
+	}, {
+		name: "TriggerBinding with the name of a ClusterTriggerBinding",
+		obj:  &v1alpha1.TriggerBinding{ObjectMeta: metav1.ObjectMeta{Name: "ctb", Namespace: namespace}},
+		kind: "TriggerBinding",
+	}, {
+		name: "TriggerTemplate",
+		obj:  &v1alpha1.TriggerTemplate{ObjectMeta: metav1.ObjectMeta{Name: "other-tt", Namespace: namespace}},
+		kind: "TriggerTemplate",
+		want: []string{namespace + "/chain"},
+	}, {
+		name: "unreferenced TriggerTemplate",
+		obj:  &v1alpha1.TriggerTemplate{ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: namespace}},
+		kind: "TriggerTemplate",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getEventListenerTestAssets(t, resources)
+			defer cancel()
+			impl := testAssets.Controller
+			c := impl.Reconciler.(*Reconciler)
+			references := map[string]func(*v1alpha1.EventListener, metav1.Object) bool{
+				"Secret":                c.referencesInterceptor(usesSecret),
+				"Service":               c.referencesInterceptor(usesService),
+				"ConfigMap":             c.referencesInterceptor(usesConfigMap),
+				"InterceptorChain":      c.referencesInterceptor(usesInterceptorChain),
+				"ClusterInterceptor":    c.referencesInterceptor(usesClusterInterceptor),
+				"TriggerBinding":        referencesBinding(v1alpha1.NamespacedTriggerBindingKind),
+				"ClusterTriggerBinding": referencesBinding(v1alpha1.ClusterTriggerBindingKind),
+				"TriggerTemplate":       referencesTriggerTemplate,
+			}[tc.kind]
+
+			c.enqueueReferencing(impl, references)(tc.obj)
+			var got []string
+			for impl.WorkQueue.Len() > 0 {
+				key, _ := impl.WorkQueue.Get()
+				got = append(got, key.(types.NamespacedName).String())
+				impl.WorkQueue.Done(key)
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Enqueued EventListeners: -want +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersclient "github.com/tektoncd/triggers/pkg/client/injection/client"
+	eventlistenerinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/eventlistener"
+	repositoryinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/repository"
+	"github.com/tektoncd/triggers/pkg/reconciler"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	resyncPeriod = 10 * time.Hour
+)
+
+// NewController creates a new instance of a Repository controller.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := logging.FromContext(ctx)
+	kubeclientset := kubeclient.Get(ctx)
+	triggersclientset := triggersclient.Get(ctx)
+	repositoryInformer := repositoryinformer.Get(ctx)
+	eventListenerInformer := eventlistenerinformer.Get(ctx)
+
+	opt := reconciler.Options{
+		KubeClientSet:     kubeclientset,
+		TriggersClientSet: triggersclientset,
+		ConfigMapWatcher:  cmw,
+		Logger:            logger,
+		ResyncPeriod:      resyncPeriod,
+	}
+
+	c := &Reconciler{
+		Base:                reconciler.NewBase(opt, repositoryAgentName),
+		repositoryLister:    repositoryInformer.Lister(),
+		eventListenerLister: eventListenerInformer.Lister(),
+		newHookClient:       newHookClient,
+	}
+	impl := controller.NewImpl(c, c.Logger, repositoryControllerName)
+	c.enqueueAfter = impl.EnqueueAfter
+
+	c.Logger.Info("Setting up event handlers")
+	repositoryInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    impl.Enqueue,
+		UpdateFunc: controller.PassNew(impl.Enqueue),
+	})
+
+	// The address of an EventListener is the default target of the webhooks
+	// of the Repositories that reference it.
+	eventListenerInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		el, ok := obj.(*v1alpha1.EventListener)
+		if !ok {
+			return
+		}
+		repos, err := c.repositoryLister.Repositories(el.Namespace).List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, r := range repos {
+			if r.Spec.EventListener == el.Name {
+				impl.Enqueue(r)
+			}
+		}
+	}))
+
+	return impl
+}
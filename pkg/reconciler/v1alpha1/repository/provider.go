@@ -0,0 +1,297 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// hook is a webhook of a repository as the provider reports it.
+type hook struct {
+	ID     string
+	URL    string
+	Events []string
+}
+
+// hookClient manages the webhooks of a single repository at its provider.
+type hookClient interface {
+	listHooks(ctx context.Context) ([]hook, error)
+	createHook(ctx context.Context, h hook, secret string) (string, error)
+	editHook(ctx context.Context, id string, h hook, secret string) error
+	deleteHook(ctx context.Context, id string) error
+}
+
+// providerTimeout bounds every call to the API of a provider.
+const providerTimeout = 30 * time.Second
+
+// newHookClient returns the hookClient for the repository of r, which
+// authenticates to the provider with token.
+func newHookClient(r *v1alpha1.Repository, token string) (hookClient, error) {
+	u, err := url.Parse(r.Spec.URL)
+	if err != nil {
+		return nil, err
+	}
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	apiURL := r.Spec.APIURL
+	switch r.Spec.Provider {
+	case v1alpha1.GitHubProvider:
+		if apiURL == "" {
+			apiURL = fmt.Sprintf("%s://%s/api/v3", u.Scheme, u.Host)
+			if u.Host == "github.com" {
+				apiURL = "https://api.github.com"
+			}
+		}
+		base, err := url.Parse(strings.TrimSuffix(apiURL, "/") + "/")
+		if err != nil {
+			return nil, err
+		}
+		client := github.NewClient(&http.Client{
+			Timeout:   providerTimeout,
+			Transport: &tokenTransport{header: "Authorization", value: "token " + token},
+		})
+		client.BaseURL = base
+		segments := strings.Split(path, "/")
+		return &githubHooks{client: client, owner: segments[0], repo: segments[1]}, nil
+	case v1alpha1.GitLabProvider:
+		if apiURL == "" {
+			apiURL = fmt.Sprintf("%s://%s/api/v4", u.Scheme, u.Host)
+		}
+		return &gitlabHooks{
+			client: &http.Client{
+				Timeout:   providerTimeout,
+				Transport: &tokenTransport{header: "PRIVATE-TOKEN", value: token},
+			},
+			hooksURL: fmt.Sprintf("%s/projects/%s/hooks", strings.TrimSuffix(apiURL, "/"), url.PathEscape(path)),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", r.Spec.Provider)
+	}
+}
+
+// tokenTransport authenticates requests to a provider with an API token.
+type tokenTransport struct {
+	header string
+	value  string
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, t.value)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// githubHooks manages webhooks with the GitHub REST API.
+type githubHooks struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+func (g *githubHooks) listHooks(ctx context.Context) ([]hook, error) {
+	hs, _, err := g.client.Repositories.ListHooks(ctx, g.owner, g.repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+	hooks := make([]hook, 0, len(hs))
+	for _, h := range hs {
+		u, _ := h.Config["url"].(string)
+		hooks = append(hooks, hook{ID: strconv.FormatInt(h.GetID(), 10), URL: u, Events: h.Events})
+	}
+	return hooks, nil
+}
+
+func (g *githubHooks) createHook(ctx context.Context, h hook, secret string) (string, error) {
+	created, _, err := g.client.Repositories.CreateHook(ctx, g.owner, g.repo, githubHook(h, secret))
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(created.GetID(), 10), nil
+}
+
+func (g *githubHooks) editHook(ctx context.Context, id string, h hook, secret string) error {
+	hookID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, _, err = g.client.Repositories.EditHook(ctx, g.owner, g.repo, hookID, githubHook(h, secret))
+	return err
+}
+
+func (g *githubHooks) deleteHook(ctx context.Context, id string) error {
+	hookID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	resp, err := g.client.Repositories.DeleteHook(ctx, g.owner, g.repo, hookID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func githubHook(h hook, secret string) *github.Hook {
+	config := map[string]interface{}{
+		"url":          h.URL,
+		"content_type": "json",
+		"insecure_ssl": "0",
+	}
+	if secret != "" {
+		config["secret"] = secret
+	}
+	return &github.Hook{
+		Config: config,
+		Events: h.Events,
+		Active: github.Bool(true),
+	}
+}
+
+// gitlabHooks manages project hooks with the GitLab REST API.
+type gitlabHooks struct {
+	client   *http.Client
+	hooksURL string
+}
+
+func (g *gitlabHooks) listHooks(ctx context.Context) ([]hook, error) {
+	var hs []map[string]interface{}
+	if err := g.do(ctx, http.MethodGet, g.hooksURL+"?per_page=100", nil, &hs); err != nil {
+		return nil, err
+	}
+	hooks := make([]hook, 0, len(hs))
+	for _, h := range hs {
+		u, _ := h["url"].(string)
+		var events []string
+		for _, e := range v1alpha1.GitLabWebhookEvents {
+			if enabled, _ := h[e+"_events"].(bool); enabled {
+				events = append(events, e)
+			}
+		}
+		hooks = append(hooks, hook{ID: fmt.Sprint(h["id"]), URL: u, Events: events})
+	}
+	return hooks, nil
+}
+
+func (g *gitlabHooks) createHook(ctx context.Context, h hook, secret string) (string, error) {
+	var created map[string]interface{}
+	if err := g.do(ctx, http.MethodPost, g.hooksURL, gitlabHook(h, secret), &created); err != nil {
+		return "", err
+	}
+	return fmt.Sprint(created["id"]), nil
+}
+
+func (g *gitlabHooks) editHook(ctx context.Context, id string, h hook, secret string) error {
+	return g.do(ctx, http.MethodPut, g.hooksURL+"/"+id, gitlabHook(h, secret), nil)
+}
+
+func (g *gitlabHooks) deleteHook(ctx context.Context, id string) error {
+	err := g.do(ctx, http.MethodDelete, g.hooksURL+"/"+id, nil, nil)
+	if e, ok := err.(*providerError); ok && e.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// gitlabHook sets every event flag, since GitLab enables push events unless
+// they are explicitly disabled.
+func gitlabHook(h hook, secret string) map[string]interface{} {
+	body := map[string]interface{}{
+		"url":                     h.URL,
+		"enable_ssl_verification": true,
+	}
+	if secret != "" {
+		body["token"] = secret
+	}
+	for _, e := range v1alpha1.GitLabWebhookEvents {
+		body[e+"_events"] = false
+	}
+	for _, e := range h.Events {
+		body[e+"_events"] = true
+	}
+	return body
+}
+
+// providerError is returned for responses of a provider that are not
+// successful.
+type providerError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *providerError) Error() string {
+	return fmt.Sprintf("provider responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+func (g *gitlabHooks) do(ctx context.Context, method, u string, body, into interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u, payload)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &providerError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	if into == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
+
+// sameEvents reports whether a and b hold the same events in any order.
+func sameEvents(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
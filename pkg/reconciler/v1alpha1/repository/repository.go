@@ -0,0 +1,280 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	listers "github.com/tektoncd/triggers/pkg/client/listers/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/reconciler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+)
+
+const (
+	// repositoryAgentName defines logging agent name for Repository Controller
+	repositoryAgentName = "repository-controller"
+	// repositoryControllerName defines name for Repository Controller
+	repositoryControllerName = "Repository"
+	// repositoryFinalizer is set on every Repository whose webhook may be
+	// registered, so that the webhook is removed before the Repository is
+	// deleted
+	repositoryFinalizer = v1alpha1.GroupName + "/webhook"
+)
+
+var (
+	// SyncPeriod defines how often the webhook of every Repository is
+	// compared with the provider, so that webhooks that were changed or
+	// removed at the provider are corrected
+	SyncPeriod = flag.Duration("repository-sync-period", time.Hour,
+		"How often the webhooks of Repositories are synced with their provider.")
+)
+
+// Reconciler implements controller.Reconciler for Repository resources. It
+// registers a webhook at the provider of every Repository that sends events
+// to an EventListener, and keeps the webhook in sync with the Repository.
+type Reconciler struct {
+	*reconciler.Base
+	// listers index properties about resources
+	repositoryLister    listers.RepositoryLister
+	eventListenerLister listers.EventListenerLister
+	// newHookClient returns the client for the API of the provider of a
+	// Repository
+	newHookClient func(r *v1alpha1.Repository, token string) (hookClient, error)
+	// enqueueAfter schedules the next sync of a Repository
+	enqueueAfter func(obj interface{}, after time.Duration)
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile compares the actual state with the desired, and attempts to
+// converge the two.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	c.Logger.Infof("repository-reconcile %s", key)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	original, err := c.repositoryLister.Repositories(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		c.Logger.Infof("Repository %q in work queue no longer exists", key)
+		return nil
+	} else if err != nil {
+		c.Logger.Errorf("Error retrieving Repository %q: %s", name, err)
+		return err
+	}
+
+	// Don't modify the informer's copy
+	r := original.DeepCopy()
+	if r.DeletionTimestamp != nil {
+		return c.finalize(ctx, r)
+	}
+	reconcileErr := c.reconcile(ctx, r)
+	if !equality.Semantic.DeepEqual(original.Status, r.Status) {
+		if _, err := c.TriggersClientSet.TriggersV1alpha1().Repositories(namespace).UpdateStatus(r); err != nil {
+			c.Logger.Warn("Failed to update Repository status", err.Error())
+			return err
+		}
+	}
+	if reconcileErr == nil && c.enqueueAfter != nil {
+		c.enqueueAfter(r, *SyncPeriod)
+	}
+	return reconcileErr
+}
+
+func (c *Reconciler) reconcile(ctx context.Context, r *v1alpha1.Repository) error {
+	r.SetDefaults(v1alpha1.WithUpgradeViaDefaulting(ctx))
+	if err := c.addFinalizer(r); err != nil {
+		return err
+	}
+
+	webhookURL, err := c.webhookURL(r)
+	if err != nil {
+		// The Repository is requeued once the EventListener is addressable
+		r.Status.MarkWebhookFailed("EventListenerNotAddressable", "%v", err)
+		return nil
+	}
+	token, err := c.secretValue(r.Namespace, &r.Spec.TokenRef)
+	if err != nil {
+		r.Status.MarkWebhookFailed("TokenNotFound", "%v", err)
+		return err
+	}
+	var secret string
+	if r.Spec.SecretRef != nil {
+		if secret, err = c.secretValue(r.Namespace, r.Spec.SecretRef); err != nil {
+			r.Status.MarkWebhookFailed("SecretNotFound", "%v", err)
+			return err
+		}
+	}
+	hc, err := c.newHookClient(r, token)
+	if err != nil {
+		r.Status.MarkWebhookFailed("ProviderError", "%v", err)
+		return err
+	}
+
+	want := hook{URL: webhookURL, Events: r.Spec.Events}
+	configHash := hookConfigHash(want, secret)
+	existing, err := hc.listHooks(ctx)
+	if err != nil {
+		r.Status.MarkWebhookFailed("ProviderError", "failed to list webhooks: %v", err)
+		return err
+	}
+	// Adopt a webhook that sends events to the same address, so that a
+	// Repository whose status was lost does not register a second webhook
+	var current *hook
+	for i := range existing {
+		if (r.Status.WebhookID != "" && existing[i].ID == r.Status.WebhookID) || existing[i].URL == webhookURL {
+			current = &existing[i]
+			break
+		}
+	}
+
+	switch {
+	case current == nil:
+		id, err := hc.createHook(ctx, want, secret)
+		if err != nil {
+			r.Status.MarkWebhookFailed("ProviderError", "failed to create webhook: %v", err)
+			return err
+		}
+		r.Status.WebhookID = id
+		c.Logger.Infof("Created webhook %s for Repository %s/%s", id, r.Namespace, r.Name)
+	case current.URL != want.URL || !sameEvents(current.Events, want.Events) || r.Status.ConfigHash != configHash:
+		if err := hc.editHook(ctx, current.ID, want, secret); err != nil {
+			r.Status.MarkWebhookFailed("ProviderError", "failed to update webhook %s: %v", current.ID, err)
+			return err
+		}
+		r.Status.WebhookID = current.ID
+		c.Logger.Infof("Updated webhook %s for Repository %s/%s", current.ID, r.Namespace, r.Name)
+	default:
+		r.Status.WebhookID = current.ID
+	}
+	r.Status.WebhookURL = webhookURL
+	r.Status.ConfigHash = configHash
+	r.Status.MarkWebhookRegistered()
+	return nil
+}
+
+// finalize removes the webhook of a Repository that is being deleted, and
+// then releases the Repository.
+func (c *Reconciler) finalize(ctx context.Context, r *v1alpha1.Repository) error {
+	if !hasFinalizer(r) {
+		return nil
+	}
+	if r.Status.WebhookID != "" {
+		token, err := c.secretValue(r.Namespace, &r.Spec.TokenRef)
+		switch {
+		case errors.IsNotFound(err):
+			// Without a token the webhook can never be removed
+			c.Logger.Warnf("Leaving webhook %s of Repository %s/%s registered: %s", r.Status.WebhookID, r.Namespace, r.Name, err)
+		case err != nil:
+			return err
+		default:
+			hc, err := c.newHookClient(r, token)
+			if err != nil {
+				return err
+			}
+			if err := hc.deleteHook(ctx, r.Status.WebhookID); err != nil {
+				c.Logger.Errorf("Error deleting webhook %s of Repository %s/%s: %s", r.Status.WebhookID, r.Namespace, r.Name, err)
+				return err
+			}
+			c.Logger.Infof("Deleted webhook %s of Repository %s/%s", r.Status.WebhookID, r.Namespace, r.Name)
+		}
+	}
+	finalizers := make([]string, 0, len(r.Finalizers))
+	for _, f := range r.Finalizers {
+		if f != repositoryFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	r.Finalizers = finalizers
+	_, err := c.TriggersClientSet.TriggersV1alpha1().Repositories(r.Namespace).Update(r)
+	return err
+}
+
+func (c *Reconciler) addFinalizer(r *v1alpha1.Repository) error {
+	if hasFinalizer(r) {
+		return nil
+	}
+	r.Finalizers = append(r.Finalizers, repositoryFinalizer)
+	updated, err := c.TriggersClientSet.TriggersV1alpha1().Repositories(r.Namespace).Update(r)
+	if err != nil {
+		c.Logger.Errorf("Error adding finalizer to Repository %s/%s: %s", r.Namespace, r.Name, err)
+		return err
+	}
+	r.ObjectMeta = updated.ObjectMeta
+	return nil
+}
+
+func hasFinalizer(r *v1alpha1.Repository) bool {
+	for _, f := range r.Finalizers {
+		if f == repositoryFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookURL returns the address that the webhook of r sends events to.
+func (c *Reconciler) webhookURL(r *v1alpha1.Repository) (string, error) {
+	if r.Spec.WebhookURL != "" {
+		return r.Spec.WebhookURL, nil
+	}
+	el, err := c.eventListenerLister.EventListeners(r.Namespace).Get(r.Spec.EventListener)
+	if err != nil {
+		return "", err
+	}
+	if el.Status.Address == nil || el.Status.Address.URL == nil {
+		return "", fmt.Errorf("EventListener %s/%s has no address", r.Namespace, r.Spec.EventListener)
+	}
+	return el.Status.Address.URL.String(), nil
+}
+
+func (c *Reconciler) secretValue(namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	secret, err := c.KubeClientSet.CoreV1().Secrets(namespace).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in Secret %s/%s", ref.Key, namespace, ref.Name)
+	}
+	return string(value), nil
+}
+
+// hookConfigHash hashes the configuration of a webhook, including its
+// secret, which providers do not return.
+func hookConfigHash(h hook, secret string) string {
+	events := append([]string(nil), h.Events...)
+	sort.Strings(events)
+	sum := sha256.Sum256([]byte(strings.Join([]string{h.URL, strings.Join(events, ","), secret}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
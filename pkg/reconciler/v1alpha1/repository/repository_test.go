@@ -0,0 +1,309 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/system"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	repositoryName = "triggers"
+	namespace      = "foo"
+	listenerURL    = "http://el-github-listener.foo.svc.cluster.local:8080"
+)
+
+// fakeGitHub serves the hooks endpoints of the GitHub REST API for
+// tektoncd/triggers.
+type fakeGitHub struct {
+	mu     sync.Mutex
+	nextID int64
+	hooks  map[int64]map[string]interface{}
+	calls  []string
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *httptest.Server) {
+	t.Helper()
+	f := &fakeGitHub{nextID: 1, hooks: map[int64]map[string]interface{}{}}
+	srv := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeGitHub) addHook(url string, events ...string) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.nextID
+	f.nextID++
+	f.hooks[id] = map[string]interface{}{
+		"id":     id,
+		"events": events,
+		"config": map[string]interface{}{"url": url, "content_type": "json"},
+	}
+	return id
+}
+
+func (f *fakeGitHub) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, r.Method)
+	if r.Header.Get("Authorization") != "token s3cr3t" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/repos/tektoncd/triggers/hooks")
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		hooks := []map[string]interface{}{}
+		for _, h := range f.hooks {
+			hooks = append(hooks, h)
+		}
+		_ = json.NewEncoder(w).Encode(hooks)
+	case path == "" && r.Method == http.MethodPost:
+		body["id"] = f.nextID
+		f.hooks[f.nextID] = body
+		f.nextID++
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(body)
+	default:
+		id, _ := strconv.ParseInt(strings.TrimPrefix(path, "/"), 10, 64)
+		if _, ok := f.hooks[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			body["id"] = id
+			f.hooks[id] = body
+			_ = json.NewEncoder(w).Encode(body)
+		case http.MethodDelete:
+			delete(f.hooks, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func getRepositoryTestAssets(t *testing.T, r test.Resources) (test.Assets, context.CancelFunc) {
+	t.Helper()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	clients := test.SeedResources(t, ctx, r)
+	cmw := configmap.NewInformedWatcher(clients.Kube, system.GetNamespace())
+	return test.Assets{
+		Controller: NewController(ctx, cmw),
+		Clients:    clients,
+	}, cancel
+}
+
+func repository(apiURL string) *v1alpha1.Repository {
+	return &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: repositoryName, Namespace: namespace},
+		Spec: v1alpha1.RepositorySpec{
+			Provider:      v1alpha1.GitHubProvider,
+			URL:           "https://github.com/tektoncd/triggers",
+			APIURL:        apiURL,
+			EventListener: "github-listener",
+			Events:        []string{"push", "pull_request"},
+			TokenRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "github"},
+				Key:                  "token",
+			},
+			SecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "github"},
+				Key:                  "secret",
+			},
+		},
+	}
+}
+
+func resources(repos ...*v1alpha1.Repository) test.Resources {
+	return test.Resources{
+		Namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: namespace}}},
+		EventListeners: []*v1alpha1.EventListener{
+			bldr.EventListener("github-listener", namespace,
+				bldr.EventListenerStatus(bldr.EventListenerAddress("el-github-listener.foo.svc.cluster.local:8080"))),
+		},
+		Secrets: []*corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: namespace},
+			Data:       map[string][]byte{"token": []byte("s3cr3t"), "secret": []byte("hmac")},
+		}},
+		Repositories: repos,
+	}
+}
+
+func reconcileRepository(t *testing.T, testAssets test.Assets) *v1alpha1.Repository {
+	t.Helper()
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), namespace+"/"+repositoryName); err != nil {
+		t.Fatal(err)
+	}
+	r, err := testAssets.Clients.Triggers.TriggersV1alpha1().Repositories(namespace).Get(repositoryName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestReconcile_CreatesWebhook(t *testing.T) {
+	gh, srv := newFakeGitHub(t)
+	testAssets, cancel := getRepositoryTestAssets(t, resources(repository(srv.URL)))
+	defer cancel()
+
+	r := reconcileRepository(t, testAssets)
+	if c := r.Status.GetCondition(v1alpha1.WebhookRegistered); c == nil || c.Status != corev1.ConditionTrue {
+		t.Fatalf("expected %s condition to be true, got %v", v1alpha1.WebhookRegistered, c)
+	}
+	if r.Status.WebhookID != "1" || r.Status.WebhookURL != listenerURL {
+		t.Errorf("unexpected webhook in status: %+v", r.Status)
+	}
+	if diff := cmp.Diff([]string{repositoryFinalizer}, r.Finalizers); diff != "" {
+		t.Errorf("unexpected finalizers. -want, +got: %s", diff)
+	}
+	wantConfig := map[string]interface{}{
+		"url":          listenerURL,
+		"content_type": "json",
+		"insecure_ssl": "0",
+		"secret":       "hmac",
+	}
+	if diff := cmp.Diff(wantConfig, gh.hooks[1]["config"]); diff != "" {
+		t.Errorf("unexpected webhook config. -want, +got: %s", diff)
+	}
+}
+
+func TestReconcile_UpdatesWebhook(t *testing.T) {
+	gh, srv := newFakeGitHub(t)
+	// A webhook for the EventListener that was registered by hand is adopted
+	// and its events are corrected
+	id := gh.addHook(listenerURL, "push")
+	testAssets, cancel := getRepositoryTestAssets(t, resources(repository(srv.URL)))
+	defer cancel()
+
+	r := reconcileRepository(t, testAssets)
+	if r.Status.WebhookID != fmt.Sprint(id) {
+		t.Errorf("expected webhook %d to be adopted, got %q", id, r.Status.WebhookID)
+	}
+	if len(gh.hooks) != 1 {
+		t.Errorf("expected a single webhook, got %d", len(gh.hooks))
+	}
+	if diff := cmp.Diff([]interface{}{"push", "pull_request"}, gh.hooks[id]["events"]); diff != "" {
+		t.Errorf("unexpected webhook events. -want, +got: %s", diff)
+	}
+}
+
+func TestReconcile_InSync(t *testing.T) {
+	gh, srv := newFakeGitHub(t)
+	id := gh.addHook(listenerURL, "pull_request", "push")
+	repo := repository(srv.URL)
+	repo.Finalizers = []string{repositoryFinalizer}
+	repo.Status.WebhookID = fmt.Sprint(id)
+	repo.Status.ConfigHash = hookConfigHash(hook{URL: listenerURL, Events: repo.Spec.Events}, "hmac")
+	testAssets, cancel := getRepositoryTestAssets(t, resources(repo))
+	defer cancel()
+
+	reconcileRepository(t, testAssets)
+	if diff := cmp.Diff([]string{http.MethodGet}, gh.calls); diff != "" {
+		t.Errorf("expected the webhook to only be listed. -want, +got: %s", diff)
+	}
+}
+
+func TestReconcile_EventListenerNotAddressable(t *testing.T) {
+	gh, srv := newFakeGitHub(t)
+	res := resources(repository(srv.URL))
+	res.EventListeners = []*v1alpha1.EventListener{bldr.EventListener("github-listener", namespace)}
+	testAssets, cancel := getRepositoryTestAssets(t, res)
+	defer cancel()
+
+	r := reconcileRepository(t, testAssets)
+	if c := r.Status.GetCondition(v1alpha1.WebhookRegistered); c == nil || c.Status != corev1.ConditionFalse || c.Reason != "EventListenerNotAddressable" {
+		t.Errorf("expected %s condition to be false, got %v", v1alpha1.WebhookRegistered, c)
+	}
+	if len(gh.calls) != 0 {
+		t.Errorf("expected no calls to the provider, got %v", gh.calls)
+	}
+}
+
+func TestReconcile_DeletesWebhook(t *testing.T) {
+	gh, srv := newFakeGitHub(t)
+	id := gh.addHook(listenerURL, "push", "pull_request")
+	repo := repository(srv.URL)
+	repo.Finalizers = []string{repositoryFinalizer}
+	repo.DeletionTimestamp = &metav1.Time{}
+	repo.Status.WebhookID = fmt.Sprint(id)
+	testAssets, cancel := getRepositoryTestAssets(t, resources(repo))
+	defer cancel()
+
+	r := reconcileRepository(t, testAssets)
+	if len(gh.hooks) != 0 {
+		t.Errorf("expected webhook to be deleted, got %v", gh.hooks)
+	}
+	if len(r.Finalizers) != 0 {
+		t.Errorf("expected finalizer to be removed, got %v", r.Finalizers)
+	}
+}
+
+func TestGitLabHook(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "s3cr3t" || r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/hooks" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"id": 42}`))
+	}))
+	defer srv.Close()
+
+	repo := repository(srv.URL + "/api/v4")
+	repo.Spec.Provider = v1alpha1.GitLabProvider
+	repo.Spec.URL = "https://gitlab.com/group/project.git"
+	hc, err := newHookClient(repo, "s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := hc.createHook(context.Background(), hook{URL: listenerURL, Events: []string{"merge_requests"}}, "hmac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "42" {
+		t.Errorf("expected webhook 42, got %q", id)
+	}
+	if got["url"] != listenerURL || got["token"] != "hmac" || got["merge_requests_events"] != true || got["push_events"] != false {
+		t.Errorf("unexpected webhook: %v", got)
+	}
+}
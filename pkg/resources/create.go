@@ -60,12 +60,25 @@ func FindAPIResource(apiVersion, kind string, c discoveryclient.ServerResourcesI
 }
 
 // Create uses the kubeClient to create the resource defined in the
-// TriggerResourceTemplate and returns any errors with this process
-func Create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID, elName, elNamespace string, c discoveryclient.ServerResourcesInterface, dc dynamic.Interface) error {
+// TriggerResourceTemplate and returns the created resource, or any errors
+// with this process
+func Create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID, elName, elNamespace string, c discoveryclient.ServerResourcesInterface, dc dynamic.Interface) (*unstructured.Unstructured, error) {
+	return create(logger, rt, triggerName, eventID, elName, elNamespace, c, dc, metav1.CreateOptions{})
+}
+
+// DryRunCreate performs a server-side dry-run of creating the resource defined
+// in the TriggerResourceTemplate, so that schema and admission webhook
+// rejections are returned without persisting anything.
+func DryRunCreate(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID, elName, elNamespace string, c discoveryclient.ServerResourcesInterface, dc dynamic.Interface) error {
+	_, err := create(logger, rt, triggerName, eventID, elName, elNamespace, c, dc, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	return err
+}
+
+func create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID, elName, elNamespace string, c discoveryclient.ServerResourcesInterface, dc dynamic.Interface, opts metav1.CreateOptions) (*unstructured.Unstructured, error) {
 	// Assume the TriggerResourceTemplate is valid (it has an apiVersion and Kind)
 	data := new(unstructured.Unstructured)
 	if err := data.UnmarshalJSON(rt); err != nil {
-		return fmt.Errorf("couldn't unmarshal json: %v", err)
+		return nil, fmt.Errorf("couldn't unmarshal json: %v", err)
 	}
 
 	data = AddLabels(data, map[string]string{
@@ -80,10 +93,14 @@ func Create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID,
 		namespace = elNamespace
 	}
 
+	if err := resolveOwnerReferences(data, namespace, c, dc); err != nil {
+		return nil, err
+	}
+
 	// Resolve resource kind to the underlying API Resource type.
 	apiResource, err := FindAPIResource(data.GetAPIVersion(), data.GetKind(), c)
 	if err != nil {
-		return fmt.Errorf("couldn't find API resource for json: %v", err)
+		return nil, fmt.Errorf("couldn't find API resource for json: %v", err)
 	}
 
 	name := data.GetName()
@@ -98,17 +115,77 @@ func Create(logger *zap.SugaredLogger, rt json.RawMessage, triggerName, eventID,
 		Resource: apiResource.Name,
 	}
 
-	logger.Infof("For event ID %q creating resource %v", eventID, gvr)
+	if len(opts.DryRun) > 0 {
+		logger.Infof("For event ID %q validating resource %v with dry-run", eventID, gvr)
+	} else {
+		logger.Infof("For event ID %q creating resource %v", eventID, gvr)
+	}
 
-	if _, err := dc.Resource(gvr).Namespace(namespace).Create(data, metav1.CreateOptions{}); err != nil {
+	created, err := dc.Resource(gvr).Namespace(namespace).Create(data, opts)
+	if err != nil {
 		if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
-			return err
+			return nil, err
 		}
-		return fmt.Errorf("couldn't create resource with group version kind %q: %v", gvr, err)
+		return nil, fmt.Errorf("couldn't create resource with group version kind %q: %w", gvr, err)
+	}
+	return created, nil
+}
+
+// resolveOwnerReferences sets the UID of the owner references of the resource
+// that only name their owner, so that templates can make created resources
+// dependents of objects known at event time. Namespaced owners are looked up in
+// the namespace of the resource, as Kubernetes does not allow owners in other
+// namespaces.
+func resolveOwnerReferences(data *unstructured.Unstructured, namespace string, c discoveryclient.ServerResourcesInterface, dc dynamic.Interface) error {
+	refs := data.GetOwnerReferences()
+	if len(refs) == 0 {
+		return nil
 	}
+	for i, ref := range refs {
+		if ref.UID != "" {
+			continue
+		}
+		apiResource, err := FindAPIResource(ref.APIVersion, ref.Kind, c)
+		if err != nil {
+			return fmt.Errorf("couldn't find API resource for owner %s %q: %v", ref.Kind, ref.Name, err)
+		}
+		gvr := schema.GroupVersionResource{
+			Group:    apiResource.Group,
+			Version:  apiResource.Version,
+			Resource: apiResource.Name,
+		}
+		var ri dynamic.ResourceInterface = dc.Resource(gvr)
+		if apiResource.Namespaced {
+			ri = dc.Resource(gvr).Namespace(namespace)
+		}
+		owner, err := ri.Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
+				return err
+			}
+			return fmt.Errorf("couldn't get owner %s %q: %w", ref.Kind, ref.Name, err)
+		}
+		refs[i].UID = owner.GetUID()
+	}
+	data.SetOwnerReferences(refs)
 	return nil
 }
 
+// AddAnnotations adds annotations prefixed with the Triggers group name to
+// created resources.
+func AddAnnotations(us *unstructured.Unstructured, annotationsToAdd map[string]string) *unstructured.Unstructured {
+	annotations := us.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for k, v := range annotationsToAdd {
+		annotations[fmt.Sprintf("%s/%s", triggersv1.GroupName, k)] = v
+	}
+
+	us.SetAnnotations(annotations)
+	return us
+}
+
 // AddLabels adds autogenerated Tekton labels to created resources.
 func AddLabels(us *unstructured.Unstructured, labelsToAdd map[string]string) *unstructured.Unstructured {
 	labels := us.GetLabels()
@@ -193,7 +193,7 @@ func TestCreateResource(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dynamicClient.ClearActions()
-			if err := Create(logger, tt.json, triggerName, eventID, elName, elNamespace, kubeClient.Discovery(), dynamicSet); err != nil {
+			if _, err := Create(logger, tt.json, triggerName, eventID, elName, elNamespace, kubeClient.Discovery(), dynamicSet); err != nil {
 				t.Errorf("createResource() returned error: %s", err)
 			}
 
@@ -305,3 +305,95 @@ func Test_AddLabels(t *testing.T) {
 		})
 	}
 }
+
+func Test_AddAnnotations(t *testing.T) {
+	us := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					// should be overwritten
+					"triggers.tekton.dev/header.X-Event": "pull",
+					// should be preserved
+					"description": "a run",
+				},
+			},
+		}}
+	want := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"triggers.tekton.dev/header.X-Event": "push",
+					"triggers.tekton.dev/body.ref":       "main",
+					"description":                        "a run",
+				},
+			},
+		},
+	}
+	got := AddAnnotations(us, map[string]string{
+		"header.X-Event": "push",
+		"body.ref":       "main",
+	})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AddAnnotations(): -want +got: %s", diff)
+	}
+}
+
+func TestCreateResource_OwnerReferences(t *testing.T) {
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	test.AddTektonResources(kubeClient)
+	owner := &unstructured.Unstructured{}
+	owner.SetAPIVersion("tekton.dev/v1alpha1")
+	owner.SetKind("PipelineRun")
+	owner.SetNamespace("bar")
+	owner.SetName("my-pipelinerun")
+	owner.SetUID("owner-uid")
+	logger, _ := logging.NewLogger("", "")
+
+	tests := []struct {
+		name    string
+		json    string
+		want    []metav1.OwnerReference
+		wantErr bool
+	}{{
+		name: "owner resolved",
+		json: `{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","ownerReferences":[{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineRun","name":"my-pipelinerun"}]},"spec":{"type":"git"}}`,
+		want: []metav1.OwnerReference{{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun", Name: "my-pipelinerun", UID: "owner-uid"}},
+	}, {
+		name: "uid kept",
+		json: `{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","ownerReferences":[{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineRun","name":"other-pipelinerun","uid":"other-uid"}]},"spec":{"type":"git"}}`,
+		want: []metav1.OwnerReference{{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun", Name: "other-pipelinerun", UID: "other-uid"}},
+	}, {
+		name:    "owner not found",
+		json:    `{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","ownerReferences":[{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineRun","name":"missing"}]},"spec":{"type":"git"}}`,
+		wantErr: true,
+	}, {
+		name:    "owner in another namespace",
+		json:    `{"kind":"PipelineResource","apiVersion":"tekton.dev/v1alpha1","metadata":{"name":"my-pipelineresource","namespace":"foo","ownerReferences":[{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineRun","name":"my-pipelinerun"}]},"spec":{"type":"git"}}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), owner.DeepCopy())
+			dynamicSet := dynamicclientset.New(tekton.WithClient(dynamicClient))
+			_, err := Create(logger, json.RawMessage(tt.json), triggerName, eventID, "foo-el", "bar", kubeClient.Discovery(), dynamicSet)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Create() did not return error when expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() returned error: %s", err)
+			}
+			var got []metav1.OwnerReference
+			for _, a := range dynamicClient.Actions() {
+				if c, ok := a.(ktesting.CreateAction); ok {
+					got = c.GetObject().(*unstructured.Unstructured).GetOwnerReferences()
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Create() owner references -want +got: %s", diff)
+			}
+		})
+	}
+}
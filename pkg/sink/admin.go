@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// NewAdminHandler returns the handler of the operational endpoints of the
+// Sink: /metrics and, if enabled, /debug/pprof/. If a token file is
+// configured, requests must send the token as a bearer token.
+func NewAdminHandler(args Args, logger *zap.SugaredLogger) http.Handler {
+	mux := http.NewServeMux()
+	if args.Metrics {
+		mux.Handle("/metrics", promhttp.Handler())
+	}
+	if args.Profiling {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	if args.AdminTokenFile == "" {
+		return mux
+	}
+	return &tokenHandler{Handler: mux, tokenFile: args.AdminTokenFile, logger: logger}
+}
+
+// tokenHandler requires requests to send the token in tokenFile as a bearer
+// token. The file is read for every request, so that the token can be
+// rotated without restarting the Sink.
+type tokenHandler struct {
+	http.Handler
+	tokenFile string
+	logger    *zap.SugaredLogger
+}
+
+func (h *tokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, err := ioutil.ReadFile(h.tokenFile)
+	if err != nil {
+		h.logger.Errorf("Error reading the admin token: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	token = bytes.TrimSpace(token)
+	auth := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if len(token) == 0 || !strings.HasPrefix(auth, prefix) ||
+		subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), token) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	h.Handler.ServeHTTP(w, r)
+}
+
+// NewAdminServer returns the HTTP server for the admin port of the Sink. It
+// requires client certificates if a client CA file is configured, which
+// requires a TLS certificate, since plain HTTP cannot verify clients.
+func NewAdminServer(args Args, handler http.Handler) (*http.Server, error) {
+	if args.AdminClientCAFile != "" && args.AdminTLSCertFile == "" {
+		return nil, fmt.Errorf("the admin client CA requires an admin TLS certificate")
+	}
+	srv := &http.Server{
+		Addr:         fmt.Sprintf(":%s", args.AdminPort),
+		Handler:      handler,
+		ReadTimeout:  args.ReadTimeout,
+		WriteTimeout: args.WriteTimeout,
+		IdleTimeout:  args.IdleTimeout,
+		TLSConfig:    args.TLS.Clone(),
+	}
+	if args.AdminClientCAFile != "" {
+		pool, err := clientCAPool(args.AdminClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the admin client CA: %w", err)
+		}
+		if srv.TLSConfig == nil {
+			srv.TLSConfig = &tls.Config{}
+		}
+		srv.TLSConfig.ClientCAs = pool
+		srv.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return srv, nil
+}
+
+// clientCAPool returns the pool of the CA certificates in the file that client
+// certificates are verified with.
+func clientCAPool(file string) (*x509.CertPool, error) {
+	ca, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+// ListenAndServeAdmin serves the admin port over TLS when a certificate is
+// configured and over plain HTTP otherwise. The certificate is reloaded when
+// its files change.
+func ListenAndServeAdmin(srv *http.Server, args Args) error {
+	if args.AdminTLSCertFile != "" {
+		return listenAndServeTLS(srv, args.AdminTLSCertFile, args.AdminTLSKeyFile)
+	}
+	return srv.ListenAndServe()
+}
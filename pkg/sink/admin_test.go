@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestNewAdminHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     Args
+		path     string
+		auth     string
+		wantCode int
+	}{{
+		name:     "metrics",
+		args:     Args{Metrics: true},
+		path:     "/metrics",
+		wantCode: http.StatusOK,
+	}, {
+		name:     "metrics disabled",
+		args:     Args{},
+		path:     "/metrics",
+		wantCode: http.StatusNotFound,
+	}, {
+		name:     "profiling",
+		args:     Args{Profiling: true},
+		path:     "/debug/pprof/",
+		wantCode: http.StatusOK,
+	}, {
+		name:     "profiling disabled",
+		args:     Args{Metrics: true},
+		path:     "/debug/pprof/",
+		wantCode: http.StatusNotFound,
+	}, {
+		name:     "valid token",
+		args:     Args{Metrics: true, AdminTokenFile: tokenFile},
+		path:     "/metrics",
+		auth:     "Bearer secret",
+		wantCode: http.StatusOK,
+	}, {
+		name:     "missing token",
+		args:     Args{Metrics: true, AdminTokenFile: tokenFile},
+		path:     "/metrics",
+		wantCode: http.StatusUnauthorized,
+	}, {
+		name:     "invalid token",
+		args:     Args{Metrics: true, AdminTokenFile: tokenFile},
+		path:     "/metrics",
+		auth:     "Bearer secre",
+		wantCode: http.StatusUnauthorized,
+	}, {
+		name:     "missing token file",
+		args:     Args{Metrics: true, AdminTokenFile: filepath.Join(dir, "missing")},
+		path:     "/metrics",
+		auth:     "Bearer secret",
+		wantCode: http.StatusInternalServerError,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewAdminHandler(tt.args, zap.NewNop().Sugar())
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("%s returned %d, want %d", tt.path, rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNewAdminServer_ClientCert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cert, certPEM := selfSignedCert(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := ioutil.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := NewAdminServer(Args{AdminPort: "9000", AdminClientCAFile: caFile, AdminTLSCertFile: "tls.crt", AdminTLSKeyFile: "tls.key"}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if err != nil {
+		t.Fatalf("NewAdminServer() = %v", err)
+	}
+	ts := httptest.NewUnstartedServer(srv.Handler)
+	ts.TLS = srv.TLSConfig
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := ts.Client()
+	if _, err := client.Get(ts.URL); err == nil {
+		t.Error("Request without client certificate succeeded")
+	}
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{cert}
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Request with client certificate failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Request with client certificate returned %d", resp.StatusCode)
+	}
+
+	if _, err := NewAdminServer(Args{AdminPort: "9000", AdminClientCAFile: filepath.Join(dir, "missing"), AdminTLSCertFile: "tls.crt", AdminTLSKeyFile: "tls.key"}, nil); err == nil {
+		t.Error("NewAdminServer() did not fail for a missing client CA")
+	}
+	// Without TLS, client certificates would not be verified
+	if _, err := NewAdminServer(Args{AdminPort: "9000", AdminClientCAFile: caFile}, nil); err == nil {
+		t.Error("NewAdminServer() did not fail for a client CA without a TLS certificate")
+	}
+}
+
+// selfSignedCert returns a self-signed client certificate and its PEM encoding.
+func selfSignedCert(t *testing.T) (tls.Certificate, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "prometheus"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
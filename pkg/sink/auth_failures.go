@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"go.uber.org/zap"
+)
+
+// The defaults of the AuthFailureBlock of an EventListener.
+const (
+	defaultAuthFailureWindowSeconds = 60
+	defaultAuthFailureBlockSeconds  = 600
+)
+
+// maxTrackedSources bounds the memory used to block sources. The failures of
+// further sources are only counted in the metrics.
+const maxTrackedSources = 10000
+
+// AuthFailureTracker counts the events of each source that failed
+// authentication, and blocks the sources that failed too often.
+type AuthFailureTracker struct {
+	mu      sync.Mutex
+	sources map[string]*sourceFailures
+}
+
+// sourceFailures are the failures of a source in its current window.
+type sourceFailures struct {
+	failures     int32
+	since        time.Time
+	blockedUntil time.Time
+}
+
+// NewAuthFailureTracker returns an AuthFailureTracker without any failures.
+func NewAuthFailureTracker() *AuthFailureTracker {
+	return &AuthFailureTracker{
+		sources: map[string]*sourceFailures{},
+	}
+}
+
+// eventSource returns the source of an event received from remoteAddr: its IP
+// address, or the /64 network of IPv6 addresses, which is usually assigned to
+// a single host. It is empty for events that were not received over HTTP.
+func eventSource(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	if ip.To4() != nil {
+		return ip.String()
+	}
+	mask := net.CIDRMask(64, 128)
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
+
+// blocked returns how long the events of the source are still rejected for.
+func (t *AuthFailureTracker) blocked(source string, now time.Time) time.Duration {
+	if t == nil || source == "" {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.sources[source]; ok && now.Before(s.blockedUntil) {
+		return s.blockedUntil.Sub(now)
+	}
+	return 0
+}
+
+// record counts an event of the source that failed authentication in the
+// interceptor. If b is set, the source is blocked once b.MaxFailures of its
+// events failed within the window. It returns true if this failure blocked the
+// source.
+func (t *AuthFailureTracker) record(source, interceptor string, b *triggersv1.AuthFailureBlock, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	authFailures.WithLabelValues(interceptor).Inc()
+	if b == nil {
+		return false
+	}
+
+	window := seconds(orDefault(b.WindowSeconds, defaultAuthFailureWindowSeconds))
+	s, ok := t.sources[source]
+	if !ok {
+		if len(t.sources) >= maxTrackedSources {
+			t.prune(now, window)
+			if len(t.sources) >= maxTrackedSources {
+				return false
+			}
+		}
+		s = &sourceFailures{since: now}
+		t.sources[source] = s
+	}
+	if now.Sub(s.since) > window {
+		s.failures, s.since = 0, now
+	}
+	s.failures++
+	if s.failures < b.MaxFailures || now.Before(s.blockedUntil) {
+		return false
+	}
+	s.blockedUntil = now.Add(seconds(orDefault(b.BlockSeconds, defaultAuthFailureBlockSeconds)))
+	s.failures, s.since = 0, now
+	sourceBlocks.Inc()
+	return true
+}
+
+// prune forgets the sources that are not blocked and whose window passed.
+func (t *AuthFailureTracker) prune(now time.Time, window time.Duration) {
+	for source, s := range t.sources {
+		if now.Sub(s.since) > window && !now.Before(s.blockedUntil) {
+			delete(t.sources, source)
+		}
+	}
+}
+
+// orDefault returns n, or def if n is zero.
+func orDefault(n, def int32) int32 {
+	if n == 0 {
+		return def
+	}
+	return n
+}
+
+// recordAuthFailure counts an event that an interceptor rejected because its
+// signature or token is missing or invalid.
+func (r Sink) recordAuthFailure(el *triggersv1.EventListener, request *http.Request, interceptor string, log *zap.SugaredLogger) {
+	source := eventSource(request.RemoteAddr)
+	if r.AuthFailures == nil || source == "" {
+		return
+	}
+	if r.AuthFailures.record(source, interceptor, el.Spec.AuthFailureBlock, time.Now()) {
+		log.Warnf("Blocking the events of %s after %d of them failed authentication", source, el.Spec.AuthFailureBlock.MaxFailures)
+	}
+}
+
+// rejectBlockedSource responds to an event of a blocked source with 429 Too
+// Many Requests, without reading the event.
+func (r Sink) rejectBlockedSource(response http.ResponseWriter, retryAfter time.Duration) {
+	sourceEvents.WithLabelValues("http", "blocked").Inc()
+	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	response.WriteHeader(http.StatusTooManyRequests)
+	body := Response{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		Message:       "too many events from this source failed authentication",
+	}
+	if err := json.NewEncoder(response).Encode(body); err != nil {
+		r.Logger.Errorf("failed to write back sink response: %v", err)
+	}
+}
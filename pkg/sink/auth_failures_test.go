@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventSource(t *testing.T) {
+	for remoteAddr, want := range map[string]string{
+		"192.0.2.1:1234":                 "192.0.2.1",
+		"192.0.2.1":                      "192.0.2.1",
+		"[2001:db8:1:2:3:4:5:6]:1234":    "2001:db8:1:2::/64",
+		"[2001:db8:1:2:ffff::1]:443":     "2001:db8:1:2::/64",
+		"[::ffff:192.0.2.1]:1234":        "192.0.2.1",
+		"":                               "",
+		"not an address":                 "",
+		"[2001:db8:1:3:3:4:5:6]:1234":    "2001:db8:1:3::/64",
+		"[2001:db8:1:2:3:4:5:6%eth0]:80": "",
+	} {
+		if got := eventSource(remoteAddr); got != want {
+			t.Errorf("eventSource(%q) = %q, want %q", remoteAddr, got, want)
+		}
+	}
+}
+
+func TestAuthFailureTracker(t *testing.T) {
+	tracker := NewAuthFailureTracker()
+	b := &triggersv1.AuthFailureBlock{MaxFailures: 3, WindowSeconds: 60, BlockSeconds: 300}
+	now := time.Now()
+	blocks := counterValue(t, sourceBlocks)
+
+	// Failures outside of the window are not counted
+	tracker.record("192.0.2.1", "github", b, now)
+	tracker.record("192.0.2.1", "github", b, now.Add(61*time.Second))
+	if tracker.record("192.0.2.1", "github", b, now.Add(62*time.Second)) {
+		t.Fatal("record() blocked the source after 2 failures in the window")
+	}
+	if !tracker.record("192.0.2.1", "github", b, now.Add(63*time.Second)) {
+		t.Fatal("record() did not block the source after 3 failures in the window")
+	}
+	blockedAt := now.Add(63 * time.Second)
+	if got := counterValue(t, sourceBlocks) - blocks; got != 1 {
+		t.Errorf("eventlistener_source_blocks_total increased by %v, want 1", got)
+	}
+
+	if got := tracker.blocked("192.0.2.1", blockedAt.Add(time.Minute)); got != 4*time.Minute {
+		t.Errorf("blocked() = %s, want 4m0s", got)
+	}
+	if got := tracker.blocked("192.0.2.2", blockedAt); got != 0 {
+		t.Errorf("blocked() = %s for another source, want 0s", got)
+	}
+	if got := tracker.blocked("192.0.2.1", blockedAt.Add(5*time.Minute)); got != 0 {
+		t.Errorf("blocked() = %s after the block, want 0s", got)
+	}
+
+	// Without a block, failures are only counted
+	failures := counterValue(t, authFailures.WithLabelValues("gitlab"))
+	for i := 0; i < 5; i++ {
+		if tracker.record("192.0.2.3", "gitlab", nil, now) {
+			t.Fatal("record() blocked a source without an AuthFailureBlock")
+		}
+	}
+	if got := tracker.blocked("192.0.2.3", now); got != 0 {
+		t.Errorf("blocked() = %s without an AuthFailureBlock, want 0s", got)
+	}
+	if got := counterValue(t, authFailures.WithLabelValues("gitlab")) - failures; got != 5 {
+		t.Errorf("eventlistener_auth_failures_total{interceptor=\"gitlab\"} increased by %v, want 5", got)
+	}
+}
+
+func TestHandleEvent_AuthFailureBlock(t *testing.T) {
+	gitlab := &triggersv1.EventInterceptor{
+		GitLab: &triggersv1.GitLabInterceptor{
+			SecretRef: &triggersv1.SecretRef{SecretName: "secret", SecretKey: "token"},
+		},
+	}
+	el := &triggersv1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-eventlistener", Namespace: namespace},
+		Spec: triggersv1.EventListenerSpec{
+			// Both Triggers reject the events, which count once
+			Triggers: []triggersv1.EventListenerTrigger{{
+				Name:         "first",
+				Template:     triggersv1.EventListenerTemplate{Name: "tt"},
+				Interceptors: []*triggersv1.EventInterceptor{gitlab},
+			}, {
+				Name:         "second",
+				Template:     triggersv1.EventListenerTemplate{Name: "tt"},
+				Interceptors: []*triggersv1.EventInterceptor{gitlab},
+			}},
+			AuthFailureBlock: &triggersv1.AuthFailureBlock{MaxFailures: 2, BlockSeconds: 120},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("token")},
+	}
+	sink, _ := getSinkAssets(t, test.Resources{
+		EventListeners: []*triggersv1.EventListener{el},
+		Secrets:        []*corev1.Secret{secret},
+	}, el.Name, DefaultAuthOverride{})
+	sink.AuthFailures = NewAuthFailureTracker()
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitLab-Token", "guess")
+		rec := httptest.NewRecorder()
+		sink.HandleEvent(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := send("192.0.2.1:1234"); rec.Code != http.StatusAccepted {
+			t.Fatalf("Response code of failure %d = %d, want %d", i+1, rec.Code, http.StatusAccepted)
+		}
+	}
+	rec := send("192.0.2.1:5678")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("Response code of a blocked source = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "120" {
+		t.Errorf("Retry-After = %q, want 120", got)
+	}
+	if rec := send("192.0.2.2:1234"); rec.Code != http.StatusAccepted {
+		t.Errorf("Response code of another source = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+}
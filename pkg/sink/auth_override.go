@@ -24,6 +24,11 @@ type AuthOverride interface {
 		defaultDynamicClient dynamic.Interface) (discoveryClient discoveryclient.ServerResourcesInterface,
 		dynamicClient dynamic.Interface,
 		err error)
+	// OverrideKubeClient constructs a Kubernetes client that uses the token
+	// provided as the bearer token, e.g. for the Secret reads of interceptors.
+	OverrideKubeClient(token string,
+		log *zap.SugaredLogger,
+		defaultKubeClient kubernetes.Interface) (kubernetes.Interface, error)
 }
 
 func isServiceAccountToken(secret *corev1.Secret, sa *corev1.ServiceAccount) bool {
@@ -133,3 +138,19 @@ func (r DefaultAuthOverride) OverrideAuthentication(token string,
 
 	return
 }
+
+func (r DefaultAuthOverride) OverrideKubeClient(token string,
+	log *zap.SugaredLogger,
+	defaultKubeClient kubernetes.Interface) (kubernetes.Interface, error) {
+	clusterConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Errorf("overrideKubeClient: problem getting in cluster config: %#v\n", err)
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(newConfig(token, clusterConfig))
+	if err != nil {
+		log.Errorf("overrideKubeClient: problem getting kube client: %#v\n", err)
+		return nil, err
+	}
+	return kubeClient, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/template"
+	"go.uber.org/zap"
+)
+
+// callbackTimeout is how long the sink waits for the callback URL of an event
+// to accept its outcome.
+const callbackTimeout = 10 * time.Second
+
+// Callback is the outcome of an event that is posted to its callback URL.
+type Callback struct {
+	// EventListener is the name of the eventListener
+	EventListener string `json:"eventListener"`
+	// Namespace is the namespace that the eventListener is running in
+	Namespace string `json:"namespace,omitempty"`
+	// EventID is the uniqueID assigned to the event
+	EventID string `json:"eventID,omitempty"`
+	// DeliveryID is the ID of the delivery of the event by its provider
+	DeliveryID string `json:"deliveryID,omitempty"`
+	// CorrelationID is the ID sent in the Tekton-Correlation-Id header
+	CorrelationID string `json:"correlationID,omitempty"`
+	// Matched lists the Triggers that created resources for the event
+	Matched []string `json:"matched,omitempty"`
+	// Resources lists the resources created for the event
+	Resources []CreatedResource `json:"resources,omitempty"`
+	// Errors lists the Triggers that failed for a known reason
+	Errors []TriggerError `json:"errors,omitempty"`
+}
+
+// CreatedResource identifies a resource that a Trigger created.
+type CreatedResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// postCallback posts the outcome of an event to the URL in the urlField of the
+// intercepted event body. The callback is dropped if the URL is not on the
+// allowed hosts, so that events cannot make the sink send requests to
+// arbitrary hosts.
+func (r Sink) postCallback(c *triggersv1.EventCallback, body []byte, callback Callback, log *zap.SugaredLogger) {
+	u, err := callbackURL(c, body)
+	if err != nil {
+		log.Debugf("Not posting a callback: %s", err)
+		return
+	}
+	if !allowedCallbackURL(c, u) {
+		log.Warnf("Not posting a callback to %s, which is not an allowed host", u.Host)
+		callbacks.WithLabelValues("rejected").Inc()
+		return
+	}
+
+	payload, err := json.Marshal(callback)
+	if err != nil {
+		log.Errorf("Failed to marshal callback: %s", err)
+		callbacks.WithLabelValues("failed").Inc()
+		return
+	}
+	client := http.Client{}
+	if r.HTTPClient != nil {
+		client = *r.HTTPClient
+	}
+	client.Timeout = callbackTimeout
+	// A redirect could lead to a host that is not allowed
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := client.Post(u.String(), "application/json", bytes.NewReader(payload))
+	if err != nil {
+		log.Errorf("Failed to post callback to %s: %s", u.Host, err)
+		callbacks.WithLabelValues("failed").Inc()
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Errorf("Callback to %s failed with status code %d", u.Host, resp.StatusCode)
+		callbacks.WithLabelValues("failed").Inc()
+		return
+	}
+	callbacks.WithLabelValues("sent").Inc()
+}
+
+// callbackURL returns the callback URL in the urlField of the event body.
+func callbackURL(c *triggersv1.EventCallback, body []byte) (*url.URL, error) {
+	e, err := template.NewEvent(body, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := template.ParseJSONPathValue(e, "$(body."+c.URLField+")")
+	if err != nil {
+		return nil, fmt.Errorf("no callback URL in body.%s: %w", c.URLField, err)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid callback URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("callback URL must be an absolute http or https URL")
+	}
+	return u, nil
+}
+
+// allowedCallbackURL reports whether the host of the URL is one of the
+// allowed hosts of the callback.
+func allowedCallbackURL(c *triggersv1.EventCallback, u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range c.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// firstAccepted returns the event as the first Trigger, in the order of the
+// EventListener, whose interceptors accepted it passed it on, or nil if no
+// Trigger accepted it.
+func firstAccepted(el *triggersv1.EventListener, namespaces []string, accepted map[triggerKey][]byte) []byte {
+	for _, ns := range namespaces {
+		for _, t := range el.Spec.Triggers {
+			if body, ok := accepted[triggerKey{trigger: t.Name, namespace: ns}]; ok {
+				return body
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueSorted returns the sorted names without duplicates.
+func uniqueSorted(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	unique := names[:1]
+	for _, n := range names[1:] {
+		if n != unique[len(unique)-1] {
+			unique = append(unique, n)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+)
+
+func TestHandleEvent_Callback(t *testing.T) {
+	received := make(chan Callback, 1)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var c Callback
+		if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+			t.Errorf("Error decoding callback: %s", err)
+		}
+		received <- c
+	}))
+	defer callbackServer.Close()
+	callbackURL := callbackServer.URL + "/done"
+
+	tests := []struct {
+		name     string
+		urlField string
+		filter   string
+		overlay  string
+		event    string
+		want     bool
+	}{{
+		name:     "URL from the event",
+		urlField: "callback.url",
+		filter:   "true",
+		event:    fmt.Sprintf(`{"url": "https://example.com", "callback": {"url": %q}}`, callbackURL),
+		want:     true,
+	}, {
+		name:     "URL from an overlay of the interceptor",
+		urlField: "callbackURL",
+		filter:   "true",
+		overlay:  fmt.Sprintf("'%s'", callbackURL),
+		event:    `{"url": "https://example.com"}`,
+		want:     true,
+	}, {
+		name:     "event rejected by the interceptor",
+		urlField: "callback.url",
+		filter:   "body.url == 'https://trusted.example.com'",
+		event:    fmt.Sprintf(`{"url": "https://example.com", "callback": {"url": %q}}`, callbackURL),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var interceptorOps []bldr.EventInterceptorOp
+			if tc.overlay != "" {
+				interceptorOps = append(interceptorOps, bldr.EventListenerCELOverlay("callbackURL", tc.overlay))
+			}
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerCallback(triggersv1.EventCallback{
+					URLField:     tc.urlField,
+					AllowedHosts: []string{"127.0.0.1"},
+				}),
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+					bldr.EventListenerCELInterceptor(tc.filter, interceptorOps...),
+				),
+			))
+			sink, _ := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(tc.event)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Event", "push")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Error sending event: %s", err)
+			}
+			defer resp.Body.Close()
+			var response Response
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				t.Fatalf("Error reading response body: %s", err)
+			}
+
+			if !tc.want {
+				select {
+				case got := <-received:
+					t.Errorf("posted callback %+v for an event that no interceptor accepted", got)
+				case <-time.After(500 * time.Millisecond):
+				}
+				return
+			}
+			select {
+			case got := <-received:
+				want := Callback{
+					EventListener: el.Name,
+					Namespace:     namespace,
+					EventID:       response.EventID,
+					Matched:       []string{"my-trigger"},
+					Resources: []CreatedResource{{
+						APIVersion: "tekton.dev/v1alpha1",
+						Kind:       "PipelineResource",
+						Namespace:  namespace,
+						Name:       "my-pipelineresource",
+					}},
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("callback -want,+got: %s", diff)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("no callback was posted")
+			}
+		})
+	}
+}
+
+func Test_callbackURL(t *testing.T) {
+	c := &triggersv1.EventCallback{URLField: "callback.url"}
+	for _, tc := range []struct {
+		event   string
+		want    string
+		wantErr bool
+	}{{
+		event: `{"callback": {"url": "https://ci.example.com/builds/1"}}`,
+		want:  "https://ci.example.com/builds/1",
+	}, {
+		event: `{"callback": {"url": "https://ci.example.com/builds?id=1&step=2"}}`,
+		want:  "https://ci.example.com/builds?id=1&step=2",
+	}, {
+		event:   `{}`,
+		wantErr: true,
+	}, {
+		event:   `{"callback": {"url": "file:///etc/passwd"}}`,
+		wantErr: true,
+	}, {
+		event:   `{"callback": {"url": "/builds/1"}}`,
+		wantErr: true,
+	}} {
+		t.Run(tc.event, func(t *testing.T) {
+			got, err := callbackURL(c, []byte(tc.event))
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("callbackURL() = %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("callbackURL() returned error: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("callbackURL() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_allowedCallbackURL(t *testing.T) {
+	c := &triggersv1.EventCallback{AllowedHosts: []string{"ci.example.com", "*.hooks.example.com"}}
+	for _, tc := range []struct {
+		url  string
+		want bool
+	}{
+		{url: "https://ci.example.com/builds/1", want: true},
+		{url: "https://CI.example.com:8443/builds/1", want: true},
+		{url: "https://eu.hooks.example.com/", want: true},
+		{url: "https://hooks.example.com/", want: false},
+		{url: "https://ci.example.com.evil.com/", want: false},
+		{url: "https://evilhooks.example.com/", want: false},
+		{url: "http://169.254.169.254/latest/meta-data", want: false},
+	} {
+		u, err := url.Parse(tc.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := allowedCallbackURL(c, u); got != tc.want {
+			t.Errorf("allowedCallbackURL(%s) = %t, want %t", tc.url, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/logging"
+	"github.com/tektoncd/triggers/pkg/resources"
+	"github.com/tektoncd/triggers/pkg/template"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// captureConfigMapPrefix is the prefix of the name of the ConfigMap that
+// holds the captured values of an event. It is followed by the $(uid) of the
+// resources of the Trigger.
+const captureConfigMapPrefix = "triggers-event-"
+
+// captureEvent captures the parts of the event selected by the capture of a
+// Trigger. With the Annotations target, the captured values are added to the
+// resources. Otherwise a ConfigMap holding them is returned, which the sink
+// creates before the resources so that it exists when their Pods start.
+func (r Sink) captureEvent(c *triggersv1.EventCapture, res []json.RawMessage, body []byte, header http.Header, uid, triggerName, eventID string) ([]json.RawMessage, *corev1.ConfigMap, error) {
+	values, err := template.CaptureEvent(c, body, header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to capture event: %w", err)
+	}
+	// The captured values outlive the event, and must not keep the tokens
+	// that payloads contain by accident
+	for k, v := range values {
+		values[k] = logging.Secrets.Redact(v)
+	}
+
+	if c.Target == triggersv1.CaptureAnnotations {
+		out := make([]json.RawMessage, len(res))
+		for i, rr := range res {
+			data := new(unstructured.Unstructured)
+			if err := data.UnmarshalJSON(rr); err != nil {
+				return nil, nil, fmt.Errorf("couldn't unmarshal json: %w", err)
+			}
+			if out[i], err = resources.AddAnnotations(data, values).MarshalJSON(); err != nil {
+				return nil, nil, err
+			}
+		}
+		return out, nil, nil
+	}
+
+	labels := make(map[string]string, 3)
+	for k, v := range map[string]string{
+		triggersv1.EventListenerLabelKey: r.EventListenerName,
+		triggersv1.EventIDLabelKey:       eventID,
+		triggersv1.TriggerLabelKey:       triggerName,
+	} {
+		labels[fmt.Sprintf("%s/%s", triggersv1.GroupName, strings.TrimLeft(k, "/"))] = v
+	}
+	return res, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   captureConfigMapPrefix + uid,
+			Labels: labels,
+		},
+		Data: values,
+	}, nil
+}
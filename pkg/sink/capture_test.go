@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestHandleEvent_Capture(t *testing.T) {
+	capture := triggersv1.EventCapture{
+		Headers: []string{"X-Event"},
+		Body:    []string{"url", "missing"},
+	}
+	tests := []struct {
+		name   string
+		target triggersv1.CaptureTarget
+		// wantConfigMap is the data of the ConfigMap, if one is created
+		wantConfigMap map[string]string
+		// wantAnnotations are the annotations of the created resource
+		wantAnnotations map[string]interface{}
+	}{{
+		name:   "ConfigMap",
+		target: triggersv1.CaptureConfigMap,
+		wantConfigMap: map[string]string{
+			"header.X-Event": "push",
+			"body.url":       "https://example.com",
+		},
+	}, {
+		name:   "Annotations",
+		target: triggersv1.CaptureAnnotations,
+		wantAnnotations: map[string]interface{}{
+			"triggers.tekton.dev/header.X-Event": "push",
+			"triggers.tekton.dev/body.url":       "https://example.com",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capture.Target = tt.target
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+					bldr.EventListenerTriggerCapture(capture),
+				),
+			))
+			sink, dynamicClient := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{"url": "https://example.com"}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Event", "push")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Error sending event: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusCreated {
+				t.Fatalf("Response code doesn't match: %v", resp.Status)
+			}
+
+			cms, err := sink.KubeClientSet.CoreV1().ConfigMaps(namespace).List(metav1.ListOptions{
+				LabelSelector: eventIDLabel + "=" + eventID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotConfigMap map[string]string
+			for _, cm := range cms.Items {
+				if cm.Name != captureConfigMapPrefix+eventID {
+					t.Errorf("ConfigMap name = %s, want %s", cm.Name, captureConfigMapPrefix+eventID)
+				}
+				gotConfigMap = cm.Data
+			}
+			if diff := cmp.Diff(tt.wantConfigMap, gotConfigMap); diff != "" {
+				t.Errorf("Captured ConfigMap mismatch (-want + got): %s", diff)
+			}
+
+			obj := dynamicClient.Actions()[0].(ktesting.CreateAction).GetObject().(*unstructured.Unstructured)
+			gotAnnotations, _, _ := unstructured.NestedMap(obj.Object, "metadata", "annotations")
+			if diff := cmp.Diff(tt.wantAnnotations, gotAnnotations); diff != "" {
+				t.Errorf("Captured annotations mismatch (-want + got): %s", diff)
+			}
+		})
+	}
+}
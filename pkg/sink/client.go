@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// NewHTTPClient returns the HTTP client that the Sink uses to call
+// interceptor services. It keeps connections to every interceptor alive
+// across events, limits the connections per host and caches DNS lookups, so
+// that a busy EventListener doesn't open a new connection and resolve the
+// Service name for every event. Connections follow the TLS policy of the
+// Args and are verified with its interceptor CAs.
+func NewHTTPClient(args Args) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	dial := dialer.DialContext
+	if args.DNSCacheTTL > 0 {
+		dial = newDNSCache(net.DefaultResolver, args.DNSCacheTTL).dialContext(dialer)
+	}
+	tlsConfig := args.TLS.Clone()
+	if args.InterceptorRootCAs != nil {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		tlsConfig.RootCAs = args.InterceptorRootCAs
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dial,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          args.MaxIdleConnsPerHost * 10,
+			MaxIdleConnsPerHost:   args.MaxIdleConnsPerHost,
+			MaxConnsPerHost:       args.MaxConnsPerHost,
+			IdleConnTimeout:       args.IdleConnTimeout,
+			TLSHandshakeTimeout:   10 * time.Second,
+			TLSClientConfig:       tlsConfig,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+// interceptorCAPool returns the system CAs with the CAs of the file added, e.g.
+// the service CA of OpenShift.
+func interceptorCAPool(file string) (*x509.CertPool, error) {
+	ca, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// dnsCache caches the addresses of hosts for a TTL. When a lookup fails, the
+// addresses of the last successful lookup are used, so that a DNS hiccup
+// doesn't fail events for interceptors whose addresses rarely change, like
+// Kubernetes Services.
+type dnsCache struct {
+	resolver hostResolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+func newDNSCache(resolver hostResolver, ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		resolver: resolver,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  map[string]dnsEntry{},
+	}
+}
+
+// lookup returns the addresses of host, from the cache if they haven't
+// expired.
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	c.mu.Lock()
+	entry, cached := c.entries[host]
+	c.mu.Unlock()
+	if cached && c.now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, host)
+	if err != nil {
+		if cached {
+			return entry.addrs, nil
+		}
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[host] = dnsEntry{addrs: addrs, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return addrs, nil
+}
+
+// dialContext returns a dial function that resolves host names with the
+// cache and tries their addresses in order.
+func (c *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		addrs, err := c.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var conn net.Conn
+		for _, a := range addrs {
+			if conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(a, port)); err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
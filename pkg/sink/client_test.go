@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeResolver struct {
+	addrs   []string
+	err     error
+	lookups int
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups++
+	return r.addrs, r.err
+}
+
+func TestDNSCache(t *testing.T) {
+	resolver := &fakeResolver{addrs: []string{"10.0.0.1"}}
+	now := time.Now()
+	c := newDNSCache(resolver, 30*time.Second)
+	c.now = func() time.Time { return now }
+	lookup := func() []string {
+		t.Helper()
+		addrs, err := c.lookup(context.Background(), "interceptor.ns.svc")
+		if err != nil {
+			t.Fatalf("lookup() error: %v", err)
+		}
+		return addrs
+	}
+
+	lookup()
+	lookup()
+	if resolver.lookups != 1 {
+		t.Errorf("Resolver was called %d times within the TTL, want 1", resolver.lookups)
+	}
+
+	// The entry expired, so the host is resolved again
+	now = now.Add(time.Minute)
+	resolver.addrs = []string{"10.0.0.2"}
+	if diff := cmp.Diff([]string{"10.0.0.2"}, lookup()); diff != "" {
+		t.Errorf("lookup() after TTL -want/+got: %s", diff)
+	}
+
+	// Failed lookups fall back to the last addresses
+	now = now.Add(time.Minute)
+	resolver.err = errors.New("i/o timeout")
+	if diff := cmp.Diff([]string{"10.0.0.2"}, lookup()); diff != "" {
+		t.Errorf("lookup() after failure -want/+got: %s", diff)
+	}
+	if _, err := c.lookup(context.Background(), "other.ns.svc"); err == nil {
+		t.Error("lookup() of an uncached host did not return the resolver error")
+	}
+}
+
+func TestDNSCache_Dial(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing listens on the first address, so the next one is tried
+	c := newDNSCache(&fakeResolver{addrs: []string{"127.0.0.2", host}}, time.Minute)
+	dial := c.dialContext(&net.Dialer{Timeout: time.Second})
+	conn, err := dial(context.Background(), "tcp", net.JoinHostPort("interceptor.ns.svc", port))
+	if err != nil {
+		t.Fatalf("dial() error: %v", err)
+	}
+	conn.Close()
+}
+
+func TestNewHTTPClient_ReusesConnections(t *testing.T) {
+	var mu sync.Mutex
+	conns := 0
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	client := NewHTTPClient(Args{MaxIdleConnsPerHost: 10, IdleConnTimeout: time.Minute, DNSCacheTTL: time.Minute})
+	for i := 0; i < 10; i++ {
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+		if _, err := ioutil.ReadAll(resp.Body); err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if conns != 1 {
+		t.Errorf("Opened %d connections for sequential requests, want 1", conns)
+	}
+}
+
+func TestNewHTTPClient_TLSPolicy(t *testing.T) {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	ts.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	ts.StartTLS()
+	defer ts.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+
+	for _, tc := range []struct {
+		minVersion uint16
+		wantErr    bool
+	}{{
+		minVersion: tls.VersionTLS12,
+	}, {
+		minVersion: tls.VersionTLS13,
+		wantErr:    true,
+	}} {
+		client := NewHTTPClient(Args{MaxIdleConnsPerHost: 1, TLS: &tls.Config{RootCAs: roots, MinVersion: tc.minVersion}})
+		resp, err := client.Get(ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		if (err != nil) != tc.wantErr {
+			t.Errorf("Get() with minimum version %#x = %v, wantErr %t", tc.minVersion, err, tc.wantErr)
+		}
+	}
+}
+
+func TestNewHTTPClient_InterceptorRootCAs(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(ts.Certificate())
+
+	for _, tc := range []struct {
+		name    string
+		args    Args
+		wantErr bool
+	}{{
+		name:    "system CAs",
+		args:    Args{MaxIdleConnsPerHost: 1},
+		wantErr: true,
+	}, {
+		name: "interceptor CAs",
+		args: Args{MaxIdleConnsPerHost: 1, InterceptorRootCAs: roots},
+	}, {
+		name: "interceptor CAs with a TLS policy",
+		args: Args{MaxIdleConnsPerHost: 1, InterceptorRootCAs: roots, TLS: &tls.Config{MinVersion: tls.VersionTLS12}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := NewHTTPClient(tc.args).Get(ts.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Get() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// deliveryHeaders are the headers that carry the delivery ID of the events of
+// well-known providers. They identify the redeliveries of an event when the
+// EventListener does not set a deduplication header.
+var deliveryHeaders = []string{
+	"X-GitHub-Delivery",
+	"X-Gitlab-Event-UUID",
+	"X-Request-UUID",
+}
+
+// coalescingKey returns the delivery ID that concurrent deliveries of an
+// event share, read from the deduplication header of the EventListener or a
+// well-known delivery header.
+func coalescingKey(el *triggersv1.EventListener, header http.Header) string {
+	if el.Spec.Deduplication != nil {
+		return deliveryIDOf(el, header)
+	}
+	for _, h := range deliveryHeaders {
+		if id := header.Get(h); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// DeliveryCoalescer coalesces concurrent deliveries of the same event, e.g.
+// when a provider retries a delivery while the first one is still being
+// processed. Only the first delivery is processed, and the others get its
+// response.
+type DeliveryCoalescer struct {
+	mu       sync.Mutex
+	inflight map[string]*coalescedDelivery
+}
+
+// coalescedDelivery is a delivery that is being processed. done is closed
+// once its response is recorded.
+type coalescedDelivery struct {
+	done     chan struct{}
+	response *recordedResponse
+	// joined counts the deliveries that wait for the response
+	joined int
+}
+
+// NewDeliveryCoalescer returns a DeliveryCoalescer without any delivery in
+// flight.
+func NewDeliveryCoalescer() *DeliveryCoalescer {
+	return &DeliveryCoalescer{inflight: map[string]*coalescedDelivery{}}
+}
+
+// join returns the delivery in flight with the id and false if there is one.
+// Otherwise the caller processes the delivery, and must finish it.
+func (c *DeliveryCoalescer) join(id string) (*coalescedDelivery, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d, ok := c.inflight[id]; ok {
+		d.joined++
+		return d, false
+	}
+	d := &coalescedDelivery{done: make(chan struct{})}
+	c.inflight[id] = d
+	return d, true
+}
+
+// finish records the response of a delivery for the deliveries that joined
+// it, and lets the next delivery with the id be processed again.
+func (c *DeliveryCoalescer) finish(id string, d *coalescedDelivery, response *recordedResponse) {
+	c.mu.Lock()
+	delete(c.inflight, id)
+	c.mu.Unlock()
+	// The response is read concurrently from here on
+	response.WriteHeader(http.StatusOK)
+	d.response = response
+	close(d.done)
+}
+
+// recordedResponse is an http.ResponseWriter that records a response, so
+// that it can be sent to several senders.
+type recordedResponse struct {
+	header http.Header
+	// sentHeader is the header at the time the status code was written,
+	// which is what a sender receives
+	sentHeader http.Header
+	code       int
+	body       bytes.Buffer
+}
+
+func newRecordedResponse() *recordedResponse {
+	return &recordedResponse{header: http.Header{}}
+}
+
+func (w *recordedResponse) Header() http.Header {
+	return w.header
+}
+
+func (w *recordedResponse) WriteHeader(code int) {
+	if w.code != 0 {
+		return
+	}
+	w.code = code
+	w.sentHeader = w.header.Clone()
+}
+
+func (w *recordedResponse) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
+
+// replay sends the recorded response.
+func (w *recordedResponse) replay(response http.ResponseWriter) error {
+	for k, v := range w.sentHeader {
+		response.Header()[k] = v
+	}
+	response.WriteHeader(w.code)
+	_, err := response.Write(w.body.Bytes())
+	return err
+}
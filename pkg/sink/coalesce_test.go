@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestCoalescingKey(t *testing.T) {
+	dedup := bldr.EventListener("el", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerDeduplication(triggersv1.Deduplication{Header: "X-Delivery", Group: "push"}),
+	))
+	plain := bldr.EventListener("el", namespace)
+	tests := []struct {
+		name   string
+		el     *triggersv1.EventListener
+		header http.Header
+		want   string
+	}{{
+		name:   "deduplication header",
+		el:     dedup,
+		header: http.Header{"X-Delivery": []string{"d1"}, "X-Github-Delivery": []string{"d2"}},
+		want:   "d1",
+	}, {
+		name:   "only the deduplication header with deduplication",
+		el:     dedup,
+		header: http.Header{"X-Github-Delivery": []string{"d2"}},
+	}, {
+		name:   "GitHub delivery",
+		el:     plain,
+		header: http.Header{"X-Github-Delivery": []string{"d2"}},
+		want:   "d2",
+	}, {
+		name:   "GitLab event",
+		el:     plain,
+		header: http.Header{"X-Gitlab-Event-Uuid": []string{"d3"}},
+		want:   "d3",
+	}, {
+		name: "no delivery ID",
+		el:   plain,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := coalescingKey(tc.el, tc.header); got != tc.want {
+				t.Errorf("coalescingKey() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// trackedBody is a request body that records whether it was read.
+type trackedBody struct {
+	io.Reader
+	read *int32
+}
+
+func (b trackedBody) Read(p []byte) (int, error) {
+	atomic.StoreInt32(b.read, 1)
+	return b.Reader.Read(p)
+}
+
+func TestHandleEvent_CoalescedDeliveries(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"generateName":"ref-"},"spec":{"type":"git"}}`)}),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1", bldr.EventListenerTriggerName("my-trigger")),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	sink.Deliveries = NewDeliveryCoalescer()
+	// The creation of the first delivery blocks until the redelivery arrived
+	created, unblock := make(chan struct{}, 1), make(chan struct{})
+	dynamicClient.PrependReactor("create", "pipelineresources", func(ktesting.Action) (bool, runtime.Object, error) {
+		created <- struct{}{}
+		<-unblock
+		return false, nil, nil
+	})
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+	client := &http.Client{Transport: &http.Transport{ExpectContinueTimeout: time.Minute}}
+
+	post := func(read *int32) (int, Response) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, ts.URL, trackedBody{Reader: bytes.NewReader([]byte(`{}`)), read: read})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.ContentLength = 2
+		req.Header.Set("X-GitHub-Delivery", "d1")
+		req.Header.Set("Expect", "100-continue")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Error sending Post request: %s", err)
+		}
+		defer resp.Body.Close()
+		var body Response
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("Error reading response body: %s", err)
+		}
+		return resp.StatusCode, body
+	}
+
+	type result struct {
+		code int
+		body Response
+	}
+	var firstRead, redeliveryRead int32
+	first := make(chan result)
+	go func() {
+		code, body := post(&firstRead)
+		first <- result{code: code, body: body}
+	}()
+	<-created
+	redelivery := make(chan result)
+	go func() {
+		code, body := post(&redeliveryRead)
+		redelivery <- result{code: code, body: body}
+	}()
+	// Wait for the redelivery to join the first delivery
+	for joined := 0; joined == 0; {
+		time.Sleep(10 * time.Millisecond)
+		sink.Deliveries.mu.Lock()
+		joined = sink.Deliveries.inflight["d1"].joined
+		sink.Deliveries.mu.Unlock()
+	}
+	close(unblock)
+
+	got, gotRedelivery := <-first, <-redelivery
+	if got.code != http.StatusCreated || gotRedelivery.code != http.StatusCreated {
+		t.Errorf("Response codes = %d, %d, want %d", got.code, gotRedelivery.code, http.StatusCreated)
+	}
+	if got.body.EventID == "" || got.body.EventID != gotRedelivery.body.EventID {
+		t.Errorf("Event IDs = %q, %q, want the redelivery to get the response of the first delivery", got.body.EventID, gotRedelivery.body.EventID)
+	}
+	if len(getCreatedPipelineResources(t, dynamicClient.Actions())) != 1 {
+		t.Errorf("got %d resources, want the event to be processed once", len(dynamicClient.Actions()))
+	}
+	if atomic.LoadInt32(&firstRead) != 1 {
+		t.Error("the body of the first delivery was not sent")
+	}
+	if atomic.LoadInt32(&redeliveryRead) != 0 {
+		t.Error("the body of the coalesced delivery was sent, want it to be rejected before 100 Continue")
+	}
+
+	// Deliveries that do not overlap are processed each
+	post(new(int32))
+	if len(getCreatedPipelineResources(t, dynamicClient.Actions())) != 2 {
+		t.Errorf("got %d resources, want a later delivery to be processed", len(dynamicClient.Actions()))
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+)
+
+// errInterceptorOverloaded is returned for requests to a ClusterInterceptor
+// that are shed because of its concurrency.
+var errInterceptorOverloaded = errors.New("interceptor overloaded")
+
+// InterceptorLimiter limits the requests in flight to each ClusterInterceptor
+// with a concurrency, across all events and Triggers of the EventListener.
+type InterceptorLimiter struct {
+	mu     sync.Mutex
+	limits map[string]*concurrencyLimit
+}
+
+// concurrencyLimit holds the requests in flight to a ClusterInterceptor and
+// the number of requests waiting for one of them to finish.
+type concurrencyLimit struct {
+	concurrency triggersv1.InterceptorConcurrency
+	inflight    chan struct{}
+	queued      int32
+}
+
+// NewInterceptorLimiter returns an InterceptorLimiter without any request in
+// flight.
+func NewInterceptorLimiter() *InterceptorLimiter {
+	return &InterceptorLimiter{limits: map[string]*concurrencyLimit{}}
+}
+
+// limit returns the limit of the ClusterInterceptor with the name. The limit
+// is replaced when the concurrency of the ClusterInterceptor changes, and the
+// requests in flight under the old limit are not counted by the new one.
+func (l *InterceptorLimiter) limit(name string, c triggersv1.InterceptorConcurrency) *concurrencyLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cl, ok := l.limits[name]; ok && cl.concurrency == c {
+		return cl
+	}
+	cl := &concurrencyLimit{concurrency: c, inflight: make(chan struct{}, c.MaxInFlight)}
+	l.limits[name] = cl
+	return cl
+}
+
+// acquire waits until a request to the ClusterInterceptor with the name may
+// be sent, and returns the function that releases it once the response is
+// read. Requests that do not fit in the queue fail with
+// errInterceptorOverloaded, and queued requests fail when ctx is done.
+func (l *InterceptorLimiter) acquire(ctx context.Context, name string, c triggersv1.InterceptorConcurrency) (func(), error) {
+	cl := l.limit(name, c)
+	release := func() {
+		<-cl.inflight
+		interceptorInFlight.WithLabelValues(name).Dec()
+	}
+	select {
+	case cl.inflight <- struct{}{}:
+		interceptorInFlight.WithLabelValues(name).Inc()
+		return release, nil
+	default:
+	}
+
+	l.mu.Lock()
+	if cl.queued >= c.MaxQueued {
+		l.mu.Unlock()
+		return nil, fmt.Errorf("%w: %d requests in flight and %d queued", errInterceptorOverloaded, c.MaxInFlight, c.MaxQueued)
+	}
+	cl.queued++
+	l.mu.Unlock()
+	interceptorQueued.WithLabelValues(name).Inc()
+	defer func() {
+		l.mu.Lock()
+		cl.queued--
+		l.mu.Unlock()
+		interceptorQueued.WithLabelValues(name).Dec()
+	}()
+
+	select {
+	case cl.inflight <- struct{}{}:
+		interceptorInFlight.WithLabelValues(name).Inc()
+		return release, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("request to the interceptor was queued until the event timed out: %w", ctx.Err())
+	}
+}
+
+// limitedInterceptor is an interceptor whose request was acquired from an
+// InterceptorLimiter. The request is released once the body of the response
+// is closed, or when the request fails.
+type limitedInterceptor struct {
+	interceptors.Interceptor
+	release func()
+}
+
+func (i limitedInterceptor) ExecuteTrigger(request *http.Request) (*http.Response, error) {
+	resp, err := i.Interceptor.ExecuteTrigger(request)
+	if err != nil || resp == nil || resp.Body == nil {
+		i.release()
+		return resp, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: i.release}
+	return resp, nil
+}
+
+// releasingBody releases the request of a response when it is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.once.Do(b.release)
+	return b.ReadCloser.Close()
+}
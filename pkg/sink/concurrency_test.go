@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/logging"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	faketriggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInterceptorLimiter(t *testing.T) {
+	l := NewInterceptorLimiter()
+	c := triggersv1.InterceptorConcurrency{MaxInFlight: 1, MaxQueued: 1}
+	ctx := context.Background()
+
+	release, err := l.acquire(ctx, "ci", c)
+	if err != nil {
+		t.Fatalf("acquire() = %v", err)
+	}
+	queued := make(chan error)
+	go func() {
+		release, err := l.acquire(ctx, "ci", c)
+		if err == nil {
+			release()
+		}
+		queued <- err
+	}()
+	// Wait for the second request to be queued
+	for n := int32(0); n == 0; {
+		time.Sleep(10 * time.Millisecond)
+		l.mu.Lock()
+		n = l.limits["ci"].queued
+		l.mu.Unlock()
+	}
+	if _, err := l.acquire(ctx, "ci", c); !errors.Is(err, errInterceptorOverloaded) {
+		t.Errorf("acquire() = %v, want the request over the queue to be shed", err)
+	}
+	// Other ClusterInterceptors have their own limit
+	other, err := l.acquire(ctx, "other", c)
+	if err != nil {
+		t.Fatalf("acquire() = %v for another ClusterInterceptor", err)
+	}
+	other()
+
+	release()
+	if err := <-queued; err != nil {
+		t.Errorf("acquire() = %v, want the queued request to proceed", err)
+	}
+
+	// Queued requests fail when the event times out
+	release, _ = l.acquire(ctx, "ci", c)
+	defer release()
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(timeout, "ci", c); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquire() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestExecuteInterceptor_clusterInterceptorConcurrency(t *testing.T) {
+	started, unblock := make(chan struct{}, 1), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-unblock
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+	client := srv.Client()
+	u, _ := url.Parse(srv.URL)
+	// Redirect all requests to the fake server.
+	client.Transport = &http.Transport{
+		Proxy: http.ProxyURL(u),
+	}
+
+	ci := &triggersv1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "github"},
+		Spec: triggersv1.ClusterInterceptorSpec{
+			ClientConfig: triggersv1.ClientConfig{
+				Service: &triggersv1.ServiceReference{Name: "github-interceptor", Namespace: "tekton-pipelines"},
+			},
+			Concurrency: &triggersv1.InterceptorConcurrency{MaxInFlight: 1},
+		},
+	}
+	logger, _ := logging.NewLogger("", "")
+	r := Sink{
+		HTTPClient:        client,
+		TriggersClient:    faketriggersclientset.NewSimpleClientset(ci),
+		Logger:            logger,
+		InterceptorLimits: NewInterceptorLimiter(),
+	}
+	trigger := bldr.Trigger("tt", "v1alpha1", bldr.EventListenerClusterInterceptor("github"))
+	execute := func() error {
+		req, err := http.NewRequest(http.MethodPost, "/", nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest: %v", err)
+		}
+		_, _, err = r.executeInterceptors(&trigger, namespace, req, []byte(`{}`), logger)
+		return err
+	}
+
+	first := make(chan error)
+	go func() { first <- execute() }()
+	<-started
+	var rerr *reasonError
+	if err := execute(); !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonInterceptorOverloaded {
+		t.Errorf("expected %s error while a request is in flight, got: %v", triggersv1.ReasonInterceptorOverloaded, err)
+	}
+	close(unblock)
+	if err := <-first; err != nil {
+		t.Fatalf("executeInterceptors: %v", err)
+	}
+	// The request is released once its response is read
+	if err := execute(); err != nil {
+		t.Errorf("executeInterceptors: %v, want the request to be sent after the first one finished", err)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/resources"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// CorrelationIDHeader is the request header in which the sender of an event
+// may send an ID to correlate it with. It is passed on to interceptors,
+// returned in the response, logged and set on the created resources.
+const CorrelationIDHeader = "Tekton-Correlation-Id"
+
+// providerDeliveryHeaders are the headers in which Git providers send the ID
+// of a delivery: GitHub, GitLab and Bitbucket, in that order.
+var providerDeliveryHeaders = []string{"X-GitHub-Delivery", "X-Gitlab-Event-UUID", "X-Request-UUID"}
+
+// eventDeliveryID returns the ID of the delivery of an event, read from the
+// deduplication header of the EventListener or else from the header of the
+// provider that sent it.
+func eventDeliveryID(el *triggersv1.EventListener, header http.Header) string {
+	if id := deliveryIDOf(el, header); id != "" {
+		return id
+	}
+	for _, h := range providerDeliveryHeaders {
+		if id := header.Get(h); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// withCorrelation adds the delivery and correlation IDs of an event to the
+// fields of its logger.
+func withCorrelation(log *zap.SugaredLogger, deliveryID, correlationID string) *zap.SugaredLogger {
+	if deliveryID != "" {
+		log = log.With(zap.String(triggersv1.DeliveryIDLabelKey, deliveryID))
+	}
+	if correlationID != "" {
+		log = log.With(zap.String(triggersv1.CorrelationIDLabelKey, correlationID))
+	}
+	return log
+}
+
+// correlate annotates a resource with the delivery and correlation IDs of the
+// event it is created for, and labels it with the IDs that are valid label
+// values, so that resources can be selected by them.
+func correlate(rt json.RawMessage, deliveryID, correlationID string) (json.RawMessage, error) {
+	ids := map[string]string{}
+	if deliveryID != "" {
+		ids[triggersv1.DeliveryIDLabelKey] = deliveryID
+	}
+	if correlationID != "" {
+		ids[triggersv1.CorrelationIDLabelKey] = correlationID
+	}
+	if len(ids) == 0 {
+		return rt, nil
+	}
+	data := new(unstructured.Unstructured)
+	if err := data.UnmarshalJSON(rt); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal json: %w", err)
+	}
+	annotations := map[string]string{}
+	labels := map[string]string{}
+	for k, v := range ids {
+		annotations[strings.TrimPrefix(k, "/")] = v
+		if len(validation.IsValidLabelValue(v)) == 0 {
+			labels[k] = v
+		}
+	}
+	return resources.AddLabels(resources.AddAnnotations(data, annotations), labels).MarshalJSON()
+}
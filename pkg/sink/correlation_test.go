@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestEventDeliveryID(t *testing.T) {
+	dedup := bldr.EventListener("el", "ns", bldr.EventListenerSpec(
+		bldr.EventListenerDeduplication(triggersv1.Deduplication{Header: "X-Delivery", Group: "push"}),
+	))
+	plain := bldr.EventListener("el", "ns")
+	tests := []struct {
+		name   string
+		el     *triggersv1.EventListener
+		header http.Header
+		want   string
+	}{{
+		name:   "github",
+		el:     plain,
+		header: http.Header{"X-Github-Delivery": {"72d3162e"}},
+		want:   "72d3162e",
+	}, {
+		name:   "gitlab",
+		el:     plain,
+		header: http.Header{"X-Gitlab-Event-Uuid": {"13792a34"}},
+		want:   "13792a34",
+	}, {
+		name:   "bitbucket",
+		el:     plain,
+		header: http.Header{"X-Request-Uuid": {"afe23a5f"}},
+		want:   "afe23a5f",
+	}, {
+		name:   "deduplication header",
+		el:     dedup,
+		header: http.Header{"X-Delivery": {"d1"}, "X-Github-Delivery": {"72d3162e"}},
+		want:   "d1",
+	}, {
+		name:   "deduplication header missing",
+		el:     dedup,
+		header: http.Header{"X-Github-Delivery": {"72d3162e"}},
+		want:   "72d3162e",
+	}, {
+		name:   "none",
+		el:     plain,
+		header: http.Header{"X-Github-Event": {"push"}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := eventDeliveryID(tc.el, tc.header); got != tc.want {
+				t.Errorf("eventDeliveryID() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_Correlation(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"ref"},"spec":{"type":"git"}}`)}),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1", bldr.EventListenerTriggerName("my-trigger")),
+	))
+	tests := []struct {
+		name            string
+		correlationID   string
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{{
+		name:          "label values",
+		correlationID: "build-42",
+		wantLabels: map[string]string{
+			"triggers.tekton.dev/delivery-id":    "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+			"triggers.tekton.dev/correlation-id": "build-42",
+		},
+		wantAnnotations: map[string]string{
+			"triggers.tekton.dev/delivery-id":    "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+			"triggers.tekton.dev/correlation-id": "build-42",
+		},
+	}, {
+		name:          "not a label value",
+		correlationID: "ci run #42",
+		wantLabels: map[string]string{
+			"triggers.tekton.dev/delivery-id": "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		},
+		wantAnnotations: map[string]string{
+			"triggers.tekton.dev/delivery-id":    "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+			"triggers.tekton.dev/correlation-id": "ci run #42",
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, dynamicClient := getSinkAssets(t, test.Resources{
+				TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+				EventListeners:   []*triggersv1.EventListener{el},
+			}, el.Name, DefaultAuthOverride{})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+			req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+			req.Header.Set(CorrelationIDHeader, tc.correlationID)
+			sink.HandleEvent(rec, req)
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("Response code = %d, want %d", rec.Code, http.StatusCreated)
+			}
+			if got := rec.Header().Get(CorrelationIDHeader); got != tc.correlationID {
+				t.Errorf("%s response header = %q, want %q", CorrelationIDHeader, got, tc.correlationID)
+			}
+			var body Response
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.DeliveryID != "72d3162e-cc78-11e3-81ab-4c9367dc0958" || body.CorrelationID != tc.correlationID {
+				t.Errorf("Response has delivery ID %q and correlation ID %q", body.DeliveryID, body.CorrelationID)
+			}
+
+			prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+			if len(prs) != 1 {
+				t.Fatalf("got %d created resources, want 1", len(prs))
+			}
+			labels := map[string]string{}
+			for k, v := range prs[0].Labels {
+				if strings.HasSuffix(k, "-id") {
+					labels[k] = v
+				}
+			}
+			if diff := cmp.Diff(tc.wantLabels, labels); diff != "" {
+				t.Errorf("created resource has unexpected labels -want,+got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, prs[0].Annotations); diff != "" {
+				t.Errorf("created resource has unexpected annotations -want,+got: %s", diff)
+			}
+		})
+	}
+}
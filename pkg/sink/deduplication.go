@@ -0,0 +1,361 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"go.uber.org/zap"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationclient "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const (
+	// defaultDeliveryTTL is how long a delivery is remembered, unless the
+	// EventListener specifies its own TTL.
+	defaultDeliveryTTL = time.Hour
+	// deliverySweepInterval is how often the Leases and ConfigMaps of
+	// expired deliveries are deleted.
+	deliverySweepInterval = 10 * time.Minute
+	// deliveryGroupLabel labels the Leases and ConfigMaps of the deliveries
+	// of a deduplication group.
+	deliveryGroupLabel = triggersv1.GroupName + "/deduplication-group"
+	// deliveryExpiresAnnotation holds the time that the delivery recorded
+	// in a ConfigMap expires at.
+	deliveryExpiresAnnotation = triggersv1.GroupName + "/delivery-expires"
+)
+
+// errDuplicateDelivery is returned for an event whose delivery was already
+// claimed by an EventListener of its deduplication group.
+var errDuplicateDelivery = errors.New("delivery was already claimed by an EventListener of the deduplication group")
+
+// deliveryStore records the deliveries that the EventListeners of a
+// deduplication group claimed.
+type deliveryStore interface {
+	// claim records the delivery until ttl after now, and reports whether
+	// no EventListener held it.
+	claim(id string, now time.Time, ttl time.Duration) (bool, error)
+	// release removes the record of a delivery, so that a redelivery of
+	// it is processed again, unless another EventListener took it over.
+	release(id string) error
+	// sweep removes the records of the deliveries that expired before now.
+	sweep(now time.Time) error
+}
+
+// claimDelivery claims the delivery of an event for the EventListener. It
+// returns a function that releases the claim, which is nil if the event is
+// not deduplicated, or errDuplicateDelivery if the delivery is already
+// claimed. Events are processed rather than dropped if the claim cannot be
+// recorded.
+func (r Sink) claimDelivery(el *triggersv1.EventListener, header http.Header, log *zap.SugaredLogger) (func(), error) {
+	d := el.Spec.Deduplication
+	if d == nil {
+		return nil, nil
+	}
+	id := deliveryIDOf(el, header)
+	if id == "" {
+		log.Debugf("Processing event without the %s header without deduplication", d.Header)
+		return nil, nil
+	}
+	store, err := r.deliveryStore(d)
+	if err != nil {
+		log.Errorf("Processing delivery %s without deduplication: %s", id, err)
+		return nil, nil
+	}
+	claimed, err := store.claim(id, time.Now(), deliveryTTL(d))
+	if err != nil {
+		log.Errorf("Processing delivery %s without deduplication: %s", id, err)
+		return nil, nil
+	}
+	if !claimed {
+		return nil, errDuplicateDelivery
+	}
+	return func() {
+		if err := store.release(id); err != nil {
+			log.Errorf("Error releasing delivery %s: %s", id, err)
+		}
+	}, nil
+}
+
+// deliveryIDOf returns the delivery ID of an event, read from the
+// deduplication header of the EventListener, if any.
+func deliveryIDOf(el *triggersv1.EventListener, header http.Header) string {
+	if el.Spec.Deduplication == nil {
+		return ""
+	}
+	return header.Get(el.Spec.Deduplication.Header)
+}
+
+// deliveryStore returns the store of the deliveries of a deduplication group.
+func (r Sink) deliveryStore(d *triggersv1.Deduplication) (deliveryStore, error) {
+	holder := r.EventListenerNamespace + "/" + r.EventListenerName
+	ns := d.Namespace
+	if ns == "" {
+		ns = r.EventListenerNamespace
+	}
+	switch d.Backend {
+	case triggersv1.DeduplicationConfigMap:
+		return &configMapDeliveryStore{client: r.KubeClientSet.CoreV1().ConfigMaps(ns), group: d.Group, holder: holder}, nil
+	default:
+		return &leaseDeliveryStore{client: r.KubeClientSet.CoordinationV1().Leases(ns), group: d.Group, holder: holder}, nil
+	}
+}
+
+// SweepDeliveries periodically deletes the Leases and ConfigMaps of the
+// deliveries that expired, until stopCh is closed.
+func (r Sink) SweepDeliveries(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(deliverySweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			r.sweepDeliveries()
+		}
+	}
+}
+
+func (r Sink) sweepDeliveries() {
+	el, err := r.TriggersClient.TriggersV1alpha1().EventListeners(r.EventListenerNamespace).Get(r.EventListenerName, metav1.GetOptions{})
+	if err != nil {
+		r.Logger.Errorf("Error getting EventListener %s in Namespace %s: %s", r.EventListenerName, r.EventListenerNamespace, err)
+		return
+	}
+	if el.Spec.Deduplication == nil {
+		return
+	}
+	store, err := r.deliveryStore(el.Spec.Deduplication)
+	if err == nil {
+		err = store.sweep(time.Now())
+	}
+	if err != nil {
+		r.Logger.Errorf("Error deleting expired deliveries: %s", err)
+	}
+}
+
+// acknowledgeDuplicate responds with 200 OK to an event whose delivery was
+// already claimed, so that the provider does not redeliver it.
+func (r Sink) acknowledgeDuplicate(response http.ResponseWriter, eventID string) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusOK)
+	body := Response{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		EventID:       eventID,
+		Message:       errDuplicateDelivery.Error(),
+	}
+	if err := json.NewEncoder(response).Encode(body); err != nil {
+		r.Logger.Errorf("failed to write back sink response: %v", err)
+	}
+}
+
+// deliveryTTL returns how long the deliveries of a deduplication group are
+// remembered.
+func deliveryTTL(d *triggersv1.Deduplication) time.Duration {
+	if d.TTLSeconds > 0 {
+		return time.Duration(d.TTLSeconds) * time.Second
+	}
+	return defaultDeliveryTTL
+}
+
+// deliveryObjectName returns the name of the Lease or ConfigMap that records
+// a delivery. Delivery IDs are hashed since they are not valid names.
+func deliveryObjectName(group, id string) string {
+	sum := sha256.Sum256([]byte(group + "\n" + id))
+	return "triggers-delivery-" + hex.EncodeToString(sum[:16])
+}
+
+// leaseDeliveryStore records each delivery in a Lease that is held for the
+// TTL of the delivery.
+type leaseDeliveryStore struct {
+	client coordinationclient.LeaseInterface
+	group  string
+	holder string
+}
+
+func (s *leaseDeliveryStore) claim(id string, now time.Time, ttl time.Duration) (bool, error) {
+	acquired := metav1.NewMicroTime(now)
+	seconds := int32(ttl / time.Second)
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       &s.holder,
+		LeaseDurationSeconds: &seconds,
+		AcquireTime:          &acquired,
+		RenewTime:            &acquired,
+	}
+	_, err := s.client.Create(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   deliveryObjectName(s.group, id),
+			Labels: map[string]string{deliveryGroupLabel: s.group},
+		},
+		Spec: spec,
+	})
+	if !kerrors.IsAlreadyExists(err) {
+		return err == nil, err
+	}
+	existing, err := s.client.Get(deliveryObjectName(s.group, id), metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if !leaseExpired(existing, now) {
+		return false, nil
+	}
+	// The Lease of an expired delivery is taken over, unless another
+	// EventListener took it over first
+	existing.Spec = spec
+	if _, err := s.client.Update(existing); err != nil {
+		if kerrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *leaseDeliveryStore) release(id string) error {
+	lease, err := s.client.Get(deliveryObjectName(s.group, id), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != s.holder {
+		return nil
+	}
+	return deleteUnchanged(s.client.Delete, lease.ObjectMeta)
+}
+
+func (s *leaseDeliveryStore) sweep(now time.Time) error {
+	leases, err := s.client.List(metav1.ListOptions{LabelSelector: deliveryGroupLabel + "=" + s.group})
+	if err != nil {
+		return err
+	}
+	for i := range leases.Items {
+		if !leaseExpired(&leases.Items[i], now) {
+			continue
+		}
+		if err := s.client.Delete(leases.Items[i].Name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return now.After(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
+
+// configMapDeliveryStore records each delivery in a ConfigMap that is
+// annotated with the time the delivery expires at.
+type configMapDeliveryStore struct {
+	client coreclient.ConfigMapInterface
+	group  string
+	holder string
+}
+
+func (s *configMapDeliveryStore) claim(id string, now time.Time, ttl time.Duration) (bool, error) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        deliveryObjectName(s.group, id),
+			Labels:      map[string]string{deliveryGroupLabel: s.group},
+			Annotations: map[string]string{deliveryExpiresAnnotation: now.Add(ttl).UTC().Format(time.RFC3339)},
+		},
+		Data: map[string]string{"holder": s.holder},
+	}
+	_, err := s.client.Create(cm)
+	if !kerrors.IsAlreadyExists(err) {
+		return err == nil, err
+	}
+	existing, err := s.client.Get(cm.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if !configMapExpired(existing, now) {
+		return false, nil
+	}
+	existing.Annotations = cm.Annotations
+	existing.Data = cm.Data
+	if _, err := s.client.Update(existing); err != nil {
+		if kerrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *configMapDeliveryStore) release(id string) error {
+	cm, err := s.client.Get(deliveryObjectName(s.group, id), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data["holder"] != s.holder {
+		return nil
+	}
+	return deleteUnchanged(s.client.Delete, cm.ObjectMeta)
+}
+
+func (s *configMapDeliveryStore) sweep(now time.Time) error {
+	cms, err := s.client.List(metav1.ListOptions{LabelSelector: deliveryGroupLabel + "=" + s.group})
+	if err != nil {
+		return err
+	}
+	for i := range cms.Items {
+		if !configMapExpired(&cms.Items[i], now) {
+			continue
+		}
+		if err := s.client.Delete(cms.Items[i].Name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func configMapExpired(cm *corev1.ConfigMap, now time.Time) bool {
+	expires, err := time.Parse(time.RFC3339, cm.Annotations[deliveryExpiresAnnotation])
+	return err != nil || now.After(expires)
+}
+
+// deleteUnchanged deletes the object that records a delivery if it is still
+// the version that was read, so that a claim that another EventListener took
+// over in the meantime is kept.
+func deleteUnchanged(del func(string, *metav1.DeleteOptions) error, meta metav1.ObjectMeta) error {
+	err := del(meta.Name, &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &meta.UID, ResourceVersion: &meta.ResourceVersion},
+	})
+	if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
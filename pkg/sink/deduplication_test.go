@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	dynamicclientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
+	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeliveryStores(t *testing.T) {
+	stores := map[string]func(kube *fakekubeclientset.Clientset, holder string) deliveryStore{
+		"lease": func(kube *fakekubeclientset.Clientset, holder string) deliveryStore {
+			return &leaseDeliveryStore{client: kube.CoordinationV1().Leases(namespace), group: "push", holder: holder}
+		},
+		"configmap": func(kube *fakekubeclientset.Clientset, holder string) deliveryStore {
+			return &configMapDeliveryStore{client: kube.CoreV1().ConfigMaps(namespace), group: "push", holder: holder}
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			kube := fakekubeclientset.NewSimpleClientset()
+			a, b := newStore(kube, "foo/el-a"), newStore(kube, "foo/el-b")
+			now := time.Now()
+
+			claim := func(s deliveryStore, id string, now time.Time, want bool) {
+				t.Helper()
+				got, err := s.claim(id, now, time.Hour)
+				if err != nil {
+					t.Fatalf("claim(%s) returned error: %s", id, err)
+				}
+				if got != want {
+					t.Errorf("claim(%s) = %t, want %t", id, got, want)
+				}
+			}
+			claim(a, "d1", now, true)
+			claim(b, "d1", now, false)
+			claim(b, "d2", now, true)
+			// An expired delivery is claimed again
+			claim(b, "d1", now.Add(2*time.Hour), true)
+			// A delivery that another EventListener took over is kept
+			if err := a.release("d1"); err != nil {
+				t.Fatalf("release returned error: %s", err)
+			}
+			claim(a, "d1", now.Add(2*time.Hour), false)
+
+			if err := a.release("d2"); err != nil {
+				t.Fatalf("release returned error: %s", err)
+			}
+			claim(a, "d2", now, false)
+			if err := b.release("d2"); err != nil {
+				t.Fatalf("release returned error: %s", err)
+			}
+			claim(a, "d2", now, true)
+			if err := a.release("unknown"); err != nil {
+				t.Errorf("release of an unknown delivery returned error: %s", err)
+			}
+
+			// Only d1 was claimed again after d2 expired
+			if err := a.sweep(now.Add(90 * time.Minute)); err != nil {
+				t.Fatalf("sweep returned error: %s", err)
+			}
+			claim(b, "d1", now.Add(90*time.Minute), false)
+			claim(b, "d2", now, true)
+		})
+	}
+}
+
+func TestDeliveryStore_LeaseObject(t *testing.T) {
+	kube := fakekubeclientset.NewSimpleClientset()
+	s := &leaseDeliveryStore{client: kube.CoordinationV1().Leases(namespace), group: "push", holder: "foo/el-a"}
+	if _, err := s.claim("d1", time.Now(), 90*time.Second); err != nil {
+		t.Fatalf("claim returned error: %s", err)
+	}
+	lease, err := kube.CoordinationV1().Leases(namespace).Get(deliveryObjectName("push", "d1"), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting Lease: %s", err)
+	}
+	if got := lease.Labels[deliveryGroupLabel]; got != "push" {
+		t.Errorf("Lease group label = %q, want push", got)
+	}
+	if got := *lease.Spec.HolderIdentity; got != "foo/el-a" {
+		t.Errorf("Lease holder = %q, want foo/el-a", got)
+	}
+	if got := *lease.Spec.LeaseDurationSeconds; got != 90 {
+		t.Errorf("Lease duration = %d, want 90", got)
+	}
+}
+
+func TestHandleEvent_Deduplication(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"ref"},"spec":{"type":"git"}}`)}),
+		))
+	tb := bldr.TriggerBinding("my-triggerbinding", namespace,
+		bldr.TriggerBindingSpec(
+			bldr.TriggerBindingParam("branch", "$(body.ref)"),
+		))
+	dedup := bldr.EventListenerDeduplication(triggersv1.Deduplication{Header: "X-GitHub-Delivery", Group: "push"})
+	elA := bldr.EventListener("el-a", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1", bldr.EventListenerTriggerName("my-trigger")),
+		dedup,
+	))
+	elB := bldr.EventListener("el-b", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1", bldr.EventListenerTriggerName("my-trigger")),
+		dedup,
+	))
+	elFailing := bldr.EventListener("el-failing", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("my-trigger"),
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+		),
+		dedup,
+	))
+	sinkA, _ := getSinkAssets(t, test.Resources{
+		TriggerBindings:  []*triggersv1.TriggerBinding{tb},
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{elA, elB, elFailing},
+	}, elA.Name, DefaultAuthOverride{})
+	sinkB, sinkFailing := sinkA, sinkA
+	sinkB.EventListenerName = elB.Name
+	sinkFailing.EventListenerName = elFailing.Name
+
+	post := func(sink Sink, delivery string) (int, Response) {
+		t.Helper()
+		// Every delivery creates the same resource
+		sink.DynamicClient = dynamicclientset.New(tekton.WithClient(fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())))
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+		if delivery != "" {
+			req.Header.Set("X-GitHub-Delivery", delivery)
+		}
+		sink.HandleEvent(rec, req)
+		var body Response
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("Error reading response body: %s", err)
+		}
+		return rec.Code, body
+	}
+
+	if code, _ := post(sinkA, "d1"); code != http.StatusCreated {
+		t.Errorf("first delivery got code %d, want %d", code, http.StatusCreated)
+	}
+	code, body := post(sinkB, "d1")
+	if code != http.StatusOK {
+		t.Errorf("duplicate delivery got code %d, want %d", code, http.StatusOK)
+	}
+	if body.Message != errDuplicateDelivery.Error() {
+		t.Errorf("duplicate delivery got message %q, want %q", body.Message, errDuplicateDelivery.Error())
+	}
+	// Events without a delivery ID are not deduplicated
+	for _, sink := range []Sink{sinkA, sinkB} {
+		if code, _ := post(sink, ""); code != http.StatusCreated {
+			t.Errorf("event without delivery ID got code %d, want %d", code, http.StatusCreated)
+		}
+	}
+
+	// A failed delivery is released, so that another EventListener processes it
+	if code, _ := post(sinkFailing, "d2"); code != http.StatusAccepted {
+		t.Errorf("failed delivery got code %d, want %d", code, http.StatusAccepted)
+	}
+	if code, _ := post(sinkB, "d2"); code != http.StatusCreated {
+		t.Errorf("redelivery of a failed delivery got code %d, want %d", code, http.StatusCreated)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/template"
+)
+
+// checkEventAge returns an error if the event was sent longer ago than the
+// maximum age, or if it does not have a valid timestamp.
+func checkEventAge(a *triggersv1.EventAge, body []byte, header http.Header, now time.Time) error {
+	value, err := eventTimestamp(a, body, header)
+	if err != nil {
+		return err
+	}
+	sent, err := parseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	if age := now.Sub(sent); age > a.MaxAge.Duration {
+		return fmt.Errorf("event sent at %s is %s old, more than the maximum age of %s", sent.Format(time.RFC3339), age.Round(time.Second), a.MaxAge.Duration)
+	}
+	return nil
+}
+
+// eventTimestamp returns the value of the header or body field with the time
+// the event was sent.
+func eventTimestamp(a *triggersv1.EventAge, body []byte, header http.Header) (string, error) {
+	if a.Header != "" {
+		if v := header.Get(a.Header); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("event has no timestamp in header %s", a.Header)
+	}
+	event, err := template.NewEvent(body, header, nil)
+	if err != nil {
+		return "", err
+	}
+	v, err := template.ParseJSONPath(event, "$(body."+a.Body+")")
+	if err != nil {
+		return "", fmt.Errorf("event has no timestamp in body field %s: %w", a.Body, err)
+	}
+	return v, nil
+}
+
+// parseTimestamp parses a timestamp in RFC 3339 format, as an HTTP date, or as
+// Unix seconds.
+func parseTimestamp(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.Unix(0, int64(secs*float64(time.Second))), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return t, nil
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid event timestamp %q", v)
+}
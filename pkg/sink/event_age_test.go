@@ -0,0 +1,140 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_checkEventAge(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	hour := metav1.Duration{Duration: time.Hour}
+	tests := []struct {
+		name    string
+		age     triggersv1.EventAge
+		body    string
+		header  http.Header
+		wantErr bool
+	}{{
+		name: "RFC 3339 body field",
+		age:  triggersv1.EventAge{MaxAge: hour, Body: "head_commit.timestamp"},
+		body: `{"head_commit": {"timestamp": "2020-06-01T13:30:00+02:00"}}`,
+	}, {
+		name: "Unix seconds body field",
+		age:  triggersv1.EventAge{MaxAge: hour, Body: "repository.pushed_at"},
+		body: `{"repository": {"pushed_at": 1591010000}}`,
+	}, {
+		name:   "HTTP date header",
+		age:    triggersv1.EventAge{MaxAge: hour, Header: "X-Sent"},
+		header: http.Header{"X-Sent": []string{"Mon, 01 Jun 2020 11:30:00 GMT"}},
+	}, {
+		name:    "stale body field",
+		age:     triggersv1.EventAge{MaxAge: hour, Body: "head_commit.timestamp"},
+		body:    `{"head_commit": {"timestamp": "2020-06-01T10:59:59Z"}}`,
+		wantErr: true,
+	}, {
+		name:    "stale header",
+		age:     triggersv1.EventAge{MaxAge: hour, Header: "X-Sent"},
+		header:  http.Header{"X-Sent": []string{"1590000000"}},
+		wantErr: true,
+	}, {
+		name:    "missing body field",
+		age:     triggersv1.EventAge{MaxAge: hour, Body: "head_commit.timestamp"},
+		body:    `{}`,
+		wantErr: true,
+	}, {
+		name:    "missing header",
+		age:     triggersv1.EventAge{MaxAge: hour, Header: "X-Sent"},
+		wantErr: true,
+	}, {
+		name:    "invalid timestamp",
+		age:     triggersv1.EventAge{MaxAge: hour, Body: "timestamp"},
+		body:    `{"timestamp": "yesterday"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(tt.body)
+			if tt.body == "" {
+				body = nil
+			}
+			err := checkEventAge(&tt.age, body, tt.header, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkEventAge() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_MaxEventAge(t *testing.T) {
+	tests := []struct {
+		name     string
+		sent     time.Time
+		wantCode int
+	}{{
+		name:     "recent event",
+		sent:     time.Now().Add(-time.Minute),
+		wantCode: http.StatusCreated,
+	}, {
+		name:     "stale event",
+		sent:     time.Now().Add(-2 * time.Hour),
+		wantCode: http.StatusAccepted,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+					bldr.EventListenerTriggerMaxEventAge(triggersv1.EventAge{
+						MaxAge: metav1.Duration{Duration: time.Hour},
+						Header: "X-Sent",
+					}),
+				),
+			))
+			sink, dynamicClient := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{"url": "https://example.com"}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Event", "push")
+			req.Header.Set("X-Sent", tt.sent.Format(time.RFC3339))
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Error sending event: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Response code = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if created := len(dynamicClient.Actions()) > 0; created != (tt.wantCode == http.StatusCreated) {
+				t.Errorf("Resources created = %t, want %t", created, tt.wantCode == http.StatusCreated)
+			}
+		})
+	}
+}
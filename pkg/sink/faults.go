@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"math/rand"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// FaultInjector injects failures into the processing of events, so that the
+// alerting and retries around an EventListener can be tested in staging. A
+// nil FaultInjector injects nothing. Rates are fractions between 0 and 1.
+type FaultInjector struct {
+	// InterceptorLatency is added before calling an interceptor, at
+	// InterceptorLatencyRate.
+	InterceptorLatency     time.Duration
+	InterceptorLatencyRate float64
+	// CreateFailureRate fails the creation of resources with a Service
+	// Unavailable error.
+	CreateFailureRate float64
+	// SecretDropRate fails reading secrets, e.g. of interceptors, as if they
+	// did not exist.
+	SecretDropRate float64
+
+	// random returns a number in [0, 1). It defaults to rand.Float64.
+	random func() float64
+}
+
+// NewFaultInjector returns the FaultInjector configured by the args, or nil
+// if fault injection is not enabled.
+func NewFaultInjector(args Args) *FaultInjector {
+	if !args.FaultInjection {
+		return nil
+	}
+	return &FaultInjector{
+		InterceptorLatency:     args.FaultInterceptorLatency,
+		InterceptorLatencyRate: args.FaultInterceptorLatencyRate,
+		CreateFailureRate:      args.FaultCreateFailureRate,
+		SecretDropRate:         args.FaultSecretDropRate,
+	}
+}
+
+// inject reports whether a fault happens at the rate and counts it.
+func (f *FaultInjector) inject(fault string, rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	random := f.random
+	if random == nil {
+		random = rand.Float64
+	}
+	if random() >= rate {
+		return false
+	}
+	injectedFaults.WithLabelValues(fault).Inc()
+	return true
+}
+
+// delayInterceptor sleeps before an interceptor is called.
+func (f *FaultInjector) delayInterceptor() {
+	if f != nil && f.inject("interceptor-latency", f.InterceptorLatencyRate) {
+		time.Sleep(f.InterceptorLatency)
+	}
+}
+
+// createFailure returns an error for a resource that should fail to be
+// created.
+func (f *FaultInjector) createFailure() error {
+	if f != nil && f.inject("create-failure", f.CreateFailureRate) {
+		return kerrors.NewServiceUnavailable("injected resource creation failure")
+	}
+	return nil
+}
+
+// KubeClient returns a client that drops secrets at the SecretDropRate.
+func (f *FaultInjector) KubeClient(c kubernetes.Interface) kubernetes.Interface {
+	if f == nil || f.SecretDropRate <= 0 {
+		return c
+	}
+	return &faultKubeClient{Interface: c, f: f}
+}
+
+type faultKubeClient struct {
+	kubernetes.Interface
+	f *FaultInjector
+}
+
+func (c *faultKubeClient) CoreV1() corev1client.CoreV1Interface {
+	return &faultCoreV1{CoreV1Interface: c.Interface.CoreV1(), f: c.f}
+}
+
+type faultCoreV1 struct {
+	corev1client.CoreV1Interface
+	f *FaultInjector
+}
+
+func (c *faultCoreV1) Secrets(namespace string) corev1client.SecretInterface {
+	return &faultSecrets{SecretInterface: c.CoreV1Interface.Secrets(namespace), f: c.f}
+}
+
+type faultSecrets struct {
+	corev1client.SecretInterface
+	f *FaultInjector
+}
+
+func (s *faultSecrets) Get(name string, options metav1.GetOptions) (*corev1.Secret, error) {
+	if s.f.inject("secret-drop", s.f.SecretDropRate) {
+		return nil, kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, name)
+	}
+	return s.SecretInterface.Get(name, options)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFaultInjector(t *testing.T) {
+	var nilFaults *FaultInjector
+	nilFaults.delayInterceptor()
+	if err := nilFaults.createFailure(); err != nil {
+		t.Errorf("createFailure() of nil FaultInjector = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		random      float64
+		wantFailure bool
+	}{{
+		name:        "below rate",
+		random:      0.1,
+		wantFailure: true,
+	}, {
+		name:   "at rate",
+		random: 0.5,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &FaultInjector{
+				CreateFailureRate: 0.5,
+				SecretDropRate:    0.5,
+				random:            func() float64 { return tt.random },
+			}
+			err := f.createFailure()
+			if (err != nil) != tt.wantFailure || (err != nil && !kerrors.IsServiceUnavailable(err)) {
+				t.Errorf("createFailure() = %v, want failure %v", err, tt.wantFailure)
+			}
+
+			kubeClient := fakekubeclientset.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: namespace},
+			})
+			_, err = f.KubeClient(kubeClient).CoreV1().Secrets(namespace).Get("token", metav1.GetOptions{})
+			if (err != nil) != tt.wantFailure || (err != nil && !kerrors.IsNotFound(err)) {
+				t.Errorf("Get() of secret = %v, want failure %v", err, tt.wantFailure)
+			}
+		})
+	}
+}
+
+func TestNewFaultInjector(t *testing.T) {
+	if f := NewFaultInjector(Args{FaultCreateFailureRate: 1}); f != nil {
+		t.Errorf("NewFaultInjector() without fault injection = %+v, want nil", f)
+	}
+	f := NewFaultInjector(Args{FaultInjection: true, FaultCreateFailureRate: 1})
+	if f == nil || f.CreateFailureRate != 1 {
+		t.Errorf("NewFaultInjector() = %+v", f)
+	}
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	if f.KubeClient(kubeClient) != kubeClient {
+		t.Error("KubeClient() wrapped the client without a secret drop rate")
+	}
+}
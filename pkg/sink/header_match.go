@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// matchHeaders returns an error if the header does not match all of the
+// HeaderMatches.
+func matchHeaders(matches []triggersv1.HeaderMatch, header http.Header) error {
+	for _, m := range matches {
+		values := header[http.CanonicalHeaderKey(m.Name)]
+		if !matchHeader(m, values) {
+			return fmt.Errorf("header %s with values %q does not match", m.Name, values)
+		}
+	}
+	return nil
+}
+
+// matchHeader returns true if any of the values of a header is one of the
+// values or starts with one of the prefixes of the HeaderMatch.
+func matchHeader(m triggersv1.HeaderMatch, values []string) bool {
+	for _, v := range values {
+		for _, want := range m.Values {
+			if v == want {
+				return true
+			}
+		}
+		for _, p := range m.Prefixes {
+			if strings.HasPrefix(v, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
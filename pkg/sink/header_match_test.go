@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"net/http"
+	"testing"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+func TestMatchHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		matches []triggersv1.HeaderMatch
+		header  http.Header
+		wantErr bool
+	}{{
+		name:   "no matches",
+		header: http.Header{},
+	}, {
+		name:    "exact value",
+		matches: []triggersv1.HeaderMatch{{Name: "X-GitHub-Event", Values: []string{"push", "create"}}},
+		header:  http.Header{"X-Github-Event": {"push"}},
+	}, {
+		name:    "other value",
+		matches: []triggersv1.HeaderMatch{{Name: "X-GitHub-Event", Values: []string{"push"}}},
+		header:  http.Header{"X-Github-Event": {"pull_request"}},
+		wantErr: true,
+	}, {
+		name:    "prefix",
+		matches: []triggersv1.HeaderMatch{{Name: "X-Event-Key", Prefixes: []string{"pr:"}}},
+		header:  http.Header{"X-Event-Key": {"pr:opened"}},
+	}, {
+		name:    "missing header",
+		matches: []triggersv1.HeaderMatch{{Name: "X-Event-Key", Prefixes: []string{"pr:"}}},
+		header:  http.Header{},
+		wantErr: true,
+	}, {
+		name: "all headers must match",
+		matches: []triggersv1.HeaderMatch{
+			{Name: "X-GitHub-Event", Values: []string{"push"}},
+			{Name: "X-GitHub-Hook-Installation-Target-Type", Values: []string{"repository"}},
+		},
+		header:  http.Header{"X-Github-Event": {"push"}, "X-Github-Hook-Installation-Target-Type": {"organization"}},
+		wantErr: true,
+	}, {
+		name:    "any value of the header",
+		matches: []triggersv1.HeaderMatch{{Name: "X-Tag", Values: []string{"b"}}},
+		header:  http.Header{"X-Tag": {"a", "b"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := matchHeaders(tt.matches, tt.header); (err != nil) != tt.wantErr {
+				t.Errorf("matchHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,11 +17,18 @@ limitations under the License.
 package sink
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"os"
+	"strings"
+	"time"
 
 	pipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	resourceclientset "github.com/tektoncd/pipeline/pkg/client/resource/clientset/versioned"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"github.com/tektoncd/triggers/pkg/interceptors/webhook"
 	"golang.org/x/xerrors"
 	discoveryclient "k8s.io/client-go/discovery"
 	kubeclientset "k8s.io/client-go/kubernetes"
@@ -43,6 +50,88 @@ var (
 		"The namespace of the EventListener resource for this sink.")
 	portFlag = flag.String("port", "",
 		"The port for the EventListener sink to listen on.")
+	readTimeoutFlag = flag.Duration("read-timeout", 30*time.Second,
+		"The maximum duration for reading an entire request, including the body.")
+	writeTimeoutFlag = flag.Duration("write-timeout", 60*time.Second,
+		"The maximum duration before timing out writes of the response.")
+	idleTimeoutFlag = flag.Duration("idle-timeout", 120*time.Second,
+		"The maximum amount of time to wait for the next request on a keep-alive connection.")
+	maxConcurrentStreamsFlag = flag.Uint("max-concurrent-streams", 250,
+		"The maximum number of concurrent HTTP/2 streams per connection.")
+	maxTriggersPerEventFlag = flag.Int("max-triggers-per-event", 0,
+		"The maximum number of Triggers that may create resources for a single event. 0 means no limit.")
+	h2cFlag = flag.Bool("h2c", true,
+		"Accept HTTP/2 connections without TLS from clients with prior knowledge.")
+	tlsCertFlag = flag.String("tls-cert-file", "",
+		"The TLS certificate file. When set together with -tls-key-file the sink serves HTTPS and HTTP/2.")
+	tlsKeyFlag = flag.String("tls-key-file", "",
+		"The TLS private key file.")
+	tlsClientCAFlag = flag.String("tls-client-ca-file", "",
+		"A file with the CA certificates that the client certificates of senders are verified with. Senders that present no certificate are still accepted.")
+	tlsMinVersionFlag = flag.String("tls-min-version", "",
+		"The minimum TLS version of the sink, the admin endpoints and interceptor connections: VersionTLS10, VersionTLS11, VersionTLS12 or VersionTLS13.")
+	tlsCipherSuitesFlag = flag.String("tls-cipher-suites", "",
+		"A comma-separated list of the IANA names of the cipher suites allowed for TLS 1.2 and earlier. Defaults to the Go defaults.")
+	tlsCurvePreferencesFlag = flag.String("tls-curve-preferences", "",
+		"A comma-separated list of the curves used for key exchange, in order of preference: X25519, P256, P384 or P521.")
+	metricsFlag = flag.Bool("metrics", true,
+		"Expose Prometheus metrics on /metrics.")
+	adminPortFlag = flag.String("admin-port", "",
+		"The port to serve the admin endpoints like /metrics on. Defaults to the event port.")
+	adminTokenFileFlag = flag.String("admin-token-file", "",
+		"A file with the bearer token that requests to the admin endpoints must send.")
+	adminTLSCertFlag = flag.String("admin-tls-cert-file", "",
+		"The TLS certificate file of the admin endpoints. Requires -admin-port.")
+	adminTLSKeyFlag = flag.String("admin-tls-key-file", "",
+		"The TLS private key file of the admin endpoints.")
+	adminClientCAFlag = flag.String("admin-client-ca-file", "",
+		"A file with the CA certificates that client certificates of the admin endpoints must be signed by.")
+	pprofFlag = flag.Bool("pprof", false,
+		"Serve the Go profiler on /debug/pprof/ of the admin endpoints. Requires -admin-port.")
+	maxIdleConnsPerHostFlag = flag.Int("interceptor-max-idle-conns-per-host", 100,
+		"The maximum number of idle keep-alive connections to each interceptor service.")
+	maxConnsPerHostFlag = flag.Int("interceptor-max-conns-per-host", 0,
+		"The maximum number of connections to each interceptor service. 0 means no limit.")
+	idleConnTimeoutFlag = flag.Duration("interceptor-idle-conn-timeout", 90*time.Second,
+		"How long an idle connection to an interceptor service is kept open.")
+	interceptorRetriesFlag = flag.Int("interceptor-retries", 0,
+		"How many times a request to an interceptor service that fails to connect, times out or is responded to with a 5xx status is sent again.")
+	interceptorRetryBackoffFlag = flag.Duration("interceptor-retry-backoff", 200*time.Millisecond,
+		"The delay before the first retry of a request to an interceptor service, which doubles with each further retry.")
+	interceptorCAFlag = flag.String("interceptor-ca-file", "",
+		"A file with CA certificates that are trusted for HTTPS connections to interceptor services, in addition to the system CAs.")
+	dnsCacheTTLFlag = flag.Duration("interceptor-dns-cache-ttl", 30*time.Second,
+		"How long the addresses of interceptor services are cached. 0 disables the cache.")
+	sqsQueueURLFlag = flag.String("sqs-queue-url", "",
+		"The URL of an Amazon SQS queue to consume events from.")
+	sqsRegionFlag = flag.String("sqs-region", "",
+		"The AWS region of the SQS queue. Defaults to the region in the queue URL.")
+	sqsBatchSizeFlag = flag.Int("sqs-batch-size", 10,
+		"The maximum number of SQS messages to receive at once, between 1 and 10.")
+	sqsVisibilityTimeoutFlag = flag.Duration("sqs-visibility-timeout", 30*time.Second,
+		"How long a received SQS message is hidden from other consumers.")
+	fipsFlag = flag.Bool("fips", false,
+		"Restrict signature verification to FIPS-approved algorithms. Requires a sink built with GOEXPERIMENT=boringcrypto.")
+	faultInjectionFlag = flag.Bool("fault-injection", false,
+		"Enable the -fault-* flags, which inject failures for testing. Never enable this in production.")
+	faultInterceptorLatencyFlag = flag.Duration("fault-interceptor-latency", 0,
+		"The latency added before calling an interceptor. Requires -fault-injection.")
+	faultInterceptorLatencyRateFlag = flag.Float64("fault-interceptor-latency-rate", 0,
+		"The fraction of interceptor calls to add -fault-interceptor-latency to, between 0 and 1. Requires -fault-injection.")
+	faultCreateFailureRateFlag = flag.Float64("fault-create-failure-rate", 0,
+		"The fraction of resource creations to fail, between 0 and 1. Requires -fault-injection.")
+	faultSecretDropRateFlag = flag.Float64("fault-secret-drop-rate", 0,
+		"The fraction of secret reads to fail as if the secret did not exist, between 0 and 1. Requires -fault-injection.")
+	goMaxProcsFlag = flag.Int("gomaxprocs", 0,
+		"The maximum number of OS threads that execute Go code at once. 0 keeps the GOMAXPROCS default.")
+	gcPercentFlag = flag.Int("gc-percent", 100,
+		"The garbage collection target percentage, like GOGC. -1 disables the garbage collector. Overrides GOGC when set.")
+	snapshotDirFlag = flag.String("snapshot-dir", os.TempDir(),
+		"The directory that goroutine and heap snapshots are written to when the sink receives SIGQUIT.")
+	warmUpFlag = flag.String("warm-up", string(triggersv1.WarmUpWarn),
+		"What to do with the problems found by checking the interceptors at startup: off, warn or fail.")
+	statusUpdateIntervalFlag = flag.Duration("status-update-interval", time.Minute,
+		"How often at most the last event time and the Trigger counters are written to the EventListener status.")
 )
 
 // Args define the arguments for Sink.
@@ -53,6 +142,84 @@ type Args struct {
 	ElNamespace string
 	// Port is the port the Sink should listen on.
 	Port string
+	// ReadTimeout, WriteTimeout and IdleTimeout configure the Sink HTTP server.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// MaxConcurrentStreams is the maximum number of HTTP/2 streams per connection.
+	MaxConcurrentStreams uint32
+	// MaxTriggersPerEvent limits how many Triggers may create resources for
+	// a single event. 0 means no limit.
+	MaxTriggersPerEvent int
+	// H2C enables HTTP/2 without TLS.
+	H2C bool
+	// TLSCertFile and TLSKeyFile enable TLS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSClientCAFile verifies the client certificates that senders present
+	// with its CAs, so that trusted senders can be identified by them.
+	TLSClientCAFile string
+	// TLS restricts the TLS versions, cipher suites and curves of the Sink,
+	// the admin port and the connections to interceptors. It is nil if no
+	// restrictions are configured.
+	TLS *tls.Config
+	// Metrics exposes Prometheus metrics on /metrics.
+	Metrics bool
+	// AdminPort is the port of the admin endpoints. If empty, they are
+	// served on Port.
+	AdminPort string
+	// AdminTokenFile holds the bearer token required by the admin endpoints.
+	AdminTokenFile string
+	// AdminTLSCertFile and AdminTLSKeyFile enable TLS for the admin port.
+	AdminTLSCertFile string
+	AdminTLSKeyFile  string
+	// AdminClientCAFile requires client certificates signed by its CAs on
+	// the admin port.
+	AdminClientCAFile string
+	// Profiling serves the Go profiler on the admin port.
+	Profiling bool
+	// MaxIdleConnsPerHost, MaxConnsPerHost and IdleConnTimeout configure
+	// the connection pool for calls to interceptor services.
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	// InterceptorRetry is the default retry policy for requests to
+	// interceptor services.
+	InterceptorRetry webhook.RetryPolicy
+	// InterceptorRootCAs are the CAs that HTTPS connections to interceptor
+	// services are verified with. If nil, the system CAs are used.
+	InterceptorRootCAs *x509.CertPool
+	// DNSCacheTTL is how long the addresses of interceptor services are
+	// cached.
+	DNSCacheTTL time.Duration
+	// SQSQueueURL is the URL of the SQS queue to consume, if any.
+	SQSQueueURL string
+	// SQSRegion is the AWS region of the SQS queue.
+	SQSRegion string
+	// SQSBatchSize is the maximum number of SQS messages received at once.
+	SQSBatchSize int
+	// SQSVisibilityTimeout is how long a received SQS message is hidden.
+	SQSVisibilityTimeout time.Duration
+	// StatusUpdateInterval is how often at most the EventListener status is
+	// written.
+	StatusUpdateInterval time.Duration
+	// FIPS restricts signature verification to FIPS-approved algorithms.
+	FIPS bool
+	// WarmUp is what the sink does with the problems found by its warm-up.
+	WarmUp triggersv1.WarmUpMode
+	// GoMaxProcs overrides GOMAXPROCS if it is positive.
+	GoMaxProcs int
+	// GCPercent overrides the garbage collection target percentage if set.
+	GCPercent *int
+	// SnapshotDir is where snapshots are written to on SIGQUIT.
+	SnapshotDir string
+	// FaultInjection enables the injection of failures for testing,
+	// configured by the Fault fields.
+	FaultInjection              bool
+	FaultInterceptorLatency     time.Duration
+	FaultInterceptorLatencyRate float64
+	FaultCreateFailureRate      float64
+	FaultSecretDropRate         float64
 }
 
 // Clients define the set of client dependencies Sink requires.
@@ -76,19 +243,172 @@ func GetArgs() (Args, error) {
 	if *portFlag == "" {
 		return Args{}, xerrors.Errorf("-%s arg not found", port)
 	}
+	if (*tlsCertFlag == "") != (*tlsKeyFlag == "") {
+		return Args{}, xerrors.New("-tls-cert-file and -tls-key-file must be set together")
+	}
+	if *tlsClientCAFlag != "" && *tlsCertFlag == "" {
+		return Args{}, xerrors.New("-tls-client-ca-file requires -tls-cert-file")
+	}
+	if *adminPortFlag != "" && *adminPortFlag == *portFlag {
+		return Args{}, xerrors.New("-admin-port must differ from -port")
+	}
+	if (*adminTLSCertFlag == "") != (*adminTLSKeyFlag == "") {
+		return Args{}, xerrors.New("-admin-tls-cert-file and -admin-tls-key-file must be set together")
+	}
+	if *adminPortFlag == "" && (*adminTLSCertFlag != "" || *pprofFlag) {
+		return Args{}, xerrors.New("-admin-tls-cert-file and -pprof require -admin-port")
+	}
+	if *adminClientCAFlag != "" && *adminTLSCertFlag == "" {
+		return Args{}, xerrors.New("-admin-client-ca-file requires -admin-tls-cert-file")
+	}
+	if *maxIdleConnsPerHostFlag < 0 || *maxConnsPerHostFlag < 0 {
+		return Args{}, xerrors.New("-interceptor-max-idle-conns-per-host and -interceptor-max-conns-per-host must not be negative")
+	}
+	if *interceptorRetriesFlag < 0 || *interceptorRetriesFlag > 10 {
+		return Args{}, xerrors.New("-interceptor-retries must be between 0 and 10")
+	}
+	if *interceptorRetryBackoffFlag <= 0 {
+		return Args{}, xerrors.New("-interceptor-retry-backoff must be positive")
+	}
+	if *sqsBatchSizeFlag < 1 || *sqsBatchSizeFlag > 10 {
+		return Args{}, xerrors.New("-sqs-batch-size must be between 1 and 10")
+	}
+	if *sqsVisibilityTimeoutFlag < time.Second {
+		return Args{}, xerrors.New("-sqs-visibility-timeout must be at least 1s")
+	}
+	if *statusUpdateIntervalFlag < time.Second {
+		return Args{}, xerrors.New("-status-update-interval must be at least 1s")
+	}
+	if *maxTriggersPerEventFlag < 0 {
+		return Args{}, xerrors.New("-max-triggers-per-event must not be negative")
+	}
+	switch triggersv1.WarmUpMode(*warmUpFlag) {
+	case triggersv1.WarmUpOff, triggersv1.WarmUpWarn, triggersv1.WarmUpFail:
+	default:
+		return Args{}, xerrors.New("-warm-up must be off, warn or fail")
+	}
+	if *goMaxProcsFlag < 0 {
+		return Args{}, xerrors.New("-gomaxprocs must not be negative")
+	}
+	if *gcPercentFlag < -1 {
+		return Args{}, xerrors.New("-gc-percent must be -1 or more")
+	}
+	if err := validateFaults(); err != nil {
+		return Args{}, err
+	}
+	tlsConfig, err := tlsPolicyConfig()
+	if err != nil {
+		return Args{}, err
+	}
+	var interceptorRootCAs *x509.CertPool
+	if *interceptorCAFlag != "" {
+		if interceptorRootCAs, err = interceptorCAPool(*interceptorCAFlag); err != nil {
+			return Args{}, xerrors.Errorf("failed to read -interceptor-ca-file: %w", err)
+		}
+	}
+	// The GC percent is only overridden when set, so that GOGC still works
+	var gcPercent *int
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "gc-percent" {
+			gcPercent = gcPercentFlag
+		}
+	})
 	return Args{
-		ElName:      *nameFlag,
-		ElNamespace: *namespaceFlag,
-		Port:        *portFlag,
+		ElName:               *nameFlag,
+		ElNamespace:          *namespaceFlag,
+		Port:                 *portFlag,
+		ReadTimeout:          *readTimeoutFlag,
+		WriteTimeout:         *writeTimeoutFlag,
+		IdleTimeout:          *idleTimeoutFlag,
+		MaxConcurrentStreams: uint32(*maxConcurrentStreamsFlag),
+		MaxTriggersPerEvent:  *maxTriggersPerEventFlag,
+		H2C:                  *h2cFlag,
+		TLSCertFile:          *tlsCertFlag,
+		TLSKeyFile:           *tlsKeyFlag,
+		TLSClientCAFile:      *tlsClientCAFlag,
+		TLS:                  tlsConfig,
+		Metrics:              *metricsFlag,
+		AdminPort:            *adminPortFlag,
+		AdminTokenFile:       *adminTokenFileFlag,
+		AdminTLSCertFile:     *adminTLSCertFlag,
+		AdminTLSKeyFile:      *adminTLSKeyFlag,
+		AdminClientCAFile:    *adminClientCAFlag,
+		Profiling:            *pprofFlag,
+		MaxIdleConnsPerHost:  *maxIdleConnsPerHostFlag,
+		MaxConnsPerHost:      *maxConnsPerHostFlag,
+		IdleConnTimeout:      *idleConnTimeoutFlag,
+		InterceptorRootCAs:   interceptorRootCAs,
+		DNSCacheTTL:          *dnsCacheTTLFlag,
+		InterceptorRetry:     webhook.RetryPolicy{Retries: *interceptorRetriesFlag, Backoff: *interceptorRetryBackoffFlag},
+		SQSQueueURL:          *sqsQueueURLFlag,
+		SQSRegion:            *sqsRegionFlag,
+		SQSBatchSize:         *sqsBatchSizeFlag,
+		SQSVisibilityTimeout: *sqsVisibilityTimeoutFlag,
+		StatusUpdateInterval: *statusUpdateIntervalFlag,
+		FIPS:                 *fipsFlag,
+		WarmUp:               triggersv1.WarmUpMode(*warmUpFlag),
+		GoMaxProcs:           *goMaxProcsFlag,
+		GCPercent:            gcPercent,
+		SnapshotDir:          *snapshotDirFlag,
+
+		FaultInjection:              *faultInjectionFlag,
+		FaultInterceptorLatency:     *faultInterceptorLatencyFlag,
+		FaultInterceptorLatencyRate: *faultInterceptorLatencyRateFlag,
+		FaultCreateFailureRate:      *faultCreateFailureRateFlag,
+		FaultSecretDropRate:         *faultSecretDropRateFlag,
 	}, nil
 }
 
+// validateFaults checks that the -fault-* flags are only set together with
+// -fault-injection, so that failures are never injected by accident.
+func validateFaults() error {
+	rates := []float64{*faultInterceptorLatencyRateFlag, *faultCreateFailureRateFlag, *faultSecretDropRateFlag}
+	set := *faultInterceptorLatencyFlag != 0
+	for _, r := range rates {
+		if r < 0 || r > 1 {
+			return xerrors.New("-fault-* rates must be between 0 and 1")
+		}
+		set = set || r != 0
+	}
+	if set && !*faultInjectionFlag {
+		return xerrors.New("-fault-* flags require -fault-injection")
+	}
+	if *faultInterceptorLatencyFlag < 0 {
+		return xerrors.New("-fault-interceptor-latency must not be negative")
+	}
+	return nil
+}
+
+// tlsPolicyConfig returns the TLS configuration of the -tls-* flags, or nil
+// if none is set.
+func tlsPolicyConfig() (*tls.Config, error) {
+	policy := &triggersv1.TLSPolicy{MinVersion: *tlsMinVersionFlag}
+	if *tlsCipherSuitesFlag != "" {
+		policy.CipherSuites = strings.Split(*tlsCipherSuitesFlag, ",")
+	}
+	if *tlsCurvePreferencesFlag != "" {
+		policy.CurvePreferences = strings.Split(*tlsCurvePreferencesFlag, ",")
+	}
+	if policy.MinVersion == "" && policy.CipherSuites == nil && policy.CurvePreferences == nil {
+		return nil, nil
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, xerrors.Errorf("invalid -tls-* flags: %w", err)
+	}
+	return policy.Config()
+}
+
 // ConfigureClients returns the kubernetes and triggers clientsets
 func ConfigureClients() (Clients, error) {
 	clusterConfig, err := rest.InClusterConfig()
 	if err != nil {
 		return Clients{}, xerrors.Errorf("Failed to get in cluster config: %s", err)
 	}
+	return NewClients(clusterConfig)
+}
+
+// NewClients returns the kubernetes and triggers clientsets of the config.
+func NewClients(clusterConfig *rest.Config) (Clients, error) {
 	kubeClient, err := kubeclientset.NewForConfig(clusterConfig)
 	if err != nil {
 		return Clients{}, xerrors.Errorf("Failed to create KubeClient: %s", err)
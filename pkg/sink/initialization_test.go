@@ -17,8 +17,10 @@ limitations under the License.
 package sink
 
 import (
+	"crypto/tls"
 	"flag"
 	"testing"
+	"time"
 )
 
 func Test_GetArgs(t *testing.T) {
@@ -31,6 +33,12 @@ func Test_GetArgs(t *testing.T) {
 	if err := flag.Set(port, "port"); err != nil {
 		t.Errorf("Error setting flag port: %s", err)
 	}
+	if err := flag.Set("read-timeout", "10s"); err != nil {
+		t.Errorf("Error setting flag read-timeout: %s", err)
+	}
+	if err := flag.Set("max-concurrent-streams", "50"); err != nil {
+		t.Errorf("Error setting flag max-concurrent-streams: %s", err)
+	}
 	sinkArgs, err := GetArgs()
 	if err != nil {
 		t.Fatalf("GetArgs() returned unexpected error: %s", err)
@@ -44,6 +52,199 @@ func Test_GetArgs(t *testing.T) {
 	if sinkArgs.Port != "port" {
 		t.Errorf("Error port want port, got %s", sinkArgs.Port)
 	}
+	if sinkArgs.ReadTimeout != 10*time.Second {
+		t.Errorf("Error read-timeout want 10s, got %s", sinkArgs.ReadTimeout)
+	}
+	if sinkArgs.IdleTimeout != 120*time.Second {
+		t.Errorf("Error idle-timeout want 120s, got %s", sinkArgs.IdleTimeout)
+	}
+	if sinkArgs.MaxConcurrentStreams != 50 {
+		t.Errorf("Error max-concurrent-streams want 50, got %d", sinkArgs.MaxConcurrentStreams)
+	}
+	if sinkArgs.SQSQueueURL != "" || sinkArgs.SQSBatchSize != 10 || sinkArgs.SQSVisibilityTimeout != 30*time.Second {
+		t.Errorf("Error SQS args want defaults, got %q, %d, %s", sinkArgs.SQSQueueURL, sinkArgs.SQSBatchSize, sinkArgs.SQSVisibilityTimeout)
+	}
+	if sinkArgs.StatusUpdateInterval != time.Minute {
+		t.Errorf("Error status-update-interval want 1m, got %s", sinkArgs.StatusUpdateInterval)
+	}
+	if sinkArgs.InterceptorRetry.Retries != 0 || sinkArgs.InterceptorRetry.Backoff != 200*time.Millisecond {
+		t.Errorf("Error interceptor retry want no retries, got %+v", sinkArgs.InterceptorRetry)
+	}
+}
+
+func Test_GetArgs_StatusUpdateIntervalError(t *testing.T) {
+	for _, f := range []string{name, elNamespace, port} {
+		if err := flag.Set(f, "value"); err != nil {
+			t.Errorf("Error setting flag %s: %s", f, err)
+		}
+	}
+	if err := flag.Set("status-update-interval", "100ms"); err != nil {
+		t.Errorf("Error setting flag status-update-interval: %s", err)
+	}
+	defer flag.Set("status-update-interval", "1m")
+	if sinkArgs, err := GetArgs(); err == nil {
+		t.Errorf("GetArgs() did not return error when expected; sinkArgs: %v", sinkArgs)
+	}
+}
+
+func Test_GetArgs_SQSBatchSizeError(t *testing.T) {
+	for _, f := range []string{name, elNamespace, port} {
+		if err := flag.Set(f, "value"); err != nil {
+			t.Errorf("Error setting flag %s: %s", f, err)
+		}
+	}
+	if err := flag.Set("sqs-batch-size", "11"); err != nil {
+		t.Errorf("Error setting flag sqs-batch-size: %s", err)
+	}
+	defer flag.Set("sqs-batch-size", "10")
+	if sinkArgs, err := GetArgs(); err == nil {
+		t.Errorf("GetArgs() did not return error when expected; sinkArgs: %v", sinkArgs)
+	}
+}
+
+func Test_GetArgs_Runtime(t *testing.T) {
+	for f, v := range map[string]string{name: "value", elNamespace: "value", port: "value", "gomaxprocs": "2", "gc-percent": "-1"} {
+		defaultValue := flag.Lookup(f).DefValue
+		if err := flag.Set(f, v); err != nil {
+			t.Errorf("Error setting flag %s: %s", f, err)
+		}
+		defer flag.Set(f, defaultValue)
+	}
+	sinkArgs, err := GetArgs()
+	if err != nil {
+		t.Fatalf("GetArgs() returned unexpected error: %s", err)
+	}
+	if sinkArgs.GoMaxProcs != 2 {
+		t.Errorf("Error gomaxprocs want 2, got %d", sinkArgs.GoMaxProcs)
+	}
+	if sinkArgs.GCPercent == nil || *sinkArgs.GCPercent != -1 {
+		t.Errorf("Error gc-percent want -1, got %v", sinkArgs.GCPercent)
+	}
+
+	for f, v := range map[string]string{"gomaxprocs": "-1", "gc-percent": "-2"} {
+		if err := flag.Set(f, v); err != nil {
+			t.Errorf("Error setting flag %s: %s", f, err)
+		}
+		if sinkArgs, err := GetArgs(); err == nil {
+			t.Errorf("GetArgs() with -%s=%s did not return error; sinkArgs: %v", f, v, sinkArgs)
+		}
+		flag.Set(f, flag.Lookup(f).DefValue)
+	}
+}
+
+func Test_GetArgs_TLSPolicy(t *testing.T) {
+	flags := map[string]string{
+		name: "value", elNamespace: "value", port: "value",
+		"tls-min-version":       "VersionTLS12",
+		"tls-cipher-suites":     "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"tls-curve-preferences": "X25519,P256",
+	}
+	for f, v := range flags {
+		defaultValue := flag.Lookup(f).DefValue
+		if err := flag.Set(f, v); err != nil {
+			t.Errorf("Error setting flag %s: %s", f, err)
+		}
+		defer flag.Set(f, defaultValue)
+	}
+	sinkArgs, err := GetArgs()
+	if err != nil {
+		t.Fatalf("GetArgs() returned unexpected error: %s", err)
+	}
+	if c := sinkArgs.TLS; c == nil || c.MinVersion != tls.VersionTLS12 || len(c.CipherSuites) != 1 || len(c.CurvePreferences) != 2 {
+		t.Errorf("Error -tls-* flags want TLS 1.2, 1 cipher suite and 2 curves, got %+v", c)
+	}
+
+	for f, v := range map[string]string{"tls-min-version": "1.2", "tls-cipher-suites": "TLS_RSA_WITH_RC4_128_SHA", "tls-curve-preferences": "P224"} {
+		if err := flag.Set(f, v); err != nil {
+			t.Errorf("Error setting flag %s: %s", f, err)
+		}
+		if sinkArgs, err := GetArgs(); err == nil {
+			t.Errorf("GetArgs() with -%s=%s did not return error; sinkArgs: %v", f, v, sinkArgs)
+		}
+		flag.Set(f, flags[f])
+	}
+}
+
+func Test_GetArgs_AdminError(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+	}{{
+		name:  "admin port is the event port",
+		flags: map[string]string{"admin-port": "value"},
+	}, {
+		name:  "admin TLS without admin port",
+		flags: map[string]string{"admin-tls-cert-file": "tls.crt", "admin-tls-key-file": "tls.key"},
+	}, {
+		name:  "admin TLS certificate without key",
+		flags: map[string]string{"admin-port": "9000", "admin-tls-cert-file": "tls.crt"},
+	}, {
+		name:  "client CA without admin TLS",
+		flags: map[string]string{"admin-port": "9000", "admin-client-ca-file": "ca.crt"},
+	}, {
+		name:  "profiling without admin port",
+		flags: map[string]string{"pprof": "true"},
+	}, {
+		name:  "missing interceptor CA file",
+		flags: map[string]string{"interceptor-ca-file": "/nonexistent/service-ca.crt"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, f := range []string{name, elNamespace, port} {
+				if err := flag.Set(f, "value"); err != nil {
+					t.Errorf("Error setting flag %s: %s", f, err)
+				}
+			}
+			for f, v := range tt.flags {
+				defaultValue := flag.Lookup(f).DefValue
+				if err := flag.Set(f, v); err != nil {
+					t.Errorf("Error setting flag %s: %s", f, err)
+				}
+				defer flag.Set(f, defaultValue)
+			}
+			if sinkArgs, err := GetArgs(); err == nil {
+				t.Errorf("GetArgs() did not return error when expected; sinkArgs: %v", sinkArgs)
+			}
+		})
+	}
+}
+
+func Test_GetArgs_FaultError(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+	}{{
+		name:  "fault without fault injection",
+		flags: map[string]string{"fault-create-failure-rate": "0.1"},
+	}, {
+		name:  "latency without fault injection",
+		flags: map[string]string{"fault-interceptor-latency": "1s"},
+	}, {
+		name:  "rate above 1",
+		flags: map[string]string{"fault-injection": "true", "fault-secret-drop-rate": "1.5"},
+	}, {
+		name:  "negative latency",
+		flags: map[string]string{"fault-injection": "true", "fault-interceptor-latency": "-1s"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, f := range []string{name, elNamespace, port} {
+				if err := flag.Set(f, "value"); err != nil {
+					t.Errorf("Error setting flag %s: %s", f, err)
+				}
+			}
+			for f, v := range tt.flags {
+				defaultValue := flag.Lookup(f).DefValue
+				if err := flag.Set(f, v); err != nil {
+					t.Errorf("Error setting flag %s: %s", f, err)
+				}
+				defer flag.Set(f, defaultValue)
+			}
+			if sinkArgs, err := GetArgs(); err == nil {
+				t.Errorf("GetArgs() did not return error when expected; sinkArgs: %v", sinkArgs)
+			}
+		})
+	}
 }
 
 func Test_GetArgs_error(t *testing.T) {
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/resources"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// renderedByAnnotation marks resources that an interceptor rendered instead
+// of the TriggerTemplate of the Trigger.
+const renderedByAnnotation = "rendered-by"
+
+// interceptorResources returns the resources in the body of an interceptor
+// response, {"resources": [...]}, if the Trigger allows their kinds.
+func interceptorResources(allowed *triggersv1.InterceptorResources, body []byte) ([]json.RawMessage, error) {
+	if allowed == nil {
+		return nil, errors.New("interceptor responded with resources, but the Trigger does not set interceptorResources")
+	}
+	var response struct {
+		Resources []json.RawMessage `json:"resources"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse the resources of the interceptor response: %w", err)
+	}
+	if len(response.Resources) == 0 {
+		return nil, errors.New("interceptor responded without resources")
+	}
+	out := make([]json.RawMessage, len(response.Resources))
+	for i, raw := range response.Resources {
+		data := new(unstructured.Unstructured)
+		if err := data.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("invalid resource %d in the interceptor response: %w", i, err)
+		}
+		if !containsString(allowed.Kinds, data.GetKind()) {
+			return nil, fmt.Errorf("interceptor responded with a resource of kind %s, which is not in interceptorResources.kinds", data.GetKind())
+		}
+		// Record that the resource was not rendered by the TriggerTemplate
+		b, err := resources.AddAnnotations(data, map[string]string{renderedByAnnotation: "interceptor"}).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
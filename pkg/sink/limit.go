@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// triggerLimit limits how many Triggers may create resources for a single
+// event, so that a filter that accidentally matches everything does not
+// flood the cluster with resources.
+type triggerLimit struct {
+	max     int32
+	matched int32
+}
+
+// newTriggerLimit returns a triggerLimit for an event. A max of zero means
+// no limit.
+func newTriggerLimit(max int) *triggerLimit {
+	return &triggerLimit{max: int32(max)}
+}
+
+// admit counts a Trigger that matched the event, and returns an error with
+// the TooManyTriggers reason once more Triggers matched than the limit.
+// Triggers are processed concurrently, so the Triggers admitted are the
+// first to pass their interceptors.
+func (l *triggerLimit) admit(trigger string) error {
+	if l == nil || l.max <= 0 {
+		return nil
+	}
+	if atomic.AddInt32(&l.matched, 1) <= l.max {
+		return nil
+	}
+	triggerOverflows.WithLabelValues(trigger).Inc()
+	return withReason(triggersv1.ReasonTooManyTriggers,
+		fmt.Errorf("the event matched more than the maximum of %d Triggers per event", l.max))
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTriggerLimit(t *testing.T) {
+	var unlimited *triggerLimit
+	for _, l := range []*triggerLimit{unlimited, newTriggerLimit(0)} {
+		for i := 0; i < 3; i++ {
+			if err := l.admit("my-trigger"); err != nil {
+				t.Errorf("admit() without limit = %v, want nil", err)
+			}
+		}
+	}
+
+	l := newTriggerLimit(2)
+	for i := 0; i < 2; i++ {
+		if err := l.admit("my-trigger"); err != nil {
+			t.Errorf("admit() within the limit = %v, want nil", err)
+		}
+	}
+	var rerr *reasonError
+	if err := l.admit("my-trigger"); !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonTooManyTriggers {
+		t.Errorf("admit() over the limit = %v, want TooManyTriggers error", err)
+	}
+}
+
+func TestHandleEvent_TooManyTriggers(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"generateName":"ref-"},"spec":{"type":"git"}}`)}),
+		))
+	triggers := []string{"trigger-1", "trigger-2", "trigger-3"}
+	var opts []bldr.EventListenerSpecOp
+	for _, name := range triggers {
+		opts = append(opts, bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName(name),
+			bldr.EventListenerCELInterceptor("true"),
+		))
+	}
+	// A filter that matches no events does not count towards the limit
+	opts = append(opts, bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+		bldr.EventListenerTriggerName("filtered"),
+		bldr.EventListenerCELInterceptor("false"),
+	))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(opts...))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	sink.MaxTriggersPerEvent = 2
+	overflows := func() float64 {
+		var sum float64
+		for _, name := range triggers {
+			sum += counterValue(t, triggerOverflows.WithLabelValues(name))
+		}
+		return sum
+	}
+	before := overflows()
+
+	rec := httptest.NewRecorder()
+	sink.HandleEvent(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`))))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Response code = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	var body Response
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	var overflowed int
+	for _, e := range body.Errors {
+		if e.Reason == triggersv1.ReasonTooManyTriggers {
+			overflowed++
+		}
+	}
+	if overflowed != 1 {
+		t.Errorf("got errors %+v, want one TooManyTriggers error", body.Errors)
+	}
+	if got := len(getCreatedPipelineResources(t, dynamicClient.Actions())); got != 2 {
+		t.Errorf("got %d resources, want resources for 2 Triggers", got)
+	}
+	if got := overflows() - before; got != 1 {
+		t.Errorf("eventlistener_trigger_overflows_total increased by %v, want 1", got)
+	}
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/logging"
+	"github.com/tektoncd/triggers/pkg/template"
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// manualPathPrefix is the path that Triggers are fired on demand on, followed
+// by the name of the Trigger.
+const manualPathPrefix = "/manual/"
+
+// HandleManual fires a Trigger of the EventListener on demand, with the param
+// values of a JSON object instead of an event. The bindings and interceptors
+// of the Trigger are skipped, so that pipelines can be started without
+// crafting the payload of a provider. Requests must send the token of the
+// EventListener as a bearer token.
+func (r Sink) HandleManual(response http.ResponseWriter, request *http.Request) {
+	name, ok := manualTrigger(response, request)
+	if !ok {
+		return
+	}
+	el, err := r.TriggersClient.TriggersV1alpha1().EventListeners(r.EventListenerNamespace).Get(r.EventListenerName, metav1.GetOptions{})
+	if err != nil {
+		r.Logger.Errorf("Error getting EventListener %s in Namespace %s: %s", r.EventListenerName, r.EventListenerNamespace, err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	r.serveManual(response, request, el, name)
+}
+
+// manualTrigger returns the name of the Trigger that a manual request fires,
+// or responds with an error if the request cannot fire one.
+func manualTrigger(response http.ResponseWriter, request *http.Request) (string, bool) {
+	name := strings.TrimPrefix(request.URL.Path, manualPathPrefix)
+	if name == "" || strings.Contains(name, "/") {
+		http.NotFound(response, request)
+		return "", false
+	}
+	if request.Method != http.MethodPost {
+		response.Header().Set("Allow", http.MethodPost)
+		http.Error(response, "manual triggering requires POST", http.StatusMethodNotAllowed)
+		return "", false
+	}
+	return name, true
+}
+
+// serveManual fires the Trigger with the name of the EventListener.
+func (r Sink) serveManual(response http.ResponseWriter, request *http.Request, el *triggersv1.EventListener, name string) {
+	if el.Spec.Manual == nil {
+		http.Error(response, "manual triggering is not enabled", http.StatusNotFound)
+		return
+	}
+	if el.Spec.Maintenance != nil {
+		r.rejectForMaintenance(response, el.Spec.Maintenance)
+		return
+	}
+	if el.Spec.AuthFailureBlock != nil {
+		if retryAfter := r.AuthFailures.blocked(eventSource(request.RemoteAddr), time.Now()); retryAfter > 0 {
+			r.rejectBlockedSource(response, retryAfter)
+			return
+		}
+	}
+
+	eventID := template.UID()
+	correlationID := request.Header.Get(CorrelationIDHeader)
+	eventLog := withCorrelation(r.Logger.With(zap.String(triggersv1.EventIDLabelKey, eventID), zap.String(triggersv1.TriggerLabelKey, name)), "", correlationID)
+	if correlationID != "" {
+		response.Header().Set(CorrelationIDHeader, correlationID)
+	}
+	authorized, err := r.authorizeManual(el, request)
+	if err != nil {
+		// Not counted as an authentication failure, since the sender is not
+		// at fault
+		eventLog.Errorf("Failed to authorize manual firing: %s", err)
+		sourceEvents.WithLabelValues("manual", "error").Inc()
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !authorized {
+		eventLog.Warn("Rejecting manual firing: the request does not send the bearer token")
+		r.recordAuthFailure(el, request, "manual", eventLog)
+		sourceEvents.WithLabelValues("manual", "failed").Inc()
+		response.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(response, "invalid or missing bearer token", http.StatusUnauthorized)
+		return
+	}
+	var t *triggersv1.EventListenerTrigger
+	for i := range el.Spec.Triggers {
+		if el.Spec.Triggers[i].Name == name {
+			t = &el.Spec.Triggers[i]
+		}
+	}
+	if t == nil {
+		http.Error(response, fmt.Sprintf("the EventListener has no Trigger %q", name), http.StatusNotFound)
+		return
+	}
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		eventLog.Errorf("Error reading the params: %s", err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	values, err := manualValues(body)
+	if err != nil {
+		sourceEvents.WithLabelValues("manual", "error").Inc()
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Status != nil {
+		r.Status.RecordEvent(time.Now())
+	}
+	defer trackEvent("manual")()
+	eventLog.Infof("Firing Trigger %s manually", name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), eventTimeout(el))
+	defer cancel()
+	r.Pins = r.Pins.For(el)
+	err = r.fireTrigger(ctx, t, values, eventID, correlationID, eventLog)
+	var perr *manualParamsError
+	if errors.As(err, &perr) {
+		sourceEvents.WithLabelValues("manual", "error").Inc()
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Status != nil {
+		var rerr *reasonError
+		r.Status.RecordTrigger(t.Name, time.Now(), err == nil, errors.As(err, &rerr))
+	}
+
+	code := http.StatusCreated
+	var triggerErrors []TriggerError
+	if err != nil {
+		code = http.StatusAccepted
+		cause := err
+		var rerr *reasonError
+		if errors.As(err, &rerr) {
+			triggerErrors = append(triggerErrors, TriggerError{Trigger: t.Name, Reason: rerr.reason, Phase: rerr.phase})
+			cause = rerr.err
+		}
+		if kerrors.IsUnauthorized(cause) {
+			code = http.StatusUnauthorized
+		} else if kerrors.IsForbidden(cause) {
+			code = http.StatusForbidden
+		}
+	}
+	recordSourceEvent("manual", code, triggerErrors, nil)
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(code)
+	res := Response{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		EventID:       eventID,
+		CorrelationID: correlationID,
+		Errors:        triggerErrors,
+	}
+	if err := json.NewEncoder(response).Encode(res); err != nil {
+		eventLog.Errorf("failed to write back sink response: %v", err)
+	}
+}
+
+// authorizeManual returns whether the request sends the token of the
+// EventListener as a bearer token. It returns an error if the token cannot be
+// read. The Secret is read for every request, so that the token can be
+// rotated without restarting the sink.
+func (r Sink) authorizeManual(el *triggersv1.EventListener, request *http.Request) (bool, error) {
+	token, err := r.secretToken(el.Namespace, el.Spec.Manual.TokenSecret)
+	if err != nil {
+		return false, err
+	}
+	const prefix = "Bearer "
+	auth := request.Header.Get("Authorization")
+	return strings.HasPrefix(auth, prefix) && subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), token) == 1, nil
+}
+
+// secretToken returns the token in the key of the Secret, which is redacted
+// from the logs from then on. The Secret is read every time, so that the token
+// can be rotated without restarting the sink.
+func (r Sink) secretToken(ns string, ref triggersv1.SecretRef) ([]byte, error) {
+	secret, err := r.KubeClientSet.CoreV1().Secrets(ns).Get(ref.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the token Secret %s: %w", ref.SecretName, err)
+	}
+	token := bytes.TrimSpace(secret.Data[ref.SecretKey])
+	logging.Secrets.AddSecret(token)
+	if len(token) == 0 {
+		return nil, fmt.Errorf("the token Secret %s has no key %s", ref.SecretName, ref.SecretKey)
+	}
+	return token, nil
+}
+
+// manualValues returns the param values of a JSON object. String values are
+// used as they are, and other values as JSON, like binding values that select
+// objects or arrays.
+func manualValues(body []byte) (map[string]string, error) {
+	values := map[string]string{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return values, nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("the params must be a JSON object: %w", err)
+	}
+	for name, v := range raw {
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			values[name] = s
+			continue
+		}
+		var b bytes.Buffer
+		if err := json.Compact(&b, v); err != nil {
+			return nil, fmt.Errorf("invalid value of param %s: %w", name, err)
+		}
+		values[name] = b.String()
+	}
+	return values, nil
+}
+
+// manualParamsError is returned when the param values of a manual firing do
+// not fit the params of the TriggerTemplate.
+type manualParamsError struct {
+	msg string
+}
+
+func (e *manualParamsError) Error() string {
+	return e.msg
+}
+
+// fireTrigger renders the TriggerTemplate of the Trigger with the param values
+// and creates its resources in the namespace of the EventListener.
+func (r Sink) fireTrigger(ctx context.Context, t *triggersv1.EventListenerTrigger, values map[string]string, eventID, correlationID string, log *zap.SugaredLogger) error {
+	// Only the TriggerTemplate is resolved, without the bindings
+	rt, err := template.ResolvePinnedTrigger(triggersv1.EventListenerTrigger{Template: t.Template}, nil, nil,
+		r.TriggersClient.TriggersV1alpha1().TriggerTemplates(r.EventListenerNamespace).Get,
+		r.Pins)
+	if err != nil {
+		log.Error(err)
+		return withReason(triggersv1.ReasonTemplateInvalid, err)
+	}
+	params, err := manualParams(rt.TriggerTemplate.Spec.Params, values)
+	if err != nil {
+		return err
+	}
+	specs := template.SensitiveParamSpecs(rt.TriggerTemplate)
+	sensitive := template.SensitiveValues(params, specs)
+	log.Infof("params: %+v", template.RedactParams(params, specs))
+	resources, err := template.ResolveResourcesForTrigger(rt.TriggerTemplate, params, template.UID(), template.TriggerContext{
+		TriggerName:      t.Name,
+		TriggerNamespace: r.EventListenerNamespace,
+		ListenerName:     r.EventListenerName,
+	})
+	if err != nil {
+		log.Error(template.Redact(err.Error(), sensitive))
+		return withReason(triggersv1.ReasonTemplateInvalid, err)
+	}
+
+	token, err := r.retrieveAuthToken(t.ServiceAccount, log)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	if _, err := r.createResources(ctx, token, resources, nil, r.EventListenerNamespace, t.Cluster, t.Name, eventID, "", correlationID, t.ValidateBeforeCreate, sensitive, log); err != nil {
+		log.Error(template.Redact(err.Error(), sensitive))
+		if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
+			return withReason(triggersv1.ReasonRBACDenied, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// manualParams returns the params of the TriggerTemplate with the values, and
+// the defaults of the params without a value. Values of params that the
+// TriggerTemplate does not declare are rejected, since they would be ignored.
+func manualParams(specs []triggersv1.ParamSpec, values map[string]string) ([]pipelinev1.Param, error) {
+	declared := make(map[string]bool, len(specs))
+	var missing []string
+	for _, spec := range specs {
+		declared[spec.Name] = true
+		if _, ok := values[spec.Name]; !ok && spec.Default == nil {
+			missing = append(missing, spec.Name)
+		}
+	}
+	var unknown []string
+	params := make([]pipelinev1.Param, 0, len(values))
+	for name, value := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		params = append(params, pipelinev1.Param{Name: name, Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeString, StringVal: value}})
+	}
+	sort.Strings(unknown)
+	switch {
+	case len(unknown) > 0:
+		return nil, &manualParamsError{msg: fmt.Sprintf("the TriggerTemplate has no params %s", strings.Join(unknown, ", "))}
+	case len(missing) > 0:
+		return nil, &manualParamsError{msg: fmt.Sprintf("the params %s have no default and must be set", strings.Join(missing, ", "))}
+	}
+	return template.MergeInDefaultParams(params, specs), nil
+}
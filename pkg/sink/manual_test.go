@@ -0,0 +1,248 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestManualValues(t *testing.T) {
+	got, err := manualValues([]byte(`{"revision": "main", "count": 3, "labels": {"a": "b"}}`))
+	if err != nil {
+		t.Fatalf("manualValues() returned unexpected error: %s", err)
+	}
+	want := map[string]string{"revision": "main", "count": "3", "labels": `{"a":"b"}`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("manualValues(): -want +got: %s", diff)
+	}
+	if got, err := manualValues(nil); err != nil || len(got) != 0 {
+		t.Errorf("manualValues() of an empty body = %v, %v, want no values", got, err)
+	}
+	if _, err := manualValues([]byte(`["main"]`)); err == nil {
+		t.Error("manualValues() did not return error for an array")
+	}
+}
+
+func TestHandleManual(t *testing.T) {
+	pr := pipelinev1alpha1.PipelineResource{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineResource"},
+		ObjectMeta: metav1.ObjectMeta{Name: "$(params.name)", Namespace: namespace},
+		Spec: pipelinev1alpha1.PipelineResourceSpec{
+			Type:   pipelinev1alpha1.PipelineResourceTypeGit,
+			Params: []pipelinev1alpha1.ResourceParam{{Name: "revision", Value: "$(params.revision)"}},
+		},
+	}
+	prBytes, err := json.Marshal(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateParam("name", "", ""),
+			bldr.TriggerTemplateParam("revision", "", "main"),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: prBytes}),
+		))
+	// The name must be set on every firing
+	tt.Spec.Params[0].Default = nil
+	el := bldr.EventListener("my-eventlistener", namespace,
+		bldr.EventListenerSpec(
+			bldr.EventListenerManual("manual", "token"),
+			bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerName("build"),
+				bldr.EventListenerTriggerBinding("missing-binding", "", "v1alpha1"),
+			)))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("s3cr3t\n")},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		body     string
+		wantCode int
+		wantName string
+	}{{
+		name:     "fires the trigger without its bindings",
+		path:     "/manual/build",
+		token:    "s3cr3t",
+		body:     `{"name": "manual-pr"}`,
+		wantCode: http.StatusCreated,
+		wantName: "manual-pr",
+	}, {
+		name:     "missing token",
+		path:     "/manual/build",
+		body:     `{"name": "manual-pr"}`,
+		wantCode: http.StatusUnauthorized,
+	}, {
+		name:     "wrong token",
+		path:     "/manual/build",
+		token:    "guess",
+		body:     `{"name": "manual-pr"}`,
+		wantCode: http.StatusUnauthorized,
+	}, {
+		name:     "unknown trigger",
+		path:     "/manual/deploy",
+		token:    "s3cr3t",
+		wantCode: http.StatusNotFound,
+	}, {
+		name:     "unknown param",
+		path:     "/manual/build",
+		token:    "s3cr3t",
+		body:     `{"name": "manual-pr", "branch": "main"}`,
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "missing param without default",
+		path:     "/manual/build",
+		token:    "s3cr3t",
+		body:     `{"revision": "v1"}`,
+		wantCode: http.StatusBadRequest,
+	}, {
+		name:     "not a POST",
+		method:   http.MethodGet,
+		path:     "/manual/build",
+		token:    "s3cr3t",
+		wantCode: http.StatusMethodNotAllowed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, dynamicClient := getSinkAssets(t, test.Resources{
+				TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+				EventListeners:   []*triggersv1.EventListener{el},
+				Secrets:          []*corev1.Secret{secret},
+			}, el.Name, DefaultAuthOverride{})
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tc.path, strings.NewReader(tc.body))
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			sink.HandleManual(rec, req)
+			if rec.Code != tc.wantCode {
+				t.Fatalf("Response code = %d, want %d: %s", rec.Code, tc.wantCode, rec.Body.String())
+			}
+			prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+			if tc.wantName == "" {
+				if len(prs) != 0 {
+					t.Errorf("Created %d resources, want none", len(prs))
+				}
+				return
+			}
+			if len(prs) != 1 || prs[0].Name != tc.wantName || prs[0].Spec.Params[0].Value != "main" {
+				t.Errorf("Created resources %+v, want one named %s with the default revision", prs, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestHandleManual_NotEnabled(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace,
+		bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerName("build"),
+			)))
+	sink, _ := getSinkAssets(t, test.Resources{
+		EventListeners: []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	req := httptest.NewRequest(http.MethodPost, "/manual/build", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	sink.HandleManual(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Response code = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandleManual_Maintenance(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace,
+		bldr.EventListenerSpec(
+			bldr.EventListenerManual("manual", "token"),
+			bldr.EventListenerMaintenance(300),
+			bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerName("build"),
+			)))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		EventListeners: []*triggersv1.EventListener{el},
+		Secrets:        []*corev1.Secret{secret},
+	}, el.Name, DefaultAuthOverride{})
+
+	req := httptest.NewRequest(http.MethodPost, "/manual/build", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	rec := httptest.NewRecorder()
+	sink.HandleManual(rec, req)
+
+	// Manual firings are rejected like events
+	eventRec := httptest.NewRecorder()
+	sink.HandleEvent(eventRec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusServiceUnavailable || rec.Code != eventRec.Code {
+		t.Errorf("Response code = %d, want %d like events", rec.Code, eventRec.Code)
+	}
+	if got, want := rec.Header().Get("Retry-After"), "300"; got != want {
+		t.Errorf("Retry-After = %q, want %q", got, want)
+	}
+	if rec.Body.String() != eventRec.Body.String() {
+		t.Errorf("Response body = %q, want %q like events", rec.Body.String(), eventRec.Body.String())
+	}
+	if len(dynamicClient.Actions()) != 0 {
+		t.Errorf("Expected no resources to be created, got actions: %v", dynamicClient.Actions())
+	}
+}
+
+func TestHandleManual_SecretUnavailable(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace,
+		bldr.EventListenerSpec(
+			bldr.EventListenerManual("missing", "token"),
+			bldr.EventListenerAuthFailureBlock(triggersv1.AuthFailureBlock{MaxFailures: 1}),
+			bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerName("build"),
+			)))
+	sink, _ := getSinkAssets(t, test.Resources{
+		EventListeners: []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	sink.AuthFailures = NewAuthFailureTracker()
+	// The sender is not blocked for a token that the sink cannot read
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/manual/build", strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer token")
+		rec := httptest.NewRecorder()
+		sink.HandleManual(rec, req)
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("Response code = %d, want %d", rec.Code, http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	servedNamespaces = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eventlistener_served_namespaces",
+		Help: "Number of namespaces that the EventListener currently serves Triggers in, by EventListener.",
+	}, []string{"eventlistener_namespace", "eventlistener"})
+	servedTriggers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eventlistener_served_triggers",
+		Help: "Number of Triggers that the EventListener currently serves, counted once per namespace, by EventListener.",
+	}, []string{"eventlistener_namespace", "eventlistener"})
+	interceptorErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_interceptor_errors_total",
+		Help: "Number of events that interceptors rejected or failed to process, by interceptor kind and reason.",
+	}, []string{"interceptor", "reason"})
+	sourceUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eventlistener_source_up",
+		Help: "Whether a source is delivering events to the EventListener, by source.",
+	}, []string{"source"})
+	sourceEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_source_events_total",
+		Help: "Number of events that the EventListener processed, by source and result.",
+	}, []string{"source", "result"})
+	injectedFaults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_injected_faults_total",
+		Help: "Number of failures injected for testing, by fault.",
+	}, []string{"fault"})
+	missingFields = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_missing_fields_total",
+		Help: "Number of binding param expressions that referred to fields missing from an event, by trigger and outcome.",
+	}, []string{"trigger", "outcome"})
+	retriedCreations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_retried_creations_total",
+		Help: "Number of resources whose creation was retried after a transient failure, by trigger and result.",
+	}, []string{"trigger", "result"})
+	phaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "eventlistener_trigger_phase_duration_seconds",
+		Help: "Duration of the phases of processing an event for a Trigger, by trigger and phase.",
+	}, []string{"trigger", "phase"})
+	phaseTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_trigger_phase_timeouts_total",
+		Help: "Number of times a phase of processing an event for a Trigger exceeded its timeout, by trigger and phase.",
+	}, []string{"trigger", "phase"})
+	triggerOverflows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_trigger_overflows_total",
+		Help: "Number of times a Trigger matched an event after the maximum number of Triggers per event was reached, by trigger.",
+	}, []string{"trigger"})
+	interceptorInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eventlistener_interceptor_requests_in_flight",
+		Help: "Number of requests in flight to ClusterInterceptors with a concurrency, by interceptor.",
+	}, []string{"interceptor"})
+	interceptorQueued = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eventlistener_interceptor_requests_queued",
+		Help: "Number of requests waiting for the concurrency of ClusterInterceptors, by interceptor.",
+	}, []string{"interceptor"})
+	authFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_auth_failures_total",
+		Help: "Number of events whose signature or token an interceptor rejected, by interceptor.",
+	}, []string{"interceptor"})
+	sourceBlocks = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "eventlistener_source_blocks_total",
+		Help: "Number of times a source was blocked after too many of its events failed authentication.",
+	})
+	eventsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "eventlistener_events_in_flight",
+		Help: "Number of events that the EventListener is processing, by source.",
+	}, []string{"source"})
+	eventDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "eventlistener_event_duration_seconds",
+		Help:    "Duration of processing an event, from receiving it to responding, by source.",
+		Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"source"})
+	callbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "eventlistener_callbacks_total",
+		Help: "Number of callbacks with the outcome of an event, by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(servedNamespaces, servedTriggers, interceptorErrors, sourceUp, sourceEvents, injectedFaults, missingFields, retriedCreations, phaseDuration, phaseTimeouts, triggerOverflows, interceptorInFlight, interceptorQueued, authFailures, sourceBlocks, eventsInFlight, eventDuration, callbacks)
+}
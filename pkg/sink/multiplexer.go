@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// Multiplexer serves the events of all EventListeners from a single process,
+// e.g. the sink embedded in the controller. The events of an EventListener are
+// sent to /<namespace>/<name>, and its manual firings to
+// /<namespace>/<name>/manual/<trigger>. Each EventListener has its own Sink,
+// which uses the credentials of its ServiceAccount like the pod of a separate
+// sink.
+type Multiplexer struct {
+	// KubeClientSet reads the tokens of the ServiceAccounts.
+	KubeClientSet kubernetes.Interface
+	// TriggersClient looks up the EventListener of an event.
+	TriggersClient triggersclientset.Interface
+	// Config is the configuration that the clients of the Sinks are created
+	// from, with the token of the ServiceAccount of their EventListener.
+	Config *rest.Config
+	// NewSink returns the Sink of an EventListener whose clients use the
+	// config. It is called on the first event of the EventListener and
+	// again when its ServiceAccount changes. The background work of the
+	// Sink stops when stopCh is closed.
+	NewSink func(el *triggersv1.EventListener, config *rest.Config, stopCh <-chan struct{}) (*Sink, error)
+	Logger  *zap.SugaredLogger
+
+	mu    sync.Mutex
+	sinks map[string]*multiplexedSink
+}
+
+type multiplexedSink struct {
+	sink           *Sink
+	serviceAccount string
+	stopCh         chan struct{}
+}
+
+// eventListenerPath returns the namespace and name of the EventListener that
+// an event sent to the path is for.
+func eventListenerPath(path string) (namespace, name string, ok bool) {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// ServeHTTP handles an event with the Sink of its EventListener.
+func (m *Multiplexer) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	namespace, name, ok := eventListenerPath(request.URL.Path)
+	if !ok {
+		http.NotFound(response, request)
+		return
+	}
+	el, err := m.TriggersClient.TriggersV1alpha1().EventListeners(namespace).Get(name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		m.forget(namespace + "/" + name)
+		http.NotFound(response, request)
+		return
+	}
+	if err != nil {
+		m.Logger.Errorf("Error getting EventListener %s in Namespace %s: %s", name, namespace, err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	s, err := m.sink(el)
+	if err != nil {
+		m.Logger.Errorf("Error creating the sink of EventListener %s in Namespace %s: %s", name, namespace, err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	// The Sink serves the path after the EventListener, with the
+	// EventListener that was already fetched
+	prefix := "/" + namespace + "/" + name
+	handler := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		s.serveEvent(response, request, el)
+	})
+	if strings.HasPrefix(strings.TrimPrefix(request.URL.Path, prefix), manualPathPrefix) {
+		handler = func(response http.ResponseWriter, request *http.Request) {
+			if trigger, ok := manualTrigger(response, request); ok {
+				s.serveManual(response, request, el, trigger)
+			}
+		}
+	}
+	http.StripPrefix(prefix, handler).ServeHTTP(response, request)
+}
+
+// sink returns the Sink of the EventListener, and creates it on its first
+// event or when its ServiceAccount changed.
+func (m *Multiplexer) sink(el *triggersv1.EventListener) (*Sink, error) {
+	key := el.Namespace + "/" + el.Name
+	sa := el.Spec.ServiceAccountName
+	if sa == "" {
+		sa = "default"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sinks[key]; ok {
+		if s.serviceAccount == sa {
+			return s.sink, nil
+		}
+		close(s.stopCh)
+		delete(m.sinks, key)
+	}
+
+	// Only the token is needed, which any Sink can read
+	token, err := Sink{KubeClientSet: m.KubeClientSet}.retrieveAuthToken(&corev1.ObjectReference{Namespace: el.Namespace, Name: sa}, nil)
+	if err == nil && token == "" {
+		err = fmt.Errorf("no token found for ServiceAccount %s/%s", el.Namespace, sa)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stopCh := make(chan struct{})
+	s, err := m.NewSink(el, newConfig(token, m.Config), stopCh)
+	if err != nil {
+		close(stopCh)
+		return nil, err
+	}
+	if m.sinks == nil {
+		m.sinks = map[string]*multiplexedSink{}
+	}
+	m.sinks[key] = &multiplexedSink{sink: s, serviceAccount: sa, stopCh: stopCh}
+	return s, nil
+}
+
+// forget stops the Sink of an EventListener that was deleted.
+func (m *Multiplexer) forget(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sinks[key]; ok {
+		close(s.stopCh)
+		delete(m.sinks, key)
+	}
+	namespace, name, _ := eventListenerPath("/" + key)
+	forgetServed(namespace, name)
+}
+
+// Stop stops the background work of all Sinks.
+func (m *Multiplexer) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, s := range m.sinks {
+		close(s.stopCh)
+		delete(m.sinks, key)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestEventListenerPath(t *testing.T) {
+	for _, tc := range []struct {
+		path            string
+		namespace, name string
+		ok              bool
+	}{
+		{path: "/ns/el", namespace: "ns", name: "el", ok: true},
+		{path: "/ns/el/", namespace: "ns", name: "el", ok: true},
+		{path: "/ns/el/extra", namespace: "ns", name: "el", ok: true},
+		{path: "/ns", ok: false},
+		{path: "/ns/", ok: false},
+		{path: "//el", ok: false},
+		{path: "/", ok: false},
+	} {
+		namespace, name, ok := eventListenerPath(tc.path)
+		if namespace != tc.namespace || name != tc.name || ok != tc.ok {
+			t.Errorf("eventListenerPath(%q) = %q, %q, %t, want %q, %q, %t", tc.path, namespace, name, ok, tc.namespace, tc.name, tc.ok)
+		}
+	}
+}
+
+func TestMultiplexer(t *testing.T) {
+	el := &triggersv1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-eventlistener", Namespace: namespace},
+		Spec:       triggersv1.EventListenerSpec{ServiceAccountName: "sa"},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: namespace},
+		Secrets:    []corev1.ObjectReference{{Name: "sa-token"}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sa-token",
+			Namespace:   namespace,
+			Annotations: map[string]string{corev1.ServiceAccountNameKey: "sa"},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+		Data: map[string][]byte{corev1.ServiceAccountTokenKey: []byte("token")},
+	}
+	s, _ := getSinkAssets(t, test.Resources{
+		EventListeners:  []*triggersv1.EventListener{el},
+		ServiceAccounts: []*corev1.ServiceAccount{sa},
+		Secrets:         []*corev1.Secret{secret},
+	}, el.Name, DefaultAuthOverride{})
+
+	var tokens []string
+	m := &Multiplexer{
+		KubeClientSet:  s.KubeClientSet,
+		TriggersClient: s.TriggersClient,
+		Config:         &rest.Config{Host: "https://kubernetes.default.svc"},
+		Logger:         s.Logger,
+		NewSink: func(el *triggersv1.EventListener, cfg *rest.Config, stopCh <-chan struct{}) (*Sink, error) {
+			tokens = append(tokens, cfg.BearerToken)
+			r := s
+			return &r, nil
+		},
+	}
+	defer m.Stop()
+
+	send := func(path string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := send("/" + namespace + "/my-eventlistener"); code != http.StatusAccepted {
+			t.Fatalf("Response code of event %d = %d, want %d", i+1, code, http.StatusAccepted)
+		}
+	}
+	if len(tokens) != 1 || tokens[0] != "token" {
+		t.Errorf("Sinks were created with the tokens %q, want one with the token of the ServiceAccount", tokens)
+	}
+	if code := send("/" + namespace + "/other"); code != http.StatusNotFound {
+		t.Errorf("Response code of an event for a missing EventListener = %d, want %d", code, http.StatusNotFound)
+	}
+	if code := send("/"); code != http.StatusNotFound {
+		t.Errorf("Response code of an event without an EventListener = %d, want %d", code, http.StatusNotFound)
+	}
+
+	// A ServiceAccount without a token fails the Sink of the EventListener
+	el.Spec.ServiceAccountName = "no-token"
+	if _, err := s.TriggersClient.TriggersV1alpha1().EventListeners(namespace).Update(el); err != nil {
+		t.Fatal(err)
+	}
+	if code := send("/" + namespace + "/my-eventlistener"); code != http.StatusInternalServerError {
+		t.Errorf("Response code of an event for a ServiceAccount without a token = %d, want %d", code, http.StatusInternalServerError)
+	}
+}
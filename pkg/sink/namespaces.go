@@ -0,0 +1,172 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// namespaceSyncTimeout bounds how long the first event for an EventListener
+// with a namespaceSelector waits for the Namespace informer to sync.
+const namespaceSyncTimeout = 30 * time.Second
+
+// NamespaceTracker tracks the namespaces that an EventListener serves its
+// Triggers in. It watches Namespaces, so namespaces that start or stop
+// matching the namespaceSelector are picked up without restarting the sink.
+type NamespaceTracker struct {
+	kubeClient kubernetes.Interface
+	logger     *zap.SugaredLogger
+	stopCh     <-chan struct{}
+
+	once   sync.Once
+	lister corev1listers.NamespaceLister
+	synced cache.InformerSynced
+
+	mu        sync.Mutex
+	name      string
+	namespace string
+	selector  labels.Selector
+	triggers  int
+	served    map[string]bool
+}
+
+// NewNamespaceTracker returns a NamespaceTracker whose Namespace informer
+// runs until stopCh is closed.
+func NewNamespaceTracker(k kubernetes.Interface, logger *zap.SugaredLogger, stopCh <-chan struct{}) *NamespaceTracker {
+	return &NamespaceTracker{
+		kubeClient: k,
+		logger:     logger,
+		stopCh:     stopCh,
+	}
+}
+
+// Namespaces returns the namespaces to serve the Triggers of the
+// EventListener in: the namespace of the EventListener followed by the
+// namespaces selected by its namespaceSelector, in order.
+func (t *NamespaceTracker) Namespaces(el *triggersv1.EventListener) ([]string, error) {
+	var selector labels.Selector
+	if el.Spec.NamespaceSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(el.Spec.NamespaceSelector); err != nil {
+			return nil, err
+		}
+		if err := t.start(); err != nil {
+			return nil, err
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.name = el.Name
+	t.namespace = el.Namespace
+	t.selector = selector
+	t.triggers = len(el.Spec.Triggers)
+	return t.update()
+}
+
+// start starts the Namespace informer the first time it is called, so that
+// the EventListener ServiceAccount only needs to list and watch Namespaces
+// when a namespaceSelector is used.
+func (t *NamespaceTracker) start() error {
+	t.once.Do(func() {
+		factory := informers.NewSharedInformerFactory(t.kubeClient, 0)
+		informer := factory.Core().V1().Namespaces()
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { t.resync() },
+			UpdateFunc: func(interface{}, interface{}) { t.resync() },
+			DeleteFunc: func(interface{}) { t.resync() },
+		})
+		t.lister = informer.Lister()
+		t.synced = informer.Informer().HasSynced
+		factory.Start(t.stopCh)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), namespaceSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), t.synced) {
+		return errors.New("timed out waiting for the Namespace informer to sync")
+	}
+	return nil
+}
+
+// resync updates the served namespaces when a Namespace is added, changed or
+// deleted.
+func (t *NamespaceTracker) resync() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.selector == nil {
+		return
+	}
+	if _, err := t.update(); err != nil {
+		t.logger.Errorf("Error updating the served namespaces: %s", err)
+	}
+}
+
+// update recomputes the served namespaces and the metrics. t.mu must be held.
+func (t *NamespaceTracker) update() ([]string, error) {
+	served := map[string]bool{t.namespace: true}
+	var selected []string
+	if t.selector != nil {
+		nss, err := t.lister.List(t.selector)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nss {
+			if ns.Name == t.namespace || ns.DeletionTimestamp != nil {
+				continue
+			}
+			served[ns.Name] = true
+			selected = append(selected, ns.Name)
+		}
+	}
+	for ns := range served {
+		if t.served != nil && !t.served[ns] {
+			t.logger.Infof("Serving Triggers in namespace %s", ns)
+		}
+	}
+	for ns := range t.served {
+		if !served[ns] {
+			t.logger.Infof("Stopped serving Triggers in namespace %s", ns)
+		}
+	}
+	t.served = served
+
+	// The sink embedded in the controller serves many EventListeners
+	servedNamespaces.WithLabelValues(t.namespace, t.name).Set(float64(len(served)))
+	servedTriggers.WithLabelValues(t.namespace, t.name).Set(float64(len(served) * t.triggers))
+
+	sort.Strings(selected)
+	return append([]string{t.namespace}, selected...), nil
+}
+
+// forgetServed removes the metrics of an EventListener that was deleted.
+func forgetServed(namespace, name string) {
+	servedNamespaces.DeleteLabelValues(namespace, name)
+	servedTriggers.DeleteLabelValues(namespace, name)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tektoncd/pipeline/pkg/logging"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+)
+
+func gaugeValue(t *testing.T, g prometheus.Gauge) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := g.Write(&m); err != nil {
+		t.Fatalf("Error reading gauge: %s", err)
+	}
+	return m.GetGauge().GetValue()
+}
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		t.Fatalf("Error reading counter: %s", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestNamespaceTracker_NoSelector(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	tracker := NewNamespaceTracker(fakekubeclientset.NewSimpleClientset(), logger, stopCh)
+
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1"),
+	))
+	got, err := tracker.Namespaces(el)
+	if err != nil {
+		t.Fatalf("Namespaces() = %v", err)
+	}
+	if diff := cmp.Diff([]string{namespace}, got); diff != "" {
+		t.Errorf("Namespaces() -want,+got: %s", diff)
+	}
+	if tracker.lister != nil {
+		t.Error("Namespace informer started without a namespaceSelector")
+	}
+
+	// The metrics of EventListeners served by the same process are separate
+	other := bldr.EventListener("other-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("tt-1", "v1alpha1"),
+		bldr.EventListenerTrigger("tt-2", "v1alpha1"),
+	))
+	if _, err := NewNamespaceTracker(fakekubeclientset.NewSimpleClientset(), logger, stopCh).Namespaces(other); err != nil {
+		t.Fatalf("Namespaces() = %v", err)
+	}
+	if v := gaugeValue(t, servedTriggers.WithLabelValues(namespace, el.Name)); v != 1 {
+		t.Errorf("served triggers of %s = %v, want 1", el.Name, v)
+	}
+	if v := gaugeValue(t, servedTriggers.WithLabelValues(namespace, other.Name)); v != 2 {
+		t.Errorf("served triggers of %s = %v, want 2", other.Name, v)
+	}
+	forgetServed(namespace, other.Name)
+	if servedTriggers.DeleteLabelValues(namespace, other.Name) {
+		t.Errorf("served triggers of deleted %s were not removed", other.Name)
+	}
+}
+
+func TestNamespaceTracker_Selector(t *testing.T) {
+	ns := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	team := map[string]string{"team": "a"}
+	kubeClient := fakekubeclientset.NewSimpleClientset(
+		ns(namespace, nil),
+		ns("team-a-dev", team),
+		ns("team-a-prod", team),
+		ns("team-b", map[string]string{"team": "b"}),
+	)
+	logger, _ := logging.NewLogger("", "")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	tracker := NewNamespaceTracker(kubeClient, logger, stopCh)
+
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerNamespaceSelector(team),
+		bldr.EventListenerTrigger("tt-1", "v1alpha1"),
+		bldr.EventListenerTrigger("tt-2", "v1alpha1"),
+	))
+	got, err := tracker.Namespaces(el)
+	if err != nil {
+		t.Fatalf("Namespaces() = %v", err)
+	}
+	if diff := cmp.Diff([]string{namespace, "team-a-dev", "team-a-prod"}, got); diff != "" {
+		t.Errorf("Namespaces() -want,+got: %s", diff)
+	}
+	if v := gaugeValue(t, servedNamespaces.WithLabelValues(namespace, el.Name)); v != 3 {
+		t.Errorf("served namespaces = %v, want 3", v)
+	}
+	if v := gaugeValue(t, servedTriggers.WithLabelValues(namespace, el.Name)); v != 6 {
+		t.Errorf("served triggers = %v, want 6", v)
+	}
+
+	// Namespaces that start or stop matching the selector are picked up
+	// without calling Namespaces again.
+	if _, err := kubeClient.CoreV1().Namespaces().Update(ns("team-b", team)); err != nil {
+		t.Fatalf("Error updating namespace: %s", err)
+	}
+	if err := kubeClient.CoreV1().Namespaces().Delete("team-a-prod", &metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Error deleting namespace: %s", err)
+	}
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return len(tracker.served) == 3 && tracker.served["team-b"], nil
+	}); err != nil {
+		t.Fatal("served namespaces were not updated")
+	}
+
+	got, err = tracker.Namespaces(el)
+	if err != nil {
+		t.Fatalf("Namespaces() = %v", err)
+	}
+	if diff := cmp.Diff([]string{namespace, "team-a-dev", "team-b"}, got); diff != "" {
+		t.Errorf("Namespaces() -want,+got: %s", diff)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// The phases of processing an event for a Trigger.
+const (
+	phaseInterceptor = "interceptor"
+	phaseRender      = "render"
+	phaseCreate      = "create"
+)
+
+// mergePhaseTimeouts returns the phase timeouts of a Trigger, which override
+// those of the EventListener phase by phase.
+func mergePhaseTimeouts(el, trigger *triggersv1.PhaseTimeouts) triggersv1.PhaseTimeouts {
+	var out triggersv1.PhaseTimeouts
+	for _, p := range []*triggersv1.PhaseTimeouts{el, trigger} {
+		if p == nil {
+			continue
+		}
+		if p.InterceptorSeconds > 0 {
+			out.InterceptorSeconds = p.InterceptorSeconds
+		}
+		if p.RenderSeconds > 0 {
+			out.RenderSeconds = p.RenderSeconds
+		}
+		if p.CreateSeconds > 0 {
+			out.CreateSeconds = p.CreateSeconds
+		}
+	}
+	return out
+}
+
+// seconds converts a timeout in seconds to a Duration.
+func seconds(s int32) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// runPhase runs a phase of processing an event for a Trigger and records its
+// duration. A timeout of zero only limits the phase by the event timeout.
+// Once the timeout of the phase passes, runPhase returns a DeadlineExceeded
+// error for the phase without waiting for fn, since calls to the Kubernetes
+// API cannot be cancelled.
+func runPhase(ctx context.Context, trigger, phase string, timeout time.Duration, fn func(ctx context.Context) error) error {
+	start := time.Now()
+	defer func() {
+		phaseDuration.WithLabelValues(trigger, phase).Observe(time.Since(start).Seconds())
+	}()
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	phaseCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(phaseCtx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-phaseCtx.Done():
+		// Prefer the error of the phase if it finished at the timeout
+		select {
+		case err = <-done:
+		default:
+			err = phaseCtx.Err()
+		}
+	}
+	// Only the timeout of the phase is reported here, the event timeout is
+	// reported for all pending Triggers at once
+	if err == nil || ctx.Err() != nil || phaseCtx.Err() == nil {
+		return err
+	}
+	phaseTimeouts.WithLabelValues(trigger, phase).Inc()
+	rerr := &reasonError{
+		reason: triggersv1.ReasonDeadlineExceeded,
+		err:    fmt.Errorf("%s phase did not finish within its timeout of %s: %w", phase, timeout, err),
+		phase:  phase,
+	}
+	var ierr *reasonError
+	if errors.As(err, &ierr) {
+		rerr.interceptor = ierr.interceptor
+	}
+	return rerr
+}
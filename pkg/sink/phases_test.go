@@ -0,0 +1,169 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestMergePhaseTimeouts(t *testing.T) {
+	el := &triggersv1.PhaseTimeouts{InterceptorSeconds: 5, CreateSeconds: 10}
+	trigger := &triggersv1.PhaseTimeouts{InterceptorSeconds: 20, RenderSeconds: 2}
+	tests := []struct {
+		name        string
+		el, trigger *triggersv1.PhaseTimeouts
+		want        triggersv1.PhaseTimeouts
+	}{{
+		name: "none",
+	}, {
+		name: "EventListener",
+		el:   el,
+		want: *el,
+	}, {
+		name:    "Trigger",
+		trigger: trigger,
+		want:    *trigger,
+	}, {
+		name:    "Trigger overrides EventListener by phase",
+		el:      el,
+		trigger: trigger,
+		want:    triggersv1.PhaseTimeouts{InterceptorSeconds: 20, RenderSeconds: 2, CreateSeconds: 10},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, mergePhaseTimeouts(tc.el, tc.trigger)); diff != "" {
+				t.Errorf("mergePhaseTimeouts() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRunPhase(t *testing.T) {
+	hang := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+	errFailed := errors.New("failed")
+
+	// A phase that exceeds its timeout fails without waiting for it
+	start := time.Now()
+	err := runPhase(context.Background(), "my-trigger", phaseCreate, 10*time.Millisecond, hang)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("runPhase() returned after %s, want it to return at the timeout", elapsed)
+	}
+	var rerr *reasonError
+	if !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonDeadlineExceeded || rerr.phase != phaseCreate {
+		t.Errorf("runPhase() = %v, want DeadlineExceeded error for the create phase", err)
+	}
+
+	// Errors of phases that finish in time are returned as they are
+	for _, timeout := range []time.Duration{0, time.Minute} {
+		if err := runPhase(context.Background(), "my-trigger", phaseRender, timeout, func(context.Context) error { return errFailed }); err != errFailed {
+			t.Errorf("runPhase() with timeout %s = %v, want %v", timeout, err, errFailed)
+		}
+		if err := runPhase(context.Background(), "my-trigger", phaseRender, timeout, func(context.Context) error { return nil }); err != nil {
+			t.Errorf("runPhase() with timeout %s = %v, want nil", timeout, err)
+		}
+	}
+
+	// The event timeout is not reported as the timeout of the phase
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = runPhase(ctx, "my-trigger", phaseRender, time.Minute, func(ctx context.Context) error { return checkDeadline(ctx) })
+	if err == nil || (errors.As(err, &rerr) && rerr.phase != "") {
+		t.Errorf("runPhase() after the event timeout = %v, want error without phase", err)
+	}
+}
+
+func TestHandleEvent_PhaseTimeouts(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"generateName":"ref-"},"spec":{"type":"git"}}`)}),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerPhaseTimeouts(triggersv1.PhaseTimeouts{CreateSeconds: 1}),
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("hung-create"),
+		),
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("slow-interceptor"),
+			bldr.EventListenerCELInterceptor("true"),
+			bldr.EventListenerTriggerPhaseTimeouts(triggersv1.PhaseTimeouts{InterceptorSeconds: 1, CreateSeconds: 5}),
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	// Creating resources hangs for the Trigger without interceptors, whose
+	// resources are created first
+	hung := true
+	dynamicClient.PrependReactor("create", "pipelineresources", func(ktesting.Action) (bool, runtime.Object, error) {
+		if hung {
+			hung = false
+			time.Sleep(3 * time.Second)
+		}
+		return false, nil, nil
+	})
+	sink.Faults = &FaultInjector{
+		InterceptorLatency:     3 * time.Second,
+		InterceptorLatencyRate: 1,
+		random:                 func() float64 { return 0 },
+	}
+	counter := phaseTimeouts.WithLabelValues("hung-create", phaseCreate)
+	before := counterValue(t, counter)
+
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	sink.HandleEvent(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`))))
+	if elapsed := time.Since(start); elapsed >= 3*time.Second {
+		t.Errorf("Response took %s, want it to be sent at the phase timeouts", elapsed)
+	}
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("Response code = %d, want %d", rec.Code, http.StatusAccepted)
+	}
+	var body Response
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	wantErrors := []TriggerError{
+		{Trigger: "hung-create", Reason: triggersv1.ReasonDeadlineExceeded, Phase: phaseCreate},
+		{Trigger: "slow-interceptor", Reason: triggersv1.ReasonDeadlineExceeded, Phase: phaseInterceptor},
+	}
+	sortErrors := cmpopts.SortSlices(func(a, b TriggerError) bool { return a.Trigger < b.Trigger })
+	if diff := cmp.Diff(wantErrors, body.Errors, sortErrors); diff != "" {
+		t.Errorf("did not get expected errors back -want,+got: %s", diff)
+	}
+	if got := counterValue(t, counter) - before; got != 1 {
+		t.Errorf("eventlistener_trigger_phase_timeouts_total increased by %v, want 1", got)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/github"
+)
+
+// isGitHubPing returns true for the ping event that GitHub sends when a
+// webhook is created, if the EventListener acknowledges it instead of
+// evaluating its Triggers.
+func isGitHubPing(el *triggersv1.EventListener, request *http.Request) bool {
+	if request.Header.Get("X-GitHub-Event") != "ping" {
+		return false
+	}
+	acknowledge := false
+	for _, t := range el.Spec.Triggers {
+		for _, i := range t.Interceptors {
+			if i == nil || i.GitHub == nil {
+				continue
+			}
+			if i.GitHub.ProcessPing {
+				return false
+			}
+			acknowledge = true
+		}
+	}
+	return acknowledge
+}
+
+// authenticatePing returns nil if the ping event is from a trusted sender or
+// passes the secret check of a GitHub interceptor of the EventListener, so
+// that its zen is only echoed to the owners of the webhook secret. Failures to
+// read a secret are returned before authentication errors, since the sender is
+// not at fault.
+func (r Sink) authenticatePing(el *triggersv1.EventListener, request *http.Request, event []byte) error {
+	if trusted, err := r.trustedSender(el, request); err == nil && trusted != "" {
+		return nil
+	}
+	var authErr, secretErr error
+	for _, t := range el.Spec.Triggers {
+		for _, i := range t.Interceptors {
+			if i == nil || i.GitHub == nil {
+				continue
+			}
+			if i.GitHub.SecretRef == nil {
+				return nil
+			}
+			err := github.ValidateSignature(r.KubeClientSet, i.GitHub, r.EventListenerNamespace, request.Header, event)
+			switch {
+			case err == nil:
+				return nil
+			case errors.Is(err, interceptors.ErrAuthFailed):
+				authErr = err
+			default:
+				secretErr = err
+			}
+		}
+	}
+	if secretErr != nil {
+		return secretErr
+	}
+	return authErr
+}
+
+// acknowledgePing responds with 200 OK and the zen of the ping event, so that
+// GitHub shows the webhook as working.
+func (r Sink) acknowledgePing(response http.ResponseWriter, event []byte, eventID string) {
+	var ping struct {
+		Zen string `json:"zen"`
+	}
+	// The zen is only echoed back, so a malformed ping is acknowledged too
+	_ = json.Unmarshal(event, &ping)
+	if ping.Zen == "" {
+		ping.Zen = "pong"
+	}
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(http.StatusOK)
+	body := Response{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		EventID:       eventID,
+		Message:       ping.Zen,
+	}
+	if err := json.NewEncoder(response).Encode(body); err != nil {
+		r.Logger.Errorf("failed to write back sink response: %v", err)
+	}
+}
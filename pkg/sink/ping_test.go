@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func githubTrigger(gh *triggersv1.GitHubInterceptor) bldr.EventListenerSpecOp {
+	return func(spec *triggersv1.EventListenerSpec) {
+		spec.Triggers = append(spec.Triggers, triggersv1.EventListenerTrigger{
+			Name:         "my-trigger",
+			Bindings:     []*triggersv1.EventListenerBinding{{Name: "my-triggerbinding", Kind: triggersv1.NamespacedTriggerBindingKind}},
+			Template:     triggersv1.EventListenerTemplate{Name: "my-triggertemplate"},
+			Interceptors: []*triggersv1.EventInterceptor{{GitHub: gh}},
+		})
+	}
+}
+
+func Test_isGitHubPing(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  []bldr.EventListenerSpecOp
+		event string
+		want  bool
+	}{{
+		name:  "ping to GitHub interceptor",
+		spec:  []bldr.EventListenerSpecOp{githubTrigger(&triggersv1.GitHubInterceptor{EventTypes: []string{"push"}})},
+		event: "ping",
+		want:  true,
+	}, {
+		name:  "push to GitHub interceptor",
+		spec:  []bldr.EventListenerSpecOp{githubTrigger(&triggersv1.GitHubInterceptor{})},
+		event: "push",
+	}, {
+		name:  "ping without GitHub interceptor",
+		spec:  []bldr.EventListenerSpecOp{bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1")},
+		event: "ping",
+	}, {
+		name: "ping processed by a GitHub interceptor",
+		spec: []bldr.EventListenerSpecOp{
+			githubTrigger(&triggersv1.GitHubInterceptor{}),
+			githubTrigger(&triggersv1.GitHubInterceptor{ProcessPing: true}),
+		},
+		event: "ping",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(tt.spec...))
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("X-GitHub-Event", tt.event)
+			if got := isGitHubPing(el, req); got != tt.want {
+				t.Errorf("isGitHubPing() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_GitHubPing(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		githubTrigger(&triggersv1.GitHubInterceptor{EventTypes: []string{"push"}}),
+	))
+	sink, dynamicClient := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+	req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{"zen": "Keep it logically awesome.", "hook_id": 1}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-GitHub-Event", "ping")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error sending event: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Response code = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var got Response
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("Error decoding response: %s", err)
+	}
+	want := Response{
+		EventListener: el.Name,
+		Namespace:     namespace,
+		EventID:       eventID,
+		Message:       "Keep it logically awesome.",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Response mismatch (-want + got): %s", diff)
+	}
+	if actions := dynamicClient.Actions(); len(actions) != 0 {
+		t.Errorf("Resources were created for a ping event: %v", actions)
+	}
+}
+
+func TestHandleEvent_GitHubPingSignature(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		githubTrigger(&triggersv1.GitHubInterceptor{
+			SecretRef:  &triggersv1.SecretRef{SecretName: "github-secret", SecretKey: "token"},
+			EventTypes: []string{"push"},
+		}),
+	))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-secret", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	body := []byte(`{"zen": "Keep it logically awesome.", "hook_id": 1}`)
+	sign := func(key string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	tests := []struct {
+		name      string
+		signature string
+		wantCode  int
+	}{{
+		name:      "valid signature",
+		signature: sign("s3cr3t"),
+		wantCode:  http.StatusOK,
+	}, {
+		name:      "wrong signature",
+		signature: sign("guess"),
+		wantCode:  http.StatusUnauthorized,
+	}, {
+		name:     "missing signature",
+		wantCode: http.StatusUnauthorized,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resources := sqsTestResources(el)
+			resources.Secrets = []*corev1.Secret{secret}
+			sink, dynamicClient := getSinkAssets(t, resources, el.Name, DefaultAuthOverride{})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "ping")
+			if tc.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tc.signature)
+			}
+			rec := httptest.NewRecorder()
+			sink.HandleEvent(rec, req)
+			if rec.Code != tc.wantCode {
+				t.Errorf("Response code = %d, want %d", rec.Code, tc.wantCode)
+			}
+			if echoed := strings.Contains(rec.Body.String(), "logically awesome"); echoed != (tc.wantCode == http.StatusOK) {
+				t.Errorf("Response %q echoes the zen: %t", rec.Body.String(), echoed)
+			}
+			if actions := dynamicClient.Actions(); len(actions) != 0 {
+				t.Errorf("Resources were created for a ping event: %v", actions)
+			}
+		})
+	}
+}
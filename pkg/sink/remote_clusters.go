@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	dynamicClientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
+	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	discoveryclient "k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// RemoteClusterCache keeps the clients for the remote clusters of Triggers by
+// their kubeconfig Secret, so that the clients and their connections are
+// reused across events. The clients of a Secret are rebuilt when it changes.
+type RemoteClusterCache struct {
+	mu      sync.Mutex
+	clients map[string]*remoteClients
+	// newClients builds the clients for a remote cluster. It is replaced in
+	// tests.
+	newClients func(*rest.Config) (discoveryclient.ServerResourcesInterface, dynamic.Interface, error)
+}
+
+type remoteClients struct {
+	resourceVersion string
+	discoveryClient discoveryclient.ServerResourcesInterface
+	dynamicClient   dynamic.Interface
+}
+
+// NewRemoteClusterCache returns an empty RemoteClusterCache.
+func NewRemoteClusterCache() *RemoteClusterCache {
+	return &RemoteClusterCache{
+		clients:    map[string]*remoteClients{},
+		newClients: newRemoteClients,
+	}
+}
+
+func newRemoteClients(config *rest.Config) (discoveryclient.ServerResourcesInterface, dynamic.Interface, error) {
+	dc, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kubeClient.Discovery(), dynamicClientset.New(tekton.WithClient(dc)), nil
+}
+
+// get returns the clients for the kubeconfig under key in the Secret.
+func (c *RemoteClusterCache) get(secret *corev1.Secret, key string) (discoveryclient.ServerResourcesInterface, dynamic.Interface, error) {
+	cacheKey := secret.Namespace + "/" + secret.Name + "/" + key
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.clients[cacheKey]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.discoveryClient, cached.dynamicClient, nil
+	}
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, nil, withReason(triggersv1.ReasonSecretMissing,
+			fmt.Errorf("key %s not found in kubeconfig Secret %s/%s", key, secret.Namespace, secret.Name))
+	}
+	config, err := remoteClusterConfig(kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kubeconfig Secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	discoveryClient, dynamicClient, err := c.newClients(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.clients[cacheKey] = &remoteClients{
+		resourceVersion: secret.ResourceVersion,
+		discoveryClient: discoveryClient,
+		dynamicClient:   dynamicClient,
+	}
+	return discoveryClient, dynamicClient, nil
+}
+
+// remoteClusterConfig returns the REST config of the current context of a
+// kubeconfig. Credentials have to be inline, since authentication plugins and
+// files would run commands or read files of the sink.
+func remoteClusterConfig(kubeconfig []byte) (*rest.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	for name, user := range config.AuthInfos {
+		switch {
+		case user.Exec != nil, user.AuthProvider != nil:
+			return nil, fmt.Errorf("user %s uses an authentication plugin, which is not supported", name)
+		case user.ClientCertificate != "", user.ClientKey != "", user.TokenFile != "":
+			return nil, fmt.Errorf("user %s refers to files, only inline credentials are supported", name)
+		}
+	}
+	for name, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return nil, fmt.Errorf("cluster %s refers to a file, only inline certificates are supported", name)
+		}
+	}
+	return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+// remoteClusterClients returns the clients for the remote cluster of a Trigger
+// in the namespace ns. The kubeconfig Secret is read with the token of the
+// ServiceAccount of the Trigger if it has one, so that a Trigger can only
+// target the clusters whose Secrets its ServiceAccount may read.
+func (r Sink) remoteClusterClients(c *triggersv1.RemoteCluster, ns, token string, log *zap.SugaredLogger) (discoveryclient.ServerResourcesInterface, dynamic.Interface, error) {
+	if r.RemoteClusters == nil {
+		return nil, nil, errors.New("remote clusters are not supported by this sink")
+	}
+	kubeClient := r.KubeClientSet
+	if token != "" {
+		var err error
+		if kubeClient, err = r.Auth.OverrideKubeClient(token, log, r.KubeClientSet); err != nil {
+			return nil, nil, err
+		}
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(c.KubeconfigSecret.SecretName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil, withReason(triggersv1.ReasonSecretMissing, err)
+	} else if err != nil {
+		return nil, nil, err
+	}
+	key := c.KubeconfigSecret.SecretKey
+	if key == "" {
+		key = triggersv1.DefaultKubeconfigKey
+	}
+	return r.RemoteClusters.get(secret, key)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	dynamicclientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
+	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	discoveryclient "k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- name: workload
+  cluster:
+    server: https://workload.example.com
+contexts:
+- name: workload
+  context:
+    cluster: workload
+    user: triggers
+current-context: workload
+users:
+- name: triggers
+  user:
+    token: workload-token
+`
+
+func Test_remoteClusterConfig(t *testing.T) {
+	config, err := remoteClusterConfig([]byte(testKubeconfig))
+	if err != nil {
+		t.Fatalf("remoteClusterConfig() returned error: %s", err)
+	}
+	if config.Host != "https://workload.example.com" || config.BearerToken != "workload-token" {
+		t.Errorf("remoteClusterConfig() = host %q, token %q", config.Host, config.BearerToken)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		replace string
+		with    string
+	}{{
+		name:    "exec plugin",
+		replace: "token: workload-token",
+		with:    "exec: {apiVersion: client.authentication.k8s.io/v1beta1, command: cat}",
+	}, {
+		name:    "auth provider",
+		replace: "token: workload-token",
+		with:    "auth-provider: {name: gcp}",
+	}, {
+		name:    "token file",
+		replace: "token: workload-token",
+		with:    "tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token",
+	}, {
+		name:    "client certificate file",
+		replace: "token: workload-token",
+		with:    "client-certificate: /etc/tls/tls.crt",
+	}, {
+		name:    "certificate authority file",
+		replace: "server: https://workload.example.com",
+		with:    "server: https://workload.example.com\n    certificate-authority: /etc/ca.crt",
+	}, {
+		name:    "not a kubeconfig",
+		replace: "kind: Config",
+		with:    "kind: [Config",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfig := strings.Replace(testKubeconfig, tc.replace, tc.with, 1)
+			if _, err := remoteClusterConfig([]byte(kubeconfig)); err == nil {
+				t.Error("remoteClusterConfig() did not return an error")
+			}
+		})
+	}
+}
+
+func TestRemoteClusterCache(t *testing.T) {
+	var built int
+	c := NewRemoteClusterCache()
+	c.newClients = func(*rest.Config) (discoveryclient.ServerResourcesInterface, dynamic.Interface, error) {
+		built++
+		return nil, nil, nil
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: namespace, ResourceVersion: "1"},
+		Data:       map[string][]byte{"kubeconfig": []byte(testKubeconfig)},
+	}
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.get(secret, "kubeconfig"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if built != 1 {
+		t.Errorf("built clients %d times for the same Secret, want 1", built)
+	}
+
+	// A rotated kubeconfig gets new clients
+	secret.ResourceVersion = "2"
+	if _, _, err := c.get(secret, "kubeconfig"); err != nil {
+		t.Fatal(err)
+	}
+	if built != 2 {
+		t.Errorf("built clients %d times after the Secret changed, want 2", built)
+	}
+
+	if _, _, err := c.get(secret, "other"); err == nil {
+		t.Error("get() with a missing key did not return an error")
+	}
+}
+
+func TestHandleEvent_RemoteCluster(t *testing.T) {
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: namespace},
+		Data:       map[string][]byte{"config": []byte(testKubeconfig)},
+	}
+	tests := []struct {
+		name          string
+		secretName    string
+		wantCode      int
+		wantErrors    []TriggerError
+		wantNamespace string
+	}{{
+		name:          "resources are created on the remote cluster",
+		secretName:    "workload",
+		wantCode:      http.StatusCreated,
+		wantNamespace: "workloads",
+	}, {
+		name:       "missing kubeconfig Secret",
+		secretName: "missing",
+		wantCode:   http.StatusAccepted,
+		wantErrors: []TriggerError{{Trigger: "my-trigger", Reason: triggersv1.ReasonSecretMissing}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+					bldr.EventListenerTriggerCluster(triggersv1.RemoteCluster{
+						KubeconfigSecret: triggersv1.SecretRef{SecretName: tc.secretName, SecretKey: "config"},
+						Namespace:        "workloads",
+					}),
+				),
+			))
+			resources := sqsTestResources(el)
+			resources.Secrets = []*corev1.Secret{kubeconfigSecret}
+			sink, localClient := getSinkAssets(t, resources, el.Name, DefaultAuthOverride{})
+			remoteClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
+			sink.RemoteClusters = NewRemoteClusterCache()
+			sink.RemoteClusters.newClients = func(config *rest.Config) (discoveryclient.ServerResourcesInterface, dynamic.Interface, error) {
+				if config.Host != "https://workload.example.com" {
+					t.Errorf("clients built for host %q", config.Host)
+				}
+				return sink.DiscoveryClient, dynamicclientset.New(tekton.WithClient(remoteClient)), nil
+			}
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{"url": "https://example.com"}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("X-Event", "push")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Error sending event: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantCode {
+				t.Errorf("Response code = %d, want %d", resp.StatusCode, tc.wantCode)
+			}
+			var gotBody Response
+			if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+				t.Fatalf("Error reading response body: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantErrors, gotBody.Errors); diff != "" {
+				t.Errorf("did not get expected errors back -want,+got: %s", diff)
+			}
+
+			if n := len(localClient.Actions()); n != 0 {
+				t.Errorf("%d requests to the cluster of the EventListener, want none", n)
+			}
+			var namespaces []string
+			for _, a := range remoteClient.Actions() {
+				namespaces = append(namespaces, a.GetNamespace())
+			}
+			var wantNamespaces []string
+			if tc.wantNamespace != "" {
+				wantNamespaces = []string{tc.wantNamespace}
+			}
+			if diff := cmp.Diff(wantNamespaces, namespaces); diff != "" {
+				t.Errorf("remote cluster requests in namespaces -want,+got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/triggers/pkg/resources"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// creationAttemptsAnnotation is the number of attempts it took to create
+	// a resource. It is only set on resources whose creation was retried.
+	creationAttemptsAnnotation = "creation-attempts"
+	// originalEventIDAnnotation is the ID of the event that a retried
+	// resource was created for.
+	originalEventIDAnnotation = "original-event-id"
+	// deliveryIDAnnotation is the delivery ID of the event that a retried
+	// resource was created for, read from the deduplication header.
+	deliveryIDAnnotation = "delivery-id"
+)
+
+// createBackoff is the backoff between the attempts to create a resource.
+var createBackoff = wait.Backoff{
+	Steps:    3,
+	Duration: 200 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// isTransientCreateError reports whether the creation of a resource failed
+// for a reason that may not persist, so that it is worth another attempt.
+func isTransientCreateError(err error) bool {
+	var status kerrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	switch status.Status().Reason {
+	case metav1.StatusReasonServerTimeout, metav1.StatusReasonTimeout, metav1.StatusReasonTooManyRequests,
+		metav1.StatusReasonServiceUnavailable, metav1.StatusReasonInternalError:
+		return true
+	}
+	return false
+}
+
+// createWithRetries calls create with the number of the attempt until it
+// succeeds, fails for a reason that is not transient, the backoff is
+// exhausted or the deadline of the event passes. It returns the number of
+// attempts.
+func createWithRetries(ctx context.Context, backoff wait.Backoff, create func(attempt int) error) (int, error) {
+	attempt := 0
+	for {
+		attempt++
+		err := create(attempt)
+		if err == nil || !isTransientCreateError(err) {
+			return attempt, err
+		}
+		if backoff.Steps <= 1 {
+			return attempt, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// annotateRetry annotates a resource that is created again with the number of
+// the attempt and the IDs of the event it is created for, so that retried
+// creations can be told apart downstream.
+func annotateRetry(rt json.RawMessage, attempt int, eventID, deliveryID string) (json.RawMessage, error) {
+	data := new(unstructured.Unstructured)
+	if err := data.UnmarshalJSON(rt); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal json: %w", err)
+	}
+	annotations := map[string]string{
+		creationAttemptsAnnotation: strconv.Itoa(attempt),
+		originalEventIDAnnotation:  eventID,
+	}
+	if deliveryID != "" {
+		annotations[deliveryIDAnnotation] = deliveryID
+	}
+	return resources.AddAnnotations(data, annotations).MarshalJSON()
+}
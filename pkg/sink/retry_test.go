@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func TestIsTransientCreateError(t *testing.T) {
+	gr := schema.GroupResource{Group: "tekton.dev", Resource: "pipelineruns"}
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: kerrors.NewServiceUnavailable("unavailable"), want: true},
+		{err: kerrors.NewTooManyRequests("slow down", 1), want: true},
+		{err: kerrors.NewInternalError(errors.New("etcd")), want: true},
+		{err: kerrors.NewServerTimeout(gr, "create", 1), want: true},
+		{err: kerrors.NewTimeoutError("timeout", 1), want: true},
+		{err: fmt.Errorf("couldn't create resource: %w", kerrors.NewServiceUnavailable("unavailable")), want: true},
+		{err: kerrors.NewAlreadyExists(gr, "run"), want: false},
+		{err: kerrors.NewBadRequest("invalid"), want: false},
+		{err: kerrors.NewForbidden(gr, "run", errors.New("denied")), want: false},
+		{err: errors.New("couldn't unmarshal json"), want: false},
+	}
+	for _, tc := range tests {
+		if got := isTransientCreateError(tc.err); got != tc.want {
+			t.Errorf("isTransientCreateError(%v) = %t, want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestCreateWithRetries(t *testing.T) {
+	backoff := wait.Backoff{Steps: 3, Duration: time.Millisecond, Factor: 2}
+	unavailable := kerrors.NewServiceUnavailable("unavailable")
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+	}{{
+		name:         "created at once",
+		wantAttempts: 1,
+	}, {
+		name:         "created by a retry",
+		errs:         []error{unavailable, unavailable},
+		wantAttempts: 3,
+	}, {
+		name:         "permanent failure",
+		errs:         []error{kerrors.NewBadRequest("invalid")},
+		wantAttempts: 1,
+		wantErr:      true,
+	}, {
+		name:         "backoff exhausted",
+		errs:         []error{unavailable, unavailable, unavailable, unavailable},
+		wantAttempts: 3,
+		wantErr:      true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []int
+			attempts, err := createWithRetries(context.Background(), backoff, func(attempt int) error {
+				got = append(got, attempt)
+				if attempt <= len(tc.errs) {
+					return tc.errs[attempt-1]
+				}
+				return nil
+			})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("createWithRetries() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if attempts != tc.wantAttempts || len(got) != tc.wantAttempts {
+				t.Errorf("createWithRetries() made %d attempts %v, want %d", attempts, got, tc.wantAttempts)
+			}
+		})
+	}
+
+	// No attempt is made after the deadline of the event
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts, err := createWithRetries(ctx, wait.Backoff{Steps: 3, Duration: time.Hour}, func(int) error { return unavailable })
+	if err == nil || attempts != 1 {
+		t.Errorf("createWithRetries() after the deadline = %d, %v, want 1 attempt with error", attempts, err)
+	}
+}
+
+func TestHandleEvent_RetriedCreation(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"ref"},"spec":{"type":"git"}}`)}),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1", bldr.EventListenerTriggerName("my-trigger")),
+		bldr.EventListenerDeduplication(triggersv1.Deduplication{Header: "X-GitHub-Delivery", Group: "push", Backend: triggersv1.DeduplicationConfigMap}),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	// The first attempt fails for a transient reason
+	failed := false
+	dynamicClient.PrependReactor("create", "pipelineresources", func(ktesting.Action) (bool, runtime.Object, error) {
+		if failed {
+			return false, nil, nil
+		}
+		failed = true
+		return true, nil, kerrors.NewServiceUnavailable("etcd is unavailable")
+	})
+	counter := retriedCreations.WithLabelValues("my-trigger", "created")
+	before := counterValue(t, counter)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("X-GitHub-Delivery", "d1")
+	sink.HandleEvent(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Response code = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+	if len(prs) != 2 {
+		t.Fatalf("got %d attempts to create resources, want 2", len(prs))
+	}
+	if diff := cmp.Diff(map[string]string{"triggers.tekton.dev/delivery-id": "d1"}, prs[0].Annotations); diff != "" {
+		t.Errorf("first attempt has unexpected annotations -want,+got: %s", diff)
+	}
+	eventID := prs[1].Labels[triggersv1.GroupName+triggersv1.EventIDLabelKey]
+	want := map[string]string{
+		"triggers.tekton.dev/creation-attempts": "2",
+		"triggers.tekton.dev/original-event-id": eventID,
+		"triggers.tekton.dev/delivery-id":       "d1",
+	}
+	if diff := cmp.Diff(want, prs[1].Annotations); diff != "" {
+		t.Errorf("retried resource has unexpected annotations -want,+got: %s", diff)
+	}
+	if got := counterValue(t, counter) - before; got != 1 {
+		t.Errorf("eventlistener_retried_creations_total increased by %v, want 1", got)
+	}
+}
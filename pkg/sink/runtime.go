@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TuneRuntime applies the GOMAXPROCS and garbage collection settings of the
+// Args to the Go runtime.
+func TuneRuntime(args Args, logger *zap.SugaredLogger) {
+	if args.GoMaxProcs > 0 {
+		runtime.GOMAXPROCS(args.GoMaxProcs)
+		logger.Infof("Set GOMAXPROCS to %d", args.GoMaxProcs)
+	}
+	if args.GCPercent != nil {
+		debug.SetGCPercent(*args.GCPercent)
+		logger.Infof("Set the garbage collection target percentage to %d", *args.GCPercent)
+	}
+}
+
+// SnapshotOnSIGQUIT writes a snapshot of the goroutines and the heap of the
+// sink to the snapshot directory every time the sink receives SIGQUIT, until
+// stopCh is closed. The sink keeps running, unlike with the default SIGQUIT
+// handling of Go, which dumps the goroutines and exits.
+func SnapshotOnSIGQUIT(args Args, logger *zap.SugaredLogger, stopCh <-chan struct{}) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGQUIT)
+	defer signal.Stop(signals)
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-signals:
+			paths, err := writeSnapshot(args.SnapshotDir, time.Now())
+			if err != nil {
+				logger.Errorf("Error writing snapshot: %s", err)
+				continue
+			}
+			logger.Infof("Wrote snapshot to %s", strings.Join(paths, ", "))
+		}
+	}
+}
+
+// writeSnapshot writes a dump of the stacks of all goroutines, and a heap
+// profile that can be read with go tool pprof, to dir. The file names contain
+// the time of the snapshot, so that snapshots do not overwrite each other.
+func writeSnapshot(dir string, now time.Time) ([]string, error) {
+	stamp := now.UTC().Format("20060102T150405Z")
+	var paths []string
+	for _, p := range []struct {
+		profile string
+		debug   int
+		ext     string
+	}{
+		{profile: "goroutine", debug: 2, ext: "txt"},
+		{profile: "heap", debug: 0, ext: "pb.gz"},
+	} {
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.%s", p.profile, stamp, p.ext))
+		f, err := os.Create(path)
+		if err != nil {
+			return paths, err
+		}
+		err = pprof.Lookup(p.profile).WriteTo(f, p.debug)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/logging"
+)
+
+func TestTuneRuntime(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	defer debug.SetGCPercent(debug.SetGCPercent(100))
+
+	gcPercent := 50
+	TuneRuntime(Args{GoMaxProcs: 3, GCPercent: &gcPercent}, logger)
+	if got := runtime.GOMAXPROCS(0); got != 3 {
+		t.Errorf("GOMAXPROCS = %d, want 3", got)
+	}
+	if got := debug.SetGCPercent(100); got != 50 {
+		t.Errorf("GC percent = %d, want 50", got)
+	}
+
+	// Unset values keep the runtime defaults
+	TuneRuntime(Args{}, logger)
+	if got := runtime.GOMAXPROCS(0); got != 3 {
+		t.Errorf("GOMAXPROCS = %d, want 3", got)
+	}
+	if got := debug.SetGCPercent(100); got != 100 {
+		t.Errorf("GC percent = %d, want 100", got)
+	}
+}
+
+func TestWriteSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Date(2020, 6, 1, 12, 3, 0, 0, time.UTC)
+	paths, err := writeSnapshot(dir, now)
+	if err != nil {
+		t.Fatalf("writeSnapshot() returned error: %s", err)
+	}
+	want := []string{
+		filepath.Join(dir, "goroutine-20200601T120300Z.txt"),
+		filepath.Join(dir, "heap-20200601T120300Z.pb.gz"),
+	}
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Errorf("did not write expected files -want,+got: %s", diff)
+	}
+
+	goroutines, err := ioutil.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goroutines), "TestWriteSnapshot") {
+		t.Errorf("goroutine dump does not contain the stack of the test:\n%s", goroutines)
+	}
+	if info, err := os.Stat(paths[1]); err != nil || info.Size() == 0 {
+		t.Errorf("heap profile is missing or empty: %v", err)
+	}
+
+	if _, err := writeSnapshot(filepath.Join(dir, "missing"), now); err == nil {
+		t.Error("writeSnapshot() to a missing directory did not return an error")
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// NewServer returns the HTTP server for the Sink. HTTP/2 is always available
+// over TLS and, when enabled, over cleartext connections (h2c) for clients
+// with prior knowledge.
+func NewServer(args Args, handler http.Handler) (*http.Server, error) {
+	h2s := &http2.Server{
+		MaxConcurrentStreams: args.MaxConcurrentStreams,
+		IdleTimeout:          args.IdleTimeout,
+	}
+	if args.H2C {
+		handler = &h2cHandler{Handler: handler, s: h2s}
+	}
+	srv := &http.Server{
+		Addr:         fmt.Sprintf(":%s", args.Port),
+		Handler:      handler,
+		ReadTimeout:  args.ReadTimeout,
+		WriteTimeout: args.WriteTimeout,
+		IdleTimeout:  args.IdleTimeout,
+		TLSConfig:    args.TLS.Clone(),
+	}
+	// Client certificates are optional, they only identify trusted senders
+	if args.TLSClientCAFile != "" {
+		pool, err := clientCAPool(args.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the client CA: %w", err)
+		}
+		if srv.TLSConfig == nil {
+			srv.TLSConfig = &tls.Config{}
+		}
+		srv.TLSConfig.ClientCAs = pool
+		srv.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// ListenAndServe serves the Sink over TLS when a certificate is configured
+// and over plain HTTP otherwise.
+func ListenAndServe(srv *http.Server, args Args) error {
+	if args.TLSCertFile != "" {
+		return listenAndServeTLS(srv, args.TLSCertFile, args.TLSKeyFile)
+	}
+	return srv.ListenAndServe()
+}
+
+// listenAndServeTLS serves over TLS with the certificate in the files, which
+// is reloaded when they change.
+func listenAndServeTLS(srv *http.Server, certFile, keyFile string) error {
+	certs, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	if srv.TLSConfig == nil {
+		srv.TLSConfig = &tls.Config{}
+	}
+	srv.TLSConfig.GetCertificate = certs.GetCertificate
+	return srv.ListenAndServeTLS("", "")
+}
+
+// certReloader serves the certificate in a certificate and a key file and
+// reloads it when the files change, e.g. when the kubelet updates a mounted
+// Secret, so that certificates are rotated without restarting the Sink.
+type certReloader struct {
+	certFile, keyFile string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate for tls.Config. A
+// certificate that fails to load, e.g. while only one of the files is
+// updated, is retried on the next handshake and the previous one is served
+// until then.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if modTime, err := r.filesModTime(); err == nil && !modTime.Equal(r.modTime) {
+		_ = r.load(modTime)
+	}
+	return r.cert, nil
+}
+
+func (r *certReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+// filesModTime returns the latest modification time of the files.
+func (r *certReloader) filesModTime() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// h2cHandler serves HTTP/2 over cleartext connections that start with the
+// HTTP/2 connection preface and passes all other requests to Handler.
+type h2cHandler struct {
+	http.Handler
+	s *http2.Server
+}
+
+func (h *h2cHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The HTTP/1 server parses the first line of the preface as a request.
+	if r.Method == "PRI" && r.RequestURI == "*" && r.ProtoMajor == 2 {
+		conn, err := hijackPriorKnowledge(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer conn.Close()
+		h.s.ServeConn(conn, &http2.ServeConnOpts{Handler: h.Handler})
+		return
+	}
+	h.Handler.ServeHTTP(w, r)
+}
+
+// hijackPriorKnowledge takes over the connection after the first line of the
+// HTTP/2 preface and replays the full preface to the HTTP/2 server.
+func hijackPriorKnowledge(w http.ResponseWriter) (net.Conn, error) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, fmt.Errorf("connection does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	const rest = "SM\r\n\r\n"
+	buf := make([]byte, len(rest))
+	if _, err := io.ReadFull(rw, buf); err != nil || string(buf) != rest {
+		conn.Close()
+		return nil, fmt.Errorf("invalid HTTP/2 connection preface")
+	}
+	// The HTTP/1 server set the deadlines of its read and write timeouts,
+	// which would close the connection while it is in use. The HTTP/2 server
+	// closes it once it is idle instead.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &prefaceConn{
+		Conn:   conn,
+		reader: io.MultiReader(bytes.NewBufferString(http2.ClientPreface), rw),
+		writer: rw.Writer,
+	}, nil
+}
+
+// prefaceConn is a hijacked connection that reads from the buffered data of
+// the HTTP/1 server first.
+type prefaceConn struct {
+	net.Conn
+	reader io.Reader
+	writer *bufio.Writer
+}
+
+func (c *prefaceConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+func (c *prefaceConn) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, c.writer.Flush()
+}
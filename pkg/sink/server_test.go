@@ -0,0 +1,320 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+func TestNewServer(t *testing.T) {
+	args := Args{
+		Port:                 "8080",
+		ReadTimeout:          time.Second,
+		WriteTimeout:         2 * time.Second,
+		IdleTimeout:          3 * time.Second,
+		MaxConcurrentStreams: 10,
+		H2C:                  true,
+	}
+	srv, err := NewServer(args, http.NotFoundHandler())
+	if err != nil {
+		t.Fatalf("NewServer() returned unexpected error: %s", err)
+	}
+	if srv.Addr != ":8080" {
+		t.Errorf("Addr = %s, want :8080", srv.Addr)
+	}
+	if srv.ReadTimeout != args.ReadTimeout || srv.WriteTimeout != args.WriteTimeout || srv.IdleTimeout != args.IdleTimeout {
+		t.Errorf("timeouts = %s/%s/%s, want %s/%s/%s", srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout, args.ReadTimeout, args.WriteTimeout, args.IdleTimeout)
+	}
+	if _, ok := srv.TLSNextProto[http2.NextProtoTLS]; !ok {
+		t.Errorf("HTTP/2 over TLS is not configured")
+	}
+}
+
+func TestNewServer_Protocols(t *testing.T) {
+	h2cClient := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		h2c       bool
+		client    *http.Client
+		wantProto int
+		wantErr   bool
+	}{{
+		name:      "http/1.1",
+		h2c:       true,
+		client:    http.DefaultClient,
+		wantProto: 1,
+	}, {
+		name:      "h2c",
+		h2c:       true,
+		client:    h2cClient,
+		wantProto: 2,
+	}, {
+		name:    "h2c disabled",
+		h2c:     false,
+		client:  h2cClient,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, r.Proto)
+			})
+			srv, err := NewServer(Args{H2C: tt.h2c, MaxConcurrentStreams: 10}, handler)
+			if err != nil {
+				t.Fatalf("NewServer() returned unexpected error: %s", err)
+			}
+			ts := httptest.NewServer(srv.Handler)
+			defer ts.Close()
+
+			resp, err := tt.client.Get(ts.URL)
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatalf("Get() returned unexpected error: %s", err)
+				}
+				return
+			}
+			defer resp.Body.Close()
+			if tt.wantErr {
+				t.Fatalf("Get() expected error, got response %s", resp.Status)
+			}
+			if resp.ProtoMajor != tt.wantProto {
+				t.Errorf("ProtoMajor = %d, want %d", resp.ProtoMajor, tt.wantProto)
+			}
+		})
+	}
+}
+
+func TestNewServer_H2CTimeouts(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Proto)
+	})
+	args := Args{
+		H2C:                  true,
+		MaxConcurrentStreams: 10,
+		ReadTimeout:          100 * time.Millisecond,
+		WriteTimeout:         100 * time.Millisecond,
+		IdleTimeout:          10 * time.Second,
+	}
+	srv, err := NewServer(args, handler)
+	if err != nil {
+		t.Fatalf("NewServer() returned unexpected error: %s", err)
+	}
+	ts := httptest.NewUnstartedServer(srv.Handler)
+	ts.Config.ReadTimeout, ts.Config.WriteTimeout, ts.Config.IdleTimeout = srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout
+	var mu sync.Mutex
+	conns := 0
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+	client := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+
+	// The connection outlives the read and write timeouts of HTTP/1
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			time.Sleep(300 * time.Millisecond)
+		}
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("Get() returned unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if conns != 1 {
+		t.Errorf("Got %d connections, want the h2c connection to be reused", conns)
+	}
+}
+
+// deadlineConn records the deadlines set on a connection.
+type deadlineConn struct {
+	net.Conn
+	deadlines []time.Time
+}
+
+func (c *deadlineConn) SetDeadline(t time.Time) error {
+	c.deadlines = append(c.deadlines, t)
+	return nil
+}
+
+// hijackRecorder is a ResponseWriter whose connection has the rest of the
+// HTTP/2 preface buffered.
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.conn, bufio.NewReadWriter(bufio.NewReader(strings.NewReader("SM\r\n\r\n")), bufio.NewWriter(r.conn)), nil
+}
+
+func Test_hijackPriorKnowledge(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := &deadlineConn{Conn: server}
+	hijacked, err := hijackPriorKnowledge(&hijackRecorder{ResponseRecorder: httptest.NewRecorder(), conn: conn})
+	if err != nil {
+		t.Fatalf("hijackPriorKnowledge() returned unexpected error: %s", err)
+	}
+	defer hijacked.Close()
+	// The deadlines of the HTTP/1 server's timeouts are cleared
+	if len(conn.deadlines) != 1 || !conn.deadlines[0].IsZero() {
+		t.Errorf("Deadlines set on the hijacked connection = %v, want the deadline cleared", conn.deadlines)
+	}
+	preface := make([]byte, len(http2.ClientPreface))
+	if _, err := io.ReadFull(hijacked, preface); err != nil || string(preface) != http2.ClientPreface {
+		t.Errorf("Read %q, %v from the hijacked connection, want the full preface", preface, err)
+	}
+}
+
+func TestNewServer_TLSPolicy(t *testing.T) {
+	args := Args{MaxConcurrentStreams: 10, TLS: &tls.Config{MinVersion: tls.VersionTLS13}}
+	srv, err := NewServer(args, http.NotFoundHandler())
+	if err != nil {
+		t.Fatalf("NewServer() returned unexpected error: %s", err)
+	}
+	ts := httptest.NewUnstartedServer(srv.Handler)
+	ts.TLS = srv.TLSConfig
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := ts.Client()
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Get() with TLS 1.3 returned unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	client.CloseIdleConnections()
+	client.Transport.(*http.Transport).TLSClientConfig.MaxVersion = tls.VersionTLS12
+	if resp, err := client.Get(ts.URL); err == nil {
+		resp.Body.Close()
+		t.Error("Get() with TLS 1.2 succeeded, want the minimum version to be enforced")
+	}
+
+	// The policy is shared with the admin port and interceptor clients, so
+	// the server configures HTTP/2 on a copy
+	if args.TLS.NextProtos != nil {
+		t.Errorf("NewServer() modified the TLS policy of the Args: %v", args.TLS.NextProtos)
+	}
+}
+
+func Test_certReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	// write writes the files with the given modification time, since the
+	// file system may not tell apart writes in quick succession
+	write := func(certPEM, keyPEM []byte, modTime time.Time) {
+		t.Helper()
+		for f, data := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+			if err := ioutil.WriteFile(f, data, 0600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(f, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	keyPair := func() (tls.Certificate, []byte, []byte) {
+		t.Helper()
+		cert, certPEM := selfSignedCert(t)
+		der, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert, certPEM, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	}
+	served := func(r *certReloader) []byte {
+		t.Helper()
+		cert, err := r.GetCertificate(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert.Certificate[0]
+	}
+
+	if _, err := newCertReloader(certFile, keyFile); err == nil {
+		t.Error("newCertReloader() did not fail for missing files")
+	}
+
+	now := time.Now()
+	first, certPEM, keyPEM := keyPair()
+	write(certPEM, keyPEM, now)
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader() = %v", err)
+	}
+	if !bytes.Equal(served(r), first.Certificate[0]) {
+		t.Error("GetCertificate() did not return the certificate in the files")
+	}
+
+	// A rotated certificate is served from the next handshake
+	second, certPEM, keyPEM := keyPair()
+	write(certPEM, keyPEM, now.Add(time.Minute))
+	if !bytes.Equal(served(r), second.Certificate[0]) {
+		t.Error("GetCertificate() did not reload the rotated certificate")
+	}
+
+	// A key that does not match keeps the previous certificate
+	_, _, otherKeyPEM := keyPair()
+	write(certPEM, otherKeyPEM, now.Add(2*time.Minute))
+	if !bytes.Equal(served(r), second.Certificate[0]) {
+		t.Error("GetCertificate() did not keep the previous certificate for a mismatched key")
+	}
+}
@@ -18,31 +18,51 @@ package sink
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	pipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	resourceclientset "github.com/tektoncd/pipeline/pkg/client/resource/clientset/versioned"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
 	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/alert"
+	"github.com/tektoncd/triggers/pkg/interceptors/bitbucket"
+	"github.com/tektoncd/triggers/pkg/interceptors/captcha"
 	"github.com/tektoncd/triggers/pkg/interceptors/cel"
 	"github.com/tektoncd/triggers/pkg/interceptors/github"
 	"github.com/tektoncd/triggers/pkg/interceptors/gitlab"
+	"github.com/tektoncd/triggers/pkg/interceptors/opa"
+	"github.com/tektoncd/triggers/pkg/interceptors/scanner"
+	"github.com/tektoncd/triggers/pkg/interceptors/sentry"
+	"github.com/tektoncd/triggers/pkg/interceptors/supplychain"
 	"github.com/tektoncd/triggers/pkg/interceptors/webhook"
+	"github.com/tektoncd/triggers/pkg/logging"
 	"github.com/tektoncd/triggers/pkg/resources"
 	"github.com/tektoncd/triggers/pkg/template"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	discoveryclient "k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
+// defaultRetryAfterSeconds is the Retry-After delay sent while an EventListener
+// is in maintenance mode, unless the EventListener specifies one.
+const defaultRetryAfterSeconds = 60
+
 // Sink defines the sink resource for processing incoming events for the
 // EventListener.
 type Sink struct {
@@ -57,6 +77,39 @@ type Sink struct {
 	EventListenerNamespace string
 	Logger                 *zap.SugaredLogger
 	Auth                   AuthOverride
+	// Namespaces tracks the namespaces selected by the namespaceSelector of
+	// the EventListener. If nil, Triggers are only served in the namespace
+	// of the EventListener.
+	Namespaces *NamespaceTracker
+	// Status records the time of the last event and the Trigger counters in
+	// the status of the EventListener. If nil, they are not recorded.
+	Status *StatusWriter
+	// Faults injects failures for testing. If nil, none are injected.
+	Faults *FaultInjector
+	// Deliveries coalesces concurrent deliveries of the same event. If nil,
+	// each delivery is processed.
+	Deliveries *DeliveryCoalescer
+	// MaxTriggersPerEvent limits how many Triggers may create resources for
+	// a single event. If zero, there is no limit.
+	MaxTriggersPerEvent int
+	// InterceptorLimits limits the requests in flight to ClusterInterceptors
+	// with a concurrency. If nil, requests are not limited.
+	InterceptorLimits *InterceptorLimiter
+	// InterceptorRetry is how requests to webhook interceptors and
+	// ClusterInterceptors are retried, unless a webhook interceptor sets its
+	// own retries. If zero, requests are not retried.
+	InterceptorRetry webhook.RetryPolicy
+	// AuthFailures counts the events whose authentication failed by source,
+	// and blocks sources as configured by the EventListener. If nil, they
+	// are not counted.
+	AuthFailures *AuthFailureTracker
+	// Pins keeps the bindings and TriggerTemplates that Triggers pin to a
+	// generation or version. If nil, pinned references to objects that
+	// were updated fail.
+	Pins *template.Pins
+	// RemoteClusters keeps the clients for the remote clusters of Triggers.
+	// If nil, Triggers that target a remote cluster fail.
+	RemoteClusters *RemoteClusterCache
 }
 
 // Response defines the HTTP body that the Sink responds to events with.
@@ -67,171 +120,871 @@ type Response struct {
 	Namespace string `json:"namespace,omitempty"`
 	// EventID is a uniqueID that gets assigned to each incoming request
 	EventID string `json:"eventID,omitempty"`
+	// DeliveryID is the ID of the delivery of the event by its provider
+	DeliveryID string `json:"deliveryID,omitempty"`
+	// CorrelationID is the ID sent in the Tekton-Correlation-Id header
+	CorrelationID string `json:"correlationID,omitempty"`
+	// Errors lists the Triggers that failed for a known reason
+	Errors []TriggerError `json:"errors,omitempty"`
+	// Message is set for events that are acknowledged without evaluating
+	// the Triggers, like GitHub ping events
+	Message string `json:"message,omitempty"`
+}
+
+// TriggerError describes why processing a Trigger failed. Reason is one of
+// the reasons defined in the v1alpha1 API, e.g. SecretMissing.
+type TriggerError struct {
+	Trigger string `json:"trigger"`
+	// Namespace is set when the Trigger failed in a namespace selected by
+	// the namespaceSelector of the EventListener.
+	Namespace string `json:"namespace,omitempty"`
+	Reason    string `json:"reason"`
+	// Interceptor names the interceptor that failed, e.g. cel or
+	// webhook/my-service, when the failure came from an interceptor.
+	Interceptor string `json:"interceptor,omitempty"`
+	// Message is the message of an interceptor that rejected the event.
+	Message string `json:"message,omitempty"`
+	// Phase is set when the Trigger failed because a phase of processing
+	// the event, e.g. create, exceeded its timeout.
+	Phase string `json:"phase,omitempty"`
+}
+
+// maxRejectionMessageLength limits the size of the rejection messages of
+// interceptors that are returned to senders.
+const maxRejectionMessageLength = 256
+
+// errTriggerSkipped is returned for a Trigger whose header matches do not
+// select the event, or that is not served in a selected namespace because its
+// TriggerTemplate does not exist there.
+var errTriggerSkipped = errors.New("trigger is skipped for this event")
+
+// reasonError wraps an error that occurred while processing a Trigger with
+// the reason it is reported under.
+type reasonError struct {
+	reason string
+	err    error
+	// interceptor is set when the error came from an interceptor
+	interceptor string
+	// phase is set when a phase exceeded its timeout
+	phase string
+}
+
+func (e *reasonError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.err
+}
+
+// withReason wraps err with the given reason. Authorization errors from the
+// Kubernetes API are always reported as RBACDenied.
+func withReason(reason string, err error) error {
+	if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
+		reason = triggersv1.ReasonRBACDenied
+	}
+	return &reasonError{reason: reason, err: err}
+}
+
+// triggerResult is the outcome of processing a single Trigger.
+type triggerResult struct {
+	key triggerKey
+	triggerOutput
+	code int
+	err  *TriggerError
+}
+
+// triggerOutput is what processing a Trigger produced, also when it failed.
+type triggerOutput struct {
+	// accepted is the event as the interceptors of the Trigger passed it
+	// on, or nil if they did not accept it
+	accepted []byte
+	created  []CreatedResource
+}
+
+// triggerKey identifies a Trigger processed in a namespace.
+type triggerKey struct {
+	trigger   string
+	namespace string
 }
 
 // HandleEvent processes an incoming HTTP event for the event listener.
 func (r Sink) HandleEvent(response http.ResponseWriter, request *http.Request) {
 	el, err := r.TriggersClient.TriggersV1alpha1().EventListeners(r.EventListenerNamespace).Get(r.EventListenerName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		http.NotFound(response, request)
+		return
+	}
 	if err != nil {
-		r.Logger.Fatalf("Error getting EventListener %s in Namespace %s: %s", r.EventListenerName, r.EventListenerNamespace, err)
+		r.Logger.Errorf("Error getting EventListener %s in Namespace %s: %s", r.EventListenerName, r.EventListenerNamespace, err)
 		response.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	r.serveEvent(response, request, el)
+}
+
+// serveEvent handles an event for the EventListener.
+func (r Sink) serveEvent(response http.ResponseWriter, request *http.Request, el *triggersv1.EventListener) {
+	if el.Spec.Maintenance != nil {
+		r.rejectForMaintenance(response, el.Spec.Maintenance)
+		return
+	}
+	if el.Spec.AuthFailureBlock != nil {
+		if retryAfter := r.AuthFailures.blocked(eventSource(request.RemoteAddr), time.Now()); retryAfter > 0 {
+			r.rejectBlockedSource(response, retryAfter)
+			return
+		}
+	}
+	// Deliveries are coalesced before the body is read, so that senders
+	// that wait for 100 Continue do not send the body of a delivery that is
+	// already being processed
+	if id := coalescingKey(el, request.Header); r.Deliveries != nil && id != "" {
+		r.coalesceEvent(response, request, el, id)
+		return
+	}
+	r.handleEvent(response, request, el)
+}
+
+// coalesceEvent processes the first of the concurrent deliveries with the id,
+// and responds to the others with its response.
+func (r Sink) coalesceEvent(response http.ResponseWriter, request *http.Request, el *triggersv1.EventListener, id string) {
+	d, first := r.Deliveries.join(id)
+	if first {
+		recorded := newRecordedResponse()
+		func() {
+			defer r.Deliveries.finish(id, d, recorded)
+			r.handleEvent(recorded, request, el)
+		}()
+	} else {
+		select {
+		case <-d.done:
+		case <-request.Context().Done():
+			return
+		}
+		r.Logger.Infof("Responding to delivery %s with the response of the delivery in flight", id)
+		sourceEvents.WithLabelValues("http", "coalesced").Inc()
+	}
+	if err := d.response.replay(response); err != nil {
+		r.Logger.Errorf("failed to write back sink response: %v", err)
+	}
+}
+
+// handleEvent processes an event whose body has not been read yet.
+func (r Sink) handleEvent(response http.ResponseWriter, request *http.Request, el *triggersv1.EventListener) {
+	defer trackEvent("http")()
 	event, err := ioutil.ReadAll(request.Body)
 	if err != nil {
 		r.Logger.Errorf("Error reading event body: %s", err)
 		response.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	// XML and YAML events are processed as JSON, which is what the bindings
+	// and interceptors read
+	var toJSON func([]byte) ([]byte, error)
+	switch contentType := request.Header.Get("Content-Type"); {
+	case isXMLContentType(contentType):
+		toJSON = xmlToJSON
+	case isYAMLContentType(contentType):
+		toJSON = yamlToJSON
+	}
+	if toJSON != nil {
+		if event, err = toJSON(event); err != nil {
+			r.Logger.Error(err)
+			sourceEvents.WithLabelValues("http", "error").Inc()
+			http.Error(response, err.Error(), http.StatusBadRequest)
+			return
+		}
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	if r.Status != nil {
+		r.Status.RecordEvent(time.Now())
+	}
 
 	eventID := template.UID()
-	eventLog := r.Logger.With(zap.String(triggersv1.EventIDLabelKey, eventID))
+	deliveryID, correlationID := eventDeliveryID(el, request.Header), request.Header.Get(CorrelationIDHeader)
+	eventLog := withCorrelation(r.Logger.With(zap.String(triggersv1.EventIDLabelKey, eventID)), deliveryID, correlationID)
+	if correlationID != "" {
+		response.Header().Set(CorrelationIDHeader, correlationID)
+	}
 	eventLog.Debugf("EventListener: %s in Namespace: %s handling event (EventID: %s) with payload: %s and header: %v",
-		r.EventListenerName, r.EventListenerNamespace, eventID, string(event), request.Header)
-
-	result := make(chan int, 10)
-	// Execute each Trigger
-	for _, t := range el.Spec.Triggers {
-		go func(t triggersv1.EventListenerTrigger) {
-			localRequest := request.Clone(request.Context())
-			if err := r.processTrigger(&t, localRequest, event, eventID, eventLog); err != nil {
-				if kerrors.IsUnauthorized(err) {
-					result <- http.StatusUnauthorized
-					return
+		r.EventListenerName, r.EventListenerNamespace, eventID, string(event), logging.RedactHeaders(request.Header))
+
+	if isGitHubPing(el, request) {
+		switch err := r.authenticatePing(el, request, event); {
+		case err == nil:
+			eventLog.Info("Acknowledging GitHub ping event")
+			r.acknowledgePing(response, event, eventID)
+		case errors.Is(err, interceptors.ErrAuthFailed):
+			eventLog.Warnf("Rejecting GitHub ping event: %v", err)
+			r.recordAuthFailure(el, request, "github", eventLog)
+			sourceEvents.WithLabelValues("http", "failed").Inc()
+			http.Error(response, "invalid or missing signature", http.StatusUnauthorized)
+		default:
+			eventLog.Errorf("Failed to validate the signature of the GitHub ping event: %v", err)
+			sourceEvents.WithLabelValues("http", "error").Inc()
+			response.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	code, triggerErrors, err := r.processEvent(el, request, event, eventID, eventLog)
+	recordSourceEvent("http", code, triggerErrors, err)
+	if errors.Is(err, errDuplicateDelivery) {
+		eventLog.Infof("Acknowledging duplicate delivery %s", request.Header.Get(el.Spec.Deduplication.Header))
+		r.acknowledgeDuplicate(response, eventID)
+		return
+	}
+	if err != nil {
+		eventLog.Error(err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response.WriteHeader(code)
+	response.Header().Set("Content-Type", "application/json")
+	body := Response{
+		EventListener: r.EventListenerName,
+		Namespace:     r.EventListenerNamespace,
+		EventID:       eventID,
+		DeliveryID:    deliveryID,
+		CorrelationID: correlationID,
+		Errors:        triggerErrors,
+	}
+	if err := json.NewEncoder(response).Encode(body); err != nil {
+		eventLog.Errorf("failed to write back sink response: %w", err)
+	}
+}
+
+// processEvent executes the Triggers of the EventListener for an event and
+// returns the status code to respond with and the Triggers that failed for a
+// known reason. It returns errDuplicateDelivery for a delivery that another
+// EventListener of the deduplication group claimed.
+func (r Sink) processEvent(el *triggersv1.EventListener, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger) (int, []TriggerError, error) {
+	release, err := r.claimDelivery(el, request.Header, eventLog)
+	if err != nil {
+		return 0, nil, err
+	}
+	code, triggerErrors, err := r.executeTriggers(el, request, event, eventID, eventLog)
+	// A delivery that failed without creating resources is released, so
+	// that its redelivery is processed again
+	processed := code == http.StatusCreated || (code == http.StatusAccepted && len(triggerErrors) == 0)
+	if release != nil && (err != nil || !processed) {
+		release()
+	}
+	return code, triggerErrors, err
+}
+
+// executeTriggers executes the Triggers of the EventListener for an event in
+// each served namespace.
+func (r Sink) executeTriggers(el *triggersv1.EventListener, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger) (int, []TriggerError, error) {
+	// r is a copy, so the Triggers of this event resolve their pins with
+	// the Pins of this EventListener
+	r.Pins = r.Pins.For(el)
+	if err := r.Pins.Prune(el); err != nil {
+		eventLog.Warnf("Failed to delete the superseded pinned copies: %v", err)
+	}
+	namespaces := []string{r.EventListenerNamespace}
+	if r.Namespaces != nil {
+		var err error
+		if namespaces, err = r.Namespaces.Namespaces(el); err != nil {
+			return 0, nil, fmt.Errorf("error getting the namespaces to serve Triggers in: %w", err)
+		}
+	}
+
+	// The event is processed even if the sender disconnects, but not for
+	// longer than the event timeout, so that a hung interceptor or API call
+	// does not hold on to its Triggers forever.
+	timeout := eventTimeout(el)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	// The interceptors of trusted senders only filter their events
+	if trusted, err := r.trustedSender(el, request); err != nil {
+		eventLog.Warnf("Failed to check if the sender of the event is trusted: %v", err)
+	} else if trusted != "" {
+		eventLog.Debugf("Skipping the signature validation of interceptors for the sender trusted by its %s", trusted)
+		ctx = interceptors.WithTrustedSender(ctx)
+	}
+
+	deliveryID, correlationID := eventDeliveryID(el, request.Header), request.Header.Get(CorrelationIDHeader)
+	limit := newTriggerLimit(r.MaxTriggersPerEvent)
+	// An event counts once as failing authentication, however many of its
+	// Triggers it failed
+	var authFailed int32
+	triggers := len(el.Spec.Triggers) * len(namespaces)
+	result := make(chan triggerResult, triggers)
+	pending := make(map[triggerKey]bool, triggers)
+	// Execute each Trigger in each served namespace
+	for _, ns := range namespaces {
+		for _, t := range el.Spec.Triggers {
+			pending[triggerKey{trigger: t.Name, namespace: ns}] = true
+			go func(t triggersv1.EventListenerTrigger, ns string) {
+				key := triggerKey{trigger: t.Name, namespace: ns}
+				localRequest := request.Clone(ctx)
+				out, err := r.processTrigger(&t, ns, mergePhaseTimeouts(el.Spec.PhaseTimeouts, t.PhaseTimeouts), localRequest, event, eventID, deliveryID, correlationID, limit, eventLog)
+				var rerr *reasonError
+				if r.Status != nil && !errors.Is(err, errTriggerSkipped) {
+					// Rejected events are filtered, not failed
+					failed := errors.As(err, &rerr) && rerr.reason != triggersv1.ReasonInterceptorRejected
+					r.Status.RecordTrigger(t.Name, time.Now(), err == nil, failed)
 				}
-				if kerrors.IsForbidden(err) {
-					result <- http.StatusForbidden
+				if err != nil {
+					res := triggerResult{key: key, triggerOutput: out, code: http.StatusAccepted}
+					if errors.Is(err, errTriggerSkipped) {
+						result <- res
+						return
+					}
+					if errors.Is(err, interceptors.ErrAuthFailed) && errors.As(err, &rerr) && atomic.CompareAndSwapInt32(&authFailed, 0, 1) {
+						r.recordAuthFailure(el, request, rerr.interceptor, eventLog)
+					}
+					cause := err
+					if errors.As(err, &rerr) {
+						res.err = &TriggerError{Trigger: t.Name, Reason: rerr.reason, Interceptor: rerr.interceptor, Phase: rerr.phase}
+						if rerr.reason == triggersv1.ReasonInterceptorRejected {
+							res.err.Message = truncateMessage(rerr.err.Error(), maxRejectionMessageLength)
+						}
+						if ns != r.EventListenerNamespace {
+							res.err.Namespace = ns
+						}
+						cause = rerr.err
+					}
+					if kerrors.IsUnauthorized(cause) {
+						res.code = http.StatusUnauthorized
+					} else if kerrors.IsForbidden(cause) {
+						res.code = http.StatusForbidden
+					}
+					result <- res
+					return
 				}
-				result <- http.StatusAccepted
-				return
-			}
-			result <- http.StatusCreated
-		}(t)
+				result <- triggerResult{key: key, triggerOutput: out, code: http.StatusCreated}
+			}(t, ns)
+		}
 	}
 
 	//The eventlistener waits until all the trigger executions (up-to the creation of the resources) and
 	//only when at least one of the execution completed successfully, it returns response code 201(Created) otherwise it returns 202 (Accepted).
 	code := http.StatusAccepted
-	for i := 0; i < len(el.Spec.Triggers); i++ {
-		thiscode := <-result
+	var triggerErrors []TriggerError
+	var matched []string
+	var created []CreatedResource
+	accepted := map[triggerKey][]byte{}
+wait:
+	for i := 0; i < triggers; i++ {
+		var res triggerResult
+		select {
+		case res = <-result:
+		case <-ctx.Done():
+			eventLog.Warnf("Processing the event exceeded its timeout of %s", timeout)
+			triggerErrors = append(triggerErrors, r.deadlineErrors(el, namespaces, pending)...)
+			break wait
+		}
+		delete(pending, res.key)
+		if res.err != nil {
+			triggerErrors = append(triggerErrors, *res.err)
+		}
+		if res.code == http.StatusCreated {
+			matched = append(matched, res.key.trigger)
+		}
+		created = append(created, res.created...)
+		if res.accepted != nil {
+			accepted[res.key] = res.accepted
+		}
+		thiscode := res.code
 		// current take - if someone is doing unauthorized stuff, we abort immediately;
 		// unauthorized should be the final status code vs. the less than comparison
 		// below around accepted vs. created
 		if thiscode == http.StatusUnauthorized || thiscode == http.StatusForbidden {
 			code = thiscode
-			break
+			break wait
 		}
 		if thiscode < code {
 			code = thiscode
 		}
 	}
+	// Only events that the interceptors of a Trigger accepted get a
+	// callback, so that unauthenticated senders cannot make the sink send
+	// requests. Its URL is read from the event as the first of those
+	// Triggers passed it on.
+	if body := firstAccepted(el, namespaces, accepted); el.Spec.Callback != nil && body != nil {
+		callback := Callback{
+			EventListener: r.EventListenerName,
+			Namespace:     r.EventListenerNamespace,
+			EventID:       eventID,
+			DeliveryID:    deliveryID,
+			CorrelationID: correlationID,
+			Matched:       uniqueSorted(matched),
+			Resources:     created,
+			Errors:        triggerErrors,
+		}
+		go r.postCallback(el.Spec.Callback, body, callback, eventLog)
+	}
+	return code, triggerErrors, nil
+}
 
-	response.WriteHeader(code)
+// eventTimeout returns how long the sink processes an event for the
+// EventListener.
+func eventTimeout(el *triggersv1.EventListener) time.Duration {
+	return el.Spec.EventTimeout()
+}
+
+// deadlineErrors returns the errors of the Triggers that were still being
+// processed when the event timeout passed, in the order they were started.
+func (r Sink) deadlineErrors(el *triggersv1.EventListener, namespaces []string, pending map[triggerKey]bool) []TriggerError {
+	var errs []TriggerError
+	for _, ns := range namespaces {
+		for _, t := range el.Spec.Triggers {
+			if !pending[triggerKey{trigger: t.Name, namespace: ns}] {
+				continue
+			}
+			err := TriggerError{Trigger: t.Name, Reason: triggersv1.ReasonDeadlineExceeded}
+			if ns != r.EventListenerNamespace {
+				err.Namespace = ns
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// checkDeadline returns a DeadlineExceeded error once the event timeout has
+// passed, so that a Trigger stops before its next stage.
+func checkDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return withReason(triggersv1.ReasonDeadlineExceeded, err)
+	}
+	return nil
+}
+
+// rejectForMaintenance responds with 503 Service Unavailable and a Retry-After
+// header so that providers queue the event for redelivery.
+func (r Sink) rejectForMaintenance(response http.ResponseWriter, m *triggersv1.MaintenanceMode) {
+	retryAfter := m.RetryAfterSeconds
+	if retryAfter == 0 {
+		retryAfter = defaultRetryAfterSeconds
+	}
 	response.Header().Set("Content-Type", "application/json")
+	response.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+	response.WriteHeader(http.StatusServiceUnavailable)
 	body := Response{
 		EventListener: r.EventListenerName,
 		Namespace:     r.EventListenerNamespace,
-		EventID:       eventID,
 	}
 	if err := json.NewEncoder(response).Encode(body); err != nil {
-		eventLog.Errorf("failed to write back sink response: %w", err)
+		r.Logger.Errorf("failed to write back sink response: %v", err)
 	}
 }
 
-func (r Sink) processTrigger(t *triggersv1.EventListenerTrigger, request *http.Request, event []byte, eventID string, eventLog *zap.SugaredLogger) error {
+func (r Sink) processTrigger(t *triggersv1.EventListenerTrigger, ns string, timeouts triggersv1.PhaseTimeouts, request *http.Request, event []byte, eventID, deliveryID, correlationID string, limit *triggerLimit, eventLog *zap.SugaredLogger) (triggerOutput, error) {
+	var out triggerOutput
 	if t == nil {
-		return errors.New("EventListenerTrigger not defined")
+		return out, errors.New("EventListenerTrigger not defined")
 	}
+	ctx := request.Context()
 	log := eventLog.With(zap.String(triggersv1.TriggerLabelKey, t.Name))
+	// Header matches are checked first since they are the cheapest way to
+	// tell that the Trigger is not meant for the event
+	if err := matchHeaders(t.MatchHeaders, request.Header); err != nil {
+		log.Debugf("Trigger not selected: %s", err)
+		return out, errTriggerSkipped
+	}
+	if ns != r.EventListenerNamespace {
+		log = log.With(zap.String("namespace", ns))
+		// Triggers are only served in a selected namespace that provides
+		// their TriggerTemplate.
+		if _, err := r.TriggersClient.TriggersV1alpha1().TriggerTemplates(ns).Get(t.Template.Name, metav1.GetOptions{}); err != nil {
+			if kerrors.IsNotFound(err) {
+				return out, errTriggerSkipped
+			}
+			log.Error(err)
+			return out, withReason(triggersv1.ReasonTemplateInvalid, err)
+		}
+	}
 
-	finalPayload, header, err := r.executeInterceptors(t, request, event, log)
-	if err != nil {
-		log.Error(err)
-		return err
+	// Stale events are dropped before they are sent to any interceptor
+	if t.MaxEventAge != nil {
+		if err := checkEventAge(t.MaxEventAge, event, request.Header, time.Now()); err != nil {
+			log.Info(err)
+			return out, err
+		}
 	}
 
-	rt, err := template.ResolveTrigger(*t,
-		r.TriggersClient.TriggersV1alpha1().TriggerBindings(r.EventListenerNamespace).Get,
-		r.TriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Get,
-		r.TriggersClient.TriggersV1alpha1().TriggerTemplates(r.EventListenerNamespace).Get)
+	// The interceptors read Secrets with the credentials of the Trigger if it
+	// sets a ServiceAccount for them
+	interceptorSink := r
+	if t.InterceptorServiceAccount != nil {
+		kubeClient, err := r.interceptorKubeClient(t.InterceptorServiceAccount, eventLog)
+		if err != nil {
+			log.Error(err)
+			return out, err
+		}
+		interceptorSink.KubeClientSet = kubeClient
+	}
+	var finalPayload []byte
+	var header http.Header
+	err := runPhase(ctx, t.Name, phaseInterceptor, seconds(timeouts.InterceptorSeconds), func(ctx context.Context) error {
+		var err error
+		finalPayload, header, err = interceptorSink.executeInterceptors(t, ns, request.WithContext(ctx), event, log)
+		return err
+	})
 	if err != nil {
 		log.Error(err)
-		return err
+		return out, err
+	}
+	out.accepted = finalPayload
+	if out.accepted == nil || webhook.IsResourcesResponse(header) {
+		out.accepted = event
+	}
+	if err := limit.admit(t.Name); err != nil {
+		log.Warn(err)
+		return out, err
 	}
 
-	params, err := template.ResolveParams(rt, finalPayload, header)
+	var resources []json.RawMessage
+	var captured *corev1.ConfigMap
+	// The values of sensitive params are redacted from everything logged
+	// once they are resolved
+	var sensitive []string
+	err = runPhase(ctx, t.Name, phaseRender, seconds(timeouts.RenderSeconds), func(ctx context.Context) error {
+		var err error
+		uid := template.UID()
+		if webhook.IsResourcesResponse(header) {
+			// The interceptor rendered the resources itself, so the bindings and
+			// template are skipped
+			if resources, err = interceptorResources(t.InterceptorResources, finalPayload); err != nil {
+				log.Error(err)
+				return withReason(triggersv1.ReasonResourceRejected, err)
+			}
+			log.Infof("Creating %d resources rendered by an interceptor", len(resources))
+			// Captures are read from the event instead of the resources
+			finalPayload, header = event, request.Header
+		} else {
+			if err := checkDeadline(ctx); err != nil {
+				log.Error(err)
+				return err
+			}
+			rt, err := template.ResolvePinnedTrigger(*t,
+				r.TriggersClient.TriggersV1alpha1().TriggerBindings(ns).Get,
+				r.TriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Get,
+				r.TriggersClient.TriggersV1alpha1().TriggerTemplates(ns).Get,
+				r.Pins)
+			if err != nil {
+				log.Error(err)
+				return withReason(triggersv1.ReasonTemplateInvalid, err)
+			}
+
+			params, missing, err := template.ResolveParamsWithMissingFields(rt, finalPayload, header)
+			var mfErr *template.MissingFieldError
+			if errors.As(err, &mfErr) {
+				log.Error(err)
+				missingFields.WithLabelValues(t.Name, string(triggersv1.MissingFieldFail)).Inc()
+				return withReason(triggersv1.ReasonFieldMissing, err)
+			} else if err != nil {
+				log.Error(err)
+				return err
+			}
+			for _, m := range missing {
+				log.Warnf("Using the %s policy of the Trigger: %s", t.OnMissingField, m)
+				missingFields.WithLabelValues(t.Name, string(t.OnMissingField)).Inc()
+			}
+			specs := template.SensitiveParamSpecs(rt.TriggerTemplate)
+			sensitive = template.SensitiveValues(params, specs)
+			log.Infof("params: %+v", template.RedactParams(params, specs))
+			resources, err = template.ResolveResourcesForTrigger(rt.TriggerTemplate, params, uid, template.TriggerContext{
+				TriggerName:      t.Name,
+				TriggerNamespace: ns,
+				ListenerName:     r.EventListenerName,
+			})
+			if err != nil {
+				log.Error(template.Redact(err.Error(), sensitive))
+				return withReason(triggersv1.ReasonTemplateInvalid, err)
+			}
+		}
+		if t.Capture != nil {
+			if resources, captured, err = r.captureEvent(t.Capture, resources, finalPayload, header, uid, t.Name, eventID); err != nil {
+				log.Error(template.Redact(err.Error(), sensitive))
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		log.Error(err)
-		return err
+		log.Error(template.Redact(err.Error(), sensitive))
+		return out, err
 	}
-	log.Info("params: %+v", params)
-	resources := template.ResolveResources(rt.TriggerTemplate, params)
-	token, err := r.retrieveAuthToken(t.ServiceAccount, eventLog)
-	if err != nil {
-		log.Error(err)
-		return err
+
+	err = runPhase(ctx, t.Name, phaseCreate, seconds(timeouts.CreateSeconds), func(ctx context.Context) error {
+		if err := checkDeadline(ctx); err != nil {
+			log.Error(err)
+			return err
+		}
+		token, err := r.retrieveAuthToken(t.ServiceAccount, eventLog)
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+		out.created, err = r.createResources(ctx, token, resources, captured, ns, t.Cluster, t.Name, eventID, deliveryID, correlationID, t.ValidateBeforeCreate, sensitive, log)
+		if err != nil {
+			log.Error(template.Redact(err.Error(), sensitive))
+			if kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err) {
+				return withReason(triggersv1.ReasonRBACDenied, err)
+			}
+			return err
+		}
+		return nil
+	})
+	return out, err
+}
+
+// interceptorKubeClient returns a Kubernetes client with the credentials of
+// the ServiceAccount. Unlike the ServiceAccount of a Trigger, it never falls
+// back to the credentials of the EventListener, so that interceptors cannot
+// read Secrets that the ServiceAccount is not allowed to.
+func (r Sink) interceptorKubeClient(saRef *corev1.ObjectReference, eventLog *zap.SugaredLogger) (kubernetes.Interface, error) {
+	token, err := r.retrieveAuthToken(saRef, eventLog)
+	if err == nil && token == "" {
+		err = fmt.Errorf("no token found for interceptor ServiceAccount %s/%s", saRef.Namespace, saRef.Name)
 	}
-	if err := r.createResources(token, resources, t.Name, eventID, log); err != nil {
-		log.Error(err)
-		return err
+	if err != nil {
+		return nil, withReason(triggersv1.ReasonSecretMissing, err)
 	}
-	return nil
+	return r.Auth.OverrideKubeClient(token, eventLog, r.KubeClientSet)
 }
 
-func (r Sink) executeInterceptors(t *triggersv1.EventListenerTrigger, in *http.Request, event []byte, log *zap.SugaredLogger) ([]byte, http.Header, error) {
-	if len(t.Interceptors) == 0 {
-		return event, in.Header, nil
+func (r Sink) executeInterceptors(t *triggersv1.EventListenerTrigger, ns string, in *http.Request, event []byte, log *zap.SugaredLogger) ([]byte, http.Header, error) {
+	chain, err := r.expandInterceptorChains(t.Interceptors)
+	if err != nil {
+		log.Error(err)
+		return nil, nil, err
+	}
+	// Only interceptor services may respond with resources, not senders
+	header := in.Header.Clone()
+	header.Del(webhook.ResponseTypeHeader)
+	if len(chain) == 0 {
+		return event, header, nil
 	}
 
 	// The request body to the first interceptor in the chain should be the received event body.
-	request := &http.Request{
-		Method: http.MethodPost,
-		Header: in.Header,
-		Body:   ioutil.NopCloser(bytes.NewBuffer(event)),
-	}
-	var resp *http.Response
-	for _, i := range t.Interceptors {
+	// The remote address is kept throughout the chain so interceptors can filter on
+	// the sender, and the context so that interceptors stop at the event timeout.
+	ctx := in.Context()
+	payload := event
+	for _, i := range chain {
+		if err := checkDeadline(ctx); err != nil {
+			log.Error(err)
+			return nil, nil, err
+		}
+		request := (&http.Request{
+			Method:     http.MethodPost,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader(payload)),
+			RemoteAddr: in.RemoteAddr,
+		}).WithContext(ctx)
 		var interceptor interceptors.Interceptor
 		switch {
 		case i.Webhook != nil:
-			interceptor = webhook.NewInterceptor(i.Webhook, r.HTTPClient, r.EventListenerNamespace, log)
+			interceptor = webhook.NewInterceptor(i.Webhook, r.HTTPClient, r.EventListenerNamespace, r.InterceptorRetry, log)
 		case i.GitHub != nil:
 			interceptor = github.NewInterceptor(i.GitHub, r.KubeClientSet, r.EventListenerNamespace, log)
 		case i.GitLab != nil:
 			interceptor = gitlab.NewInterceptor(i.GitLab, r.KubeClientSet, r.EventListenerNamespace, log)
 		case i.CEL != nil:
-			interceptor = cel.NewInterceptor(i.CEL, r.KubeClientSet, r.EventListenerNamespace, log)
+			trigger := cel.Trigger{Name: t.Name, Namespace: ns, ListenerName: r.EventListenerName}
+			interceptor = cel.NewInterceptor(i.CEL, trigger, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.Sentry != nil:
+			interceptor = sentry.NewInterceptor(i.Sentry, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.Bitbucket != nil:
+			interceptor = bitbucket.NewInterceptor(i.Bitbucket, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.Alert != nil:
+			interceptor = alert.NewInterceptor(i.Alert, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.OPA != nil:
+			interceptor = opa.NewInterceptor(i.OPA, r.HTTPClient, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.Scanner != nil:
+			interceptor = scanner.NewInterceptor(i.Scanner, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.SupplyChain != nil:
+			interceptor = supplychain.NewInterceptor(i.SupplyChain, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.Captcha != nil:
+			interceptor = captcha.NewInterceptor(i.Captcha, r.HTTPClient, r.KubeClientSet, r.EventListenerNamespace, log)
+		case i.Ref != nil:
+			wh, concurrency, err := r.clusterInterceptorWebhook(i)
+			if err != nil {
+				log.Error(err)
+				return nil, nil, err
+			}
+			interceptor = webhook.NewInterceptor(wh, r.HTTPClient, r.EventListenerNamespace, r.InterceptorRetry, log)
+			if concurrency != nil && r.InterceptorLimits != nil {
+				release, err := r.InterceptorLimits.acquire(ctx, i.Ref.Name, *concurrency)
+				if err != nil {
+					log.Error(err)
+					return nil, nil, interceptorError(ctx, i, err)
+				}
+				interceptor = limitedInterceptor{Interceptor: interceptor, release: release}
+			}
 		default:
 			return nil, nil, fmt.Errorf("unknown interceptor type: %v", i)
 		}
-		var err error
-		resp, err = interceptor.ExecuteTrigger(request)
+		r.Faults.delayInterceptor()
+		resp, err := interceptor.ExecuteTrigger(request)
 		if err != nil {
 			log.Error(err)
-			return nil, nil, err
+			return nil, nil, interceptorError(ctx, i, err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading interceptor response body: %w", err)
 		}
-
 		// Set the next request to be the output of the last response to enable
 		// request chaining.
-		request = &http.Request{
-			Method: http.MethodPost,
-			Header: resp.Header,
-			Body:   ioutil.NopCloser(resp.Body),
+		header = resp.Header
+		if i.Webhook == nil && i.Ref == nil {
+			payload = body
+			continue
+		}
+		switch {
+		case webhook.IsResourcesResponse(resp.Header):
+			// The remaining interceptors are skipped once an interceptor
+			// service responds with the resources to create
+			return body, header, nil
+		case webhook.IsPatchResponse(resp.Header):
+			// Interceptor services may respond with a JSON Patch of the event
+			// they received instead of the whole event
+			if payload, err = webhook.ApplyPatch(payload, body); err != nil {
+				log.Error(err)
+				return nil, nil, interceptorError(ctx, i, err)
+			}
+			header.Del(webhook.ResponseTypeHeader)
+		default:
+			payload = body
 		}
 	}
-	payload, err := ioutil.ReadAll(resp.Body)
+	return payload, header, nil
+}
+
+// clusterInterceptorWebhook resolves the ClusterInterceptor referenced by the
+// interceptor into the webhook that calls it, and returns the concurrency of
+// the ClusterInterceptor.
+func (r Sink) clusterInterceptorWebhook(i *triggersv1.EventInterceptor) (*triggersv1.WebhookInterceptor, *triggersv1.InterceptorConcurrency, error) {
+	ci, err := r.TriggersClient.TriggersV1alpha1().ClusterInterceptors().Get(i.Ref.Name, metav1.GetOptions{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("error reading final response body: %w", err)
+		return nil, nil, withReason(triggersv1.ReasonInterceptorUnreachable, err)
+	}
+	wh, err := webhook.FromClusterInterceptor(ci, i.Params)
+	return wh, ci.Spec.Concurrency, err
+}
+
+// expandInterceptorChains replaces the interceptors that reference an
+// InterceptorChain with the interceptors of the chain.
+func (r Sink) expandInterceptorChains(in []*triggersv1.EventInterceptor) ([]*triggersv1.EventInterceptor, error) {
+	out := make([]*triggersv1.EventInterceptor, 0, len(in))
+	for _, i := range in {
+		if i.Chain == nil {
+			out = append(out, i)
+			continue
+		}
+		ic, err := r.TriggersClient.TriggersV1alpha1().InterceptorChains(r.EventListenerNamespace).Get(i.Chain.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, withReason(triggersv1.ReasonInterceptorUnreachable, err)
+		}
+		out = append(out, ic.Spec.Interceptors...)
+	}
+	return out, nil
+}
+
+// interceptorError attaches the name of the interceptor and a reason to its
+// errors. Errors that do not indicate a problem with the configuration mean
+// that the interceptor rejected the event.
+func interceptorError(ctx context.Context, i *triggersv1.EventInterceptor, err error) error {
+	kind, name := interceptorName(i)
+	reason := triggersv1.ReasonInterceptorRejected
+	var uerr *url.Error
+	switch {
+	case ctx.Err() != nil:
+		// The interceptor did not respond before the event timeout
+		reason = triggersv1.ReasonDeadlineExceeded
+	case i.OPA != nil && (kerrors.IsNotFound(err) || errors.As(err, &uerr)):
+		// The policy ConfigMap is missing or the OPA server cannot be reached
+		reason = triggersv1.ReasonInterceptorUnreachable
+	case errors.Is(err, errInterceptorOverloaded):
+		reason = triggersv1.ReasonInterceptorOverloaded
+	case kerrors.IsNotFound(err):
+		reason = triggersv1.ReasonSecretMissing
+	case kerrors.IsUnauthorized(err) || kerrors.IsForbidden(err):
+		reason = triggersv1.ReasonRBACDenied
+	case (i.Webhook != nil || i.Ref != nil) && errors.As(err, &uerr):
+		reason = triggersv1.ReasonInterceptorUnreachable
+	}
+	interceptorErrors.WithLabelValues(kind, reason).Inc()
+	return &reasonError{reason: reason, err: err, interceptor: name}
+}
+
+// interceptorName returns the kind of the interceptor, e.g. cel, and a name
+// that also identifies the Service or ClusterInterceptor it calls.
+func interceptorName(i *triggersv1.EventInterceptor) (kind, name string) {
+	switch {
+	case i.Webhook != nil:
+		if i.Webhook.ObjectRef != nil {
+			return "webhook", "webhook/" + i.Webhook.ObjectRef.Name
+		}
+		return "webhook", "webhook"
+	case i.GitHub != nil:
+		return "github", "github"
+	case i.GitLab != nil:
+		return "gitlab", "gitlab"
+	case i.CEL != nil:
+		return "cel", "cel"
+	case i.Sentry != nil:
+		return "sentry", "sentry"
+	case i.Bitbucket != nil:
+		return "bitbucket", "bitbucket"
+	case i.Alert != nil:
+		return "alert", "alert"
+	case i.OPA != nil:
+		return "opa", "opa"
+	case i.Scanner != nil:
+		return "scanner", "scanner"
+	case i.SupplyChain != nil:
+		return "supplychain", "supplychain"
+	case i.Captcha != nil:
+		return "captcha", "captcha"
+	case i.Ref != nil:
+		return "ref", "ref/" + i.Ref.Name
+	}
+	return "unknown", "unknown"
+}
+
+// truncateMessage shortens msg to at most n bytes, without splitting a
+// multi-byte character.
+func truncateMessage(msg string, n int) string {
+	if len(msg) <= n {
+		return msg
+	}
+	for n > 0 && !utf8.RuneStart(msg[n]) {
+		n--
 	}
-	defer resp.Body.Close()
-	return payload, resp.Header, nil
+	return msg[:n]
 }
 
-func (r Sink) createResources(token string, res []json.RawMessage, triggerName, eventID string, log *zap.SugaredLogger) error {
+// createResources creates the resources of a Trigger. The captured ConfigMap,
+// if any, is created first with the credentials of the EventListener.
+// Creations that fail for a transient reason are retried, and the resources
+// created by a retry are annotated with the attempt and the event IDs. If the
+// Trigger has a cluster, the resources are created there with the credentials
+// of its kubeconfig.
+func (r Sink) createResources(ctx context.Context, token string, res []json.RawMessage, captured *corev1.ConfigMap, ns string, cluster *triggersv1.RemoteCluster, triggerName, eventID, deliveryID, correlationID string, validate bool, sensitive []string, log *zap.SugaredLogger) ([]CreatedResource, error) {
 	discoveryClient := r.DiscoveryClient
 	dynamicClient := r.DynamicClient
+	target := ns
 	var err error
-	if len(token) > 0 {
+	if cluster != nil {
+		discoveryClient, dynamicClient, err = r.remoteClusterClients(cluster, ns, token, log)
+		if err != nil {
+			log.Errorf("problem getting clients for the remote cluster: %s", err)
+			return nil, err
+		}
+		if cluster.Namespace != "" {
+			target = cluster.Namespace
+		}
+	} else if len(token) > 0 {
 		// So at start up the discovery and dynamic clients are created using the in cluster config
 		// of this pod (i.e. using the credentials of the serviceaccount associated with the EventListener)
 
@@ -240,15 +993,80 @@ func (r Sink) createResources(token string, res []json.RawMessage, triggerName,
 		discoveryClient, dynamicClient, err = r.Auth.OverrideAuthentication(token, log, r.DiscoveryClient, r.DynamicClient)
 		if err != nil {
 			log.Errorf("problem cloning rest config: %#v", err)
-			return err
+			return nil, err
+		}
+	}
+
+	if deliveryID != "" || correlationID != "" {
+		correlated := make([]json.RawMessage, 0, len(res))
+		for _, rr := range res {
+			rt, err := correlate(rr, deliveryID, correlationID)
+			if err != nil {
+				return nil, err
+			}
+			correlated = append(correlated, rt)
+		}
+		res = correlated
+	}
+
+	if validate {
+		for i, rr := range res {
+			if err := resources.DryRunCreate(r.Logger, rr, triggerName, eventID, r.EventListenerName, target, discoveryClient, dynamicClient); err != nil {
+				log.Errorf("dry-run of resource template %d rejected: %s", i, template.Redact(err.Error(), sensitive))
+				return nil, withReason(triggersv1.ReasonResourceRejected, err)
+			}
 		}
 	}
 
+	if captured != nil {
+		if _, err := r.KubeClientSet.CoreV1().ConfigMaps(ns).Create(captured); err != nil {
+			log.Errorf("problem creating ConfigMap for captured event: %v", err)
+			return nil, err
+		}
+	}
+
+	var created []CreatedResource
 	for _, rr := range res {
-		if err := resources.Create(r.Logger, rr, triggerName, eventID, r.EventListenerName, r.EventListenerNamespace, discoveryClient, dynamicClient); err != nil {
-			log.Errorf("problem creating obj: %#v", err)
-			return err
+		if err := checkDeadline(ctx); err != nil {
+			log.Error(err)
+			return created, err
+		}
+		var obj *unstructured.Unstructured
+		attempts, err := createWithRetries(ctx, createBackoff, func(attempt int) error {
+			rt := rr
+			if attempt > 1 {
+				var err error
+				if rt, err = annotateRetry(rr, attempt, eventID, deliveryID); err != nil {
+					return err
+				}
+			}
+			if err := r.Faults.createFailure(); err != nil {
+				log.Errorf("problem creating obj (attempt %d): %s", attempt, err)
+				return err
+			}
+			var err error
+			if obj, err = resources.Create(r.Logger, rt, triggerName, eventID, r.EventListenerName, target, discoveryClient, dynamicClient); err != nil {
+				log.Errorf("problem creating obj (attempt %d): %s", attempt, template.Redact(err.Error(), sensitive))
+				return err
+			}
+			return nil
+		})
+		if attempts > 1 {
+			result := "created"
+			if err != nil {
+				result = "failed"
+			}
+			retriedCreations.WithLabelValues(triggerName, result).Inc()
 		}
+		if err != nil {
+			return created, err
+		}
+		created = append(created, CreatedResource{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		})
 	}
-	return nil
+	return created, nil
 }
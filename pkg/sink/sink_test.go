@@ -18,8 +18,14 @@ package sink
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -27,12 +33,15 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	discoveryclient "k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -42,8 +51,10 @@ import (
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/logging"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	faketriggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
 	dynamicclientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
 	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
+	"github.com/tektoncd/triggers/pkg/interceptors/webhook"
 	"github.com/tektoncd/triggers/pkg/template"
 	"github.com/tektoncd/triggers/test"
 	bldr "github.com/tektoncd/triggers/test/builder"
@@ -255,6 +266,450 @@ func TestHandleEvent(t *testing.T) {
 	}
 }
 
+func TestHandleEvent_Maintenance(t *testing.T) {
+	tests := []struct {
+		name           string
+		retryAfter     int32
+		wantRetryAfter string
+	}{{
+		name:           "default retry after",
+		wantRetryAfter: "60",
+	}, {
+		name:           "custom retry after",
+		retryAfter:     300,
+		wantRetryAfter: "300",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerMaintenance(tc.retryAfter),
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1"),
+			))
+			sink, dynamicClient := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1.EventListener{el}}, el.Name, DefaultAuthOverride{})
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+			if err != nil {
+				t.Fatalf("Error creating Post request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("Response code doesn't match: %v", resp.Status)
+			}
+			if got := resp.Header.Get("Retry-After"); got != tc.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tc.wantRetryAfter)
+			}
+			if len(dynamicClient.Actions()) != 0 {
+				t.Errorf("Expected no resources to be created, got actions: %v", dynamicClient.Actions())
+			}
+		})
+	}
+}
+
+func TestHandleEvent_MissingEventListener(t *testing.T) {
+	sink, _ := getSinkAssets(t, test.Resources{}, "my-eventlistener", DefaultAuthOverride{})
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("Error creating Post request: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Response code doesn't match: %v", resp.Status)
+	}
+}
+
+func TestHandleEvent_ErrorReasons(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("dne", "v1alpha1",
+			bldr.EventListenerTriggerName("missing-template"),
+		),
+		bldr.EventListenerTrigger("dne", "v1alpha1",
+			bldr.EventListenerTriggerName("missing-secret"),
+			func(trigger *triggersv1.EventListenerTrigger) {
+				trigger.Interceptors = append(trigger.Interceptors, &triggersv1.EventInterceptor{
+					GitHub: &triggersv1.GitHubInterceptor{SecretRef: &triggersv1.SecretRef{SecretName: "dne", SecretKey: "token"}},
+				})
+			},
+		),
+		bldr.EventListenerTrigger("dne", "v1alpha1",
+			bldr.EventListenerTriggerName("rejected"),
+			bldr.EventListenerCELInterceptor("has(body.value)"),
+		),
+	))
+	sink, _ := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1.EventListener{el}}, el.Name, DefaultAuthOverride{})
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("Error creating Post request: %s", err)
+	}
+	req.Header.Set("X-Hub-Signature", "sha1=abc")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error sending Post request: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Response code doesn't match: %v", resp.Status)
+	}
+	var gotBody Response
+	if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	wantErrors := []TriggerError{
+		{Trigger: "missing-secret", Reason: triggersv1.ReasonSecretMissing, Interceptor: "github"},
+		{Trigger: "missing-template", Reason: triggersv1.ReasonTemplateInvalid},
+		{
+			Trigger:     "rejected",
+			Reason:      triggersv1.ReasonInterceptorRejected,
+			Interceptor: "cel",
+			Message:     "expression has(body.value) did not return true",
+		},
+	}
+	sortErrors := cmpopts.SortSlices(func(a, b TriggerError) bool { return a.Trigger < b.Trigger })
+	if diff := cmp.Diff(wantErrors, gotBody.Errors, sortErrors); diff != "" {
+		t.Errorf("did not get expected errors back -want,+got: %s", diff)
+	}
+}
+
+func TestHandleEvent_EventTimeout(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerEventTimeout(1),
+		bldr.EventListenerTrigger("dne", "v1alpha1",
+			bldr.EventListenerTriggerName("slow"),
+			bldr.EventListenerCELInterceptor("true"),
+		),
+	))
+	sink, _ := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1.EventListener{el}}, el.Name, DefaultAuthOverride{})
+	// The interceptor is delayed past the timeout of the EventListener
+	sink.Faults = &FaultInjector{
+		InterceptorLatency:     3 * time.Second,
+		InterceptorLatencyRate: 1,
+		random:                 func() float64 { return 0 },
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	start := time.Now()
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("Error sending Post request: %s", err)
+	}
+	defer resp.Body.Close()
+	if elapsed := time.Since(start); elapsed >= 3*time.Second {
+		t.Errorf("Response took %s, want it to be sent at the event timeout", elapsed)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Response code doesn't match: %v", resp.Status)
+	}
+	var gotBody Response
+	if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	wantErrors := []TriggerError{{Trigger: "slow", Reason: triggersv1.ReasonDeadlineExceeded}}
+	if diff := cmp.Diff(wantErrors, gotBody.Errors); diff != "" {
+		t.Errorf("did not get expected errors back -want,+got: %s", diff)
+	}
+}
+
+func TestHandleEvent_ValidateBeforeCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		validate   bool
+		wantErrors []TriggerError
+	}{{
+		name:     "without validation",
+		validate: false,
+	}, {
+		name:       "with validation",
+		validate:   true,
+		wantErrors: []TriggerError{{Trigger: "my-trigger", Reason: triggersv1.ReasonResourceRejected}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resourceTemplate := func(name string) runtime.RawExtension {
+				return runtime.RawExtension{Raw: []byte(fmt.Sprintf(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"%s"},"spec":{"type":"git"}}`, name))}
+			}
+			tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+				bldr.TriggerTemplateSpec(
+					bldr.TriggerResourceTemplate(resourceTemplate("valid")),
+					bldr.TriggerResourceTemplate(resourceTemplate("rejected")),
+				))
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					func(trigger *triggersv1.EventListenerTrigger) {
+						trigger.ValidateBeforeCreate = tc.validate
+					},
+				),
+			))
+			sink, dynamicClient := getSinkAssets(t, test.Resources{
+				TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+				EventListeners:   []*triggersv1.EventListener{el},
+			}, el.Name, DefaultAuthOverride{})
+			dynamicClient.PrependReactor("create", "pipelineresources", func(action ktesting.Action) (bool, runtime.Object, error) {
+				obj := action.(ktesting.CreateAction).GetObject().(*unstructured.Unstructured)
+				if obj.GetName() == "rejected" {
+					return true, nil, kerrors.NewBadRequest("admission webhook denied the request")
+				}
+				return false, nil, nil
+			})
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+			if err != nil {
+				t.Fatalf("Error creating Post request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusAccepted {
+				t.Errorf("Response code doesn't match: %v", resp.Status)
+			}
+			var gotBody Response
+			if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+				t.Fatalf("Error reading response body: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantErrors, gotBody.Errors); diff != "" {
+				t.Errorf("did not get expected errors back -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_OnMissingField(t *testing.T) {
+	tests := []struct {
+		policy     triggersv1.MissingFieldPolicy
+		wantNames  []string
+		wantErrors []TriggerError
+	}{{
+		policy:     triggersv1.MissingFieldFail,
+		wantErrors: []TriggerError{{Trigger: "my-trigger", Reason: triggersv1.ReasonFieldMissing}},
+	}, {
+		policy:    triggersv1.MissingFieldEmpty,
+		wantNames: []string{"ref-"},
+	}, {
+		policy:    triggersv1.MissingFieldDefault,
+		wantNames: []string{"ref-main"},
+	}}
+	for _, tc := range tests {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			tb := bldr.TriggerBinding("my-triggerbinding", namespace,
+				bldr.TriggerBindingSpec(
+					bldr.TriggerBindingParam("branch", "$(body.ref)"),
+				))
+			tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+				bldr.TriggerTemplateSpec(
+					bldr.TriggerTemplateParam("branch", "", "main"),
+					bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"ref-$(params.branch)"},"spec":{"type":"git"}}`)}),
+				))
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+					bldr.EventListenerTriggerOnMissingField(tc.policy),
+				),
+			))
+			sink, dynamicClient := getSinkAssets(t, test.Resources{
+				TriggerBindings:  []*triggersv1.TriggerBinding{tb},
+				TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+				EventListeners:   []*triggersv1.EventListener{el},
+			}, el.Name, DefaultAuthOverride{})
+			counter := missingFields.WithLabelValues("my-trigger", string(tc.policy))
+			before := counterValue(t, counter)
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+			if err != nil {
+				t.Fatalf("Error creating Post request: %s", err)
+			}
+			defer resp.Body.Close()
+			var gotBody Response
+			if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+				t.Fatalf("Error reading response body: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantErrors, gotBody.Errors); diff != "" {
+				t.Errorf("did not get expected errors back -want,+got: %s", diff)
+			}
+			var gotNames []string
+			for _, pr := range getCreatedPipelineResources(t, dynamicClient.Actions()) {
+				gotNames = append(gotNames, pr.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("did not create expected resources -want,+got: %s", diff)
+			}
+			if got := counterValue(t, counter) - before; got != 1 {
+				t.Errorf("eventlistener_missing_fields_total increased by %v, want 1", got)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_SensitiveParams(t *testing.T) {
+	tb := bldr.TriggerBinding("my-triggerbinding", namespace,
+		bldr.TriggerBindingSpec(
+			bldr.TriggerBindingParam("token", "$(body.token)"),
+			bldr.TriggerBindingParam("revision", "$(body.revision)"),
+		))
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateSensitiveParam("token", "", ""),
+			bldr.TriggerTemplateParam("revision", "", ""),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"my-resource"},"spec":{"type":"git","params":[{"name":"token","value":"$(params.token)"},{"name":"revision","value":"$(params.revision)"}]}}`),
+			}),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("my-trigger"),
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+			func(trigger *triggersv1.EventListenerTrigger) {
+				trigger.ValidateBeforeCreate = true
+			},
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerBindings:  []*triggersv1.TriggerBinding{tb},
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	var logs bytes.Buffer
+	sink.Logger = zap.New(zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(&logs),
+		zapcore.DebugLevel,
+	)).Sugar()
+	// The rejection echoes the rendered resource like admission webhooks do
+	dynamicClient.PrependReactor("create", "pipelineresources", func(action ktesting.Action) (bool, runtime.Object, error) {
+		obj := action.(ktesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		params, _, _ := unstructured.NestedSlice(obj.Object, "spec", "params")
+		return true, nil, kerrors.NewBadRequest(fmt.Sprintf("admission webhook denied the request: %v", params))
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{"token":"s3cr3t-t0k3n","revision":"abc123"}`)))
+	if err != nil {
+		t.Fatalf("Error creating Post request: %s", err)
+	}
+	defer resp.Body.Close()
+	var gotBody Response
+	if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	wantErrors := []TriggerError{{Trigger: "my-trigger", Reason: triggersv1.ReasonResourceRejected}}
+	if diff := cmp.Diff(wantErrors, gotBody.Errors); diff != "" {
+		t.Errorf("did not get expected errors back -want,+got: %s", diff)
+	}
+
+	// The event payload is only logged at debug level before the params are
+	// resolved
+	got := strings.Replace(logs.String(), `{\"token\":\"s3cr3t-t0k3n\",\"revision\":\"abc123\"}`, "", -1)
+	if strings.Contains(got, "s3cr3t-t0k3n") {
+		t.Errorf("sensitive param value was logged: %s", got)
+	}
+	for _, want := range []string{"abc123", template.Redacted} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in logs: %s", want, got)
+		}
+	}
+}
+
+func TestHandleEvent_NamespaceSelector(t *testing.T) {
+	team := map[string]string{"team": "a"}
+	ns := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	tt := func(namespace string) *triggersv1.TriggerTemplate {
+		return bldr.TriggerTemplate("my-triggertemplate", namespace,
+			bldr.TriggerTemplateSpec(
+				bldr.TriggerTemplateParam("url", "", ""),
+				bldr.TriggerResourceTemplate(runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"my-pipelineresource"},"spec":{"type":"git","params":[{"name":"url","value":"$(params.url)"}]}}`),
+				}),
+			))
+	}
+	tb := func(namespace, url string) *triggersv1.TriggerBinding {
+		return bldr.TriggerBinding("my-triggerbinding", namespace,
+			bldr.TriggerBindingSpec(bldr.TriggerBindingParam("url", url)))
+	}
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerNamespaceSelector(team),
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("my-trigger"),
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		Namespaces: []*corev1.Namespace{
+			ns(namespace, nil),
+			// Served with its own TriggerBinding
+			ns("team-a", team),
+			// Skipped, there is no TriggerTemplate
+			ns("team-a-empty", team),
+			// Fails, there is no TriggerBinding
+			ns("team-a-broken", team),
+			// Not selected
+			ns("team-b", map[string]string{"team": "b"}),
+		},
+		TriggerBindings: []*triggersv1.TriggerBinding{
+			tb(namespace, "https://example.com/el"),
+			tb("team-a", "https://example.com/team-a"),
+			tb("team-b", "https://example.com/team-b"),
+		},
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt(namespace), tt("team-a"), tt("team-a-broken"), tt("team-b")},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	sink.Namespaces = NewNamespaceTracker(sink.KubeClientSet, sink.Logger, stopCh)
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("Error creating Post request: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Response code doesn't match: %v", resp.Status)
+	}
+	var gotBody Response
+	if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+		t.Fatalf("Error reading response body: %s", err)
+	}
+	wantErrors := []TriggerError{{Trigger: "my-trigger", Namespace: "team-a-broken", Reason: triggersv1.ReasonTemplateInvalid}}
+	if diff := cmp.Diff(wantErrors, gotBody.Errors); diff != "" {
+		t.Errorf("did not get expected errors back -want,+got: %s", diff)
+	}
+
+	want := []string{
+		namespace + "/https://example.com/el",
+		"team-a/https://example.com/team-a",
+	}
+	var got []string
+	for _, action := range dynamicClient.Actions() {
+		pr := getCreatedPipelineResources(t, []ktesting.Action{action})[0]
+		got = append(got, action.GetNamespace()+"/"+pr.Spec.Params[0].Value)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(x, y string) bool { return x < y })); diff != "" {
+		t.Errorf("Created resources mismatch (-want + got): %s", diff)
+	}
+}
+
 func TestHandleEventWithInterceptors(t *testing.T) {
 	eventBody := json.RawMessage(`{"head_commit": {"id": "testrevision"}, "repository": {"url": "testurl"}, "foo": "bar\t\r\nbaz昨"}`)
 
@@ -506,6 +961,197 @@ func TestHandleEventWithWebhookInterceptors(t *testing.T) {
 	}
 }
 
+func TestHandleEvent_MatchHeaders(t *testing.T) {
+	tt := bldr.TriggerTemplate("tt", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"generateName":"push-"},"spec":{"type":"git"}}`),
+			}),
+		))
+	trigger := func(name, event string) triggersv1.EventListenerTrigger {
+		return triggersv1.EventListenerTrigger{
+			Name:     name,
+			Template: triggersv1.EventListenerTemplate{Name: "tt"},
+			Interceptors: []*triggersv1.EventInterceptor{{
+				Webhook: &triggersv1.WebhookInterceptor{
+					ObjectRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Name: "foo"},
+				},
+			}},
+			MatchHeaders: []triggersv1.HeaderMatch{{Name: "X-GitHub-Event", Values: []string{event}}},
+		}
+	}
+	el := &triggersv1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{Name: "el", Namespace: namespace},
+		Spec: triggersv1.EventListenerSpec{
+			Triggers: []triggersv1.EventListenerTrigger{trigger("push", "push"), trigger("pr", "pull_request")},
+		},
+	}
+
+	var mu sync.Mutex
+	intercepted := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		intercepted++
+		mu.Unlock()
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	client := srv.Client()
+	client.Transport = &http.Transport{Proxy: http.ProxyURL(u)}
+
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+	sink.HTTPClient = client
+
+	ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("X-GitHub-Event", "push")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error sending request: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Response code doesn't match: %v", resp.Status)
+	}
+	// The interceptor of the pull request Trigger is never called
+	mu.Lock()
+	defer mu.Unlock()
+	if intercepted != 1 {
+		t.Errorf("interceptor called %d times, want 1", intercepted)
+	}
+	if got := len(getCreatedPipelineResources(t, dynamicClient.Actions())); got != 1 {
+		t.Errorf("created %d resources, want 1", got)
+	}
+}
+
+func TestHandleEventWithInterceptorResources(t *testing.T) {
+	pr := pipelinev1alpha1.PipelineResource{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1alpha1",
+			Kind:       "PipelineResource",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rendered",
+			Namespace: namespace,
+		},
+		Spec: pipelinev1alpha1.PipelineResourceSpec{
+			Type: pipelinev1alpha1.PipelineResourceTypeGit,
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(webhook.ResponseTypeHeader, webhook.ResourcesResponse)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"resources": []interface{}{pr}})
+	}))
+	defer srv.Close()
+	client := srv.Client()
+	u, _ := url.Parse(srv.URL)
+	client.Transport = &http.Transport{
+		Proxy: http.ProxyURL(u),
+	}
+
+	renderer := bldr.EventListenerTriggerInterceptor("renderer", "v1", "Service", namespace)
+	// The interceptors after the one that responds with resources are skipped
+	filter := bldr.EventListenerCELInterceptor("false")
+	for _, tc := range []struct {
+		name      string
+		allowed   *triggersv1.InterceptorResources
+		wantCode  int
+		wantError *TriggerError
+		want      []pipelinev1alpha1.PipelineResource
+	}{{
+		name:     "allowed kind",
+		allowed:  &triggersv1.InterceptorResources{Kinds: []string{"PipelineResource"}},
+		wantCode: http.StatusCreated,
+		want: []pipelinev1alpha1.PipelineResource{func() pipelinev1alpha1.PipelineResource {
+			want := *pr.DeepCopy()
+			want.Labels = map[string]string{
+				resourceLabel: "my-eventlistener",
+				triggerLabel:  "fast",
+				eventIDLabel:  eventID,
+			}
+			want.Annotations = map[string]string{"triggers.tekton.dev/rendered-by": "interceptor"}
+			return want
+		}()},
+	}, {
+		name:      "disallowed kind",
+		allowed:   &triggersv1.InterceptorResources{Kinds: []string{"PipelineRun"}},
+		wantCode:  http.StatusAccepted,
+		wantError: &TriggerError{Trigger: "fast", Reason: triggersv1.ReasonResourceRejected},
+	}, {
+		name:      "not allowed",
+		wantCode:  http.StatusAccepted,
+		wantError: &TriggerError{Trigger: "fast", Reason: triggersv1.ReasonResourceRejected},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace,
+				bldr.EventListenerSpec(
+					bldr.EventListenerTrigger("tt", "v1alpha1",
+						bldr.EventListenerTriggerName("fast"),
+						renderer,
+						filter,
+						func(t *triggersv1.EventListenerTrigger) {
+							t.InterceptorResources = tc.allowed
+						},
+					)))
+			sink, dynamicClient := getSinkAssets(t, test.Resources{EventListeners: []*triggersv1.EventListener{el}}, el.Name, DefaultAuthOverride{})
+			sink.HTTPClient = client
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			resp, err := http.Post(ts.URL, "application/json", bytes.NewReader([]byte(`{}`)))
+			if err != nil {
+				t.Fatalf("Error creating Post request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantCode {
+				t.Errorf("Response code = %d, want %d", resp.StatusCode, tc.wantCode)
+			}
+			var body Response
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Error decoding response: %s", err)
+			}
+			var wantErrors []TriggerError
+			if tc.wantError != nil {
+				wantErrors = []TriggerError{*tc.wantError}
+			}
+			if diff := cmp.Diff(wantErrors, body.Errors); diff != "" {
+				t.Errorf("Errors: -want +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want, getCreatedPipelineResources(t, dynamicClient.Actions()), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Created resources: -want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestExecuteInterceptor_forgedResourcesResponse(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	req.Header.Set(webhook.ResponseTypeHeader, webhook.ResourcesResponse)
+	for _, trigger := range []triggersv1.EventListenerTrigger{
+		bldr.Trigger("tt", "v1alpha1"),
+		bldr.Trigger("tt", "v1alpha1", bldr.EventListenerCELInterceptor("true")),
+	} {
+		r := Sink{KubeClientSet: fakekubeclientset.NewSimpleClientset(), Logger: logger}
+		_, header, err := r.executeInterceptors(&trigger, namespace, req, []byte(`{"resources":[]}`), logger)
+		if err != nil {
+			t.Fatalf("executeInterceptors: %v", err)
+		}
+		if webhook.IsResourcesResponse(header) {
+			t.Errorf("expected the %s header of the event to be dropped", webhook.ResponseTypeHeader)
+		}
+	}
+}
+
 // sequentialInterceptor is a HTTP server that will return sequential responses.
 // It expects a request of the form `{"i": n}`.
 // The response body will always return with the next value set, whereas the
@@ -590,7 +1236,7 @@ func TestExecuteInterceptor(t *testing.T) {
 			if err != nil {
 				t.Fatalf("http.NewRequest: %v", err)
 			}
-			resp, header, err := r.executeInterceptors(trigger, req, []byte(`{}`), logger)
+			resp, header, err := r.executeInterceptors(trigger, namespace, req, []byte(`{}`), logger)
 			if err != nil {
 				t.Fatalf("executeInterceptors: %v", err)
 			}
@@ -610,6 +1256,68 @@ func TestExecuteInterceptor(t *testing.T) {
 	}
 }
 
+func TestExecuteInterceptor_patchResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(webhook.ResponseTypeHeader, webhook.PatchResponse)
+		_, _ = w.Write([]byte(`[{"op":"test","path":"/ref","value":"refs/heads/main"},{"op":"add","path":"/release","value":true}]`))
+	}))
+	defer srv.Close()
+	client := srv.Client()
+	// Redirect all requests to the fake server.
+	u, _ := url.Parse(srv.URL)
+	client.Transport = &http.Transport{
+		Proxy: http.ProxyURL(u),
+	}
+
+	logger, _ := logging.NewLogger("", "")
+	r := Sink{
+		HTTPClient:    client,
+		KubeClientSet: fakekubeclientset.NewSimpleClientset(),
+		Logger:        logger,
+	}
+	trigger := &triggersv1.EventListenerTrigger{
+		Interceptors: []*triggersv1.EventInterceptor{{
+			Webhook: &triggersv1.WebhookInterceptor{
+				ObjectRef: &corev1.ObjectReference{
+					APIVersion: "v1",
+					Kind:       "Service",
+					Name:       "foo",
+				},
+			},
+		}, {
+			// The patched event is passed to the next interceptor
+			CEL: &triggersv1.CELInterceptor{Filter: "has(body.release) || body.ref != 'refs/heads/main'"},
+		}},
+	}
+
+	for _, tc := range []struct {
+		event string
+		want  string
+	}{{
+		event: `{"ref":"refs/heads/main"}`,
+		want:  `{"ref":"refs/heads/main","release":true}`,
+	}, {
+		// The test operation fails, so the event is not patched
+		event: `{"ref":"refs/heads/dev"}`,
+		want:  `{"ref":"refs/heads/dev"}`,
+	}} {
+		req, err := http.NewRequest(http.MethodPost, "/", nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest: %v", err)
+		}
+		resp, header, err := r.executeInterceptors(trigger, namespace, req, []byte(tc.event), logger)
+		if err != nil {
+			t.Fatalf("executeInterceptors: %v", err)
+		}
+		if diff := cmp.Diff(tc.want, string(resp)); diff != "" {
+			t.Errorf("Body: -want +got: %s", diff)
+		}
+		if header.Get(webhook.ResponseTypeHeader) != "" {
+			t.Errorf("expected the %s header to be removed, got %q", webhook.ResponseTypeHeader, header.Get(webhook.ResponseTypeHeader))
+		}
+	}
+}
+
 // errorInterceptor is a HTTP server that will always return an error response.
 type errorInterceptor struct{}
 
@@ -671,8 +1379,13 @@ func TestExecuteInterceptor_error(t *testing.T) {
 	if err != nil {
 		t.Fatalf("http.NewRequest: %v", err)
 	}
-	if resp, _, err := s.executeInterceptors(trigger, req, nil, logger); err == nil {
-		t.Errorf("expected error, got: %+v, %v", string(resp), err)
+	resp, _, err := s.executeInterceptors(trigger, namespace, req, nil, logger)
+	if err == nil {
+		t.Fatalf("expected error, got: %+v, %v", string(resp), err)
+	}
+	var rerr *reasonError
+	if !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonInterceptorRejected || rerr.interceptor != "webhook/"+errHost {
+		t.Errorf("expected rejection by webhook/%s, got: %#v", errHost, err)
 	}
 
 	if si.called {
@@ -680,6 +1393,163 @@ func TestExecuteInterceptor_error(t *testing.T) {
 	}
 }
 
+func TestExecuteInterceptor_deadline(t *testing.T) {
+	// The interceptor does not respond until the test is done
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	client := srv.Client()
+	u, _ := url.Parse(srv.URL)
+	client.Transport = &http.Transport{
+		Proxy: http.ProxyURL(u),
+	}
+
+	logger, _ := logging.NewLogger("", "")
+	s := Sink{
+		HTTPClient: client,
+		Logger:     logger,
+	}
+	trigger := &triggersv1.EventListenerTrigger{
+		Interceptors: []*triggersv1.EventInterceptor{{
+			Webhook: &triggersv1.WebhookInterceptor{
+				ObjectRef: &corev1.ObjectReference{
+					APIVersion: "v1",
+					Kind:       "Service",
+					Name:       "hung",
+				},
+			},
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	_, _, err = s.executeInterceptors(trigger, namespace, req.WithContext(ctx), nil, logger)
+	var rerr *reasonError
+	if !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonDeadlineExceeded || rerr.interceptor != "webhook/hung" {
+		t.Errorf("expected webhook/hung to exceed the deadline, got: %#v", err)
+	}
+
+	// Interceptors are not called once the deadline has passed
+	_, _, err = s.executeInterceptors(trigger, namespace, req.WithContext(ctx), nil, logger)
+	if !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonDeadlineExceeded || rerr.interceptor != "" {
+		t.Errorf("expected the deadline to be exceeded before calling an interceptor, got: %#v", err)
+	}
+}
+
+func TestExecuteInterceptor_clusterInterceptor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "github-interceptor.tekton-pipelines.svc" {
+			http.Error(w, "unexpected host "+r.Host, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Params", r.Header.Get(webhook.ParamsHeader))
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+	client := srv.Client()
+	u, _ := url.Parse(srv.URL)
+	// Redirect all requests to the fake server.
+	client.Transport = &http.Transport{
+		Proxy: http.ProxyURL(u),
+	}
+
+	ci := &triggersv1.ClusterInterceptor{
+		ObjectMeta: metav1.ObjectMeta{Name: "github"},
+		Spec: triggersv1.ClusterInterceptorSpec{
+			ClientConfig: triggersv1.ClientConfig{
+				Service: &triggersv1.ServiceReference{Name: "github-interceptor", Namespace: "tekton-pipelines"},
+			},
+		},
+	}
+	logger, _ := logging.NewLogger("", "")
+	r := Sink{
+		HTTPClient:     client,
+		TriggersClient: faketriggersclientset.NewSimpleClientset(ci),
+		Logger:         logger,
+	}
+
+	trigger := bldr.Trigger("tt", "v1alpha1",
+		bldr.EventListenerClusterInterceptor("github", bldr.EventInterceptorRefParam("secretName", `"github-secret"`)))
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	resp, header, err := r.executeInterceptors(&trigger, namespace, req, []byte(`{"foo":"bar"}`), logger)
+	if err != nil {
+		t.Fatalf("executeInterceptors: %v", err)
+	}
+	if diff := cmp.Diff(`{"foo":"bar"}`, string(resp)); diff != "" {
+		t.Errorf("Body: -want +got: %s", diff)
+	}
+	if diff := cmp.Diff(`{"secretName":"github-secret"}`, header.Get("Params")); diff != "" {
+		t.Errorf("Params: -want +got: %s", diff)
+	}
+
+	missing := bldr.Trigger("tt", "v1alpha1", bldr.EventListenerClusterInterceptor("gitlab"))
+	_, _, err = r.executeInterceptors(&missing, namespace, req, []byte(`{}`), logger)
+	var rerr *reasonError
+	if !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonInterceptorUnreachable {
+		t.Errorf("expected %s error for missing ClusterInterceptor, got: %v", triggersv1.ReasonInterceptorUnreachable, err)
+	}
+}
+
+func TestExecuteInterceptor_interceptorChain(t *testing.T) {
+	ic := &triggersv1.InterceptorChain{
+		ObjectMeta: metav1.ObjectMeta{Name: "checks", Namespace: namespace},
+		Spec: triggersv1.InterceptorChainSpec{
+			Interceptors: []*triggersv1.EventInterceptor{{
+				CEL: &triggersv1.CELInterceptor{Filter: "body.sender != 'bot'"},
+			}, {
+				CEL: &triggersv1.CELInterceptor{
+					Overlays: []triggersv1.CELOverlay{{Key: "checked", Expression: "'yes'"}},
+				},
+			}},
+		},
+	}
+	logger, _ := logging.NewLogger("", "")
+	r := Sink{
+		KubeClientSet:          fakekubeclientset.NewSimpleClientset(),
+		TriggersClient:         faketriggersclientset.NewSimpleClientset(ic),
+		EventListenerNamespace: namespace,
+		Logger:                 logger,
+	}
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+
+	// The interceptors of the chain run in place of the reference, followed
+	// by the interceptors of the Trigger.
+	trigger := bldr.Trigger("tt", "v1alpha1",
+		bldr.EventListenerInterceptorChain("checks"),
+		bldr.EventListenerCELInterceptor("body.checked == 'yes'"))
+	resp, _, err := r.executeInterceptors(&trigger, namespace, req, []byte(`{"sender":"user"}`), logger)
+	if err != nil {
+		t.Fatalf("executeInterceptors: %v", err)
+	}
+	if diff := cmp.Diff(`{"checked":"yes","sender":"user"}`, string(resp)); diff != "" {
+		t.Errorf("Body: -want +got: %s", diff)
+	}
+
+	if _, _, err := r.executeInterceptors(&trigger, namespace, req, []byte(`{"sender":"bot"}`), logger); err == nil {
+		t.Error("expected the chain to filter the event")
+	}
+
+	missing := bldr.Trigger("tt", "v1alpha1", bldr.EventListenerInterceptorChain("other"))
+	_, _, err = r.executeInterceptors(&missing, namespace, req, []byte(`{}`), logger)
+	var rerr *reasonError
+	if !errors.As(err, &rerr) || rerr.reason != triggersv1.ReasonInterceptorUnreachable {
+		t.Errorf("expected %s error for missing InterceptorChain, got: %v", triggersv1.ReasonInterceptorUnreachable, err)
+	}
+}
+
 const userWithPermissions = "user-with-permissions"
 const userWithoutPermissions = "user-with-no-permissions"
 
@@ -733,7 +1603,7 @@ func TestRetriveveAuthToken(t *testing.T) {
 
 	token, err := r.retrieveAuthToken(&corev1.ObjectReference{Name: userWithoutPermissions, Namespace: userWithoutPermissions}, nil)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 	if token != userWithoutPermissions {
 		t.Fatalf("got token %s instead of %s", token, userWithoutPermissions)
@@ -765,6 +1635,128 @@ func (r fakeAuth) OverrideAuthentication(token string,
 	return defaultDiscoverClient, defaultDynamicClient, nil
 }
 
+func (r fakeAuth) OverrideKubeClient(token string,
+	log *zap.SugaredLogger,
+	defaultKubeClient kubernetes.Interface) (kubernetes.Interface, error) {
+	return defaultKubeClient, nil
+}
+
+// interceptorAuth returns a Kubernetes client that is not allowed to read
+// Secrets for every token except allowedToken.
+type interceptorAuth struct {
+	DefaultAuthOverride
+	allowedToken string
+}
+
+func (r interceptorAuth) OverrideKubeClient(token string,
+	log *zap.SugaredLogger,
+	defaultKubeClient kubernetes.Interface) (kubernetes.Interface, error) {
+	if token == r.allowedToken {
+		return defaultKubeClient, nil
+	}
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+	kubeClient.PrependReactor("get", "secrets", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(corev1.Resource("secrets"), action.(ktesting.GetAction).GetName(), errors.New(token+" may not read secrets"))
+	})
+	return kubeClient, nil
+}
+
+func TestHandleEvent_InterceptorServiceAccount(t *testing.T) {
+	serviceAccount := func(name string, withToken bool) (*corev1.ServiceAccount, *corev1.Secret) {
+		sa := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(name)},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name + "-token",
+				Namespace:   namespace,
+				Annotations: map[string]string{corev1.ServiceAccountNameKey: name},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+			Data: map[string][]byte{corev1.ServiceAccountTokenKey: []byte(name)},
+		}
+		if withToken {
+			sa.Secrets = []corev1.ObjectReference{{Name: secret.Name}}
+		}
+		return sa, secret
+	}
+	teamA, teamAToken := serviceAccount("team-a", true)
+	teamB, teamBToken := serviceAccount("team-b", true)
+	noToken, _ := serviceAccount("no-token", false)
+	webhookSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b-webhook", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("secret")},
+	}
+	body := []byte(`{"url": "https://example.com"}`)
+	mac := hmac.New(sha1.New, []byte("secret"))
+	mac.Write(body)
+
+	tests := []struct {
+		serviceAccount string
+		wantCode       int
+		wantErrors     []TriggerError
+	}{{
+		serviceAccount: "team-a",
+		wantCode:       http.StatusForbidden,
+		wantErrors:     []TriggerError{{Trigger: "my-trigger", Reason: triggersv1.ReasonRBACDenied, Interceptor: "github"}},
+	}, {
+		serviceAccount: "no-token",
+		wantCode:       http.StatusAccepted,
+		wantErrors:     []TriggerError{{Trigger: "my-trigger", Reason: triggersv1.ReasonSecretMissing}},
+	}, {
+		serviceAccount: "team-b",
+		wantCode:       http.StatusCreated,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.serviceAccount, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+				bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+					bldr.EventListenerTriggerName("my-trigger"),
+					bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+					bldr.EventListenerTriggerInterceptorServiceAccount(tc.serviceAccount, namespace),
+					func(trigger *triggersv1.EventListenerTrigger) {
+						trigger.Interceptors = append(trigger.Interceptors, &triggersv1.EventInterceptor{
+							GitHub: &triggersv1.GitHubInterceptor{SecretRef: &triggersv1.SecretRef{SecretName: "team-b-webhook", SecretKey: "token"}},
+						})
+					},
+				),
+			))
+			resources := sqsTestResources(el)
+			resources.ServiceAccounts = []*corev1.ServiceAccount{teamA, teamB, noToken}
+			resources.Secrets = []*corev1.Secret{teamAToken, teamBToken, webhookSecret}
+			sink, dynamicClient := getSinkAssets(t, resources, el.Name, interceptorAuth{allowedToken: "team-b"})
+
+			ts := httptest.NewServer(http.HandlerFunc(sink.HandleEvent))
+			defer ts.Close()
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Error creating Post request: %s", err)
+			}
+			req.Header.Set("X-Event", "push")
+			req.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(mac.Sum(nil)))
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Error sending Post request: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantCode {
+				t.Errorf("Response code = %v, want %d", resp.Status, tc.wantCode)
+			}
+			var gotBody Response
+			if err := json.NewDecoder(resp.Body).Decode(&gotBody); err != nil {
+				t.Fatalf("Error reading response body: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantErrors, gotBody.Errors); diff != "" {
+				t.Errorf("did not get expected errors back -want,+got: %s", diff)
+			}
+			if created := len(dynamicClient.Actions()); (tc.wantCode == http.StatusCreated) != (created > 0) {
+				t.Errorf("Created %d resources, want resources only for status %d", created, http.StatusCreated)
+			}
+		})
+	}
+}
+
 func TestHandleEventWithInterceptorsAndTriggerAuth(t *testing.T) {
 	for _, testCase := range []struct {
 		userVal    string
@@ -920,3 +1912,22 @@ func TestHandleEventWithInterceptorsAndTriggerAuth(t *testing.T) {
 	}
 
 }
+
+func Test_truncateMessage(t *testing.T) {
+	for _, tc := range []struct {
+		msg  string
+		n    int
+		want string
+	}{
+		{msg: "rejected", n: 20, want: "rejected"},
+		{msg: "rejected", n: 3, want: "rej"},
+		// "é" is two bytes, which are not split
+		{msg: "café", n: 4, want: "caf"},
+		{msg: "café", n: 5, want: "café"},
+		{msg: "日本", n: 2, want: ""},
+	} {
+		if got := truncateMessage(tc.msg, tc.n); got != tc.want {
+			t.Errorf("truncateMessage(%q, %d) = %q, want %q", tc.msg, tc.n, got, tc.want)
+		}
+	}
+}
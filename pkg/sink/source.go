@@ -0,0 +1,193 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"go.uber.org/zap"
+)
+
+// httpShutdownTimeout is how long the HTTPSource waits for requests in flight
+// when it stops.
+const httpShutdownTimeout = 10 * time.Second
+
+// Source delivers events to the Sink from a transport, e.g. HTTP or a queue.
+// Sources pass each event to the Sink like HandleEvent does, so that all of
+// them execute the Triggers the same way.
+type Source interface {
+	// Name identifies the source in logs and metrics, e.g. http or sqs.
+	Name() string
+	// Run delivers events until stopCh is closed. It returns an error if the
+	// source cannot deliver events anymore.
+	Run(stopCh <-chan struct{}) error
+}
+
+// SourceFactory returns the Source that the args configure, or nil if the
+// source is not enabled for the EventListener.
+type SourceFactory func(r Sink, args Args) (Source, error)
+
+var (
+	sourcesMu       sync.Mutex
+	sourceFactories = map[string]SourceFactory{}
+)
+
+// RegisterSource makes a Source available to the sink. Sources register
+// themselves in an init function, and are enabled by the flags that the
+// reconciler derives from the EventListener spec.
+func RegisterSource(name string, f SourceFactory) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	if _, ok := sourceFactories[name]; ok {
+		panic(fmt.Sprintf("source %s registered twice", name))
+	}
+	sourceFactories[name] = f
+}
+
+// NewSources returns the registered sources that the args enable, ordered by
+// name.
+func NewSources(r Sink, args Args) ([]Source, error) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	names := make([]string, 0, len(sourceFactories))
+	for name := range sourceFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sources []Source
+	for _, name := range names {
+		s, err := sourceFactories[name](r, args)
+		if err != nil {
+			return nil, fmt.Errorf("error creating source %s: %w", name, err)
+		}
+		if s != nil {
+			sources = append(sources, s)
+		}
+	}
+	return sources, nil
+}
+
+// RunSources runs the sources until stopCh is closed or one of them fails,
+// and returns the first error.
+func RunSources(sources []Source, stopCh <-chan struct{}, logger *zap.SugaredLogger) error {
+	stop := make(chan struct{})
+	var once sync.Once
+	go func() {
+		select {
+		case <-stopCh:
+			once.Do(func() { close(stop) })
+		case <-stop:
+		}
+	}()
+
+	errs := make(chan error, len(sources))
+	var wg sync.WaitGroup
+	for _, s := range sources {
+		wg.Add(1)
+		go func(s Source) {
+			defer wg.Done()
+			logger.Infof("Starting source %s", s.Name())
+			sourceUp.WithLabelValues(s.Name()).Set(1)
+			err := s.Run(stop)
+			sourceUp.WithLabelValues(s.Name()).Set(0)
+			if err != nil {
+				errs <- fmt.Errorf("source %s failed: %w", s.Name(), err)
+				// Stop the other sources so that the pod restarts
+				once.Do(func() { close(stop) })
+				return
+			}
+			logger.Infof("Stopped source %s", s.Name())
+		}(s)
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
+// HTTPSource serves events that are sent to the Sink over HTTP.
+type HTTPSource struct {
+	Server *http.Server
+	Args   Args
+}
+
+// Name implements Source.
+func (s *HTTPSource) Name() string {
+	return "http"
+}
+
+// Run implements Source. Requests in flight are given some time to complete
+// when stopCh is closed.
+func (s *HTTPSource) Run(stopCh <-chan struct{}) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- ListenAndServe(s.Server, s.Args)
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-stopCh:
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	return s.Server.Shutdown(ctx)
+}
+
+// trackEvent counts an event of the source as in flight until the returned
+// func is called, and then records how long it took. The events in flight
+// are the backlog of the sink, which scales it better than its CPU usage.
+func trackEvent(source string) func() {
+	start := time.Now()
+	eventsInFlight.WithLabelValues(source).Inc()
+	return func() {
+		eventsInFlight.WithLabelValues(source).Dec()
+		eventDuration.WithLabelValues(source).Observe(time.Since(start).Seconds())
+	}
+}
+
+// recordSourceEvent counts an event by the source it came from and its
+// result.
+func recordSourceEvent(source string, code int, triggerErrors []TriggerError, err error) {
+	result := "processed"
+	switch {
+	case errors.Is(err, errDuplicateDelivery):
+		result = "duplicate"
+	case err != nil:
+		result = "error"
+	case timedOut(triggerErrors):
+		result = "timeout"
+	case code == http.StatusUnauthorized || code == http.StatusForbidden || len(triggerErrors) > 0:
+		result = "failed"
+	}
+	sourceEvents.WithLabelValues(source, result).Inc()
+}
+
+// timedOut reports whether a Trigger did not finish within the event timeout.
+func timedOut(triggerErrors []TriggerError) bool {
+	for _, e := range triggerErrors {
+		if e.Reason == triggersv1.ReasonDeadlineExceeded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tektoncd/pipeline/pkg/logging"
+)
+
+// fakeSource runs until it is stopped or fails with err.
+type fakeSource struct {
+	name    string
+	err     error
+	stopped bool
+}
+
+func (s *fakeSource) Name() string {
+	return s.name
+}
+
+func (s *fakeSource) Run(stopCh <-chan struct{}) error {
+	if s.err != nil {
+		return s.err
+	}
+	<-stopCh
+	s.stopped = true
+	return nil
+}
+
+func TestNewSources(t *testing.T) {
+	RegisterSource("fake", func(r Sink, args Args) (Source, error) {
+		if args.ElName == "" {
+			return nil, nil
+		}
+		return &fakeSource{name: "fake"}, nil
+	})
+	defer delete(sourceFactories, "fake")
+
+	tests := []struct {
+		name string
+		args Args
+		want []string
+	}{{
+		name: "not enabled",
+		args: Args{},
+	}, {
+		name: "enabled",
+		args: Args{ElName: "el"},
+		want: []string{"fake"},
+	}, {
+		name: "sqs enabled",
+		args: Args{ElName: "el", SQSQueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"},
+		want: []string{"fake", "sqs"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := logging.NewLogger("", "")
+			sources, err := NewSources(Sink{Logger: logger}, tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, s := range sources {
+				got = append(got, s.Name())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewSources() -want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRunSources(t *testing.T) {
+	logger, _ := logging.NewLogger("", "")
+
+	t.Run("stopped", func(t *testing.T) {
+		sources := []Source{&fakeSource{name: "a"}, &fakeSource{name: "b"}}
+		stopCh := make(chan struct{})
+		close(stopCh)
+		if err := RunSources(sources, stopCh, logger); err != nil {
+			t.Fatalf("RunSources() = %v", err)
+		}
+		for _, s := range sources {
+			if !s.(*fakeSource).stopped {
+				t.Errorf("source %s was not stopped", s.Name())
+			}
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		running := &fakeSource{name: "running"}
+		sources := []Source{running, &fakeSource{name: "failing", err: errors.New("connection lost")}}
+		err := RunSources(sources, make(chan struct{}), logger)
+		if err == nil || err.Error() != "source failing failed: connection lost" {
+			t.Fatalf("RunSources() = %v", err)
+		}
+		if !running.stopped {
+			t.Error("the other sources were not stopped")
+		}
+	})
+}
+
+func TestHTTPSource(t *testing.T) {
+	args := Args{Port: "0"}
+	srv, err := NewServer(args, http.NotFoundHandler())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &HTTPSource{Server: srv, Args: args}
+	stopCh := make(chan struct{})
+	errs := make(chan error)
+	go func() {
+		errs <- s.Run(stopCh)
+	}()
+	close(stopCh)
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("HTTPSource did not stop")
+	}
+}
+
+func TestTrackEvent(t *testing.T) {
+	const source = "track-test"
+	done := trackEvent(source)
+	if v := gaugeValue(t, eventsInFlight.WithLabelValues(source)); v != 1 {
+		t.Errorf("Events in flight = %v, want 1", v)
+	}
+	done()
+	if v := gaugeValue(t, eventsInFlight.WithLabelValues(source)); v != 0 {
+		t.Errorf("Events in flight after the event = %v, want 0", v)
+	}
+	var m dto.Metric
+	if err := eventDuration.WithLabelValues(source).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	if n := m.GetHistogram().GetSampleCount(); n != 1 {
+		t.Errorf("Recorded %d event durations, want 1", n)
+	}
+}
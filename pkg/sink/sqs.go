@@ -0,0 +1,237 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/logging"
+	"github.com/tektoncd/triggers/pkg/sqs"
+	"github.com/tektoncd/triggers/pkg/template"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// sqsSourceName is the name of the SQSConsumer as a Source.
+	sqsSourceName = "sqs"
+	// sqsRetryInterval is how long the SQSConsumer waits before receiving
+	// again after a receive failed.
+	sqsRetryInterval = 5 * time.Second
+	// sqsMetadataPrefix marks the attribute headers that map a metadata
+	// field of a message instead of a message attribute.
+	sqsMetadataPrefix = "sqs:"
+)
+
+func init() {
+	RegisterSource(sqsSourceName, newSQSConsumer)
+}
+
+// SQSQueue is the part of the SQS API that the SQSConsumer uses.
+type SQSQueue interface {
+	ReceiveMessages(ctx context.Context, max int, visibilityTimeout time.Duration) ([]sqs.Message, error)
+	DeleteMessage(ctx context.Context, receiptHandle string) error
+	ChangeMessageVisibility(ctx context.Context, receiptHandle string, timeout time.Duration) error
+}
+
+// SQSConsumer receives events from an Amazon SQS queue and processes them
+// like the events that the Sink receives over HTTP. A message is only
+// deleted from the queue after all Triggers processed it without failing for
+// a known reason. Otherwise it is left on the queue and redelivered once its
+// visibility timeout expires.
+type SQSConsumer struct {
+	Sink  Sink
+	Queue SQSQueue
+	// BatchSize is the maximum number of messages received at once.
+	BatchSize int
+	// VisibilityTimeout is how long a received message is hidden from other
+	// consumers. While a message is processed, its visibility is extended
+	// every half VisibilityTimeout.
+	VisibilityTimeout time.Duration
+}
+
+// newSQSConsumer returns an SQSConsumer for the queue of the args, if any.
+func newSQSConsumer(r Sink, args Args) (Source, error) {
+	if args.SQSQueueURL == "" {
+		return nil, nil
+	}
+	queue, err := sqs.NewClient(args.SQSQueueURL, args.SQSRegion, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	r.Logger.Infof("Consuming events from SQS queue %s", args.SQSQueueURL)
+	return &SQSConsumer{
+		Sink:              r,
+		Queue:             queue,
+		BatchSize:         args.SQSBatchSize,
+		VisibilityTimeout: args.SQSVisibilityTimeout,
+	}, nil
+}
+
+// Name implements Source.
+func (c *SQSConsumer) Name() string {
+	return sqsSourceName
+}
+
+// Run implements Source. It receives and processes messages until stopCh is
+// closed. Receive failures are retried.
+func (c *SQSConsumer) Run(stopCh <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	for ctx.Err() == nil {
+		messages, err := c.Queue.ReceiveMessages(ctx, c.BatchSize, c.VisibilityTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			c.Sink.Logger.Errorf("Error receiving messages from SQS: %s", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(sqsRetryInterval):
+			}
+			continue
+		}
+		var wg sync.WaitGroup
+		for _, m := range messages {
+			wg.Add(1)
+			go func(m sqs.Message) {
+				defer wg.Done()
+				c.handleMessage(ctx, m)
+			}(m)
+		}
+		wg.Wait()
+	}
+	return nil
+}
+
+// handleMessage processes a message and deletes it from the queue if it was
+// processed successfully.
+func (c *SQSConsumer) handleMessage(ctx context.Context, m sqs.Message) {
+	defer trackEvent(sqsSourceName)()
+	eventID := template.UID()
+	log := c.Sink.Logger.With(zap.String(triggersv1.EventIDLabelKey, eventID), zap.String("sqsMessageID", m.MessageID))
+
+	done := make(chan struct{})
+	defer close(done)
+	go c.extendVisibility(ctx, m, done, log)
+
+	if !c.process(m, eventID, log) {
+		// Leave the message on the queue so that it is redelivered after its
+		// visibility timeout
+		return
+	}
+	if err := c.Queue.DeleteMessage(ctx, m.ReceiptHandle); err != nil {
+		log.Errorf("Error deleting processed message from SQS: %s", err)
+	}
+}
+
+// process executes the Triggers of the EventListener for a message and
+// reports whether it succeeded.
+func (c *SQSConsumer) process(m sqs.Message, eventID string, log *zap.SugaredLogger) bool {
+	r := c.Sink
+	el, err := r.TriggersClient.TriggersV1alpha1().EventListeners(r.EventListenerNamespace).Get(r.EventListenerName, metav1.GetOptions{})
+	if err != nil {
+		log.Errorf("Error getting EventListener %s in Namespace %s: %s", r.EventListenerName, r.EventListenerNamespace, err)
+		return false
+	}
+	if el.Spec.Maintenance != nil {
+		log.Info("EventListener is in maintenance, leaving message on the queue")
+		return false
+	}
+
+	body := []byte(m.Body)
+	request, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range m.Attributes {
+		request.Header.Set(name, value)
+	}
+	if el.Spec.SQS != nil {
+		for _, ah := range el.Spec.SQS.AttributeHeaders {
+			if value, ok := attributeValue(m, ah.Attribute); ok {
+				request.Header.Set(ah.Header, value)
+			}
+		}
+	}
+	log.Debugf("EventListener: %s in Namespace: %s handling SQS message (EventID: %s) with payload: %s and header: %v",
+		r.EventListenerName, r.EventListenerNamespace, eventID, m.Body, logging.RedactHeaders(request.Header))
+
+	code, triggerErrors, err := r.processEvent(el, request, body, eventID, log)
+	recordSourceEvent(sqsSourceName, code, triggerErrors, err)
+	if errors.Is(err, errDuplicateDelivery) {
+		log.Info("Deleting SQS message of a duplicate delivery")
+		return true
+	}
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if code == http.StatusUnauthorized || code == http.StatusForbidden || len(triggerErrors) > 0 {
+		log.Errorf("Processing SQS message failed with status %d: %v", code, triggerErrors)
+		return false
+	}
+	return true
+}
+
+// attributeValue returns the value of a message attribute, or of a metadata
+// field of the message if the name has the sqs: prefix.
+func attributeValue(m sqs.Message, name string) (string, bool) {
+	if !strings.HasPrefix(name, sqsMetadataPrefix) {
+		value, ok := m.Attributes[name]
+		return value, ok
+	}
+	name = strings.TrimPrefix(name, sqsMetadataPrefix)
+	if name == "MessageId" {
+		return m.MessageID, true
+	}
+	value, ok := m.SystemAttributes[name]
+	return value, ok
+}
+
+// extendVisibility keeps a message hidden from other consumers until done is
+// closed.
+func (c *SQSConsumer) extendVisibility(ctx context.Context, m sqs.Message, done <-chan struct{}, log *zap.SugaredLogger) {
+	ticker := time.NewTicker(c.VisibilityTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Queue.ChangeMessageVisibility(ctx, m.ReceiptHandle, c.VisibilityTimeout); err != nil {
+				log.Errorf("Error extending the visibility timeout of SQS message: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/sqs"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// fakeQueue delivers its messages on the first receive and then blocks until
+// the context is cancelled.
+type fakeQueue struct {
+	mu         sync.Mutex
+	messages   []sqs.Message
+	deleted    []string
+	extended   []string
+	deliveries int
+}
+
+func (q *fakeQueue) ReceiveMessages(ctx context.Context, max int, visibilityTimeout time.Duration) ([]sqs.Message, error) {
+	q.mu.Lock()
+	q.deliveries++
+	if q.deliveries == 1 {
+		defer q.mu.Unlock()
+		return q.messages, nil
+	}
+	q.mu.Unlock()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (q *fakeQueue) DeleteMessage(ctx context.Context, receiptHandle string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.deleted = append(q.deleted, receiptHandle)
+	return nil
+}
+
+func (q *fakeQueue) ChangeMessageVisibility(ctx context.Context, receiptHandle string, timeout time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.extended = append(q.extended, receiptHandle)
+	return nil
+}
+
+func sqsTestResources(el *triggersv1.EventListener) test.Resources {
+	return test.Resources{
+		TriggerBindings: []*triggersv1.TriggerBinding{
+			bldr.TriggerBinding("my-triggerbinding", namespace,
+				bldr.TriggerBindingSpec(bldr.TriggerBindingParam("url", "$(body.url)/$(header.X-Event)"))),
+		},
+		TriggerTemplates: []*triggersv1.TriggerTemplate{
+			bldr.TriggerTemplate("my-triggertemplate", namespace,
+				bldr.TriggerTemplateSpec(
+					bldr.TriggerTemplateParam("url", "", ""),
+					bldr.TriggerResourceTemplate(runtime.RawExtension{
+						Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"my-pipelineresource"},"spec":{"type":"git","params":[{"name":"url","value":"$(params.url)"}]}}`),
+					}),
+				)),
+		},
+		EventListeners: []*triggersv1.EventListener{el},
+	}
+}
+
+func TestSQSConsumer(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("my-trigger"),
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+	queue := &fakeQueue{messages: []sqs.Message{{
+		MessageID:     "ok",
+		ReceiptHandle: "handle-ok",
+		Body:          `{"url": "https://example.com"}`,
+		Attributes:    map[string]string{"X-Event": "push"},
+	}, {
+		// Left on the queue, since the binding refers to a missing field
+		MessageID:     "missing-url",
+		ReceiptHandle: "handle-missing-url",
+		Body:          `{}`,
+		Attributes:    map[string]string{"X-Event": "push"},
+	}}}
+	c := &SQSConsumer{Sink: sink, Queue: queue, BatchSize: 10, VisibilityTimeout: 30 * time.Second}
+
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		if err := c.Run(stopCh); err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+		close(done)
+	}()
+	if err := wait.PollImmediate(10*time.Millisecond, 10*time.Second, func() (bool, error) {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return queue.deliveries > 1, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	close(stopCh)
+	<-done
+
+	if diff := cmp.Diff([]string{"handle-ok"}, queue.deleted, cmpopts.SortSlices(func(x, y string) bool { return x < y })); diff != "" {
+		t.Errorf("Deleted messages mismatch (-want + got): %s", diff)
+	}
+	prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+	if len(prs) != 1 || prs[0].Spec.Params[0].Value != "https://example.com/push" {
+		t.Errorf("Created resources mismatch: %+v", prs)
+	}
+}
+
+func TestSQSConsumer_Failures(t *testing.T) {
+	tests := []struct {
+		name string
+		spec []bldr.EventListenerSpecOp
+	}{{
+		name: "maintenance",
+		spec: []bldr.EventListenerSpecOp{
+			bldr.EventListenerMaintenance(60),
+			bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+			),
+		},
+	}, {
+		name: "missing template",
+		spec: []bldr.EventListenerSpecOp{
+			bldr.EventListenerTrigger("missing", "v1alpha1",
+				bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+			),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(tt.spec...))
+			sink, dynamicClient := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+			queue := &fakeQueue{}
+			c := &SQSConsumer{Sink: sink, Queue: queue, BatchSize: 10, VisibilityTimeout: 30 * time.Second}
+
+			c.handleMessage(context.Background(), sqs.Message{
+				MessageID:     "id",
+				ReceiptHandle: "handle",
+				Body:          `{"url": "https://example.com"}`,
+			})
+			if len(queue.deleted) != 0 {
+				t.Errorf("Message was deleted after failing: %v", queue.deleted)
+			}
+			if len(dynamicClient.Actions()) != 0 {
+				t.Errorf("Unexpected actions: %v", dynamicClient.Actions())
+			}
+		})
+	}
+}
+
+func TestSQSConsumer_ExtendsVisibility(t *testing.T) {
+	queue := &fakeQueue{}
+	c := &SQSConsumer{Queue: queue, VisibilityTimeout: 20 * time.Millisecond}
+	sink, _ := getSinkAssets(t, test.Resources{}, "", DefaultAuthOverride{})
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(done)
+	}()
+	c.extendVisibility(context.Background(), sqs.Message{ReceiptHandle: "handle"}, done, sink.Logger)
+	if len(queue.extended) == 0 {
+		t.Error("Visibility timeout of the message was not extended")
+	}
+}
+
+func TestSQSConsumer_AttributeHeaders(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerSQS(triggersv1.SQSSource{
+			QueueURL:         "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+			AttributeHeaders: []triggersv1.AttributeHeader{{Attribute: "type", Header: "X-Event"}},
+		}),
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, sqsTestResources(el), el.Name, DefaultAuthOverride{})
+	queue := &fakeQueue{}
+	c := &SQSConsumer{Sink: sink, Queue: queue, BatchSize: 10, VisibilityTimeout: 30 * time.Second}
+
+	c.handleMessage(context.Background(), sqs.Message{
+		MessageID:     "id",
+		ReceiptHandle: "handle",
+		Body:          `{"url": "https://example.com"}`,
+		Attributes:    map[string]string{"type": "push"},
+	})
+	if diff := cmp.Diff([]string{"handle"}, queue.deleted); diff != "" {
+		t.Errorf("Deleted messages mismatch (-want + got): %s", diff)
+	}
+	prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+	if len(prs) != 1 || prs[0].Spec.Params[0].Value != "https://example.com/push" {
+		t.Errorf("Created resources mismatch: %+v", prs)
+	}
+}
+
+func TestAttributeValue(t *testing.T) {
+	m := sqs.Message{
+		MessageID:        "id",
+		Attributes:       map[string]string{"type": "push"},
+		SystemAttributes: map[string]string{"SentTimestamp": "1600000000000"},
+	}
+	tests := []struct {
+		name      string
+		attribute string
+		want      string
+		wantOK    bool
+	}{{
+		name:      "message attribute",
+		attribute: "type",
+		want:      "push",
+		wantOK:    true,
+	}, {
+		name:      "missing message attribute",
+		attribute: "action",
+	}, {
+		name:      "message ID",
+		attribute: "sqs:MessageId",
+		want:      "id",
+		wantOK:    true,
+	}, {
+		name:      "system attribute",
+		attribute: "sqs:SentTimestamp",
+		want:      "1600000000000",
+		wantOK:    true,
+	}, {
+		name:      "missing system attribute",
+		attribute: "sqs:MessageGroupId",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := attributeValue(m, tt.attribute)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("attributeValue(%q) = %q, %t, want %q, %t", tt.attribute, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"go.uber.org/zap"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// statusJitter spreads the status updates of the replicas of a sink, so that
+// they don't conflict with each other on every update.
+const statusJitter = 0.2
+
+// triggerCounts are the counters of a Trigger that are not written to the
+// status of the EventListener yet.
+type triggerCounts struct {
+	events      int64
+	created     int64
+	failed      int64
+	lastCreated time.Time
+}
+
+// StatusWriter batches the last event time and the counters of the Triggers
+// of an EventListener, and writes them to its status at most once per
+// interval, so that busy EventListeners don't load the API server.
+type StatusWriter struct {
+	client    triggersclientset.Interface
+	name      string
+	namespace string
+	interval  time.Duration
+	logger    *zap.SugaredLogger
+
+	mu        sync.Mutex
+	lastEvent time.Time
+	triggers  map[string]*triggerCounts
+}
+
+// NewStatusWriter returns a StatusWriter for the EventListener with the given
+// name and namespace that writes every interval.
+func NewStatusWriter(client triggersclientset.Interface, name, namespace string, interval time.Duration, logger *zap.SugaredLogger) *StatusWriter {
+	return &StatusWriter{
+		client:    client,
+		name:      name,
+		namespace: namespace,
+		interval:  interval,
+		logger:    logger,
+		triggers:  map[string]*triggerCounts{},
+	}
+}
+
+// RecordEvent records that the Sink received an event at t.
+func (w *StatusWriter) RecordEvent(t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t.After(w.lastEvent) {
+		w.lastEvent = t
+	}
+}
+
+// RecordTrigger records that the Trigger processed an event at t, and
+// whether it created resources or failed for a known reason.
+func (w *StatusWriter) RecordTrigger(trigger string, t time.Time, created, failed bool) {
+	if trigger == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	c, ok := w.triggers[trigger]
+	if !ok {
+		c = &triggerCounts{}
+		w.triggers[trigger] = c
+	}
+	c.events++
+	if created {
+		c.created++
+		if t.After(c.lastCreated) {
+			c.lastCreated = t
+		}
+	}
+	if failed {
+		c.failed++
+	}
+}
+
+// Run writes the recorded status every interval, with jitter, until stopCh
+// is closed, and then writes it a last time.
+func (w *StatusWriter) Run(stopCh <-chan struct{}) {
+	wait.JitterUntil(w.flush, w.interval, statusJitter, true, stopCh)
+	w.flush()
+}
+
+// flush writes the status recorded since the last write. It is recorded again
+// if the write fails for a reason other than missing permissions.
+func (w *StatusWriter) flush() {
+	w.mu.Lock()
+	lastEvent, triggers := w.lastEvent, w.triggers
+	w.lastEvent, w.triggers = time.Time{}, map[string]*triggerCounts{}
+	w.mu.Unlock()
+	if lastEvent.IsZero() && len(triggers) == 0 {
+		return
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return w.patch(lastEvent, triggers)
+	})
+	if err == nil {
+		return
+	}
+	// The ServiceAccount of the EventListener doesn't have to grant this
+	// permission
+	if kerrors.IsForbidden(err) {
+		w.logger.Debugf("Not permitted to update the EventListener status: %s", err)
+		return
+	}
+	w.logger.Errorf("Error updating the EventListener status: %s", err)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if lastEvent.After(w.lastEvent) {
+		w.lastEvent = lastEvent
+	}
+	for name, c := range triggers {
+		if pending, ok := w.triggers[name]; ok {
+			c.events += pending.events
+			c.created += pending.created
+			c.failed += pending.failed
+			if pending.lastCreated.After(c.lastCreated) {
+				c.lastCreated = pending.lastCreated
+			}
+		}
+		w.triggers[name] = c
+	}
+}
+
+// patch adds the counters to the current status of the EventListener. The
+// resourceVersion in the patch makes it fail with a conflict if another
+// replica updated the status in the meantime.
+func (w *StatusWriter) patch(lastEvent time.Time, triggers map[string]*triggerCounts) error {
+	el, err := w.client.TriggersV1alpha1().EventListeners(w.namespace).Get(w.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	status := map[string]interface{}{
+		"triggers": mergeTriggerStatus(el, triggers),
+	}
+	if !lastEvent.IsZero() && (el.Status.LastEventTime == nil || lastEvent.After(el.Status.LastEventTime.Time)) {
+		status["lastEventTime"] = metav1.NewTime(lastEvent)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": el.ResourceVersion,
+		},
+		"status": status,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.client.TriggersV1alpha1().EventListeners(w.namespace).Patch(w.name, types.MergePatchType, patch, "status")
+	return err
+}
+
+// mergeTriggerStatus adds the counters to the Trigger statuses of the
+// EventListener, in the order of its Triggers. Triggers that were removed from
+// the EventListener are dropped.
+func mergeTriggerStatus(el *triggersv1.EventListener, triggers map[string]*triggerCounts) []triggersv1.TriggerStatus {
+	current := map[string]triggersv1.TriggerStatus{}
+	for _, ts := range el.Status.Triggers {
+		current[ts.Name] = ts
+	}
+	seen := map[string]bool{}
+	var out []triggersv1.TriggerStatus
+	for _, t := range el.Spec.Triggers {
+		ts, ok := current[t.Name]
+		c, recorded := triggers[t.Name]
+		if (!ok && !recorded) || seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		ts.Name = t.Name
+		if recorded {
+			ts.Events += c.events
+			ts.Created += c.created
+			ts.Failed += c.failed
+			if !c.lastCreated.IsZero() && (ts.LastCreatedTime == nil || c.lastCreated.After(ts.LastCreatedTime.Time)) {
+				lastCreated := metav1.NewTime(c.lastCreated)
+				ts.LastCreatedTime = &lastCreated
+			}
+		}
+		out = append(out, ts)
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	faketriggersclientset "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func statusPatches(client *faketriggersclientset.Clientset) int {
+	patches := 0
+	for _, a := range client.Actions() {
+		if a.GetVerb() == "patch" && a.(ktesting.PatchAction).GetSubresource() == "status" {
+			patches++
+		}
+	}
+	return patches
+}
+
+func TestStatusWriter(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace,
+		bldr.EventListenerSpec(
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerTriggerName("push")),
+			bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerTriggerName("pull-request")),
+		))
+	client := faketriggersclientset.NewSimpleClientset(el)
+	w := NewStatusWriter(client, el.Name, namespace, time.Minute, zap.NewNop().Sugar())
+
+	first := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name    string
+		record  func()
+		patches int
+		want    triggersv1.EventListenerStatus
+	}{{
+		name:    "nothing recorded",
+		record:  func() {},
+		patches: 0,
+	}, {
+		name: "first batch",
+		record: func() {
+			w.RecordEvent(first)
+			w.RecordTrigger("push", first, true, false)
+			w.RecordEvent(first.Add(time.Second))
+			w.RecordTrigger("push", first.Add(time.Second), false, false)
+			w.RecordTrigger("pull-request", first.Add(time.Second), false, true)
+		},
+		patches: 1,
+		want: triggersv1.EventListenerStatus{
+			LastEventTime: &metav1.Time{Time: first.Add(time.Second)},
+			Triggers: []triggersv1.TriggerStatus{
+				{Name: "push", Events: 2, Created: 1, LastCreatedTime: &metav1.Time{Time: first}},
+				{Name: "pull-request", Events: 1, Failed: 1},
+			},
+		},
+	}, {
+		name: "second batch is added",
+		record: func() {
+			w.RecordEvent(first.Add(time.Minute))
+			w.RecordTrigger("push", first.Add(time.Minute), true, false)
+			w.RecordTrigger("unknown", first.Add(time.Minute), true, false)
+		},
+		patches: 2,
+		want: triggersv1.EventListenerStatus{
+			LastEventTime: &metav1.Time{Time: first.Add(time.Minute)},
+			Triggers: []triggersv1.TriggerStatus{
+				{Name: "push", Events: 3, Created: 2, LastCreatedTime: &metav1.Time{Time: first.Add(time.Minute)}},
+				{Name: "pull-request", Events: 1, Failed: 1},
+			},
+		},
+	}} {
+		tt.record()
+		w.flush()
+		if patches := statusPatches(client); patches != tt.patches {
+			t.Errorf("%s: status patched %d times, want %d", tt.name, patches, tt.patches)
+		}
+		got, err := client.TriggersV1alpha1().EventListeners(namespace).Get(el.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tt.want, got.Status); diff != "" {
+			t.Errorf("%s: -want +got: %s", tt.name, diff)
+		}
+	}
+}
+
+func TestStatusWriter_error(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace,
+		bldr.EventListenerSpec(bldr.EventListenerTrigger("tt", "v1alpha1", bldr.EventListenerTriggerName("push"))))
+	client := faketriggersclientset.NewSimpleClientset(el)
+	fail := true
+	client.PrependReactor("patch", "eventlisteners", func(action ktesting.Action) (bool, runtime.Object, error) {
+		return fail, nil, errors.New("unavailable")
+	})
+	w := NewStatusWriter(client, el.Name, namespace, time.Minute, zap.NewNop().Sugar())
+
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	w.RecordEvent(now)
+	w.RecordTrigger("push", now, true, false)
+	w.flush()
+	// The counters of a failed write are written with the next batch
+	fail = false
+	w.RecordTrigger("push", now, false, false)
+	w.flush()
+
+	got, err := client.TriggersV1alpha1().EventListeners(namespace).Get(el.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := triggersv1.EventListenerStatus{
+		LastEventTime: &metav1.Time{Time: now},
+		Triggers: []triggersv1.TriggerStatus{
+			{Name: "push", Events: 2, Created: 1, LastCreatedTime: &metav1.Time{Time: now}},
+		},
+	}
+	if diff := cmp.Diff(want, got.Status); diff != "" {
+		t.Errorf("-want +got: %s", diff)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// SenderTokenHeader is the request header in which trusted senders send the
+// token of the trustedSenders of the EventListener.
+const SenderTokenHeader = "Tekton-Sender-Token"
+
+// trustedSender returns how the sender of the request is trusted by the
+// EventListener, or an empty string if it is not trusted. The token header is
+// removed from the request, so that it is not passed on to the interceptors
+// and bindings.
+func (r Sink) trustedSender(el *triggersv1.EventListener, request *http.Request) (string, error) {
+	token := request.Header.Get(SenderTokenHeader)
+	request.Header.Del(SenderTokenHeader)
+	t := el.Spec.TrustedSenders
+	if t == nil {
+		return "", nil
+	}
+	// Only certificates verified with the client CA of the sink identify a
+	// sender
+	if request.TLS != nil && len(request.TLS.VerifiedChains) > 0 {
+		cn := request.TLS.VerifiedChains[0][0].Subject.CommonName
+		for _, name := range t.CommonNames {
+			if cn == name {
+				return "client certificate " + cn, nil
+			}
+		}
+	}
+	// Headers like X-Forwarded-For are set by the sender, so only the address
+	// of the connection identifies it
+	if ip := remoteIP(request.RemoteAddr); ip != nil {
+		for _, sourceRange := range t.SourceRanges {
+			if _, cidr, err := net.ParseCIDR(sourceRange); err == nil && cidr.Contains(ip) {
+				return "source range " + sourceRange, nil
+			}
+		}
+	}
+	if t.TokenSecret != nil && token != "" {
+		want, err := r.secretToken(el.Namespace, *t.TokenSecret)
+		if err != nil {
+			return "", err
+		}
+		if subtle.ConstantTimeCompare([]byte(token), want) == 1 {
+			return "token", nil
+		}
+	}
+	return "", nil
+}
+
+// remoteIP returns the IP address of the remote address of a request, or nil
+// for requests that were not received over HTTP.
+func remoteIP(remoteAddr string) net.IP {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return net.ParseIP(host)
+}
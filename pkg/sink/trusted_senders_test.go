@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestTrustedSender(t *testing.T) {
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrustedSenders(triggersv1.TrustedSenders{
+			CommonNames:  []string{"build-events"},
+			SourceRanges: []string{"10.0.0.0/8"},
+			TokenSecret:  &triggersv1.SecretRef{SecretName: "sender", SecretKey: "token"},
+		}),
+	))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sender", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	verified := func(cn string) *tls.ConnectionState {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	tests := []struct {
+		name       string
+		el         *triggersv1.EventListener
+		remoteAddr string
+		forwarded  string
+		tls        *tls.ConnectionState
+		token      string
+		want       string
+	}{{
+		name:       "no trusted senders",
+		el:         bldr.EventListener("my-eventlistener", namespace),
+		remoteAddr: "10.0.0.1:1234",
+		token:      "s3cr3t",
+	}, {
+		name:       "client certificate",
+		el:         el,
+		remoteAddr: "192.0.2.1:1234",
+		tls:        verified("build-events"),
+		want:       "client certificate build-events",
+	}, {
+		name:       "client certificate of another sender",
+		el:         el,
+		remoteAddr: "192.0.2.1:1234",
+		tls:        verified("deploy-events"),
+	}, {
+		name:       "unverified client certificate",
+		el:         el,
+		remoteAddr: "192.0.2.1:1234",
+		tls:        &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "build-events"}}}},
+	}, {
+		name:       "source range",
+		el:         el,
+		remoteAddr: "10.1.2.3:1234",
+		want:       "source range 10.0.0.0/8",
+	}, {
+		name:       "forwarded address in a source range",
+		el:         el,
+		remoteAddr: "192.0.2.1:1234",
+		forwarded:  "10.1.2.3",
+	}, {
+		name:       "token",
+		el:         el,
+		remoteAddr: "192.0.2.1:1234",
+		token:      "s3cr3t",
+		want:       "token",
+	}, {
+		name:       "wrong token",
+		el:         el,
+		remoteAddr: "192.0.2.1:1234",
+		token:      "guess",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, _ := getSinkAssets(t, test.Resources{Secrets: []*corev1.Secret{secret}}, el.Name, DefaultAuthOverride{})
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.TLS = tc.tls
+			if tc.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+			if tc.token != "" {
+				req.Header.Set(SenderTokenHeader, tc.token)
+			}
+			got, err := sink.trustedSender(tc.el, req)
+			if err != nil {
+				t.Fatalf("trustedSender() = %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("trustedSender() = %q, want %q", got, tc.want)
+			}
+			if req.Header.Get(SenderTokenHeader) != "" {
+				t.Errorf("trustedSender() kept the %s header", SenderTokenHeader)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_TrustedSender(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"ref"},"spec":{"type":"git"}}`)}),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrustedSenders(triggersv1.TrustedSenders{SourceRanges: []string{"10.0.0.0/8"}}),
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1", bldr.EventListenerTriggerName("my-trigger")),
+	))
+	el.Spec.Triggers[0].Interceptors = []*triggersv1.EventInterceptor{{
+		GitHub: &triggersv1.GitHubInterceptor{
+			SecretRef:  &triggersv1.SecretRef{SecretName: "github", SecretKey: "token"},
+			EventTypes: []string{"push"},
+		},
+	}}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		eventType  string
+		wantCode   int
+	}{{
+		name:       "trusted sender skips the signature",
+		remoteAddr: "10.1.2.3:1234",
+		eventType:  "push",
+		wantCode:   http.StatusCreated,
+	}, {
+		name:       "trusted sender is still filtered",
+		remoteAddr: "10.1.2.3:1234",
+		eventType:  "pull_request",
+		wantCode:   http.StatusAccepted,
+	}, {
+		name:       "other sender needs a signature",
+		remoteAddr: "192.0.2.1:1234",
+		eventType:  "push",
+		wantCode:   http.StatusAccepted,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sink, dynamicClient := getSinkAssets(t, test.Resources{
+				TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+				EventListeners:   []*triggersv1.EventListener{el},
+			}, el.Name, DefaultAuthOverride{})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{}`)))
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set("X-GitHub-Event", tc.eventType)
+			sink.HandleEvent(rec, req)
+			if rec.Code != tc.wantCode {
+				t.Fatalf("Response code = %d, want %d: %s", rec.Code, tc.wantCode, rec.Body.String())
+			}
+			wantCreated := 0
+			if tc.wantCode == http.StatusCreated {
+				wantCreated = 1
+			}
+			if prs := getCreatedPipelineResources(t, dynamicClient.Actions()); len(prs) != wantCreated {
+				t.Errorf("got %d created resources, want %d", len(prs), wantCreated)
+			}
+		})
+	}
+}
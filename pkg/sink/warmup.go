@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/interceptors"
+	"github.com/tektoncd/triggers/pkg/interceptors/cel"
+	"github.com/tektoncd/triggers/pkg/interceptors/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// warmUpTimeout limits how long the warm-up delays serving events.
+const warmUpTimeout = 30 * time.Second
+
+// WarmUp checks the interceptors of the Triggers of the EventListener before
+// the sink serves events, so that common misconfigurations are reported at
+// startup instead of failing every event: it compiles CEL expressions, reads
+// the Secrets that interceptors reference, and pings the Services of webhook
+// interceptors and ClusterInterceptors. It returns all the problems found.
+func (r Sink) WarmUp() []error {
+	ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+	defer cancel()
+	el, err := r.TriggersClient.TriggersV1alpha1().EventListeners(r.EventListenerNamespace).Get(r.EventListenerName, metav1.GetOptions{})
+	if err != nil {
+		return []error{fmt.Errorf("error getting the EventListener: %w", err)}
+	}
+	w := warmUp{sink: r, ctx: ctx, checked: map[string]bool{}}
+	for _, t := range el.Spec.Triggers {
+		chain, err := r.expandInterceptorChains(t.Interceptors)
+		if err != nil {
+			w.fail(t.Name, err)
+			continue
+		}
+		for _, i := range chain {
+			w.checkInterceptor(t.Name, i)
+		}
+	}
+	return w.errs
+}
+
+// warmUp holds the state of a warm-up. Secrets and Services referenced by
+// several Triggers are only checked once.
+type warmUp struct {
+	sink    Sink
+	ctx     context.Context
+	checked map[string]bool
+	errs    []error
+}
+
+func (w *warmUp) fail(trigger string, err error) {
+	w.errs = append(w.errs, fmt.Errorf("trigger %s: %w", trigger, err))
+}
+
+// once returns true the first time it is called with a key.
+func (w *warmUp) once(key string) bool {
+	if w.checked[key] {
+		return false
+	}
+	w.checked[key] = true
+	return true
+}
+
+func (w *warmUp) checkInterceptor(trigger string, i *triggersv1.EventInterceptor) {
+	switch {
+	case i.CEL != nil:
+		if i.CEL.Filter != "" {
+			if err := cel.Compile(i.CEL.Filter); err != nil {
+				w.fail(trigger, fmt.Errorf("invalid CEL filter %q: %w", i.CEL.Filter, err))
+			}
+		}
+		for _, o := range i.CEL.Overlays {
+			if err := cel.Compile(o.Expression); err != nil {
+				w.fail(trigger, fmt.Errorf("invalid CEL overlay %q: %w", o.Expression, err))
+			}
+		}
+	case i.Webhook != nil:
+		w.ping(trigger, i.Webhook)
+	case i.Ref != nil:
+		wh, _, err := w.sink.clusterInterceptorWebhook(i)
+		if err != nil {
+			w.fail(trigger, fmt.Errorf("ClusterInterceptor %s: %w", i.Ref.Name, err))
+			return
+		}
+		w.ping(trigger, wh)
+	}
+	for _, sr := range interceptorSecretRefs(i) {
+		w.checkSecret(trigger, sr)
+	}
+}
+
+func (w *warmUp) ping(trigger string, wh *triggersv1.WebhookInterceptor) {
+	if wh.ObjectRef == nil || !w.once(fmt.Sprintf("service/%s/%s", wh.ObjectRef.Namespace, wh.ObjectRef.Name)) {
+		return
+	}
+	if err := webhook.Ping(w.ctx, w.sink.HTTPClient, wh, w.sink.EventListenerNamespace); err != nil {
+		w.fail(trigger, fmt.Errorf("interceptor Service %s is unreachable: %w", wh.ObjectRef.Name, err))
+	}
+}
+
+func (w *warmUp) checkSecret(trigger string, sr *triggersv1.SecretRef) {
+	if !w.once(fmt.Sprintf("secret/%s/%s/%s", sr.Namespace, sr.SecretName, sr.SecretKey)) {
+		return
+	}
+	token, err := interceptors.GetSecretToken(w.sink.KubeClientSet, sr, w.sink.EventListenerNamespace)
+	switch {
+	case err != nil:
+		w.fail(trigger, fmt.Errorf("error reading Secret %s: %w", sr.SecretName, err))
+	case len(token) == 0:
+		w.fail(trigger, fmt.Errorf("Secret %s has no key %s", sr.SecretName, sr.SecretKey))
+	}
+}
+
+// interceptorSecretRefs returns the Secrets that the interceptor reads.
+func interceptorSecretRefs(i *triggersv1.EventInterceptor) []*triggersv1.SecretRef {
+	var refs []*triggersv1.SecretRef
+	switch {
+	case i.GitHub != nil:
+		refs = []*triggersv1.SecretRef{i.GitHub.SecretRef, i.GitHub.PreviousSecretRef}
+	case i.GitLab != nil:
+		refs = []*triggersv1.SecretRef{i.GitLab.SecretRef, i.GitLab.PreviousSecretRef}
+	case i.Bitbucket != nil:
+		refs = []*triggersv1.SecretRef{i.Bitbucket.SecretRef, i.Bitbucket.PreviousSecretRef}
+	case i.Sentry != nil:
+		refs = []*triggersv1.SecretRef{i.Sentry.SecretRef}
+	case i.Alert != nil:
+		refs = []*triggersv1.SecretRef{i.Alert.SecretRef}
+	case i.Scanner != nil:
+		refs = []*triggersv1.SecretRef{i.Scanner.SecretRef}
+	case i.SupplyChain != nil:
+		refs = []*triggersv1.SecretRef{i.SupplyChain.SecretRef, i.SupplyChain.PublicKeyRef}
+	case i.Captcha != nil:
+		refs = []*triggersv1.SecretRef{i.Captcha.SecretRef}
+	}
+	out := refs[:0]
+	for _, sr := range refs {
+		if sr != nil {
+			out = append(out, sr)
+		}
+	}
+	return out
+}
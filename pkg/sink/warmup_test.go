@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWarmUp(t *testing.T) {
+	pings := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pings++
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	// Redirect all requests to the fake server.
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(u)}}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-secret", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("secret")},
+	}
+	github := func(secretName string) *triggersv1.EventInterceptor {
+		return &triggersv1.EventInterceptor{GitHub: &triggersv1.GitHubInterceptor{
+			SecretRef: &triggersv1.SecretRef{SecretName: secretName, SecretKey: "token"},
+		}}
+	}
+	service := &triggersv1.EventInterceptor{Webhook: &triggersv1.WebhookInterceptor{
+		ObjectRef: &corev1.ObjectReference{Kind: "Service", APIVersion: "v1", Name: "checks"},
+	}}
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("tt", "v1alpha1",
+			bldr.EventListenerTriggerName("valid"),
+			bldr.EventListenerCELInterceptor(`body.action == "opened"`)),
+		bldr.EventListenerTrigger("tt", "v1alpha1",
+			bldr.EventListenerTriggerName("invalid-cel"),
+			bldr.EventListenerCELInterceptor(`body.action ==`)),
+		bldr.EventListenerTrigger("tt", "v1alpha1",
+			bldr.EventListenerTriggerName("missing-clusterinterceptor"),
+			bldr.EventListenerClusterInterceptor("gitlab")),
+	))
+	el.Spec.Triggers[0].Interceptors = append(el.Spec.Triggers[0].Interceptors, github("github-secret"), service)
+	el.Spec.Triggers[1].Interceptors = append(el.Spec.Triggers[1].Interceptors, github("missing-secret"), service)
+	sink, _ := getSinkAssets(t, test.Resources{
+		EventListeners: []*triggersv1.EventListener{el},
+		Secrets:        []*corev1.Secret{secret},
+	}, el.Name, DefaultAuthOverride{})
+	sink.HTTPClient = client
+
+	errs := sink.WarmUp()
+	want := []string{
+		`trigger invalid-cel: invalid CEL filter "body.action =="`,
+		`trigger invalid-cel: error reading Secret missing-secret`,
+		`trigger missing-clusterinterceptor: ClusterInterceptor gitlab`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("WarmUp() = %v, want %d errors", errs, len(want))
+	}
+	for i, err := range errs {
+		if !strings.HasPrefix(err.Error(), want[i]) {
+			t.Errorf("WarmUp() error %d = %q, want prefix %q", i, err, want[i])
+		}
+	}
+	// Services are pinged once, even if several Triggers use them
+	if pings != 1 {
+		t.Errorf("got %d pings, want 1", pings)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+// The keys that attributes and the text of elements with attributes or child
+// elements are stored under in the JSON form of an XML event.
+const (
+	xmlAttributePrefix = "-"
+	xmlTextKey         = "#text"
+)
+
+// isXMLContentType reports whether an event with the Content-Type is XML,
+// e.g. application/xml, text/xml or application/soap+xml.
+func isXMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// xmlToJSON converts an XML event to JSON, so that bindings and CEL
+// expressions can read it like any other event. The root element becomes the
+// only key of the JSON object, and each element becomes:
+//
+//   - a string with its text if it has neither attributes nor child elements
+//   - otherwise an object with its attributes under their name prefixed with -,
+//     its child elements under their name, and its text under #text
+//
+// Attributes are not prefixed with @ since JSONPath reads it as the current
+// object. Repeated child elements become an array. Names are used without their
+// namespace, namespace declarations are dropped and all values are strings.
+func xmlToJSON(event []byte) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(event))
+	var root map[string]interface{}
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML event: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root != nil {
+			return nil, errors.New("invalid XML event: more than one root element")
+		}
+		value, err := decodeXMLElement(d, start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML event: %w", err)
+		}
+		root = map[string]interface{}{start.Name.Local: value}
+	}
+	if root == nil {
+		return nil, errors.New("invalid XML event: no root element")
+	}
+	return json.Marshal(root)
+}
+
+// decodeXMLElement decodes the element that starts with start, up to and
+// including its end.
+func decodeXMLElement(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	fields := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		fields[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+	var text strings.Builder
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(d, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := fields[name].(type) {
+			case nil:
+				fields[name] = child
+			case []interface{}:
+				fields[name] = append(existing, child)
+			default:
+				fields[name] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			if len(fields) == 0 {
+				return value, nil
+			}
+			if value != "" {
+				fields[xmlTextKey] = value
+			}
+			return fields, nil
+		}
+	}
+}
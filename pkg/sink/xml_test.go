@@ -0,0 +1,148 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestIsXMLContentType(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"application/xml":                   true,
+		"text/xml; charset=utf-8":           true,
+		"application/soap+xml":              true,
+		"application/json":                  false,
+		"application/x-www-form-urlencoded": false,
+		"":                                  false,
+	} {
+		if got := isXMLContentType(contentType); got != want {
+			t.Errorf("isXMLContentType(%q) = %t, want %t", contentType, got, want)
+		}
+	}
+}
+
+func TestXMLToJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  string
+	}{{
+		name:  "text",
+		event: `<?xml version="1.0" encoding="utf-8"?><build>42</build>`,
+		want:  `{"build":"42"}`,
+	}, {
+		name:  "empty element",
+		event: `<build/>`,
+		want:  `{"build":""}`,
+	}, {
+		name:  "child elements",
+		event: `<build><id>42</id><result> succeeded </result></build>`,
+		want:  `{"build":{"id":"42","result":"succeeded"}}`,
+	}, {
+		name:  "attributes and text",
+		event: `<build id="42"><result reason="manual">succeeded</result></build>`,
+		want:  `{"build":{"-id":"42","result":{"#text":"succeeded","-reason":"manual"}}}`,
+	}, {
+		name:  "repeated elements",
+		event: `<build><commit>a</commit><commit>b</commit><commit>c</commit></build>`,
+		want:  `{"build":{"commit":["a","b","c"]}}`,
+	}, {
+		name:  "namespaces",
+		event: `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" xmlns="urn:tfs"><s:Body><Notify s:id="1">done</Notify></s:Body></s:Envelope>`,
+		want:  `{"Envelope":{"Body":{"Notify":{"#text":"done","-id":"1"}}}}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := xmlToJSON([]byte(tc.event))
+			if err != nil {
+				t.Fatalf("xmlToJSON() = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("xmlToJSON() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestXMLToJSON_error(t *testing.T) {
+	for _, event := range []string{
+		``,
+		`not xml`,
+		`<build><id>42</build>`,
+		`<build/><build/>`,
+	} {
+		if got, err := xmlToJSON([]byte(event)); err == nil {
+			t.Errorf("xmlToJSON(%q) = %s, wanted error", event, got)
+		}
+	}
+}
+
+func TestHandleEvent_XML(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateParam("revision", "", ""),
+			bldr.TriggerTemplateParam("status", "", ""),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"ref-$(params.revision)-$(params.status)"},"spec":{"type":"git"}}`)}),
+		))
+	tb := bldr.TriggerBinding("my-triggerbinding", namespace,
+		bldr.TriggerBindingSpec(
+			bldr.TriggerBindingParam("revision", "$(body.build.sourceVersion)"),
+			bldr.TriggerBindingParam("status", "$(body.build.-status)"),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("my-trigger"),
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+			bldr.EventListenerCELInterceptor(`body.build["-status"] == "succeeded" && header.match("Content-Type", "application/json")`),
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		TriggerBindings:  []*triggersv1.TriggerBinding{tb},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`<build status="succeeded"><sourceVersion>abc123</sourceVersion></build>`)))
+	req.Header.Set("Content-Type", "application/xml")
+	sink.HandleEvent(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Response code = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+	if len(prs) != 1 || prs[0].Name != "ref-abc123-succeeded" {
+		t.Errorf("got resources %v, want ref-abc123-succeeded", prs)
+	}
+
+	// Events that are not well-formed are rejected
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`<build>`)))
+	req.Header.Set("Content-Type", "text/xml")
+	sink.HandleEvent(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Response code = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	yamlv2 "gopkg.in/yaml.v2"
+	"sigs.k8s.io/yaml"
+)
+
+// isYAMLContentType reports whether an event with the Content-Type is YAML,
+// e.g. application/yaml, application/x-yaml, text/yaml or a type ending in
+// +yaml.
+func isYAMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+	return strings.HasSuffix(mediaType, "+yaml")
+}
+
+// yamlToJSON converts a YAML event to the equivalent JSON, so that bindings
+// and CEL expressions can read it like any other event. Keys that are not
+// strings, e.g. numbers or booleans, become strings. Events with several
+// documents or duplicate keys are rejected rather than converted partially.
+func yamlToJSON(event []byte) ([]byte, error) {
+	d := yamlv2.NewDecoder(bytes.NewReader(event))
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("invalid YAML event: no document")
+		}
+		return nil, fmt.Errorf("invalid YAML event: %w", err)
+	}
+	if err := d.Decode(&doc); err != io.EOF {
+		return nil, errors.New("invalid YAML event: more than one document")
+	}
+	converted, err := yaml.YAMLToJSONStrict(event)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML event: %w", err)
+	}
+	return converted, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/test"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestIsYAMLContentType(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"application/yaml":                  true,
+		"application/x-yaml; charset=utf-8": true,
+		"text/yaml":                         true,
+		"text/x-yaml":                       true,
+		"application/vnd.deploy+yaml":       true,
+		"application/json":                  false,
+		"application/xml":                   false,
+		"":                                  false,
+	} {
+		if got := isYAMLContentType(contentType); got != want {
+			t.Errorf("isYAMLContentType(%q) = %t, want %t", contentType, got, want)
+		}
+	}
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  string
+	}{{
+		name:  "mapping",
+		event: "build:\n  id: 42\n  status: succeeded\n",
+		want:  `{"build":{"id":42,"status":"succeeded"}}`,
+	}, {
+		name:  "sequence",
+		event: "commits:\n- a\n- b\n",
+		want:  `{"commits":["a","b"]}`,
+	}, {
+		name:  "keys that are not strings",
+		event: "1: one\ntrue: yes\n",
+		want:  `{"1":"one","true":true}`,
+	}, {
+		name:  "document start",
+		event: "---\nname: deploy\n",
+		want:  `{"name":"deploy"}`,
+	}, {
+		name:  "flow style",
+		event: `{"name": "deploy", "replicas": 2}`,
+		want:  `{"name":"deploy","replicas":2}`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := yamlToJSON([]byte(tc.event))
+			if err != nil {
+				t.Fatalf("yamlToJSON() = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("yamlToJSON() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestYAMLToJSON_error(t *testing.T) {
+	for _, event := range []string{
+		``,
+		"a: [b",
+		"a: 1\n---\nb: 2\n",
+		"a: 1\na: 2\n",
+	} {
+		if got, err := yamlToJSON([]byte(event)); err == nil {
+			t.Errorf("yamlToJSON(%q) = %s, wanted error", event, got)
+		}
+	}
+}
+
+func TestHandleEvent_YAML(t *testing.T) {
+	tt := bldr.TriggerTemplate("my-triggertemplate", namespace,
+		bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateParam("service", "", ""),
+			bldr.TriggerTemplateParam("version", "", ""),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1alpha1","kind":"PipelineResource","metadata":{"name":"$(params.service)-$(params.version)"},"spec":{"type":"git"}}`)}),
+		))
+	tb := bldr.TriggerBinding("my-triggerbinding", namespace,
+		bldr.TriggerBindingSpec(
+			bldr.TriggerBindingParam("service", "$(body.release.service)"),
+			bldr.TriggerBindingParam("version", "$(body.release.version)"),
+		))
+	el := bldr.EventListener("my-eventlistener", namespace, bldr.EventListenerSpec(
+		bldr.EventListenerTrigger("my-triggertemplate", "v1alpha1",
+			bldr.EventListenerTriggerName("my-trigger"),
+			bldr.EventListenerTriggerBinding("my-triggerbinding", "", "v1alpha1"),
+			bldr.EventListenerCELInterceptor(`body.release.approved && header.match("Content-Type", "application/json")`),
+		),
+	))
+	sink, dynamicClient := getSinkAssets(t, test.Resources{
+		TriggerTemplates: []*triggersv1.TriggerTemplate{tt},
+		TriggerBindings:  []*triggersv1.TriggerBinding{tb},
+		EventListeners:   []*triggersv1.EventListener{el},
+	}, el.Name, DefaultAuthOverride{})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("release:\n  service: api\n  version: v2\n  approved: true\n")))
+	req.Header.Set("Content-Type", "application/yaml")
+	sink.HandleEvent(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Response code = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	prs := getCreatedPipelineResources(t, dynamicClient.Actions())
+	if len(prs) != 1 || prs[0].Name != "api-v2" {
+		t.Errorf("got resources %v, want api-v2", prs)
+	}
+
+	// Events that are not valid YAML are rejected
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("release: [api")))
+	req.Header.Set("Content-Type", "text/yaml")
+	sink.HandleEvent(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Response code = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
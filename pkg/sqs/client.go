@@ -0,0 +1,243 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sqs is a minimal client for the Amazon SQS query API. It implements
+// the calls that an EventListener needs to consume a queue: receiving,
+// deleting and extending the visibility of messages.
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	apiVersion  = "2012-11-05"
+	serviceName = "sqs"
+	// MaxBatchSize is the maximum number of messages that SQS returns for a
+	// single receive.
+	MaxBatchSize = 10
+	// waitTimeSeconds is the long polling duration of a receive.
+	waitTimeSeconds = 20
+)
+
+// Message is a message received from a queue.
+type Message struct {
+	MessageID     string
+	ReceiptHandle string
+	Body          string
+	// Attributes holds the string and number message attributes of the
+	// message.
+	Attributes map[string]string
+	// SystemAttributes holds the metadata that SQS keeps for the message,
+	// e.g. SentTimestamp or ApproximateReceiveCount.
+	SystemAttributes map[string]string
+}
+
+// Client calls the SQS API of a single queue.
+type Client struct {
+	// QueueURL is the URL of the queue.
+	QueueURL string
+	// Region is the AWS region of the queue.
+	Region      string
+	Credentials *credentials.Credentials
+	HTTPClient  *http.Client
+}
+
+// NewClient returns a Client for the queue with the given URL. If region is
+// empty, it is taken from the queue URL. Credentials are resolved like in the
+// AWS SDK, from the environment (including the web identity token of IAM
+// roles for service accounts), the shared credentials file or the instance
+// metadata.
+func NewClient(queueURL, region string, httpClient *http.Client) (*Client, error) {
+	if region == "" {
+		var err error
+		if region, err = RegionFromURL(queueURL); err != nil {
+			return nil, err
+		}
+	}
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+	return &Client{
+		QueueURL:    queueURL,
+		Region:      region,
+		Credentials: sess.Config.Credentials,
+		HTTPClient:  httpClient,
+	}, nil
+}
+
+// RegionFromURL returns the region of a queue URL such as
+// https://sqs.us-east-1.amazonaws.com/123456789012/events.
+func RegionFromURL(queueURL string) (string, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid queue URL: %w", err)
+	}
+	parts := strings.Split(u.Hostname(), ".")
+	switch {
+	case len(parts) >= 4 && parts[0] == "sqs":
+		return parts[1], nil
+	case len(parts) >= 4 && parts[1] == "queue":
+		return parts[0], nil
+	}
+	return "", fmt.Errorf("cannot determine the region of queue URL %s", queueURL)
+}
+
+// ReceiveMessages long polls the queue for up to max messages. Received
+// messages are invisible to other consumers for visibilityTimeout.
+func (c *Client) ReceiveMessages(ctx context.Context, max int, visibilityTimeout time.Duration) ([]Message, error) {
+	params := url.Values{
+		"Action":                 {"ReceiveMessage"},
+		"MaxNumberOfMessages":    {strconv.Itoa(max)},
+		"VisibilityTimeout":      {strconv.Itoa(int(visibilityTimeout.Seconds()))},
+		"WaitTimeSeconds":        {strconv.Itoa(waitTimeSeconds)},
+		"MessageAttributeName.1": {"All"},
+		"AttributeName.1":        {"All"},
+	}
+	var resp receiveMessageResponse
+	if err := c.call(ctx, params, &resp); err != nil {
+		return nil, err
+	}
+	messages := make([]Message, 0, len(resp.Messages))
+	for _, m := range resp.Messages {
+		msg := Message{
+			MessageID:        m.MessageID,
+			ReceiptHandle:    m.ReceiptHandle,
+			Body:             m.Body,
+			Attributes:       map[string]string{},
+			SystemAttributes: map[string]string{},
+		}
+		for _, a := range m.MessageAttributes {
+			// Binary attributes can't be represented as headers
+			if !strings.HasPrefix(a.Value.DataType, "Binary") {
+				msg.Attributes[a.Name] = a.Value.StringValue
+			}
+		}
+		for _, a := range m.Attributes {
+			msg.SystemAttributes[a.Name] = a.Value
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// DeleteMessage deletes a received message from the queue.
+func (c *Client) DeleteMessage(ctx context.Context, receiptHandle string) error {
+	return c.call(ctx, url.Values{
+		"Action":        {"DeleteMessage"},
+		"ReceiptHandle": {receiptHandle},
+	}, nil)
+}
+
+// ChangeMessageVisibility makes a received message invisible to other
+// consumers for timeout from now.
+func (c *Client) ChangeMessageVisibility(ctx context.Context, receiptHandle string, timeout time.Duration) error {
+	return c.call(ctx, url.Values{
+		"Action":            {"ChangeMessageVisibility"},
+		"ReceiptHandle":     {receiptHandle},
+		"VisibilityTimeout": {strconv.Itoa(int(timeout.Seconds()))},
+	}, nil)
+}
+
+// call sends a signed request to the query API of the queue and decodes the
+// XML response into out, if set.
+func (c *Client) call(ctx context.Context, params url.Values, out interface{}) error {
+	params.Set("Version", apiVersion)
+	body := []byte(params.Encode())
+	req, err := http.NewRequest(http.MethodPost, c.QueueURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if _, err := v4.NewSigner(c.Credentials).Sign(req, bytes.NewReader(body), serviceName, c.Region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign %s request: %w", params.Get("Action"), err)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := xml.Unmarshal(payload, &errResp); err != nil || errResp.Error.Code == "" {
+			return fmt.Errorf("%s failed with status %d", params.Get("Action"), resp.StatusCode)
+		}
+		return &Error{Code: errResp.Error.Code, Message: errResp.Error.Message}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", params.Get("Action"), err)
+	}
+	return nil
+}
+
+// Error is an error returned by the SQS API.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+type receiveMessageResponse struct {
+	Messages []struct {
+		MessageID         string `xml:"MessageId"`
+		ReceiptHandle     string `xml:"ReceiptHandle"`
+		Body              string `xml:"Body"`
+		MessageAttributes []struct {
+			Name  string `xml:"Name"`
+			Value struct {
+				DataType    string `xml:"DataType"`
+				StringValue string `xml:"StringValue"`
+			} `xml:"Value"`
+		} `xml:"MessageAttribute"`
+		Attributes []struct {
+			Name  string `xml:"Name"`
+			Value string `xml:"Value"`
+		} `xml:"Attribute"`
+	} `xml:"ReceiveMessageResult>Message"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/google/go-cmp/cmp"
+)
+
+const receiveResponse = `<ReceiveMessageResponse>
+  <ReceiveMessageResult>
+    <Message>
+      <MessageId>id-1</MessageId>
+      <ReceiptHandle>handle-1</ReceiptHandle>
+      <Body>{"foo": "bar"}</Body>
+      <MessageAttribute>
+        <Name>X-Event</Name>
+        <Value><DataType>String</DataType><StringValue>push</StringValue></Value>
+      </MessageAttribute>
+      <MessageAttribute>
+        <Name>Blob</Name>
+        <Value><DataType>Binary</DataType><BinaryValue>AAEC</BinaryValue></Value>
+      </MessageAttribute>
+      <Attribute>
+        <Name>SentTimestamp</Name>
+        <Value>1600000000000</Value>
+      </Attribute>
+    </Message>
+    <Message>
+      <MessageId>id-2</MessageId>
+      <ReceiptHandle>handle-2</ReceiptHandle>
+      <Body>{}</Body>
+    </Message>
+  </ReceiveMessageResult>
+</ReceiveMessageResponse>`
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &Client{
+		QueueURL:    srv.URL + "/123456789012/events",
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		HTTPClient:  srv.Client(),
+	}
+}
+
+func TestReceiveMessages(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]string{
+			"Action":              "ReceiveMessage",
+			"MaxNumberOfMessages": "5",
+			"VisibilityTimeout":   "30",
+			"WaitTimeSeconds":     "20",
+			"AttributeName.1":     "All",
+			"Version":             apiVersion,
+		}
+		for k, v := range want {
+			if got := r.PostForm.Get(k); got != v {
+				t.Errorf("%s = %q, want %q", k, got, v)
+			}
+		}
+		if auth := r.Header.Get("Authorization"); !strings.Contains(auth, "/us-east-1/sqs/aws4_request") {
+			t.Errorf("request is not signed for sqs in us-east-1: %q", auth)
+		}
+		fmt.Fprint(w, receiveResponse)
+	})
+	got, err := c.ReceiveMessages(context.Background(), 5, 30*time.Second)
+	if err != nil {
+		t.Fatalf("ReceiveMessages() error: %v", err)
+	}
+	want := []Message{{
+		MessageID:        "id-1",
+		ReceiptHandle:    "handle-1",
+		Body:             `{"foo": "bar"}`,
+		Attributes:       map[string]string{"X-Event": "push"},
+		SystemAttributes: map[string]string{"SentTimestamp": "1600000000000"},
+	}, {
+		MessageID:        "id-2",
+		ReceiptHandle:    "handle-2",
+		Body:             "{}",
+		Attributes:       map[string]string{},
+		SystemAttributes: map[string]string{},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReceiveMessages() -want/+got: %s", diff)
+	}
+}
+
+func TestDeleteMessage(t *testing.T) {
+	var action, handle string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		action, handle = r.FormValue("Action"), r.FormValue("ReceiptHandle")
+		fmt.Fprint(w, "<DeleteMessageResponse></DeleteMessageResponse>")
+	})
+	if err := c.DeleteMessage(context.Background(), "handle-1"); err != nil {
+		t.Fatalf("DeleteMessage() error: %v", err)
+	}
+	if action != "DeleteMessage" || handle != "handle-1" {
+		t.Errorf("got Action %q and ReceiptHandle %q", action, handle)
+	}
+}
+
+func TestChangeMessageVisibility(t *testing.T) {
+	var action, timeout string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		action, timeout = r.FormValue("Action"), r.FormValue("VisibilityTimeout")
+		fmt.Fprint(w, "<ChangeMessageVisibilityResponse></ChangeMessageVisibilityResponse>")
+	})
+	if err := c.ChangeMessageVisibility(context.Background(), "handle-1", time.Minute); err != nil {
+		t.Fatalf("ChangeMessageVisibility() error: %v", err)
+	}
+	if action != "ChangeMessageVisibility" || timeout != "60" {
+		t.Errorf("got Action %q and VisibilityTimeout %q", action, timeout)
+	}
+}
+
+func TestCall_Error(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>ReceiptHandleIsInvalid</Code><Message>bad handle</Message></Error></ErrorResponse>`)
+	})
+	err := c.DeleteMessage(context.Background(), "handle-1")
+	var sqsErr *Error
+	if !errors.As(err, &sqsErr) || sqsErr.Code != "ReceiptHandleIsInvalid" {
+		t.Errorf("DeleteMessage() error = %v, want a ReceiptHandleIsInvalid error", err)
+	}
+}
+
+func TestRegionFromURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{{
+		url:  "https://sqs.us-east-1.amazonaws.com/123456789012/events",
+		want: "us-east-1",
+	}, {
+		url:  "https://eu-west-1.queue.amazonaws.com/123456789012/events",
+		want: "eu-west-1",
+	}, {
+		url:     "http://localhost:9324/queue/events",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := RegionFromURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegionFromURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RegionFromURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"net/http"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// CaptureEvent returns the values of the headers and body fields selected by
+// the capture, keyed by header.<name> and body.<path>. Headers and body fields
+// that are missing from the event are skipped.
+func CaptureEvent(c *triggersv1.EventCapture, body []byte, header http.Header) (map[string]string, error) {
+	event, err := NewEvent(body, header, nil)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(c.Headers)+len(c.Body))
+	for _, h := range c.Headers {
+		if v := header.Values(h); len(v) > 0 {
+			values["header."+h] = strings.Join(v, ",")
+		}
+	}
+	for _, path := range c.Body {
+		// Body paths are validated to be plain field paths, so they can only
+		// fail to evaluate if the field is missing
+		if v, err := ParseJSONPathValue(event, "$(body."+path+")"); err == nil {
+			values["body."+path] = v
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+func TestCaptureEvent(t *testing.T) {
+	body := json.RawMessage(`{"head_commit": {"id": "abc123", "message": "fix"}, "repository": {"name": "triggers", "url": "https://example.com/r?a=1&b=2"}, "size": 3, "title": "say \"hi\"\nand <bye>"}`)
+	header := http.Header{
+		"X-Github-Event": []string{"push"},
+		"X-Multi":        []string{"a", "b"},
+	}
+	capture := &triggersv1.EventCapture{
+		Headers: []string{"X-GitHub-Event", "X-Multi", "X-Missing"},
+		Body:    []string{"head_commit.message", "repository", "repository.url", "size", "title", "missing.field"},
+	}
+	got, err := CaptureEvent(capture, body, header)
+	if err != nil {
+		t.Fatalf("CaptureEvent() error: %v", err)
+	}
+	want := map[string]string{
+		"header.X-GitHub-Event":    "push",
+		"header.X-Multi":           "a,b",
+		"body.head_commit.message": "fix",
+		"body.repository":          `{"name":"triggers","url":"https://example.com/r?a=1&b=2"}`,
+		"body.repository.url":      "https://example.com/r?a=1&b=2",
+		"body.size":                "3",
+		"body.title":               "say \"hi\"\nand <bye>",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CaptureEvent() -want/+got: %s", diff)
+	}
+}
+
+func TestCaptureEvent_Error(t *testing.T) {
+	_, err := CaptureEvent(&triggersv1.EventCapture{Body: []string{"foo"}}, json.RawMessage(`{blahblah}`), nil)
+	var bodyErr *BodyError
+	if !errors.As(err, &bodyErr) {
+		t.Errorf("CaptureEvent() error = %v, want a *BodyError", err)
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package template resolves the bindings and templates of a Trigger.
+//
+// Binding evaluation can be used on its own, outside of an EventListener:
+// NewEvent builds an Event from the body, headers and extensions of an event,
+// and EvaluateBindings replaces the $(body.x), $(header.x) and
+// $(extensions.x) expressions in binding params with values from the Event.
+// Errors are returned as *BodyError, *ExpressionError and
+// *DuplicateParamError so that callers can inspect them with errors.As.
+package template
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import "fmt"
+
+// BodyError is returned when the body of an event is not valid JSON.
+type BodyError struct {
+	Err error
+}
+
+func (e *BodyError) Error() string {
+	return fmt.Sprintf("failed to unmarshal request body: %v", e.Err)
+}
+
+func (e *BodyError) Unwrap() error {
+	return e.Err
+}
+
+// ExpressionError is returned when an expression in the value of a binding
+// param cannot be evaluated against an event, e.g. because the field does
+// not exist.
+type ExpressionError struct {
+	// Param is the name of the param
+	Param string
+	// Expression is the expression as written in the param value, e.g.
+	// $(body.head_commit.id)
+	Expression string
+	Err        error
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("failed to replace JSONPath value for param %s: %s: %v", e.Param, e.Expression, e.Err)
+}
+
+func (e *ExpressionError) Unwrap() error {
+	return e.Err
+}
+
+// MissingFieldError is returned when a JSONPath expression refers to a field
+// or an array element that is not in its input.
+type MissingFieldError struct {
+	Err error
+}
+
+func (e *MissingFieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *MissingFieldError) Unwrap() error {
+	return e.Err
+}
+
+// DuplicateParamError is returned when more than one binding of a trigger
+// defines a param with the same name.
+type DuplicateParamError struct {
+	Name string
+	// Bindings names the two bindings that set the param, e.g.
+	// TriggerBinding/my-binding, unless a single binding sets it twice.
+	Bindings []string
+}
+
+func (e *DuplicateParamError) Error() string {
+	if len(e.Bindings) == 2 {
+		return fmt.Sprintf("duplicate param name: %s is set by both %s and %s", e.Name, e.Bindings[0], e.Bindings[1])
+	}
+	return fmt.Sprintf("duplicate param name: %s", e.Name)
+}
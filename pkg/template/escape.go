@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// jsonEscape returns s escaped as the contents of a JSON string, without the
+// surrounding quotes.
+func jsonEscape(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return ""
+	}
+	// Strip the quotes and the newline that the encoder appends
+	return string(b.Bytes()[1 : b.Len()-2])
+}
+
+// shellEscape returns s quoted as a single word for POSIX shells.
+func shellEscape(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// yamlEscape returns s quoted as a YAML double-quoted scalar. JSON strings
+// are valid YAML double-quoted scalars.
+func yamlEscape(s string) string {
+	return `"` + jsonEscape(s) + `"`
+}
+
+// EscapeParamValue returns the value of a param escaped for substitution into
+// a resource template. The resource templates are JSON, so the value is JSON
+// escaped after the given escape unless it is raw.
+func EscapeParamValue(value string, escape triggersv1.ParamEscape) string {
+	switch escape {
+	case triggersv1.RawEscape:
+		return value
+	case triggersv1.ShellEscape:
+		return jsonEscape(shellEscape(value))
+	case triggersv1.YAMLEscape:
+		return jsonEscape(yamlEscape(value))
+	case triggersv1.Base64Escape:
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	default:
+		return jsonEscape(value)
+	}
+}
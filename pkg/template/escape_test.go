@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// awkwardMessage is a commit message with every character that needs escaping
+// in JSON, shells or YAML.
+const awkwardMessage = "Fix \"quotes\" and 'apostrophes'\n\n" +
+	"* backslashes \\ and \\n, tabs\tand\r\ncarriage returns\n" +
+	"* $(params.other) $VAR `cmd` ${x} | & ; < > # ~ *\n" +
+	"* yaml: key: [a, b] {c: d} - e ! % @\n" +
+	"* control \x00\x07\x1b and unicode é 烈 \u2028 🚀"
+
+func TestEscapeParamValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		escape triggersv1.ParamEscape
+		want   string
+	}{{
+		name:  "default is json",
+		value: "say \"hi\"\n\\o/",
+		want:  `say \"hi\"\n\\o/`,
+	}, {
+		name:   "json",
+		value:  "<a&b>\t",
+		escape: triggersv1.JSONEscape,
+		want:   `<a&b>\t`,
+	}, {
+		name:   "shell",
+		value:  "it's $HOME",
+		escape: triggersv1.ShellEscape,
+		want:   `'it'\\''s $HOME'`,
+	}, {
+		name:   "yaml",
+		value:  "key: \"v\"\n",
+		escape: triggersv1.YAMLEscape,
+		want:   `\"key: \\\"v\\\"\\n\"`,
+	}, {
+		name:   "raw",
+		value:  `{"a": ["b"]}`,
+		escape: triggersv1.RawEscape,
+		want:   `{"a": ["b"]}`,
+	}, {
+		name:   "base64",
+		value:  "s3cr3t\n",
+		escape: triggersv1.Base64Escape,
+		want:   "czNjcjN0Cg==",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeParamValue(tt.value, tt.escape); got != tt.want {
+				t.Errorf("EscapeParamValue() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyParamsToResourceTemplate_Escapes(t *testing.T) {
+	params := []pipelinev1.Param{
+		bldr.Param("msg", awkwardMessage),
+		bldr.Param("other", "substituted"),
+		bldr.Param("obj", `{"a": ["b"]}`),
+	}
+	specs := bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+		bldr.TriggerTemplateParam("msg", "", ""),
+		bldr.TriggerTemplateEscapedParam("other", "", "", triggersv1.ShellEscape),
+		bldr.TriggerTemplateEscapedParam("obj", "", "", triggersv1.RawEscape),
+	)).Spec.Params
+	rt := json.RawMessage(`{
+		"json": "$(params.msg)",
+		"jsonEscape": "$(params.msg | jsonEscape)",
+		"shell": "echo $(params.msg | shellEscape)",
+		"yaml": "message: $(params.msg|yamlEscape)",
+		"raw": $(params.obj),
+		"spec": "$(params.other)",
+		"override": "$(params.other | jsonEscape)"
+	}`)
+
+	got := ApplyParamsToResourceTemplate(params, specs, rt)
+	var resource map[string]interface{}
+	if err := json.Unmarshal(got, &resource); err != nil {
+		t.Fatalf("ApplyParamsToResourceTemplate() returned invalid JSON: %s\n%s", err, got)
+	}
+	want := map[string]interface{}{
+		"json":       awkwardMessage,
+		"jsonEscape": awkwardMessage,
+		"shell":      "echo " + shellEscape(awkwardMessage),
+		"yaml":       "message: " + yamlEscape(awkwardMessage),
+		"raw":        map[string]interface{}{"a": []interface{}{"b"}},
+		"spec":       "'substituted'",
+		"override":   "substituted",
+	}
+	if diff := cmp.Diff(want, resource); diff != "" {
+		t.Errorf("ApplyParamsToResourceTemplate(): -want +got: %s", diff)
+	}
+
+	// The YAML scalar decodes to the message again
+	var yamlValue string
+	if err := json.Unmarshal([]byte(yamlEscape(awkwardMessage)), &yamlValue); err != nil || yamlValue != awkwardMessage {
+		t.Errorf("yamlEscape() = %s, does not decode to the message: %v", yamlEscape(awkwardMessage), err)
+	}
+}
+
+func TestResolveResources_AwkwardCommitMessage(t *testing.T) {
+	body, err := json.Marshal(map[string]interface{}{
+		"head_commit": map[string]interface{}{"message": awkwardMessage},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+		bldr.TriggerTemplateParam("message", "", ""),
+		bldr.TriggerResourceTemplate(runtime.RawExtension{
+			Raw: []byte(`{"kind": "ConfigMap", "apiVersion": "v1", "data": {"message": "$(params.message)", "labelled": "commit: $(params.message)"}}`),
+		}),
+	))
+	tb := bldr.TriggerBinding("tb", ns, bldr.TriggerBindingSpec(
+		bldr.TriggerBindingParam("message", "$(body.head_commit.message)"),
+	))
+	params, err := ResolveParams(ResolvedTrigger{TriggerBindings: []*triggersv1.TriggerBinding{tb}, TriggerTemplate: tt}, body, nil)
+	if err != nil {
+		t.Fatalf("ResolveParams() returned unexpected error: %s", err)
+	}
+	resources, err := ResolveResources(tt, params)
+	if err != nil {
+		t.Fatalf("ResolveResources() returned unexpected error: %s", err)
+	}
+	var cm struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(resources[0], &cm); err != nil {
+		t.Fatalf("ResolveResources() returned invalid JSON: %s\n%s", err, resources[0])
+	}
+	want := map[string]string{"message": awkwardMessage, "labelled": "commit: " + awkwardMessage}
+	if diff := cmp.Diff(want, cm.Data); diff != "" {
+		t.Errorf("ResolveResources(): -want +got: %s", diff)
+	}
+}
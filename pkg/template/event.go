@@ -18,6 +18,7 @@ package template
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -29,41 +30,127 @@ import (
 // ResolveParams takes given triggerbindings and produces the resulting
 // resource params.
 func ResolveParams(rt ResolvedTrigger, body []byte, header http.Header) ([]pipelinev1.Param, error) {
-	out, err := MergeBindingParams(rt.TriggerBindings, rt.ClusterTriggerBindings)
+	params, _, err := ResolveParamsWithMissingFields(rt, body, header)
+	return params, err
+}
+
+// ResolveParamsWithMissingFields resolves the params like ResolveParams, and
+// also returns an *ExpressionError for every binding param expression that
+// refers to a field that is not in the event, but that was handled according
+// to the OnMissingField policy of the trigger instead of failing.
+func ResolveParamsWithMissingFields(rt ResolvedTrigger, body []byte, header http.Header) ([]pipelinev1.Param, []*ExpressionError, error) {
+	event, err := NewEvent(body, header, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error merging trigger params: %w", err)
+		return nil, nil, err
 	}
+	return resolveEventParams(rt, event)
+}
+
+// ResolveEventParams merges the params of the bindings of the trigger,
+// evaluates them against the event and adds the defaults of the
+// TriggerTemplate params.
+func ResolveEventParams(rt ResolvedTrigger, event *Event) ([]pipelinev1.Param, error) {
+	params, _, err := resolveEventParams(rt, event)
+	return params, err
+}
 
-	out, err = applyEventValuesToParams(out, body, header)
+func resolveEventParams(rt ResolvedTrigger, event *Event) ([]pipelinev1.Param, []*ExpressionError, error) {
+	bindings := rt.Bindings
+	if bindings == nil {
+		bindings = legacyBindingOrder(rt.TriggerBindings, rt.ClusterTriggerBindings)
+	}
+	out, err := mergeBindings(bindings, rt.OnBindingConflict)
 	if err != nil {
-		return nil, fmt.Errorf("failed to ApplyEventValuesToParams: %w", err)
+		return nil, nil, fmt.Errorf("error merging trigger params: %w", err)
 	}
-	return MergeInDefaultParams(out, rt.TriggerTemplate.Spec.Params), nil
+
+	out, missing, err := evaluateBindings(out, mergeDefaults(bindings), event, rt.OnMissingField)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to ApplyEventValuesToParams: %w", err)
+	}
+	if rt.OnMissingField == triggersv1.MissingFieldDefault {
+		defaults := map[string]bool{}
+		for _, ps := range rt.TriggerTemplate.Spec.Params {
+			defaults[ps.Name] = ps.Default != nil
+		}
+		for _, m := range missing {
+			if !defaults[m.Param] {
+				return nil, nil, fmt.Errorf("failed to ApplyEventValuesToParams: %w, and the TriggerTemplate has no default for the param", m)
+			}
+		}
+	}
+	return MergeInDefaultParams(out, rt.TriggerTemplate.Spec.Params), missing, nil
 }
 
 // ResolveResources resolves a templated resource by replacing params with their values.
-func ResolveResources(template *triggersv1.TriggerTemplate, params []pipelinev1.Param) []json.RawMessage {
+// When the TriggerTemplate uses the gotemplate engine, each resource template is
+// rendered as a Go template instead.
+func ResolveResources(template *triggersv1.TriggerTemplate, params []pipelinev1.Param) ([]json.RawMessage, error) {
+	return ResolveResourcesWithUID(template, params, UID())
+}
+
+// ResolveResourcesWithUID resolves the resources like ResolveResources, with
+// $(uid) replaced by the given uid.
+func ResolveResourcesWithUID(template *triggersv1.TriggerTemplate, params []pipelinev1.Param, uid string) ([]json.RawMessage, error) {
+	return ResolveResourcesForTrigger(template, params, uid, TriggerContext{})
+}
+
+// ResolveResourcesForTrigger resolves the resources like
+// ResolveResourcesWithUID, with the $(tt.<name>) variables replaced by the
+// values of the TriggerContext.
+func ResolveResourcesForTrigger(template *triggersv1.TriggerTemplate, params []pipelinev1.Param, uid string, tc TriggerContext) ([]json.RawMessage, error) {
+	render := func(raw []byte) (json.RawMessage, error) {
+		if template.Spec.Engine == triggersv1.GoTemplateEngine {
+			return ApplyGoTemplateToResourceTemplate(params, raw, uid, tc)
+		}
+		// The context is applied first so that variables in param values
+		// are not replaced
+		rt := ApplyTriggerContextToResourceTemplate(raw, tc)
+		rt = ApplyParamsToResourceTemplate(params, template.Spec.Params, rt)
+		return ApplyUIDToResourceTemplate(rt, uid), nil
+	}
+	patches := make([]json.RawMessage, len(template.Spec.Patches))
+	for i := range template.Spec.Patches {
+		p, err := render(template.Spec.Patches[i].RawExtension.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render patch %d: %w", i, err)
+		}
+		patches[i] = p
+	}
 	resources := make([]json.RawMessage, len(template.Spec.ResourceTemplates))
-	uid := UID()
 	for i := range template.Spec.ResourceTemplates {
-		resources[i] = ApplyParamsToResourceTemplate(params, template.Spec.ResourceTemplates[i].RawExtension.Raw)
-		resources[i] = ApplyUIDToResourceTemplate(resources[i], uid)
+		rt, err := render(template.Spec.ResourceTemplates[i].RawExtension.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render resource template %d: %w", i, err)
+		}
+		if resources[i], err = ApplyPatchesToResource(rt, patches); err != nil {
+			return nil, fmt.Errorf("failed to patch resource template %d: %w", i, err)
+		}
 	}
-	return resources
+	return resources, nil
 }
 
-// event represents a HTTP event that Triggers processes
-type event struct {
+// Event is the input that bindings are evaluated against. Its fields are
+// available in binding values as $(header.<name>), $(body.<path>) and
+// $(extensions.<path>).
+type Event struct {
+	// Header holds the HTTP headers of the event, with the values of
+	// repeated headers joined by commas
 	Header map[string]string `json:"header"`
-	Body   interface{}       `json:"body"`
+	// Body is the decoded JSON body of the event
+	Body interface{} `json:"body"`
+	// Extensions holds additional fields that callers add to the event,
+	// e.g. values computed by interceptors
+	Extensions map[string]interface{} `json:"extensions"`
 }
 
-// newEvent returns a new Event from HTTP headers and body
-func newEvent(body []byte, headers http.Header) (*event, error) {
+// NewEvent returns a new Event from the HTTP body, headers and extensions of
+// an event. It returns a *BodyError if the body is not valid JSON.
+func NewEvent(body []byte, headers http.Header, extensions map[string]interface{}) (*Event, error) {
 	var data interface{}
 	if len(body) > 0 {
 		if err := json.Unmarshal(body, &data); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal request body: %w", err)
+			return nil, &BodyError{Err: err}
 		}
 	}
 	joinedHeaders := make(map[string]string, len(headers))
@@ -71,32 +158,75 @@ func newEvent(body []byte, headers http.Header) (*event, error) {
 		joinedHeaders[k] = strings.Join(v, ",")
 	}
 
-	return &event{
-		Header: joinedHeaders,
-		Body:   data,
+	return &Event{
+		Header:     joinedHeaders,
+		Body:       data,
+		Extensions: extensions,
 	}, nil
 }
 
-// applyEventValuesToParams returns a slice of Params with the JSONPath variables replaced
-// with values from the event body and headers.
-func applyEventValuesToParams(params []pipelinev1.Param, body []byte, header http.Header) ([]pipelinev1.Param, error) {
-	event, err := newEvent(body, header)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal event: %w", err)
-	}
+// EvaluateBindings returns a copy of the binding params with the $()
+// expressions in their values replaced with values from the event. It
+// returns an *ExpressionError for the first expression that cannot be
+// evaluated.
+func EvaluateBindings(params []pipelinev1.Param, event *Event) ([]pipelinev1.Param, error) {
+	out, _, err := evaluateBindings(params, nil, event, triggersv1.MissingFieldFail)
+	return out, err
+}
 
-	for idx, p := range params {
+// EvaluateBindingParams evaluates the params of a binding like
+// EvaluateBindings, except that params with a default take it if their value
+// refers to a field that is not in the event.
+func EvaluateBindingParams(params []triggersv1.BindingParam, event *Event) ([]pipelinev1.Param, error) {
+	b := newResolvedBinding("", "", params)
+	out, _, err := evaluateBindings(b.Params, b.Defaults, event, triggersv1.MissingFieldFail)
+	return out, err
+}
+
+// evaluateBindings evaluates the binding params like EvaluateBindings, but
+// handles expressions that refer to fields that are not in the event
+// according to the policy: they are replaced with empty strings, or the param
+// is left out. Params with a default in defaults take their default instead,
+// whatever the policy. The expressions handled by the policy are returned.
+func evaluateBindings(params []pipelinev1.Param, defaults map[string]string, event *Event, policy triggersv1.MissingFieldPolicy) ([]pipelinev1.Param, []*ExpressionError, error) {
+	out := make([]pipelinev1.Param, 0, len(params))
+	var missing []*ExpressionError
+params:
+	for _, p := range params {
 		pValue := p.Value.StringVal
+		omit := false
 		// Find all expressions wrapped in $() from the value
 		expressions, originals := findTektonExpressions(pValue)
 		for i, expr := range expressions {
-			val, err := ParseJSONPath(event, expr)
+			val, err := ParseJSONPathValue(event, expr)
 			if err != nil {
-				return nil, fmt.Errorf("failed to replace JSONPath value for param %s: %s: %w", p.Name, p.Value, err)
+				exprErr := &ExpressionError{Param: p.Name, Expression: originals[i], Err: err}
+				var mfErr *MissingFieldError
+				if !errors.As(err, &mfErr) {
+					return nil, nil, exprErr
+				}
+				if d, ok := defaults[p.Name]; ok {
+					out = append(out, pipelinev1.Param{
+						Name:  p.Name,
+						Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeString, StringVal: d},
+					})
+					continue params
+				}
+				if policy != triggersv1.MissingFieldEmpty && policy != triggersv1.MissingFieldDefault {
+					return nil, nil, exprErr
+				}
+				missing = append(missing, exprErr)
+				omit = omit || policy == triggersv1.MissingFieldDefault
 			}
 			pValue = strings.ReplaceAll(pValue, originals[i], val)
 		}
-		params[idx].Value = pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeString, StringVal: pValue}
+		if omit {
+			continue
+		}
+		out = append(out, pipelinev1.Param{
+			Name:  p.Name,
+			Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeString, StringVal: pValue},
+		})
 	}
-	return params, nil
+	return out, missing, nil
 }
@@ -18,6 +18,7 @@ package template
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -45,7 +46,7 @@ func toString(rawMessages []json.RawMessage) []string {
 	return stringMessages
 }
 
-func TestApplyEventValuesToParams(t *testing.T) {
+func TestEvaluateBindings_Event(t *testing.T) {
 	var objects = `{"a":"v","c":{"d":"e"},"empty": "","null": null, "number": 42}`
 	var arrays = `[{"a": "b"}, {"c": "d"}, {"e": "f"}]`
 	tests := []struct {
@@ -172,7 +173,11 @@ func TestApplyEventValuesToParams(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyEventValuesToParams(tt.params, tt.body, tt.header)
+			event, err := NewEvent(tt.body, tt.header, nil)
+			if err != nil {
+				t.Fatalf("NewEvent() error: %v", err)
+			}
+			got, err := EvaluateBindings(tt.params, event)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -183,7 +188,7 @@ func TestApplyEventValuesToParams(t *testing.T) {
 	}
 }
 
-func TestApplyEventValuesToParams_Error(t *testing.T) {
+func TestEvaluateBindings_EventError(t *testing.T) {
 	tests := []struct {
 		name   string
 		params []pipelinev1.Param
@@ -205,7 +210,11 @@ func TestApplyEventValuesToParams_Error(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := applyEventValuesToParams(tt.params, tt.body, tt.header)
+			event, err := NewEvent(tt.body, tt.header, nil)
+			if err != nil {
+				return
+			}
+			got, err := EvaluateBindings(tt.params, event)
 			if err == nil {
 				t.Errorf("did not get expected error - got: %v", got)
 			}
@@ -213,6 +222,104 @@ func TestApplyEventValuesToParams_Error(t *testing.T) {
 	}
 }
 
+func TestEvaluateBindings(t *testing.T) {
+	params := []pipelinev1.Param{
+		bldr.Param("sha", "$(body.sha)"),
+		bldr.Param("event", "$(header.X-Event)"),
+		bldr.Param("ref", "$(extensions.ref)"),
+		bldr.Param("url", "https://$(extensions.repo.host)/$(body.repo)"),
+		bldr.Param("branch", `$(header.x-branch ? header.x-branch : "main")`),
+	}
+	event, err := NewEvent(json.RawMessage(`{"sha": "abc123", "repo": "tektoncd/triggers"}`),
+		http.Header{"X-Event": []string{"push"}},
+		map[string]interface{}{
+			"ref":  "main",
+			"repo": map[string]interface{}{"host": "github.com"},
+		})
+	if err != nil {
+		t.Fatalf("NewEvent() error: %v", err)
+	}
+	got, err := EvaluateBindings(params, event)
+	if err != nil {
+		t.Fatalf("EvaluateBindings() error: %v", err)
+	}
+	want := []pipelinev1.Param{
+		bldr.Param("sha", "abc123"),
+		bldr.Param("event", "push"),
+		bldr.Param("ref", "main"),
+		bldr.Param("url", "https://github.com/tektoncd/triggers"),
+		bldr.Param("branch", "main"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EvaluateBindings() -want/+got: %s", diff)
+	}
+	if params[0].Value.StringVal != "$(body.sha)" {
+		t.Errorf("EvaluateBindings() modified its input params: %v", params)
+	}
+}
+
+func TestEvaluateBindings_Error(t *testing.T) {
+	params := []pipelinev1.Param{bldr.Param("foo", "prefix-$(body.missing)")}
+	event, err := NewEvent(json.RawMessage(`{}`), nil, nil)
+	if err != nil {
+		t.Fatalf("NewEvent() error: %v", err)
+	}
+	_, err = EvaluateBindings(params, event)
+	var exprErr *ExpressionError
+	if !errors.As(err, &exprErr) {
+		t.Fatalf("EvaluateBindings() error = %v, want an *ExpressionError", err)
+	}
+	if exprErr.Param != "foo" || exprErr.Expression != "$(body.missing)" {
+		t.Errorf("ExpressionError = %+v, want param foo and expression $(body.missing)", exprErr)
+	}
+}
+
+func TestEvaluateBindingParams(t *testing.T) {
+	withDefault := func(p triggersv1.BindingParam, def string) triggersv1.BindingParam {
+		p.Default = &def
+		return p
+	}
+	params := []triggersv1.BindingParam{
+		withDefault(bldr.BindingParam("sha", "$(body.sha)"), "unknown"),
+		withDefault(bldr.BindingParam("ref", "$(body.pull_request.head.ref)"), "main"),
+		withDefault(bldr.BindingParam("url", "https://$(header.X-Host)/$(body.repo)"), ""),
+	}
+	event, err := NewEvent(json.RawMessage(`{"sha": "abc123", "repo": "tektoncd/triggers"}`), nil, nil)
+	if err != nil {
+		t.Fatalf("NewEvent() error: %v", err)
+	}
+	got, err := EvaluateBindingParams(params, event)
+	if err != nil {
+		t.Fatalf("EvaluateBindingParams() error: %v", err)
+	}
+	want := []pipelinev1.Param{
+		bldr.Param("sha", "abc123"),
+		bldr.Param("ref", "main"),
+		bldr.Param("url", ""),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EvaluateBindingParams() -want/+got: %s", diff)
+	}
+
+	// Params without a default fail for fields that are not in the event
+	if _, err := EvaluateBindingParams([]triggersv1.BindingParam{bldr.BindingParam("ref", "$(body.ref)")}, event); err == nil {
+		t.Error("EvaluateBindingParams() did not fail for a missing field without a default")
+	}
+
+	// Defaults do not apply to expressions that are invalid
+	if _, err := EvaluateBindingParams([]triggersv1.BindingParam{withDefault(bldr.BindingParam("foo", "$(body.sha[)"), "bar")}, event); err == nil {
+		t.Error("EvaluateBindingParams() did not fail for an invalid expression with a default")
+	}
+}
+
+func TestNewEvent_Error(t *testing.T) {
+	_, err := NewEvent(json.RawMessage(`{blahblah}`), nil, nil)
+	var bodyErr *BodyError
+	if !errors.As(err, &bodyErr) {
+		t.Errorf("NewEvent() error = %v, want a *BodyError", err)
+	}
+}
+
 func TestResolveParams(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -323,7 +430,7 @@ func TestResolveParams(t *testing.T) {
 		}, {
 			Name: "param2",
 			Value: pipelinev1.ArrayOrString{
-				StringVal: "bar\\r\\nbaz",
+				StringVal: "bar\r\nbaz",
 				Type:      pipelinev1.ParamTypeString,
 			},
 		}},
@@ -394,6 +501,108 @@ func TestResolveParams_Error(t *testing.T) {
 	}
 }
 
+func TestResolveParamsWithMissingFields(t *testing.T) {
+	bindings := []*triggersv1.TriggerBinding{
+		bldr.TriggerBinding("tb", ns, bldr.TriggerBindingSpec(
+			bldr.TriggerBindingParam("p1", "$(body.foo)"),
+			bldr.TriggerBindingParam("p2", "ref-$(body.missing)"),
+			bldr.TriggerBindingParam("p3", "$(body.list[3])"),
+			bldr.TriggerBindingParamWithDefault("p4", "$(body.head.ref)", "fallback"),
+		)),
+	}
+	template := bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+		bldr.TriggerTemplateParam("p2", "", "main"),
+		bldr.TriggerTemplateParam("p3", "", "none"),
+	))
+	tests := []struct {
+		name        string
+		policy      triggersv1.MissingFieldPolicy
+		want        []pipelinev1.Param
+		wantMissing []string
+	}{{
+		name:   "empty",
+		policy: triggersv1.MissingFieldEmpty,
+		want: []pipelinev1.Param{
+			bldr.Param("p1", "bar"),
+			bldr.Param("p2", "ref-"),
+			bldr.Param("p3", ""),
+			bldr.Param("p4", "fallback"),
+		},
+		wantMissing: []string{"p2", "p3"},
+	}, {
+		name:   "default",
+		policy: triggersv1.MissingFieldDefault,
+		want: []pipelinev1.Param{
+			bldr.Param("p1", "bar"),
+			bldr.Param("p2", "main"),
+			bldr.Param("p3", "none"),
+			bldr.Param("p4", "fallback"),
+		},
+		wantMissing: []string{"p2", "p3"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := ResolvedTrigger{
+				TriggerBindings: bindings,
+				TriggerTemplate: template,
+				OnMissingField:  tt.policy,
+			}
+			params, missing, err := ResolveParamsWithMissingFields(rt, json.RawMessage(`{"foo": "bar", "list": []}`), nil)
+			if err != nil {
+				t.Fatalf("ResolveParamsWithMissingFields() returned unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tt.want, params, cmpopts.SortSlices(test.CompareParams)); diff != "" {
+				t.Errorf("didn't get expected params -want + got: %s", diff)
+			}
+			var gotMissing []string
+			for _, m := range missing {
+				gotMissing = append(gotMissing, m.Param)
+			}
+			if diff := cmp.Diff(tt.wantMissing, gotMissing); diff != "" {
+				t.Errorf("didn't get expected missing fields -want + got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveParamsWithMissingFields_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   triggersv1.MissingFieldPolicy
+		template *triggersv1.TriggerTemplate
+	}{{
+		name:     "unset policy fails",
+		template: bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(bldr.TriggerTemplateParam("p1", "", "main"))),
+	}, {
+		name:     "fail",
+		policy:   triggersv1.MissingFieldFail,
+		template: bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(bldr.TriggerTemplateParam("p1", "", "main"))),
+	}, {
+		name:     "default without a default value",
+		policy:   triggersv1.MissingFieldDefault,
+		template: bldr.TriggerTemplate("tt", ns),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := ResolvedTrigger{
+				TriggerBindings: []*triggersv1.TriggerBinding{
+					bldr.TriggerBinding("tb", ns, bldr.TriggerBindingSpec(
+						bldr.TriggerBindingParam("p1", "$(body.missing)"))),
+				},
+				TriggerTemplate: tt.template,
+				OnMissingField:  tt.policy,
+			}
+			params, _, err := ResolveParamsWithMissingFields(rt, json.RawMessage(`{}`), nil)
+			var mfErr *MissingFieldError
+			if !errors.As(err, &mfErr) {
+				t.Errorf("ResolveParamsWithMissingFields() = %v, %v, want a *MissingFieldError", params, err)
+			}
+		})
+	}
+}
+
 func TestResolveResources(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -436,7 +645,7 @@ func TestResolveResources(t *testing.T) {
 			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"rt1": "$(params.p1)"}`)}),
 		)),
 		params: []pipelinev1.Param{
-			bldr.Param("p1", `{"a": "v\\r\\n烈"}`),
+			bldr.Param("p1", `{"a": "v\r\n烈"}`),
 		},
 		want: []json.RawMessage{
 			json.RawMessage(`{"rt1": "{\"a\": \"v\\r\\n烈\"}"}`),
@@ -459,13 +668,59 @@ func TestResolveResources(t *testing.T) {
 			json.RawMessage(`{"rt1": "cbhtc"}`),
 			json.RawMessage(`{"rt2": "cbhtc"}`),
 		},
+	}, {
+		name: "gotemplate engine renders params and uid",
+		template: bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateEngine(triggersv1.GoTemplateEngine),
+			bldr.TriggerTemplateParam("p1", "desc", ""),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"rt1": "{{ .params.p1 | upper }}-{{ .uid }}"}`)}),
+		)),
+		params: []pipelinev1.Param{
+			bldr.Param("p1", "val1"),
+		},
+		want: []json.RawMessage{
+			json.RawMessage(`{"rt1":"VAL1-cbhtc"}`),
+		},
+	}, {
+		name: "patches are rendered and applied to every resource",
+		template: bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateParam("p1", "desc", ""),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "metadata": {"name": "run-$(uid)"}}`)}),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm", "labels": {"a": "b"}}}`)}),
+			bldr.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata": {"labels": {"team": "$(params.p1)"}}}`)}),
+			bldr.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata": {"annotations": {"uid": "$(uid)"}}}`)}),
+		)),
+		params: []pipelinev1.Param{
+			bldr.Param("p1", "val1"),
+		},
+		want: []json.RawMessage{
+			json.RawMessage(`{"apiVersion":"tekton.dev/v1beta1","kind":"PipelineRun","metadata":{"annotations":{"uid":"cbhtc"},"labels":{"team":"val1"},"name":"run-cbhtc"}}`),
+			json.RawMessage(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"annotations":{"uid":"cbhtc"},"labels":{"a":"b","team":"val1"},"name":"cm"}}`),
+		},
+	}, {
+		name: "gotemplate engine renders patches",
+		template: bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+			bldr.TriggerTemplateEngine(triggersv1.GoTemplateEngine),
+			bldr.TriggerTemplateParam("p1", "desc", ""),
+			bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}}`)}),
+			bldr.TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata": {"labels": {"team": "{{ .params.p1 | upper }}"}}}`)}),
+		)),
+		params: []pipelinev1.Param{
+			bldr.Param("p1", "val1"),
+		},
+		want: []json.RawMessage{
+			json.RawMessage(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"labels":{"team":"VAL1"},"name":"cm"}}`),
+		},
 	}}
 
 	for _, tt := range tests {
 		// Seeded for UID() to return "cbhtc"
 		utilrand.Seed(0)
 		t.Run(tt.name, func(t *testing.T) {
-			got := ResolveResources(tt.template, tt.params)
+			got, err := ResolveResources(tt.template, tt.params)
+			if err != nil {
+				t.Fatalf("ResolveResources() returned unexpected error: %s", err)
+			}
 			// Use toString so that it is easy to compare the json.RawMessage diffs
 			if diff := cmp.Diff(toString(tt.want), toString(got)); diff != "" {
 				t.Errorf("didn't get expected resource template -want + got: %s", diff)
@@ -0,0 +1,366 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// goTemplateFuncs is the sandboxed set of functions available to resource
+// templates rendered with the GoTemplate engine. The functions follow the
+// naming and argument order of their sprig equivalents, but nothing that
+// reaches outside of the template (env, files, network, time) is exposed.
+// Unlike sprig, squote escapes embedded single quotes the way shells do.
+var goTemplateFuncs = template.FuncMap{
+	"default":    defaultValue,
+	"empty":      empty,
+	"coalesce":   coalesce,
+	"ternary":    ternary,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"title":      strings.Title,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"trunc":      trunc,
+	"replace":    func(old, new, s string) string { return strings.Replace(s, old, new, -1) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"quote":      func(s interface{}) string { return strconv.Quote(stringValue(s)) },
+	"squote":     func(s interface{}) string { return shellEscape(stringValue(s)) },
+	"splitList":  func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":       join,
+	"list":       func(v ...interface{}) []interface{} { return v },
+	"append":     appendList,
+	"first":      first,
+	"last":       last,
+	"toString":   stringValue,
+	"toJson":     toJSON,
+	"fromJson":   fromJSON,
+	"atoi":       func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) },
+	"int":        toInt,
+	"add":        arith(func(a, b int64) (int64, error) { return a + b, nil }),
+	"sub":        arith(func(a, b int64) (int64, error) { return a - b, nil }),
+	"mul":        arith(func(a, b int64) (int64, error) { return a * b, nil }),
+	"div":        arith(div),
+	"mod":        arith(mod),
+	"b64enc":     func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"b64dec":     b64dec,
+	"sha256sum":  func(s string) string { h := sha256.Sum256([]byte(s)); return hex.EncodeToString(h[:]) },
+
+	// The escapes of param values of the default engine
+	"jsonEscape":  jsonEscape,
+	"shellEscape": shellEscape,
+	"yamlEscape":  yamlEscape,
+}
+
+// ApplyGoTemplateToResourceTemplate renders every string in the resource
+// template as a Go text/template. The params are available as .params, the
+// unique ID of the event as .uid and the TriggerContext as .tt, e.g.
+// .tt.triggerName. Rendering values rather than the raw JSON keeps the result
+// valid JSON regardless of what the templates produce. A value whose template
+// ends with an action that calls toJson is replaced by the JSON value it
+// renders, so that templates can produce numbers, booleans, lists and objects.
+func ApplyGoTemplateToResourceTemplate(params []pipelinev1.Param, rt json.RawMessage, uid string, tc TriggerContext) (json.RawMessage, error) {
+	var resource interface{}
+	if err := json.Unmarshal(rt, &resource); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource template: %w", err)
+	}
+	data := map[string]interface{}{
+		"params": paramValues(params),
+		"uid":    uid,
+		"tt":     tc.variables(),
+	}
+	rendered, err := renderValue(resource, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rendered)
+}
+
+func newGoTemplate(text string) (*template.Template, error) {
+	return template.New("resourcetemplate").Option("missingkey=error").Funcs(goTemplateFuncs).Parse(text)
+}
+
+func paramValues(params []pipelinev1.Param) map[string]interface{} {
+	values := make(map[string]interface{}, len(params))
+	for _, p := range params {
+		if p.Value.Type == pipelinev1.ParamTypeArray {
+			values[p.Name] = p.Value.ArrayVal
+		} else {
+			values[p.Name] = p.Value.StringVal
+		}
+	}
+	return values
+}
+
+func renderValue(v interface{}, data map[string]interface{}) (interface{}, error) {
+	switch val := v.(type) {
+	case string:
+		return renderString(val, data, true)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, e := range val {
+			key, err := renderString(k, data, false)
+			if err != nil {
+				return nil, err
+			}
+			if out[key.(string)], err = renderValue(e, data); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, e := range val {
+			var err error
+			if out[i], err = renderValue(e, data); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// renderString renders a string of the resource template. If typed is set and
+// the template ends with a toJson action, the JSON value that it renders is
+// returned instead of the string.
+func renderString(s string, data map[string]interface{}, typed bool) (interface{}, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	t, err := newGoTemplate(s)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	if typed && endsWithToJSON(t.Tree.Root) {
+		var value interface{}
+		if err := json.Unmarshal(buf.Bytes(), &value); err == nil {
+			return value, nil
+		}
+	}
+	return buf.String(), nil
+}
+
+// endsWithToJSON reports whether the last output of the template, apart from
+// whitespace, is an action whose pipeline ends with toJson.
+func endsWithToJSON(root *parse.ListNode) bool {
+	for i := len(root.Nodes) - 1; i >= 0; i-- {
+		switch n := root.Nodes[i].(type) {
+		case *parse.TextNode:
+			if len(bytes.TrimSpace(n.Text)) > 0 {
+				return false
+			}
+		case *parse.ActionNode:
+			if n.Pipe == nil || len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) == 0 {
+				return false
+			}
+			cmd := n.Pipe.Cmds[len(n.Pipe.Cmds)-1]
+			ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+			return ok && ident.Ident == "toJson"
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	}
+	return false
+}
+
+func defaultValue(d interface{}, given ...interface{}) interface{} {
+	if len(given) == 0 || empty(given[0]) {
+		return d
+	}
+	return given[0]
+}
+
+func coalesce(v ...interface{}) interface{} {
+	for _, val := range v {
+		if !empty(val) {
+			return val
+		}
+	}
+	return nil
+}
+
+func ternary(vt, vf interface{}, v bool) interface{} {
+	if v {
+		return vt
+	}
+	return vf
+}
+
+func trunc(n int, s string) string {
+	if n < 0 || len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+func join(sep string, v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return stringValue(v)
+	}
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = stringValue(rv.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+func appendList(l interface{}, v interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(l)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot append to %T", l)
+	}
+	out := make([]interface{}, rv.Len(), rv.Len()+1)
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return append(out, v), nil
+}
+
+func first(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() == 0 {
+		return nil
+	}
+	return rv.Index(0).Interface()
+}
+
+func last(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() == 0 {
+		return nil
+	}
+	return rv.Index(rv.Len() - 1).Interface()
+}
+
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	}
+	return fmt.Sprint(v)
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func fromJSON(s string) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal([]byte(s), &v)
+	return v, err
+}
+
+func b64dec(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+func toInt(v interface{}) (int64, error) {
+	switch val := v.(type) {
+	case string:
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return int64(f), nil
+		}
+		return 0, fmt.Errorf("unable to convert %q to an integer", val)
+	case int:
+		return int64(val), nil
+	case int64:
+		return val, nil
+	case float64:
+		return int64(val), nil
+	case bool:
+		if val {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("unable to convert %v to an integer", v)
+}
+
+func arith(op func(a, b int64) (int64, error)) func(a, b interface{}) (int64, error) {
+	return func(a, b interface{}) (int64, error) {
+		x, err := toInt(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := toInt(b)
+		if err != nil {
+			return 0, err
+		}
+		return op(x, y)
+	}
+}
+
+func div(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func mod(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a % b, nil
+}
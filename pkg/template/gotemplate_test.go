@@ -0,0 +1,149 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+)
+
+func TestApplyGoTemplateToResourceTemplate(t *testing.T) {
+	params := []pipelinev1.Param{
+		bldr.Param("name", "Tekton"),
+		bldr.Param("empty", ""),
+		bldr.Param("count", "3"),
+		bldr.Param("payload", `{"a":"b"}`),
+		bldr.Param("message", "it's\n\"done\""),
+		{Name: "list", Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeArray, ArrayVal: []string{"x", "y"}}},
+	}
+	tests := []struct {
+		name string
+		rt   string
+		want string
+	}{{
+		name: "no templates",
+		rt:   `{"foo": "bar", "num": 1, "ok": true}`,
+		want: `{"foo":"bar","num":1,"ok":true}`,
+	}, {
+		name: "params and uid",
+		rt:   `{"foo": "{{ .params.name }}-{{ .uid }}"}`,
+		want: `{"foo":"Tekton-abcde"}`,
+	}, {
+		name: "trigger context",
+		rt:   `{"foo": "{{ .tt.listenerName }}-{{ .tt.triggerName }}-{{ .tt.triggerNamespace }}"}`,
+		want: `{"foo":"listener-push-ci"}`,
+	}, {
+		name: "default",
+		rt:   `{"foo": "{{ .params.empty | default \"main\" }}"}`,
+		want: `{"foo":"main"}`,
+	}, {
+		name: "arithmetic",
+		rt:   `{"foo": "{{ add .params.count 2 }}"}`,
+		want: `{"foo":"5"}`,
+	}, {
+		name: "loops over array params",
+		rt:   `{"foo": "{{ range $i, $e := .params.list }}{{ if $i }},{{ end }}{{ $e | upper }}{{ end }}"}`,
+		want: `{"foo":"X,Y"}`,
+	}, {
+		name: "json values are escaped",
+		rt:   `{"foo": "{{ .params.payload }}"}`,
+		want: `{"foo":"{\"a\":\"b\"}"}`,
+	}, {
+		name: "escapes",
+		rt:   `{"shell": "echo {{ .params.message | shellEscape }}", "yaml": "msg: {{ .params.message | yamlEscape }}", "json": "{{ .params.message | jsonEscape }}"}`,
+		want: `{"json":"it's\\n\\\"done\\\"","shell":"echo 'it'\\''s\n\"done\"'","yaml":"msg: \"it's\\n\\\"done\\\"\""}`,
+	}, {
+		name: "squote escapes single quotes",
+		rt:   `{"foo": "echo {{ .params.message | squote }}"}`,
+		want: `{"foo":"echo 'it'\\''s\n\"done\"'"}`,
+	}, {
+		name: "loops render text within a value",
+		rt:   `{"foo": "{{ range .params.list }}- {{ . }}\n{{ end }}"}`,
+		want: `{"foo":"- x\n- y\n"}`,
+	}, {
+		name: "integer from toJson",
+		rt:   `{"replicas": "{{ add .params.count 2 | toJson }}", "count": "{{ .params.count }}"}`,
+		want: `{"count":"3","replicas":5}`,
+	}, {
+		name: "boolean from toJson",
+		rt:   `{"ok": "{{ eq .params.name \"Tekton\" | toJson }}"}`,
+		want: `{"ok":true}`,
+	}, {
+		name: "list built by a loop",
+		rt:   `{"args": "{{ $args := list }}{{ range .params.list }}{{ $args = append $args (upper .) }}{{ end }}{{ toJson $args }}"}`,
+		want: `{"args":["X","Y"]}`,
+	}, {
+		name: "object from toJson",
+		rt:   `{"obj": "{{ .params.payload | fromJson | toJson }}"}`,
+		want: `{"obj":{"a":"b"}}`,
+	}, {
+		name: "toJson within text renders a string",
+		rt:   `{"foo": "n-{{ toJson 1 }}", "{{ toJson 2 }}": "key"}`,
+		want: `{"2":"key","foo":"n-1"}`,
+	}, {
+		name: "keys and nested values",
+		rt:   `{"{{ .params.name | lower }}": [{"bar": "{{ sha256sum \"a\" | trunc 7 }}"}]}`,
+		want: `{"tekton":[{"bar":"ca97811"}]}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyGoTemplateToResourceTemplate(params, json.RawMessage(tt.rt), "abcde", TriggerContext{TriggerName: "push", TriggerNamespace: "ci", ListenerName: "listener"})
+			if err != nil {
+				t.Fatalf("ApplyGoTemplateToResourceTemplate() returned unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("ApplyGoTemplateToResourceTemplate() -want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyGoTemplateToResourceTemplate_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   string
+	}{{
+		name: "invalid json",
+		rt:   `{"foo": `,
+	}, {
+		name: "invalid template",
+		rt:   `{"foo": "{{ .params.name "}`,
+	}, {
+		name: "unknown function",
+		rt:   `{"foo": "{{ env \"HOME\" }}"}`,
+	}, {
+		name: "missing param",
+		rt:   `{"foo": "{{ .params.missing }}"}`,
+	}, {
+		name: "division by zero",
+		rt:   `{"foo": "{{ div 1 0 }}"}`,
+	}, {
+		name: "append to a string",
+		rt:   `{"foo": "{{ append \"a\" \"b\" | toJson }}"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := ApplyGoTemplateToResourceTemplate(nil, json.RawMessage(tt.rt), "abcde", TriggerContext{}); err == nil {
+				t.Errorf("ApplyGoTemplateToResourceTemplate() did not return error, got: %s", got)
+			}
+		})
+	}
+}
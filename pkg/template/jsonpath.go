@@ -9,6 +9,7 @@ import (
 	"net/textproto"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"k8s.io/client-go/util/jsonpath"
@@ -24,29 +25,78 @@ var (
 	jsonRegexp = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
 )
 
+// parseJSONModifier is the modifier that decodes a string holding JSON, e.g.
+// $(body.payload | parseJSON | .action)
+const parseJSONModifier = "parseJSON"
+
 // ParseJSONPath extracts a subset of the given JSON input
 // using the provided JSONPath expression.
+// The expression can be followed by stages separated by |, which are either
+// the parseJSON modifier or a JSONPath expression, starting with . or [, that
+// is evaluated against the result of the previous stage.
+// A single string result is JSON escaped, without the surrounding quotes.
 func ParseJSONPath(input interface{}, expr string) (string, error) {
-	j := jsonpath.New("").AllowMissingKeys(false)
-	buf := new(bytes.Buffer)
+	return parseJSONPath(input, expr, true)
+}
 
-	//First turn the expression into fully valid JSONPath
-	expr, err := TektonJSONPathExpression(expr)
-	if err != nil {
-		return "", err
+// ParseJSONPathValue extracts a subset of the given JSON input like
+// ParseJSONPath, but returns a single string result as it is. The value is
+// escaped where it is used instead, e.g. when it is substituted into a
+// resource template.
+func ParseJSONPathValue(input interface{}, expr string) (string, error) {
+	return parseJSONPath(input, expr, false)
+}
+
+func parseJSONPath(input interface{}, expr string, escape bool) (string, error) {
+	if !isTektonExpr(expr) {
+		return "", errors.New("expression not wrapped in $()")
 	}
+	unwrapped := strings.TrimSuffix(strings.TrimPrefix(expr, "$("), ")")
+	if c, ok := splitConditional(unwrapped); ok {
+		return evaluateConditional(input, c, escape)
+	}
+	stages := splitPipeline(unwrapped)
 
-	if err := j.Parse(expr); err != nil {
+	//First turn the expression into fully valid JSONPath
+	path, err := relaxedJSONPathExpression(stages[0])
+	if err != nil {
 		return "", err
 	}
-
-	fullResults, err := j.FindResults(input)
+	fullResults, err := findResults(input, path)
 	if err != nil {
 		return "", err
 	}
+	for _, stage := range stages[1:] {
+		value, err := singleResult(fullResults)
+		if err != nil {
+			return "", fmt.Errorf("cannot apply %q: %w", stage, err)
+		}
+		switch {
+		case stage == parseJSONModifier:
+			s, ok := value.(string)
+			if !ok {
+				return "", fmt.Errorf("%s expects a string, got %T", parseJSONModifier, value)
+			}
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+				return "", fmt.Errorf("%s: %w", parseJSONModifier, err)
+			}
+			fullResults = [][]reflect.Value{{reflect.ValueOf(&parsed).Elem()}}
+		case strings.HasPrefix(stage, ".") || strings.HasPrefix(stage, "["):
+			// Unlike the first stage, the path is not relaxed since
+			// subscripts of the result itself, e.g. [0], are not valid
+			// after a leading .
+			if fullResults, err = findResults(value, "{"+stage+"}"); err != nil {
+				return "", err
+			}
+		default:
+			return "", fmt.Errorf("unknown modifier %q", stage)
+		}
+	}
 
+	buf := new(bytes.Buffer)
 	for _, r := range fullResults {
-		if err := printResults(buf, r); err != nil {
+		if err := printResults(buf, r, escape); err != nil {
 			return "", err
 		}
 	}
@@ -54,9 +104,149 @@ func ParseJSONPath(input interface{}, expr string) (string, error) {
 	return buf.String(), nil
 }
 
+// findResults evaluates a JSONPath expression against the input. It returns
+// a *MissingFieldError if the expression refers to a field or an array element
+// that is not in the input.
+func findResults(input interface{}, expr string) ([][]reflect.Value, error) {
+	j := jsonpath.New("").AllowMissingKeys(false)
+	if err := j.Parse(expr); err != nil {
+		return nil, err
+	}
+
+	results, err := j.FindResults(input)
+	if err != nil && isMissingField(err) {
+		return nil, &MissingFieldError{Err: err}
+	}
+	return results, err
+}
+
+// isMissingField reports whether an error of the jsonpath package is caused by
+// a missing map key or an out of bounds array index. The package does not
+// return typed errors, so its messages are matched.
+func isMissingField(err error) bool {
+	msg := err.Error()
+	return strings.HasSuffix(msg, " is not found") || strings.HasPrefix(msg, "array index out of bounds")
+}
+
+// singleResult returns the value of JSONPath results with exactly one value.
+func singleResult(results [][]reflect.Value) (interface{}, error) {
+	if len(results) != 1 || len(results[0]) != 1 {
+		return nil, errors.New("expected a single value")
+	}
+	return results[0][0].Interface(), nil
+}
+
+// conditional is an expression of the form cond ? then : else.
+type conditional struct {
+	cond, then, els string
+}
+
+// splitConditional splits an expression at its first ? and the matching :,
+// ignoring those inside brackets and quotes, so that else may be another
+// conditional.
+func splitConditional(expr string) (conditional, bool) {
+	depth, nested, question := 0, 0, -1
+	var quote rune
+	for i, ch := range expr {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[' || ch == '(' || ch == '{':
+			depth++
+		case ch == ']' || ch == ')' || ch == '}':
+			depth--
+		case depth != 0:
+		case ch == '?' && question < 0:
+			question = i
+		case ch == '?':
+			nested++
+		case ch == ':' && question >= 0 && nested > 0:
+			nested--
+		case ch == ':' && question >= 0:
+			return conditional{
+				cond: strings.TrimSpace(expr[:question]),
+				then: strings.TrimSpace(expr[question+1 : i]),
+				els:  strings.TrimSpace(expr[i+1:]),
+			}, true
+		}
+	}
+	return conditional{}, false
+}
+
+// evaluateConditional returns the then branch of the conditional if its
+// condition holds, and the else branch otherwise. The condition holds if it
+// refers to a field in the input whose value is not null, false or an empty
+// string. The branches are expressions or quoted strings.
+func evaluateConditional(input interface{}, c conditional, escape bool) (string, error) {
+	if c.cond == "" || c.then == "" || c.els == "" {
+		return "", errors.New("conditional expects the form cond ? then : else")
+	}
+	branch := c.els
+	v, err := parseJSONPath(input, "$("+c.cond+")", false)
+	var mfErr *MissingFieldError
+	switch {
+	case errors.As(err, &mfErr):
+	case err != nil:
+		return "", err
+	case v != "" && v != "null" && v != "false":
+		branch = c.then
+	}
+	if literal, ok := unquote(branch); ok {
+		b, err := getResults([]reflect.Value{reflect.ValueOf(literal)}, escape)
+		return string(b), err
+	}
+	return parseJSONPath(input, "$("+branch+")", escape)
+}
+
+// unquote returns the value of a string in double or single quotes. Double
+// quoted strings may contain Go escape sequences.
+func unquote(s string) (string, bool) {
+	if len(s) < 2 {
+		return "", false
+	}
+	switch {
+	case s[0] == '"' && s[len(s)-1] == '"':
+		v, err := strconv.Unquote(s)
+		return v, err == nil
+	case s[0] == '\'' && s[len(s)-1] == '\'':
+		return s[1 : len(s)-1], true
+	}
+	return "", false
+}
+
+// splitPipeline splits an expression into its stages separated by |,
+// ignoring | inside brackets and quotes.
+func splitPipeline(expr string) []string {
+	var stages []string
+	depth, start := 0, 0
+	var quote rune
+	for i, ch := range expr {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[' || ch == '(' || ch == '{':
+			depth++
+		case ch == ']' || ch == ')' || ch == '}':
+			depth--
+		case ch == '|' && depth == 0:
+			stages = append(stages, strings.TrimSpace(expr[start:i]))
+			start = i + 1
+		}
+	}
+	return append(stages, strings.TrimSpace(expr[start:]))
+}
+
 // PrintResults writes the results into writer
-func printResults(wr io.Writer, values []reflect.Value) error {
-	results, err := getResults(values)
+func printResults(wr io.Writer, values []reflect.Value, escape bool) error {
+	results, err := getResults(values, escape)
 	if err != nil {
 		return fmt.Errorf("error getting values for jsonpath results: %w", err)
 	}
@@ -67,13 +257,27 @@ func printResults(wr io.Writer, values []reflect.Value) error {
 	return nil
 }
 
-func getResults(values []reflect.Value) ([]byte, error) {
+// marshalUnescaped returns the JSON encoding of v without escaping HTML
+// characters, so that values like URLs keep their & in objects and arrays.
+func marshalUnescaped(v interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func getResults(values []reflect.Value, escape bool) ([]byte, error) {
 	if len(values) == 1 {
 		v := values[0]
 		t := reflect.TypeOf(v.Interface())
 		switch {
 		case t == nil:
 			return []byte("null"), nil
+		case t.Kind() == reflect.String && !escape:
+			return []byte(v.Interface().(string)), nil
 		case t.Kind() == reflect.String:
 			b, err := json.Marshal(v.Interface())
 			if err != nil {
@@ -83,6 +287,8 @@ func getResults(values []reflect.Value) ([]byte, error) {
 			// create a representation of the json value that can be embedded in a CRD definition and
 			// we want to leave it up to the user if they want the surrounding quotation marks or not.
 			return b[1 : len(b)-1], nil
+		case !escape:
+			return marshalUnescaped(v.Interface())
 		default:
 			return json.Marshal(v.Interface())
 		}
@@ -99,6 +305,9 @@ func getResults(values []reflect.Value) ([]byte, error) {
 			results = append(results, r.Interface())
 		}
 	}
+	if !escape {
+		return marshalUnescaped(results)
+	}
 	return json.Marshal(results)
 }
 
@@ -174,10 +383,7 @@ func findTektonExpressions(in string) ([]string, []string) {
 				if numOpenBrackets < 0 {
 					raw := e[:i]
 					originals = append(originals, fmt.Sprintf("$(%s)", raw))
-					if strings.Index(raw, "header.") == 0 {
-						raw = "header." + textproto.CanonicalMIMEHeaderKey(raw[len("header."):])
-					}
-					results = append(results, fmt.Sprintf("$(%s)", raw))
+					results = append(results, fmt.Sprintf("$(%s)", canonicalHeaders(raw)))
 				}
 			default:
 				continue
@@ -189,3 +395,18 @@ func findTektonExpressions(in string) ([]string, []string) {
 	}
 	return results, originals
 }
+
+// canonicalHeaders canonicalizes the header names that an expression, or the
+// parts of a conditional, refer to.
+func canonicalHeaders(raw string) string {
+	if c, ok := splitConditional(raw); ok {
+		return fmt.Sprintf("%s ? %s : %s", canonicalHeaders(c.cond), canonicalHeaders(c.then), canonicalHeaders(c.els))
+	}
+	if strings.Index(raw, "header.") != 0 {
+		return raw
+	}
+	// Only the header name is canonicalized, not the stages that follow it
+	stages := splitPipeline(raw)
+	stages[0] = "header." + textproto.CanonicalMIMEHeaderKey(stages[0][len("header."):])
+	return strings.Join(stages, " | ")
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -88,6 +89,26 @@ func TestParseJSONPath(t *testing.T) {
 		in:   `{"body":{"child":[{"a": "b", "w": "1"}, {"a": "c", "w": "2"}, {"a": "d", "w": "3"}]}}`,
 		expr: "$(body.child[?(@.a == 'd')].w)",
 		want: "3",
+	}, {
+		name: "parseJSON",
+		in:   `{"body": {"payload": "{\"action\": \"opened\", \"number\": 1}"}}`,
+		expr: "$(body.payload | parseJSON)",
+		want: `{"action":"opened","number":1}`,
+	}, {
+		name: "parseJSON with path",
+		in:   `{"body": {"payload": "{\"action\": \"opened\", \"number\": 1}"}}`,
+		expr: "$(body.payload | parseJSON | .action)",
+		want: "opened",
+	}, {
+		name: "parseJSON with array filter",
+		in:   `{"body": {"payload": "[{\"a\": \"b|c\"}, {\"a\": \"d\"}]"}}`,
+		expr: "$(body.payload|parseJSON|[?(@.a == 'b|c')].a)",
+		want: "b|c",
+	}, {
+		name: "parseJSON null",
+		in:   `{"body": {"payload": "null"}}`,
+		expr: "$(body.payload | parseJSON)",
+		want: "null",
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -107,6 +128,102 @@ func TestParseJSONPath(t *testing.T) {
 	}
 }
 
+func TestParseJSONPath_MissingField(t *testing.T) {
+	data := map[string]interface{}{"body": map[string]interface{}{"key": "val", "list": []interface{}{"a"}}}
+	tests := []struct {
+		expr        string
+		wantMissing bool
+	}{
+		{expr: "$(body.missing)", wantMissing: true},
+		{expr: "$(body.list[1])", wantMissing: true},
+		{expr: `$(body.key | parseJSON)`, wantMissing: false},
+		{expr: "$(body.key[0])", wantMissing: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseJSONPath(data, tt.expr)
+			var mfErr *MissingFieldError
+			if got := errors.As(err, &mfErr); got != tt.wantMissing {
+				t.Errorf("ParseJSONPath() error = %v, want a *MissingFieldError: %t", err, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestParseJSONPath_Conditional(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"body": {"ref": "dev", "empty": "", "null": null, "draft": false, "pr": {"head": "feature"}, "quote": "a\"b"}}`), &data); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{{
+		name: "present field",
+		expr: `$(body.ref ? body.ref : "main")`,
+		want: "dev",
+	}, {
+		name: "missing field",
+		expr: `$(body.missing ? body.missing : "main")`,
+		want: "main",
+	}, {
+		name: "empty string",
+		expr: `$(body.empty ? body.empty : 'main')`,
+		want: "main",
+	}, {
+		name: "null",
+		expr: `$(body.null ? body.null : "main")`,
+		want: "main",
+	}, {
+		name: "false",
+		expr: `$(body.draft ? "draft" : "ready")`,
+		want: "ready",
+	}, {
+		name: "other field",
+		expr: `$(body.pr ? body.pr.head : body.ref)`,
+		want: "feature",
+	}, {
+		name: "chained",
+		expr: `$(body.missing ? body.missing : body.empty ? body.empty : body.ref ? body.ref : "main")`,
+		want: "dev",
+	}, {
+		name: "stages in a branch",
+		expr: `$(body.pr ? body.pr | .head : "none")`,
+		want: "feature",
+	}, {
+		name: "escaped result",
+		expr: `$(body.missing ? "x" : body.quote)`,
+		want: `a\"b`,
+	}, {
+		name: "escaped literal",
+		expr: `$(body.missing ? "x" : "a\"b")`,
+		want: `a\"b`,
+	}, {
+		name: "question mark in a literal",
+		expr: `$(body.ref ? "why?" : "because:")`,
+		want: "why?",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJSONPath(data, tt.expr)
+			if err != nil {
+				t.Fatalf("ParseJSONPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseJSONPath() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// A missing field in the branch that is taken is still missing
+	_, err := ParseJSONPath(data, `$(body.ref ? body.missing : "main")`)
+	var mfErr *MissingFieldError
+	if !errors.As(err, &mfErr) {
+		t.Errorf("ParseJSONPath() error = %v, want a *MissingFieldError", err)
+	}
+}
+
 func TestParseJSONPath_Error(t *testing.T) {
 	testJSON := `{"body": {"key": "val"}}`
 	invalidExprs := []string{
@@ -118,6 +235,12 @@ func TestParseJSONPath_Error(t *testing.T) {
 		"body",
 		"$(body.missing)",
 		"$(body.key[0])",
+		"$(body | parseJSON)",
+		"$(body.key | parseJSON)",
+		"$(body.key | unknown)",
+		`$(body.key ? : "fallback")`,
+		`$(body.key ? "a" : )`,
+		`$(body.key ? body.missing : "fallback")`,
 	}
 	var data interface{}
 	err := json.Unmarshal([]byte(testJSON), &data)
@@ -248,6 +371,14 @@ func TestFindTektonExpressions(t *testing.T) {
 		in:       "start:$(body.[?(@.a == 'd')])-$(body.another-one)",
 		want:     []string{"$(body.[?(@.a == 'd')])", "$(body.another-one)"},
 		original: []string{"$(body.[?(@.a == 'd')])", "$(body.another-one)"},
+	}, {
+		in:       "$(header.x-payload | parseJSON | .action)",
+		want:     []string{"$(header.X-Payload | parseJSON | .action)"},
+		original: []string{"$(header.x-payload | parseJSON | .action)"},
+	}, {
+		in:       `$(header.x-ref ? header.x-ref : body.ref ? body.ref : "main")`,
+		want:     []string{`$(header.X-Ref ? header.X-Ref : body.ref ? body.ref : "main")`},
+		original: []string{`$(header.x-ref ? header.x-ref : body.ref ? body.ref : "main")`},
 	}, {
 		in:       "$(this)-$(not-this",
 		want:     []string{"$(this)"},
@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	pipelinev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// patchScheme holds the types whose patch strategies are known, i.e. the
+// Kubernetes and Pipelines types.
+var patchScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(patchScheme))
+	utilruntime.Must(pipelinev1alpha1.AddToScheme(patchScheme))
+	utilruntime.Must(pipelinev1.AddToScheme(patchScheme))
+}
+
+// ApplyPatchesToResource applies the patches in order to a rendered resource.
+// Resources of known types are patched with a strategic merge patch, so that
+// e.g. a patch of the containers of a Pod merges them by name, and resources
+// of other types with a JSON merge patch.
+func ApplyPatchesToResource(rt json.RawMessage, patches []json.RawMessage) (json.RawMessage, error) {
+	if len(patches) == 0 {
+		return rt, nil
+	}
+	var tm metav1.TypeMeta
+	if err := json.Unmarshal(rt, &tm); err != nil {
+		return nil, err
+	}
+	typed, err := patchScheme.New(schema.FromAPIVersionAndKind(tm.APIVersion, tm.Kind))
+	strategic := err == nil
+	for _, p := range patches {
+		if strategic {
+			rt, err = strategicpatch.StrategicMergePatch(rt, p, typed)
+		} else {
+			rt, err = jsonpatch.MergePatch(rt, p)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rt, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApplyPatchesToResource(t *testing.T) {
+	pod := `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod"}, "spec": {"containers": [{"name": "a", "image": "a"}, {"name": "b", "image": "b"}]}}`
+	for _, tc := range []struct {
+		name    string
+		rt      string
+		patches []string
+		want    string
+	}{{
+		name: "no patches",
+		rt:   pod,
+		want: pod,
+	}, {
+		name:    "strategic merge patch of a known type",
+		rt:      pod,
+		patches: []string{`{"spec": {"nodeSelector": {"pool": "ci"}, "containers": [{"name": "b", "imagePullPolicy": "Always"}]}}`},
+		want:    `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod"},"spec":{"containers":[{"image":"a","name":"a"},{"image":"b","imagePullPolicy":"Always","name":"b"}],"nodeSelector":{"pool":"ci"}}}`,
+	}, {
+		name:    "JSON merge patch of an unknown type",
+		rt:      `{"apiVersion": "example.dev/v1", "kind": "Job", "metadata": {"name": "job"}, "spec": {"steps": [{"name": "a"}], "drop": true}}`,
+		patches: []string{`{"spec": {"steps": [{"name": "b"}], "drop": null}}`},
+		want:    `{"apiVersion":"example.dev/v1","kind":"Job","metadata":{"name":"job"},"spec":{"steps":[{"name":"b"}]}}`,
+	}, {
+		name: "patches are applied in order",
+		rt:   `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}}`,
+		patches: []string{
+			`{"metadata": {"labels": {"a": "1", "b": "1"}}}`,
+			`{"metadata": {"labels": {"b": "2"}}}`,
+		},
+		want: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"labels":{"a":"1","b":"2"},"name":"cm"}}`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			patches := make([]json.RawMessage, len(tc.patches))
+			for i, p := range tc.patches {
+				patches[i] = json.RawMessage(p)
+			}
+			got, err := ApplyPatchesToResource(json.RawMessage(tc.rt), patches)
+			if err != nil {
+				t.Fatalf("ApplyPatchesToResource() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, string(got)); diff != "" {
+				t.Errorf("ApplyPatchesToResource() -want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyPatchesToResource_Error(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		rt    string
+		patch string
+	}{{
+		name:  "resource is not valid JSON",
+		rt:    `{"apiVersion": "v1", "kind": "ConfigMap", "data": {"a": $(params.a)}}`,
+		patch: `{"metadata": {"labels": {"a": "b"}}}`,
+	}, {
+		name:  "patch is not valid JSON",
+		rt:    `{"apiVersion": "example.dev/v1", "kind": "Job"}`,
+		patch: `{"metadata": "x`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ApplyPatchesToResource(json.RawMessage(tc.rt), []json.RawMessage{json.RawMessage(tc.patch)}); err == nil {
+				t.Error("ApplyPatchesToResource() did not return an error")
+			}
+		})
+	}
+}
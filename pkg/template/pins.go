@@ -0,0 +1,299 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+)
+
+// pinAnnotation holds the pin of the copy that a ControllerRevision stores.
+const pinAnnotation = triggersv1.GroupName + "/pin"
+
+// Pins keeps a copy of the bindings and TriggerTemplates that Triggers pin to
+// a generation or version, as they were when they matched the pin. Once such
+// an object is updated, the references keep resolving to the copy until the
+// Trigger is pinned to the new generation or version, so that every event uses
+// one version or the other.
+//
+// Pins created by NewStoredPins store the copies of the Triggers of an
+// EventListener, returned by For, as ControllerRevisions owned by the
+// EventListener, so that they survive restarts and are shared by all the
+// replicas of its sink. Other Pins keep the copies in memory.
+type Pins struct {
+	client appsv1client.ControllerRevisionsGetter
+	owner  *triggersv1.EventListener
+	cache  *pinCache
+}
+
+// pinCache holds the copies that Pins resolved or stored before.
+type pinCache struct {
+	mu      sync.Mutex
+	objects map[string]runtime.Object
+	// pruned holds the generation of each EventListener that its superseded
+	// copies were last deleted at
+	pruned map[string]int64
+}
+
+// NewPins returns Pins that keep their copies in memory.
+func NewPins() *Pins {
+	return &Pins{cache: &pinCache{objects: map[string]runtime.Object{}, pruned: map[string]int64{}}}
+}
+
+// NewStoredPins returns Pins that store the copies of the Triggers of an
+// EventListener as ControllerRevisions with the client.
+func NewStoredPins(client appsv1client.ControllerRevisionsGetter) *Pins {
+	p := NewPins()
+	p.client = client
+	return p
+}
+
+// For returns the Pins of the Triggers of the EventListener, which store their
+// copies in its namespace.
+func (p *Pins) For(el *triggersv1.EventListener) *Pins {
+	if p == nil {
+		return nil
+	}
+	return &Pins{client: p.client, owner: el, cache: p.cache}
+}
+
+// Prune deletes the stored copies that no Trigger of the EventListener pins
+// anymore. The copies are only listed when the EventListener changed since
+// they were last pruned.
+func (p *Pins) Prune(el *triggersv1.EventListener) error {
+	if p == nil || p.client == nil {
+		return nil
+	}
+	owner := el.Namespace + "/" + el.Name
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+	if generation, ok := p.cache.pruned[owner]; ok && generation == el.Generation {
+		return nil
+	}
+
+	pinned := map[string]bool{}
+	for _, t := range el.Spec.Triggers {
+		for _, b := range t.Bindings {
+			kind := b.Kind
+			if kind == "" {
+				kind = triggersv1.NamespacedTriggerBindingKind
+			}
+			pinned[pinRef(string(kind), b.Name, b.Generation, b.Version)] = true
+		}
+		pinned[pinRef("TriggerTemplate", t.Template.Name, t.Template.Generation, t.Template.Version)] = true
+	}
+	revisions := p.client.ControllerRevisions(el.Namespace)
+	list, err := revisions.List(metav1.ListOptions{
+		LabelSelector: triggersv1.GroupName + triggersv1.PinnedByLabelKey + "=" + el.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing the pinned copies of EventListener %s: %w", owner, err)
+	}
+	for _, rev := range list.Items {
+		key := rev.Annotations[pinAnnotation]
+		if pinned[refOfKey(key)] {
+			continue
+		}
+		if err := revisions.Delete(rev.Name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting the superseded pinned copy %s: %w", rev.Name, err)
+		}
+		delete(p.cache.objects, owner+"|"+key)
+	}
+	p.cache.pruned[owner] = el.Generation
+	return nil
+}
+
+type pinnable interface {
+	metav1.Object
+	runtime.Object
+}
+
+// resolve returns obj if it is at the generation and has the version that a
+// reference pins it to, and otherwise the copy of the object that matched the
+// pin before. Pins may be nil, in which case objects that do not match the pin
+// fail to resolve.
+func (p *Pins) resolve(kind string, obj pinnable, generation int64, version string) (runtime.Object, error) {
+	if generation == 0 && version == "" {
+		return obj, nil
+	}
+	key := pinKey(kind, obj.GetNamespace(), obj.GetName(), generation, version)
+	err := matchPin(kind, obj, generation, version)
+	if p == nil {
+		if err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+	cacheKey := p.cacheKey(key)
+	if err == nil {
+		// The copy is stored again when the object changed without changing
+		// its generation or version, e.g. its labels
+		cached, ok := p.cache.objects[cacheKey]
+		if !ok || cached.(metav1.Object).GetResourceVersion() != obj.GetResourceVersion() {
+			if err := p.store(key, obj); err != nil {
+				return nil, err
+			}
+			p.cache.objects[cacheKey] = obj.DeepCopyObject()
+		}
+		return obj, nil
+	}
+	if pinned, ok := p.cache.objects[cacheKey]; ok {
+		return pinned.DeepCopyObject(), nil
+	}
+	pinned, loadErr := p.load(kind, key)
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	if pinned == nil {
+		return nil, err
+	}
+	p.cache.objects[cacheKey] = pinned
+	return pinned.DeepCopyObject(), nil
+}
+
+// stored reports whether the Pins store their copies as ControllerRevisions.
+func (p *Pins) stored() bool {
+	return p.client != nil && p.owner != nil
+}
+
+func (p *Pins) cacheKey(key string) string {
+	if p.owner == nil {
+		return key
+	}
+	return p.owner.Namespace + "/" + p.owner.Name + "|" + key
+}
+
+// revisionName returns the name of the ControllerRevision that stores the copy
+// of the pin.
+func (p *Pins) revisionName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("%s-pin-%s", p.owner.Name, hex.EncodeToString(sum[:])[:10])
+}
+
+// store creates or updates the ControllerRevision of the pin with the object.
+func (p *Pins) store(key string, obj runtime.Object) error {
+	if !p.stored() {
+		return nil
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("error marshaling the pinned copy: %w", err)
+	}
+	rev := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            p.revisionName(key),
+			Namespace:       p.owner.Namespace,
+			Labels:          map[string]string{triggersv1.GroupName + triggersv1.PinnedByLabelKey: p.owner.Name},
+			Annotations:     map[string]string{pinAnnotation: key},
+			OwnerReferences: []metav1.OwnerReference{*p.owner.GetOwnerReference()},
+		},
+		Data:     runtime.RawExtension{Raw: raw},
+		Revision: 1,
+	}
+	revisions := p.client.ControllerRevisions(p.owner.Namespace)
+	_, err = revisions.Create(rev)
+	if kerrors.IsAlreadyExists(err) {
+		var existing *appsv1.ControllerRevision
+		if existing, err = revisions.Get(rev.Name, metav1.GetOptions{}); err == nil {
+			existing.Annotations = rev.Annotations
+			existing.Data = rev.Data
+			existing.Revision++
+			_, err = revisions.Update(existing)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error storing the pinned copy %s: %w", rev.Name, err)
+	}
+	return nil
+}
+
+// load returns the stored copy of the pin, or nil if there is none.
+func (p *Pins) load(kind, key string) (runtime.Object, error) {
+	if !p.stored() {
+		return nil, nil
+	}
+	name := p.revisionName(key)
+	rev, err := p.client.ControllerRevisions(p.owner.Namespace).Get(name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting the pinned copy %s: %w", name, err)
+	}
+	if rev.Annotations[pinAnnotation] != key {
+		return nil, nil
+	}
+	var obj runtime.Object
+	switch kind {
+	case string(triggersv1.ClusterTriggerBindingKind):
+		obj = &triggersv1.ClusterTriggerBinding{}
+	case string(triggersv1.NamespacedTriggerBindingKind):
+		obj = &triggersv1.TriggerBinding{}
+	default:
+		obj = &triggersv1.TriggerTemplate{}
+	}
+	if err := json.Unmarshal(rev.Data.Raw, obj); err != nil {
+		return nil, fmt.Errorf("error unmarshaling the pinned copy %s: %w", name, err)
+	}
+	return obj, nil
+}
+
+// pinKey identifies the copy of an object that matched a pin.
+func pinKey(kind, namespace, name string, generation int64, version string) string {
+	return fmt.Sprintf("%s/%s/%s@%d/%s", kind, namespace, name, generation, version)
+}
+
+// pinRef identifies a pin of a reference, which may resolve in any of the
+// namespaces that the EventListener serves Triggers in.
+func pinRef(kind, name string, generation int64, version string) string {
+	return fmt.Sprintf("%s/%s@%d/%s", kind, name, generation, version)
+}
+
+// refOfKey returns the pinRef of a pinKey.
+func refOfKey(key string) string {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[0] + "/" + parts[2]
+}
+
+// matchPin returns an error if obj is not at the generation or does not have
+// the version of a pin.
+func matchPin(kind string, obj metav1.Object, generation int64, version string) error {
+	if generation != 0 && obj.GetGeneration() != generation {
+		return fmt.Errorf("%s %s is at generation %d, not at the pinned generation %d", kind, obj.GetName(), obj.GetGeneration(), generation)
+	}
+	if got := obj.GetLabels()[triggersv1.GroupName+triggersv1.VersionLabelKey]; version != "" && got != version {
+		return fmt.Errorf("%s %s has version %q, not the pinned version %q", kind, obj.GetName(), got, version)
+	}
+	return nil
+}
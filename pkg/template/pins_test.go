@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func pinnedEventListener(generation int64, bindingGeneration int64) *triggersv1.EventListener {
+	return &triggersv1.EventListener{
+		ObjectMeta: metav1.ObjectMeta{Name: "el", Namespace: "ns", UID: "el-uid", Generation: generation},
+		Spec: triggersv1.EventListenerSpec{
+			Triggers: []triggersv1.EventListenerTrigger{{
+				Bindings: []*triggersv1.EventListenerBinding{{Name: "tb", Kind: triggersv1.NamespacedTriggerBindingKind, Generation: bindingGeneration}},
+				Template: triggersv1.EventListenerTemplate{Name: "tt", Version: "v1"},
+			}},
+		},
+	}
+}
+
+func TestStoredPins(t *testing.T) {
+	pinnedTB := &triggersv1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "tb", Namespace: "ns", Generation: 2},
+		Spec: triggersv1.TriggerBindingSpec{
+			Params: []triggersv1.BindingParam{bldr.BindingParam("foo", "bar")},
+		},
+	}
+	pinnedTT := &triggersv1.TriggerTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tt",
+			Namespace: "ns",
+			Labels:    map[string]string{triggersv1.GroupName + triggersv1.VersionLabelKey: "v1"},
+		},
+	}
+	currentTB, currentTT := pinnedTB, pinnedTT
+	getTB := func(name string, options metav1.GetOptions) (*triggersv1.TriggerBinding, error) {
+		return currentTB, nil
+	}
+	getTT := func(name string, options metav1.GetOptions) (*triggersv1.TriggerTemplate, error) {
+		return currentTT, nil
+	}
+	el := pinnedEventListener(1, 2)
+	trigger := el.Spec.Triggers[0]
+	want := ResolvedTrigger{
+		TriggerBindings:        []*triggersv1.TriggerBinding{pinnedTB},
+		ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{},
+		TriggerTemplate:        pinnedTT,
+		Bindings:               []ResolvedBinding{newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, "tb", pinnedTB.Spec.Params)},
+	}
+	kubeClient := fake.NewSimpleClientset()
+
+	if _, err := ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, NewStoredPins(kubeClient.AppsV1()).For(el)); err != nil {
+		t.Fatalf("ResolvePinnedTrigger() returned unexpected error: %s", err)
+	}
+	revisions, err := kubeClient.AppsV1().ControllerRevisions("ns").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Error listing ControllerRevisions: %s", err)
+	}
+	if len(revisions.Items) != 2 {
+		t.Fatalf("Got %d ControllerRevisions, want one for each pinned object", len(revisions.Items))
+	}
+	for _, rev := range revisions.Items {
+		if got := rev.Labels[triggersv1.GroupName+triggersv1.PinnedByLabelKey]; got != "el" {
+			t.Errorf("ControllerRevision %s is labeled with EventListener %q, want el", rev.Name, got)
+		}
+		if len(rev.OwnerReferences) != 1 || rev.OwnerReferences[0].UID != el.UID {
+			t.Errorf("ControllerRevision %s is not owned by the EventListener: %v", rev.Name, rev.OwnerReferences)
+		}
+	}
+
+	// A restarted sink, or another replica, resolves the updated objects to
+	// the stored copies
+	currentTB = pinnedTB.DeepCopy()
+	currentTB.Generation = 3
+	currentTB.Spec.Params = []triggersv1.BindingParam{bldr.BindingParam("foo", "baz")}
+	currentTT = pinnedTT.DeepCopy()
+	currentTT.Labels[triggersv1.GroupName+triggersv1.VersionLabelKey] = "v2"
+	got, err := ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, NewStoredPins(kubeClient.AppsV1()).For(el))
+	if err != nil {
+		t.Fatalf("ResolvePinnedTrigger() after a restart returned unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolvePinnedTrigger() after a restart: -want +got: %s", diff)
+	}
+
+	// The copies of other EventListeners are not shared
+	other := pinnedEventListener(1, 2)
+	other.Name, other.UID = "other", "other-uid"
+	if _, err := ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, NewStoredPins(kubeClient.AppsV1()).For(other)); err == nil {
+		t.Error("ResolvePinnedTrigger() resolved to the copies of another EventListener")
+	}
+}
+
+func TestPins_Prune(t *testing.T) {
+	tb := &triggersv1.TriggerBinding{ObjectMeta: metav1.ObjectMeta{Name: "tb", Namespace: "ns", Generation: 2}}
+	tt := &triggersv1.TriggerTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tt",
+			Namespace: "ns",
+			Labels:    map[string]string{triggersv1.GroupName + triggersv1.VersionLabelKey: "v1"},
+		},
+	}
+	getTB := func(name string, options metav1.GetOptions) (*triggersv1.TriggerBinding, error) {
+		return tb, nil
+	}
+	getTT := func(name string, options metav1.GetOptions) (*triggersv1.TriggerTemplate, error) {
+		return tt, nil
+	}
+	el := pinnedEventListener(1, 2)
+	kubeClient := fake.NewSimpleClientset()
+	pins := NewStoredPins(kubeClient.AppsV1())
+	if _, err := ResolvePinnedTrigger(el.Spec.Triggers[0], getTB, getCTB, getTT, pins.For(el)); err != nil {
+		t.Fatalf("ResolvePinnedTrigger() returned unexpected error: %s", err)
+	}
+	countRevisions := func() int {
+		t.Helper()
+		revisions, err := kubeClient.AppsV1().ControllerRevisions("ns").List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("Error listing ControllerRevisions: %s", err)
+		}
+		return len(revisions.Items)
+	}
+
+	// Copies that are still pinned are kept
+	if err := pins.Prune(el); err != nil {
+		t.Fatalf("Prune() returned unexpected error: %s", err)
+	}
+	if got := countRevisions(); got != 2 {
+		t.Fatalf("Got %d ControllerRevisions after pruning, want 2", got)
+	}
+
+	// Bumping the pin of the binding supersedes its copy
+	if err := pins.Prune(pinnedEventListener(2, 3)); err != nil {
+		t.Fatalf("Prune() after bumping the pin returned unexpected error: %s", err)
+	}
+	if got := countRevisions(); got != 1 {
+		t.Fatalf("Got %d ControllerRevisions after bumping the pin, want only the TriggerTemplate's", got)
+	}
+	tb.Generation = 3
+	if _, err := ResolvePinnedTrigger(el.Spec.Triggers[0], getTB, getCTB, getTT, pins.For(el)); err == nil {
+		t.Error("ResolvePinnedTrigger() resolved to the superseded copy")
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
+
+// Redacted replaces the values of sensitive params.
+const Redacted = "[REDACTED]"
+
+// SensitiveValues returns the resolved values of the params that are declared
+// sensitive in paramSpecs, and their base64 encodings as substituted into the
+// data of Secrets, longest first so that Redact replaces values that contain
+// other values before those.
+func SensitiveValues(params []pipelinev1.Param, paramSpecs []triggersv1.ParamSpec) []string {
+	sensitive := sensitiveParams(paramSpecs)
+	var values []string
+	for _, p := range params {
+		if !sensitive[p.Name] {
+			continue
+		}
+		for _, v := range append([]string{p.Value.StringVal}, p.Value.ArrayVal...) {
+			if v != "" {
+				values = append(values, v, base64.StdEncoding.EncodeToString([]byte(v)))
+			}
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	return values
+}
+
+// RedactParams returns a copy of params with the values of the params that
+// are declared sensitive in paramSpecs replaced.
+func RedactParams(params []pipelinev1.Param, paramSpecs []triggersv1.ParamSpec) []pipelinev1.Param {
+	sensitive := sensitiveParams(paramSpecs)
+	out := make([]pipelinev1.Param, 0, len(params))
+	for _, p := range params {
+		if sensitive[p.Name] {
+			p = pipelinev1.Param{
+				Name:  p.Name,
+				Value: pipelinev1.ArrayOrString{Type: p.Value.Type, StringVal: Redacted},
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Redact replaces every occurrence of values in s.
+func Redact(s string, values []string) string {
+	for _, v := range values {
+		s = strings.Replace(s, v, Redacted, -1)
+	}
+	return s
+}
+
+// SensitiveParamSpecs returns the ParamSpecs of the TriggerTemplate with the
+// params that are substituted into Secrets declared sensitive, so that the
+// per-event credentials that Secrets are created with are never logged. With
+// the gotemplate engine, any param can be rendered into a Secret, so all
+// params are sensitive when the TriggerTemplate has a Secret. Params in the
+// patches of a TriggerTemplate with a Secret are sensitive as well.
+func SensitiveParamSpecs(tt *triggersv1.TriggerTemplate) []triggersv1.ParamSpec {
+	inSecrets := map[string]bool{}
+	all := false
+	for _, rt := range tt.Spec.ResourceTemplates {
+		if !isSecret(rt.RawExtension.Raw) {
+			continue
+		}
+		if tt.Spec.Engine == triggersv1.GoTemplateEngine {
+			all = true
+			break
+		}
+		for _, m := range triggersv1.ParamsRegexp.FindAllSubmatch(rt.RawExtension.Raw, -1) {
+			inSecrets[string(m[1])] = true
+		}
+		// The patches are applied to the Secret too
+		for _, p := range tt.Spec.Patches {
+			for _, m := range triggersv1.ParamsRegexp.FindAllSubmatch(p.RawExtension.Raw, -1) {
+				inSecrets[string(m[1])] = true
+			}
+		}
+	}
+	specs := make([]triggersv1.ParamSpec, len(tt.Spec.Params))
+	for i, ps := range tt.Spec.Params {
+		if all || inSecrets[ps.Name] {
+			ps.Sensitive = true
+		}
+		specs[i] = ps
+	}
+	return specs
+}
+
+// isSecret reports whether the resource template is a core Secret.
+func isSecret(raw []byte) bool {
+	var tm struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := json.Unmarshal(raw, &tm); err != nil {
+		return false
+	}
+	return tm.APIVersion == "v1" && tm.Kind == "Secret"
+}
+
+func sensitiveParams(paramSpecs []triggersv1.ParamSpec) map[string]bool {
+	sensitive := map[string]bool{}
+	for _, ps := range paramSpecs {
+		if ps.Sensitive {
+			sensitive[ps.Name] = true
+		}
+	}
+	return sensitive
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	bldr "github.com/tektoncd/triggers/test/builder"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRedact(t *testing.T) {
+	tt := bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+		bldr.TriggerTemplateSensitiveParam("token", "", ""),
+		bldr.TriggerTemplateSensitiveParam("keys", "", ""),
+		bldr.TriggerTemplateSensitiveParam("empty", "", ""),
+		bldr.TriggerTemplateParam("revision", "", ""),
+	))
+	params := []pipelinev1.Param{
+		bldr.Param("token", "abc"),
+		bldr.Param("revision", "abc123"),
+		bldr.Param("empty", ""),
+		{Name: "keys", Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeArray, ArrayVal: []string{"k1", "abcdef"}}},
+	}
+
+	values := SensitiveValues(params, tt.Spec.Params)
+	if diff := cmp.Diff([]string{"YWJjZGVm", "abcdef", "YWJj", "azE=", "abc", "k1"}, values); diff != "" {
+		t.Errorf("SensitiveValues(): -want +got: %s", diff)
+	}
+
+	wantParams := []pipelinev1.Param{
+		bldr.Param("token", Redacted),
+		bldr.Param("revision", "abc123"),
+		bldr.Param("empty", Redacted),
+		{Name: "keys", Value: pipelinev1.ArrayOrString{Type: pipelinev1.ParamTypeArray, StringVal: Redacted}},
+	}
+	if diff := cmp.Diff(wantParams, RedactParams(params, tt.Spec.Params)); diff != "" {
+		t.Errorf("RedactParams(): -want +got: %s", diff)
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{{
+		in:   "token abc, keys k1 abcdef",
+		want: "token [REDACTED], keys [REDACTED] [REDACTED]",
+	}, {
+		in:   `"data": {"token": "YWJj"}`,
+		want: `"data": {"token": "[REDACTED]"}`,
+	}, {
+		in:   "nothing sensitive",
+		want: "nothing sensitive",
+	}}
+	for _, tc := range tests {
+		if got := Redact(tc.in, values); got != tc.want {
+			t.Errorf("Redact(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSensitiveParamSpecs(t *testing.T) {
+	secret := json.RawMessage(`{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "token-$(uid)"}, "data": {"token": "$(params.token | base64)"}, "stringData": {"user": "$(params.user)"}}`)
+	pipelineRun := json.RawMessage(`{"apiVersion": "tekton.dev/v1beta1", "kind": "PipelineRun", "metadata": {"name": "$(params.revision)"}}`)
+	patch := runtime.RawExtension{Raw: []byte(`{"metadata": {"labels": {"revision": "$(params.revision)"}}}`)}
+	newTemplate := func(engine triggersv1.TemplateEngine, raws ...json.RawMessage) *triggersv1.TriggerTemplate {
+		ops := []bldr.TriggerTemplateSpecOp{
+			bldr.TriggerTemplateParam("token", "", ""),
+			bldr.TriggerTemplateParam("user", "", ""),
+			bldr.TriggerTemplateParam("revision", "", ""),
+		}
+		for _, raw := range raws {
+			ops = append(ops, bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: raw}))
+		}
+		tt := bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(ops...))
+		tt.Spec.Engine = engine
+		return tt
+	}
+
+	for _, tc := range []struct {
+		name string
+		tt   *triggersv1.TriggerTemplate
+		want map[string]bool
+	}{{
+		name: "params in Secrets",
+		tt:   newTemplate(triggersv1.DefaultTemplateEngine, secret, pipelineRun),
+		want: map[string]bool{"token": true, "user": true},
+	}, {
+		name: "no Secret",
+		tt:   newTemplate(triggersv1.DefaultTemplateEngine, pipelineRun),
+		want: map[string]bool{},
+	}, {
+		name: "params in patches of a template with a Secret",
+		tt: func() *triggersv1.TriggerTemplate {
+			tt := newTemplate(triggersv1.DefaultTemplateEngine, secret, pipelineRun)
+			bldr.TriggerTemplatePatch(patch)(&tt.Spec)
+			return tt
+		}(),
+		want: map[string]bool{"token": true, "user": true, "revision": true},
+	}, {
+		name: "params in patches of a template without a Secret",
+		tt: func() *triggersv1.TriggerTemplate {
+			tt := newTemplate(triggersv1.DefaultTemplateEngine, pipelineRun)
+			bldr.TriggerTemplatePatch(patch)(&tt.Spec)
+			return tt
+		}(),
+		want: map[string]bool{},
+	}, {
+		name: "all params with the gotemplate engine",
+		tt:   newTemplate(triggersv1.GoTemplateEngine, secret, pipelineRun),
+		want: map[string]bool{"token": true, "user": true, "revision": true},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := map[string]bool{}
+			for _, ps := range SensitiveParamSpecs(tc.tt) {
+				if ps.Sensitive {
+					got[ps.Name] = true
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SensitiveParamSpecs(): -want +got: %s", diff)
+			}
+			for _, ps := range tc.tt.Spec.Params {
+				if ps.Sensitive {
+					t.Errorf("SensitiveParamSpecs() modified the TriggerTemplate")
+				}
+			}
+		})
+	}
+}
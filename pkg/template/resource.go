@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	triggersv1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
@@ -38,6 +37,44 @@ type ResolvedTrigger struct {
 	TriggerBindings        []*triggersv1.TriggerBinding
 	ClusterTriggerBindings []*triggersv1.ClusterTriggerBinding
 	TriggerTemplate        *triggersv1.TriggerTemplate
+	// OnMissingField is how binding params whose values refer to fields
+	// that are not in an event are handled
+	OnMissingField triggersv1.MissingFieldPolicy
+	// Bindings are the bindings of the trigger in the order they are
+	// listed, which is the order their params override each other in. If
+	// nil, the params of the TriggerBindings are merged before those of the
+	// ClusterTriggerBindings.
+	Bindings []ResolvedBinding
+	// OnBindingConflict is how params that more than one binding sets are
+	// handled
+	OnBindingConflict triggersv1.BindingConflictPolicy
+}
+
+// ResolvedBinding holds the params of a binding of a trigger, after merging in
+// the params of the bindings it includes.
+type ResolvedBinding struct {
+	Kind   triggersv1.TriggerBindingKind
+	Name   string
+	Params []pipelinev1.Param
+	// Defaults are the values that Params take, by name, if they refer to
+	// fields that are not in the event
+	Defaults map[string]string
+}
+
+// newResolvedBinding returns the ResolvedBinding of the params of a binding,
+// which are split into their values and defaults.
+func newResolvedBinding(kind triggersv1.TriggerBindingKind, name string, params []triggersv1.BindingParam) ResolvedBinding {
+	b := ResolvedBinding{Kind: kind, Name: name}
+	for _, p := range params {
+		b.Params = append(b.Params, p.Param)
+		if p.Default != nil {
+			if b.Defaults == nil {
+				b.Defaults = map[string]string{}
+			}
+			b.Defaults[p.Name] = *p.Default
+		}
+	}
+	return b
 }
 
 type getTriggerBinding func(name string, options metav1.GetOptions) (*triggersv1.TriggerBinding, error)
@@ -45,23 +82,56 @@ type getTriggerTemplate func(name string, options metav1.GetOptions) (*triggersv
 type getClusterTriggerBinding func(name string, options metav1.GetOptions) (*triggersv1.ClusterTriggerBinding, error)
 
 // ResolveTrigger takes in a trigger containing object refs to bindings and
-// templates and resolves them to their underlying values.
+// templates and resolves them to their underlying values. References that are
+// pinned to a generation or version fail to resolve to any other.
 func ResolveTrigger(trigger triggersv1.EventListenerTrigger, getTB getTriggerBinding, getCTB getClusterTriggerBinding, getTT getTriggerTemplate) (ResolvedTrigger, error) {
+	return ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, nil)
+}
+
+// ResolvePinnedTrigger resolves a trigger like ResolveTrigger, except that
+// references that are pinned to a generation or version of an object that was
+// updated since resolve to the copy of the object in pins.
+func ResolvePinnedTrigger(trigger triggersv1.EventListenerTrigger, getTB getTriggerBinding, getCTB getClusterTriggerBinding, getTT getTriggerTemplate, pins *Pins) (ResolvedTrigger, error) {
 	tb := make([]*triggersv1.TriggerBinding, 0, len(trigger.Bindings))
 	ctb := make([]*triggersv1.ClusterTriggerBinding, 0, len(trigger.Bindings))
+	bindings := make([]ResolvedBinding, 0, len(trigger.Bindings))
 	for _, b := range trigger.Bindings {
 		if b.Kind == triggersv1.ClusterTriggerBindingKind {
 			ctb2, err := getCTB(b.Name, metav1.GetOptions{})
 			if err != nil {
 				return ResolvedTrigger{}, fmt.Errorf("error getting ClusterTriggerBinding %s: %w", b.Name, err)
 			}
+			pinned, err := pins.resolve(string(triggersv1.ClusterTriggerBindingKind), ctb2, b.Generation, b.Version)
+			if err != nil {
+				return ResolvedTrigger{}, err
+			}
+			ctb2 = pinned.(*triggersv1.ClusterTriggerBinding)
+			if len(ctb2.Spec.Includes) > 0 {
+				ctb2 = ctb2.DeepCopy()
+				if ctb2.Spec, err = resolveIncludes(ctb2.Spec, triggersv1.ClusterTriggerBindingKind, getTB, getCTB, map[string]bool{bindingKey(triggersv1.ClusterTriggerBindingKind, b.Name): true}); err != nil {
+					return ResolvedTrigger{}, fmt.Errorf("error resolving includes of ClusterTriggerBinding %s: %w", b.Name, err)
+				}
+			}
 			ctb = append(ctb, ctb2)
+			bindings = append(bindings, newResolvedBinding(triggersv1.ClusterTriggerBindingKind, b.Name, ctb2.Spec.Params))
 		} else {
 			tb2, err := getTB(b.Name, metav1.GetOptions{})
 			if err != nil {
 				return ResolvedTrigger{}, fmt.Errorf("error getting TriggerBinding %s: %w", b.Name, err)
 			}
+			pinned, err := pins.resolve(string(triggersv1.NamespacedTriggerBindingKind), tb2, b.Generation, b.Version)
+			if err != nil {
+				return ResolvedTrigger{}, err
+			}
+			tb2 = pinned.(*triggersv1.TriggerBinding)
+			if len(tb2.Spec.Includes) > 0 {
+				tb2 = tb2.DeepCopy()
+				if tb2.Spec, err = resolveIncludes(tb2.Spec, triggersv1.NamespacedTriggerBindingKind, getTB, getCTB, map[string]bool{bindingKey(triggersv1.NamespacedTriggerBindingKind, b.Name): true}); err != nil {
+					return ResolvedTrigger{}, fmt.Errorf("error resolving includes of TriggerBinding %s: %w", b.Name, err)
+				}
+			}
 			tb = append(tb, tb2)
+			bindings = append(bindings, newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, b.Name, tb2.Spec.Params))
 		}
 	}
 
@@ -70,12 +140,96 @@ func ResolveTrigger(trigger triggersv1.EventListenerTrigger, getTB getTriggerBin
 	if err != nil {
 		return ResolvedTrigger{}, fmt.Errorf("error getting TriggerTemplate %s: %w", ttName, err)
 	}
-	return ResolvedTrigger{TriggerBindings: tb, ClusterTriggerBindings: ctb, TriggerTemplate: tt}, nil
+	pinned, err := pins.resolve("TriggerTemplate", tt, trigger.Template.Generation, trigger.Template.Version)
+	if err != nil {
+		return ResolvedTrigger{}, err
+	}
+	tt = pinned.(*triggersv1.TriggerTemplate)
+	return ResolvedTrigger{
+		TriggerBindings:        tb,
+		ClusterTriggerBindings: ctb,
+		TriggerTemplate:        tt,
+		OnMissingField:         trigger.OnMissingField,
+		Bindings:               bindings,
+		OnBindingConflict:      trigger.OnBindingConflict,
+	}, nil
+}
+
+// resolveIncludes returns a binding spec without includes, after merging in
+// the params and defaults of the bindings it includes, recursively. Includes
+// without a kind refer to bindings of the same kind as the including binding.
+// seen holds the bindings on the current include path to detect cycles.
+func resolveIncludes(spec triggersv1.TriggerBindingSpec, kind triggersv1.TriggerBindingKind, getTB getTriggerBinding, getCTB getClusterTriggerBinding, seen map[string]bool) (triggersv1.TriggerBindingSpec, error) {
+	var resolved triggersv1.TriggerBindingSpec
+	for _, inc := range spec.Includes {
+		incKind := inc.Kind
+		if incKind == "" {
+			incKind = kind
+		}
+		key := bindingKey(incKind, inc.Name)
+		if seen[key] {
+			return resolved, fmt.Errorf("include cycle detected at %s", key)
+		}
+		var incSpec triggersv1.TriggerBindingSpec
+		if incKind == triggersv1.ClusterTriggerBindingKind {
+			ctb, err := getCTB(inc.Name, metav1.GetOptions{})
+			if err != nil {
+				return resolved, fmt.Errorf("error getting ClusterTriggerBinding %s: %w", inc.Name, err)
+			}
+			incSpec = ctb.Spec
+		} else {
+			if kind == triggersv1.ClusterTriggerBindingKind {
+				return resolved, fmt.Errorf("ClusterTriggerBinding cannot include TriggerBinding %s", inc.Name)
+			}
+			tb, err := getTB(inc.Name, metav1.GetOptions{})
+			if err != nil {
+				return resolved, fmt.Errorf("error getting TriggerBinding %s: %w", inc.Name, err)
+			}
+			incSpec = tb.Spec
+		}
+		seen[key] = true
+		included, err := resolveIncludes(incSpec, incKind, getTB, getCTB, seen)
+		delete(seen, key)
+		if err != nil {
+			return resolved, err
+		}
+		resolved = overrideSpec(resolved, included)
+	}
+	return overrideSpec(resolved, spec), nil
+}
+
+// overrideSpec returns the params of spec with those of overrides. Params that
+// overrides sets lose their defaults in spec.
+func overrideSpec(spec, overrides triggersv1.TriggerBindingSpec) triggersv1.TriggerBindingSpec {
+	return triggersv1.TriggerBindingSpec{Params: overrideParams(spec.Params, overrides.Params)}
+}
+
+// overrideParams returns params with the values of overrides, replacing params
+// with the same name in place and appending the others.
+func overrideParams(params, overrides []triggersv1.BindingParam) []triggersv1.BindingParam {
+	for _, o := range overrides {
+		replaced := false
+		for i := range params {
+			if params[i].Name == o.Name {
+				params[i] = o
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			params = append(params, o)
+		}
+	}
+	return params
+}
+
+func bindingKey(kind triggersv1.TriggerBindingKind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
 }
 
 // MergeInDefaultParams returns the params with the addition of all
 // paramSpecs that have default values and are already in the params list
-func MergeInDefaultParams(params []pipelinev1.Param, paramSpecs []pipelinev1.ParamSpec) []pipelinev1.Param {
+func MergeInDefaultParams(params []pipelinev1.Param, paramSpecs []triggersv1.ParamSpec) []pipelinev1.Param {
 	allParamsMap := map[string]pipelinev1.ArrayOrString{}
 	for _, paramSpec := range paramSpecs {
 		if paramSpec.Default != nil {
@@ -89,24 +243,33 @@ func MergeInDefaultParams(params []pipelinev1.Param, paramSpecs []pipelinev1.Par
 }
 
 // ApplyParamsToResourceTemplate returns the TriggerResourceTemplate with the
-// param values substituted for all matching param variables in the template
-func ApplyParamsToResourceTemplate(params []pipelinev1.Param, rt json.RawMessage) json.RawMessage {
+// param values substituted for all matching param variables in the template.
+// Each value is escaped as set in the variable, or else in the ParamSpec of
+// the param. The values are substituted in a single pass, so param variables
+// in the values are not substituted themselves.
+func ApplyParamsToResourceTemplate(params []pipelinev1.Param, paramSpecs []triggersv1.ParamSpec, rt json.RawMessage) json.RawMessage {
 	// Assume the params are valid
+	values := make(map[string]string, len(params))
 	for _, param := range params {
-		rt = applyParamToResourceTemplate(param, rt)
+		values[param.Name] = param.Value.StringVal
 	}
-	return rt
-}
-
-// applyParamToResourceTemplate returns the TriggerResourceTemplate with the
-// param value substituted for all matching param variables in the template
-func applyParamToResourceTemplate(param pipelinev1.Param, rt json.RawMessage) json.RawMessage {
-	// Assume the param is valid
-	paramVariable := fmt.Sprintf("$(params.%s)", param.Name)
-	// Escape quotes so that that JSON strings can be appended to regular strings.
-	// See #257 for discussion on this behavior.
-	paramValue := strings.Replace(param.Value.StringVal, `"`, `\"`, -1)
-	return bytes.Replace(rt, []byte(paramVariable), []byte(paramValue), -1)
+	escapes := make(map[string]triggersv1.ParamEscape, len(paramSpecs))
+	for _, paramSpec := range paramSpecs {
+		escapes[paramSpec.Name] = paramSpec.Escape
+	}
+	return triggersv1.ParamsRegexp.ReplaceAllFunc(rt, func(variable []byte) []byte {
+		match := triggersv1.ParamsRegexp.FindSubmatch(variable)
+		name := string(match[1])
+		value, ok := values[name]
+		if !ok {
+			return variable
+		}
+		escape := triggersv1.ParamEscape(match[2])
+		if escape == "" {
+			escape = escapes[name]
+		}
+		return []byte(EscapeParamValue(value, escape))
+	})
 }
 
 // UID generates a random string like the Kubernetes apiserver generateName metafield postfix.
@@ -118,6 +281,36 @@ func ApplyUIDToResourceTemplate(rt json.RawMessage, uid string) json.RawMessage
 	return bytes.Replace(rt, uidMatch, []byte(uid), -1)
 }
 
+// TriggerContext describes the Trigger that resources are resolved for. Its
+// values are available in resource templates as $(tt.triggerName),
+// $(tt.triggerNamespace) and $(tt.listenerName).
+type TriggerContext struct {
+	// TriggerName is the name of the EventListenerTrigger
+	TriggerName string
+	// TriggerNamespace is the namespace that the Trigger is served in
+	TriggerNamespace string
+	// ListenerName is the name of the EventListener
+	ListenerName string
+}
+
+// variables returns the values of the context by their variable names.
+func (tc TriggerContext) variables() map[string]string {
+	return map[string]string{
+		"triggerName":      tc.TriggerName,
+		"triggerNamespace": tc.TriggerNamespace,
+		"listenerName":     tc.ListenerName,
+	}
+}
+
+// ApplyTriggerContextToResourceTemplate returns the TriggerResourceTemplate
+// with the $(tt.<name>) variables replaced by the values of the context.
+func ApplyTriggerContextToResourceTemplate(rt json.RawMessage, tc TriggerContext) json.RawMessage {
+	for name, value := range tc.variables() {
+		rt = bytes.Replace(rt, []byte(fmt.Sprintf("$(tt.%s)", name)), []byte(jsonEscape(value)), -1)
+	}
+	return rt
+}
+
 func convertParamMapToArray(paramMap map[string]pipelinev1.ArrayOrString) []pipelinev1.Param {
 	params := []pipelinev1.Param{}
 	for name, value := range paramMap {
@@ -128,19 +321,69 @@ func convertParamMapToArray(paramMap map[string]pipelinev1.ArrayOrString) []pipe
 
 // MergeBindingParams merges params across multiple bindings.
 func MergeBindingParams(bindings []*triggersv1.TriggerBinding, clusterbindings []*triggersv1.ClusterTriggerBinding) ([]pipelinev1.Param, error) {
-	params := []pipelinev1.Param{}
+	return mergeBindings(legacyBindingOrder(bindings, clusterbindings), triggersv1.BindingConflictFail)
+}
+
+// legacyBindingOrder returns the bindings with the TriggerBindings before the
+// ClusterTriggerBindings, for triggers whose binding order is not known.
+func legacyBindingOrder(bindings []*triggersv1.TriggerBinding, clusterbindings []*triggersv1.ClusterTriggerBinding) []ResolvedBinding {
+	out := make([]ResolvedBinding, 0, len(bindings)+len(clusterbindings))
 	for _, b := range bindings {
-		params = append(params, b.Spec.Params...)
+		out = append(out, newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, b.Name, b.Spec.Params))
 	}
 	for _, cb := range clusterbindings {
-		params = append(params, cb.Spec.Params...)
+		out = append(out, newResolvedBinding(triggersv1.ClusterTriggerBindingKind, cb.Name, cb.Spec.Params))
 	}
-	seen := make(map[string]bool, len(params))
-	for _, p := range params {
-		if seen[p.Name] {
-			return nil, fmt.Errorf("duplicate param name: %s", p.Name)
+	return out
+}
+
+// mergeDefaults returns the defaults of the params that mergeBindings merges,
+// by name. A param has the default of the binding whose value it takes.
+func mergeDefaults(bindings []ResolvedBinding) map[string]string {
+	defaults := map[string]string{}
+	for _, b := range bindings {
+		for _, p := range b.Params {
+			if d, ok := b.Defaults[p.Name]; ok {
+				defaults[p.Name] = d
+			} else {
+				delete(defaults, p.Name)
+			}
+		}
+	}
+	return defaults
+}
+
+// mergeBindings merges the params of the bindings in order. A param that
+// more than one binding sets fails the merge, unless the policy is
+// lastWins, in which case the value of the last binding is used. A param
+// that a single binding sets more than once always fails the merge.
+func mergeBindings(bindings []ResolvedBinding, policy triggersv1.BindingConflictPolicy) ([]pipelinev1.Param, error) {
+	params := []pipelinev1.Param{}
+	// index and setBy hold the position of each param and the binding
+	// that set it
+	index := map[string]int{}
+	setBy := map[string]string{}
+	for _, b := range bindings {
+		key := bindingKey(b.Kind, b.Name)
+		seen := make(map[string]bool, len(b.Params))
+		for _, p := range b.Params {
+			if seen[p.Name] {
+				return nil, &DuplicateParamError{Name: p.Name}
+			}
+			seen[p.Name] = true
+			i, ok := index[p.Name]
+			if !ok {
+				index[p.Name] = len(params)
+				setBy[p.Name] = key
+				params = append(params, p)
+				continue
+			}
+			if policy != triggersv1.BindingConflictLastWins {
+				return nil, &DuplicateParamError{Name: p.Name, Bindings: []string{setBy[p.Name], key}}
+			}
+			params[i] = p
+			setBy[p.Name] = key
 		}
-		seen[p.Name] = true
 	}
 	return params, nil
 }
@@ -18,6 +18,7 @@ package template
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/tektoncd/triggers/test"
 	bldr "github.com/tektoncd/triggers/test/builder"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func Test_MergeInDefaultParams(t *testing.T) {
@@ -36,37 +38,37 @@ func Test_MergeInDefaultParams(t *testing.T) {
 			Name:  "oneid",
 			Value: pipelinev1beta1.ArrayOrString{StringVal: "onevalue"},
 		}
-		oneParamSpec = pipelinev1beta1.ParamSpec{
+		oneParamSpec = triggersv1.ParamSpec{ParamSpec: pipelinev1beta1.ParamSpec{
 			Name:    "oneid",
 			Default: &pipelinev1beta1.ArrayOrString{StringVal: "onedefault"},
-		}
+		}}
 		wantDefaultOneParam = pipelinev1beta1.Param{
 			Name:  "oneid",
 			Value: pipelinev1beta1.ArrayOrString{StringVal: "onedefault"},
 		}
-		twoParamSpec = pipelinev1beta1.ParamSpec{
+		twoParamSpec = triggersv1.ParamSpec{ParamSpec: pipelinev1beta1.ParamSpec{
 			Name:    "twoid",
 			Default: &pipelinev1beta1.ArrayOrString{StringVal: "twodefault"},
-		}
+		}}
 		wantDefaultTwoParam = pipelinev1beta1.Param{
 			Name:  "twoid",
 			Value: pipelinev1beta1.ArrayOrString{StringVal: "twodefault"},
 		}
-		threeParamSpec = pipelinev1beta1.ParamSpec{
+		threeParamSpec = triggersv1.ParamSpec{ParamSpec: pipelinev1beta1.ParamSpec{
 			Name:    "threeid",
 			Default: &pipelinev1beta1.ArrayOrString{StringVal: "threedefault"},
-		}
+		}}
 		wantDefaultThreeParam = pipelinev1beta1.Param{
 			Name:  "threeid",
 			Value: pipelinev1beta1.ArrayOrString{StringVal: "threedefault"},
 		}
-		noDefaultParamSpec = pipelinev1beta1.ParamSpec{
+		noDefaultParamSpec = triggersv1.ParamSpec{ParamSpec: pipelinev1beta1.ParamSpec{
 			Name: "nodefault",
-		}
+		}}
 	)
 	type args struct {
 		params     []pipelinev1beta1.Param
-		paramSpecs []pipelinev1beta1.ParamSpec
+		paramSpecs []triggersv1.ParamSpec
 	}
 	tests := []struct {
 		name string
@@ -77,7 +79,7 @@ func Test_MergeInDefaultParams(t *testing.T) {
 			name: "add one default param",
 			args: args{
 				params:     []pipelinev1beta1.Param{},
-				paramSpecs: []pipelinev1beta1.ParamSpec{oneParamSpec},
+				paramSpecs: []triggersv1.ParamSpec{oneParamSpec},
 			},
 			want: []pipelinev1beta1.Param{wantDefaultOneParam},
 		},
@@ -85,7 +87,7 @@ func Test_MergeInDefaultParams(t *testing.T) {
 			name: "add multiple default params",
 			args: args{
 				params:     []pipelinev1beta1.Param{},
-				paramSpecs: []pipelinev1beta1.ParamSpec{oneParamSpec, twoParamSpec, threeParamSpec},
+				paramSpecs: []triggersv1.ParamSpec{oneParamSpec, twoParamSpec, threeParamSpec},
 			},
 			want: []pipelinev1beta1.Param{wantDefaultOneParam, wantDefaultTwoParam, wantDefaultThreeParam},
 		},
@@ -93,7 +95,7 @@ func Test_MergeInDefaultParams(t *testing.T) {
 			name: "do not override existing value",
 			args: args{
 				params:     []pipelinev1beta1.Param{oneParam},
-				paramSpecs: []pipelinev1beta1.ParamSpec{oneParamSpec},
+				paramSpecs: []triggersv1.ParamSpec{oneParamSpec},
 			},
 			want: []pipelinev1beta1.Param{oneParam},
 		},
@@ -101,7 +103,7 @@ func Test_MergeInDefaultParams(t *testing.T) {
 			name: "add no default params",
 			args: args{
 				params:     []pipelinev1beta1.Param{},
-				paramSpecs: []pipelinev1beta1.ParamSpec{noDefaultParamSpec},
+				paramSpecs: []triggersv1.ParamSpec{noDefaultParamSpec},
 			},
 			want: []pipelinev1beta1.Param{},
 		},
@@ -116,7 +118,7 @@ func Test_MergeInDefaultParams(t *testing.T) {
 	}
 }
 
-func Test_applyParamToResourceTemplate(t *testing.T) {
+func Test_ApplyParamsToResourceTemplate_oneParam(t *testing.T) {
 	var (
 		oneParam = pipelinev1beta1.Param{
 			Name:  "oneid",
@@ -187,9 +189,9 @@ func Test_applyParamToResourceTemplate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := applyParamToResourceTemplate(tt.args.param, tt.args.rt)
+			got := ApplyParamsToResourceTemplate([]pipelinev1beta1.Param{tt.args.param}, nil, tt.args.rt)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
-				t.Errorf("applyParamToResourceTemplate(): -want +got: %s", diff)
+				t.Errorf("ApplyParamsToResourceTemplate(): -want +got: %s", diff)
 			}
 		})
 	}
@@ -239,7 +241,7 @@ func Test_ApplyParamsToResourceTemplate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ApplyParamsToResourceTemplate(tt.args.params, tt.args.rt)
+			got := ApplyParamsToResourceTemplate(tt.args.params, nil, tt.args.rt)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("ApplyParamsToResourceTemplate(): -want +got: %s", diff)
 			}
@@ -255,13 +257,29 @@ var (
 		"tb-params": {
 			ObjectMeta: metav1.ObjectMeta{Name: "tb-params"},
 			Spec: triggersv1.TriggerBindingSpec{
-				Params: []pipelinev1beta1.Param{{
-					Name: "foo",
-					Value: pipelinev1beta1.ArrayOrString{
-						Type:      pipelinev1beta1.ParamTypeString,
-						StringVal: "bar",
-					},
-				}},
+				Params: []triggersv1.BindingParam{bldr.BindingParam("foo", "bar")},
+			},
+		},
+		"tb-includes": {
+			ObjectMeta: metav1.ObjectMeta{Name: "tb-includes"},
+			Spec: triggersv1.TriggerBindingSpec{
+				Includes: []triggersv1.TriggerBindingRef{
+					{Name: "tb-params"},
+					{Name: "ctb-params", Kind: triggersv1.ClusterTriggerBindingKind},
+				},
+				Params: []triggersv1.BindingParam{bldr.BindingParam("foo", "baz")},
+			},
+		},
+		"tb-cycle-a": {
+			ObjectMeta: metav1.ObjectMeta{Name: "tb-cycle-a"},
+			Spec: triggersv1.TriggerBindingSpec{
+				Includes: []triggersv1.TriggerBindingRef{{Name: "tb-cycle-b"}},
+			},
+		},
+		"tb-cycle-b": {
+			ObjectMeta: metav1.ObjectMeta{Name: "tb-cycle-b"},
+			Spec: triggersv1.TriggerBindingSpec{
+				Includes: []triggersv1.TriggerBindingRef{{Name: "tb-cycle-a"}},
 			},
 		},
 	}
@@ -276,13 +294,20 @@ var (
 		"ctb-params": {
 			ObjectMeta: metav1.ObjectMeta{Name: "ctb-params"},
 			Spec: triggersv1.TriggerBindingSpec{
-				Params: []pipelinev1beta1.Param{{
-					Name: "foo-ctb",
-					Value: pipelinev1beta1.ArrayOrString{
-						Type:      pipelinev1beta1.ParamTypeString,
-						StringVal: "bar-ctb",
-					},
-				}},
+				Params: []triggersv1.BindingParam{bldr.BindingParam("foo-ctb", "bar-ctb")},
+			},
+		},
+		"ctb-includes": {
+			ObjectMeta: metav1.ObjectMeta{Name: "ctb-includes"},
+			Spec: triggersv1.TriggerBindingSpec{
+				Includes: []triggersv1.TriggerBindingRef{{Name: "ctb-params"}},
+				Params:   []triggersv1.BindingParam{bldr.BindingParam("foo", "bar")},
+			},
+		},
+		"ctb-includes-tb": {
+			ObjectMeta: metav1.ObjectMeta{Name: "ctb-includes-tb"},
+			Spec: triggersv1.TriggerBindingSpec{
+				Includes: []triggersv1.TriggerBindingRef{{Name: "tb-params", Kind: triggersv1.NamespacedTriggerBindingKind}},
 			},
 		},
 	}
@@ -322,6 +347,7 @@ func Test_ResolveTrigger(t *testing.T) {
 				TriggerBindings:        []*triggersv1.TriggerBinding{tb},
 				ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{},
 				TriggerTemplate:        &tt,
+				Bindings:               []ResolvedBinding{newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, tb.Name, tb.Spec.Params)},
 			},
 		},
 		{
@@ -333,6 +359,7 @@ func Test_ResolveTrigger(t *testing.T) {
 				TriggerBindings:        []*triggersv1.TriggerBinding{},
 				ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{ctb},
 				TriggerTemplate:        &tt,
+				Bindings:               []ResolvedBinding{newResolvedBinding(triggersv1.ClusterTriggerBindingKind, ctb.Name, ctb.Spec.Params)},
 			},
 		},
 		{
@@ -343,7 +370,7 @@ func Test_ResolveTrigger(t *testing.T) {
 					APIVersion: "v1alpha1",
 				},
 			},
-			want: ResolvedTrigger{TriggerBindings: []*triggersv1.TriggerBinding{}, ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{}, TriggerTemplate: &tt},
+			want: ResolvedTrigger{TriggerBindings: []*triggersv1.TriggerBinding{}, ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{}, TriggerTemplate: &tt, Bindings: []ResolvedBinding{}},
 		},
 		{
 			name: "multiple bindings with builder",
@@ -355,6 +382,10 @@ func Test_ResolveTrigger(t *testing.T) {
 				TriggerBindings:        []*triggersv1.TriggerBinding{tb},
 				ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{ctb},
 				TriggerTemplate:        &tt,
+				Bindings: []ResolvedBinding{
+					newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, tb.Name, tb.Spec.Params),
+					newResolvedBinding(triggersv1.ClusterTriggerBindingKind, ctb.Name, ctb.Spec.Params),
+				},
 			},
 		},
 		{
@@ -397,6 +428,38 @@ func Test_ResolveTrigger(t *testing.T) {
 					clusterTriggerBindings["ctb-params"],
 				},
 				TriggerTemplate: &tt,
+				Bindings: []ResolvedBinding{
+					newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, tb.Name, tb.Spec.Params),
+					newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, "tb-params", triggerBindings["tb-params"].Spec.Params),
+					newResolvedBinding(triggersv1.ClusterTriggerBindingKind, ctb.Name, ctb.Spec.Params),
+					newResolvedBinding(triggersv1.ClusterTriggerBindingKind, "ctb-params", clusterTriggerBindings["ctb-params"].Spec.Params),
+				},
+			},
+		},
+		{
+			name: "binding with includes",
+			trigger: bldr.Trigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerBinding("tb-includes", "", "v1alpha1"),
+				bldr.EventListenerTriggerBinding("ctb-includes", "ClusterTriggerBinding", "v1alpha1"),
+			),
+			want: ResolvedTrigger{
+				TriggerBindings: []*triggersv1.TriggerBinding{{
+					ObjectMeta: metav1.ObjectMeta{Name: "tb-includes"},
+					Spec: triggersv1.TriggerBindingSpec{
+						Params: []triggersv1.BindingParam{bldr.BindingParam("foo", "baz"), bldr.BindingParam("foo-ctb", "bar-ctb")},
+					},
+				}},
+				ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{{
+					ObjectMeta: metav1.ObjectMeta{Name: "ctb-includes"},
+					Spec: triggersv1.TriggerBindingSpec{
+						Params: []triggersv1.BindingParam{bldr.BindingParam("foo-ctb", "bar-ctb"), bldr.BindingParam("foo", "bar")},
+					},
+				}},
+				TriggerTemplate: &tt,
+				Bindings: []ResolvedBinding{
+					{Kind: triggersv1.NamespacedTriggerBindingKind, Name: "tb-includes", Params: []pipelinev1beta1.Param{bldr.Param("foo", "baz"), bldr.Param("foo-ctb", "bar-ctb")}},
+					{Kind: triggersv1.ClusterTriggerBindingKind, Name: "ctb-includes", Params: []pipelinev1beta1.Param{bldr.Param("foo-ctb", "bar-ctb"), bldr.Param("foo", "bar")}},
+				},
 			},
 		},
 		{
@@ -415,6 +478,7 @@ func Test_ResolveTrigger(t *testing.T) {
 				TriggerBindings:        []*triggersv1.TriggerBinding{tb},
 				ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{},
 				TriggerTemplate:        &tt,
+				Bindings:               []ResolvedBinding{newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, tb.Name, tb.Spec.Params)},
 			},
 		},
 	}
@@ -466,6 +530,24 @@ func Test_ResolveTrigger_error(t *testing.T) {
 			getCTB: getCTB,
 			getTT:  getTT,
 		},
+		{
+			name: "error include cycle",
+			trigger: bldr.Trigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerBinding("tb-cycle-a", "", "v1alpha1"),
+			),
+			getTB:  getTB,
+			getCTB: getCTB,
+			getTT:  getTT,
+		},
+		{
+			name: "error clustertriggerbinding includes triggerbinding",
+			trigger: bldr.Trigger("my-triggertemplate", "v1alpha1",
+				bldr.EventListenerTriggerBinding("ctb-includes-tb", "ClusterTriggerBinding", "v1alpha1"),
+			),
+			getTB:  getTB,
+			getCTB: getCTB,
+			getTT:  getTT,
+		},
 		{
 			name: "error triggerbinding and triggertemplate",
 			trigger: bldr.Trigger("invalid-tt-name", "v1alpha1",
@@ -486,6 +568,69 @@ func Test_ResolveTrigger_error(t *testing.T) {
 	}
 }
 
+func Test_ResolvePinnedTrigger(t *testing.T) {
+	pinnedTB := &triggersv1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "tb", Generation: 2},
+		Spec: triggersv1.TriggerBindingSpec{
+			Params: []triggersv1.BindingParam{bldr.BindingParam("foo", "bar")},
+		},
+	}
+	pinnedTT := &triggersv1.TriggerTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "tt",
+			Labels: map[string]string{triggersv1.GroupName + triggersv1.VersionLabelKey: "v1"},
+		},
+	}
+	currentTB, currentTT := pinnedTB, pinnedTT
+	getTB := func(name string, options metav1.GetOptions) (*triggersv1.TriggerBinding, error) {
+		return currentTB, nil
+	}
+	getTT := func(name string, options metav1.GetOptions) (*triggersv1.TriggerTemplate, error) {
+		return currentTT, nil
+	}
+	trigger := triggersv1.EventListenerTrigger{
+		Bindings: []*triggersv1.EventListenerBinding{{Name: "tb", Kind: triggersv1.NamespacedTriggerBindingKind, Generation: 2}},
+		Template: triggersv1.EventListenerTemplate{Name: "tt", Version: "v1"},
+	}
+	want := ResolvedTrigger{
+		TriggerBindings:        []*triggersv1.TriggerBinding{pinnedTB},
+		ClusterTriggerBindings: []*triggersv1.ClusterTriggerBinding{},
+		TriggerTemplate:        pinnedTT,
+		Bindings:               []ResolvedBinding{newResolvedBinding(triggersv1.NamespacedTriggerBindingKind, "tb", pinnedTB.Spec.Params)},
+	}
+	pins := NewPins()
+
+	got, err := ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, pins)
+	if err != nil {
+		t.Fatalf("ResolvePinnedTrigger() returned unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolvePinnedTrigger(): -want +got: %s", diff)
+	}
+
+	// Updates do not change what pinned references resolve to
+	currentTB = pinnedTB.DeepCopy()
+	currentTB.Generation = 3
+	currentTB.Spec.Params = []triggersv1.BindingParam{bldr.BindingParam("foo", "baz")}
+	currentTT = pinnedTT.DeepCopy()
+	currentTT.Labels[triggersv1.GroupName+triggersv1.VersionLabelKey] = "v2"
+	got, err = ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, pins)
+	if err != nil {
+		t.Fatalf("ResolvePinnedTrigger() after the updates returned unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolvePinnedTrigger() after the updates: -want +got: %s", diff)
+	}
+
+	// Without a copy of the pinned objects, they fail to resolve
+	if _, err := ResolvePinnedTrigger(trigger, getTB, getCTB, getTT, NewPins()); err == nil {
+		t.Error("ResolvePinnedTrigger() did not return error for objects that do not match the pins")
+	}
+	if _, err := ResolveTrigger(trigger, getTB, getCTB, getTT); err == nil {
+		t.Error("ResolveTrigger() did not return error for objects that do not match the pins")
+	}
+}
+
 func Test_ApplyUIDToResourceTemplate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -520,6 +665,52 @@ func Test_ApplyUIDToResourceTemplate(t *testing.T) {
 	}
 }
 
+func Test_ApplyTriggerContextToResourceTemplate(t *testing.T) {
+	tc := TriggerContext{TriggerName: "github-push", TriggerNamespace: "ci", ListenerName: "listener"}
+	tests := []struct {
+		name string
+		rt   json.RawMessage
+		want json.RawMessage
+	}{{
+		name: "no variables",
+		rt:   json.RawMessage(`{"rt": "nothing to see here"}`),
+		want: json.RawMessage(`{"rt": "nothing to see here"}`),
+	}, {
+		name: "all variables",
+		rt:   json.RawMessage(`{"metadata": {"generateName": "$(tt.listenerName)-$(tt.triggerName)-", "labels": {"ns": "$(tt.triggerNamespace)", "trigger": "$(tt.triggerName)"}}}`),
+		want: json.RawMessage(`{"metadata": {"generateName": "listener-github-push-", "labels": {"ns": "ci", "trigger": "github-push"}}}`),
+	}, {
+		name: "unknown variable",
+		rt:   json.RawMessage(`{"rt": "$(tt.eventListener)"}`),
+		want: json.RawMessage(`{"rt": "$(tt.eventListener)"}`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ApplyTriggerContextToResourceTemplate(tt.rt, tc)
+			if diff := cmp.Diff(string(tt.want), string(got)); diff != "" {
+				t.Errorf("ApplyTriggerContextToResourceTemplate(): -want +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveResourcesForTrigger(t *testing.T) {
+	template := bldr.TriggerTemplate("tt", ns, bldr.TriggerTemplateSpec(
+		bldr.TriggerTemplateParam("p1", "", ""),
+		bldr.TriggerResourceTemplate(runtime.RawExtension{Raw: []byte(`{"rt": "$(tt.listenerName)/$(tt.triggerName): $(params.p1) $(uid)"}`)}),
+	))
+	// Variables in param values are not replaced
+	params := []pipelinev1beta1.Param{bldr.Param("p1", "$(tt.triggerNamespace)")}
+	got, err := ResolveResourcesForTrigger(template, params, "abcde", TriggerContext{TriggerName: "push", TriggerNamespace: "ci", ListenerName: "listener"})
+	if err != nil {
+		t.Fatalf("ResolveResourcesForTrigger() returned unexpected error: %s", err)
+	}
+	want := []json.RawMessage{json.RawMessage(`{"rt": "listener/push: $(tt.triggerNamespace) abcde"}`)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveResourcesForTrigger(): -want +got: %s", diff)
+	}
+}
+
 func TestMergeBindingParams(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -641,3 +832,100 @@ func TestMergeBindingParams(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeBindingParams_DuplicateParamError(t *testing.T) {
+	bindings := []*triggersv1.TriggerBinding{
+		bldr.TriggerBinding("", "", bldr.TriggerBindingSpec(
+			bldr.TriggerBindingParam("param1", "value1"),
+			bldr.TriggerBindingParam("param1", "value3"),
+		)),
+	}
+	_, err := MergeBindingParams(bindings, nil)
+	var dupErr *DuplicateParamError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("MergeBindingParams() error = %v, want a *DuplicateParamError", err)
+	}
+	if dupErr.Name != "param1" {
+		t.Errorf("DuplicateParamError.Name = %s, want param1", dupErr.Name)
+	}
+}
+
+func Test_mergeBindings(t *testing.T) {
+	bindings := []ResolvedBinding{{
+		Kind:   triggersv1.ClusterTriggerBindingKind,
+		Name:   "base",
+		Params: []pipelinev1beta1.Param{bldr.Param("environment", "staging"), bldr.Param("url", "$(body.url)")},
+	}, {
+		Kind:   triggersv1.NamespacedTriggerBindingKind,
+		Name:   "prod",
+		Params: []pipelinev1beta1.Param{bldr.Param("environment", "prod")},
+	}}
+
+	got, err := mergeBindings(bindings, triggersv1.BindingConflictLastWins)
+	if err != nil {
+		t.Fatalf("mergeBindings() returned unexpected error: %s", err)
+	}
+	want := []pipelinev1beta1.Param{bldr.Param("environment", "prod"), bldr.Param("url", "$(body.url)")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeBindings() -want +got: %s", diff)
+	}
+
+	_, err = mergeBindings(bindings, triggersv1.BindingConflictFail)
+	wantErr := "duplicate param name: environment is set by both ClusterTriggerBinding/base and TriggerBinding/prod"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("mergeBindings() error = %v, want %q", err, wantErr)
+	}
+
+	// A binding that sets a param twice is invalid regardless of the policy
+	bindings[1].Params = append(bindings[1].Params, bldr.Param("environment", "dev"))
+	if _, err := mergeBindings(bindings, triggersv1.BindingConflictLastWins); err == nil {
+		t.Error("mergeBindings() expected error for a param set twice by a binding")
+	}
+}
+
+func Test_mergeDefaults(t *testing.T) {
+	bindings := []ResolvedBinding{{
+		Kind:     triggersv1.ClusterTriggerBindingKind,
+		Name:     "base",
+		Params:   []pipelinev1beta1.Param{bldr.Param("environment", "$(body.env)"), bldr.Param("url", "$(body.url)")},
+		Defaults: map[string]string{"environment": "staging", "url": "https://example.com"},
+	}, {
+		Kind:   triggersv1.NamespacedTriggerBindingKind,
+		Name:   "prod",
+		Params: []pipelinev1beta1.Param{bldr.Param("environment", "$(body.environment)")},
+	}}
+
+	// A param takes the default of the binding whose value it takes
+	want := map[string]string{"url": "https://example.com"}
+	if diff := cmp.Diff(want, mergeDefaults(bindings)); diff != "" {
+		t.Errorf("mergeDefaults() -want +got: %s", diff)
+	}
+}
+
+func Test_overrideSpec(t *testing.T) {
+	withDefault := func(p triggersv1.BindingParam, def string) triggersv1.BindingParam {
+		p.Default = &def
+		return p
+	}
+	included := triggersv1.TriggerBindingSpec{
+		Params: []triggersv1.BindingParam{
+			withDefault(bldr.BindingParam("revision", "$(body.sha)"), "main"),
+			withDefault(bldr.BindingParam("url", "$(body.url)"), "https://example.com"),
+		},
+	}
+	spec := triggersv1.TriggerBindingSpec{
+		Params: []triggersv1.BindingParam{bldr.BindingParam("url", "$(body.repository.url)")},
+	}
+
+	// Overridden params lose the defaults of the included binding
+	got := overrideSpec(included, spec)
+	want := triggersv1.TriggerBindingSpec{
+		Params: []triggersv1.BindingParam{
+			withDefault(bldr.BindingParam("revision", "$(body.sha)"), "main"),
+			bldr.BindingParam("url", "$(body.repository.url)"),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("overrideSpec() -want +got: %s", diff)
+	}
+}
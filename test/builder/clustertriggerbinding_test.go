@@ -52,14 +52,14 @@ func TestClusterTriggerBindingBuilder(t *testing.T) {
 					Name: "name",
 				},
 				Spec: v1alpha1.TriggerBindingSpec{
-					Params: []pipelinev1.Param{
-						{
+					Params: []v1alpha1.BindingParam{
+						{Param: pipelinev1.Param{
 							Name: "param1",
 							Value: pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -76,21 +76,21 @@ func TestClusterTriggerBindingBuilder(t *testing.T) {
 					Name: "name",
 				},
 				Spec: v1alpha1.TriggerBindingSpec{
-					Params: []pipelinev1.Param{
-						{
+					Params: []v1alpha1.BindingParam{
+						{Param: pipelinev1.Param{
 							Name: "param1",
 							Value: pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
-						{
+						}},
+						{Param: pipelinev1.Param{
 							Name: "param2",
 							Value: pipelinev1.ArrayOrString{
 								StringVal: "value2",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 				},
 			},
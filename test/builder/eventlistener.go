@@ -5,6 +5,7 @@ import (
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 )
@@ -73,6 +74,140 @@ func EventListenerServiceAccount(saName string) EventListenerSpecOp {
 	}
 }
 
+// EventListenerMaintenance puts the EventListener into maintenance mode with the
+// specified Retry-After delay.
+func EventListenerMaintenance(retryAfterSeconds int32) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Maintenance = &v1alpha1.MaintenanceMode{RetryAfterSeconds: retryAfterSeconds}
+	}
+}
+
+// EventListenerNamespaceSelector serves the Triggers of the EventListener in
+// the namespaces with the specified labels.
+func EventListenerNamespaceSelector(matchLabels map[string]string) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+// EventListenerSQS sets the SQS queue that the EventListener consumes.
+func EventListenerSQS(source v1alpha1.SQSSource) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.SQS = &source
+	}
+}
+
+// EventListenerAdmin sets the admin endpoints of the EventListener.
+func EventListenerAdmin(admin v1alpha1.AdminEndpoints) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Admin = &admin
+	}
+}
+
+// EventListenerPrometheusMonitor sets the Prometheus Operator monitor of the
+// EventListenerSpec.
+func EventListenerPrometheusMonitor(monitor v1alpha1.PrometheusMonitor) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.PrometheusMonitor = &monitor
+	}
+}
+
+// EventListenerAutoscaling sets the KEDA autoscaling of the EventListenerSpec.
+func EventListenerAutoscaling(autoscaling v1alpha1.QueueAutoscaling) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Autoscaling = &autoscaling
+	}
+}
+
+// EventListenerReplicas sets the replicas of the EventListenerSpec.
+func EventListenerReplicas(replicas int32) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Replicas = &replicas
+	}
+}
+
+// EventListenerAvailability sets the availability of the EventListenerSpec.
+func EventListenerAvailability(availability v1alpha1.Availability) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Availability = &availability
+	}
+}
+
+// EventListenerEventTimeout sets how long the sink processes an event.
+func EventListenerEventTimeout(seconds int32) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.EventTimeoutSeconds = seconds
+	}
+}
+
+// EventListenerPhaseTimeouts sets the phase timeouts of the EventListener.
+func EventListenerPhaseTimeouts(timeouts v1alpha1.PhaseTimeouts) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.PhaseTimeouts = &timeouts
+	}
+}
+
+// EventListenerDeduplication sets the Deduplication of the EventListener.
+func EventListenerDeduplication(d v1alpha1.Deduplication) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Deduplication = &d
+	}
+}
+
+// EventListenerRuntime sets the Runtime of the EventListener.
+func EventListenerRuntime(r v1alpha1.SinkRuntime) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Runtime = &r
+	}
+}
+
+// EventListenerAuthFailureBlock blocks the sources of events that fail
+// authentication too often.
+func EventListenerAuthFailureBlock(b v1alpha1.AuthFailureBlock) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.AuthFailureBlock = &b
+	}
+}
+
+// EventListenerManual enables firing the Triggers on demand with the bearer
+// token in the key of the Secret.
+func EventListenerManual(secretName, secretKey string) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Manual = &v1alpha1.ManualTriggering{
+			TokenSecret: v1alpha1.SecretRef{SecretName: secretName, SecretKey: secretKey},
+		}
+	}
+}
+
+// EventListenerTrustedSenders skips the signature and token validation of
+// interceptors for the events of the senders.
+func EventListenerTrustedSenders(t v1alpha1.TrustedSenders) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.TrustedSenders = &t
+	}
+}
+
+// EventListenerOpenShift sets the OpenShift integration of the EventListener.
+func EventListenerOpenShift(o v1alpha1.OpenShift) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.OpenShift = &o
+	}
+}
+
+// EventListenerTLSSecret sets the TLS Secret that the sink serves HTTPS with.
+func EventListenerTLSSecret(secret string) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.TLSSecret = secret
+	}
+}
+
+// EventListenerCallback sets the callback of the EventListener.
+func EventListenerCallback(c v1alpha1.EventCallback) EventListenerSpecOp {
+	return func(spec *v1alpha1.EventListenerSpec) {
+		spec.Callback = &c
+	}
+}
+
 // EventListenerTrigger adds an EventListenerTrigger to the EventListenerSpec Triggers.
 // Any number of EventListenerTriggerOp modifiers can be passed to create/modify it.
 func EventListenerTrigger(ttName, apiVersion string, ops ...EventListenerTriggerOp) EventListenerSpecOp {
@@ -151,6 +286,56 @@ func EventListenerTriggerName(name string) EventListenerTriggerOp {
 	}
 }
 
+// EventListenerTriggerCapture sets the EventCapture of the EventListenerTrigger.
+func EventListenerTriggerCapture(capture v1alpha1.EventCapture) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.Capture = &capture
+	}
+}
+
+// EventListenerTriggerMaxEventAge sets the EventAge of the EventListenerTrigger.
+func EventListenerTriggerMaxEventAge(age v1alpha1.EventAge) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.MaxEventAge = &age
+	}
+}
+
+// EventListenerTriggerCluster sets the RemoteCluster of the EventListenerTrigger.
+func EventListenerTriggerCluster(c v1alpha1.RemoteCluster) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.Cluster = &c
+	}
+}
+
+// EventListenerTriggerMatchHeader adds a HeaderMatch to the EventListenerTrigger.
+func EventListenerTriggerMatchHeader(m v1alpha1.HeaderMatch) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.MatchHeaders = append(trigger.MatchHeaders, m)
+	}
+}
+
+// EventListenerTriggerOnMissingField sets the MissingFieldPolicy of the EventListenerTrigger.
+func EventListenerTriggerOnMissingField(policy v1alpha1.MissingFieldPolicy) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.OnMissingField = policy
+	}
+}
+
+// EventListenerTriggerOnBindingConflict sets the BindingConflictPolicy of the EventListenerTrigger.
+func EventListenerTriggerOnBindingConflict(policy v1alpha1.BindingConflictPolicy) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.OnBindingConflict = policy
+	}
+}
+
+// EventListenerTriggerPhaseTimeouts sets the phase timeouts of the
+// EventListenerTrigger.
+func EventListenerTriggerPhaseTimeouts(timeouts v1alpha1.PhaseTimeouts) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.PhaseTimeouts = &timeouts
+	}
+}
+
 // EventListenerTriggerServiceAccount set the specified ServiceAccount of the EventListenerTrigger.
 func EventListenerTriggerServiceAccount(saName, namespace string) EventListenerTriggerOp {
 	return func(trigger *v1alpha1.EventListenerTrigger) {
@@ -161,6 +346,16 @@ func EventListenerTriggerServiceAccount(saName, namespace string) EventListenerT
 	}
 }
 
+// EventListenerTriggerInterceptorServiceAccount sets the ServiceAccount that the interceptors of the EventListenerTrigger read Secrets with.
+func EventListenerTriggerInterceptorServiceAccount(name, namespace string) EventListenerTriggerOp {
+	return func(trigger *v1alpha1.EventListenerTrigger) {
+		trigger.InterceptorServiceAccount = &corev1.ObjectReference{
+			Namespace: namespace,
+			Name:      name,
+		}
+	}
+}
+
 // EventListenerTriggerBinding adds a Binding to the Trigger in EventListenerSpec Triggers.
 func EventListenerTriggerBinding(name, kind, apiVersion string) EventListenerTriggerOp {
 	return func(trigger *v1alpha1.EventListenerTrigger) {
@@ -224,6 +419,27 @@ func EventInterceptorParam(name, value string) EventInterceptorOp {
 	}
 }
 
+// EventInterceptorRetries sets the retries and the retry backoff of the
+// webhook of the EventInterceptor.
+func EventInterceptorRetries(retries int32, backoff string) EventInterceptorOp {
+	return func(i *v1alpha1.EventInterceptor) {
+		if i.Webhook != nil {
+			i.Webhook.Retries = &retries
+			i.Webhook.RetryBackoff = backoff
+		}
+	}
+}
+
+// EventListenerInterceptorChain adds a reference to an InterceptorChain to the
+// EventListenerTrigger.
+func EventListenerInterceptorChain(name string) EventListenerTriggerOp {
+	return func(t *v1alpha1.EventListenerTrigger) {
+		t.Interceptors = append(t.Interceptors, &v1alpha1.EventInterceptor{
+			Chain: &v1alpha1.InterceptorChainRef{Name: name},
+		})
+	}
+}
+
 // EventListenerCELInterceptor adds a CEL filter to the EventListenerTrigger.
 func EventListenerCELInterceptor(filter string, ops ...EventInterceptorOp) EventListenerTriggerOp {
 	return func(t *v1alpha1.EventListenerTrigger) {
@@ -249,3 +465,28 @@ func EventListenerCELOverlay(key, expression string) EventInterceptorOp {
 		}
 	}
 }
+
+// EventListenerClusterInterceptor adds an interceptor referencing the named
+// ClusterInterceptor to the EventListenerTrigger.
+func EventListenerClusterInterceptor(name string, ops ...EventInterceptorOp) EventListenerTriggerOp {
+	return func(t *v1alpha1.EventListenerTrigger) {
+		i := &v1alpha1.EventInterceptor{
+			Ref: &v1alpha1.InterceptorRef{Name: name},
+		}
+		for _, op := range ops {
+			op(i)
+		}
+		t.Interceptors = append(t.Interceptors, i)
+	}
+}
+
+// EventInterceptorRefParam adds a param with the given JSON value to the
+// EventInterceptor.
+func EventInterceptorRefParam(name, value string) EventInterceptorOp {
+	return func(i *v1alpha1.EventInterceptor) {
+		if i.Params == nil {
+			i.Params = map[string]runtime.RawExtension{}
+		}
+		i.Params[name] = runtime.RawExtension{Raw: []byte(value)}
+	}
+}
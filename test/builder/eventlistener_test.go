@@ -9,6 +9,7 @@ import (
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1alpha1 "knative.dev/pkg/apis/duck/v1alpha1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -451,6 +452,35 @@ func TestEventListenerBuilder(t *testing.T) {
 				),
 			),
 		),
+	}, {
+		name: "One Trigger with ClusterInterceptor",
+		normal: &v1alpha1.EventListener{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+			},
+			Spec: v1alpha1.EventListenerSpec{
+				Triggers: []v1alpha1.EventListenerTrigger{{
+					Interceptors: []*v1alpha1.EventInterceptor{{
+						Ref: &v1alpha1.InterceptorRef{Name: "github"},
+						Params: map[string]runtime.RawExtension{
+							"secretName": {Raw: []byte(`"github-secret"`)},
+						},
+					}},
+					Template: v1alpha1.EventListenerTemplate{
+						Name:       "tt1",
+						APIVersion: "v1alpha1",
+					},
+				}},
+			},
+		},
+		builder: EventListener("name", "namespace",
+			EventListenerSpec(
+				EventListenerTrigger("tt1", "v1alpha1",
+					EventListenerClusterInterceptor("github", EventInterceptorRefParam("secretName", `"github-secret"`)),
+				),
+			),
+		),
 	},
 	}
 	for _, tt := range tests {
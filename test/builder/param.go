@@ -1,6 +1,9 @@
 package builder
 
-import "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+)
 
 func Param(name, value string) v1beta1.Param {
 	return v1beta1.Param{
@@ -11,3 +14,8 @@ func Param(name, value string) v1beta1.Param {
 		},
 	}
 }
+
+// BindingParam returns a binding param without a default.
+func BindingParam(name, value string) v1alpha1.BindingParam {
+	return v1alpha1.BindingParam{Param: Param(name, value)}
+}
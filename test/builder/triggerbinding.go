@@ -56,13 +56,34 @@ func TriggerBindingSpec(ops ...TriggerBindingSpecOp) TriggerBindingOp {
 // TriggerBindingParam adds a param to the TriggerBindingSpec.
 func TriggerBindingParam(name, value string) TriggerBindingSpecOp {
 	return func(spec *v1alpha1.TriggerBindingSpec) {
-		spec.Params = append(spec.Params,
-			pipelinev1.Param{
+		spec.Params = append(spec.Params, v1alpha1.BindingParam{
+			Param: pipelinev1.Param{
 				Name: name,
 				Value: pipelinev1.ArrayOrString{
 					StringVal: value,
 					Type:      pipelinev1.ParamTypeString,
 				},
-			})
+			},
+		})
+	}
+}
+
+// TriggerBindingParamWithDefault adds a param with the default value it takes
+// when its value refers to a field that is not in the event.
+func TriggerBindingParamWithDefault(name, value, def string) TriggerBindingSpecOp {
+	return func(spec *v1alpha1.TriggerBindingSpec) {
+		TriggerBindingParam(name, value)(spec)
+		spec.Params[len(spec.Params)-1].Default = &def
+	}
+}
+
+// TriggerBindingInclude adds a reference to a binding whose params are
+// inherited by the TriggerBindingSpec.
+func TriggerBindingInclude(name string, kind v1alpha1.TriggerBindingKind) TriggerBindingSpecOp {
+	return func(spec *v1alpha1.TriggerBindingSpec) {
+		spec.Includes = append(spec.Includes, v1alpha1.TriggerBindingRef{
+			Name: name,
+			Kind: kind,
+		})
 	}
 }
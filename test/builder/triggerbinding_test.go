@@ -38,14 +38,14 @@ func TestTriggerBindingBuilder(t *testing.T) {
 					Namespace: "namespace",
 				},
 				Spec: v1alpha1.TriggerBindingSpec{
-					Params: []pipelinev1.Param{
-						{
+					Params: []v1alpha1.BindingParam{
+						{Param: pipelinev1.Param{
 							Name: "param1",
 							Value: pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -63,21 +63,21 @@ func TestTriggerBindingBuilder(t *testing.T) {
 					Namespace: "namespace",
 				},
 				Spec: v1alpha1.TriggerBindingSpec{
-					Params: []pipelinev1.Param{
-						{
+					Params: []v1alpha1.BindingParam{
+						{Param: pipelinev1.Param{
 							Name: "param1",
 							Value: pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
-						{
+						}},
+						{Param: pipelinev1.Param{
 							Name: "param2",
 							Value: pipelinev1.ArrayOrString{
 								StringVal: "value2",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -67,17 +67,54 @@ func TriggerResourceTemplate(resourceTemplate runtime.RawExtension) TriggerTempl
 	}
 }
 
+// TriggerTemplatePatch adds a patch of the rendered resources to the
+// TriggerTemplateSpec.
+func TriggerTemplatePatch(patch runtime.RawExtension) TriggerTemplateSpecOp {
+	return func(spec *v1alpha1.TriggerTemplateSpec) {
+		spec.Patches = append(spec.Patches,
+			v1alpha1.TriggerResourcePatch{
+				RawExtension: patch,
+			})
+	}
+}
+
 // TriggerTemplateParam adds a ParamSpec to the TriggerTemplateSpec.
 func TriggerTemplateParam(name, description, defaultValue string) TriggerTemplateSpecOp {
 	return func(spec *v1alpha1.TriggerTemplateSpec) {
-		spec.Params = append(spec.Params,
-			pipelinev1.ParamSpec{
+		spec.Params = append(spec.Params, v1alpha1.ParamSpec{
+			ParamSpec: pipelinev1.ParamSpec{
 				Name:        name,
 				Description: description,
 				Default: &pipelinev1.ArrayOrString{
 					StringVal: defaultValue,
 					Type:      pipelinev1.ParamTypeString,
 				},
-			})
+			},
+		})
+	}
+}
+
+// TriggerTemplateSensitiveParam adds a ParamSpec whose value is redacted to
+// the TriggerTemplateSpec.
+func TriggerTemplateSensitiveParam(name, description, defaultValue string) TriggerTemplateSpecOp {
+	return func(spec *v1alpha1.TriggerTemplateSpec) {
+		TriggerTemplateParam(name, description, defaultValue)(spec)
+		spec.Params[len(spec.Params)-1].Sensitive = true
+	}
+}
+
+// TriggerTemplateEscapedParam adds a ParamSpec with the given escape to the
+// TriggerTemplateSpec.
+func TriggerTemplateEscapedParam(name, description, defaultValue string, escape v1alpha1.ParamEscape) TriggerTemplateSpecOp {
+	return func(spec *v1alpha1.TriggerTemplateSpec) {
+		TriggerTemplateParam(name, description, defaultValue)(spec)
+		spec.Params[len(spec.Params)-1].Escape = escape
+	}
+}
+
+// TriggerTemplateEngine sets the rendering engine of the TriggerTemplateSpec.
+func TriggerTemplateEngine(engine v1alpha1.TemplateEngine) TriggerTemplateSpecOp {
+	return func(spec *v1alpha1.TriggerTemplateSpec) {
+		spec.Engine = engine
 	}
 }
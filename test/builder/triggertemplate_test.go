@@ -39,15 +39,15 @@ func TestTriggerTemplateBuilder(t *testing.T) {
 					Namespace: "namespace",
 				},
 				Spec: v1alpha1.TriggerTemplateSpec{
-					Params: []pipelinev1.ParamSpec{
-						{
+					Params: []v1alpha1.ParamSpec{
+						{ParamSpec: pipelinev1.ParamSpec{
 							Name:        "param1",
 							Description: "description",
 							Default: &pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -65,23 +65,23 @@ func TestTriggerTemplateBuilder(t *testing.T) {
 					Namespace: "namespace",
 				},
 				Spec: v1alpha1.TriggerTemplateSpec{
-					Params: []pipelinev1.ParamSpec{
-						{
+					Params: []v1alpha1.ParamSpec{
+						{ParamSpec: pipelinev1.ParamSpec{
 							Name:        "param1",
 							Description: "description",
 							Default: &pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
-						{
+						}},
+						{ParamSpec: pipelinev1.ParamSpec{
 							Name:        "param2",
 							Description: "description",
 							Default: &pipelinev1.ArrayOrString{
 								StringVal: "value2",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 				},
 			},
@@ -153,23 +153,23 @@ func TestTriggerTemplateBuilder(t *testing.T) {
 					APIVersion: "v1alpha1",
 				},
 				Spec: v1alpha1.TriggerTemplateSpec{
-					Params: []pipelinev1.ParamSpec{
-						{
+					Params: []v1alpha1.ParamSpec{
+						{ParamSpec: pipelinev1.ParamSpec{
 							Name:        "param1",
 							Description: "description",
 							Default: &pipelinev1.ArrayOrString{
 								StringVal: "value1",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
-						{
+						}},
+						{ParamSpec: pipelinev1.ParamSpec{
 							Name:        "param2",
 							Description: "description",
 							Default: &pipelinev1.ArrayOrString{
 								StringVal: "value2",
 								Type:      pipelinev1.ParamTypeString,
 							},
-						},
+						}},
 					},
 					ResourceTemplates: []v1alpha1.TriggerResourceTemplate{
 						{
@@ -194,6 +194,42 @@ func TestTriggerTemplateBuilder(t *testing.T) {
 				),
 			),
 		},
+		{
+			name: "Engine",
+			normal: &v1alpha1.TriggerTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.TriggerTemplateSpec{
+					Engine: v1alpha1.GoTemplateEngine,
+				},
+			},
+			builder: TriggerTemplate("name", "namespace",
+				TriggerTemplateSpec(
+					TriggerTemplateEngine(v1alpha1.GoTemplateEngine),
+				),
+			),
+		},
+		{
+			name: "Patch",
+			normal: &v1alpha1.TriggerTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: v1alpha1.TriggerTemplateSpec{
+					Patches: []v1alpha1.TriggerResourcePatch{{
+						RawExtension: runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"a":"b"}}}`)},
+					}},
+				},
+			},
+			builder: TriggerTemplate("name", "namespace",
+				TriggerTemplateSpec(
+					TriggerTemplatePatch(runtime.RawExtension{Raw: []byte(`{"metadata":{"labels":{"a":"b"}}}`)}),
+				),
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -30,8 +30,12 @@ import (
 	dynamicclientset "github.com/tektoncd/triggers/pkg/client/dynamic/clientset"
 	"github.com/tektoncd/triggers/pkg/client/dynamic/clientset/tekton"
 	faketriggersclient "github.com/tektoncd/triggers/pkg/client/injection/client/fake"
+	fakeclustereventlistenerinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/clustereventlistener/fake"
+	fakeclusterinterceptorinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/clusterinterceptor/fake"
 	fakeclustertriggerbindinginformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/clustertriggerbinding/fake"
 	fakeeventlistenerinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/eventlistener/fake"
+	fakeinterceptorchaininformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/interceptorchain/fake"
+	fakerepositoryinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/repository/fake"
 	faketriggerbindinginformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/triggerbinding/fake"
 	faketriggertemplateinformer "github.com/tektoncd/triggers/pkg/client/injection/informers/triggers/v1alpha1/triggertemplate/fake"
 	appsv1 "k8s.io/api/apps/v1"
@@ -47,14 +51,21 @@ import (
 	fakeserviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
 	"knative.dev/pkg/controller"
+	// Link in the fake dynamic client for controllers that manage resources
+	// whose types are not vendored
+	_ "knative.dev/pkg/injection/clients/dynamicclient/fake"
 )
 
 // Resources represents the desired state of the system (i.e. existing resources)
 // to seed controllers with.
 type Resources struct {
 	Namespaces             []*corev1.Namespace
+	ClusterEventListeners  []*v1alpha1.ClusterEventListener
+	ClusterInterceptors    []*v1alpha1.ClusterInterceptor
 	ClusterTriggerBindings []*v1alpha1.ClusterTriggerBinding
 	EventListeners         []*v1alpha1.EventListener
+	InterceptorChains      []*v1alpha1.InterceptorChain
+	Repositories           []*v1alpha1.Repository
 	TriggerBindings        []*v1alpha1.TriggerBinding
 	TriggerTemplates       []*v1alpha1.TriggerTemplate
 	Deployments            []*appsv1.Deployment
@@ -93,8 +104,12 @@ func SeedResources(t *testing.T, ctx context.Context, r Resources) Clients {
 	}
 
 	// Setup fake informer for reconciler tests
+	celInformer := fakeclustereventlistenerinformer.Get(ctx)
+	ciInformer := fakeclusterinterceptorinformer.Get(ctx)
 	ctbInformer := fakeclustertriggerbindinginformer.Get(ctx)
 	elInformer := fakeeventlistenerinformer.Get(ctx)
+	icInformer := fakeinterceptorchaininformer.Get(ctx)
+	repoInformer := fakerepositoryinformer.Get(ctx)
 	ttInformer := faketriggertemplateinformer.Get(ctx)
 	tbInformer := faketriggerbindinginformer.Get(ctx)
 	deployInformer := fakedeployinformer.Get(ctx)
@@ -110,6 +125,22 @@ func SeedResources(t *testing.T, ctx context.Context, r Resources) Clients {
 		}
 	}
 	// Create test Resources
+	for _, cel := range r.ClusterEventListeners {
+		if err := celInformer.Informer().GetIndexer().Add(cel); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Triggers.TriggersV1alpha1().ClusterEventListeners().Create(cel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ci := range r.ClusterInterceptors {
+		if err := ciInformer.Informer().GetIndexer().Add(ci); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Triggers.TriggersV1alpha1().ClusterInterceptors().Create(ci); err != nil {
+			t.Fatal(err)
+		}
+	}
 	for _, ctb := range r.ClusterTriggerBindings {
 		if err := ctbInformer.Informer().GetIndexer().Add(ctb); err != nil {
 			t.Fatal(err)
@@ -134,6 +165,22 @@ func SeedResources(t *testing.T, ctx context.Context, r Resources) Clients {
 			t.Fatal(err)
 		}
 	}
+	for _, ic := range r.InterceptorChains {
+		if err := icInformer.Informer().GetIndexer().Add(ic); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Triggers.TriggersV1alpha1().InterceptorChains(ic.Namespace).Create(ic); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, repo := range r.Repositories {
+		if err := repoInformer.Informer().GetIndexer().Add(repo); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Triggers.TriggersV1alpha1().Repositories(repo.Namespace).Create(repo); err != nil {
+			t.Fatal(err)
+		}
+	}
 	for _, tt := range r.TriggerTemplates {
 		if err := ttInformer.Informer().GetIndexer().Add(tt); err != nil {
 			t.Fatal(err)
@@ -196,6 +243,14 @@ func SeedResources(t *testing.T, ctx context.Context, r Resources) Clients {
 // nolint: golint
 func GetResourcesFromClients(c Clients) (*Resources, error) {
 	testResources := &Resources{}
+	// Add ClusterEventListeners
+	celList, err := c.Triggers.TriggersV1alpha1().ClusterEventListeners().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cel := range celList.Items {
+		testResources.ClusterEventListeners = append(testResources.ClusterEventListeners, cel.DeepCopy())
+	}
 	// Add ClusterTriggerBindings
 	ctbList, err := c.Triggers.TriggersV1alpha1().ClusterTriggerBindings().List(metav1.ListOptions{})
 	if err != nil {
@@ -219,6 +274,22 @@ func GetResourcesFromClients(c Clients) (*Resources, error) {
 		for _, el := range elList.Items {
 			testResources.EventListeners = append(testResources.EventListeners, el.DeepCopy())
 		}
+		// Add InterceptorChains
+		icList, err := c.Triggers.TriggersV1alpha1().InterceptorChains(ns.Name).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, ic := range icList.Items {
+			testResources.InterceptorChains = append(testResources.InterceptorChains, ic.DeepCopy())
+		}
+		// Add Repositories
+		repoList, err := c.Triggers.TriggersV1alpha1().Repositories(ns.Name).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repoList.Items {
+			testResources.Repositories = append(testResources.Repositories, repo.DeepCopy())
+		}
 		// Add TriggerBindings
 		tbList, err := c.Triggers.TriggersV1alpha1().TriggerBindings(ns.Name).List(metav1.ListOptions{})
 		if err != nil {
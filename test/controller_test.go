@@ -17,12 +17,17 @@ limitations under the License.
 package test
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"github.com/tektoncd/triggers/pkg/client/clientset/versioned/scheme"
+	fakefactory "github.com/tektoncd/triggers/pkg/client/injection/informers/factory/fake"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
@@ -228,3 +233,25 @@ func TestGetResourcesFromClients(t *testing.T) {
 		})
 	}
 }
+
+// TestInjectionInformers checks that the injection informer factory serves
+// every Triggers kind, so that a new CRD is not published without informers.
+func TestInjectionInformers(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	f := fakefactory.Get(ctx)
+	pkgPath := reflect.TypeOf(v1alpha1.EventListener{}).PkgPath()
+	kinds := 0
+	for kind, typ := range scheme.Scheme.KnownTypes(v1alpha1.SchemeGroupVersion) {
+		if typ.PkgPath() != pkgPath || strings.HasSuffix(kind, "List") {
+			continue
+		}
+		kinds++
+		gvr, _ := meta.UnsafeGuessKindToResource(v1alpha1.SchemeGroupVersion.WithKind(kind))
+		if _, err := f.ForResource(gvr); err != nil {
+			t.Errorf("no informer for %s: %v", kind, err)
+		}
+	}
+	if kinds == 0 {
+		t.Error("no Triggers kinds are registered in the scheme")
+	}
+}